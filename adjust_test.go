@@ -1011,6 +1011,9 @@ func TestAdjustConditionalFormats(t *testing.T) {
 	opts, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Len(t, format, 1)
+	format[0].Priority = 2
+	format[0].FormatStyle, err = f.GetConditionalStyle(formatID)
+	assert.NoError(t, err)
 	assert.Equal(t, format, opts["C1:D1"])
 
 	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
@@ -1068,8 +1071,10 @@ func TestAdjustConditionalFormats(t *testing.T) {
 		opts, err := f.GetConditionalFormats("Sheet1")
 		assert.NoError(t, err)
 		assert.Len(t, opts, 2)
-		assert.Equal(t, format, opts["D1:D2"])
-		assert.Equal(t, format, opts["D4:D4"])
+		for ref, priority := range map[string]int{"D1:D2": 1, "D4:D4": 2} {
+			format[0].Priority = priority
+			assert.Equal(t, format, opts[ref])
+		}
 	})
 }
 
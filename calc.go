@@ -16377,14 +16377,10 @@ func (fn *formulaFuncs) DOLLAR(argsList *list.List) formulaArg {
 			decimals, dot = 0, ""
 		}
 	}
-	symbol := map[CultureName]string{
-		CultureNameUnknown: "$",
-		CultureNameEnUS:    "$",
-		CultureNameJaJP:    "¥",
-		CultureNameKoKR:    "\u20a9",
-		CultureNameZhCN:    "¥",
-		CultureNameZhTW:    "NT$",
-	}[fn.f.options.CultureInfo]
+	symbol := "$"
+	if info, ok := supportedCultureInfo[fn.f.options.CultureInfo]; ok {
+		symbol = info.currency
+	}
 	numFmtCode := fmt.Sprintf("%s#,##0%s%s;(%s#,##0%s%s)",
 		symbol, dot, strings.Repeat("0", decimals), symbol, dot, strings.Repeat("0", decimals))
 	return newStringFormulaArg(format(value, numFmtCode, false, CellTypeNumber, nil))
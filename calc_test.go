@@ -5228,6 +5228,21 @@ func TestCalcDBCS(t *testing.T) {
 	assert.Equal(t, "\uff40\uff5e\u00b7\uff01\uff20\uff03\uff04\u00a5\uff05\u2026\uff3e\uff06\uff0a\uff08\uff09\uff3f\uff0d\uff0b\uff1d\uff3b\uff3d\uff5b\uff5d\uff3c\uff5c\uff1b\uff1a\uff07\uff02\uff1c\uff0c\uff1e\uff0e\uff1f\uff0f\uff10\uff11\uff12\uff13\uff14\uff15\uff16\uff17\uff18\uff19\uff10\u3000\uff41\uff42\uff43\u3000\uff21\uff22\uff23\u3000\uff65\uff9e\uff9f\u3000\uff74\uff78\uff7e\uff99", result)
 }
 
+func TestCalcDOLLAR(t *testing.T) {
+	for lang, expected := range map[CultureName]string{
+		CultureNameUnknown: "$1,234.56",
+		CultureNameJaJP:    "¥1,234.56",
+		CultureNameZhTW:    "NT$1,234.56",
+	} {
+		f := NewFile(Options{CultureInfo: lang})
+		assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=DOLLAR(1234.56)"))
+		result, err := f.CalcCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.NoError(t, f.Close())
+	}
+}
+
 func TestCalcFORMULATEXT(t *testing.T) {
 	f, formulaText := NewFile(), "=SUM(B1:C1)"
 	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", formulaText))
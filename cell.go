@@ -68,7 +68,7 @@ var cellTypes = map[string]CellType{
 // will be returned, otherwise the original value will be returned. All cells'
 // values will be the same in a merged range.
 func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error) {
-	return f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+	return f.getCellStringFunc(sheet, cell, func(_ *xlsxWorksheet, c *xlsxC) (string, bool, error) {
 		sst, err := f.sharedStringsReader()
 		if err != nil {
 			return "", true, err
@@ -78,6 +78,43 @@ func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error)
 	})
 }
 
+// GetCellFormattedValueAndColor provides a function to get formatted value
+// from cell by given worksheet name and cell reference in spreadsheet, and the
+// color name of the number format section which applied for the cell value,
+// such as "Red" or "Color10". The color name will be empty if the number
+// format section doesn't specify a color. For example, get formatted value and
+// color of the cell A1 on Sheet1:
+//
+//	val, color, err := f.GetCellFormattedValueAndColor("Sheet1", "A1")
+func (f *File) GetCellFormattedValueAndColor(sheet, cell string) (string, string, error) {
+	var color string
+	val, err := f.getCellStringFunc(sheet, cell, func(_ *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		sst, err := f.sharedStringsReader()
+		if err != nil {
+			return "", true, err
+		}
+		val, err := c.getValueFrom(f, sst, false)
+		if err != nil {
+			return val, true, err
+		}
+		raw, cellType := c.V, CellTypeNumber
+		switch c.T {
+		case "s", "inlineStr":
+			if raw, err = c.getValueFrom(f, sst, true); err != nil {
+				return val, true, err
+			}
+			cellType = cellTypes[c.T]
+		case "d":
+			cellType = CellTypeDate
+		case "b", "e", "str":
+			return val, true, err
+		}
+		_, color, err = f.formattedValueAndColor(&xlsxC{S: c.S, V: raw}, false, cellType)
+		return val, true, err
+	})
+	return val, color, err
+}
+
 // GetCellType provides a function to get the cell's data type by given
 // worksheet name and cell reference in spreadsheet file.
 func (f *File) GetCellType(sheet, cell string) (CellType, error) {
@@ -344,7 +381,9 @@ func setCellUint(value uint64) (t string, v string) {
 }
 
 // SetCellBool provides a function to set bool type value of a cell by given
-// worksheet name, cell reference and cell value.
+// worksheet name, cell reference and cell value. The value will be written as
+// the native boolean type cell by default, or as the text TRUE or FALSE if the
+// BoolAsText option of the workbook was enabled.
 func (f *File) SetCellBool(sheet, cell string, value bool) error {
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -360,7 +399,13 @@ func (f *File) SetCellBool(sheet, cell string, value bool) error {
 		return err
 	}
 	c.S = ws.prepareCellStyle(col, row, c.S)
-	c.T, c.V = setCellBool(value)
+	if f.options.BoolAsText {
+		if c.T, c.V, err = f.setCellString(strings.ToUpper(strconv.FormatBool(value))); err != nil {
+			return err
+		}
+	} else {
+		c.T, c.V = setCellBool(value)
+	}
 	c.IS = nil
 	return f.removeFormula(c, ws, sheet)
 }
@@ -419,7 +464,9 @@ func (c *xlsxC) setCellFloat(value float64, precision, bitSize int) {
 }
 
 // SetCellStr provides a function to set string type value of a cell. Total
-// number of characters that a cell can contain 32767 characters.
+// number of characters that a cell can contain 32767 characters. The leading
+// apostrophe of the value will be treated as the quote prefix if the
+// ApostropheAsQuotePrefix option of the workbook was enabled.
 func (f *File) SetCellStr(sheet, cell, value string) error {
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -435,6 +482,12 @@ func (f *File) SetCellStr(sheet, cell, value string) error {
 		return err
 	}
 	c.S = ws.prepareCellStyle(col, row, c.S)
+	if f.options.ApostropheAsQuotePrefix && strings.HasPrefix(value, "'") {
+		if c.S, err = f.getQuotePrefixStyle(c.S); err != nil {
+			return err
+		}
+		value = value[1:]
+	}
 	if c.T, c.V, err = f.setCellString(value); err != nil {
 		return err
 	}
@@ -1101,7 +1154,9 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 }
 
 // GetCellRichText provides a function to get rich text of cell by given
-// worksheet.
+// worksheet. The text runs without run properties inherit the font of the
+// cell style, the Font of these runs will be the effective font of the cell
+// and the IsInherited will be true.
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -1113,6 +1168,7 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	}
 	if c.T == "inlineStr" && c.IS != nil {
 		runs = getCellRichText(c.IS)
+		err = f.setRichTextInheritedFont(runs, c.S)
 		return
 	}
 	if c.T != "s" || c.V == "" {
@@ -1130,9 +1186,36 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 		return
 	}
 	runs = getCellRichText(&sst.SI[siIdx])
+	err = f.setRichTextInheritedFont(runs, c.S)
 	return
 }
 
+// setRichTextInheritedFont provides a function to set the font of the cell
+// style by given style index for the rich text runs without run properties.
+func (f *File) setRichTextInheritedFont(runs []RichTextRun, styleID int) error {
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	style := &Style{}
+	if s.CellXfs != nil && styleID >= 0 && styleID < len(s.CellXfs.Xf) {
+		if xf := s.CellXfs.Xf[styleID]; extractStyleCondFuncs["font"](xf, s) {
+			f.extractFont(s.Fonts.Font[*xf.FontID], s, style)
+		}
+	}
+	for i := range runs {
+		if runs[i].Font != nil {
+			continue
+		}
+		runs[i].IsInherited = true
+		if style.Font != nil {
+			font := *style.Font
+			runs[i].Font = &font
+		}
+	}
+	return err
+}
+
 // newRpr create run properties for the rich text by given font format.
 func newRpr(fnt *Font) *xlsxRPr {
 	rpr := xlsxRPr{}
@@ -1149,15 +1232,27 @@ func newRpr(fnt *Font) *xlsxRPr {
 	if fnt.Underline != "" {
 		rpr.U = &attrValString{Val: &fnt.Underline}
 	}
+	if fnt.Condense {
+		rpr.Condense = &trueVal
+	}
+	if fnt.Extend {
+		rpr.Extend = &trueVal
+	}
 	if fnt.Family != "" {
 		rpr.RFont = &attrValString{Val: &fnt.Family}
 	}
-	if inStrSlice([]string{"baseline", "superscript", "subscript"}, fnt.VertAlign, true) != -1 {
-		rpr.VertAlign = &attrValString{Val: &fnt.VertAlign}
+	if fnt.Charset != nil {
+		rpr.Charset = &attrValInt{Val: intPtr(*fnt.Charset)}
+	}
+	if idx := inStrSlice(supportedFontVertAlign, fnt.VertAlign, false); idx != -1 {
+		rpr.VertAlign = &attrValString{Val: stringPtr(supportedFontVertAlign[idx])}
 	}
 	if fnt.Size > 0 {
 		rpr.Sz = &attrValFloat{Val: &fnt.Size}
 	}
+	if idx := inStrSlice(supportedFontSchemes, fnt.Scheme, false); idx != -1 {
+		rpr.Scheme = &attrValString{Val: stringPtr(supportedFontSchemes[idx])}
+	}
 	rpr.Color = newFontColor(fnt)
 	return &rpr
 }
@@ -1180,6 +1275,17 @@ func newFont(rPr *xlsxRPr) *Font {
 		font.Size = *rPr.Sz.Val
 	}
 	font.Strike = rPr.Strike != nil
+	font.Condense = rPr.Condense != nil
+	font.Extend = rPr.Extend != nil
+	if rPr.VertAlign != nil && rPr.VertAlign.Val != nil {
+		font.VertAlign = *rPr.VertAlign.Val
+	}
+	if rPr.Charset != nil && rPr.Charset.Val != nil {
+		font.Charset = intPtr(*rPr.Charset.Val)
+	}
+	if rPr.Scheme != nil && rPr.Scheme.Val != nil {
+		font.Scheme = *rPr.Scheme.Val
+	}
 	if rPr.Color != nil {
 		font.Color = strings.TrimPrefix(rPr.Color.RGB, "FF")
 		if rPr.Color.Theme != nil {
@@ -1205,7 +1311,10 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 		run := xlsxR{T: &xlsxT{}}
 		run.T.Val, run.T.Space = trimCellValue(textRun.Text, false)
 		fnt := textRun.Font
-		if fnt != nil {
+		if fnt != nil && !textRun.IsInherited {
+			if err := fnt.validate(); err != nil {
+				return textRuns, err
+			}
 			run.RPr = newRpr(fnt)
 		}
 		textRuns = append(textRuns, run)
@@ -1214,8 +1323,10 @@ func setRichText(runs []RichTextRun) ([]xlsxR, error) {
 }
 
 // SetCellRichText provides a function to set cell with rich text by given
-// worksheet. For example, set rich text on the A1 cell of the worksheet named
-// Sheet1:
+// worksheet. The text runs without Font or with IsInherited will be written
+// without run properties, so they inherit the font of the cell style in the
+// same way as the rich text created by Excel. For example, set rich text on
+// the A1 cell of the worksheet named Sheet1:
 //
 //	package main
 //
@@ -1494,18 +1605,25 @@ func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c
 // it is possible to apply a format to the cell value, it will do so, if not
 // then an error will be returned, along with the raw value of the cell.
 func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, error) {
+	val, _, err := f.formattedValueAndColor(c, raw, cellType)
+	return val, err
+}
+
+// formattedValueAndColor provides a function to returns a value after
+// formatted and the color name of the applied number format section.
+func (f *File) formattedValueAndColor(c *xlsxC, raw bool, cellType CellType) (string, string, error) {
 	if raw || c.S == 0 {
-		return c.V, nil
+		return c.V, "", nil
 	}
 	styleSheet, err := f.stylesReader()
 	if err != nil {
-		return c.V, err
+		return c.V, "", err
 	}
 	if styleSheet.CellXfs == nil {
-		return c.V, err
+		return c.V, "", err
 	}
 	if c.S >= len(styleSheet.CellXfs.Xf) || c.S < 0 {
-		return c.V, err
+		return c.V, "", err
 	}
 	var numFmtID int
 	if styleSheet.CellXfs.Xf[c.S].NumFmtID != nil {
@@ -1514,18 +1632,20 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	date1904 := false
 	wb, err := f.workbookReader()
 	if err != nil {
-		return c.V, err
+		return c.V, "", err
 	}
 	if wb != nil && wb.WorkbookPr != nil {
 		date1904 = wb.WorkbookPr.Date1904
 	}
 	if fmtCode, ok := styleSheet.getCustomNumFmtCode(numFmtID); ok {
-		return format(c.V, fmtCode, date1904, cellType, f.options), err
+		val, color := formatWithColor(c.V, fmtCode, date1904, cellType, f.options)
+		return val, color, err
 	}
 	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
-		return f.applyBuiltInNumFmt(c, fmtCode, numFmtID, date1904, cellType), err
+		val, color := f.applyBuiltInNumFmt(c, fmtCode, numFmtID, date1904, cellType)
+		return val, color, err
 	}
-	return c.V, err
+	return c.V, "", err
 }
 
 // getCustomNumFmtCode provides a function to returns custom number format code.
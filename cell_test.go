@@ -353,6 +353,70 @@ func TestSetCellBool(t *testing.T) {
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellBool("Sheet1", "A", true))
 	// Test set cell boolean data type value with invalid sheet name
 	assert.Equal(t, ErrSheetNameInvalid, f.SetCellBool("Sheet:1", "A1", true))
+	// Test set cell boolean value as native boolean type and text
+	assert.NoError(t, f.SetCellBool("Sheet1", "A1", true))
+	f = NewFile(Options{BoolAsText: true})
+	assert.NoError(t, f.SetCellBool("Sheet1", "A1", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", false))
+	for cell, expected := range map[string]string{"A1": "TRUE", "A2": "FALSE"} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeSharedString, cellType)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+}
+
+func TestSetCellStrQuotePrefix(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellStr("Sheet1", "A1", "'123"))
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "'123", val)
+
+	f = NewFile(Options{ApostropheAsQuotePrefix: true})
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "'123"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", []byte("'0012")))
+	assert.NoError(t, f.SetCellStr("Sheet1", "B1", "'=SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellStr("Sheet1", "B2", "text"))
+	for cell, expected := range map[string]string{"A1": "123", "A2": "0012", "B1": "=SUM(A1:A2)", "B2": "text"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	for cell, expected := range map[string]bool{"A1": true, "A2": true, "B1": true, "B2": false} {
+		ID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s.CellXfs.Xf[ID].QuotePrefix != nil && *s.CellXfs.Xf[ID].QuotePrefix, cell)
+	}
+	// Test the derived quote prefix styles are cached and keep the cell styles
+	ID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	ID2, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, ID, ID2)
+	ID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(ID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	ID2, err = f.getQuotePrefixStyle(ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ID, ID2)
+	// Test get quote prefix style with invalid style ID
+	_, err = f.getQuotePrefixStyle(100)
+	assert.Equal(t, newInvalidStyleID(100), err)
+	// Test set cell value with quote prefix with unsupported charset style sheet
+	f = NewFile(Options{ApostropheAsQuotePrefix: true})
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellStr("Sheet1", "A1", "'123"), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestSetCellTime(t *testing.T) {
@@ -540,6 +604,52 @@ func TestGetCellValue(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestGetCellFormattedValueAndColor(t *testing.T) {
+	f := NewFile()
+	numFmt := "[Green]#,##0;[Red](#,##0);\"-\";[Blue]\"note: \"@"
+	style, err := f.NewStyle(&Style{CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A6", style))
+	for cell, value := range map[string]interface{}{"A1": 1234567, "A2": -1234.5, "A3": 0, "A4": "abc", "A5": true} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStr("Sheet1", "A6", "inline"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[5].C[0].setInlineStr("inline")
+	for _, expected := range [][]string{
+		{"A1", "1,234,567", "Green"},
+		{"A2", "(1,235)", "Red"},
+		{"A3", "-", ""},
+		{"A4", "note: abc", "Blue"},
+		{"A5", "TRUE", ""},
+		{"A6", "note: inline", "Blue"},
+		{"A7", "", ""},
+	} {
+		val, color, err := f.GetCellFormattedValueAndColor("Sheet1", expected[0])
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], val, expected[0])
+		assert.Equal(t, expected[2], color, expected[0])
+	}
+	// Test get formatted value and color with built-in number format
+	style, err = f.NewStyle(&Style{NumFmt: 38})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", -5))
+	val, color, err := f.GetCellFormattedValueAndColor("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "(5)", val)
+	assert.Equal(t, "Red", color)
+	// Test get formatted value and color with invalid sheet name
+	_, _, err = f.GetCellFormattedValueAndColor("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get formatted value and color with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellFormattedValueAndColor("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetCellType(t *testing.T) {
 	f := NewFile()
 	cellType, err := f.GetCellType("Sheet1", "A1")
@@ -773,9 +883,12 @@ func TestGetCellRichText(t *testing.T) {
 	runs, err = f.GetCellRichText("Sheet1", "A1")
 	assert.NoError(t, err)
 
+	defaultFont := &Font{Family: "Calibri", Size: 11, ColorTheme: &theme}
 	assert.Equal(t, runsSource[0].Text, runs[0].Text)
-	assert.Nil(t, runs[0].Font)
+	assert.Equal(t, defaultFont, runs[0].Font)
+	assert.True(t, runs[0].IsInherited)
 	assert.NotNil(t, runs[1].Font)
+	assert.False(t, runs[1].IsInherited)
 
 	runsSource[1].Font.Color = strings.ToUpper(runsSource[1].Font.Color)
 	assert.True(t, reflect.DeepEqual(runsSource[1].Font, runs[1].Font), "should get the same font")
@@ -792,7 +905,7 @@ func TestGetCellRichText(t *testing.T) {
 	}
 	runs, err = f.GetCellRichText("Sheet1", "A1")
 	assert.NoError(t, err)
-	assert.Equal(t, []RichTextRun{{Text: "A"}, {Text: "1"}}, runs)
+	assert.Equal(t, []RichTextRun{{Text: "A", Font: defaultFont, IsInherited: true}, {Text: "1", Font: defaultFont, IsInherited: true}}, runs)
 
 	// Test get cell rich text when string item index overflow
 	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
@@ -845,6 +958,46 @@ func TestGetCellRichText(t *testing.T) {
 	// Test get cell rich text with invalid sheet name
 	_, err = f.GetCellRichText("Sheet:1", "A1")
 	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get cell rich text with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0] = xlsxC{T: "inlineStr", IS: &xlsxSI{T: &xlsxT{Val: "A"}}}
+	_, err = f.GetCellRichText("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestCellRichTextInheritedFont(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Family: "Arial", Size: 14, Color: "FF0000"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A1", []RichTextRun{
+		{Text: "inherited "},
+		{Text: "bold", Font: &Font{Bold: true, Family: "Times New Roman", Size: 9}},
+	}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	runs, err := f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []RichTextRun{
+		{Text: "inherited ", Font: &Font{Family: "Arial", Size: 14, Color: "FF0000"}, IsInherited: true},
+		{Text: "bold", Font: &Font{Bold: true, Underline: "none", Family: "Times New Roman", Size: 9}},
+	}, runs)
+	// Test the inherited runs are written without run properties
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A2", runs))
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Nil(t, sst.SI[len(sst.SI)-1].R[0].RPr)
+	// Test the stream writer writes the inherited runs without run properties
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{runs}, RowOpts{StyleID: style}))
+	assert.NoError(t, sw.Flush())
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `<c r="A1" s="1" t="inlineStr"><is><r><t xml:space="preserve">inherited </t></r><r><rPr><rFont val="Times New Roman"></rFont>`)
+	streamRuns, err := f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, runs, streamRuns)
+	assert.NoError(t, f.Close())
 }
 
 func TestSetCellRichText(t *testing.T) {
@@ -1156,3 +1309,37 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestCellRichTextFontExtensions(t *testing.T) {
+	f := NewFile()
+	charset := 134
+	runs := []RichTextRun{
+		{Text: "H", Font: &Font{Bold: true}},
+		{Text: "2", Font: &Font{Bold: true, VertAlign: "subscript", Scheme: "minor"}},
+		{Text: "O", Font: &Font{Bold: true, Condense: true, Extend: true}},
+		{Text: " m", Font: &Font{Family: "SimSun", Charset: &charset}},
+		{Text: "2", Font: &Font{VertAlign: "SUPERSCRIPT", Scheme: "major"}},
+	}
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A1", runs))
+	result, err := f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, result, len(runs))
+	for i, expected := range []Font{
+		{VertAlign: ""},
+		{VertAlign: "subscript", Scheme: "minor"},
+		{Condense: true, Extend: true},
+		{Charset: &charset},
+		{VertAlign: "superscript", Scheme: "major"},
+	} {
+		assert.Equal(t, runs[i].Text, result[i].Text)
+		assert.Equal(t, expected.VertAlign, result[i].Font.VertAlign)
+		assert.Equal(t, expected.Scheme, result[i].Font.Scheme)
+		assert.Equal(t, expected.Charset, result[i].Font.Charset)
+		assert.Equal(t, expected.Condense, result[i].Font.Condense)
+		assert.Equal(t, expected.Extend, result[i].Font.Extend)
+	}
+	// Test set cell rich text with invalid font vertical alignment and scheme
+	assert.Equal(t, ErrFontVertAlign, f.SetCellRichText("Sheet1", "A2", []RichTextRun{{Text: "x", Font: &Font{VertAlign: "top"}}}))
+	assert.Equal(t, ErrFontScheme, f.SetCellRichText("Sheet1", "A2", []RichTextRun{{Text: "x", Font: &Font{Scheme: "body"}}}))
+	assert.NoError(t, f.Close())
+}
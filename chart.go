@@ -12,10 +12,15 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // ChartType is the type of supported chart types.
@@ -78,6 +83,11 @@ const (
 	WireframeContour
 	Bubble
 	Bubble3D
+	BoxWhisker
+	Waterfall
+	Funnel
+	Histogram
+	Pareto
 )
 
 // ChartLineType is the type of supported chart line types.
@@ -91,6 +101,61 @@ const (
 	ChartLineAutomatic
 )
 
+// ChartTrendlineType is the type of supported chart series trendline types.
+type ChartTrendlineType byte
+
+// This section defines the currently supported chart series trendline types
+// enumeration.
+const (
+	ChartTrendlineNone ChartTrendlineType = iota
+	ChartTrendlineLinear
+	ChartTrendlineExponential
+	ChartTrendlineLogarithmic
+	ChartTrendlinePolynomial
+	ChartTrendlinePower
+	ChartTrendlineMovingAverage
+)
+
+// ChartSplitType is the type of supported split types of the pie of pie and
+// bar of pie chart.
+type ChartSplitType byte
+
+// This section defines the currently supported split types of the pie of pie
+// and bar of pie chart enumeration.
+const (
+	ChartSplitAuto ChartSplitType = iota
+	ChartSplitPosition
+	ChartSplitValue
+	ChartSplitPercent
+	ChartSplitCustom
+)
+
+// ChartErrorBarsType is the type of supported chart series error bars types.
+type ChartErrorBarsType byte
+
+// This section defines the currently supported chart series error bars types
+// enumeration.
+const (
+	ChartErrorBarsNone ChartErrorBarsType = iota
+	ChartErrorBarsFixedValue
+	ChartErrorBarsPercentage
+	ChartErrorBarsStandardDeviation
+	ChartErrorBarsStandardError
+	ChartErrorBarsCustom
+)
+
+// ChartErrorBarsDirection is the type of supported chart series error bars
+// directions.
+type ChartErrorBarsDirection byte
+
+// This section defines the currently supported chart series error bars
+// directions enumeration.
+const (
+	ChartErrorBarsDirectionY ChartErrorBarsDirection = iota
+	ChartErrorBarsDirectionX
+	ChartErrorBarsDirectionBoth
+)
+
 // ChartTickLabelPositionType is the type of supported chart tick label position
 // types.
 type ChartTickLabelPositionType byte
@@ -104,6 +169,50 @@ const (
 	ChartTickLabelNone
 )
 
+// chartTrendlineTypes defined the chart series trendline types XML value.
+var chartTrendlineTypes = map[ChartTrendlineType]string{
+	ChartTrendlineLinear:        "linear",
+	ChartTrendlineExponential:   "exp",
+	ChartTrendlineLogarithmic:   "log",
+	ChartTrendlinePolynomial:    "poly",
+	ChartTrendlinePower:         "power",
+	ChartTrendlineMovingAverage: "movingAvg",
+}
+
+// chartErrorBarsTypes defined the chart series error bars types XML value.
+var chartErrorBarsTypes = map[ChartErrorBarsType]string{
+	ChartErrorBarsFixedValue:        "fixedVal",
+	ChartErrorBarsPercentage:        "percentage",
+	ChartErrorBarsStandardDeviation: "stdDev",
+	ChartErrorBarsStandardError:     "stdErr",
+	ChartErrorBarsCustom:            "cust",
+}
+
+// chartErrorBarsDefaultValues defined the default value of the chart series
+// error bars by the error bars types.
+var chartErrorBarsDefaultValues = map[ChartErrorBarsType]float64{
+	ChartErrorBarsFixedValue:        1,
+	ChartErrorBarsPercentage:        5,
+	ChartErrorBarsStandardDeviation: 1,
+}
+
+// chartErrorBarsChartTypes defined the chart types which support the error
+// bars, and whether the chart type support the error bars on the X axis.
+var chartErrorBarsChartTypes = map[ChartType]bool{
+	Area:               false,
+	AreaStacked:        false,
+	AreaPercentStacked: false,
+	Bar:                false,
+	BarStacked:         false,
+	BarPercentStacked:  false,
+	Col:                false,
+	ColStacked:         false,
+	ColPercentStacked:  false,
+	Line:               false,
+	Scatter:            true,
+	Bubble:             true,
+}
+
 // This section defines the default value of chart properties.
 var (
 	chartView3DRotX = map[ChartType]int{
@@ -216,6 +325,14 @@ var (
 		Contour:                     0,
 		WireframeContour:            0,
 	}
+	plotAreaChartSerAx = map[ChartType]bool{
+		Area3D:        true,
+		Col3D:         true,
+		Col3DCone:     true,
+		Col3DPyramid:  true,
+		Col3DCylinder: true,
+		Line3D:        true,
+	}
 	plotAreaChartOverlap = map[ChartType]int{
 		BarStacked:        100,
 		BarPercentStacked: 100,
@@ -283,6 +400,43 @@ var (
 		Bubble:                      0,
 		Bubble3D:                    0,
 	}
+	chartExLayoutIDs = map[ChartType]string{
+		BoxWhisker: "boxWhisker",
+		Waterfall:  "waterfall",
+		Funnel:     "funnel",
+		Histogram:  "clusteredColumn",
+		Pareto:     "clusteredColumn",
+	}
+	chartGroupTypes = map[string][]ChartType{
+		"areaChart":   {Area, AreaStacked, AreaPercentStacked},
+		"area3DChart": {Area3D, Area3DStacked, Area3DPercentStacked},
+		"barChart":    {Bar, BarStacked, BarPercentStacked, Col, ColStacked, ColPercentStacked},
+		"bar3DChart": {
+			Bar3DClustered, Bar3DStacked, Bar3DPercentStacked,
+			Bar3DConeClustered, Bar3DConeStacked, Bar3DConePercentStacked,
+			Bar3DPyramidClustered, Bar3DPyramidStacked, Bar3DPyramidPercentStacked,
+			Bar3DCylinderClustered, Bar3DCylinderStacked, Bar3DCylinderPercentStacked,
+			Col3D, Col3DClustered, Col3DStacked, Col3DPercentStacked,
+			Col3DCone, Col3DConeClustered, Col3DConeStacked, Col3DConePercentStacked,
+			Col3DPyramid, Col3DPyramidClustered, Col3DPyramidStacked, Col3DPyramidPercentStacked,
+			Col3DCylinder, Col3DCylinderClustered, Col3DCylinderStacked, Col3DCylinderPercentStacked,
+		},
+		"bubbleChart":    {Bubble, Bubble3D},
+		"doughnutChart":  {Doughnut},
+		"lineChart":      {Line},
+		"line3DChart":    {Line3D},
+		"pieChart":       {Pie},
+		"pie3DChart":     {Pie3D},
+		"ofPieChart":     {PieOfPie, BarOfPie},
+		"radarChart":     {Radar},
+		"scatterChart":   {Scatter},
+		"surface3DChart": {Surface3D, WireframeSurface3D},
+		"surfaceChart":   {Contour, WireframeContour},
+	}
+	chartExDataLabelPos = map[ChartType]string{
+		BoxWhisker: "outEnd",
+		Waterfall:  "outEnd",
+	}
 	chartLegendPosition = map[string]string{
 		"bottom":    "b",
 		"left":      "l",
@@ -516,6 +670,44 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts == nil {
 		return nil, ErrParameterInvalid
 	}
+	for _, fnt := range []*Font{&opts.XAxis.Font, &opts.YAxis.Font, &opts.YAxis2.Font, &opts.ZAxis.Font} {
+		if err := fnt.validate(); err != nil {
+			return opts, err
+		}
+	}
+	for _, title := range opts.Title {
+		if title.Font == nil {
+			continue
+		}
+		if err := title.Font.validate(); err != nil {
+			return opts, err
+		}
+	}
+	for _, format := range []*ChartTitle{&opts.TitleFormat, &opts.XAxis.TitleFormat, &opts.YAxis.TitleFormat, &opts.YAxis2.TitleFormat, &opts.ZAxis.TitleFormat} {
+		if err := format.parse(); err != nil {
+			return opts, err
+		}
+	}
+	if err := opts.parseXAxisType(); err != nil {
+		return opts, err
+	}
+	if opts.GapWidth != nil && *opts.GapWidth > 500 {
+		return opts, ErrChartGapWidth
+	}
+	if opts.Overlap != nil && (*opts.Overlap < -100 || *opts.Overlap > 100) {
+		return opts, ErrChartOverlap
+	}
+	if err := opts.PlotArea.parseSplit(); err != nil {
+		return opts, err
+	}
+	for i := range opts.Series {
+		if err := opts.Series[i].Trendline.parse(); err != nil {
+			return opts, err
+		}
+		if err := opts.Series[i].parseDataPoints(); err != nil {
+			return opts, err
+		}
+	}
 	if opts.Dimension.Width == 0 {
 		opts.Dimension.Width = defaultChartDimensionWidth
 	}
@@ -537,6 +729,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.Legend.Position == "" {
 		opts.Legend.Position = defaultChartLegendPosition
 	}
+	if err := opts.Legend.parse(); err != nil {
+		return opts, err
+	}
 	opts.parseTitle()
 	if opts.VaryColors == nil {
 		opts.VaryColors = boolPtr(true)
@@ -550,19 +745,575 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	return opts, nil
 }
 
-// parseTitle parse the title settings of the chart with default value.
+// parseDataPoints provides a function to validate the data points of the chart
+// series, the index of the data points should be unique, and the explosion of
+// the data points should be in the range 0-400.
+func (ser *ChartSeries) parseDataPoints() error {
+	indexes := make(map[int]bool, len(ser.DataPoints))
+	for _, dp := range ser.DataPoints {
+		if dp.Index < 0 || indexes[dp.Index] || dp.Explosion < 0 || dp.Explosion > 400 {
+			return ErrParameterInvalid
+		}
+		indexes[dp.Index] = true
+	}
+	return nil
+}
+
+// parse provides a function to validate the trendline of the chart series and
+// set the default order of the polynomial trendline and the default period of
+// the moving average trendline.
+func (t *ChartTrendline) parse() error {
+	if t.Type == ChartTrendlineNone {
+		return nil
+	}
+	if _, ok := chartTrendlineTypes[t.Type]; !ok || t.Forward < 0 || t.Backward < 0 {
+		return ErrParameterInvalid
+	}
+	if t.Type == ChartTrendlinePolynomial {
+		if t.Order == 0 {
+			t.Order = 2
+		}
+		if t.Order < 2 || t.Order > 6 {
+			return ErrParameterInvalid
+		}
+	}
+	if t.Type == ChartTrendlineMovingAverage {
+		if t.Period == 0 {
+			t.Period = 2
+		}
+		if t.Period < 2 || t.Period > 255 {
+			return ErrParameterInvalid
+		}
+		if t.Forward > 0 || t.Backward > 0 || t.Intercept != nil || t.DisplayEquation || t.DisplayRSquared {
+			return ErrParameterInvalid
+		}
+	}
+	if t.Intercept != nil && t.Type != ChartTrendlineLinear &&
+		t.Type != ChartTrendlineExponential && t.Type != ChartTrendlinePolynomial {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// parseErrorBars provides a function to validate the error bars of the chart
+// series, set the default value of the error bars and get the cached values of
+// the custom error bars.
+func (f *File) parseErrorBars(opts *Chart) error {
+	for i := range opts.Series {
+		ser := &opts.Series[i]
+		errBars := &ser.ErrorBars
+		errBars.plusValues, errBars.minusValues = nil, nil
+		if errBars.Type == ChartErrorBarsNone {
+			continue
+		}
+		chartType := opts.Type
+		if ser.Type != Area {
+			chartType = ser.Type
+		}
+		supportX, ok := chartErrorBarsChartTypes[chartType]
+		if !ok || errBars.Direction > ChartErrorBarsDirectionBoth ||
+			(errBars.Direction != ChartErrorBarsDirectionY && !supportX) {
+			return ErrParameterInvalid
+		}
+		if _, ok = chartErrorBarsTypes[errBars.Type]; !ok || errBars.Value < 0 {
+			return ErrParameterInvalid
+		}
+		if errBars.Value == 0 {
+			errBars.Value = chartErrorBarsDefaultValues[errBars.Type]
+		}
+		if errBars.Type != ChartErrorBarsCustom {
+			continue
+		}
+		if errBars.Plus == "" && errBars.Minus == "" {
+			return ErrParameterInvalid
+		}
+		var err error
+		if errBars.Plus != "" {
+			if errBars.plusValues, err = f.getChartRangeNumbers(errBars.Plus); err != nil {
+				return err
+			}
+		}
+		if errBars.Minus != "" {
+			if errBars.minusValues, err = f.getChartRangeNumbers(errBars.Minus); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseSeriesTypes provides a function to group the series of the chart by
+// the chart type and the axis of each series. The series of the chart type on
+// the primary axis are kept in the chart, and each group of the other series
+// will be plotted as a combo chart in the same plot area.
+func (opts *Chart) parseSeriesTypes() (*Chart, []*Chart, error) {
+	type group struct {
+		chartType ChartType
+		secondary bool
+	}
+	var (
+		comboCharts []*Chart
+		groups      = make(map[group]*Chart)
+		chart       = *opts
+	)
+	chart.Series = nil
+	for _, ser := range opts.Series {
+		g := group{chartType: ser.Type, secondary: ser.SecondaryAxis}
+		if ser.Type == Area {
+			g.chartType = opts.Type
+		}
+		if g == (group{chartType: opts.Type}) {
+			chart.Series = append(chart.Series, ser)
+			continue
+		}
+		if _, ok := chartExLayoutIDs[opts.Type]; ok {
+			return opts, nil, ErrParameterInvalid
+		}
+		if _, ok := chartValAxNumFmtFormatCode[g.chartType]; !ok {
+			return opts, nil, newUnsupportedChartType(g.chartType)
+		}
+		comboChart, ok := groups[g]
+		if !ok {
+			comboChart = &Chart{}
+			*comboChart = *opts
+			comboChart.Type, comboChart.Series = g.chartType, nil
+			if g.secondary {
+				comboChart.YAxis = opts.YAxis2
+				comboChart.YAxis.Secondary = true
+			}
+			groups[g] = comboChart
+			comboCharts = append(comboCharts, comboChart)
+		}
+		comboChart.Series = append(comboChart.Series, ser)
+	}
+	if len(comboCharts) == 0 {
+		return opts, nil, nil
+	}
+	if len(chart.Series) == 0 {
+		chart.Type, chart.Series = comboCharts[0].Type, comboCharts[0].Series
+		comboCharts = comboCharts[1:]
+	}
+	return &chart, comboCharts, nil
+}
+
+// parse provides a function to check the quartile calculation method of the
+// box and whisker chart and set the default value.
+func (opts *ChartBoxWhisker) parse() error {
+	if opts.QuartileMethod == "" {
+		opts.QuartileMethod = "exclusive"
+	}
+	if opts.QuartileMethod != "inclusive" && opts.QuartileMethod != "exclusive" {
+		return ErrChartQuartileMethod
+	}
+	return nil
+}
+
+// parseFunnel provides a function to check the series of the funnel chart
+// and set the default gap width.
+func (opts *Chart) parseFunnel() error {
+	if len(opts.Series) != 1 {
+		return ErrChartFunnelSeries
+	}
+	if opts.Funnel.GapWidth == nil {
+		opts.Funnel.GapWidth = uintPtr(6)
+	}
+	if *opts.Funnel.GapWidth > 500 {
+		return ErrParameterInvalid
+	}
+	return nil
+}
+
+// parseHistogram provides a function to check the binning settings of the
+// histogram and Pareto chart.
+func (opts *Chart) parseHistogram() error {
+	h := opts.Histogram
+	if h.BinWidth < 0 || h.BinCount < 0 || (h.BinWidth > 0 && h.BinCount > 0) {
+		return ErrParameterInvalid
+	}
+	if h.Overflow != nil && h.Underflow != nil && *h.Overflow < *h.Underflow {
+		return ErrParameterInvalid
+	}
+	if h.ByCategory {
+		for _, ser := range opts.Series {
+			if ser.Categories == "" {
+				return ErrParameterInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// parseWaterfall provides a function to check the totals of the waterfall
+// chart, and get the values of the data points by the series values reference
+// if the increase or decrease fill has been set.
+func (f *File) parseWaterfall(opts *Chart) error {
+	for _, idx := range opts.Waterfall.Totals {
+		if idx < 0 {
+			return ErrParameterInvalid
+		}
+	}
+	opts.Waterfall.values = nil
+	if opts.Waterfall.IncreaseFill.Type == "" && opts.Waterfall.DecreaseFill.Type == "" {
+		return nil
+	}
+	for _, ser := range opts.Series {
+		values, err := f.getChartRangeNumbers(ser.Values)
+		if err != nil {
+			return err
+		}
+		opts.Waterfall.values = append(opts.Waterfall.values, values)
+	}
+	return nil
+}
+
+// getChartRangeNumbers provides a function to get the numeric values of the
+// cells by given formula reference of the chart series, such as
+// Sheet1!$B$2:$B$7, the non-numeric cell values will be treated as zero.
+func (f *File) getChartRangeNumbers(ref string) ([]float64, error) {
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		return nil, ErrParameterInvalid
+	}
+	sheet := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(ref[:idx], "'"), "'"), "''", "'")
+	cells := strings.Split(strings.ReplaceAll(ref[idx+1:], "$", ""), ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[1])
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	var values []float64
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			val, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return nil, err
+			}
+			num, _ := strconv.ParseFloat(val, 64)
+			values = append(values, num)
+		}
+	}
+	return values, nil
+}
+
+// parseXAxisType provides a function to validate the type and the time units
+// of the horizontal axis of the chart.
+func (opts *Chart) parseXAxisType() error {
+	_, isValAx := map[ChartType]bool{Scatter: true, Bubble: true, Bubble3D: true}[opts.Type]
+	switch opts.XAxis.Type {
+	case "":
+	case "value":
+		if !isValAx {
+			return ErrParameterInvalid
+		}
+	case "category", "date":
+		if isValAx {
+			return ErrParameterInvalid
+		}
+	default:
+		return ErrParameterInvalid
+	}
+	for _, unit := range []string{opts.XAxis.BaseTimeUnit, opts.XAxis.MajorTimeUnit, opts.XAxis.MinorTimeUnit} {
+		if unit != "" && inStrSlice([]string{"days", "months", "years"}, unit, true) == -1 {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
+}
+
+// parseChartSeriesRefs provides a function to resolve the categories and
+// values of the chart series which referenced by the defined names or the
+// structured references of the tables, such as Table1[Sales].
+func (f *File) parseChartSeriesRefs(opts *Chart) error {
+	_, isChartEx := chartExLayoutIDs[opts.Type]
+	for i := range opts.Series {
+		var err error
+		ser := &opts.Series[i]
+		if ser.categories, err = f.getChartSeriesRef(ser.Categories); err != nil {
+			return err
+		}
+		if ser.values, err = f.getChartSeriesRef(ser.Values); err != nil {
+			return err
+		}
+		if opts.XAxis.Type == "date" && ser.categories == nil && ser.Categories != "" {
+			if ser.categories, err = f.getChartSeriesCacheRef(ser.Categories); err != nil {
+				return err
+			}
+		}
+		if isChartEx && (ser.categories != nil || ser.values != nil) {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
+}
+
+// getChartSeriesRef provides a function to get the formula and the cached
+// values of the chart series data by given defined name or structured
+// reference. It returns nil if the reference is a cell range reference.
+func (f *File) getChartSeriesRef(ref string) (*chartSeriesRef, error) {
+	if tableName, column, ok := parseStructuredRef(ref); ok {
+		sheet, rangeRef, err := f.getTableColumnRange(tableName, column)
+		if err != nil {
+			return nil, err
+		}
+		name, err := f.getStructuredRefDefinedName(ref)
+		if err != nil {
+			return nil, err
+		}
+		cache, err := f.getChartRangeValues(sheet, rangeRef)
+		return &chartSeriesRef{formula: "[0]!" + name, cache: cache}, err
+	}
+	scope, name, formula := "Workbook", ref, "[0]!"
+	if idx := strings.LastIndex(ref, "!"); idx != -1 {
+		scope = strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(ref[:idx], "'"), "'"), "''", "'")
+		name, formula = ref[idx+1:], ref[:idx]+"!"
+	}
+	if name == "" || isCellRangeRef(name) || checkDefinedName(name) != nil {
+		return nil, nil
+	}
+	for _, definedName := range f.GetDefinedName() {
+		if !strings.EqualFold(definedName.Name, name) || definedName.Scope != scope {
+			continue
+		}
+		seriesRef := &chartSeriesRef{formula: formula + definedName.Name}
+		refersTo := strings.TrimPrefix(definedName.RefersTo, "=")
+		if tableName, column, ok := parseStructuredRef(refersTo); ok {
+			sheet, rangeRef, err := f.getTableColumnRange(tableName, column)
+			if err != nil {
+				return nil, err
+			}
+			seriesRef.cache, err = f.getChartRangeValues(sheet, rangeRef)
+			return seriesRef, err
+		}
+		if idx := strings.LastIndex(refersTo, "!"); idx != -1 && isCellRangeRef(refersTo[idx+1:]) {
+			sheet := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(refersTo[:idx], "'"), "'"), "''", "'")
+			var err error
+			seriesRef.cache, err = f.getChartRangeValues(sheet, refersTo[idx+1:])
+			return seriesRef, err
+		}
+		return seriesRef, nil
+	}
+	return nil, ErrDefinedNameScope
+}
+
+// parseStructuredRef provides a function to get the table name and the column
+// name by given structured reference, such as Table1[Sales].
+func parseStructuredRef(ref string) (string, string, bool) {
+	idx := strings.Index(ref, "[")
+	if idx < 1 || !strings.HasSuffix(ref, "]") || strings.ContainsAny(ref[:idx], "!'") {
+		return "", "", false
+	}
+	column := ref[idx+1 : len(ref)-1]
+	if column == "" || strings.ContainsAny(column, "[]") {
+		return "", "", false
+	}
+	return ref[:idx], column, true
+}
+
+// isCellRangeRef provides a function to check if the given reference is a
+// cell reference or a cell range reference, such as $B$2:$B$7.
+func isCellRangeRef(ref string) bool {
+	for _, cell := range strings.Split(strings.ReplaceAll(ref, "$", ""), ":") {
+		if _, _, err := CellNameToCoordinates(cell); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// getTableColumnRange provides a function to get the worksheet name and the
+// absolute cell range reference of the data rows of the table column by given
+// table name and column name.
+func (f *File) getTableColumnRange(tableName, column string) (string, string, error) {
+	tables, err := f.getTables()
+	if err != nil {
+		return "", "", err
+	}
+	for sheet, tbls := range tables {
+		for _, table := range tbls {
+			if !strings.EqualFold(table.Name, tableName) {
+				continue
+			}
+			content, ok := f.Pkg.Load(table.tableXML)
+			if !ok {
+				continue
+			}
+			var t xlsxTable
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(&t); err != nil && err != io.EOF {
+				return "", "", err
+			}
+			coordinates, err := rangeRefToCoordinates(t.Ref)
+			if err != nil {
+				return "", "", err
+			}
+			_ = sortCoordinates(coordinates)
+			headerRowCount := 1
+			if t.HeaderRowCount != nil {
+				headerRowCount = *t.HeaderRowCount
+			}
+			if t.TableColumns == nil {
+				return "", "", ErrParameterInvalid
+			}
+			for idx, tableColumn := range t.TableColumns.TableColumn {
+				if tableColumn == nil || !strings.EqualFold(tableColumn.Name, column) {
+					continue
+				}
+				firstCell, _ := CoordinatesToCellName(coordinates[0]+idx, coordinates[1]+headerRowCount, true)
+				lastCell, _ := CoordinatesToCellName(coordinates[0]+idx, coordinates[3]-t.TotalsRowCount, true)
+				return sheet, firstCell + ":" + lastCell, nil
+			}
+			return "", "", ErrParameterInvalid
+		}
+	}
+	return "", "", newNoExistTableError(tableName)
+}
+
+// getStructuredRefDefinedName provides a function to get the hidden workbook
+// scope defined name which refers to the given structured reference, the
+// defined name will be created if it doesn't exist, because the structured
+// reference can't be used in the formula of the chart series directly.
+func (f *File) getStructuredRefDefinedName(ref string) (string, error) {
+	names := make(map[string]bool)
+	for _, definedName := range f.GetDefinedName() {
+		if definedName.Scope == "Workbook" && strings.EqualFold(strings.TrimPrefix(definedName.RefersTo, "="), ref) {
+			return definedName.Name, nil
+		}
+		names[strings.ToLower(definedName.Name)] = true
+	}
+	tableName, column, _ := parseStructuredRef(ref)
+	base := "_" + tableName + "_" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return r
+		}
+		return '_'
+	}, column)
+	name := base
+	for i := 2; names[strings.ToLower(name)]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return name, err
+	}
+	if wb.DefinedNames == nil {
+		wb.DefinedNames = &xlsxDefinedNames{}
+	}
+	wb.DefinedNames.DefinedName = append(wb.DefinedNames.DefinedName, xlsxDefinedName{Name: name, Hidden: true, Data: ref})
+	return name, nil
+}
+
+// getChartRangeValues provides a function to get the raw values of the cells
+// by given worksheet name and cell range reference.
+func (f *File) getChartRangeValues(sheet, rangeRef string) ([]string, error) {
+	cells := strings.Split(strings.ReplaceAll(rangeRef, "$", ""), ":")
+	if len(cells) == 1 {
+		cells = append(cells, cells[0])
+	}
+	coordinates, err := cellRefsToCoordinates(cells[0], cells[1])
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	values := []string{}
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			val, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, val)
+		}
+	}
+	return values, nil
+}
+
+// parse provides a function to validate the format settings of the chart
+// legend, and sort the deleted legend entries without duplicates.
+func (legend *ChartLegend) parse() error {
+	if legend.Font != nil {
+		if err := legend.Font.validate(); err != nil {
+			return err
+		}
+	}
+	entries := append([]int(nil), legend.DeletedEntries...)
+	sort.Ints(entries)
+	legend.DeletedEntries = entries[:0]
+	for i, idx := range entries {
+		if idx < 0 {
+			return ErrParameterInvalid
+		}
+		if i == 0 || idx != entries[i-1] {
+			legend.DeletedEntries = append(legend.DeletedEntries, idx)
+		}
+	}
+	return nil
+}
+
+// parse provides a function to validate the format settings of the chart
+// title or axis title.
+func (format *ChartTitle) parse() error {
+	if format.Rotation != nil && (*format.Rotation < -90 || *format.Rotation > 90) {
+		return ErrParameterInvalid
+	}
+	if format.Font != nil {
+		return format.Font.validate()
+	}
+	return nil
+}
+
+// parseTitle parse the title settings of the chart with default value. The
+// runs without font settings inherit the font of the title format.
 func (opts *Chart) parseTitle() {
+	fonts := make([]**Font, 0, len(opts.Title)+1)
 	for i := range opts.Title {
-		if opts.Title[i].Font == nil {
-			opts.Title[i].Font = &Font{}
+		if opts.Title[i].Font == nil && opts.TitleFormat.Font != nil {
+			fnt := *opts.TitleFormat.Font
+			opts.Title[i].Font = &fnt
+		}
+		fonts = append(fonts, &opts.Title[i].Font)
+	}
+	if opts.TitleFormat.CellRef != "" {
+		fonts = append(fonts, &opts.TitleFormat.Font)
+	}
+	for _, fnt := range fonts {
+		if *fnt == nil {
+			*fnt = &Font{}
+		}
+		if (*fnt).Color == "" {
+			(*fnt).Color = "595959"
+		}
+		if (*fnt).Size == 0 {
+			(*fnt).Size = 14
+		}
+	}
+	for _, axis := range []*ChartAxis{&opts.XAxis, &opts.YAxis, &opts.YAxis2, &opts.ZAxis} {
+		for i := range axis.Title {
+			if axis.Title[i].Font == nil && axis.TitleFormat.Font != nil {
+				fnt := *axis.TitleFormat.Font
+				axis.Title[i].Font = &fnt
+			}
 		}
-		if opts.Title[i].Font.Color == "" {
-			opts.Title[i].Font.Color = "595959"
+	}
+}
+
+// parseChartTitleRefs provides a function to get the cached text of the chart
+// title and the axis titles which referenced by the cell reference.
+func (f *File) parseChartTitleRefs(opts *Chart) error {
+	for _, format := range []*ChartTitle{&opts.TitleFormat, &opts.XAxis.TitleFormat, &opts.YAxis.TitleFormat, &opts.YAxis2.TitleFormat, &opts.ZAxis.TitleFormat} {
+		if format.CellRef == "" {
+			continue
 		}
-		if opts.Title[i].Font.Size == 0 {
-			opts.Title[i].Font.Size = 14
+		var err error
+		if format.ref, err = f.getChartSeriesCacheRef(format.CellRef); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
 // AddChart provides the method to add chart in a sheet by given chart format
@@ -700,6 +1451,11 @@ func (opts *Chart) parseTitle() {
 //	 52 | WireframeContour            | wireframe contour chart
 //	 53 | Bubble                      | bubble chart
 //	 54 | Bubble3D                    | 3D bubble chart
+//	 55 | BoxWhisker                  | box and whisker chart
+//	 56 | Waterfall                   | waterfall chart
+//	 57 | Funnel                      | funnel chart
+//	 58 | Histogram                   | histogram chart
+//	 59 | Pareto                      | Pareto chart
 //
 // In Excel a chart series is a collection of information that defines which
 // data is plotted such as values, axis labels and formatting.
@@ -712,7 +1468,12 @@ func (opts *Chart) parseTitle() {
 //	Fill
 //	Line
 //	Marker
+//	DataPoints
 //	DataLabelPosition
+//	Type
+//	SecondaryAxis
+//	Trendline
+//	ErrorBars
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -727,6 +1488,15 @@ func (opts *Chart) parseTitle() {
 // mandatory option for every chart object. This option links the chart with
 // the worksheet data that it displays.
 //
+// The 'Categories' and 'Values' can also be set as the name of a defined name
+// or the structured reference of a table column, such as Sales, Sheet1!Sales
+// or Table1[Sales], so the chart will include the new rows when the defined
+// name or the table grows with data. The defined name or table must exist
+// before adding the chart. The structured reference will be written into a
+// hidden defined name of the workbook scope, because it can't be used in the
+// chart series formula directly. The box and whisker, waterfall, funnel,
+// histogram and Pareto chart don't support these references.
+//
 // Sizes: This sets the bubble size in a data series. The 'Sizes' property is
 // optional and the default value was same with 'Values'.
 //
@@ -755,12 +1525,170 @@ func (opts *Chart) parseTitle() {
 //	x
 //	auto
 //
+// The optional field 'Fill' and 'Border' of the 'Marker' set the fill and the
+// border line of the marker, the color of the border line is the same as the
+// fill color.
+//
+// DataPoints: This sets the format of the individual data points in a data
+// series, the 'Index' is the zero-based position of the point in the series,
+// and the index of each data point must be unique. The options that can be
+// set are:
+//
+//	Index
+//	Fill
+//	Border
+//	Marker
+//	Explosion
+//
+// The 'Marker' of the data point only works for the line chart and scatter
+// chart. The 'Explosion' specifies the distance of the slice moved out from
+// the center of the pie chart, doughnut chart, pie of pie chart and bar of
+// pie chart, the range of value is 0-400 in percentage of the radius.
+//
 // DataLabelPosition: This sets the position of the chart series data label.
 //
+// Type: This sets the chart type of the series, the series of different types
+// will be plotted as the combo chart in the same plot area, for example, plot
+// some series as the line chart in a column chart. The series with the zero
+// value of 'Type' use the type of the chart, so set the type of the chart as
+// 'Area' to combine the area series with the series of other types. This
+// option only works for the series of the first chart in the combo chart.
+//
+// SecondaryAxis: This sets the series plotted on the secondary vertical axis,
+// the series of the same type on the same axis will be grouped together. The
+// secondary vertical axis can be set by the 'YAxis2' of the chart.
+//
+// Trendline: This sets the trendline of the series, the trendline will be
+// calculated by the spreadsheet application according to the series data. The
+// options that can be set are:
+//
+//	Type
+//	Name
+//	Order
+//	Period
+//	Forward
+//	Backward
+//	Intercept
+//	DisplayEquation
+//	DisplayRSquared
+//	Fill
+//	Line
+//
+// Type: Specifies the type of the trendline, the enumeration value of the
+// 'Type' are:
+//
+//	ChartTrendlineNone
+//	ChartTrendlineLinear
+//	ChartTrendlineExponential
+//	ChartTrendlineLogarithmic
+//	ChartTrendlinePolynomial
+//	ChartTrendlinePower
+//	ChartTrendlineMovingAverage
+//
+// Name: Specifies the name of the trendline displayed in the chart legend.
+//
+// Order: Specifies the order of the polynomial trendline, the range of the
+// order is 2-6 (default value is 2).
+//
+// Period: Specifies the period of the moving average trendline, the range of
+// the period is 2-255 (default value is 2).
+//
+// Forward: Specifies the number of periods that the trendline extends forward.
+//
+// Backward: Specifies the number of periods that the trendline extends
+// backward.
+//
+// Intercept: Specifies the value where the trendline crosses the vertical
+// axis, it only works for the linear, exponential and polynomial trendline.
+//
+// DisplayEquation: Specifies the trendline equation displayed on the chart,
+// the moving average trendline doesn't support this option.
+//
+// DisplayRSquared: Specifies the R-squared value displayed on the chart, the
+// moving average trendline doesn't support this option.
+//
+// Fill: This sets the color of the trendline.
+//
+// Line: This sets the width of the trendline, the trendline will be hidden if
+// the type of the line is 'ChartLineNone'.
+//
+// ErrorBars: This sets the error bars of the series. The options that can be
+// set are:
+//
+//	Type
+//	Direction
+//	Value
+//	Plus
+//	Minus
+//	NoEndCap
+//	Fill
+//	Line
+//
+// Type: Specifies the type of the error bars, the enumeration value of the
+// 'Type' are:
+//
+//	ChartErrorBarsNone
+//	ChartErrorBarsFixedValue
+//	ChartErrorBarsPercentage
+//	ChartErrorBarsStandardDeviation
+//	ChartErrorBarsStandardError
+//	ChartErrorBarsCustom
+//
+// Direction: Specifies the direction of the error bars, the enumeration value
+// of the 'Direction' are 'ChartErrorBarsDirectionY' (default value),
+// 'ChartErrorBarsDirectionX' and 'ChartErrorBarsDirectionBoth'. The error bars
+// on the X axis only works for the scatter and bubble chart. The error bars
+// only works for the 2-D area, bar, column, line, scatter and bubble chart.
+//
+// Value: Specifies the value of the fixed value, percentage and standard
+// deviation error bars, the default value are 1, 5 and 1.
+//
+// Plus: Specifies the formula reference of the cells which contain the
+// positive values of the custom error bars, such as Sheet1!$C$2:$C$6.
+//
+// Minus: Specifies the formula reference of the cells which contain the
+// negative values of the custom error bars. At least one of the 'Plus' and
+// 'Minus' is required for the custom error bars, and the error bars will only
+// be displayed in the direction which has the reference.
+//
+// NoEndCap: Specifies the error bars without the end cap.
+//
+// Fill: This sets the color of the error bars.
+//
+// Line: This sets the width of the error bars, the error bars will be hidden
+// if the type of the line is 'ChartLineNone'.
+//
+// For example, create a scatter chart with the asymmetric custom error bars on
+// the Y axis:
+//
+//	if err := f.AddChart("Sheet1", "E1", &excelize.Chart{
+//	    Type: excelize.Scatter,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Name:       "Sheet1!$B$1",
+//	            Categories: "Sheet1!$A$2:$A$6",
+//	            Values:     "Sheet1!$B$2:$B$6",
+//	            ErrorBars: excelize.ChartErrorBars{
+//	                Type:  excelize.ChartErrorBarsCustom,
+//	                Plus:  "Sheet1!$C$2:$C$6",
+//	                Minus: "Sheet1!$D$2:$D$6",
+//	            },
+//	        },
+//	    },
+//	}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
 //	ShowLegendKey
+//	Font
+//	Fill
+//	Border
+//	Overlay
+//	DeletedEntries
 //
 // Position: Set the position of the chart legend. The default legend position
 // is bottom. The available positions are:
@@ -775,14 +1703,63 @@ func (opts *Chart) parseTitle() {
 // ShowLegendKey: Set the legend keys shall be shown in data labels. The default
 // value is false.
 //
+// Font: Set the font of the legend entries.
+//
+// Fill: Set the fill of the legend, the solid fill with one color or no fill
+// without color in the pattern fill type are supported, the same as the fill
+// of the plot area.
+//
+// Border: Set the border line type of the legend, the solid and none line
+// types are supported.
+//
+// Overlay: Specifies that the legend shall be overlaid on the plot area. The
+// default value is false.
+//
+// DeletedEntries: Specifies the zero-based indexes of the legend entries to be
+// hidden from the legend, the index of the entries follows the series order,
+// and the categories order for the pie and doughnut charts. Deleting the
+// legend entries doesn't affect the plotted series. For example, hide the
+// second series from the legend:
+//
+//	Legend: excelize.ChartLegend{Position: "bottom", DeletedEntries: []int{1}}
+//
+// The 'Font', 'Fill', 'Border', 'Overlay' and 'DeletedEntries' properties are
+// not supported for the box and whisker, funnel, histogram, pareto and
+// waterfall charts.
+//
 // Set properties of the chart title. The properties that can be set are:
 //
 //	Title
+//	TitleFormat
 //
 // Title: Set the name (title) for the chart. The name is displayed above the
 // chart. The name can also be a formula such as Sheet1!$A$1 or a list with a
 // sheet name. The name property is optional. The default is to have no chart
-// title.
+// title. Each rich text run of the title will be displayed as a paragraph.
+//
+// TitleFormat: Set the format of the chart title, the properties that can be
+// set are:
+//
+//	CellRef
+//	Font
+//	Rotation
+//	Overlay
+//
+// CellRef: Specifies the cell reference such as Sheet1!$A$1 to link the title
+// text with the cell value, the 'Title' will be ignored if this property was
+// set.
+//
+// Font: Specifies the default font of the title, which will be used for the
+// rich text runs without font settings and the title linked to the cell.
+//
+// Rotation: Specifies the text rotation of the title in degrees, the value
+// should be in the range -90 to 90.
+//
+// Overlay: Specifies that the title shall be overlaid on the plot area. The
+// default value is false.
+//
+// The 'TitleFormat' property is not supported for the box and whisker,
+// funnel, histogram, pareto and waterfall charts.
 //
 // Specifies how blank cells are plotted on the chart by 'ShowBlanksAs'. The
 // default value is gap. The options that can be set are:
@@ -800,6 +1777,20 @@ func (opts *Chart) parseTitle() {
 // Specifies that each data marker in the series has a different color by
 // 'VaryColors'. The default value is true.
 //
+// Set the space between the clusters of the bar or column chart by 'GapWidth'
+// as a percentage of the bar width, the value should be great than or equal
+// to 0 and less than or equal to 500. The 'GapWidth' property is optional, the
+// default value is 150. For the 'PieOfPie' and 'BarOfPie' chart, it specifies
+// the space between the first pie and the second plot, and the default value
+// is 100.
+//
+// Set how much the bars or columns within the same cluster overlap by
+// 'Overlap' as a percentage of the bar width, the value should be great than
+// or equal to -100 and less than or equal to 100, the negative value leaves
+// space between the bars. The 'Overlap' property is optional, and only works
+// with the 2D bar or column chart. The default value is 100 for stacked and
+// percent stacked charts, and 0 for others.
+//
 // Set chart offset, scale, aspect ratio setting and print settings by 'Format',
 // same as function 'AddPicture'.
 //
@@ -807,6 +1798,11 @@ func (opts *Chart) parseTitle() {
 // can be set are:
 //
 //	SecondPlotValues
+//	SplitType
+//	SplitValue
+//	SecondPlotPoints
+//	SecondPlotSize
+//	SeriesLines
 //	ShowBubbleSize
 //	ShowCatName
 //	ShowLeaderLines
@@ -815,8 +1811,36 @@ func (opts *Chart) parseTitle() {
 //	ShowVal
 //	NumFmt
 //
-// SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
-// 'barOfPie' chart.
+// SecondPlotValues: Specifies the number of the last values of the series in
+// the second plot for the 'PieOfPie' and 'BarOfPie' chart, with the split type
+// 'ChartSplitAuto' or 'ChartSplitPosition'.
+//
+// SplitType: Specifies how to determine the values in the second plot for the
+// 'PieOfPie' and 'BarOfPie' chart. The default value is 'ChartSplitAuto',
+// which lets the application decide the values. The possible values are:
+//
+//	ChartSplitAuto
+//	ChartSplitPosition
+//	ChartSplitValue
+//	ChartSplitPercent
+//	ChartSplitCustom
+//
+// SplitValue: Specifies the values less than the given value are in the second
+// plot with the split type 'ChartSplitValue', or the values less than the given
+// percentage of the sum, in the range of 0 to 100, are in the second plot with
+// the split type 'ChartSplitPercent'.
+//
+// SecondPlotPoints: Specifies the zero-based indexes of the data points in the
+// second plot with the split type 'ChartSplitCustom'.
+//
+// SecondPlotSize: Specifies the size of the second plot as a percentage of the
+// size of the first pie, the value should be great than or equal to 5 and less
+// than or equal to 200. The 'SecondPlotSize' property is optional, the default
+// value is 75.
+//
+// SeriesLines: Specifies the format of the series lines connecting the first
+// pie and the second plot, set the type of the line to 'ChartLineNone' to hide
+// the series lines.
 //
 // ShowBubbleSize: Specifies the bubble size shall be shown in a data label. The
 // 'ShowBubbleSize' property is optional. The default value is false.
@@ -843,9 +1867,15 @@ func (opts *Chart) parseTitle() {
 // Set the primary horizontal and vertical axis options by 'XAxis' and 'YAxis'.
 // The properties of 'XAxis' that can be set are:
 //
+//	Type
 //	None
 //	MajorGridLines
 //	MinorGridLines
+//	MajorUnit
+//	MinorUnit
+//	BaseTimeUnit
+//	MajorTimeUnit
+//	MinorTimeUnit
 //	TickLabelSkip
 //	ReverseOrder
 //	Maximum
@@ -854,6 +1884,7 @@ func (opts *Chart) parseTitle() {
 //	Font
 //	NumFmt
 //	Title
+//	TitleFormat
 //
 // The properties of 'YAxis' that can be set are:
 //
@@ -861,6 +1892,7 @@ func (opts *Chart) parseTitle() {
 //	MajorGridLines
 //	MinorGridLines
 //	MajorUnit
+//	MinorUnit
 //	Secondary
 //	ReverseOrder
 //	Maximum
@@ -870,6 +1902,17 @@ func (opts *Chart) parseTitle() {
 //	LogBase
 //	NumFmt
 //	Title
+//	TitleFormat
+//
+// Type: Specifies the type of the horizontal axis, the value can be category,
+// date or value. The value type is only used for the scatter and bubble
+// charts, and the category and date types are used for the other charts with
+// the horizontal axis. The date axis shows the categories at the intervals of
+// the time units, the categories of the series should reference the cells
+// with the date values, and the serial date numbers will be cached with the
+// number format of the axis. The 'Type' property is optional. The default
+// value is value for the scatter and bubble charts, and category for the
+// other charts.
 //
 // None: Disable axes.
 //
@@ -879,7 +1922,25 @@ func (opts *Chart) parseTitle() {
 //
 // MajorUnit: Specifies the distance between major ticks. Shall contain a
 // positive floating-point number. The 'MajorUnit' property is optional. The
-// default value is auto.
+// default value is auto. The distance is in the 'MajorTimeUnit' for the date
+// axis.
+//
+// MinorUnit: Specifies the distance between minor ticks. Shall contain a
+// positive floating-point number. The 'MinorUnit' property is optional. The
+// default value is auto. The distance is in the 'MinorTimeUnit' for the date
+// axis.
+//
+// BaseTimeUnit: Specifies the smallest time unit represented on the date
+// axis, the value can be days, months or years. The 'BaseTimeUnit' property
+// is optional. The default value is auto.
+//
+// MajorTimeUnit: Specifies the time unit for the major tick marks of the date
+// axis, the value can be days, months or years. The 'MajorTimeUnit' property
+// is optional. The default value is auto.
+//
+// MinorTimeUnit: Specifies the time unit for the minor tick marks of the date
+// axis, the value can be days, months or years. The 'MinorTimeUnit' property
+// is optional. The default value is auto.
 //
 // Secondary: Specifies the current series vertical axis as the secondary axis,
 // this only works for the second and later chart in the combo chart. The
@@ -927,16 +1988,37 @@ func (opts *Chart) parseTitle() {
 //	Strike
 //	Color
 //	VertAlign
+//	Scheme
+//
+// The 'VertAlign' specifies the font is superscript or subscript, and the
+// 'Scheme' with the value 'major' or 'minor' specifies the axis uses the
+// headings or body font of the theme when the 'Family' is empty.
 //
 // LogBase: Specifies logarithmic scale base number of the vertical axis.
 //
 // NumFmt: Specifies that if linked to source and set custom number format code
 // for axis. The 'NumFmt' property is optional. The default format code is
-// 'General'.
+// 'General', and 'm/d/yyyy' for the date axis. For example, show the month
+// and year on the date axis with the format code 'mmm-yy'.
 //
 // Title: Specifies that the primary horizontal or vertical axis title and
 // resize chart. The 'Title' property is optional.
 //
+// TitleFormat: Specifies the format of the axis title, the properties that can
+// be set are the same as the 'TitleFormat' of the chart title. The title of the
+// vertical axis is rotated by -90 degrees by default.
+//
+// Set the secondary vertical axis options by 'YAxis2', the properties that can
+// be set are the same as 'YAxis' except 'Secondary'. The 'YAxis2' property only
+// works for the series plotted on the secondary axis by the 'SecondaryAxis' of
+// the series, such as the scaling, number format and title of the margin axis
+// in a revenue - margin chart.
+//
+// Set the series (depth) axis options of the 3D column, 3D area, 3D line and
+// surface charts by 'ZAxis', the properties that can be set are: 'None',
+// 'MajorGridLines', 'TickLabelPosition', 'ReverseOrder', 'Font', 'Title' and
+// 'TitleFormat', which are the same as the horizontal axis.
+//
 // Set chart size by 'Dimension' property. The 'Dimension' property is optional.
 // The default width is 480, and height is 260.
 //
@@ -949,9 +2031,152 @@ func (opts *Chart) parseTitle() {
 // 'HoleSize' property. The 'HoleSize' property is optional. The default width
 // is 75, and the value should be great than 0 and less or equal than 90.
 //
+// Set the box and whisker chart options by 'BoxWhisker' property. The
+// 'BoxWhisker' property is optional, only works with the 'BoxWhisker' chart
+// type. The box and whisker chart is saved as a chart extension part, which
+// supported in Excel 2016 and later version, and doesn't support combo charts
+// or chartsheet. The properties that can be set are:
+//
+//	ShowInnerPoints
+//	ShowOutlierPoints
+//	ShowMeanMarkers
+//	ShowMeanLine
+//	QuartileMethod
+//
+// ShowInnerPoints: Specifies the data points that lie between the lower
+// whisker line and the upper whisker line should be displayed.
+//
+// ShowOutlierPoints: Specifies the outlier points that lie either below the
+// lower whisker line or above the upper whisker line should be displayed.
+//
+// ShowMeanMarkers: Specifies the mean marker of the series should be
+// displayed.
+//
+// ShowMeanLine: Specifies the line connecting the means of the boxes in the
+// series should be displayed.
+//
+// QuartileMethod: Specifies the quartile calculation method, the value must
+// be one of 'inclusive' (include the median when calculating the quartiles)
+// or 'exclusive' (exclude the median), the default value is 'exclusive'.
+//
+// Set the waterfall chart options by 'Waterfall' property. The 'Waterfall'
+// property is optional, only works with the 'Waterfall' chart type. The
+// waterfall chart is saved as a chart extension part like the box and whisker
+// chart. The properties that can be set are:
+//
+//	Totals
+//	ShowConnectorLines
+//	IncreaseFill
+//	DecreaseFill
+//	TotalFill
+//
+// Totals: Specifies the zero-based indexes of the data points which should be
+// set as totals, the total column starts from the horizontal axis and shows
+// the value of the data point as the subtotal.
+//
+// ShowConnectorLines: Specifies the connector lines between the columns should
+// be displayed.
+//
+// IncreaseFill, DecreaseFill and TotalFill: Specifies the fill of the
+// increase, decrease and total columns. The increase and decrease columns are
+// determined by the cell values of the series at the time the chart was added.
+//
+// For example, create a classic bridge chart with data Sheet1!$A$1:$B$6:
+//
+//	for idx, row := range [][]interface{}{
+//	    {"Revenue", 420}, {"Cost of sales", -180}, {"Gross profit", 240},
+//	    {"Operating expenses", -95}, {"Other income", 15}, {"EBITDA", 160},
+//	} {
+//	    cell, err := excelize.CoordinatesToCellName(1, idx+1)
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    f.SetSheetRow("Sheet1", cell, &row)
+//	}
+//	if err := f.AddChart("Sheet1", "D1", &excelize.Chart{
+//	    Type: excelize.Waterfall,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Categories: "Sheet1!$A$1:$A$6",
+//	            Values:     "Sheet1!$B$1:$B$6",
+//	        },
+//	    },
+//	    Title:    []excelize.RichTextRun{{Text: "Revenue to EBITDA"}},
+//	    Legend:   excelize.ChartLegend{Position: "none"},
+//	    PlotArea: excelize.ChartPlotArea{ShowVal: true},
+//	    Waterfall: excelize.ChartWaterfall{
+//	        Totals:             []int{0, 2, 5},
+//	        ShowConnectorLines: true,
+//	        IncreaseFill:       excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"70AD47"}},
+//	        DecreaseFill:       excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}},
+//	        TotalFill:          excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"4472C4"}},
+//	    },
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+//
+// Set the funnel chart options by 'Funnel' property. The 'Funnel' property is
+// optional, only works with the 'Funnel' chart type. The funnel chart is saved
+// as a chart extension part like the box and whisker chart, and must have
+// exactly one series. The properties that can be set are:
+//
+//	GapWidth
+//
+// GapWidth: Specifies the space between the bars of the funnel as a
+// percentage of the bar width, the value should be between 0 and 500. The
+// default value is 6.
+//
+// Set the binning options of the histogram and Pareto chart by 'Histogram'
+// property. The 'Histogram' property is optional, only works with the
+// 'Histogram' and 'Pareto' chart type. The Pareto chart plots the bins in
+// descending order with a cumulative percentage line on the secondary value
+// axis. The properties that can be set are:
+//
+//	ByCategory
+//	BinWidth
+//	BinCount
+//	Overflow
+//	Underflow
+//
+// ByCategory: Specifies the text categories of the series will be used as the
+// bins, and the values of the same category will be summed, the series must
+// have categories. The other binning options will be ignored if this option
+// has been set.
+//
+// BinWidth: Specifies the width of each bin. This option and 'BinCount' can
+// not be set at the same time, Excel will calculate the bins automatically if
+// neither of them has been set.
+//
+// BinCount: Specifies the number of the bins.
+//
+// Overflow: Specifies the overflow bin threshold, the values above it will be
+// grouped into one bin.
+//
+// Underflow: Specifies the underflow bin threshold, the values less than or
+// equal to it will be grouped into one bin.
+//
+// The data labels of the box and whisker, waterfall, funnel, histogram and
+// Pareto chart can be
+// set by the 'ShowVal', 'ShowCatName' and 'ShowSerName' of the 'PlotArea'
+// property, the other data label options are not supported by these charts.
+//
 // combo: Specifies the create a chart that combines two or more chart types in
-// a single chart. For example, create a clustered column - line chart with
-// data Sheet1!$E$1:$L$15:
+// a single chart. The combo chart can also be created by the 'Type' and
+// 'SecondaryAxis' of each series, for example, create an area - clustered
+// column - line chart with the line series on the secondary axis:
+//
+//	err := f.AddChart("Sheet1", "E1", &excelize.Chart{
+//	    Type: excelize.Area,
+//	    Series: []excelize.ChartSeries{
+//	        {Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+//	        {Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Type: excelize.Col},
+//	        {Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4", Type: excelize.Line, SecondaryAxis: true},
+//	    },
+//	})
+//
+// For example, create a clustered column - line chart with data
+// Sheet1!$E$1:$L$15 by the combo charts:
 //
 //	package main
 //
@@ -1071,14 +2296,25 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
 	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
-	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
-	err = f.addDrawingChart(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format)
-	if err != nil {
-		return err
-	}
-	f.addChart(opts, comboCharts)
-	if err = f.addContentTypePart(chartID, "chart"); err != nil {
-		return err
+	if _, ok := chartExLayoutIDs[opts.Type]; ok {
+		drawingRID := f.addRels(drawingRels, SourceRelationshipChartEx, "../charts/chartEx"+strconv.Itoa(chartID)+".xml", "")
+		if err = f.addDrawingChartEx(sheet, drawingXML, cell, drawingRID, opts); err != nil {
+			return err
+		}
+		f.addChartEx(opts)
+		if err = f.addContentTypePart(chartID, "chartEx"); err != nil {
+			return err
+		}
+	} else {
+		drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
+		err = f.addDrawingChart(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format)
+		if err != nil {
+			return err
+		}
+		f.addChart(opts, comboCharts)
+		if err = f.addContentTypePart(chartID, "chart"); err != nil {
+			return err
+		}
 	}
 	_ = f.addContentTypePart(drawingID, "drawings")
 	f.addSheetNameSpace(sheet, SourceRelationship)
@@ -1088,7 +2324,53 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 // AddChartSheet provides the method to create a chartsheet by given chart
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and properties set. In Excel a chartsheet is a worksheet that only contains
-// a chart.
+// a chart. The chart will be sized to fill the printable area of the
+// chartsheet page.
+//
+// Set the chartsheet settings by 'ChartSheet' property of the chart. The
+// 'ChartSheet' property is optional, and only works with the chartsheet. The
+// properties that can be set are:
+//
+//	Index
+//	ZoomScale
+//	PageLayout
+//	HeaderFooter
+//
+// Index: specifies the position of the chartsheet in the workbook tab order,
+// the chartsheet will be appended after the last sheet by default.
+//
+// ZoomScale: specifies the zoom scale of the chartsheet view, the value
+// should be great than or equal to 10 and less than or equal to 400. The chart
+// will be sized with the window by default.
+//
+// PageLayout: specifies the page setup settings of the chartsheet, the
+// properties that can be set are the same as the SetPageLayout function
+// except the 'AdjustTo', 'FitToHeight', 'FitToWidth' and 'PageOrder', which
+// are not applicable to the chartsheet. The default orientation of the
+// chartsheet is landscape.
+//
+// HeaderFooter: specifies the header and footer settings of the chartsheet,
+// the properties that can be set are the same as the SetHeaderFooter function.
+//
+// For example, create a chartsheet named Chart1 as the first sheet, and print
+// the chart on A4 paper with page number in the footer:
+//
+//	index, size := 0, 9
+//	err := f.AddChartSheet("Chart1", &excelize.Chart{
+//	    Type: excelize.Col,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Name:       "Sheet1!$A$2",
+//	            Categories: "Sheet1!$B$1:$D$1",
+//	            Values:     "Sheet1!$B$2:$D$2",
+//	        },
+//	    },
+//	    ChartSheet: &excelize.ChartSheetOptions{
+//	        Index:        &index,
+//	        PageLayout:   &excelize.PageLayoutOptions{Size: &size},
+//	        HeaderFooter: &excelize.HeaderFooterOptions{OddFooter: "&C&P"},
+//	    },
+//	})
 func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error {
 	// Check if the worksheet already exists
 	idx, err := f.GetSheetIndex(sheet)
@@ -1102,10 +2384,34 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
+	if _, ok := chartExLayoutIDs[opts.Type]; ok {
+		return newUnsupportedChartType(opts.Type)
+	}
+	chartID := f.countCharts() + 1
+	if err = f.addChartSheet(sheet, "../charts/chart"+strconv.Itoa(chartID)+".xml", &opts.Format, opts.ChartSheet); err != nil {
+		return err
+	}
+	f.addChart(opts, comboCharts)
+	return f.addContentTypePart(chartID, "chart")
+}
+
+// addChartSheet provides a function to create the chartsheet and the drawing
+// part of the chartsheet by given chartsheet name, relationship target of the
+// chart part, graphic format set and chartsheet settings.
+func (f *File) addChartSheet(sheet, chartTarget string, format *GraphicOptions, opts *ChartSheetOptions) error {
 	cs := xlsxChartsheet{
 		SheetViews: &xlsxChartsheetViews{
 			SheetView: []*xlsxChartsheetView{{ZoomScaleAttr: 100, ZoomToFitAttr: true}},
 		},
+		PageMargins: &xlsxPageMargins{
+			Left: defaultChartSheetMarginLR, Right: defaultChartSheetMarginLR,
+			Top: defaultChartSheetMarginTB, Bottom: defaultChartSheetMarginTB,
+			Header: defaultChartSheetMarginHF, Footer: defaultChartSheetMarginHF,
+		},
+		PageSetup: &xlsxPageSetUp{Orientation: "landscape"},
+	}
+	if err := cs.setChartSheetOptions(opts, f.SheetCount); err != nil {
+		return err
 	}
 	f.SheetCount++
 	wb, _ := f.workbookReader()
@@ -1120,19 +2426,17 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	f.sheetMap[sheet] = path
 	f.Sheet.Store(path, nil)
 	drawingID := f.countDrawings() + 1
-	chartID := f.countCharts() + 1
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
 	f.prepareChartSheetDrawing(&cs, drawingID, sheet)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
-	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
-	if err = f.addSheetDrawingChart(drawingXML, drawingRID, &opts.Format); err != nil {
+	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, chartTarget, "")
+	width, height := cs.getPrintableSize()
+	if err := f.addSheetDrawingChart(drawingXML, drawingRID, width, height, format); err != nil {
 		return err
 	}
-	f.addChart(opts, comboCharts)
-	if err = f.addContentTypePart(chartID, "chart"); err != nil {
+	if err := f.addContentTypePart(sheetID, "chartsheet"); err != nil {
 		return err
 	}
-	_ = f.addContentTypePart(sheetID, "chartsheet")
 	_ = f.addContentTypePart(drawingID, "drawings")
 	// Update workbook.xml.rels
 	rID := f.addRels(f.getWorkbookRelsPath(), SourceRelationshipChartsheet, fmt.Sprintf("/xl/chartsheets/sheet%d.xml", sheetID), "")
@@ -1141,7 +2445,102 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	chartsheet, _ := xml.Marshal(cs)
 	f.addSheetNameSpace(sheet, NameSpaceSpreadSheet)
 	f.saveFileList(path, replaceRelationshipsBytes(f.replaceNameSpaceBytes(path, chartsheet)))
-	return err
+	if opts != nil && opts.Index != nil && *opts.Index < len(wb.Sheets.Sheet)-1 {
+		return f.MoveSheet(sheet, f.GetSheetName(*opts.Index))
+	}
+	return nil
+}
+
+// setChartSheetOptions provides a function to check and set the chartsheet
+// settings by given chartsheet options and the number of sheets in the
+// workbook before the chartsheet is created.
+func (cs *xlsxChartsheet) setChartSheetOptions(opts *ChartSheetOptions, sheetCount int) error {
+	if opts == nil {
+		return nil
+	}
+	if opts.Index != nil && (*opts.Index < 0 || *opts.Index > sheetCount) {
+		return ErrSheetIdx
+	}
+	if opts.ZoomScale != nil && *opts.ZoomScale >= 10 && *opts.ZoomScale <= 400 {
+		cs.SheetViews.SheetView[0].ZoomScaleAttr = uint32(*opts.ZoomScale)
+		cs.SheetViews.SheetView[0].ZoomToFitAttr = false
+	}
+	if opts.PageLayout != nil {
+		if err := cs.setPageSetUp(opts.PageLayout); err != nil {
+			return err
+		}
+	}
+	if opts.HeaderFooter != nil {
+		headerFooter, err := newHeaderFooter(opts.HeaderFooter)
+		if err != nil {
+			return err
+		}
+		cs.HeaderFooter = headerFooter
+	}
+	return nil
+}
+
+// getPrintableSize provides a function to get the width and height in EMUs of
+// the printable area of the chartsheet page by the paper size, orientation
+// and page margins.
+func (cs *xlsxChartsheet) getPrintableSize() (int, int) {
+	paper := chartSheetPaperSizes[1]
+	if cs.PageSetup.PaperSize != nil {
+		if size, ok := chartSheetPaperSizes[*cs.PageSetup.PaperSize]; ok {
+			paper = size
+		}
+	}
+	width, height := paper[0], paper[1]
+	if cs.PageSetup.Orientation == "landscape" {
+		width, height = height, width
+	}
+	width -= cs.PageMargins.Left + cs.PageMargins.Right
+	height -= cs.PageMargins.Top + cs.PageMargins.Bottom
+	return int(width * 96 * float64(EMU)), int(height * 96 * float64(EMU))
+}
+
+// MoveChartToChartSheet provides a function to move the chart on the
+// worksheet to a new chartsheet by given worksheet name, the index of the
+// chart in the worksheet as the order returned by the GetCharts function, and
+// the new chartsheet name. The chartsheet will be created before the
+// worksheet in the workbook tab order, and the chart will be sized to fill the
+// printable area of the chartsheet page. For example, move the first chart on
+// Sheet1 to the chartsheet named Chart1:
+//
+//	err := f.MoveChartToChartSheet("Sheet1", 0, "Chart1")
+//
+// This function doesn't support moving the chart which types are Funnel,
+// Histogram, Pareto, Treemap, Sunburst, BoxWhisker and Waterfall.
+func (f *File) MoveChartToChartSheet(sheet string, chartIndex int, newSheetName string) error {
+	if err := checkSheetName(newSheetName); err != nil {
+		return err
+	}
+	if idx, _ := f.GetSheetIndex(newSheetName); idx != -1 {
+		return ErrExistsSheet
+	}
+	charts, err := f.GetCharts(sheet)
+	if err != nil {
+		return err
+	}
+	if chartIndex < 0 || chartIndex >= len(charts) {
+		return newNoExistChartError(sheet, strconv.Itoa(chartIndex))
+	}
+	info := charts[chartIndex]
+	if _, ok := chartExLayoutIDs[info.Chart.Type]; ok {
+		return newUnsupportedChartType(info.Chart.Type)
+	}
+	if err = f.deleteDrawingChart(info.DrawingPart, info.ChartPart); err != nil {
+		return err
+	}
+	format := GraphicOptions{Locked: boolPtr(true), PrintObject: boolPtr(true)}
+	if info.Chart.Format.Locked != nil {
+		format.Locked = info.Chart.Format.Locked
+	}
+	if info.Chart.Format.PrintObject != nil {
+		format.PrintObject = info.Chart.Format.PrintObject
+	}
+	idx, _ := f.GetSheetIndex(sheet)
+	return f.addChartSheet(newSheetName, "../"+strings.TrimPrefix(info.ChartPart, "xl/"), &format, &ChartSheetOptions{Index: intPtr(idx)})
 }
 
 // getChartOptions provides a function to check format set of the chart and
@@ -1152,16 +2551,52 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 	if err != nil {
 		return options, comboCharts, err
 	}
+	if err = f.parseErrorBars(options); err != nil {
+		return options, comboCharts, err
+	}
+	if err = f.parseChartSeriesRefs(options); err != nil {
+		return options, comboCharts, err
+	}
+	if err = f.parseChartTitleRefs(options); err != nil {
+		return options, comboCharts, err
+	}
+	if options, comboCharts, err = options.parseSeriesTypes(); err != nil {
+		return options, comboCharts, err
+	}
 	for _, comboFormat := range combo {
 		comboChart, err := parseChartOptions(comboFormat)
 		if err != nil {
 			return options, comboCharts, err
 		}
+		if options.XAxis.Type == "date" {
+			comboChart.XAxis.Type = options.XAxis.Type
+		}
+		if err = f.parseErrorBars(comboChart); err != nil {
+			return options, comboCharts, err
+		}
+		if err = f.parseChartSeriesRefs(comboChart); err != nil {
+			return options, comboCharts, err
+		}
+		if err = f.parseChartTitleRefs(comboChart); err != nil {
+			return options, comboCharts, err
+		}
 		if _, ok := chartValAxNumFmtFormatCode[comboChart.Type]; !ok {
 			return options, comboCharts, newUnsupportedChartType(comboChart.Type)
 		}
 		comboCharts = append(comboCharts, comboChart)
 	}
+	if _, ok := chartExLayoutIDs[options.Type]; ok {
+		if len(comboCharts) > 0 {
+			return options, comboCharts, ErrParameterInvalid
+		}
+		return options, comboCharts, map[ChartType]func() error{
+			BoxWhisker: options.BoxWhisker.parse,
+			Waterfall:  func() error { return f.parseWaterfall(options) },
+			Funnel:     options.parseFunnel,
+			Histogram:  options.parseHistogram,
+			Pareto:     options.parseHistogram,
+		}[options.Type]()
+	}
 	if _, ok := chartValAxNumFmtFormatCode[options.Type]; !ok {
 		return options, comboCharts, newUnsupportedChartType(options.Type)
 	}
@@ -1189,6 +2624,1008 @@ func (f *File) DeleteChart(sheet, cell string) error {
 	return err
 }
 
+// GetCharts provides a function to get all charts in a worksheet by given
+// worksheet name. For each chart, this function returns the anchor position,
+// the drawing and chart part names, the cached values of the series, and the
+// chart definition which includes the chart type, series formulas, titles,
+// legend and axis settings. The series of the combo chart are returned in the
+// same chart with the type and axis of each series. The returned chart
+// definition can be used to add the same chart to other worksheet. For
+// example, copy all charts on Sheet1 to the same position on Sheet2:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    if chart.Anchor.From == "" {
+//	        continue
+//	    }
+//	    if err := f.AddChart("Sheet2", chart.Anchor.From, &chart.Chart); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
+//
+// The cached values of the series are the values last calculated by the
+// spreadsheet application, which may be empty when the chart was created
+// without calculating. The chart title which was set by formula reference will
+// be returned by its cached text, and the cell reference will be returned in
+// the 'CellRef' of the title format.
+func (f *File) GetCharts(sheet string) ([]ChartInfo, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+	var wsDr decodeChartWsDr
+	if err = f.xmlNewDecoder(bytes.NewReader(f.getDrawingContent(drawingXML))).
+		Decode(&wsDr); err != nil && err != io.EOF {
+		return nil, err
+	}
+	var charts []ChartInfo
+	for anchorType, anchors := range [][]*decodeChartAnchor{wsDr.TwoCellAnchor, wsDr.OneCellAnchor, wsDr.AbsoluteAnchor} {
+		for _, anchor := range anchors {
+			chart, err := f.getChart(sheet, drawingXML, drawingRels, []string{"twoCell", "oneCell", "absolute"}[anchorType], anchor)
+			if err != nil {
+				return charts, err
+			}
+			if chart != nil {
+				charts = append(charts, *chart)
+			}
+		}
+	}
+	return charts, nil
+}
+
+// getDrawingContent provides a function to get the content of the drawing part
+// by given drawing part path. The drawing which has been loaded will be
+// serialized to get the latest content.
+func (f *File) getDrawingContent(drawingXML string) []byte {
+	if drawing, ok := f.Drawings.Load(drawingXML); ok && drawing != nil {
+		wsDr := drawing.(*xlsxWsDr)
+		wsDr.mu.Lock()
+		defer wsDr.mu.Unlock()
+		content, _ := xml.Marshal(wsDr)
+		return content
+	}
+	return namespaceStrictToTransitional(f.readXML(drawingXML))
+}
+
+// getChart provides a function to get the chart definition by given worksheet
+// name, drawing part path, drawing relationships part path, anchor type and
+// the decoded cell anchor. It returns nil if the anchor doesn't contain chart.
+func (f *File) getChart(sheet, drawingXML, drawingRels, anchorType string, anchor *decodeChartAnchor) (*ChartInfo, error) {
+	graphicFrame := anchor.GraphicFrame
+	for _, alternateContent := range anchor.AlternateContent {
+		if graphicFrame == nil && alternateContent.Choice != nil {
+			graphicFrame = alternateContent.Choice.GraphicFrame
+		}
+	}
+	if graphicFrame == nil || graphicFrame.Graphic.GraphicData.Chart == nil {
+		return nil, nil
+	}
+	rels := f.getDrawingRelationships(drawingRels, graphicFrame.Graphic.GraphicData.Chart.RID)
+	if rels == nil {
+		return nil, nil
+	}
+	chartPart := filepath.ToSlash(filepath.Clean("xl/drawings/" + rels.Target))
+	if strings.HasPrefix(rels.Target, "/") {
+		chartPart = strings.TrimPrefix(rels.Target, "/")
+	}
+	info := &ChartInfo{
+		Name:        graphicFrame.NvGraphicFramePr.CNvPr.Name,
+		Anchor:      f.getChartAnchor(sheet, anchorType, anchor),
+		DrawingPart: drawingXML,
+		ChartPart:   chartPart,
+	}
+	info.Chart.Format = GraphicOptions{
+		OffsetX:     info.Anchor.OffsetX,
+		OffsetY:     info.Anchor.OffsetY,
+		Positioning: anchor.EditAs,
+	}
+	if anchor.ClientData != nil {
+		info.Chart.Format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+		info.Chart.Format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+	}
+	if info.Anchor.Width > 0 && info.Anchor.Height > 0 {
+		info.Chart.Dimension = ChartDimension{Width: uint(info.Anchor.Width), Height: uint(info.Anchor.Height)}
+	}
+	if rels.Type == SourceRelationshipChartEx {
+		return info, f.getChartExDefinition(info)
+	}
+	return info, f.getChartDefinition(info)
+}
+
+// getChartAnchor provides a function to get the position and size of the
+// chart in pixels by given worksheet name, anchor type and the decoded cell
+// anchor.
+func (f *File) getChartAnchor(sheet, anchorType string, anchor *decodeChartAnchor) ChartAnchor {
+	chartAnchor := ChartAnchor{Type: anchorType}
+	if anchor.Pos != nil {
+		chartAnchor.X, chartAnchor.Y = anchor.Pos.X/EMU, anchor.Pos.Y/EMU
+	}
+	if anchor.From != nil {
+		chartAnchor.From, _ = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+		chartAnchor.OffsetX, chartAnchor.OffsetY = anchor.From.ColOff/EMU, anchor.From.RowOff/EMU
+	}
+	if anchor.Ext != nil {
+		chartAnchor.Width, chartAnchor.Height = anchor.Ext.Cx/EMU, anchor.Ext.Cy/EMU
+	}
+	if anchor.From != nil && anchor.To != nil {
+		chartAnchor.To, _ = CoordinatesToCellName(anchor.To.Col+1, anchor.To.Row+1)
+		width, height := anchor.To.ColOff/EMU-chartAnchor.OffsetX, anchor.To.RowOff/EMU-chartAnchor.OffsetY
+		for col := anchor.From.Col; col < anchor.To.Col; col++ {
+			width += f.getColWidth(sheet, col+1)
+		}
+		for row := anchor.From.Row; row < anchor.To.Row; row++ {
+			height += f.getRowHeight(sheet, row+1)
+		}
+		chartAnchor.Width, chartAnchor.Height = width, height
+	}
+	return chartAnchor
+}
+
+// getChartDefinition provides a function to get the chart definition from the
+// chart part of the chart info.
+func (f *File) getChartDefinition(info *ChartInfo) error {
+	var chartSpace decodeChartSpace
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(info.ChartPart)))).
+		Decode(&chartSpace); err != nil && err != io.EOF {
+		return err
+	}
+	opts, plotArea := &info.Chart, &chartSpace.Chart.PlotArea
+	if chartSpace.Chart.Title != nil && !getAttrValBool(chartSpace.Chart.AutoTitleDeleted) {
+		opts.Title, opts.TitleFormat = getChartTitle(chartSpace.Chart.Title)
+	}
+	opts.Legend.Position = "none"
+	if legend := chartSpace.Chart.Legend; legend != nil {
+		opts.Legend.Position = "right"
+		for position, legendPos := range chartLegendPosition {
+			if legend.LegendPos != nil && legend.LegendPos.Val != nil && *legend.LegendPos.Val == legendPos {
+				opts.Legend.Position = position
+			}
+		}
+		opts.Legend.Overlay = getAttrValBool(legend.Overlay)
+		for _, entry := range legend.LegendEntry {
+			if entry.IDx != nil && entry.IDx.Val != nil && getAttrValBool(entry.Delete) {
+				opts.Legend.DeletedEntries = append(opts.Legend.DeletedEntries, *entry.IDx.Val)
+			}
+		}
+		if txPr := legend.TxPr; txPr != nil && len(txPr.P) > 0 && txPr.P[0].PPr != nil {
+			opts.Legend.Font = getChartFont(txPr.P[0].PPr.DefRPr)
+		}
+	}
+	if chartSpace.Chart.DispBlanksAs != nil && chartSpace.Chart.DispBlanksAs.Val != nil {
+		opts.ShowBlanksAs = *chartSpace.Chart.DispBlanksAs.Val
+	}
+	axes := make(map[int]*decodeChartAxis)
+	for _, axs := range [][]*decodeChartAxis{plotArea.CatAx, plotArea.DateAx, plotArea.ValAx, plotArea.SerAx} {
+		for _, ax := range axs {
+			if ax.AxID != nil && ax.AxID.Val != nil {
+				axes[*ax.AxID.Val] = ax
+			}
+		}
+	}
+	var primary *decodeChartGroup
+	for _, group := range plotArea.Charts {
+		chartType, ok := f.getChartGroupType(group)
+		if !ok {
+			continue
+		}
+		var secondary bool
+		if primary == nil {
+			primary = group
+			opts.Type, opts.VaryColors = chartType, boolPtr(getAttrValBool(group.VaryColors))
+			if len(group.AxID) > 1 && group.AxID[0].Val != nil && group.AxID[1].Val != nil {
+				opts.XAxis, opts.YAxis = getChartAxis(axes[*group.AxID[0].Val]), getChartAxis(axes[*group.AxID[1].Val])
+			}
+			if len(group.AxID) > 2 && group.AxID[2].Val != nil {
+				opts.ZAxis = getChartAxis(axes[*group.AxID[2].Val])
+			}
+			getChartGroupOptions(group, opts)
+		} else if len(group.AxID) > 1 && len(primary.AxID) > 1 && group.AxID[1].Val != nil &&
+			primary.AxID[1].Val != nil && *group.AxID[1].Val != *primary.AxID[1].Val {
+			if secondary = true; !opts.YAxis2.Secondary {
+				opts.YAxis2 = getChartAxis(axes[*group.AxID[1].Val])
+				opts.YAxis2.Secondary = true
+			}
+		}
+		for _, ser := range group.Ser {
+			series, cache := getChartSeries(ser)
+			if group != primary {
+				series.Type, series.SecondaryAxis = chartType, secondary
+			}
+			opts.Series = append(opts.Series, series)
+			info.SeriesCache = append(info.SeriesCache, cache)
+		}
+	}
+	return nil
+}
+
+// getChartGroupType provides a function to get the chart type of the chart
+// group in the plot area. It returns false if the element isn't a supported
+// chart group.
+func (f *File) getChartGroupType(group *decodeChartGroup) (ChartType, bool) {
+	chartTypes, ok := chartGroupTypes[group.XMLName.Local]
+	if !ok {
+		return 0, ok
+	}
+	matchVal := func(val *attrValString, expected, defaultVal string) bool {
+		if val == nil || val.Val == nil {
+			return expected == defaultVal
+		}
+		return *val.Val == expected
+	}
+	var bubble3D bool
+	for _, ser := range group.Ser {
+		bubble3D = bubble3D || getAttrValBool(ser.Bubble3D)
+	}
+	for _, chartType := range chartTypes {
+		if grouping, ok := plotAreaChartGrouping[chartType]; ok && group.Grouping != nil && !matchVal(group.Grouping, grouping, "") {
+			continue
+		}
+		if barDir, ok := plotAreaChartBarDir[chartType]; ok && group.BarDir != nil && !matchVal(group.BarDir, barDir, "") {
+			continue
+		}
+		shape := "box"
+		if val := f.drawChartShape(&Chart{Type: chartType}); val != nil {
+			shape = *val.Val
+		}
+		if group.XMLName.Local == "bar3DChart" && !matchVal(group.Shape, shape, "box") {
+			continue
+		}
+		if ofPieType, ok := map[ChartType]string{PieOfPie: "pie", BarOfPie: "bar"}[chartType]; ok && !matchVal(group.OfPieType, ofPieType, "pie") {
+			continue
+		}
+		if wireframe := chartType == WireframeSurface3D || chartType == WireframeContour; group.XMLName.Local == "surfaceChart" ||
+			group.XMLName.Local == "surface3DChart" {
+			if wireframe != getAttrValBool(group.Wireframe) {
+				continue
+			}
+		}
+		if group.XMLName.Local == "bubbleChart" && bubble3D != (chartType == Bubble3D) {
+			continue
+		}
+		return chartType, true
+	}
+	return chartTypes[0], true
+}
+
+// parseSplit provides a function to validate the split settings of the pie of
+// pie and bar of pie chart.
+func (plotArea *ChartPlotArea) parseSplit() error {
+	if plotArea.SplitType > ChartSplitCustom ||
+		(plotArea.SplitType == ChartSplitPercent && (plotArea.SplitValue < 0 || plotArea.SplitValue > 100)) {
+		return ErrParameterInvalid
+	}
+	for _, idx := range plotArea.SecondPlotPoints {
+		if idx < 0 {
+			return ErrParameterInvalid
+		}
+	}
+	if plotArea.SecondPlotSize != 0 && (plotArea.SecondPlotSize < 5 || plotArea.SecondPlotSize > 200) {
+		return ErrChartSecondPlotSize
+	}
+	return nil
+}
+
+// getChartSplitOptions provides a function to get the split settings of the
+// pie of pie and bar of pie chart by given chart group.
+func getChartSplitOptions(group *decodeChartGroup, plotArea *ChartPlotArea) {
+	if group.SplitType != nil && group.SplitType.Val != nil {
+		plotArea.SplitType = map[string]ChartSplitType{
+			"pos": ChartSplitPosition, "val": ChartSplitValue, "percent": ChartSplitPercent, "cust": ChartSplitCustom,
+		}[*group.SplitType.Val]
+	}
+	if group.SplitPos != nil && group.SplitPos.Val != nil {
+		if plotArea.SplitType == ChartSplitValue || plotArea.SplitType == ChartSplitPercent {
+			plotArea.SplitValue = *group.SplitPos.Val
+		} else {
+			plotArea.SecondPlotValues = int(*group.SplitPos.Val)
+		}
+	}
+	if group.CustSplit != nil {
+		for _, pt := range group.CustSplit.SecondPiePt {
+			if pt.Val != nil {
+				plotArea.SecondPlotPoints = append(plotArea.SecondPlotPoints, *pt.Val)
+			}
+		}
+	}
+	if group.SecondPieSize != nil && group.SecondPieSize.Val != nil && *group.SecondPieSize.Val > 0 {
+		plotArea.SecondPlotSize = uint(*group.SecondPieSize.Val)
+	}
+	if len(group.SerLines) == 0 {
+		plotArea.SeriesLines.Type = ChartLineNone
+	}
+}
+
+// getChartGroupOptions provides a function to get the chart options, such as
+// data labels, hole size and bubble size, by given chart group.
+func getChartGroupOptions(group *decodeChartGroup, opts *Chart) {
+	if group.HoleSize != nil && group.HoleSize.Val != nil {
+		opts.HoleSize = *group.HoleSize.Val
+	}
+	if group.BubbleScale != nil && group.BubbleScale.Val != nil {
+		opts.BubbleSize = int(*group.BubbleScale.Val)
+	}
+	if group.GapWidth != nil && group.GapWidth.Val != nil && *group.GapWidth.Val >= 0 {
+		opts.GapWidth = uintPtr(uint(*group.GapWidth.Val))
+	}
+	if group.Overlap != nil && group.Overlap.Val != nil {
+		opts.Overlap = intPtr(*group.Overlap.Val)
+	}
+	if group.XMLName.Local == "ofPieChart" {
+		getChartSplitOptions(group, &opts.PlotArea)
+	}
+	if dLbls := group.DLbls; dLbls != nil {
+		opts.Legend.ShowLegendKey = getAttrValBool(dLbls.ShowLegendKey)
+		opts.PlotArea.ShowVal = getAttrValBool(dLbls.ShowVal)
+		opts.PlotArea.ShowCatName = getAttrValBool(dLbls.ShowCatName)
+		opts.PlotArea.ShowSerName = getAttrValBool(dLbls.ShowSerName)
+		opts.PlotArea.ShowBubbleSize = getAttrValBool(dLbls.ShowBubbleSize)
+		opts.PlotArea.ShowPercent = getAttrValBool(dLbls.ShowPercent)
+		opts.PlotArea.ShowLeaderLines = getAttrValBool(dLbls.ShowLeaderLines)
+		if dLbls.NumFmt != nil {
+			opts.PlotArea.NumFmt = ChartNumFmt{CustomNumFmt: dLbls.NumFmt.FormatCode, SourceLinked: dLbls.NumFmt.SourceLinked}
+		}
+	}
+}
+
+// getChartSeries provides a function to get the chart series and the cached
+// values of the series by given decoded series.
+func getChartSeries(ser *decodeChartSeries) (ChartSeries, ChartSeriesCache) {
+	var (
+		series ChartSeries
+		cache  ChartSeriesCache
+	)
+	if ser.Tx != nil {
+		cache.Name = ser.Tx.V
+		if ser.Tx.StrRef != nil {
+			var names []string
+			series.Name, names = getChartSeriesData(&decodeChartSerData{StrRef: ser.Tx.StrRef})
+			if len(names) > 0 {
+				cache.Name = names[0]
+			}
+		}
+	}
+	categories, values := ser.Cat, ser.Val
+	if ser.XVal != nil {
+		categories = ser.XVal
+	}
+	if ser.YVal != nil {
+		values = ser.YVal
+	}
+	series.Categories, cache.Categories = getChartSeriesData(categories)
+	series.Values, cache.Values = getChartSeriesData(values)
+	series.Sizes, cache.Sizes = getChartSeriesData(ser.BubbleSize)
+	getChartMarker(ser.Marker, &series.Marker)
+	for _, pt := range ser.DPt {
+		if pt.IDx == nil || pt.IDx.Val == nil || (pt.Marker == nil && pt.Explosion == nil) {
+			continue
+		}
+		dataPoint := ChartDataPoint{Index: *pt.IDx.Val}
+		getChartMarker(pt.Marker, &dataPoint.Marker)
+		if pt.Explosion != nil && pt.Explosion.Val != nil {
+			dataPoint.Explosion = *pt.Explosion.Val
+		}
+		series.DataPoints = append(series.DataPoints, dataPoint)
+	}
+	series.Line.Smooth = getAttrValBool(ser.Smooth)
+	return series, cache
+}
+
+// getChartMarker provides a function to get the symbol and size of the marker
+// by given decoded marker.
+func getChartMarker(marker *cMarker, opts *ChartMarker) {
+	if marker == nil {
+		return
+	}
+	if marker.Symbol != nil && marker.Symbol.Val != nil {
+		opts.Symbol = *marker.Symbol.Val
+	}
+	if marker.Size != nil && marker.Size.Val != nil {
+		opts.Size = *marker.Size.Val
+	}
+}
+
+// getChartSeriesData provides a function to get the formula and the cached
+// values by given decoded series data. The external reference index prefix of
+// the workbook defined name will be removed, so that the formula can be used
+// for creating chart.
+func getChartSeriesData(data *decodeChartSerData) (string, []string) {
+	if data == nil {
+		return "", nil
+	}
+	var (
+		formula string
+		ptCount *attrValInt
+		pts     []*cPt
+	)
+	if data.StrRef != nil {
+		if formula = data.StrRef.F; data.StrRef.StrCache != nil {
+			ptCount, pts = data.StrRef.StrCache.PtCount, data.StrRef.StrCache.Pt
+		}
+	}
+	if data.NumRef != nil {
+		if formula = data.NumRef.F; data.NumRef.NumCache != nil {
+			ptCount, pts = data.NumRef.NumCache.PtCount, data.NumRef.NumCache.Pt
+		}
+	}
+	formula = strings.TrimPrefix(formula, "[0]!")
+	count := len(pts)
+	if ptCount != nil && ptCount.Val != nil {
+		count = *ptCount.Val
+	}
+	var cache []string
+	if count > 0 {
+		cache = make([]string, count)
+	}
+	for _, pt := range pts {
+		if pt.IDx >= 0 && pt.IDx < count && pt.V != nil {
+			cache[pt.IDx] = *pt.V
+		}
+	}
+	return formula, cache
+}
+
+// getChartAxis provides a function to get the chart axis settings by given
+// decoded axis.
+func getChartAxis(ax *decodeChartAxis) ChartAxis {
+	var axis ChartAxis
+	if ax == nil {
+		return axis
+	}
+	if ax.XMLName.Local == "dateAx" {
+		axis.Type = "date"
+	}
+	axis.None = getAttrValBool(ax.Delete)
+	axis.MajorGridLines = ax.MajorGridlines != nil
+	axis.MinorGridLines = ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.MinorUnit != nil && ax.MinorUnit.Val != nil {
+		axis.MinorUnit = *ax.MinorUnit.Val
+	}
+	for _, unit := range []struct {
+		attr *attrValString
+		val  *string
+	}{
+		{attr: ax.BaseTimeUnit, val: &axis.BaseTimeUnit},
+		{attr: ax.MajorTimeUnit, val: &axis.MajorTimeUnit},
+		{attr: ax.MinorTimeUnit, val: &axis.MinorTimeUnit},
+	} {
+		if unit.attr != nil && unit.attr.Val != nil {
+			*unit.val = *unit.attr.Val
+		}
+	}
+	if ax.TickLblSkip != nil && ax.TickLblSkip.Val != nil {
+		axis.TickLabelSkip = *ax.TickLblSkip.Val
+	}
+	if ax.TickLblPos != nil && ax.TickLblPos.Val != nil {
+		for tickLblPos, val := range tickLblPosVal {
+			if val == *ax.TickLblPos.Val {
+				axis.TickLabelPosition = tickLblPos
+			}
+		}
+	}
+	if scaling := ax.Scaling; scaling != nil {
+		axis.ReverseOrder = scaling.Orientation != nil && scaling.Orientation.Val != nil && *scaling.Orientation.Val == orientation[true]
+		if scaling.Max != nil {
+			axis.Maximum = scaling.Max.Val
+		}
+		if scaling.Min != nil {
+			axis.Minimum = scaling.Min.Val
+		}
+		if scaling.LogBase != nil && scaling.LogBase.Val != nil {
+			axis.LogBase = *scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	if ax.Title != nil {
+		axis.Title, axis.TitleFormat = getChartTitle(ax.Title)
+	}
+	return axis
+}
+
+// getChartTitle provides a function to get the rich text runs and the format
+// settings of the title by given decoded chart title. The title which was set
+// by formula reference will be returned by its cached text.
+func getChartTitle(title *decodeChartTitle) ([]RichTextRun, ChartTitle) {
+	var (
+		runs   []RichTextRun
+		format = ChartTitle{Overlay: getAttrValBool(title.Overlay)}
+		bodyPr *decodeChartBodyPr
+	)
+	if title.Tx.Rich != nil {
+		runs, bodyPr = getChartRichTextRuns(title.Tx.Rich), title.Tx.Rich.BodyPr
+	} else if title.Tx.StrRef != nil {
+		if _, text := getChartSeriesData(&decodeChartSerData{StrRef: title.Tx.StrRef}); len(text) > 0 {
+			runs = []RichTextRun{{Text: text[0]}}
+		}
+		format.CellRef = strings.TrimPrefix(title.Tx.StrRef.F, "[0]!")
+		if txPr := title.TxPr; txPr != nil {
+			if bodyPr = txPr.BodyPr; len(txPr.P) > 0 && txPr.P[0].PPr != nil {
+				format.Font = getChartFont(txPr.P[0].PPr.DefRPr)
+			}
+		}
+	}
+	if bodyPr != nil && bodyPr.Rot != nil && *bodyPr.Rot != 0 {
+		format.Rotation = intPtr(*bodyPr.Rot / 60000)
+	}
+	return runs, format
+}
+
+// getChartRichTextRuns provides a function to get the rich text runs by given
+// decoded chart rich text.
+func getChartRichTextRuns(rich *decodeChartRich) []RichTextRun {
+	var runs []RichTextRun
+	for _, p := range rich.P {
+		for _, r := range p.R {
+			runs = append(runs, RichTextRun{Text: r.T, Font: getChartFont(r.RPr)})
+		}
+	}
+	return runs
+}
+
+// getChartFont provides a function to get the font settings by given decoded
+// chart text run properties.
+func getChartFont(rPr *decodeChartRPr) *Font {
+	if rPr == nil {
+		return nil
+	}
+	fnt := &Font{Bold: rPr.B, Italic: rPr.I, Size: rPr.Sz / 100, Strike: rPr.Strike == "sngStrike"}
+	if inStrSlice(supportedDrawingUnderlineTypes, rPr.U, true) != -1 {
+		fnt.Underline = rPr.U
+	}
+	if rPr.SolidFill != nil && rPr.SolidFill.SrgbClr != nil && rPr.SolidFill.SrgbClr.Val != nil {
+		fnt.Color = *rPr.SolidFill.SrgbClr.Val
+	}
+	if rPr.Latin != nil {
+		if fnt.Scheme = map[string]string{"+mj-lt": "major", "+mn-lt": "minor"}[rPr.Latin.Typeface]; fnt.Scheme == "" {
+			fnt.Family = rPr.Latin.Typeface
+		}
+	}
+	return fnt
+}
+
+// getChartExDefinition provides a function to get the chart definition from
+// the chart extension part of the chart info.
+func (f *File) getChartExDefinition(info *ChartInfo) error {
+	var chartSpace decodeChartExSpace
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(info.ChartPart)))).
+		Decode(&chartSpace); err != nil && err != io.EOF {
+		return err
+	}
+	opts := &info.Chart
+	if title := chartSpace.Chart.Title; title != nil && title.Tx.Rich != nil {
+		opts.Title = getChartRichTextRuns(title.Tx.Rich)
+	}
+	for _, ser := range chartSpace.Chart.PlotArea.PlotAreaRegion.Series {
+		if ser.LayoutID == "paretoLine" {
+			opts.Type = Pareto
+			continue
+		}
+		if chartType, ok := map[string]ChartType{
+			"boxWhisker": BoxWhisker, "waterfall": Waterfall, "funnel": Funnel, "clusteredColumn": Histogram,
+		}[ser.LayoutID]; ok && opts.Type != Pareto {
+			opts.Type = chartType
+		}
+		var series ChartSeries
+		if ser.Tx != nil && ser.Tx.TxData != nil {
+			series.Name = ser.Tx.TxData.F
+		}
+		for _, data := range chartSpace.ChartData.Data {
+			if ser.DataID == nil || ser.DataID.Val == nil || data.ID != *ser.DataID.Val {
+				continue
+			}
+			if data.StrDim != nil {
+				series.Categories = data.StrDim.F
+			}
+			if data.NumDim != nil {
+				series.Values = data.NumDim.F
+			}
+		}
+		opts.Series = append(opts.Series, series)
+		info.SeriesCache = append(info.SeriesCache, ChartSeriesCache{})
+	}
+	return nil
+}
+
+// getAttrValBool provides a function to get the boolean value of the element
+// by given attribute value. The omitted value of the existing element is true.
+func getAttrValBool(val *attrValBool) bool {
+	if val == nil {
+		return false
+	}
+	return val.Val == nil || *val.Val
+}
+
+// UpdateChart provides a function to update the series formulas, title and
+// axis bounds of an existing chart in place by given worksheet name, index of
+// the chart in the drawing order which is the same as the GetCharts function
+// returns, and the patch settings. The chart will be identified by the
+// ChartName of the patch instead of the index if it isn't empty. The cached
+// values of the replaced series formulas will be recomputed, and every other
+// element of the chart will be kept unchanged. For example, change the values
+// of the first series and the title of the first chart in the worksheet named
+// Sheet1, and set the maximum of the value axis:
+//
+//	maximum := 10.0
+//	err := f.UpdateChart("Sheet1", 0, excelize.ChartPatch{
+//	    Series: []excelize.ChartSeries{
+//	        {Values: "Sheet1!$B$2:$D$2"},
+//	    },
+//	    Title: []excelize.RichTextRun{{Text: "Updated Chart"}},
+//	    YAxis: excelize.ChartAxis{Maximum: &maximum},
+//	})
+//
+// This function doesn't support updating the chart which types are Funnel,
+// Histogram, Pareto, Treemap, Sunburst, BoxWhisker and Waterfall.
+func (f *File) UpdateChart(sheet string, chartIndex int, patch ChartPatch) error {
+	charts, err := f.GetCharts(sheet)
+	if err != nil {
+		return err
+	}
+	var info *ChartInfo
+	for idx := range charts {
+		if (patch.ChartName == "" && idx == chartIndex) ||
+			(patch.ChartName != "" && charts[idx].Name == patch.ChartName) {
+			info = &charts[idx]
+			break
+		}
+	}
+	if info == nil {
+		if patch.ChartName != "" {
+			return newNoExistChartError(sheet, patch.ChartName)
+		}
+		return newNoExistChartError(sheet, strconv.Itoa(chartIndex))
+	}
+	if _, ok := chartExLayoutIDs[info.Chart.Type]; ok {
+		return newUnsupportedChartType(info.Chart.Type)
+	}
+	for _, title := range patch.Title {
+		if title.Font == nil {
+			continue
+		}
+		if err = title.Font.validate(); err != nil {
+			return err
+		}
+	}
+	content, err := f.patchChartPart(f.readXML(info.ChartPart), &patch)
+	if err != nil {
+		return err
+	}
+	f.Pkg.Store(info.ChartPart, content)
+	return err
+}
+
+// patchChartPart provides a function to apply the patch settings on the
+// content of the chart part.
+func (f *File) patchChartPart(content []byte, patch *ChartPatch) ([]byte, error) {
+	elements, err := f.getChartPartElements(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(patch.Series) > len(elements.series) {
+		return nil, ErrParameterInvalid
+	}
+	var edits []chartPartEdit
+	for i := range patch.Series {
+		serEdits, err := f.patchChartPartSeries(elements, elements.series[i], &patch.Series[i])
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, serEdits...)
+	}
+	if len(patch.Title) > 0 {
+		opts := Chart{Title: append([]RichTextRun{}, patch.Title...)}
+		opts.parseTitle()
+		edits = append(edits, elements.edit("title", elements.title, elements.chartStart, f.drawPlotAreaTitles(opts.Title, nil, "")))
+		if elements.autoTitleDeleted != nil {
+			edits = append(edits, chartPartEdit{
+				start: elements.autoTitleDeleted[0], end: elements.autoTitleDeleted[1],
+				content: []byte(fmt.Sprintf(`<%sautoTitleDeleted val="0"/>`, elements.prefix)),
+			})
+		}
+	}
+	axisEdits, err := elements.patchAxes(patch)
+	if err != nil {
+		return nil, err
+	}
+	edits = append(edits, axisEdits...)
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var buf bytes.Buffer
+	offset := 0
+	for _, edit := range edits {
+		buf.Write(content[offset:edit.start])
+		buf.Write(edit.content)
+		offset = edit.end
+	}
+	buf.Write(content[offset:])
+	return buf.Bytes(), err
+}
+
+// patchChartPartSeries provides a function to get the edits of the chart part
+// to replace the name, categories and values of the chart series.
+func (f *File) patchChartPartSeries(elements *chartPartElements, ser *chartPartSeries, opts *ChartSeries) ([]chartPartEdit, error) {
+	var edits []chartPartEdit
+	if opts.Name != "" {
+		ref, err := f.getChartSeriesCacheRef(opts.Name)
+		if err != nil {
+			return edits, err
+		}
+		pos := ser.start
+		if ser.order != nil {
+			pos = ser.order[1]
+		}
+		edits = append(edits, elements.edit("tx", ser.tx, pos, &cTx{StrRef: f.drawChartSeriesStrRef(opts.Name, ref)}))
+	}
+	if opts.Categories != "" {
+		ref, err := f.getChartSeriesCacheRef(opts.Categories)
+		if err != nil {
+			return edits, err
+		}
+		name, pos, cat := ser.catName, ser.end, &cCat{StrRef: f.drawChartSeriesStrRef(opts.Categories, ref)}
+		if ser.catNum {
+			cat = &cCat{NumRef: f.drawChartSeriesNumRef(opts.Categories, ref)}
+		}
+		if name == "" {
+			if name = "cat"; ser.valName == "yVal" {
+				name = "xVal"
+			}
+		}
+		if ser.val != nil {
+			pos = ser.val[0]
+		}
+		edits = append(edits, elements.edit(name, ser.cat, pos, cat))
+	}
+	if opts.Values != "" {
+		ref, err := f.getChartSeriesCacheRef(opts.Values)
+		if err != nil {
+			return edits, err
+		}
+		name, pos := ser.valName, ser.end
+		if name == "" {
+			name = "val"
+		}
+		if ser.cat != nil {
+			pos = ser.cat[1]
+		}
+		edits = append(edits, elements.edit(name, ser.val, pos, &cVal{NumRef: f.drawChartSeriesNumRef(opts.Values, ref)}))
+	}
+	return edits, nil
+}
+
+// getChartSeriesCacheRef provides a function to get the formula and the
+// cached values of the chart series data by given cell range reference,
+// defined name or structured reference.
+func (f *File) getChartSeriesCacheRef(ref string) (*chartSeriesRef, error) {
+	seriesRef, err := f.getChartSeriesRef(ref)
+	if err != nil || seriesRef != nil {
+		return seriesRef, err
+	}
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		return nil, ErrParameterInvalid
+	}
+	sheet := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(ref[:idx], "'"), "'"), "''", "'")
+	cache, err := f.getChartRangeValues(sheet, ref[idx+1:])
+	return &chartSeriesRef{formula: ref, cache: cache}, err
+}
+
+// patchAxes provides a function to get the edits of the chart part to replace
+// the maximum and minimum of the axes scaling.
+func (elements *chartPartElements) patchAxes(patch *ChartPatch) ([]chartPartEdit, error) {
+	var edits []chartPartEdit
+	var primary, secondary []int
+	for _, axIDs := range elements.groupAxIDs {
+		if len(axIDs) < 2 {
+			continue
+		}
+		if primary == nil {
+			primary = axIDs
+			continue
+		}
+		if secondary == nil && axIDs[1] != primary[1] {
+			secondary = axIDs
+		}
+	}
+	for _, axis := range []struct {
+		opts  ChartAxis
+		axIDs []int
+		idx   int
+	}{
+		{opts: patch.XAxis, axIDs: primary, idx: 0},
+		{opts: patch.YAxis, axIDs: primary, idx: 1},
+		{opts: patch.YAxis2, axIDs: secondary, idx: 1},
+	} {
+		if axis.opts.Maximum == nil && axis.opts.Minimum == nil {
+			continue
+		}
+		if axis.axIDs == nil {
+			return edits, ErrParameterInvalid
+		}
+		scaling, ok := elements.scalings[axis.axIDs[axis.idx]]
+		if !ok {
+			return edits, ErrParameterInvalid
+		}
+		pos := scaling.start
+		for _, rng := range [][]int{scaling.logBase, scaling.orientation} {
+			if rng != nil {
+				pos = rng[1]
+			}
+		}
+		for _, bound := range []struct {
+			name string
+			val  *float64
+			rng  []int
+		}{
+			{name: "max", val: axis.opts.Maximum, rng: scaling.max},
+			{name: "min", val: axis.opts.Minimum, rng: scaling.min},
+		} {
+			if bound.rng != nil {
+				pos = bound.rng[1]
+			}
+			if bound.val == nil {
+				continue
+			}
+			edit := chartPartEdit{start: pos, end: pos, content: []byte(fmt.Sprintf(`<%s%s val="%s"/>`,
+				elements.prefix, bound.name, strconv.FormatFloat(*bound.val, 'f', -1, 64)))}
+			if bound.rng != nil {
+				edit.start, edit.end = bound.rng[0], bound.rng[1]
+			}
+			edits = append(edits, edit)
+		}
+	}
+	return edits, nil
+}
+
+// edit provides a function to get the edit of the chart part which replaces
+// the element in the given range, or inserts the element at the given
+// position if the range is nil.
+func (elements *chartPartElements) edit(name string, rng []int, pos int, v interface{}) chartPartEdit {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if elements.prefix != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: elements.namespace})
+	}
+	if name == "title" && elements.namespaceA == "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:a"}, Value: NameSpaceDrawingML.Value})
+	}
+	var buf bytes.Buffer
+	_ = xml.NewEncoder(&buf).EncodeElement(v, start)
+	edit := chartPartEdit{start: pos, end: pos, content: buf.Bytes()}
+	if rng != nil {
+		edit.start, edit.end = rng[0], rng[1]
+	}
+	return edit
+}
+
+// getChartPartElements provides a function to get the positions of the
+// elements in the chart part which can be updated by the UpdateChart function.
+func (f *File) getChartPartElements(content []byte) (*chartPartElements, error) {
+	var (
+		elements = &chartPartElements{scalings: make(map[int]*chartPartScaling)}
+		dec      = f.xmlNewDecoder(bytes.NewReader(content))
+		names    []string
+		starts   []int
+		ser      *chartPartSeries
+		scaling  *chartPartScaling
+		axID     int
+	)
+	for {
+		offset := int(dec.InputOffset())
+		token, err := dec.RawToken()
+		if err == io.EOF {
+			return elements, nil
+		}
+		if err != nil {
+			return elements, err
+		}
+		end, depth := int(dec.InputOffset()), len(names)
+		switch element := token.(type) {
+		case xml.StartElement:
+			name, inPlotArea := element.Name.Local, depth > 3 && names[2] == "plotArea"
+			switch {
+			case depth == 0:
+				elements.prefix = element.Name.Space
+				for _, attr := range element.Attr {
+					if (attr.Name.Space == "xmlns" && attr.Name.Local == elements.prefix) ||
+						(attr.Name.Space == "" && attr.Name.Local == "xmlns" && elements.prefix == "") {
+						elements.namespace = attr.Value
+					}
+					if attr.Name.Space == "xmlns" && attr.Name.Local == "a" {
+						elements.namespaceA = attr.Value
+					}
+				}
+				if elements.prefix != "" {
+					elements.prefix += ":"
+				}
+			case depth == 1 && name == "chart":
+				elements.chartStart = end
+			case depth == 3 && names[2] == "plotArea" && strings.HasSuffix(name, "Chart"):
+				elements.groupAxIDs = append(elements.groupAxIDs, nil)
+			case depth == 3 && names[2] == "plotArea" && strings.HasSuffix(name, "Ax"):
+				axID, scaling = 0, nil
+			case depth == 4 && inPlotArea && strings.HasSuffix(names[3], "Chart") && name == "ser":
+				ser = &chartPartSeries{start: end}
+				elements.series = append(elements.series, ser)
+			case depth == 4 && inPlotArea && name == "axId":
+				for _, attr := range element.Attr {
+					if attr.Name.Local != "val" {
+						continue
+					}
+					id, _ := strconv.Atoi(attr.Value)
+					if strings.HasSuffix(names[3], "Chart") {
+						last := len(elements.groupAxIDs) - 1
+						elements.groupAxIDs[last] = append(elements.groupAxIDs[last], id)
+					}
+					if strings.HasSuffix(names[3], "Ax") {
+						axID = id
+					}
+				}
+			case depth == 4 && inPlotArea && strings.HasSuffix(names[3], "Ax") && name == "scaling":
+				scaling = &chartPartScaling{start: end}
+			case depth == 6 && inPlotArea && names[4] == "ser" && ser != nil &&
+				(names[5] == "cat" || names[5] == "xVal") && name == "numRef":
+				ser.catNum = true
+			}
+			names, starts = append(names, name), append(starts, offset)
+		case xml.EndElement:
+			if depth == 0 {
+				continue
+			}
+			name, rng := names[depth-1], []int{starts[depth-1], end}
+			names, starts, depth = names[:depth-1], starts[:depth-1], depth-1
+			inPlotArea := depth > 3 && names[2] == "plotArea"
+			switch {
+			case depth == 2 && names[1] == "chart" && name == "title":
+				elements.title = rng
+			case depth == 2 && names[1] == "chart" && name == "autoTitleDeleted":
+				elements.autoTitleDeleted = rng
+			case depth == 3 && names[2] == "plotArea" && strings.HasSuffix(name, "Ax") && scaling != nil:
+				elements.scalings[axID] = scaling
+			case depth == 4 && inPlotArea && name == "ser" && ser != nil:
+				ser.end, ser = offset, nil
+			case depth == 5 && inPlotArea && names[4] == "ser" && ser != nil:
+				switch name {
+				case "order":
+					ser.order = rng
+				case "tx":
+					ser.tx = rng
+				case "cat", "xVal":
+					ser.cat, ser.catName = rng, name
+				case "val", "yVal":
+					ser.val, ser.valName = rng, name
+				}
+			case depth == 5 && inPlotArea && names[4] == "scaling" && scaling != nil:
+				switch name {
+				case "logBase":
+					scaling.logBase = rng
+				case "orientation":
+					scaling.orientation = rng
+				case "max":
+					scaling.max = rng
+				case "min":
+					scaling.min = rng
+				}
+			}
+		}
+	}
+}
+
 // countCharts provides a function to get chart files count storage in the
 // folder xl/charts.
 func (f *File) countCharts() int {
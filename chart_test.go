@@ -5,7 +5,9 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -113,7 +115,7 @@ func TestAddSheetDrawingChart(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/drawing1.xml"
 	f.Pkg.Store(path, MacintoshCyrillicCharset)
-	assert.EqualError(t, f.addSheetDrawingChart(path, 0, &GraphicOptions{PrintObject: boolPtr(true), Locked: boolPtr(false)}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.addSheetDrawingChart(path, 0, 0, 0, &GraphicOptions{PrintObject: boolPtr(true), Locked: boolPtr(false)}), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestDeleteDrawing(t *testing.T) {
@@ -303,7 +305,7 @@ func TestAddChart(t *testing.T) {
 	// Test with illegal cell reference
 	assert.EqualError(t, f.AddChart("Sheet2", "A", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "2D Column Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x37, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bubble 3D Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0xff, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "Bubble 3D Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0xff).Error())
 	// Test add combo chart with invalid format set
 	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: []RichTextRun{{Text: "2D Column Chart"}}, PlotArea: plotArea, ShowBlanksAs: "zero"}, nil), ErrParameterInvalid.Error())
 	// Test add combo chart with unsupported chart type
@@ -352,6 +354,45 @@ func TestAddChartSheet(t *testing.T) {
 	// Test with unsupported chart type
 	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x37, Series: series, Title: []RichTextRun{{Text: "Fruit 3D Clustered Column Chart"}}}), newUnsupportedChartType(0x37).Error())
 
+	// Test add chartsheet with page setup, header footer and tab order index
+	assert.NoError(t, f.AddChartSheet("Chart2", &Chart{Type: Col, Series: series, ChartSheet: &ChartSheetOptions{
+		Index:        intPtr(0),
+		ZoomScale:    float64Ptr(80),
+		PageLayout:   &PageLayoutOptions{Size: intPtr(9), Orientation: stringPtr("portrait"), FirstPageNumber: uintPtr(2), BlackAndWhite: boolPtr(true)},
+		HeaderFooter: &HeaderFooterOptions{OddFooter: "&C&P"},
+	}}))
+	assert.Equal(t, []string{"Chart2", "Sheet1", "Chart1"}, f.GetSheetList())
+	content, ok := f.Pkg.Load("xl/chartsheets/sheet3.xml")
+	assert.True(t, ok)
+	var cs xlsxChartsheet
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &cs))
+	assert.Equal(t, uint32(80), cs.SheetViews.SheetView[0].ZoomScaleAttr)
+	assert.False(t, cs.SheetViews.SheetView[0].ZoomToFitAttr)
+	assert.Equal(t, 9, *cs.PageSetup.PaperSize)
+	assert.Equal(t, "portrait", cs.PageSetup.Orientation)
+	assert.Equal(t, "2", cs.PageSetup.FirstPageNumber)
+	assert.True(t, cs.PageSetup.BlackAndWhite)
+	assert.Equal(t, "&C&P", cs.HeaderFooter.OddFooter)
+	// Test the chart fill the printable area of the chartsheet
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &aExt{Cx: 6281928, Cy: 9317736}, drawing.(*xlsxWsDr).AbsoluteAnchor[0].Ext)
+	drawing, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &aExt{Cx: 8778240, Cy: 6400800}, drawing.(*xlsxWsDr).AbsoluteAnchor[0].Ext)
+	// Test add chartsheet with invalid chartsheet options
+	for _, opts := range []*ChartSheetOptions{
+		{Index: intPtr(-1)},
+		{Index: intPtr(4)},
+	} {
+		assert.Equal(t, ErrSheetIdx, f.AddChartSheet("Chart3", &Chart{Type: Col, Series: series, ChartSheet: opts}))
+	}
+	assert.Equal(t, newInvalidPageLayoutValueError("Orientation", "x", strings.Join(supportedPageOrientation, ", ")),
+		f.AddChartSheet("Chart3", &Chart{Type: Col, Series: series, ChartSheet: &ChartSheetOptions{PageLayout: &PageLayoutOptions{Orientation: stringPtr("x")}}}))
+	assert.Equal(t, newFieldLengthError("OddHeader"),
+		f.AddChartSheet("Chart3", &Chart{Type: Col, Series: series, ChartSheet: &ChartSheetOptions{HeaderFooter: &HeaderFooterOptions{OddHeader: strings.Repeat("c", MaxFieldLength+1)}}}))
+	assert.Equal(t, 3, f.SheetCount)
+
 	assert.NoError(t, f.UpdateLinkedValue())
 
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSheet.xlsx")))
@@ -362,6 +403,60 @@ func TestAddChartSheet(t *testing.T) {
 	assert.EqualError(t, f.AddChartSheet("Chart4", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}, Title: []RichTextRun{{Text: "2D Column Chart"}}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestMoveChartToChartSheet(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.MoveChartToChartSheet("Sheet1", 1, "Chart1"))
+	assert.Equal(t, []string{"Chart1", "Sheet1"}, f.GetSheetList())
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, Col, charts[0].Chart.Type)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing2.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, "../charts/chart2.xml", rels.Relationships[0].Target)
+	rels, err = f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestMoveChartToChartSheet.xlsx")))
+	// Test move chart with not exist chart index
+	assert.Equal(t, newNoExistChartError("Sheet1", "1"), f.MoveChartToChartSheet("Sheet1", 1, "Chart2"))
+	// Test move chart with exists sheet name
+	assert.Equal(t, ErrExistsSheet, f.MoveChartToChartSheet("Sheet1", 0, "Chart1"))
+	// Test move chart with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.MoveChartToChartSheet("Sheet1", 0, "Chart:2"))
+	// Test move chart on not exist worksheet
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.MoveChartToChartSheet("SheetN", 0, "Chart2"))
+	// Test move chart with unsupported chart type
+	assert.NoError(t, f.AddChart("Sheet1", "E32", &Chart{Type: Funnel, Series: series[:1]}))
+	assert.Equal(t, newUnsupportedChartType(Funnel), f.MoveChartToChartSheet("Sheet1", 1, "Chart2"))
+	assert.NoError(t, f.Close())
+
+	// Test move chart from the opened workbook
+	f, err = OpenFile(filepath.Join("test", "TestMoveChartToChartSheet.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.MoveChartToChartSheet("Sheet1", 0, "Chart2"))
+	assert.Equal(t, []string{"Chart1", "Chart2", "Sheet1"}, f.GetSheetList())
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	// Test move chart with unsupported charset drawing
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.MoveChartToChartSheet("Sheet1", 0, "Chart3"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteChart(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -509,3 +604,1262 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestChartFontExtensions(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "A4", &Chart{
+		Type:   Col,
+		Series: series,
+		Title:  []RichTextRun{{Text: "m"}, {Text: "2", Font: &Font{VertAlign: "superscript"}}},
+		XAxis:  ChartAxis{Font: Font{Scheme: "major"}},
+		YAxis:  ChartAxis{Font: Font{VertAlign: "subscript", Scheme: "minor", Family: "Arial"}},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	for _, expected := range []string{`baseline="30000"`, `baseline="-25000"`, `<a:latin typeface="+mj-lt"></a:latin>`, `<a:latin typeface="Arial"></a:latin>`} {
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	// Test add chart with invalid font vertical alignment and scheme
+	assert.Equal(t, ErrFontVertAlign, f.AddChart("Sheet1", "A4", &Chart{Type: Col, Series: series, XAxis: ChartAxis{Font: Font{VertAlign: "top"}}}))
+	assert.Equal(t, ErrFontScheme, f.AddChart("Sheet1", "A4", &Chart{Type: Col, Series: series, Title: []RichTextRun{{Text: "x", Font: &Font{Scheme: "body"}}}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartBoxWhisker(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Course", "School A", "School B"}, {"English", 63, 53}, {"Physics", 61, 55},
+		{"English", 62, 51}, {"Math", 77, 89}, {"Physics", 58, 94}, {"Math", 60, 51},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "A4", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$7", Values: "Sheet1!$B$2:$B$7"}}}))
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: BoxWhisker,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$7", Values: "Sheet1!$B$2:$B$7"},
+			{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$7", Values: "Sheet1!$C$2:$C$7", Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}},
+		},
+		Title:      []RichTextRun{{Text: "Test Scores"}},
+		Legend:     ChartLegend{Position: "top_right"},
+		YAxis:      ChartAxis{MajorGridLines: true, Maximum: float64Ptr(100), Minimum: float64Ptr(40)},
+		BoxWhisker: ChartBoxWhisker{ShowOutlierPoints: true, ShowMeanMarkers: true, QuartileMethod: "inclusive"},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chartEx2.xml")
+	assert.True(t, ok)
+	for _, expected := range []string{
+		`<cx:strDim type="cat"><cx:f>Sheet1!$A$2:$A$7</cx:f></cx:strDim><cx:numDim type="val"><cx:f>Sheet1!$B$2:$B$7</cx:f></cx:numDim>`,
+		`<cx:series layoutId="boxWhisker" formatIdx="1"><cx:tx><cx:txData><cx:f>Sheet1!$C$1</cx:f></cx:txData></cx:tx>`,
+		`<cx:visibility meanLine="false" meanMarker="true" nonoutliers="false" outliers="true"></cx:visibility><cx:statistics quartileMethod="inclusive"></cx:statistics>`,
+		`<cx:valScaling max="100" min="40"></cx:valScaling><cx:majorGridlines></cx:majorGridlines>`,
+		`<cx:legend pos="r" align="min" overlay="false"></cx:legend>`,
+	} {
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Len(t, drawing.(*xlsxWsDr).TwoCellAnchor, 2)
+	assert.Contains(t, drawing.(*xlsxWsDr).TwoCellAnchor[1].AlternateContent[0].Content, `<cx:chart xmlns:cx="http://schemas.microsoft.com/office/drawing/2014/chartex" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId2"></cx:chart>`)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, SourceRelationshipChartEx, rels.Relationships[1].Type)
+	assert.Equal(t, "../charts/chartEx2.xml", rels.Relationships[1].Target)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, contentTypes.Overrides, xlsxOverride{PartName: "/xl/charts/chartEx2.xml", ContentType: ContentTypeChartEx})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartBoxWhisker.xlsx")))
+	// Test add box and whisker chart with invalid quartile method
+	assert.Equal(t, ErrChartQuartileMethod, f.AddChart("Sheet1", "E1", &Chart{Type: BoxWhisker, BoxWhisker: ChartBoxWhisker{QuartileMethod: "median"}}))
+	// Test add box and whisker chart with combo chart
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E1", &Chart{Type: BoxWhisker}, &Chart{Type: Col}))
+	// Test add box and whisker chart on chartsheet
+	assert.EqualError(t, f.AddChartSheet("Chart1", &Chart{Type: BoxWhisker}), newUnsupportedChartType(BoxWhisker).Error())
+	// Test add box and whisker chart with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddChart("Sheet1", "A", &Chart{Type: BoxWhisker}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartWaterfall(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Revenue", 420}, {"Cost of sales", -180}, {"Gross profit", 240},
+		{"Operating expenses", -95}, {"Other income", 15}, {"EBITDA", 160},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	chart := &Chart{
+		Type:     Waterfall,
+		Series:   []ChartSeries{{Categories: "Sheet1!$A$1:$A$6", Values: "Sheet1!$B$1:$B$6"}},
+		Legend:   ChartLegend{Position: "none"},
+		PlotArea: ChartPlotArea{ShowVal: true},
+		Waterfall: ChartWaterfall{
+			Totals:             []int{5, 0, 2, 2},
+			ShowConnectorLines: true,
+			IncreaseFill:       Fill{Type: "pattern", Pattern: 1, Color: []string{"70AD47"}},
+			DecreaseFill:       Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}},
+			TotalFill:          Fill{Type: "pattern", Pattern: 1, Color: []string{"4472C4"}},
+		},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", chart))
+	content, ok := f.Pkg.Load("xl/charts/chartEx1.xml")
+	assert.True(t, ok)
+	for _, expected := range []string{
+		`<cx:series layoutId="waterfall" formatIdx="0">`,
+		`<cx:dataPt idx="0"><cx:spPr><a:solidFill><a:srgbClr val="4472C4"></a:srgbClr></a:solidFill></cx:spPr></cx:dataPt>`,
+		`<cx:dataPt idx="1"><cx:spPr><a:solidFill><a:srgbClr val="FF0000"></a:srgbClr></a:solidFill></cx:spPr></cx:dataPt>`,
+		`<cx:dataPt idx="4"><cx:spPr><a:solidFill><a:srgbClr val="70AD47"></a:srgbClr></a:solidFill></cx:spPr></cx:dataPt>`,
+		`<cx:dataLabels pos="outEnd"><cx:visibility seriesName="false" categoryName="false" value="true"></cx:visibility></cx:dataLabels>`,
+		`<cx:layoutPr><cx:visibility connectorLines="true"></cx:visibility><cx:subtotals><cx:idx val="0"></cx:idx><cx:idx val="2"></cx:idx><cx:idx val="5"></cx:idx></cx:subtotals></cx:layoutPr>`,
+	} {
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	assert.NotContains(t, string(content.([]byte)), "<cx:legend")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartWaterfall.xlsx")))
+	// Test add waterfall chart with only total fill
+	assert.NoError(t, f.AddChart("Sheet1", "D20", &Chart{
+		Type:      Waterfall,
+		Series:    []ChartSeries{{Values: "'Sheet1'!$B$1"}},
+		Waterfall: ChartWaterfall{Totals: []int{1}, TotalFill: Fill{Type: "pattern", Pattern: 1, Color: []string{"4472C4"}}},
+	}))
+	content, ok = f.Pkg.Load("xl/charts/chartEx2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<cx:dataPt idx="1">`)
+	assert.NotContains(t, string(content.([]byte)), `<cx:dataPt idx="0">`)
+	values, err := f.getChartRangeNumbers("'Sheet1'!$B$2")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{-180}, values)
+	// Test add waterfall chart with invalid totals
+	chart.Waterfall.Totals = []int{-1}
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "D1", chart))
+	// Test add waterfall chart with invalid series values reference
+	chart.Waterfall.Totals = nil
+	for _, ref := range []string{"$B$1:$B$6", "Sheet1!B:B6"} {
+		chart.Series[0].Values = ref
+		assert.Error(t, f.AddChart("Sheet1", "D1", chart))
+	}
+	chart.Series[0].Values = "SheetN!$B$1:$B$6"
+	assert.EqualError(t, f.AddChart("Sheet1", "D1", chart), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartFunnel(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Leads", 1200}, {"Qualified", 800}, {"Proposals", 300}, {"Closed", 120},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1:$A$4", Values: "Sheet1!$B$1:$B$4"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Funnel, Series: series, PlotArea: ChartPlotArea{ShowVal: true}}))
+	assert.NoError(t, f.AddChart("Sheet1", "D20", &Chart{Type: Funnel, Series: series, Funnel: ChartFunnel{GapWidth: uintPtr(150)}}))
+	for part, expected := range map[string][]string{
+		"xl/charts/chartEx1.xml": {
+			`<cx:series layoutId="funnel" formatIdx="0">`,
+			`<cx:dataLabels><cx:visibility seriesName="false" categoryName="false" value="true"></cx:visibility></cx:dataLabels>`,
+			`<cx:axis id="0"><cx:catScaling gapWidth="0.06"></cx:catScaling><cx:tickLabels></cx:tickLabels></cx:axis></cx:plotArea>`,
+		},
+		"xl/charts/chartEx2.xml": {`<cx:catScaling gapWidth="1.5"></cx:catScaling>`},
+	} {
+		content, ok := f.Pkg.Load(part)
+		assert.True(t, ok)
+		for _, str := range expected {
+			assert.Contains(t, string(content.([]byte)), str)
+		}
+		assert.NotContains(t, string(content.([]byte)), "<cx:layoutPr>")
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartFunnel.xlsx")))
+	// Test add funnel chart with invalid series and gap width
+	assert.Equal(t, ErrChartFunnelSeries, f.AddChart("Sheet1", "D1", &Chart{Type: Funnel}))
+	assert.Equal(t, ErrChartFunnelSeries, f.AddChart("Sheet1", "D1", &Chart{Type: Funnel, Series: append(series, series...)}))
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "D1", &Chart{Type: Funnel, Series: series, Funnel: ChartFunnel{GapWidth: uintPtr(501)}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartSeriesType(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3},
+		{"Normal", 5, 2, 4}, {"Large", 6, 7, 8}, {"Growth", 0.1, 0.2, 0.15},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Type: Col},
+		{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4", Type: Col},
+		{Name: "Sheet1!$A$5", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$5:$D$5", Type: Line, SecondaryAxis: true},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{Type: Area, Series: series, Title: []RichTextRun{{Text: "Area - Column - Line Chart"}}}))
+	// Test add chart without the series of the chart type
+	assert.NoError(t, f.AddChart("Sheet1", "F20", &Chart{Type: Bar, Series: series[1:]}))
+	// Test add chart with the series type and the combo chart
+	assert.NoError(t, f.AddChart("Sheet1", "F40", &Chart{Type: Col, Series: series[2:]}, &Chart{Type: Area, Series: series[:1]}))
+	for part, expected := range map[string][]string{
+		"xl/charts/chart1.xml": {
+			`<areaChart><grouping val="standard"></grouping><varyColors val="1"></varyColors><ser><idx val="0"></idx><order val="0"></order><tx><strRef><f>Sheet1!$A$2</f>`,
+			`<barChart><barDir val="col"></barDir><grouping val="clustered"></grouping><varyColors val="1"></varyColors><ser><idx val="1"></idx><order val="1"></order>`,
+			`<ser><idx val="2"></idx><order val="2"></order><tx><strRef><f>Sheet1!$A$4</f>`,
+			`<lineChart><grouping val="standard"></grouping><varyColors val="0"></varyColors><ser><idx val="3"></idx><order val="3"></order><tx><strRef><f>Sheet1!$A$5</f>`,
+			`<axId val="100000003"></axId><axId val="100000004"></axId></lineChart>`,
+			`<valAx><axId val="100000004"></axId><scaling><orientation val="minMax"></orientation></scaling><delete val="0"></delete><axPos val="r"></axPos>`,
+		},
+		"xl/charts/chart2.xml": {
+			`<barChart><barDir val="col"></barDir>`,
+			`<lineChart>`,
+		},
+		"xl/charts/chart3.xml": {
+			`<areaChart>`,
+			`<lineChart>`,
+		},
+	} {
+		content, ok := f.Pkg.Load(part)
+		assert.True(t, ok)
+		for _, str := range expected {
+			assert.Contains(t, string(content.([]byte)), str)
+		}
+	}
+	content, ok := f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(content.([]byte)), `<barDir val="bar"></barDir>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSeriesType.xlsx")))
+	// Test add chart with unsupported series type
+	assert.Equal(t, newUnsupportedChartType(Funnel), f.AddChart("Sheet1", "F60", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$2:$D$2", Type: Funnel}}}))
+	// Test add chart extension with the series type
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "F60", &Chart{Type: BoxWhisker, Series: []ChartSeries{{Values: "Sheet1!$B$2:$D$2", Type: Line}}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartSecondaryAxis(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Q1", "Q2", "Q3", "Q4"}, {"Revenue", 120, 150, 90, 180}, {"Margin", 0.25, 0.3, 0.18, 0.35},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	maxVal, maxRatio, minRatio := 200.0, 0.5, 0.0
+	assert.NoError(t, f.AddChart("Sheet1", "G1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$E$1", Values: "Sheet1!$B$2:$E$2"},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$E$1", Values: "Sheet1!$B$3:$E$3", Type: Line, SecondaryAxis: true},
+		},
+		YAxis:  ChartAxis{Maximum: &maxVal, Title: []RichTextRun{{Text: "Revenue"}}},
+		YAxis2: ChartAxis{Maximum: &maxRatio, Minimum: &minRatio, NumFmt: ChartNumFmt{CustomNumFmt: "0%"}, Title: []RichTextRun{{Text: "Margin"}}},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Len(t, plotArea.BarChart, 1)
+	assert.Len(t, plotArea.LineChart, 1)
+	assert.Equal(t, 100000003, *plotArea.LineChart[0].AxID[0].Val)
+	assert.Equal(t, 100000004, *plotArea.LineChart[0].AxID[1].Val)
+	assert.Len(t, plotArea.CatAx, 2)
+	assert.Len(t, plotArea.ValAx, 2)
+	primary, secondary := plotArea.ValAx[0], plotArea.ValAx[1]
+	assert.Equal(t, 100000001, *primary.AxID.Val)
+	assert.Equal(t, maxVal, *primary.Scaling.Max.Val)
+	assert.Nil(t, primary.Scaling.Min)
+	assert.NotNil(t, primary.Title)
+	assert.Equal(t, 100000004, *secondary.AxID.Val)
+	assert.Equal(t, 100000003, *secondary.CrossAx.Val)
+	assert.Equal(t, "max", *secondary.Crosses.Val)
+	assert.Equal(t, "r", *secondary.AxPos.Val)
+	assert.Equal(t, maxRatio, *secondary.Scaling.Max.Val)
+	assert.Equal(t, minRatio, *secondary.Scaling.Min.Val)
+	assert.Equal(t, "0%", secondary.NumFmt.FormatCode)
+	assert.NotNil(t, secondary.Title)
+	valAx := string(content.([]byte))
+	valAx = valAx[strings.Index(valAx, `<valAx><axId val="100000001">`):]
+	assert.Contains(t, valAx[:strings.Index(valAx, `<valAx><axId val="100000004">`)], "<a:t>Revenue</a:t>")
+	assert.Contains(t, valAx[strings.Index(valAx, `<valAx><axId val="100000004">`):], "<a:t>Margin</a:t>")
+	assert.Equal(t, 100000003, *plotArea.CatAx[1].AxID.Val)
+	assert.Equal(t, 100000004, *plotArea.CatAx[1].CrossAx.Val)
+	assert.True(t, *plotArea.CatAx[1].Delete.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartSecondaryAxis.xlsx")))
+	// Test add chart with invalid secondary axis font
+	assert.Equal(t, ErrFontScheme, f.AddChart("Sheet1", "G20", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Values: "Sheet1!$B$2:$E$2"}},
+		YAxis2: ChartAxis{Font: Font{Scheme: "unknown"}},
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartTrendline(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"X", "Y"}, {1, 2.1}, {2, 3.9}, {3, 6.2}, {4, 7.8}, {5, 10.1}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	intercept := 0.0
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{
+		Type: Scatter,
+		Series: []ChartSeries{
+			{
+				Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6",
+				Trendline: ChartTrendline{
+					Type: ChartTrendlineLinear, Name: "Trend", Forward: 1, Intercept: &intercept, DisplayEquation: true,
+					Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, Line: ChartLine{Width: 1.5},
+				},
+			},
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6"},
+		},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.ScatterChart[0].Ser
+	assert.Nil(t, ser[1].Trendline)
+	trendline := ser[0].Trendline
+	assert.NotNil(t, trendline)
+	assert.Equal(t, "Trend", trendline.Name)
+	assert.Equal(t, "linear", *trendline.TrendlineType.Val)
+	assert.Nil(t, trendline.Order)
+	assert.Equal(t, 1.0, *trendline.Forward.Val)
+	assert.Nil(t, trendline.Backward)
+	assert.Equal(t, intercept, *trendline.Intercept.Val)
+	assert.True(t, *trendline.DispEq.Val)
+	assert.False(t, *trendline.DispRSqr.Val)
+	assert.NotNil(t, trendline.TrendlineLbl)
+	assert.Contains(t, string(content.([]byte)), `<trendline><name>Trend</name><spPr><a:ln cap="rnd" w="19050"><a:solidFill><a:srgbClr val="FF0000"></a:srgbClr></a:solidFill></a:ln></spPr>`)
+	assert.True(t, strings.Index(string(content.([]byte)), "<trendline>") < strings.Index(string(content.([]byte)), "<xVal>"))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTrendline.xlsx")))
+
+	// Test add chart with polynomial and moving average trendline default values
+	chart := &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6", Trendline: ChartTrendline{Type: ChartTrendlinePolynomial, DisplayRSquared: true}},
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6", Trendline: ChartTrendline{Type: ChartTrendlineMovingAverage, Line: ChartLine{Type: ChartLineNone}}},
+		},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "D20", chart))
+	assert.Equal(t, 2, chart.Series[0].Trendline.Order)
+	assert.Equal(t, 2, chart.Series[1].Trendline.Period)
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser = *chartSpace.Chart.PlotArea.LineChart[0].Ser
+	assert.Equal(t, "poly", *ser[0].Trendline.TrendlineType.Val)
+	assert.Equal(t, 2, *ser[0].Trendline.Order.Val)
+	assert.True(t, *ser[0].Trendline.DispRSqr.Val)
+	assert.Nil(t, ser[0].Trendline.SpPr)
+	assert.Equal(t, "movingAvg", *ser[1].Trendline.TrendlineType.Val)
+	assert.Equal(t, 2, *ser[1].Trendline.Period.Val)
+	assert.Nil(t, ser[1].Trendline.DispEq)
+	assert.Nil(t, ser[1].Trendline.TrendlineLbl)
+	assert.NotNil(t, ser[1].Trendline.SpPr)
+
+	// Test add chart with invalid trendline options
+	for _, trendline := range []ChartTrendline{
+		{Type: ChartTrendlineMovingAverage + 1},
+		{Type: ChartTrendlineLinear, Forward: -1},
+		{Type: ChartTrendlineLinear, Backward: -1},
+		{Type: ChartTrendlinePolynomial, Order: 1},
+		{Type: ChartTrendlinePolynomial, Order: 7},
+		{Type: ChartTrendlineMovingAverage, Period: 1},
+		{Type: ChartTrendlineMovingAverage, Period: 256},
+		{Type: ChartTrendlineMovingAverage, DisplayEquation: true},
+		{Type: ChartTrendlineLogarithmic, Intercept: &intercept},
+		{Type: ChartTrendlinePower, Intercept: &intercept},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "D40", &Chart{
+			Type:   Scatter,
+			Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6", Trendline: trendline}},
+		}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDynamicRefs(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Month", "Sales", "Cost"}, {"Jan", 10, 6}, {"Feb", 20, 8}, {"Mar", nil, 7}, {"Apr", 40, 9}, {"May", 50, 10},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:C6", Name: "Table1"}))
+	for _, definedName := range []*DefinedName{
+		{Name: "Cost", RefersTo: "Sheet1!$C$2:$C$6"},
+		{Name: "Local", RefersTo: "Sheet1!$B$2:$B$6", Scope: "Sheet1"},
+		{Name: "Offset", RefersTo: "OFFSET(Sheet1!$B$2,0,0,COUNTA(Sheet1!$B:$B)-1,1)"},
+		{Name: "TableCost", RefersTo: "Table1[Cost]"},
+		{Name: "_Table1_Cost", RefersTo: "Sheet1!$A$1"},
+	} {
+		assert.NoError(t, f.SetDefinedName(definedName))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Table1[Month]", Values: "Table1[Sales]"},
+			{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$6", Values: "Cost"},
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!Local"},
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Offset"},
+			{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$6", Values: "TableCost"},
+		},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.LineChart[0].Ser
+	assert.Equal(t, "[0]!_Table1_Month", ser[0].Cat.StrRef.F)
+	assert.Equal(t, 5, *ser[0].Cat.StrRef.StrCache.PtCount.Val)
+	assert.Equal(t, "Jan", *ser[0].Cat.StrRef.StrCache.Pt[0].V)
+	assert.Equal(t, "[0]!_Table1_Sales", ser[0].Val.NumRef.F)
+	assert.Equal(t, 5, *ser[0].Val.NumRef.NumCache.PtCount.Val)
+	assert.Len(t, ser[0].Val.NumRef.NumCache.Pt, 4)
+	assert.Equal(t, 3, ser[0].Val.NumRef.NumCache.Pt[2].IDx)
+	assert.Equal(t, "40", *ser[0].Val.NumRef.NumCache.Pt[2].V)
+	assert.Equal(t, "Sheet1!$A$2:$A$6", ser[1].Cat.StrRef.F)
+	assert.Nil(t, ser[1].Cat.StrRef.StrCache)
+	assert.Equal(t, "[0]!Cost", ser[1].Val.NumRef.F)
+	assert.Equal(t, "6", *ser[1].Val.NumRef.NumCache.Pt[0].V)
+	assert.Equal(t, "Sheet1!Local", ser[2].Val.NumRef.F)
+	assert.Equal(t, "10", *ser[2].Val.NumRef.NumCache.Pt[0].V)
+	assert.Equal(t, "[0]!Offset", ser[3].Val.NumRef.F)
+	assert.Nil(t, ser[3].Val.NumRef.NumCache)
+	assert.Equal(t, "[0]!TableCost", ser[4].Val.NumRef.F)
+	assert.Equal(t, "10", *ser[4].Val.NumRef.NumCache.Pt[4].V)
+
+	// Test add chart with the same structured reference and a conflict name
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{
+		Type: Scatter,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Table1[Month]", Values: "Table1[Sales]"},
+			{Name: "Sheet1!$C$1", Categories: "table1[month]", Values: "Table1[Cost]"},
+		},
+	}))
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser = *chartSpace.Chart.PlotArea.ScatterChart[0].Ser
+	assert.Equal(t, "[0]!_Table1_Month", ser[0].XVal.StrRef.F)
+	assert.Equal(t, "[0]!_Table1_Sales", ser[0].YVal.NumRef.F)
+	assert.Equal(t, "[0]!_Table1_Month", ser[1].XVal.StrRef.F)
+	assert.Equal(t, "[0]!TableCost", ser[1].YVal.NumRef.F)
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "TableCost"}))
+	assert.NoError(t, f.AddChart("Sheet1", "E40", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Values: "Table1[Cost]"}},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Values: "Table1[Sales]"}},
+	}))
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	hidden := map[string]string{}
+	for _, definedName := range wb.DefinedNames.DefinedName {
+		if definedName.Hidden {
+			hidden[definedName.Name] = definedName.Data
+		}
+	}
+	assert.Equal(t, map[string]string{"_Table1_Month": "Table1[Month]", "_Table1_Sales": "Table1[Sales]", "_Table1_Cost_2": "Table1[Cost]"}, hidden)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDynamicRefs.xlsx")))
+
+	// Test add chart with invalid dynamic references
+	for values, expected := range map[string]error{
+		"Table2[Sales]":  newNoExistTableError("Table2"),
+		"Table1[Profit]": ErrParameterInvalid,
+		"Unknown":        ErrDefinedNameScope,
+		"Sheet2!Local":   ErrDefinedNameScope,
+	} {
+		assert.Equal(t, expected, f.AddChart("Sheet1", "E60", &Chart{
+			Type:   Line,
+			Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: values}},
+		}), values)
+	}
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E60", &Chart{
+		Type:   Waterfall,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Table1[Month]", Values: "Sheet1!$B$2:$B$6"}},
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartErrorBars(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"X", "Y", "Plus", "Minus"}, {1, 2.1, 0.5, 0.2}, {2, 3.9, 0.4, 0.3}, {3, 6.2, 0.6, 0.1}, {4, 7.8, 0.3, 0.4}, {5, 10.1, 0.7, 0.5},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type: Scatter,
+		Series: []ChartSeries{
+			{
+				Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6",
+				Trendline: ChartTrendline{Type: ChartTrendlineLinear},
+				ErrorBars: ChartErrorBars{Type: ChartErrorBarsCustom, Plus: "Sheet1!$C$2:$C$6", Minus: "Sheet1!$D$2:$D$6"},
+			},
+			{
+				Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6",
+				ErrorBars: ChartErrorBars{Type: ChartErrorBarsPercentage, Direction: ChartErrorBarsDirectionBoth, NoEndCap: true},
+			},
+		},
+	}))
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser := *chartSpace.Chart.PlotArea.ScatterChart[0].Ser
+	assert.Len(t, ser[0].ErrBars, 1)
+	errBars := ser[0].ErrBars[0]
+	assert.Equal(t, "y", *errBars.ErrDir.Val)
+	assert.Equal(t, "both", *errBars.ErrBarType.Val)
+	assert.Equal(t, "cust", *errBars.ErrValType.Val)
+	assert.False(t, *errBars.NoEndCap.Val)
+	assert.Nil(t, errBars.Val)
+	assert.Equal(t, "Sheet1!$C$2:$C$6", errBars.Plus.NumRef.F)
+	assert.Equal(t, 5, *errBars.Plus.NumRef.NumCache.PtCount.Val)
+	assert.Equal(t, "0.5", *errBars.Plus.NumRef.NumCache.Pt[0].V)
+	assert.Equal(t, "Sheet1!$D$2:$D$6", errBars.Minus.NumRef.F)
+	assert.Equal(t, "0.4", *errBars.Minus.NumRef.NumCache.Pt[3].V)
+	chartXML := string(content.([]byte))
+	assert.True(t, strings.Index(chartXML, "<trendline>") < strings.Index(chartXML, "<errBars>"))
+	assert.True(t, strings.Index(chartXML, "<errBars>") < strings.Index(chartXML, "<xVal>"))
+	assert.Contains(t, chartXML, `<numCache><formatCode>General</formatCode><ptCount val="5"></ptCount><pt idx="0"><v>0.5</v></pt>`)
+	assert.Len(t, ser[1].ErrBars, 2)
+	assert.Equal(t, "x", *ser[1].ErrBars[0].ErrDir.Val)
+	assert.Equal(t, "y", *ser[1].ErrBars[1].ErrDir.Val)
+	assert.Equal(t, "percentage", *ser[1].ErrBars[1].ErrValType.Val)
+	assert.Equal(t, 5.0, *ser[1].ErrBars[1].Val.Val)
+	assert.True(t, *ser[1].ErrBars[1].NoEndCap.Val)
+	assert.Nil(t, ser[1].ErrBars[1].Plus)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartErrorBars.xlsx")))
+
+	// Test add column chart with one direction custom error bars and standard error bars
+	assert.NoError(t, f.AddChart("Sheet1", "F20", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsCustom, Plus: "Sheet1!$C$2:$C$6"}},
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$6", Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsStandardError, Line: ChartLine{Type: ChartLineNone}}},
+		},
+	}))
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(content.([]byte), &chartSpace))
+	ser = *chartSpace.Chart.PlotArea.BarChart[0].Ser
+	assert.Nil(t, ser[0].ErrBars[0].ErrDir)
+	assert.Equal(t, "plus", *ser[0].ErrBars[0].ErrBarType.Val)
+	assert.Nil(t, ser[0].ErrBars[0].Minus)
+	assert.Equal(t, "stdErr", *ser[1].ErrBars[0].ErrValType.Val)
+	assert.Nil(t, ser[1].ErrBars[0].Val)
+	assert.NotNil(t, ser[1].ErrBars[0].SpPr)
+
+	// Test add chart with invalid error bars options
+	for _, chart := range []*Chart{
+		{Type: Pie, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsFixedValue}}}},
+		{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsFixedValue, Direction: ChartErrorBarsDirectionX}}}},
+		{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsFixedValue, Direction: ChartErrorBarsDirectionBoth + 1}}}},
+		{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsCustom + 1}}}},
+		{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsFixedValue, Value: -1}}}},
+		{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsCustom}}}},
+		{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsCustom, Plus: "$C$2:$C$6"}}}},
+		{Type: Scatter, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsCustom, Minus: "$D$2:$D$6"}}}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "F40", chart))
+	}
+	// Test add combo chart with invalid error bars options
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "F40",
+		&Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6"}}},
+		&Chart{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$6", ErrorBars: ChartErrorBars{Type: ChartErrorBarsFixedValue, Direction: ChartErrorBarsDirectionX}}}},
+	))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartHistogram(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"A", 12}, {"B", 35}, {"A", 48}, {"C", 7}, {"B", 64}, {"C", 23},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Categories: "Sheet1!$A$1:$A$6", Values: "Sheet1!$B$1:$B$6"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Histogram, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "D20", &Chart{Type: Histogram, Series: series, Histogram: ChartHistogram{BinWidth: 10, Overflow: float64Ptr(60), Underflow: float64Ptr(10)}}))
+	assert.NoError(t, f.AddChart("Sheet1", "D40", &Chart{Type: Pareto, Series: series, Histogram: ChartHistogram{BinCount: 5}}))
+	assert.NoError(t, f.AddChart("Sheet1", "D60", &Chart{Type: Pareto, Series: series, Histogram: ChartHistogram{ByCategory: true}}))
+	for part, expected := range map[string][]string{
+		"xl/charts/chartEx1.xml": {
+			`<cx:series layoutId="clusteredColumn" formatIdx="0">`,
+			`<cx:layoutPr><cx:binning intervalClosed="r"></cx:binning></cx:layoutPr>`,
+			`<cx:catScaling gapWidth="0"></cx:catScaling>`,
+		},
+		"xl/charts/chartEx2.xml": {`<cx:binning intervalClosed="r" underflow="10" overflow="60"><cx:binSize val="10"></cx:binSize></cx:binning>`},
+		"xl/charts/chartEx3.xml": {
+			`<cx:binning intervalClosed="r"><cx:binCount val="5"></cx:binCount></cx:binning>`,
+			`<cx:axisId val="0"></cx:axisId><cx:axisId val="1"></cx:axisId></cx:series>`,
+			`<cx:series layoutId="paretoLine" ownerIdx="0" formatIdx="1"><cx:axisId val="2"></cx:axisId></cx:series>`,
+			`<cx:axis id="2"><cx:valScaling max="1" min="0"></cx:valScaling><cx:units unit="percentage"></cx:units><cx:tickLabels></cx:tickLabels></cx:axis>`,
+		},
+		"xl/charts/chartEx4.xml": {`<cx:layoutPr><cx:aggregation></cx:aggregation></cx:layoutPr>`},
+	} {
+		content, ok := f.Pkg.Load(part)
+		assert.True(t, ok)
+		for _, str := range expected {
+			assert.Contains(t, string(content.([]byte)), str)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartHistogram.xlsx")))
+	// Test add histogram chart with invalid binning options
+	for _, opts := range []ChartHistogram{
+		{BinWidth: 10, BinCount: 5},
+		{BinWidth: -1},
+		{BinCount: -1},
+		{Overflow: float64Ptr(10), Underflow: float64Ptr(60)},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "D1", &Chart{Type: Histogram, Series: series, Histogram: opts}))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "D1", &Chart{Type: Pareto, Series: []ChartSeries{{Values: "Sheet1!$B$1:$B$6"}}, Histogram: ChartHistogram{ByCategory: true}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Month", "Sales", "Cost"}, {"Jan", 10, 6}, {"Feb", 20, 8}, {"Mar", 30, 7},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Cost", RefersTo: "Sheet1!$C$2:$C$4"}))
+	chart := &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+			{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Cost", Type: Line, SecondaryAxis: true, Marker: ChartMarker{Symbol: "circle", Size: 5}},
+		},
+		Format:    GraphicOptions{OffsetX: 10, OffsetY: 5},
+		Dimension: ChartDimension{Width: 320, Height: 240},
+		Legend:    ChartLegend{Position: "top"},
+		Title:     []RichTextRun{{Text: "Sales", Font: &Font{Bold: true, Color: "FF0000"}}},
+		XAxis:     ChartAxis{ReverseOrder: true, Title: []RichTextRun{{Text: "Month"}}},
+		YAxis:     ChartAxis{Maximum: float64Ptr(50), Minimum: float64Ptr(0), MajorGridLines: true, MajorUnit: 10},
+		YAxis2:    ChartAxis{NumFmt: ChartNumFmt{CustomNumFmt: "0.0"}},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E2", chart))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: BoxWhisker, Series: []ChartSeries{{Name: "Sheet1!$B$1", Values: "Sheet1!$B$2:$B$4"}}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestGetCharts.xlsx"))
+	assert.NoError(t, err)
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	info := charts[0]
+	assert.Equal(t, "xl/drawings/drawing1.xml", info.DrawingPart)
+	assert.Equal(t, "xl/charts/chart1.xml", info.ChartPart)
+	assert.Equal(t, ChartAnchor{Type: "twoCell", From: "E2", To: "J15", OffsetX: 10, OffsetY: 5, Width: 320, Height: 240}, info.Anchor)
+	assert.Equal(t, Col, info.Chart.Type)
+	assert.Equal(t, ChartDimension{Width: 320, Height: 240}, info.Chart.Dimension)
+	assert.Equal(t, "top", info.Chart.Legend.Position)
+	assert.Equal(t, []RichTextRun{{Text: "Sales", Font: &Font{Bold: true, Color: "FF0000", Size: 14}}}, info.Chart.Title)
+	assert.Len(t, info.Chart.Series, 2)
+	assert.Equal(t, ChartSeries{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}, info.Chart.Series[0])
+	assert.Equal(t, "Cost", info.Chart.Series[1].Values)
+	assert.Equal(t, Line, info.Chart.Series[1].Type)
+	assert.True(t, info.Chart.Series[1].SecondaryAxis)
+	assert.Equal(t, ChartMarker{Symbol: "circle", Size: 5}, info.Chart.Series[1].Marker)
+	assert.Equal(t, []ChartSeriesCache{{}, {Values: []string{"6", "8", "7"}}}, info.SeriesCache)
+	assert.True(t, info.Chart.XAxis.ReverseOrder)
+	assert.Equal(t, "Month", info.Chart.XAxis.Title[0].Text)
+	assert.Equal(t, 50.0, *info.Chart.YAxis.Maximum)
+	assert.Equal(t, 0.0, *info.Chart.YAxis.Minimum)
+	assert.Equal(t, 10.0, info.Chart.YAxis.MajorUnit)
+	assert.True(t, info.Chart.YAxis.MajorGridLines)
+	assert.True(t, info.Chart.YAxis2.Secondary)
+	assert.Equal(t, "0.0", info.Chart.YAxis2.NumFmt.CustomNumFmt)
+	assert.Equal(t, "xl/charts/chartEx2.xml", charts[1].ChartPart)
+	assert.Equal(t, BoxWhisker, charts[1].Chart.Type)
+	assert.Equal(t, []ChartSeries{{Name: "Sheet1!$B$1", Values: "Sheet1!$B$2:$B$4"}}, charts[1].Chart.Series)
+
+	// Test clone the charts to another worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, info := range charts {
+		assert.NoError(t, f.AddChart("Sheet2", info.Anchor.From, &info.Chart))
+	}
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	clones, err := f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, clones, 2)
+	for idx, clone := range clones {
+		assert.Equal(t, charts[idx].Anchor.From, clone.Anchor.From)
+		assert.Equal(t, charts[idx].Chart.Dimension, clone.Chart.Dimension)
+		assert.Equal(t, charts[idx].Chart.Type, clone.Chart.Type)
+		assert.Equal(t, charts[idx].Chart.Series, clone.Chart.Series)
+	}
+	assert.Equal(t, charts[0].Chart.YAxis, clones[0].Chart.YAxis)
+	assert.NoError(t, f.Close())
+
+	// Test get charts for all chart types
+	f = NewFile()
+	var chartTypes []ChartType
+	for chartType := range chartValAxNumFmtFormatCode {
+		chartTypes = append(chartTypes, chartType)
+	}
+	for idx, chartType := range chartTypes {
+		cell, err := CoordinatesToCellName(1, idx*20+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart("Sheet1", cell, &Chart{Type: chartType, Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Sizes: "Sheet1!$B$3:$D$3"}}}))
+	}
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, len(chartTypes))
+	for idx, chartType := range chartTypes {
+		assert.Equal(t, chartType, charts[idx].Chart.Type)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get charts which created by the spreadsheet application
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, Doughnut, charts[0].Chart.Type)
+	assert.Equal(t, "Sheet2!$B$2:$B$5", charts[0].Chart.Series[0].Values)
+	assert.Equal(t, ChartSeriesCache{
+		Name: "Monitor", Categories: []string{"> 23 Inch", "20-23 Inch", "17-20 Inch", "< 17 Inch"}, Values: []string{"19.0", "24.0", "56.0", "21.0"},
+	}, charts[0].SeriesCache[0])
+	assert.Equal(t, Col, charts[1].Chart.Type)
+	assert.Equal(t, "none", charts[1].Chart.Legend.Position)
+	// Test get charts on the worksheet without drawing
+	charts, err = f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, charts)
+	// Test get charts with invalid sheet name
+	_, err = f.GetCharts("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get charts with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get charts with unsupported charset drawing part
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test get charts with absolute anchor and unsupported charset chart
+	// extension part
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Funnel, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$3"}}}))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", []byte(`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><xdr:absoluteAnchor><xdr:pos x="95250" y="190500"/><xdr:ext cx="4572000" cy="2743200"/><xdr:graphicFrame macro=""><xdr:nvGraphicFramePr><xdr:cNvPr id="2" name="Chart 1"/><xdr:cNvGraphicFramePr/></xdr:nvGraphicFramePr><xdr:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/></xdr:xfrm><a:graphic><a:graphicData uri="http://schemas.microsoft.com/office/drawing/2014/chartex"><cx:chart xmlns:cx="http://schemas.microsoft.com/office/drawing/2014/chartex" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId1"/></a:graphicData></a:graphic></xdr:graphicFrame><xdr:clientData/></xdr:absoluteAnchor><xdr:oneCellAnchor><xdr:from><xdr:col>1</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>1</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from><xdr:ext cx="95250" cy="95250"/><xdr:graphicFrame macro=""><xdr:nvGraphicFramePr><xdr:cNvPr id="3" name="Chart 2"/><xdr:cNvGraphicFramePr/></xdr:nvGraphicFramePr><xdr:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/></xdr:xfrm><a:graphic><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/chart"><c:chart xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId2"/></a:graphicData></a:graphic></xdr:graphicFrame><xdr:clientData/></xdr:oneCellAnchor></xdr:wsDr>`))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, ChartAnchor{Type: "absolute", X: 10, Y: 20, Width: 480, Height: 288}, charts[0].Anchor)
+	assert.Equal(t, Funnel, charts[0].Chart.Type)
+	f.Pkg.Store("xl/charts/chartEx1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestUpdateChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Month", "Sales", "Cost", "Profit"}, {"Jan", 10, 6, 4}, {"Feb", 20, 8, 12}, {"Mar", 30, 7, 23},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Profit", RefersTo: "Sheet1!$D$2:$D$4"}))
+	assert.NoError(t, f.AddChart("Sheet1", "F2", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+			{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4", Type: Line, SecondaryAxis: true, Marker: ChartMarker{Symbol: "circle", Size: 5}},
+		},
+		Legend: ChartLegend{Position: "top"},
+		YAxis:  ChartAxis{Maximum: float64Ptr(50)},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "F20", &Chart{Type: Pie, Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}}}))
+	assert.NoError(t, f.UpdateChart("Sheet1", 0, ChartPatch{
+		Series: []ChartSeries{
+			{Values: "Sheet1!$C$2:$C$4"},
+			{Name: "Sheet1!$D$1", Categories: "Sheet1!$B$2:$B$4", Values: "Profit"},
+		},
+		Title:  []RichTextRun{{Text: "Profit", Font: &Font{Bold: true}}},
+		YAxis:  ChartAxis{Maximum: float64Ptr(40), Minimum: float64Ptr(5)},
+		YAxis2: ChartAxis{Maximum: float64Ptr(25)},
+	}))
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	info := charts[0]
+	assert.Equal(t, "Sheet1!$B$1", info.Chart.Series[0].Name)
+	assert.Equal(t, "Sheet1!$A$2:$A$4", info.Chart.Series[0].Categories)
+	assert.Equal(t, "Sheet1!$C$2:$C$4", info.Chart.Series[0].Values)
+	assert.Equal(t, ChartSeriesCache{Name: "", Categories: nil, Values: []string{"6", "8", "7"}}, info.SeriesCache[0])
+	assert.Equal(t, "Sheet1!$D$1", info.Chart.Series[1].Name)
+	assert.Equal(t, "Sheet1!$B$2:$B$4", info.Chart.Series[1].Categories)
+	assert.Equal(t, "Profit", info.Chart.Series[1].Values)
+	assert.Equal(t, ChartSeriesCache{Name: "Profit", Categories: []string{"10", "20", "30"}, Values: []string{"4", "12", "23"}}, info.SeriesCache[1])
+	assert.Equal(t, "circle", info.Chart.Series[1].Marker.Symbol)
+	assert.Equal(t, "top", info.Chart.Legend.Position)
+	assert.Equal(t, "Profit", info.Chart.Title[0].Text)
+	assert.True(t, info.Chart.Title[0].Font.Bold)
+	assert.Equal(t, 40.0, *info.Chart.YAxis.Maximum)
+	assert.Equal(t, 5.0, *info.Chart.YAxis.Minimum)
+	assert.Equal(t, 25.0, *info.Chart.YAxis2.Maximum)
+	assert.Nil(t, info.Chart.YAxis2.Minimum)
+	// Test update chart by name
+	assert.NoError(t, f.UpdateChart("Sheet1", -1, ChartPatch{ChartName: charts[1].Name, Title: []RichTextRun{{Text: "Sales"}}}))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sales", charts[1].Chart.Title[0].Text)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestUpdateChart.xlsx")))
+	// Test update chart with out of range index
+	assert.EqualError(t, f.UpdateChart("Sheet1", 2, ChartPatch{}), "chart 2 does not exist on sheet Sheet1")
+	// Test update chart with not exists name
+	assert.EqualError(t, f.UpdateChart("Sheet1", 0, ChartPatch{ChartName: "Chart"}), "chart Chart does not exist on sheet Sheet1")
+	// Test update chart with too many series
+	assert.Equal(t, ErrParameterInvalid, f.UpdateChart("Sheet1", 1, ChartPatch{Series: make([]ChartSeries, 2)}))
+	// Test update chart axis bounds on the chart without axes
+	assert.Equal(t, ErrParameterInvalid, f.UpdateChart("Sheet1", 1, ChartPatch{YAxis: ChartAxis{Maximum: float64Ptr(1)}}))
+	// Test update chart secondary axis bounds on the chart without secondary axis
+	assert.NoError(t, f.UpdateChart("Sheet1", 0, ChartPatch{}))
+	// Test update chart with invalid series reference
+	for _, series := range []ChartSeries{{Name: "A1"}, {Categories: "A1"}, {Values: "A1"}} {
+		assert.Equal(t, ErrParameterInvalid, f.UpdateChart("Sheet1", 0, ChartPatch{Series: []ChartSeries{series}}))
+	}
+	assert.EqualError(t, f.UpdateChart("Sheet1", 0, ChartPatch{Series: []ChartSeries{{Values: "SheetN!$A$1:$A$2"}}}), "sheet SheetN does not exist")
+	// Test update chart with invalid title font
+	assert.Equal(t, ErrFontScheme, f.UpdateChart("Sheet1", 0, ChartPatch{Title: []RichTextRun{{Text: "Sales", Font: &Font{Scheme: "unknown"}}}}))
+	// Test update chart with invalid sheet name
+	assert.EqualError(t, f.UpdateChart("Sheet:1", 0, ChartPatch{}), ErrSheetNameInvalid.Error())
+	// Test update chart with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.UpdateChart("Sheet1", 1, ChartPatch{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test update chart which created by the spreadsheet application
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.UpdateChart("Sheet1", 1, ChartPatch{
+		Series: []ChartSeries{{Values: "Sheet2!$C$3:$C$6"}},
+		Title:  []RichTextRun{{Text: "Brand"}},
+		YAxis:  ChartAxis{Maximum: float64Ptr(100), Minimum: float64Ptr(0)},
+	}))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet2!$C$3:$C$6", charts[1].Chart.Series[0].Values)
+	assert.Equal(t, "Brand", charts[1].Chart.Title[0].Text)
+	assert.Equal(t, 100.0, *charts[1].Chart.YAxis.Maximum)
+	assert.Equal(t, 0.0, *charts[1].Chart.YAxis.Minimum)
+	content, ok := f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<c:scaling><c:orientation val="minMax" /><c:max val="100"/><c:min val="0"/></c:scaling>`)
+	assert.Contains(t, string(content.([]byte)), `<c14:style val="102" />`)
+	assert.NoError(t, f.Close())
+
+	// Test update chart extension
+	f = NewFile()
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Funnel, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$3"}}}))
+	assert.Equal(t, newUnsupportedChartType(Funnel), f.UpdateChart("Sheet1", 0, ChartPatch{}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDateAxis(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Month", "Sales", "Cost"}))
+	for month := 1; month <= 12; month++ {
+		cell, err := CoordinatesToCellName(1, month+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{time.Date(2024, time.Month(month), 1, 0, 0, 0, 0, time.UTC), month * 10, month * 5}))
+	}
+	xAxis := ChartAxis{
+		Type: "date", BaseTimeUnit: "months", MajorUnit: 3, MajorTimeUnit: "months", MinorUnit: 1, MinorTimeUnit: "months",
+		NumFmt: ChartNumFmt{CustomNumFmt: "mmm-yy"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$13", Values: "Sheet1!$B$2:$B$13"}},
+		XAxis:  xAxis,
+		YAxis:  ChartAxis{MajorUnit: 20, MinorUnit: 5},
+	}))
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart1.xml"), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.Nil(t, plotArea.CatAx)
+	assert.Len(t, plotArea.DateAx, 1)
+	dateAx := plotArea.DateAx[0]
+	assert.Equal(t, 100000000, *dateAx.AxID.Val)
+	assert.Equal(t, 100000001, *dateAx.CrossAx.Val)
+	assert.Equal(t, 100000000, *plotArea.ValAx[0].CrossAx.Val)
+	assert.Equal(t, &cNumFmt{FormatCode: "mmm-yy"}, dateAx.NumFmt)
+	assert.False(t, *dateAx.Auto.Val)
+	assert.Equal(t, "months", *dateAx.BaseTimeUnit.Val)
+	assert.Equal(t, 3.0, *dateAx.MajorUnit.Val)
+	assert.Equal(t, "months", *dateAx.MajorTimeUnit.Val)
+	assert.Equal(t, 1.0, *dateAx.MinorUnit.Val)
+	assert.Equal(t, "months", *dateAx.MinorTimeUnit.Val)
+	assert.Equal(t, 5.0, *plotArea.ValAx[0].MinorUnit.Val)
+	cat := (*plotArea.LineChart[0].Ser)[0].Cat
+	assert.Nil(t, cat.StrRef)
+	assert.Equal(t, "Sheet1!$A$2:$A$13", cat.NumRef.F)
+	assert.Equal(t, "mmm-yy", cat.NumRef.NumCache.FormatCode)
+	assert.Equal(t, 12, *cat.NumRef.NumCache.PtCount.Val)
+	assert.Equal(t, "45292", *cat.NumRef.NumCache.Pt[0].V)
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "date", charts[0].Chart.XAxis.Type)
+	assert.Equal(t, "months", charts[0].Chart.XAxis.BaseTimeUnit)
+	assert.Equal(t, 3.0, charts[0].Chart.XAxis.MajorUnit)
+	assert.Equal(t, "months", charts[0].Chart.XAxis.MajorTimeUnit)
+	assert.Equal(t, 1.0, charts[0].Chart.XAxis.MinorUnit)
+	assert.Equal(t, "months", charts[0].Chart.XAxis.MinorTimeUnit)
+	assert.Equal(t, 5.0, charts[0].Chart.YAxis.MinorUnit)
+	assert.Equal(t, "45292", charts[0].SeriesCache[0].Categories[0])
+	// Test add combo chart with date axis and the default number format
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$13", Values: "Sheet1!$B$2:$B$13"}},
+		XAxis:  ChartAxis{Type: "date", BaseTimeUnit: "months"},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$13", Values: "Sheet1!$C$2:$C$13"}},
+		YAxis:  ChartAxis{Secondary: true},
+	}))
+	chartSpace = xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart2.xml"), &chartSpace))
+	plotArea = chartSpace.Chart.PlotArea
+	assert.Len(t, plotArea.DateAx, 2)
+	assert.Equal(t, &cNumFmt{FormatCode: defaultChartDateAxisNumFmt, SourceLinked: true}, plotArea.DateAx[0].NumFmt)
+	assert.Equal(t, defaultChartDateAxisNumFmt, (*plotArea.LineChart[0].Ser)[0].Cat.NumRef.NumCache.FormatCode)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDateAxis.xlsx")))
+	// Test add chart with value axis type for the scatter chart
+	assert.NoError(t, f.AddChart("Sheet1", "E40", &Chart{Type: Scatter, Series: []ChartSeries{{Categories: "Sheet1!$B$2:$B$13", Values: "Sheet1!$C$2:$C$13"}}, XAxis: ChartAxis{Type: "value"}}))
+	// Test add chart with invalid axis type and time unit
+	for _, chart := range []*Chart{
+		{Type: Line, XAxis: ChartAxis{Type: "unknown"}},
+		{Type: Line, XAxis: ChartAxis{Type: "value"}},
+		{Type: Scatter, XAxis: ChartAxis{Type: "date"}},
+		{Type: Line, XAxis: ChartAxis{Type: "date", BaseTimeUnit: "weeks"}},
+	} {
+		chart.Series = []ChartSeries{{Categories: "Sheet1!$A$2:$A$13", Values: "Sheet1!$B$2:$B$13"}}
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E1", chart))
+	}
+	// Test add chart with date axis and invalid categories reference
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E1", &Chart{Type: Line, Series: []ChartSeries{{Categories: "A2:A13", Values: "Sheet1!$B$2:$B$13"}}, XAxis: ChartAxis{Type: "date"}}))
+	assert.EqualError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Line, Series: []ChartSeries{{Categories: "SheetN!$A$2:$A$13", Values: "Sheet1!$B$2:$B$13"}}, XAxis: ChartAxis{Type: "date"}}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartTitleFormat(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Month", "Sales", "Revenue Report"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Jan", 10}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Feb", 20}))
+	series := []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$3", Values: "Sheet1!$B$2:$B$3"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: series,
+		Title: []RichTextRun{
+			{Text: "Sales", Font: &Font{Bold: true}},
+			{Text: "Fiscal Year 2024"},
+		},
+		TitleFormat: ChartTitle{Font: &Font{Color: "1F4E79", Size: 12}, Overlay: true},
+		XAxis: ChartAxis{
+			Title:       []RichTextRun{{Text: "Month"}},
+			TitleFormat: ChartTitle{Rotation: intPtr(45)},
+		},
+		YAxis: ChartAxis{
+			Title:       []RichTextRun{{Text: "Amount"}},
+			TitleFormat: ChartTitle{CellRef: "Sheet1!$C$1", Font: &Font{Italic: true}, Rotation: intPtr(-90)},
+		},
+	}))
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart1.xml"), &chartSpace))
+	assert.True(t, *chartSpace.Chart.Title.Overlay.Val)
+	assert.False(t, *chartSpace.Chart.PlotArea.CatAx[0].Title.Overlay.Val)
+	yTitle := chartSpace.Chart.PlotArea.ValAx[0].Title
+	assert.Nil(t, yTitle.Tx.Rich)
+	assert.Equal(t, "Sheet1!$C$1", yTitle.Tx.StrRef.F)
+	assert.Equal(t, "Revenue Report", *yTitle.Tx.StrRef.StrCache.Pt[0].V)
+	content := string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, content, `rot="2700000" spcFirstLastPara="false" vert="horz"`)
+	assert.Contains(t, content, `rot="-5400000" spcFirstLastPara="false" vert="horz"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTitleFormat.xlsx")))
+	// Test get the title format of the chart
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	opts := charts[0].Chart
+	assert.Len(t, opts.Title, 2)
+	assert.Equal(t, "Sales", opts.Title[0].Text)
+	assert.True(t, opts.Title[0].Font.Bold)
+	assert.Equal(t, "1F4E79", opts.Title[1].Font.Color)
+	assert.True(t, opts.TitleFormat.Overlay)
+	assert.Nil(t, opts.TitleFormat.Rotation)
+	assert.Equal(t, 45, *opts.XAxis.TitleFormat.Rotation)
+	assert.Equal(t, []RichTextRun{{Text: "Revenue Report"}}, opts.YAxis.Title)
+	assert.Equal(t, "Sheet1!$C$1", opts.YAxis.TitleFormat.CellRef)
+	assert.Equal(t, -90, *opts.YAxis.TitleFormat.Rotation)
+	assert.True(t, opts.YAxis.TitleFormat.Font.Italic)
+	// Test add chart with invalid title format
+	for _, chart := range []*Chart{
+		{TitleFormat: ChartTitle{Rotation: intPtr(91)}},
+		{XAxis: ChartAxis{TitleFormat: ChartTitle{Rotation: intPtr(-91)}}},
+		{YAxis: ChartAxis{TitleFormat: ChartTitle{CellRef: "C1"}}},
+	} {
+		chart.Type, chart.Series = Col, series
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E20", chart))
+	}
+	assert.Equal(t, ErrFontScheme, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series, TitleFormat: ChartTitle{Font: &Font{Scheme: "unknown"}}}))
+	assert.EqualError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series, TitleFormat: ChartTitle{CellRef: "SheetN!$C$1"}}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series}, &Chart{Type: Line, Series: series, YAxis: ChartAxis{TitleFormat: ChartTitle{CellRef: "SheetN!$C$1"}}}), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartLegend(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: series,
+		Legend: ChartLegend{
+			Position:       "right",
+			Font:           &Font{Size: 8, Color: "7F7F7F"},
+			Fill:           Fill{Type: "pattern", Pattern: 1},
+			Border:         ChartLine{Type: ChartLineNone},
+			Overlay:        true,
+			DeletedEntries: []int{1, 1},
+		},
+	}))
+	var chartSpace xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart1.xml"), &chartSpace))
+	legend := chartSpace.Chart.Legend
+	assert.Len(t, legend.LegendEntry, 1)
+	assert.Equal(t, 1, *legend.LegendEntry[0].IDx.Val)
+	assert.True(t, *legend.LegendEntry[0].Delete.Val)
+	assert.True(t, *legend.Overlay.Val)
+	assert.Len(t, *chartSpace.Chart.PlotArea.BarChart[0].Ser, 3)
+	content := string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, content, `<legend><legendPos val="r"></legendPos><legendEntry><idx val="1"></idx><delete val="1"></delete></legendEntry><overlay val="1"></overlay><spPr><a:noFill></a:noFill><a:ln`)
+	assert.Contains(t, content, `sz="800"><a:solidFill><a:srgbClr val="7F7F7F"></a:srgbClr></a:solidFill></a:defRPr>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartLegend.xlsx")))
+	// Test get the legend settings of the chart
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "right", charts[0].Chart.Legend.Position)
+	assert.True(t, charts[0].Chart.Legend.Overlay)
+	assert.Equal(t, []int{1}, charts[0].Chart.Legend.DeletedEntries)
+	assert.Equal(t, 8.0, charts[0].Chart.Legend.Font.Size)
+	assert.Equal(t, "7F7F7F", charts[0].Chart.Legend.Font.Color)
+	// Test add chart with invalid legend settings
+	assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series, Legend: ChartLegend{DeletedEntries: []int{-1}}}))
+	assert.Equal(t, ErrFontScheme, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series, Legend: ChartLegend{Font: &Font{Scheme: "unknown"}}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDataPoints(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Q1", 2}, {"Q2", 5}, {"Q3", 9}, {"Q4", 4}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	red := Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}
+	lineSeries := []ChartSeries{{
+		Categories: "Sheet1!$A$1:$A$4",
+		Values:     "Sheet1!$B$1:$B$4",
+		Marker:     ChartMarker{Symbol: "circle", Border: ChartLine{Width: 1.5}},
+		DataPoints: []ChartDataPoint{{Index: 2, Marker: ChartMarker{Fill: red, Size: 10}}},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Line, Series: lineSeries}))
+	pieSeries := []ChartSeries{{
+		Categories: "Sheet1!$A$1:$A$4",
+		Values:     "Sheet1!$B$1:$B$4",
+		DataPoints: []ChartDataPoint{{Index: 1, Explosion: 25, Fill: red}},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "D20", &Chart{Type: Pie, Series: pieSeries}))
+	var lineChart, pieChart xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart1.xml"), &lineChart))
+	ser := (*lineChart.Chart.PlotArea.LineChart[0].Ser)[0]
+	assert.Len(t, ser.DPt, 1)
+	assert.Equal(t, 2, *ser.DPt[0].IDx.Val)
+	assert.Equal(t, 10, *ser.DPt[0].Marker.Size.Val)
+	content := string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, content, `<dPt><idx val="2"></idx><marker><size val="10"></size><spPr><a:solidFill><a:srgbClr val="FF0000">`)
+	assert.Contains(t, content, `<a:ln cap="rnd" w="19050"><a:solidFill><a:schemeClr val="accent1">`)
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart2.xml"), &pieChart))
+	ser = (*pieChart.Chart.PlotArea.PieChart[0].Ser)[0]
+	assert.Len(t, ser.DPt, 2)
+	assert.Equal(t, 1, *ser.DPt[1].IDx.Val)
+	assert.Equal(t, 25, *ser.DPt[1].Explosion.Val)
+	assert.Nil(t, ser.DPt[0].Explosion)
+	assert.Contains(t, string(f.readXML("xl/charts/chart2.xml")), `<dPt><idx val="1"></idx><explosion val="25"></explosion><spPr><a:solidFill><a:srgbClr val="FF0000">`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDataPoints.xlsx")))
+	// Test get the data points of the chart series
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, []ChartDataPoint{{Index: 2, Marker: ChartMarker{Size: 10}}}, charts[0].Chart.Series[0].DataPoints)
+	assert.Equal(t, []ChartDataPoint{{Index: 1, Explosion: 25}}, charts[1].Chart.Series[0].DataPoints)
+	// Test add chart with invalid data points
+	for _, dataPoints := range [][]ChartDataPoint{
+		{{Index: -1}},
+		{{Index: 1}, {Index: 1}},
+		{{Index: 1, Explosion: 401}},
+	} {
+		pieSeries[0].DataPoints = dataPoints
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "M1", &Chart{Type: Pie, Series: pieSeries}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartBarSpacing(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{nil, "Q1", "Q2", "Q3"}, {"A", 2, 5, 9}, {"B", 4, 3, 7}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series, GapWidth: uintPtr(50), Overlap: intPtr(-20), VaryColors: boolPtr(false)}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Col3D, Series: series, GapWidth: uintPtr(80), Overlap: intPtr(50), ZAxis: ChartAxis{ReverseOrder: true, MajorGridLines: true, Title: []RichTextRun{{Text: "Series"}}}}))
+	assert.NoError(t, f.AddChart("Sheet1", "E32", &Chart{Type: Line3D, Series: series, ZAxis: ChartAxis{None: true}}))
+	// Test the gap width and overlap are the same as the Excel-authored chart
+	assert.Contains(t, string(f.readXML("xl/charts/chart1.xml")), `<gapWidth val="50"></gapWidth><overlap val="-20"></overlap><axId val="100000000"></axId>`)
+	var colChart, col3DChart, line3DChart xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart1.xml"), &colChart))
+	assert.False(t, *colChart.Chart.PlotArea.BarChart[0].VaryColors.Val)
+	assert.Nil(t, colChart.Chart.PlotArea.SerAx)
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart2.xml"), &col3DChart))
+	assert.Equal(t, 80, *col3DChart.Chart.PlotArea.Bar3DChart[0].GapWidth.Val)
+	assert.Nil(t, col3DChart.Chart.PlotArea.Bar3DChart[0].Overlap)
+	assert.Len(t, col3DChart.Chart.PlotArea.Bar3DChart[0].AxID, 3)
+	assert.Len(t, col3DChart.Chart.PlotArea.SerAx, 1)
+	assert.Equal(t, "maxMin", *col3DChart.Chart.PlotArea.SerAx[0].Scaling.Orientation.Val)
+	assert.NotNil(t, col3DChart.Chart.PlotArea.SerAx[0].MajorGridlines)
+	assert.NotNil(t, col3DChart.Chart.PlotArea.SerAx[0].Title)
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart3.xml"), &line3DChart))
+	assert.Len(t, line3DChart.Chart.PlotArea.Line3DChart[0].AxID, 3)
+	assert.True(t, *line3DChart.Chart.PlotArea.SerAx[0].Delete.Val)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartBarSpacing.xlsx")))
+	// Test get the gap width, overlap and series axis of the charts
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 3)
+	assert.Equal(t, uintPtr(50), charts[0].Chart.GapWidth)
+	assert.Equal(t, intPtr(-20), charts[0].Chart.Overlap)
+	assert.Equal(t, boolPtr(false), charts[0].Chart.VaryColors)
+	assert.True(t, charts[1].Chart.ZAxis.ReverseOrder)
+	assert.True(t, charts[2].Chart.ZAxis.None)
+	// Test add chart with invalid gap width and overlap
+	assert.Equal(t, ErrChartGapWidth, f.AddChart("Sheet1", "N1", &Chart{Type: Col, Series: series, GapWidth: uintPtr(501)}))
+	for _, overlap := range []int{-101, 101} {
+		assert.Equal(t, ErrChartOverlap, f.AddChart("Sheet1", "N1", &Chart{Type: Col, Series: series, Overlap: intPtr(overlap)}))
+	}
+	// Test add chart with invalid series axis font
+	assert.Equal(t, ErrFontVertAlign, f.AddChart("Sheet1", "N1", &Chart{Type: Col3D, Series: series, ZAxis: ChartAxis{Font: Font{VertAlign: "x"}}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartOfPie(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{{"Product", 420}, {"Service", 310}, {"License", 150}, {"Training", 40}, {"Support", 30}, {"Other", 20}} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Revenue", Categories: "Sheet1!$A$1:$A$6", Values: "Sheet1!$B$1:$B$6"}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{
+		Type: BarOfPie, Series: series, GapWidth: uintPtr(100),
+		PlotArea: ChartPlotArea{
+			ShowPercent: true, SplitType: ChartSplitCustom, SecondPlotPoints: []int{3, 4, 5},
+			SecondPlotSize: 60, SeriesLines: ChartLine{Width: 1},
+		},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "D16", &Chart{
+		Type: PieOfPie, Series: series,
+		PlotArea: ChartPlotArea{SplitType: ChartSplitPercent, SplitValue: 5, SeriesLines: ChartLine{Type: ChartLineNone}},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "D31", &Chart{Type: PieOfPie, Series: series, PlotArea: ChartPlotArea{SecondPlotValues: 3}}))
+	// Test the elements are in the order of the Excel-authored chart
+	assert.Contains(t, string(f.readXML("xl/charts/chart1.xml")), `<gapWidth val="100"></gapWidth><splitType val="cust"></splitType><custSplit><secondPiePt val="3"></secondPiePt><secondPiePt val="4"></secondPiePt><secondPiePt val="5"></secondPiePt></custSplit><secondPieSize val="60"></secondPieSize><serLines><spPr><a:ln cap="rnd" w="12700"></a:ln></spPr></serLines>`)
+	var pieOfPie xlsxChartSpace
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart2.xml"), &pieOfPie))
+	ofPie := pieOfPie.Chart.PlotArea.OfPieChart[0]
+	assert.Equal(t, "percent", *ofPie.SplitType.Val)
+	assert.Equal(t, 5.0, *ofPie.SplitPos.Val)
+	assert.Nil(t, ofPie.SerLines)
+	assert.Contains(t, string(f.readXML("xl/charts/chart3.xml")), `<splitPos val="3"></splitPos><serLines></serLines>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartOfPie.xlsx")))
+	// Test get the split settings of the charts
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 3)
+	assert.Equal(t, BarOfPie, charts[0].Chart.Type)
+	assert.Equal(t, uintPtr(100), charts[0].Chart.GapWidth)
+	assert.Equal(t, ChartSplitCustom, charts[0].Chart.PlotArea.SplitType)
+	assert.Equal(t, []int{3, 4, 5}, charts[0].Chart.PlotArea.SecondPlotPoints)
+	assert.Equal(t, uint(60), charts[0].Chart.PlotArea.SecondPlotSize)
+	assert.Equal(t, ChartLineUnset, charts[0].Chart.PlotArea.SeriesLines.Type)
+	assert.Equal(t, PieOfPie, charts[1].Chart.Type)
+	assert.Equal(t, ChartSplitPercent, charts[1].Chart.PlotArea.SplitType)
+	assert.Equal(t, 5.0, charts[1].Chart.PlotArea.SplitValue)
+	assert.Equal(t, ChartLineNone, charts[1].Chart.PlotArea.SeriesLines.Type)
+	assert.Equal(t, ChartSplitAuto, charts[2].Chart.PlotArea.SplitType)
+	assert.Equal(t, 3, charts[2].Chart.PlotArea.SecondPlotValues)
+	// Test add chart with invalid split settings
+	for _, plotArea := range []ChartPlotArea{
+		{SplitType: ChartSplitCustom + 1},
+		{SplitType: ChartSplitPercent, SplitValue: 101},
+		{SplitType: ChartSplitCustom, SecondPlotPoints: []int{-1}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddChart("Sheet1", "N1", &Chart{Type: PieOfPie, Series: series, PlotArea: plotArea}))
+	}
+	for _, size := range []uint{4, 201} {
+		assert.Equal(t, ErrChartSecondPlotSize, f.AddChart("Sheet1", "N1", &Chart{Type: BarOfPie, Series: series, PlotArea: ChartPlotArea{SecondPlotSize: size}}))
+	}
+	assert.NoError(t, f.Close())
+}
@@ -24,7 +24,9 @@ import (
 // Define the default cell size and EMU unit of measurement.
 const (
 	defaultColWidth        float64 = 9.140625
+	defaultColWidthChars   float64 = 8.43
 	defaultColWidthPixels  float64 = 64
+	defaultMaxDigitWidth   float64 = 7
 	defaultRowHeight       float64 = 15
 	defaultRowHeightPixels float64 = 20
 	EMU                    int     = 9525
@@ -648,6 +650,7 @@ func (f *File) positionObjectPixels(sheet string, col, row, x1, y1, width, heigh
 // getColWidth provides a function to get column width in pixels by given
 // sheet name and column number.
 func (f *File) getColWidth(sheet string, col int) int {
+	maxDigitWidth, _ := f.getDefaultFontMetrics()
 	ws, _ := f.workSheetReader(sheet)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
@@ -659,14 +662,17 @@ func (f *File) getColWidth(sheet string, col int) int {
 			}
 		}
 		if width != 0 {
-			return int(convertColWidthToPixels(width))
+			return int(convertColWidthToPixels(width, maxDigitWidth))
 		}
 	}
 	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultColWidth > 0 {
-		return int(convertColWidthToPixels(ws.SheetFormatPr.DefaultColWidth))
+		return int(convertColWidthToPixels(ws.SheetFormatPr.DefaultColWidth, maxDigitWidth))
 	}
 	// Optimization for when the column widths haven't changed.
-	return int(defaultColWidthPixels)
+	if maxDigitWidth == defaultMaxDigitWidth {
+		return int(defaultColWidthPixels)
+	}
+	return int(math.Trunc(defaultColWidthChars*maxDigitWidth + getColPadding(maxDigitWidth)))
 }
 
 // GetColStyle provides a function to get column style ID by given worksheet
@@ -736,11 +742,18 @@ func (f *File) GetColWidth(sheet, col string) (float64, error) {
 //
 //	err := f.InsertCols("Sheet1", "C", 2)
 //
+// Create two columns before column C in Sheet1, which inherit the formatting
+// of the column on the left:
+//
+//	err := f.InsertCols("Sheet1", "C", 2, excelize.InsertColsOptions{
+//	    InheritStyle: excelize.InheritFromLeft,
+//	})
+//
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) InsertCols(sheet, col string, n int) error {
+func (f *File) InsertCols(sheet, col string, n int, opts ...InsertColsOptions) error {
 	num, err := ColumnNameToNumber(col)
 	if err != nil {
 		return err
@@ -748,7 +761,58 @@ func (f *File) InsertCols(sheet, col string, n int) error {
 	if n < 1 || n > MaxColumns {
 		return ErrColumnNumber
 	}
-	return f.adjustHelper(sheet, columns, num, n)
+	if err = f.adjustHelper(sheet, columns, num, n); err != nil {
+		return err
+	}
+	var options InsertColsOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	return f.inheritColsStyle(sheet, num, n, options.InheritStyle)
+}
+
+// inheritColsStyle provides a function to copy the column style, width,
+// outline level and the cell styles of the neighbor column to the inserted
+// columns by given formatting inheritance type.
+func (f *File) inheritColsStyle(sheet string, col, n int, inherit InheritStyleType) error {
+	src := col - 1
+	if inherit == InheritFromRight {
+		src = col + n
+	}
+	if inherit == InheritNone || src < 1 || src > MaxColumns {
+		return nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= src && src <= c.Max {
+				colData := xlsxCol{
+					Min: col, Max: col + n - 1, BestFit: c.BestFit, CustomWidth: c.CustomWidth,
+					OutlineLevel: c.OutlineLevel, Style: c.Style, Width: c.Width,
+				}
+				ws.Cols.Col = flatCols(colData, ws.Cols.Col, func(fc, c xlsxCol) xlsxCol {
+					return fc
+				})
+				break
+			}
+		}
+	}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if src > len(rowData.C) || rowData.C[src-1].S == 0 {
+			continue
+		}
+		fillColumns(rowData, col+n-1, rowIdx+1)
+		for c := col; c < col+n; c++ {
+			rowData.C[c-1].S = rowData.C[src-1].S
+		}
+	}
+	return nil
 }
 
 // RemoveCol provides a function to remove single column by given worksheet
@@ -783,21 +847,64 @@ func (f *File) RemoveCol(sheet, col string) error {
 	return f.adjustHelper(sheet, columns, num, -1)
 }
 
+// getColPadding provides a function to get the column padding in pixels by
+// given maximum digit width of the default font.
+func getColPadding(maxDigitWidth float64) float64 {
+	return 2*math.Ceil(maxDigitWidth/4) + 1
+}
+
 // convertColWidthToPixels provides function to convert the width of a cell
-// from user's units to pixels. Excel rounds the column width to the nearest
-// pixel. If the width hasn't been set by the user we use the default value.
-// If the column is hidden it has a value of zero.
-func convertColWidthToPixels(width float64) float64 {
-	var padding float64 = 5
+// from user's units to pixels by given maximum digit width of the default
+// font. Excel rounds the column width to the nearest pixel. If the width
+// hasn't been set by the user we use the default value. If the column is
+// hidden it has a value of zero.
+func convertColWidthToPixels(width, maxDigitWidth float64) float64 {
 	var pixels float64
-	var maxDigitWidth float64 = 7
+	padding := getColPadding(maxDigitWidth)
 	if width == 0 {
 		return pixels
 	}
 	if width < 1 {
-		pixels = (width * 12) + 0.5
+		pixels = (width * (maxDigitWidth + padding)) + 0.5
 		return math.Ceil(pixels)
 	}
 	pixels = (width*maxDigitWidth + 0.5) + padding
 	return math.Ceil(pixels)
 }
+
+// convertPixelsToColWidth provides a function to convert the width of a cell
+// from pixels to user's units by given maximum digit width of the default
+// font, it's the inverse of the convertColWidthToPixels function. The width
+// is truncated to 1/256 of the character width as Excel stored.
+func convertPixelsToColWidth(pixels, maxDigitWidth float64) float64 {
+	if pixels = math.Ceil(pixels); pixels <= 0 {
+		return 0
+	}
+	padding := getColPadding(maxDigitWidth)
+	width := (pixels - 0.75) / (maxDigitWidth + padding)
+	if width >= 1 {
+		width = (pixels - 0.75 - padding) / maxDigitWidth
+	}
+	return math.Trunc(width*256) / 256
+}
+
+// ColumnWidthToPixels provides a function to convert the column width in
+// characters to pixels, based on the maximum digit width of the default font
+// (Calibri 11) of the workbook. For example, get the pixels of the column
+// width 20 characters:
+//
+//	pixels := excelize.ColumnWidthToPixels(20)
+func ColumnWidthToPixels(width float64) float64 {
+	return convertColWidthToPixels(width, defaultMaxDigitWidth)
+}
+
+// PixelsToColumnWidth provides a function to convert the pixels to the column
+// width in characters, based on the maximum digit width of the default font
+// (Calibri 11) of the workbook. The returned width could be used as the
+// column width of the SetColWidth function to get the given pixels. For
+// example, set the width of column A in Sheet1 to 100 pixels:
+//
+//	err := f.SetColWidth("Sheet1", "A", "A", excelize.PixelsToColumnWidth(100))
+func PixelsToColumnWidth(pixels float64) float64 {
+	return convertPixelsToColWidth(pixels, defaultMaxDigitWidth)
+}
@@ -458,6 +458,64 @@ func TestInsertCols(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertCols.xlsx")))
 }
 
+func TestInsertColsInheritStyle(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	cellStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "B", style))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 20))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "B", 2))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", cellStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B3", "B3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B1", "C1"))
+	// Test insert columns with default formatting inheritance
+	assert.NoError(t, f.InsertCols("Sheet1", "B", 1))
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	// Test insert columns inherit formatting from the column on the right
+	assert.NoError(t, f.InsertCols("Sheet1", "C", 1, InsertColsOptions{InheritStyle: InheritFromRight}))
+	width, err = f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	styleID, err := f.GetCellStyle("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Equal(t, cellStyle, styleID)
+	// Test insert columns inherit formatting from the column on the left
+	assert.NoError(t, f.InsertCols("Sheet1", "E", 2, InsertColsOptions{InheritStyle: InheritFromLeft}))
+	for _, col := range []string{"E", "F"} {
+		width, err := f.GetColWidth("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, 20.0, width)
+		level, err := f.GetColOutlineLevel("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(2), level)
+		styleID, err := f.GetColStyle("Sheet1", col)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID)
+		styleID, err = f.GetCellStyle("Sheet1", col+"3")
+		assert.NoError(t, err)
+		assert.Equal(t, cellStyle, styleID)
+		val, err := f.GetCellValue("Sheet1", col+"3")
+		assert.NoError(t, err)
+		assert.Empty(t, val)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "D1:G1", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	// Test insert columns inherit formatting from the nonexistent column
+	assert.NoError(t, f.InsertCols("Sheet1", "A", 1, InsertColsOptions{InheritStyle: InheritFromLeft}))
+	width, err = f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultColWidth, width)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertColsInheritStyle.xlsx")))
+	// Test insert columns inherit formatting with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.inheritColsStyle("Sheet:1", 2, 1, InheritFromLeft))
+}
+
 func TestRemoveCol(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -484,5 +542,10 @@ func TestRemoveCol(t *testing.T) {
 }
 
 func TestConvertColWidthToPixels(t *testing.T) {
-	assert.Equal(t, -11.0, convertColWidthToPixels(-1))
+	assert.Equal(t, -11.0, convertColWidthToPixels(-1, defaultMaxDigitWidth))
+	assert.Equal(t, 146.0, ColumnWidthToPixels(20))
+	for _, pixels := range []float64{1, 12, 13, 64, 100, 1792} {
+		assert.Equal(t, pixels, ColumnWidthToPixels(PixelsToColumnWidth(pixels)))
+	}
+	assert.Equal(t, 0.0, PixelsToColumnWidth(-1))
 }
@@ -0,0 +1,206 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+)
+
+// CSVOptions define the options for the StreamToCSV function.
+//
+// Comma specifies the field delimiter, the default value is ',', set it as
+// '\t' to export TSV.
+//
+// UseCRLF specifies if the records will be ended with \r\n instead of \n.
+//
+// RawCellValue specifies if the cell values will be written without applying
+// the number formats.
+//
+// FillMergedCells specifies if the value of the top-left cell of each merged
+// cell range will be written on all cells of the range, the cells covered by
+// the merged cell ranges are empty by default.
+type CSVOptions struct {
+	Comma           rune
+	UseCRLF         bool
+	RawCellValue    bool
+	FillMergedCells bool
+}
+
+// StreamToCSV provides a function to export the worksheet as CSV records by
+// given worksheet name, the writer and the CSV options. The rows will be
+// parsed lazily and the records will be written incrementally, without
+// holding the whole worksheet in memory. Each record starts with the column
+// A, and has the fields of the columns up to the last column of the used
+// range of the worksheet, the empty cells are written as empty fields, and the
+// empty rows between the rows are written as the records with empty fields,
+// the empty rows in the tail of the worksheet will be skipped. The cell
+// values are formatted by the number formats of the cells as the GetRows
+// function does unless the RawCellValue option is set. Note that the
+// worksheet will be read twice, the first pass gets the last column of the
+// used range and the merged cell ranges. For example, export
+// the worksheet named Sheet1 as a TSV file:
+//
+//	file, err := os.Create("Sheet1.tsv")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.StreamToCSV("Sheet1", file, excelize.CSVOptions{Comma: '\t'}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) StreamToCSV(sheet string, w io.Writer, opts CSVOptions) error {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	cols, mergeCells, err := f.getCSVSheetInfo(rows.sheet, opts.FillMergedCells)
+	if err != nil {
+		_ = rows.Close()
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+	writer.UseCRLF = opts.UseCRLF
+	var rowNum, emptyRows int
+	mergedValues := make(map[int]string)
+	for rows.Next() {
+		rowNum++
+		record, err := rows.Columns(Options{RawCellValue: opts.RawCellValue})
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		record = fillMergedCells(record, rowNum, mergeCells, mergedValues)
+		if len(record) == 0 {
+			emptyRows++
+			continue
+		}
+		for ; emptyRows > 0; emptyRows-- {
+			if err = writer.Write(make([]string, cols)); err != nil {
+				_ = rows.Close()
+				return err
+			}
+		}
+		if len(record) < cols {
+			record = append(record, make([]string, cols-len(record))...)
+		}
+		if err = writer.Write(record); err != nil {
+			_ = rows.Close()
+			return err
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// getCSVSheetInfo provides a function to get the number of the columns of the
+// used range and the merged cell ranges by given worksheet XML path, the
+// merged cell ranges will be read only if the getMergeCells is true.
+func (f *File) getCSVSheetInfo(name string, getMergeCells bool) (int, [][]int, error) {
+	needClose, decoder, tempFile, err := f.xmlDecoder(name)
+	if needClose && err == nil {
+		defer tempFile.Close()
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	var (
+		col, cols  int
+		mergeCells [][]int
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cols, mergeCells, err
+		}
+		xmlElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch xmlElement.Name.Local {
+		case "row":
+			col = 0
+		case "c":
+			var c xlsxC
+			if err = decoder.DecodeElement(&c, &xmlElement); err != nil {
+				return cols, mergeCells, err
+			}
+			if col++; c.R != "" {
+				if col, _, err = CellNameToCoordinates(c.R); err != nil {
+					return cols, mergeCells, err
+				}
+			}
+			if (c.V != "" || c.IS != nil || c.F != nil) && col > cols {
+				cols = col
+			}
+		case "mergeCell":
+			if !getMergeCells {
+				continue
+			}
+			for _, attr := range xmlElement.Attr {
+				if attr.Name.Local != "ref" {
+					continue
+				}
+				coordinates, err := rangeRefToCoordinates(attr.Value)
+				if err != nil {
+					return cols, mergeCells, err
+				}
+				_ = sortCoordinates(coordinates)
+				mergeCells = append(mergeCells, coordinates)
+			}
+		}
+	}
+	for _, rect := range mergeCells {
+		if rect[0] <= cols && rect[2] > cols {
+			cols = rect[2]
+		}
+	}
+	return cols, mergeCells, nil
+}
+
+// fillMergedCells provides a function to fill the value of the top-left cell
+// of each merged cell range on the cells of the range by given record, row
+// number, merged cell ranges and the values of the top-left cells which have
+// been read.
+func fillMergedCells(record []string, row int, mergeCells [][]int, mergedValues map[int]string) []string {
+	for idx, rect := range mergeCells {
+		if row < rect[1] || row > rect[3] {
+			continue
+		}
+		if row == rect[1] && len(record) >= rect[0] {
+			mergedValues[idx] = record[rect[0]-1]
+		}
+		if value := mergedValues[idx]; value != "" {
+			if len(record) < rect[2] {
+				record = append(record, make([]string, rect[2]-len(record))...)
+			}
+			for col := rect[0]; col <= rect[2]; col++ {
+				record[col-1] = value
+			}
+		}
+		if row == rect[3] {
+			delete(mergedValues, idx)
+		}
+	}
+	return record
+}
@@ -0,0 +1,60 @@
+package excelize
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamToCSV(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Score", "Note"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"A, B", 1.5, "say \"hi\""}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B4", &[]interface{}{2}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B4", styleID))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "Merged"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A5", "B6"))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 8, 20))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.StreamToCSV("Sheet1", &buf, CSVOptions{}))
+	assert.Equal(t, "Name,Score,Note\n\"A, B\",1.50,\"say \"\"hi\"\"\"\n,,\n,2.00,\nMerged,,\n", buf.String())
+	// Test export with the TSV format, raw cell values and filled merged cells
+	buf.Reset()
+	assert.NoError(t, f.StreamToCSV("Sheet1", &buf, CSVOptions{Comma: '\t', UseCRLF: true, RawCellValue: true, FillMergedCells: true}))
+	assert.Equal(t, "Name\tScore\tNote\r\nA, B\t1.5\t\"say \"\"hi\"\"\"\r\n\t\t\r\n\t2\t\r\nMerged\tMerged\t\r\nMerged\tMerged\t\r\n", buf.String())
+	// Test export the saved workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamToCSV.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestStreamToCSV.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.StreamToCSV("Sheet1", &buf, CSVOptions{FillMergedCells: true}))
+	assert.Equal(t, "Name,Score,Note\n\"A, B\",1.50,\"say \"\"hi\"\"\"\n,,\n,2.00,\nMerged,Merged,\nMerged,Merged,\n", buf.String())
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test export not exists worksheet
+	assert.EqualError(t, f.StreamToCSV("SheetN", &buf, CSVOptions{}), "sheet SheetN does not exist")
+	// Test export with invalid delimiter
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	assert.EqualError(t, f.StreamToCSV("Sheet1", &buf, CSVOptions{Comma: '"'}), "csv: invalid field or comment delimiter")
+	// Test export with invalid merged cell range
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A1:A"}}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.StreamToCSV("Sheet1", &buf, CSVOptions{FillMergedCells: true}))
+	// Test export with invalid cell reference
+	ws.(*xlsxWorksheet).MergeCells = nil
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A"
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.StreamToCSV("Sheet1", &buf, CSVOptions{}))
+	// Test export with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.StreamToCSV("Sheet1", &buf, CSVOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
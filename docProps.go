@@ -14,8 +14,11 @@ package excelize
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // SetAppProps provides a function to set document application properties. The
@@ -116,6 +119,81 @@ func (f *File) GetAppProps() (ret *AppProperties, err error) {
 	return
 }
 
+// appPropsWriter provides a function to regenerate the heading pairs and
+// titles of parts in the document application properties by the sheets in
+// the workbook, including the worksheets generated by the stream writer.
+// Heading pairs other than worksheets and charts, such as named ranges, will
+// be preserved.
+func (f *File) appPropsWriter() {
+	if content, _ := f.Pkg.Load(defaultXMLPathDocPropsApp); content == nil {
+		return
+	}
+	app := new(xlsxProperties)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathDocPropsApp)))).
+		Decode(app); err != nil && err != io.EOF {
+		return
+	}
+	type headingPair struct {
+		name   string
+		titles []string
+	}
+	var worksheets, charts []string
+	for _, sheet := range f.GetSheetList() {
+		if name, ok := f.getSheetXMLPath(sheet); ok && strings.HasPrefix(name, "xl/chartsheets") {
+			charts = append(charts, sheet)
+			continue
+		}
+		worksheets = append(worksheets, sheet)
+	}
+	pairs := []headingPair{{name: "Worksheets", titles: worksheets}, {name: "Charts", titles: charts}}
+	if app.HeadingPairs != nil && app.TitlesOfParts != nil {
+		var headings, titles xlsxVector
+		_ = xml.Unmarshal([]byte(app.HeadingPairs.Content), &headings)
+		_ = xml.Unmarshal([]byte(app.TitlesOfParts.Content), &titles)
+		var offset int
+		for i := 0; i+1 < len(headings.Variant); i += 2 {
+			if headings.Variant[i].Lpstr == nil || headings.Variant[i+1].I4 == nil {
+				break
+			}
+			name, count := *headings.Variant[i].Lpstr, *headings.Variant[i+1].I4
+			if count < 0 || offset+count > len(titles.Lpstr) {
+				break
+			}
+			if name != "Worksheets" && name != "Charts" {
+				pairs = append(pairs, headingPair{name: name, titles: titles.Lpstr[offset : offset+count]})
+			}
+			offset += count
+		}
+	}
+	var headingsBuf, titlesBuf bytes.Buffer
+	var headingsCount, titlesCount int
+	for _, pair := range pairs {
+		if len(pair.titles) == 0 {
+			continue
+		}
+		headingsCount += 2
+		headingsBuf.WriteString("<vt:variant><vt:lpstr>")
+		_ = xml.EscapeText(&headingsBuf, []byte(pair.name))
+		headingsBuf.WriteString("</vt:lpstr></vt:variant><vt:variant><vt:i4>")
+		headingsBuf.WriteString(strconv.Itoa(len(pair.titles)))
+		headingsBuf.WriteString("</vt:i4></vt:variant>")
+		for _, title := range pair.titles {
+			titlesCount++
+			titlesBuf.WriteString("<vt:lpstr>")
+			_ = xml.EscapeText(&titlesBuf, []byte(title))
+			titlesBuf.WriteString("</vt:lpstr>")
+		}
+	}
+	app.HeadingPairs, app.TitlesOfParts = nil, nil
+	if headingsCount > 0 {
+		app.HeadingPairs = &xlsxVectorVariant{Content: fmt.Sprintf("<vt:vector size=\"%d\" baseType=\"variant\">%s</vt:vector>", headingsCount, headingsBuf.String())}
+		app.TitlesOfParts = &xlsxVectorLpstr{Content: fmt.Sprintf("<vt:vector size=\"%d\" baseType=\"lpstr\">%s</vt:vector>", titlesCount, titlesBuf.String())}
+	}
+	app.Vt = NameSpaceDocumentPropertiesVariantTypes.Value
+	output, _ := xml.Marshal(app)
+	f.saveFileList(defaultXMLPathDocPropsApp, output)
+}
+
 // SetDocProps provides a function to set document core properties. The
 // properties that can be set are:
 //
@@ -12,6 +12,8 @@
 package excelize
 
 import (
+	"bytes"
+	"encoding/xml"
 	"path/filepath"
 	"testing"
 
@@ -65,6 +67,69 @@ func TestGetAppProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAppPropsTitlesOfParts(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Data & Notes")
+	assert.NoError(t, err)
+	for _, sheet := range []string{"Sheet1", "Data & Notes"} {
+		sw, err := f.NewStreamWriter(sheet)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{sheet}))
+		assert.NoError(t, sw.Flush())
+	}
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$A$1"}},
+	}))
+	getTitlesOfParts := func(f *File) ([]string, xlsxVector) {
+		app := new(xlsxProperties)
+		assert.NoError(t, f.xmlNewDecoder(bytes.NewReader(f.readXML(defaultXMLPathDocPropsApp))).Decode(app))
+		var headings, titles xlsxVector
+		assert.NoError(t, xml.Unmarshal([]byte(app.HeadingPairs.Content), &headings))
+		assert.NoError(t, xml.Unmarshal([]byte(app.TitlesOfParts.Content), &titles))
+		assert.Equal(t, len(titles.Lpstr), titles.Size)
+		return titles.Lpstr, headings
+	}
+	file := filepath.Join("test", "TestAppPropsTitlesOfParts.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	titles, headings := getTitlesOfParts(f)
+	assert.Equal(t, f.GetSheetList(), titles)
+	assert.Equal(t, []string{"Sheet1", "Data & Notes", "Chart1"}, titles)
+	assert.Equal(t, 4, headings.Size)
+	assert.Equal(t, "Worksheets", *headings.Variant[0].Lpstr)
+	assert.Equal(t, 2, *headings.Variant[1].I4)
+	assert.Equal(t, "Charts", *headings.Variant[2].Lpstr)
+	assert.Equal(t, 1, *headings.Variant[3].I4)
+
+	// Test regenerate the sheet list and preserve the named ranges
+	f.Pkg.Store(defaultXMLPathDocPropsApp, []byte(`<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties" xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes"><HeadingPairs><vt:vector size="4" baseType="variant"><vt:variant><vt:lpstr>Worksheets</vt:lpstr></vt:variant><vt:variant><vt:i4>1</vt:i4></vt:variant><vt:variant><vt:lpstr>Named Ranges</vt:lpstr></vt:variant><vt:variant><vt:i4>1</vt:i4></vt:variant></vt:vector></HeadingPairs><TitlesOfParts><vt:vector size="2" baseType="lpstr"><vt:lpstr>Stale</vt:lpstr><vt:lpstr>Sheet1!Print_Area</vt:lpstr></vt:vector></TitlesOfParts></Properties>`))
+	assert.NoError(t, f.DeleteSheet("Chart1"))
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(file))
+	titles, headings = getTitlesOfParts(f)
+	assert.Equal(t, []string{"Sheet1", "Data & Notes", "Sheet1!Print_Area"}, titles)
+	assert.Equal(t, 4, headings.Size)
+	assert.Equal(t, "Named Ranges", *headings.Variant[2].Lpstr)
+	assert.NoError(t, f.Close())
+
+	// Test save workbook without the document application properties
+	f = NewFile()
+	f.Pkg.Delete(defaultXMLPathDocPropsApp)
+	assert.NoError(t, f.SaveAs(file))
+	_, ok := f.Pkg.Load(defaultXMLPathDocPropsApp)
+	assert.False(t, ok)
+	// Test save workbook with unsupported charset document application properties
+	f.Pkg.Store(defaultXMLPathDocPropsApp, MacintoshCyrillicCharset)
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+}
+
 func TestSetDocProps(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
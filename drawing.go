@@ -15,7 +15,9 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -64,7 +66,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		Lang:           &attrValString{Val: stringPtr("en-US")},
 		RoundedCorners: &attrValBool{Val: boolPtr(false)},
 		Chart: cChart{
-			Title: f.drawPlotAreaTitles(opts.Title, ""),
+			Title: f.drawPlotAreaTitles(opts.Title, &opts.TitleFormat, ""),
 			View3D: &cView3D{
 				RotX:        &attrValInt{Val: intPtr(chartView3DRotX[opts.Type])},
 				RotY:        &attrValInt{Val: intPtr(chartView3DRotY[opts.Type])},
@@ -81,10 +83,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 				Thickness: &attrValInt{Val: intPtr(0)},
 			},
 			PlotArea: &cPlotArea{},
-			Legend: &cLegend{
-				LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
-				Overlay:   &attrValBool{Val: boolPtr(false)},
-			},
+			Legend:   f.drawChartLegend(opts),
 
 			PlotVisOnly:      &attrValBool{Val: boolPtr(false)},
 			DispBlanksAs:     &attrValString{Val: stringPtr(opts.ShowBlanksAs)},
@@ -191,11 +190,234 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](xlsxChartSpace.Chart.PlotArea, comboCharts[idx]))
 		order += len(comboCharts[idx].Series)
 	}
+	if opts.XAxis.Type == "date" {
+		for _, ax := range xlsxChartSpace.Chart.PlotArea.CatAx {
+			xlsxChartSpace.Chart.PlotArea.DateAx = append(xlsxChartSpace.Chart.PlotArea.DateAx, f.drawPlotAreaDateAx(ax, opts))
+		}
+		xlsxChartSpace.Chart.PlotArea.CatAx = nil
+	}
 	chart, _ := xml.Marshal(xlsxChartSpace)
 	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
 	f.saveFileList(media, chart)
 }
 
+// addChartEx provides a function to create chart extension part as
+// xl/charts/chartEx%d.xml by given format sets, the chart extension part used
+// for the chart types introduced in Excel 2016, such as box and whisker chart.
+func (f *File) addChartEx(opts *Chart) {
+	count := f.countCharts()
+	chartSpace := cxChartSpace{
+		XMLNSa:  NameSpaceDrawingML.Value,
+		XMLNSr:  SourceRelationship.Value,
+		XMLNScx: NameSpaceDrawingMLChartEx.Value,
+		Chart: cxChart{
+			PlotArea: cxPlotArea{Axis: f.drawChartExAxes(opts)},
+		},
+		SpPr: f.drawShapeFill(opts.Fill, &cSpPr{
+			SolidFill: &aSolidFill{
+				SchemeClr: &aSchemeClr{Val: "bg1"},
+			},
+			Ln: f.drawChartLn(&opts.Border),
+		}),
+	}
+	if title := f.drawPlotAreaTitles(opts.Title, nil, ""); title != nil {
+		chartSpace.Chart.Title = &cxTitle{Pos: "t", Align: "ctr", Tx: cxTx{Rich: title.Tx.Rich}}
+	}
+	if pos, ok := chartLegendPosition[opts.Legend.Position]; ok {
+		legend := &cxLegend{Pos: pos, Align: "ctr"}
+		if pos == "tr" {
+			legend.Pos, legend.Align = "r", "min"
+		}
+		chartSpace.Chart.Legend = legend
+	}
+	chartSpace.Chart.PlotArea.SpPr = f.drawShapeFill(opts.PlotArea.Fill, nil)
+	for i, ser := range opts.Series {
+		data := &cxData{ID: i, NumDim: &cxDimension{Type: "val", F: ser.Values}}
+		if ser.Categories != "" {
+			data.StrDim = &cxDimension{Type: "cat", F: ser.Categories}
+		}
+		chartSpace.ChartData.Data = append(chartSpace.ChartData.Data, data)
+		series := &cxSeries{
+			LayoutID:  chartExLayoutIDs[opts.Type],
+			FormatIdx: i,
+			SpPr:      f.drawShapeFill(ser.Fill, nil),
+			DataID:    &attrValInt{Val: intPtr(i)},
+		}
+		if ser.Name != "" {
+			series.Tx = &cxTx{TxData: &cxTxData{F: ser.Name}}
+		}
+		if opts.PlotArea.ShowVal || opts.PlotArea.ShowCatName || opts.PlotArea.ShowSerName {
+			series.DataLabels = &cxDataLabels{
+				Pos: chartExDataLabelPos[opts.Type],
+				Visibility: &cxDataLabelVisibility{
+					SeriesName:   opts.PlotArea.ShowSerName,
+					CategoryName: opts.PlotArea.ShowCatName,
+					Value:        opts.PlotArea.ShowVal,
+				},
+			}
+		}
+		map[ChartType]func(ser *cxSeries, i int, opts *Chart){
+			BoxWhisker: f.drawChartExBoxWhiskerSeries,
+			Waterfall:  f.drawChartExWaterfallSeries,
+			Funnel:     func(ser *cxSeries, i int, opts *Chart) {},
+			Histogram:  f.drawChartExHistogramSeries,
+			Pareto:     f.drawChartExHistogramSeries,
+		}[opts.Type](series, i, opts)
+		chartSpace.Chart.PlotArea.PlotAreaRegion.Series = append(chartSpace.Chart.PlotArea.PlotAreaRegion.Series, series)
+	}
+	if opts.Type == Pareto {
+		for i := range opts.Series {
+			chartSpace.Chart.PlotArea.PlotAreaRegion.Series = append(chartSpace.Chart.PlotArea.PlotAreaRegion.Series, &cxSeries{
+				LayoutID:  "paretoLine",
+				OwnerIdx:  intPtr(i),
+				FormatIdx: len(opts.Series) + i,
+				AxisID:    []attrValInt{{Val: intPtr(2)}},
+			})
+		}
+	}
+	chart, _ := xml.Marshal(chartSpace)
+	media := "xl/charts/chartEx" + strconv.Itoa(count+1) + ".xml"
+	f.saveFileList(media, chart)
+}
+
+// drawChartExBoxWhiskerSeries provides a function to draw the layout
+// properties of the box and whisker chart series by given format sets.
+func (f *File) drawChartExBoxWhiskerSeries(ser *cxSeries, i int, opts *Chart) {
+	ser.LayoutPr = &cxLayoutPr{
+		Visibility: &cxVisibility{
+			MeanLine:    boolPtr(opts.BoxWhisker.ShowMeanLine),
+			MeanMarker:  boolPtr(opts.BoxWhisker.ShowMeanMarkers),
+			Nonoutliers: boolPtr(opts.BoxWhisker.ShowInnerPoints),
+			Outliers:    boolPtr(opts.BoxWhisker.ShowOutlierPoints),
+		},
+		Statistics: &cxStatistics{QuartileMethod: opts.BoxWhisker.QuartileMethod},
+	}
+}
+
+// drawChartExWaterfallSeries provides a function to draw the data points and
+// layout properties of the waterfall chart series by given format sets. The
+// increase and decrease fill will be applied to the data points by the values
+// of the cells at the time the chart was added.
+func (f *File) drawChartExWaterfallSeries(ser *cxSeries, i int, opts *Chart) {
+	ser.LayoutPr = &cxLayoutPr{
+		Visibility: &cxVisibility{ConnectorLines: boolPtr(opts.Waterfall.ShowConnectorLines)},
+	}
+	var values []float64
+	if i < len(opts.Waterfall.values) {
+		values = opts.Waterfall.values[i]
+	}
+	count, totals := len(values), make(map[int]bool, len(opts.Waterfall.Totals))
+	for _, idx := range opts.Waterfall.Totals {
+		if totals[idx] = true; idx+1 > count {
+			count = idx + 1
+		}
+	}
+	if len(totals) > 0 {
+		indexes := make([]int, 0, len(totals))
+		for idx := range totals {
+			indexes = append(indexes, idx)
+		}
+		sort.Ints(indexes)
+		ser.LayoutPr.Subtotals = &cxSubtotals{}
+		for _, idx := range indexes {
+			ser.LayoutPr.Subtotals.Idx = append(ser.LayoutPr.Subtotals.Idx, attrValInt{Val: intPtr(idx)})
+		}
+	}
+	for idx := 0; idx < count; idx++ {
+		fill := opts.Waterfall.IncreaseFill
+		if totals[idx] {
+			fill = opts.Waterfall.TotalFill
+		} else if idx >= len(values) {
+			continue
+		} else if values[idx] < 0 {
+			fill = opts.Waterfall.DecreaseFill
+		}
+		if spPr := f.drawShapeFill(fill, nil); spPr != nil {
+			ser.DataPt = append(ser.DataPt, &cxDataPt{Idx: idx, SpPr: spPr})
+		}
+	}
+}
+
+// drawChartExHistogramSeries provides a function to draw the layout
+// properties of the histogram and Pareto chart series by given format sets.
+// The bars of the Pareto chart are plotted on the primary axes, and the
+// cumulative percentage line is plotted on the secondary value axis.
+func (f *File) drawChartExHistogramSeries(ser *cxSeries, i int, opts *Chart) {
+	ser.LayoutPr = &cxLayoutPr{}
+	if opts.Histogram.ByCategory {
+		ser.LayoutPr.Aggregation = stringPtr("")
+	} else {
+		binning := &cxBinning{IntervalClosed: "r"}
+		if opts.Histogram.Overflow != nil {
+			binning.Overflow = strconv.FormatFloat(*opts.Histogram.Overflow, 'f', -1, 64)
+		}
+		if opts.Histogram.Underflow != nil {
+			binning.Underflow = strconv.FormatFloat(*opts.Histogram.Underflow, 'f', -1, 64)
+		}
+		if opts.Histogram.BinWidth > 0 {
+			binning.BinSize = &attrValFloat{Val: float64Ptr(opts.Histogram.BinWidth)}
+		}
+		if opts.Histogram.BinCount > 0 {
+			binning.BinCount = &attrValInt{Val: intPtr(opts.Histogram.BinCount)}
+		}
+		ser.LayoutPr.Binning = binning
+	}
+	if opts.Type == Pareto {
+		ser.AxisID = []attrValInt{{Val: intPtr(0)}, {Val: intPtr(1)}}
+	}
+}
+
+// drawChartExAxes provides a function to draw the cx:axis elements of the
+// chart extension by given format sets, the funnel chart only has the
+// category axis.
+func (f *File) drawChartExAxes(opts *Chart) []*cxAxis {
+	catAx := &cxAxis{
+		ID:         0,
+		Hidden:     opts.XAxis.None,
+		CatScaling: &cxCatScaling{GapWidth: "1"},
+		TickLabels: stringPtr(""),
+	}
+	if opts.Type == Funnel {
+		catAx.CatScaling.GapWidth = strconv.FormatFloat(float64(*opts.Funnel.GapWidth)/100, 'f', -1, 64)
+		return []*cxAxis{catAx}
+	}
+	if opts.Type == Histogram || opts.Type == Pareto {
+		catAx.CatScaling.GapWidth = "0"
+	}
+	axes := []*cxAxis{catAx, f.drawChartExValAx(&opts.YAxis)}
+	if opts.Type == Pareto {
+		axes = append(axes, &cxAxis{
+			ID:         2,
+			ValScaling: &cxValScaling{Max: "1", Min: "0"},
+			Units:      &cxUnits{Unit: "percentage"},
+			TickLabels: stringPtr(""),
+		})
+	}
+	return axes
+}
+
+// drawChartExValAx provides a function to draw the cx:axis element for the
+// value axis of the chart extension by given format sets.
+func (f *File) drawChartExValAx(opts *ChartAxis) *cxAxis {
+	axis := &cxAxis{ID: 1, Hidden: opts.None, ValScaling: &cxValScaling{}, TickLabels: stringPtr("")}
+	if opts.Maximum != nil {
+		axis.ValScaling.Max = strconv.FormatFloat(*opts.Maximum, 'f', -1, 64)
+	}
+	if opts.Minimum != nil {
+		axis.ValScaling.Min = strconv.FormatFloat(*opts.Minimum, 'f', -1, 64)
+	}
+	if opts.MajorUnit != 0 {
+		axis.ValScaling.MajorUnit = strconv.FormatFloat(opts.MajorUnit, 'f', -1, 64)
+	}
+	if opts.MajorGridLines {
+		axis.MajorGridlines = stringPtr("")
+	}
+	if opts.MinorGridLines {
+		axis.MinorGridlines = stringPtr("")
+	}
+	return axis
+}
+
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
 // and column series charts by given format sets.
 func (f *File) drawBaseChart(pa *cPlotArea, opts *Chart) *cPlotArea {
@@ -438,7 +660,20 @@ func (f *File) drawBaseChart(pa *cPlotArea, opts *Chart) *cPlotArea {
 			ValAx:       valAx,
 		},
 	}
-	return charts[opts.Type]
+	plotArea := charts[opts.Type]
+	if plotArea.BarChart != nil || plotArea.Bar3DChart != nil {
+		if opts.GapWidth != nil {
+			c[0].GapWidth = &attrValInt{Val: intPtr(int(*opts.GapWidth))}
+		}
+		if opts.Overlap != nil && plotArea.BarChart != nil {
+			c[0].Overlap = &attrValInt{Val: opts.Overlap}
+		}
+	}
+	if plotAreaChartSerAx[opts.Type] {
+		c[0].AxID = append(c[0].AxID, &attrValInt{Val: intPtr(100000005)})
+		plotArea.SerAx = f.drawPlotAreaSerAx(opts)
+	}
+	return plotArea
 }
 
 // drawDoughnutChart provides a function to draw the c:plotArea element for
@@ -498,11 +733,12 @@ func (f *File) drawLine3DChart(pa *cPlotArea, opts *Chart) *cPlotArea {
 				},
 				Ser:   f.drawChartSeries(opts),
 				DLbls: f.drawChartDLbls(opts),
-				AxID:  f.genAxID(opts),
+				AxID:  append(f.genAxID(opts), &attrValInt{Val: intPtr(100000005)}),
 			},
 		},
 		CatAx: f.drawPlotAreaCatAx(pa, opts),
 		ValAx: f.drawPlotAreaValAx(pa, opts),
+		SerAx: f.drawPlotAreaSerAx(opts),
 	}
 }
 
@@ -537,51 +773,59 @@ func (f *File) drawPie3DChart(pa *cPlotArea, opts *Chart) *cPlotArea {
 }
 
 // drawPieOfPieChart provides a function to draw the c:plotArea element for
-// pie chart by given format sets.
+// pie of pie chart by given format sets.
 func (f *File) drawPieOfPieChart(pa *cPlotArea, opts *Chart) *cPlotArea {
-	var splitPos *attrValInt
-	if opts.PlotArea.SecondPlotValues > 0 {
-		splitPos = &attrValInt{Val: intPtr(opts.PlotArea.SecondPlotValues)}
-	}
-	return &cPlotArea{
-		OfPieChart: []*cCharts{
-			{
-				OfPieType: &attrValString{
-					Val: stringPtr("pie"),
-				},
-				VaryColors: &attrValBool{
-					Val: opts.VaryColors,
-				},
-				Ser:      f.drawChartSeries(opts),
-				SplitPos: splitPos,
-				SerLines: &attrValString{},
-			},
-		},
-	}
+	return f.drawOfPieChart("pie", opts)
 }
 
 // drawBarOfPieChart provides a function to draw the c:plotArea element for
-// pie chart by given format sets.
+// bar of pie chart by given format sets.
 func (f *File) drawBarOfPieChart(pa *cPlotArea, opts *Chart) *cPlotArea {
-	var splitPos *attrValInt
-	if opts.PlotArea.SecondPlotValues > 0 {
-		splitPos = &attrValInt{Val: intPtr(opts.PlotArea.SecondPlotValues)}
+	return f.drawOfPieChart("bar", opts)
+}
+
+// drawOfPieChart provides a function to draw the c:plotArea element for pie
+// of pie and bar of pie chart by given type of the second plot and format
+// sets.
+func (f *File) drawOfPieChart(ofPieType string, opts *Chart) *cPlotArea {
+	c := &cCharts{
+		OfPieType: &attrValString{
+			Val: stringPtr(ofPieType),
+		},
+		VaryColors: &attrValBool{
+			Val: opts.VaryColors,
+		},
+		Ser: f.drawChartSeries(opts),
+	}
+	if opts.GapWidth != nil {
+		c.GapWidth = &attrValInt{Val: intPtr(int(*opts.GapWidth))}
+	}
+	plotArea := &opts.PlotArea
+	if splitType, ok := map[ChartSplitType]string{
+		ChartSplitPosition: "pos", ChartSplitValue: "val", ChartSplitPercent: "percent", ChartSplitCustom: "cust",
+	}[plotArea.SplitType]; ok {
+		c.SplitType = &attrValString{Val: stringPtr(splitType)}
+	}
+	switch plotArea.SplitType {
+	case ChartSplitValue, ChartSplitPercent:
+		c.SplitPos = &attrValFloat{Val: float64Ptr(plotArea.SplitValue)}
+	case ChartSplitCustom:
+		c.CustSplit = &cCustSplit{}
+		for _, idx := range plotArea.SecondPlotPoints {
+			c.CustSplit.SecondPiePt = append(c.CustSplit.SecondPiePt, &attrValInt{Val: intPtr(idx)})
+		}
+	default:
+		if plotArea.SecondPlotValues > 0 {
+			c.SplitPos = &attrValFloat{Val: float64Ptr(float64(plotArea.SecondPlotValues))}
+		}
 	}
-	return &cPlotArea{
-		OfPieChart: []*cCharts{
-			{
-				OfPieType: &attrValString{
-					Val: stringPtr("bar"),
-				},
-				VaryColors: &attrValBool{
-					Val: opts.VaryColors,
-				},
-				SplitPos: splitPos,
-				Ser:      f.drawChartSeries(opts),
-				SerLines: &attrValString{},
-			},
-		},
+	if plotArea.SecondPlotSize > 0 {
+		c.SecondPieSize = &attrValInt{Val: intPtr(int(plotArea.SecondPlotSize))}
+	}
+	if plotArea.SeriesLines.Type != ChartLineNone {
+		c.SerLines = &cChartLines{SpPr: f.drawChartLineSpPr(Fill{}, &plotArea.SeriesLines)}
 	}
+	return &cPlotArea{OfPieChart: []*cCharts{c}}
 }
 
 // drawRadarChart provides a function to draw the c:plotArea element for radar
@@ -747,6 +991,8 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 			DPt:              f.drawChartSeriesDPt(k, opts),
 			DLbls:            f.drawChartSeriesDLbls(k, opts),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(&opts.Series[k].Trendline),
+			ErrBars:          f.drawChartSeriesErrBars(&opts.Series[k].ErrorBars, opts),
 			Cat:              f.drawChartSeriesCat(opts.Series[k], opts),
 			Smooth:           &attrValBool{Val: boolPtr(opts.Series[k].Line.Smooth)},
 			Val:              f.drawChartSeriesVal(opts.Series[k], opts),
@@ -759,6 +1005,116 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 	return &ser
 }
 
+// drawChartSeriesTrendline provides a function to draw the c:trendline element
+// by given trendline format sets.
+func (f *File) drawChartSeriesTrendline(opts *ChartTrendline) *cTrendline {
+	trendlineType, ok := chartTrendlineTypes[opts.Type]
+	if !ok {
+		return nil
+	}
+	trendline := &cTrendline{
+		Name:          opts.Name,
+		TrendlineType: &attrValString{Val: stringPtr(trendlineType)},
+		DispRSqr:      &attrValBool{Val: boolPtr(opts.DisplayRSquared)},
+		DispEq:        &attrValBool{Val: boolPtr(opts.DisplayEquation)},
+	}
+	switch opts.Type {
+	case ChartTrendlinePolynomial:
+		trendline.Order = &attrValInt{Val: intPtr(opts.Order)}
+	case ChartTrendlineMovingAverage:
+		trendline.Period = &attrValInt{Val: intPtr(opts.Period)}
+		trendline.DispRSqr, trendline.DispEq = nil, nil
+	}
+	if opts.Forward > 0 {
+		trendline.Forward = &attrValFloat{Val: float64Ptr(opts.Forward)}
+	}
+	if opts.Backward > 0 {
+		trendline.Backward = &attrValFloat{Val: float64Ptr(opts.Backward)}
+	}
+	if opts.Intercept != nil {
+		trendline.Intercept = &attrValFloat{Val: float64Ptr(*opts.Intercept)}
+	}
+	if opts.DisplayEquation || opts.DisplayRSquared {
+		trendline.TrendlineLbl = &cTrendlineLbl{NumFmt: &cNumFmt{FormatCode: "General"}}
+	}
+	trendline.SpPr = f.drawChartLineSpPr(opts.Fill, &opts.Line)
+	return trendline
+}
+
+// drawChartSeriesErrBars provides a function to draw the c:errBars element by
+// given error bars and chart format sets.
+func (f *File) drawChartSeriesErrBars(opts *ChartErrorBars, chart *Chart) []*cErrBars {
+	errValType, ok := chartErrorBarsTypes[opts.Type]
+	if !ok {
+		return nil
+	}
+	errBarType := "both"
+	if opts.Type == ChartErrorBarsCustom {
+		if opts.Plus == "" {
+			errBarType = "minus"
+		}
+		if opts.Minus == "" {
+			errBarType = "plus"
+		}
+	}
+	directions := map[ChartErrorBarsDirection][]string{
+		ChartErrorBarsDirectionY:    {"y"},
+		ChartErrorBarsDirectionX:    {"x"},
+		ChartErrorBarsDirectionBoth: {"x", "y"},
+	}[opts.Direction]
+	var errBars []*cErrBars
+	for _, dir := range directions {
+		errBar := &cErrBars{
+			ErrBarType: &attrValString{Val: stringPtr(errBarType)},
+			ErrValType: &attrValString{Val: stringPtr(errValType)},
+			NoEndCap:   &attrValBool{Val: boolPtr(opts.NoEndCap)},
+			SpPr:       f.drawChartLineSpPr(opts.Fill, &opts.Line),
+		}
+		if chartErrorBarsChartTypes[chart.Type] {
+			errBar.ErrDir = &attrValString{Val: stringPtr(dir)}
+		}
+		if opts.Type == ChartErrorBarsCustom {
+			errBar.Plus = f.drawChartErrBarsVal(opts.Plus, opts.plusValues)
+			errBar.Minus = f.drawChartErrBarsVal(opts.Minus, opts.minusValues)
+		} else if opts.Type != ChartErrorBarsStandardError {
+			errBar.Val = &attrValFloat{Val: float64Ptr(opts.Value)}
+		}
+		errBars = append(errBars, errBar)
+	}
+	return errBars
+}
+
+// drawChartErrBarsVal provides a function to draw the c:plus or c:minus
+// element of the custom error bars by given formula reference and the cached
+// values of the cells.
+func (f *File) drawChartErrBarsVal(ref string, values []float64) *cVal {
+	if ref == "" {
+		return nil
+	}
+	numCache := &cNumCache{FormatCode: "General", PtCount: &attrValInt{Val: intPtr(len(values))}}
+	for idx, val := range values {
+		numCache.Pt = append(numCache.Pt, &cPt{IDx: idx, V: stringPtr(strconv.FormatFloat(val, 'f', -1, 64))})
+	}
+	return &cVal{NumRef: &cNumRef{F: ref, NumCache: numCache}}
+}
+
+// drawChartLineSpPr provides a function to draw the c:spPr element of the
+// trendline and error bars by given fill and line format sets.
+func (f *File) drawChartLineSpPr(fill Fill, line *ChartLine) *cSpPr {
+	spPr := f.drawShapeFill(fill, nil)
+	if spPr == nil && line.Width == 0 && line.Type != ChartLineNone {
+		return nil
+	}
+	ln := &aLn{W: f.ptToEMUs(line.Width), Cap: "rnd"}
+	if spPr != nil {
+		ln.SolidFill = spPr.SolidFill
+	}
+	if line.Type == ChartLineNone {
+		ln.SolidFill, ln.NoFill = nil, &attrValString{}
+	}
+	return &cSpPr{Ln: ln}
+}
+
 // drawShapeFill provides a function to draw the a:solidFill element by given
 // fill format sets.
 func (f *File) drawShapeFill(fill Fill, spPr *cSpPr) *cSpPr {
@@ -827,16 +1183,61 @@ func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
 		},
 	}}
 	chartSeriesDPt := map[ChartType][]*cDPt{Pie: dpt, Pie3D: dpt}
-	return chartSeriesDPt[opts.Type]
+	dpts := chartSeriesDPt[opts.Type]
+	for _, dp := range opts.Series[i].DataPoints {
+		var pt *cDPt
+		for _, d := range dpts {
+			if *d.IDx.Val == dp.Index {
+				pt = d
+			}
+		}
+		if pt == nil {
+			pt = &cDPt{IDx: &attrValInt{Val: intPtr(dp.Index)}}
+			dpts = append(dpts, pt)
+		}
+		f.drawChartDataPoint(pt, &dp, opts)
+	}
+	sort.Slice(dpts, func(i, j int) bool { return *dpts[i].IDx.Val < *dpts[j].IDx.Val })
+	return dpts
+}
+
+// drawChartDataPoint provides a function to draw the format of the data point
+// by given data point format sets. The marker only works for the line and
+// scatter chart, and the explosion only works for the pie and doughnut chart.
+func (f *File) drawChartDataPoint(pt *cDPt, dp *ChartDataPoint, opts *Chart) {
+	if spPr := f.drawShapeFill(dp.Fill, nil); spPr != nil {
+		if pt.SpPr == nil {
+			pt.SpPr = &cSpPr{}
+		}
+		pt.SpPr.NoFill, pt.SpPr.SolidFill = spPr.NoFill, spPr.SolidFill
+	}
+	if dp.Border.Width != 0 || dp.Border.Type == ChartLineNone {
+		if pt.SpPr == nil {
+			pt.SpPr = &cSpPr{}
+		}
+		pt.SpPr.Ln = f.drawChartLineSpPr(dp.Fill, &dp.Border).Ln
+	}
+	marker := &dp.Marker
+	if (opts.Type == Line || opts.Type == Scatter) && (marker.Symbol != "" || marker.Size != 0 ||
+		marker.Fill.Type != "" || marker.Border.Width != 0 || marker.Border.Type == ChartLineNone) {
+		pt.Marker = f.drawChartMarker(marker, &cMarker{})
+	}
+	if _, ok := map[ChartType]bool{Pie: true, Pie3D: true, Doughnut: true, PieOfPie: true, BarOfPie: true}[opts.Type]; ok && dp.Explosion > 0 {
+		pt.Explosion = &attrValInt{Val: intPtr(dp.Explosion)}
+	}
 }
 
 // drawChartSeriesCat provides a function to draw the c:cat element by given
 // chart series and format sets.
 func (f *File) drawChartSeriesCat(v ChartSeries, opts *Chart) *cCat {
 	cat := &cCat{
-		StrRef: &cStrRef{
-			F: v.Categories,
-		},
+		StrRef: f.drawChartSeriesStrRef(v.Categories, v.categories),
+	}
+	if opts.XAxis.Type == "date" {
+		cat = &cCat{NumRef: f.drawChartSeriesNumRef(v.Categories, v.categories)}
+		if cat.NumRef.NumCache != nil {
+			cat.NumRef.NumCache.FormatCode = getChartDateAxisNumFmt(opts)
+		}
 	}
 	chartSeriesCat := map[ChartType]*cCat{Scatter: nil, Bubble: nil, Bubble3D: nil}
 	if _, ok := chartSeriesCat[opts.Type]; ok || v.Categories == "" {
@@ -849,9 +1250,7 @@ func (f *File) drawChartSeriesCat(v ChartSeries, opts *Chart) *cCat {
 // chart series and format sets.
 func (f *File) drawChartSeriesVal(v ChartSeries, opts *Chart) *cVal {
 	val := &cVal{
-		NumRef: &cNumRef{
-			F: v.Values,
-		},
+		NumRef: f.drawChartSeriesNumRef(v.Values, v.values),
 	}
 	chartSeriesVal := map[ChartType]*cVal{Scatter: nil, Bubble: nil, Bubble3D: nil}
 	if _, ok := chartSeriesVal[opts.Type]; ok {
@@ -868,12 +1267,6 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 		Symbol: defaultSymbol[opts.Type],
 		Size:   &attrValInt{Val: intPtr(5)},
 	}
-	if symbol := stringPtr(opts.Series[i].Marker.Symbol); *symbol != "" {
-		marker.Symbol = &attrValString{Val: symbol}
-	}
-	if size := intPtr(opts.Series[i].Marker.Size); *size != 0 {
-		marker.Size = &attrValInt{Val: size}
-	}
 	if i < 6 {
 		marker.SpPr = &cSpPr{
 			SolidFill: &aSolidFill{
@@ -891,18 +1284,39 @@ func (f *File) drawChartSeriesMarker(i int, opts *Chart) *cMarker {
 			},
 		}
 	}
-	marker.SpPr = f.drawShapeFill(opts.Series[i].Marker.Fill, marker.SpPr)
+	marker = f.drawChartMarker(&opts.Series[i].Marker, marker)
 	chartSeriesMarker := map[ChartType]*cMarker{Scatter: marker, Line: marker}
 	return chartSeriesMarker[opts.Type]
 }
 
+// drawChartMarker provides a function to draw the symbol, size, fill and
+// border of the c:marker element by given marker format sets.
+func (f *File) drawChartMarker(opts *ChartMarker, marker *cMarker) *cMarker {
+	if opts.Symbol != "" {
+		marker.Symbol = &attrValString{Val: stringPtr(opts.Symbol)}
+	}
+	if opts.Size != 0 {
+		marker.Size = &attrValInt{Val: intPtr(opts.Size)}
+	}
+	marker.SpPr = f.drawShapeFill(opts.Fill, marker.SpPr)
+	if opts.Border.Width != 0 || opts.Border.Type == ChartLineNone {
+		if marker.SpPr == nil {
+			marker.SpPr = &cSpPr{}
+		}
+		ln := f.drawChartLineSpPr(opts.Fill, &opts.Border).Ln
+		if ln.SolidFill == nil && ln.NoFill == nil && marker.SpPr.Ln != nil {
+			ln.SolidFill = marker.SpPr.Ln.SolidFill
+		}
+		marker.SpPr.Ln = ln
+	}
+	return marker
+}
+
 // drawChartSeriesXVal provides a function to draw the c:xVal element by given
 // chart series and format sets.
 func (f *File) drawChartSeriesXVal(v ChartSeries, opts *Chart) *cCat {
 	cat := &cCat{
-		StrRef: &cStrRef{
-			F: v.Categories,
-		},
+		StrRef: f.drawChartSeriesStrRef(v.Categories, v.categories),
 	}
 	chartSeriesXVal := map[ChartType]*cCat{Scatter: cat, Bubble: cat, Bubble3D: cat}
 	return chartSeriesXVal[opts.Type]
@@ -912,9 +1326,7 @@ func (f *File) drawChartSeriesXVal(v ChartSeries, opts *Chart) *cCat {
 // chart series and format sets.
 func (f *File) drawChartSeriesYVal(v ChartSeries, opts *Chart) *cVal {
 	val := &cVal{
-		NumRef: &cNumRef{
-			F: v.Values,
-		},
+		NumRef: f.drawChartSeriesNumRef(v.Values, v.values),
 	}
 	chartSeriesYVal := map[ChartType]*cVal{Scatter: val, Bubble: val, Bubble3D: val}
 	return chartSeriesYVal[opts.Type]
@@ -926,17 +1338,53 @@ func (f *File) drawCharSeriesBubbleSize(v ChartSeries, opts *Chart) *cVal {
 	if _, ok := map[ChartType]bool{Bubble: true, Bubble3D: true}[opts.Type]; !ok {
 		return nil
 	}
-	fVal := v.Values
 	if v.Sizes != "" {
-		fVal = v.Sizes
+		return &cVal{NumRef: &cNumRef{F: v.Sizes}}
 	}
 	return &cVal{
-		NumRef: &cNumRef{
-			F: fVal,
-		},
+		NumRef: f.drawChartSeriesNumRef(v.Values, v.values),
 	}
 }
 
+// drawChartSeriesStrRef provides a function to draw the c:strRef element by
+// given formula reference and the chart series data reference which
+// referenced by the defined name or the table.
+func (f *File) drawChartSeriesStrRef(formula string, ref *chartSeriesRef) *cStrRef {
+	if ref == nil {
+		return &cStrRef{F: formula}
+	}
+	strRef := &cStrRef{F: ref.formula}
+	if ref.cache != nil {
+		strRef.StrCache = &cStrCache{PtCount: &attrValInt{Val: intPtr(len(ref.cache))}}
+		for idx, val := range ref.cache {
+			if val != "" {
+				strRef.StrCache.Pt = append(strRef.StrCache.Pt, &cPt{IDx: idx, V: stringPtr(val)})
+			}
+		}
+	}
+	return strRef
+}
+
+// drawChartSeriesNumRef provides a function to draw the c:numRef element by
+// given formula reference and the chart series data reference which
+// referenced by the defined name or the table, the non-numeric cached values
+// will be ignored.
+func (f *File) drawChartSeriesNumRef(formula string, ref *chartSeriesRef) *cNumRef {
+	if ref == nil {
+		return &cNumRef{F: formula}
+	}
+	numRef := &cNumRef{F: ref.formula}
+	if ref.cache != nil {
+		numRef.NumCache = &cNumCache{FormatCode: "General", PtCount: &attrValInt{Val: intPtr(len(ref.cache))}}
+		for idx, val := range ref.cache {
+			if ok, _, _ := isNumeric(val); ok {
+				numRef.NumCache.Pt = append(numRef.NumCache.Pt, &cPt{IDx: idx, V: stringPtr(val)})
+			}
+		}
+	}
+	return numRef
+}
+
 // drawCharSeriesBubble3D provides a function to draw the c:bubble3D element
 // by given format sets.
 func (f *File) drawCharSeriesBubble3D(opts *Chart) *attrValBool {
@@ -1026,7 +1474,7 @@ func (f *File) drawPlotAreaCatAx(pa *cPlotArea, opts *Chart) []*cAxs {
 		NumFmt:        &cNumFmt{FormatCode: "General"},
 		MajorTickMark: &attrValString{Val: stringPtr("none")},
 		MinorTickMark: &attrValString{Val: stringPtr("none")},
-		Title:         f.drawPlotAreaTitles(opts.XAxis.Title, ""),
+		Title:         f.drawPlotAreaTitles(opts.XAxis.Title, &opts.XAxis.TitleFormat, ""),
 		TickLblPos:    &attrValString{Val: stringPtr(tickLblPosVal[opts.XAxis.TickLabelPosition])},
 		SpPr:          f.drawPlotAreaSpPr(),
 		TxPr:          f.drawPlotAreaTxPr(&opts.XAxis),
@@ -1059,6 +1507,61 @@ func (f *File) drawPlotAreaCatAx(pa *cPlotArea, opts *Chart) []*cAxs {
 	return []*cAxs{ax}
 }
 
+// drawPlotAreaDateAx provides a function to draw the c:dateAx element by given
+// category axis and format sets.
+func (f *File) drawPlotAreaDateAx(catAx *cAxs, opts *Chart) *cDateAx {
+	ax := &cDateAx{
+		AxID:           catAx.AxID,
+		Scaling:        catAx.Scaling,
+		Delete:         catAx.Delete,
+		AxPos:          catAx.AxPos,
+		MajorGridlines: catAx.MajorGridlines,
+		MinorGridlines: catAx.MinorGridlines,
+		Title:          catAx.Title,
+		NumFmt:         catAx.NumFmt,
+		MajorTickMark:  catAx.MajorTickMark,
+		MinorTickMark:  catAx.MinorTickMark,
+		TickLblPos:     catAx.TickLblPos,
+		SpPr:           catAx.SpPr,
+		TxPr:           catAx.TxPr,
+		CrossAx:        catAx.CrossAx,
+		Crosses:        catAx.Crosses,
+		Auto:           &attrValBool{Val: boolPtr(false)},
+		LblOffset:      catAx.LblOffset,
+	}
+	if f.drawChartNumFmt(opts.XAxis.NumFmt) == nil {
+		ax.NumFmt = &cNumFmt{FormatCode: getChartDateAxisNumFmt(opts), SourceLinked: true}
+	}
+	for _, unit := range []struct {
+		val  string
+		attr **attrValString
+	}{
+		{val: opts.XAxis.BaseTimeUnit, attr: &ax.BaseTimeUnit},
+		{val: opts.XAxis.MajorTimeUnit, attr: &ax.MajorTimeUnit},
+		{val: opts.XAxis.MinorTimeUnit, attr: &ax.MinorTimeUnit},
+	} {
+		if unit.val != "" {
+			*unit.attr = &attrValString{Val: stringPtr(unit.val)}
+		}
+	}
+	if opts.XAxis.MajorUnit != 0 {
+		ax.MajorUnit = &attrValFloat{Val: float64Ptr(opts.XAxis.MajorUnit)}
+	}
+	if opts.XAxis.MinorUnit != 0 {
+		ax.MinorUnit = &attrValFloat{Val: float64Ptr(opts.XAxis.MinorUnit)}
+	}
+	return ax
+}
+
+// getChartDateAxisNumFmt provides a function to get the number format code of
+// the date axis and the cached category values by given format sets.
+func getChartDateAxisNumFmt(opts *Chart) string {
+	if opts.XAxis.NumFmt.CustomNumFmt != "" {
+		return opts.XAxis.NumFmt.CustomNumFmt
+	}
+	return defaultChartDateAxisNumFmt
+}
+
 // drawPlotAreaValAx provides a function to draw the c:valAx element.
 func (f *File) drawPlotAreaValAx(pa *cPlotArea, opts *Chart) []*cAxs {
 	maxVal := &attrValFloat{Val: opts.YAxis.Maximum}
@@ -1083,7 +1586,7 @@ func (f *File) drawPlotAreaValAx(pa *cPlotArea, opts *Chart) []*cAxs {
 		},
 		Delete: &attrValBool{Val: boolPtr(opts.YAxis.None)},
 		AxPos:  &attrValString{Val: stringPtr(valAxPos[opts.YAxis.ReverseOrder])},
-		Title:  f.drawPlotAreaTitles(opts.YAxis.Title, "horz"),
+		Title:  f.drawPlotAreaTitles(opts.YAxis.Title, &opts.YAxis.TitleFormat, "horz"),
 		NumFmt: &cNumFmt{
 			FormatCode: chartValAxNumFmtFormatCode[opts.Type],
 		},
@@ -1111,6 +1614,9 @@ func (f *File) drawPlotAreaValAx(pa *cPlotArea, opts *Chart) []*cAxs {
 	if opts.YAxis.MajorUnit != 0 {
 		ax.MajorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MajorUnit)}
 	}
+	if opts.YAxis.MinorUnit != 0 {
+		ax.MinorUnit = &attrValFloat{Val: float64Ptr(opts.YAxis.MinorUnit)}
+	}
 	if opts.order > 0 && opts.YAxis.Secondary && pa.ValAx != nil {
 		ax.AxID = &attrValInt{Val: intPtr(opts.YAxis.axID)}
 		ax.AxPos = &attrValString{Val: stringPtr("r")}
@@ -1123,30 +1629,25 @@ func (f *File) drawPlotAreaValAx(pa *cPlotArea, opts *Chart) []*cAxs {
 
 // drawPlotAreaSerAx provides a function to draw the c:serAx element.
 func (f *File) drawPlotAreaSerAx(opts *Chart) []*cAxs {
-	maxVal := &attrValFloat{Val: opts.YAxis.Maximum}
-	minVal := &attrValFloat{Val: opts.YAxis.Minimum}
-	if opts.YAxis.Maximum == nil {
-		maxVal = nil
-	}
-	if opts.YAxis.Minimum == nil {
-		minVal = nil
-	}
-	return []*cAxs{
-		{
-			AxID: &attrValInt{Val: intPtr(100000005)},
-			Scaling: &cScaling{
-				Orientation: &attrValString{Val: stringPtr(orientation[opts.YAxis.ReverseOrder])},
-				Max:         maxVal,
-				Min:         minVal,
-			},
-			Delete:     &attrValBool{Val: boolPtr(opts.YAxis.None)},
-			AxPos:      &attrValString{Val: stringPtr(catAxPos[opts.XAxis.ReverseOrder])},
-			TickLblPos: &attrValString{Val: stringPtr(tickLblPosVal[opts.YAxis.TickLabelPosition])},
-			SpPr:       f.drawPlotAreaSpPr(),
-			TxPr:       f.drawPlotAreaTxPr(nil),
-			CrossAx:    &attrValInt{Val: intPtr(100000001)},
+	ax := &cAxs{
+		AxID: &attrValInt{Val: intPtr(100000005)},
+		Scaling: &cScaling{
+			Orientation: &attrValString{Val: stringPtr(orientation[opts.ZAxis.ReverseOrder])},
 		},
+		Delete:        &attrValBool{Val: boolPtr(opts.ZAxis.None)},
+		AxPos:         &attrValString{Val: stringPtr(catAxPos[opts.XAxis.ReverseOrder])},
+		Title:         f.drawPlotAreaTitles(opts.ZAxis.Title, &opts.ZAxis.TitleFormat, ""),
+		MajorTickMark: &attrValString{Val: stringPtr("none")},
+		MinorTickMark: &attrValString{Val: stringPtr("none")},
+		TickLblPos:    &attrValString{Val: stringPtr(tickLblPosVal[opts.ZAxis.TickLabelPosition])},
+		SpPr:          f.drawPlotAreaSpPr(),
+		TxPr:          f.drawPlotAreaTxPr(&opts.ZAxis),
+		CrossAx:       &attrValInt{Val: intPtr(100000001)},
 	}
+	if opts.ZAxis.MajorGridLines {
+		ax.MajorGridlines = &cChartLines{SpPr: f.drawPlotAreaSpPr()}
+	}
+	return []*cAxs{ax}
 }
 
 // drawChartFont provides a function to draw the a:rPr element.
@@ -1166,8 +1667,11 @@ func drawChartFont(fnt *Font, r *aRPr) {
 		r.SolidFill.SchemeClr = nil
 		r.SolidFill.SrgbClr = &attrValString{Val: stringPtr(strings.ReplaceAll(strings.ToUpper(fnt.Color), "#", ""))}
 	}
+	if typeface := map[string]string{"major": "+mj-lt", "minor": "+mn-lt"}[strings.ToLower(fnt.Scheme)]; typeface != "" && fnt.Family == "" {
+		r.Latin = &xlsxCTTextFont{Typeface: typeface}
+	}
 	if fnt.Family != "" {
-		r.Latin.Typeface = fnt.Family
+		r.Latin = &xlsxCTTextFont{Typeface: fnt.Family}
 	}
 	if fnt.Size > 0 {
 		r.Sz = fnt.Size * 100
@@ -1175,26 +1679,77 @@ func drawChartFont(fnt *Font, r *aRPr) {
 	if fnt.Strike {
 		r.Strike = "sngStrike"
 	}
+	switch strings.ToLower(fnt.VertAlign) {
+	case "superscript":
+		r.Baseline = 30000
+	case "subscript":
+		r.Baseline = -25000
+	}
 }
 
-// drawPlotAreaTitles provides a function to draw the c:title element.
-func (f *File) drawPlotAreaTitles(runs []RichTextRun, vert string) *cTitle {
-	if len(runs) == 0 {
+// drawChartLegend provides a function to draw the c:legend element by given
+// format sets.
+func (f *File) drawChartLegend(opts *Chart) *cLegend {
+	legend := &cLegend{
+		LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
+		Overlay:   &attrValBool{Val: boolPtr(opts.Legend.Overlay)},
+		SpPr:      f.drawShapeFill(opts.Legend.Fill, nil),
+	}
+	for _, idx := range opts.Legend.DeletedEntries {
+		legend.LegendEntry = append(legend.LegendEntry, &cLegendEntry{
+			IDx: &attrValInt{Val: intPtr(idx)}, Delete: &attrValBool{Val: boolPtr(true)},
+		})
+	}
+	if ln := f.drawChartLn(&opts.Legend.Border); ln != nil {
+		if legend.SpPr == nil {
+			legend.SpPr = &cSpPr{}
+		}
+		legend.SpPr.Ln = ln
+	}
+	if opts.Legend.Font != nil {
+		legend.TxPr = &cTxPr{P: aP{PPr: &aPPr{DefRPr: &aRPr{}}, EndParaRPr: &aEndParaRPr{Lang: "en-US"}}}
+		drawChartFont(opts.Legend.Font, legend.TxPr.P.PPr.DefRPr)
+	}
+	return legend
+}
+
+// drawPlotAreaTitles provides a function to draw the c:title element. The
+// title text will be linked to the cell when the cell reference of the title
+// format was specified, otherwise the rich text runs will be used.
+func (f *File) drawPlotAreaTitles(runs []RichTextRun, format *ChartTitle, vert string) *cTitle {
+	if format == nil {
+		format = &ChartTitle{}
+	}
+	if len(runs) == 0 && format.CellRef == "" {
 		return nil
 	}
-	title := &cTitle{Tx: cTx{Rich: &cRich{}}, Overlay: &attrValBool{Val: boolPtr(false)}}
+	var bodyPr aBodyPr
+	if vert == "horz" {
+		bodyPr = aBodyPr{Rot: -5400000, Vert: vert}
+	}
+	if format.Rotation != nil {
+		bodyPr = aBodyPr{Rot: *format.Rotation * 60000, Vert: "horz"}
+	}
+	title := &cTitle{Overlay: &attrValBool{Val: boolPtr(format.Overlay)}}
+	if format.CellRef != "" {
+		title.Tx.StrRef = f.drawChartSeriesStrRef(format.CellRef, format.ref)
+		title.TxPr = cTxPr{BodyPr: bodyPr, P: aP{
+			PPr:        &aPPr{DefRPr: &aRPr{}},
+			EndParaRPr: &aEndParaRPr{Lang: "en-US", AltLang: "en-US"},
+		}}
+		drawChartFont(format.Font, title.TxPr.P.PPr.DefRPr)
+		return title
+	}
+	title.Tx.Rich = &cRich{BodyPr: bodyPr}
 	for _, run := range runs {
 		r := &aR{T: run.Text}
 		drawChartFont(run.Font, &r.RPr)
 		title.Tx.Rich.P = append(title.Tx.Rich.P, aP{
-			PPr:        &aPPr{DefRPr: aRPr{}},
-			R:          r,
+			PPr:        &aPPr{DefRPr: &aRPr{}},
+			R:          []*aR{r},
 			EndParaRPr: &aEndParaRPr{Lang: "en-US", AltLang: "en-US"},
 		})
 	}
-	if vert == "horz" {
-		title.Tx.Rich.BodyPr = aBodyPr{Rot: -5400000, Vert: vert}
-	}
 	return title
 }
 
@@ -1231,7 +1786,7 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 		},
 		P: aP{
 			PPr: &aPPr{
-				DefRPr: aRPr{
+				DefRPr: &aRPr{
 					Sz:       900,
 					B:        false,
 					I:        false,
@@ -1255,7 +1810,7 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 		},
 	}
 	if opts != nil {
-		drawChartFont(&opts.Font, &cTxPr.P.PPr.DefRPr)
+		drawChartFont(&opts.Font, cTxPr.P.PPr.DefRPr)
 		if -90 <= opts.Alignment.TextRotation && opts.Alignment.TextRotation <= 90 {
 			cTxPr.BodyPr.Rot = opts.Alignment.TextRotation * 60000
 		}
@@ -1325,6 +1880,11 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 					XMLNSMC: SourceRelationshipCompatibility.Value,
 				})
 			}
+			for _, v := range decodeWsDr.AbsoluteAnchor {
+				content.AbsoluteAnchor = append(content.AbsoluteAnchor, &xdrCellAnchor{
+					GraphicFrame: v.Content,
+				})
+			}
 			for _, v := range decodeWsDr.OneCellAnchor {
 				content.OneCellAnchor = append(content.OneCellAnchor, &xdrCellAnchor{
 					EditAs:       v.EditAs,
@@ -1407,10 +1967,79 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 	return err
 }
 
+// addDrawingChartEx provides a function to add chart extension graphic frame
+// with the fallback shape by given sheet, drawingXML, cell, relationship index
+// and format sets.
+func (f *File) addDrawingChartEx(sheet, drawingXML, cell string, rID int, opts *Chart) error {
+	content, twoCellAnchor, cNvPrID, err := f.twoCellAnchorShape(sheet, drawingXML, cell, opts.Dimension.Width, opts.Dimension.Height, opts.Format)
+	if err != nil {
+		return err
+	}
+	graphicFrame := xlsxGraphicFrame{
+		NvGraphicFramePr: xlsxNvGraphicFramePr{
+			CNvPr: &xlsxCNvPr{
+				ID:   cNvPrID,
+				Name: "Chart " + strconv.Itoa(cNvPrID),
+			},
+		},
+		Graphic: &xlsxGraphic{
+			GraphicData: &xlsxGraphicData{
+				URI: NameSpaceDrawingMLChartEx.Value,
+				ChartEx: &xlsxChartEx{
+					CX:  NameSpaceDrawingMLChartEx.Value,
+					R:   SourceRelationship.Value,
+					RID: "rId" + strconv.Itoa(rID),
+				},
+			},
+		},
+	}
+	graphic, _ := xml.Marshal(graphicFrame)
+	sp := xdrSp{
+		NvSpPr: &xdrNvSpPr{
+			CNvPr: &xlsxCNvPr{
+				ID: cNvPrID,
+			},
+			CNvSpPr: &xdrCNvSpPr{
+				TxBox: true,
+			},
+		},
+		SpPr: &xlsxSpPr{
+			SolidFill: &xlsxInnerXML{Content: "<a:prstClr val=\"white\"/>"},
+			PrstGeom: xlsxPrstGeom{
+				Prst: "rect",
+			},
+			Ln: xlsxLineProperties{W: 1, SolidFill: &xlsxInnerXML{Content: "<a:prstClr val=\"green\"/>"}},
+		},
+		TxBody: &xdrTxBody{
+			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
+			P: []*aP{
+				{R: []*aR{{T: "This chart isn't available in your version of Excel."}}},
+				{R: []*aR{{T: "Editing this shape or saving this workbook into a different file format will permanently break the chart."}}},
+			},
+		},
+	}
+	shape, _ := xml.Marshal(sp)
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	choice := xlsxChoice{XMLNSCX1: NameSpaceDrawingMLChartEx1.Value, Requires: NameSpaceDrawingMLChartEx1.Name.Local, Content: string(graphic)}
+	fallback := xlsxFallback{Content: string(shape)}
+	choiceBytes, _ := xml.Marshal(choice)
+	shapeBytes, _ := xml.Marshal(fallback)
+	twoCellAnchor.AlternateContent = append(twoCellAnchor.AlternateContent, &xlsxAlternateContent{
+		XMLNSMC: SourceRelationshipCompatibility.Value,
+		Content: string(choiceBytes) + string(shapeBytes),
+	})
+	content.TwoCellAnchor = append(content.TwoCellAnchor, twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
 // addSheetDrawingChart provides a function to add chart graphic frame for
-// chartsheet by given sheet, drawingXML, width, height, relationship index
+// chartsheet by given drawingXML, relationship index, width, height in EMUs
 // and format sets.
-func (f *File) addSheetDrawingChart(drawingXML string, rID int, opts *GraphicOptions) error {
+func (f *File) addSheetDrawingChart(drawingXML string, rID, width, height int, opts *GraphicOptions) error {
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -1418,7 +2047,7 @@ func (f *File) addSheetDrawingChart(drawingXML string, rID int, opts *GraphicOpt
 	absoluteAnchor := xdrCellAnchor{
 		EditAs: opts.Positioning,
 		Pos:    &xlsxPoint2D{},
-		Ext:    &aExt{},
+		Ext:    &aExt{Cx: width, Cy: height},
 	}
 
 	graphicFrame := xlsxGraphicFrame{
@@ -1507,6 +2136,62 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) (stri
 	return rID, err
 }
 
+// deleteDrawingChart provides a function to delete the chart graphic frame
+// and the relationship of the chart in the drawing part by given drawing part
+// path and chart part path.
+func (f *File) deleteDrawingChart(drawingXML, chartPart string) error {
+	var rID string
+	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+	if rels, _ := f.relsReader(drawingRels); rels != nil {
+		rels.mu.Lock()
+		for _, v := range rels.Relationships {
+			target := filepath.ToSlash(filepath.Clean("xl/drawings/" + v.Target))
+			if strings.HasPrefix(v.Target, "/") {
+				target = strings.TrimPrefix(v.Target, "/")
+			}
+			if v.Type == SourceRelationshipChart && target == chartPart {
+				rID = v.ID
+			}
+		}
+		rels.mu.Unlock()
+	}
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	onChart := func(anchor *xdrCellAnchor) (bool, error) {
+		var deChartAnchor decodeChartAnchor
+		if err := f.xmlNewDecoder(strings.NewReader("<decodeChartAnchor>" + anchor.GraphicFrame + "</decodeChartAnchor>")).
+			Decode(&deChartAnchor); err != nil && err != io.EOF {
+			return false, err
+		}
+		graphicFrame := deChartAnchor.GraphicFrame
+		for _, alternateContent := range deChartAnchor.AlternateContent {
+			if graphicFrame == nil && alternateContent.Choice != nil {
+				graphicFrame = alternateContent.Choice.GraphicFrame
+			}
+		}
+		return graphicFrame != nil && graphicFrame.Graphic.GraphicData.Chart != nil &&
+			graphicFrame.Graphic.GraphicData.Chart.RID == rID, nil
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	for _, anchors := range []*[]*xdrCellAnchor{&wsDr.TwoCellAnchor, &wsDr.OneCellAnchor, &wsDr.AbsoluteAnchor} {
+		for idx := 0; idx < len(*anchors); idx++ {
+			ok, err := onChart((*anchors)[idx])
+			if err != nil {
+				return err
+			}
+			if ok {
+				*anchors = append((*anchors)[:idx], (*anchors)[idx+1:]...)
+				idx--
+			}
+		}
+	}
+	f.deleteDrawingRels(drawingRels, rID)
+	return err
+}
+
 // extractEmbedRID returns embed relationship ID and all relationship ID lists
 // for giving cell anchor.
 func extractEmbedRID(pic *xlsxPic, decodePic *decodePic, rIDs []string) (string, []string) {
@@ -14,6 +14,7 @@ package excelize
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -23,17 +24,38 @@ var (
 	// ErrAttrValBool defined the error message on marshal and unmarshal
 	// boolean type XML attribute.
 	ErrAttrValBool = errors.New("unexpected child of attrValBool")
+	// ErrBorderStyle defined the error message on receive the invalid border
+	// style index number.
+	ErrBorderStyle = errors.New("border style index must be between 0 and 13")
 	// ErrCellCharsLength defined the error message for receiving a cell
 	// characters length that exceeds the limit.
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrChartFunnelSeries defined the error message on receive the funnel
+	// chart with no series or more than one series.
+	ErrChartFunnelSeries = errors.New("funnel chart must have exactly one series")
+	// ErrChartGapWidth defined the error message on receive an invalid gap
+	// width of the bar or column chart.
+	ErrChartGapWidth = errors.New("gap width must be between 0 and 500")
+	// ErrChartOverlap defined the error message on receive an invalid overlap
+	// of the bar or column chart.
+	ErrChartOverlap = errors.New("overlap must be between -100 and 100")
+	// ErrChartSecondPlotSize defined the error message on receive an invalid
+	// size of the second plot of the pie of pie or bar of pie chart.
+	ErrChartSecondPlotSize = errors.New("second plot size must be between 5 and 200")
+	// ErrChartQuartileMethod defined the error message on receive an invalid
+	// quartile calculation method of the box and whisker chart.
+	ErrChartQuartileMethod = errors.New("quartile method must be one of inclusive or exclusive")
 	// ErrColumnNumber defined the error message on receive an invalid column
 	// number.
 	ErrColumnNumber = fmt.Errorf("the column number must be greater than or equal to %d and less than or equal to %d", MinColumns, MaxColumns)
 	// ErrColumnWidth defined the error message on receive an invalid column
 	// width.
 	ErrColumnWidth = fmt.Errorf("the width of the column must be less than or equal to %d characters", MaxColumnWidth)
+	// ErrCompactStylesStream defined the error message on compact styles of
+	// the workbook with streamed worksheets.
+	ErrCompactStylesStream = errors.New("unsupported compact styles of the workbook with streamed worksheets")
 	// ErrCoordinates defined the error message on invalid coordinates tuples
 	// length.
 	ErrCoordinates = errors.New("coordinates length must be 4")
@@ -60,14 +82,29 @@ var (
 	ErrFontLength = fmt.Errorf("the length of the font family name must be less than or equal to %d", MaxFontFamilyLength)
 	// ErrFontSize defined the error message on the size of the font is invalid.
 	ErrFontSize = fmt.Errorf("font size must be between %d and %d points", MinFontSize, MaxFontSize)
+	// ErrFontScheme defined the error message on receive the invalid font
+	// scheme.
+	ErrFontScheme = fmt.Errorf("font scheme must be one of %s", strings.Join(supportedFontSchemes, ", "))
+	// ErrFontVertAlign defined the error message on receive the invalid font
+	// vertical alignment.
+	ErrFontVertAlign = fmt.Errorf("font vertical alignment must be one of %s", strings.Join(supportedFontVertAlign, ", "))
 	// ErrFormControlValue defined the error message for receiving a scroll
 	// value exceeds limit.
 	ErrFormControlValue = fmt.Errorf("scroll value must be between 0 and %d", MaxFormControlValue)
+	// ErrGradientFillStops defined the error message on receive the invalid
+	// gradient fill stops.
+	ErrGradientFillStops = errors.New("gradient fill must have at least two stops with position between 0 and 1 in ascending order")
 	// ErrGroupSheets defined the error message on group sheets.
 	ErrGroupSheets = errors.New("group worksheet must contain an active worksheet")
 	// ErrImgExt defined the error message on receive an unsupported image
 	// extension.
 	ErrImgExt = errors.New("unsupported image extension")
+	// ErrSVGFallback defined the error message on receive the SVG picture
+	// without the raster fallback picture and the SVG renderer.
+	ErrSVGFallback = errors.New("the SVG picture requires a raster fallback picture or an SVG renderer")
+	// ErrIndent defined the error message on receiving the invalid indent of
+	// the alignment.
+	ErrIndent = fmt.Errorf("indent must be between 0 and %d", MaxIndent)
 	// ErrInvalidFormula defined the error message on receive an invalid
 	// formula.
 	ErrInvalidFormula = errors.New("formula not valid")
@@ -82,6 +119,12 @@ var (
 	// ErrNameLength defined the error message on receiving the defined name or
 	// table name length exceeds the limit.
 	ErrNameLength = fmt.Errorf("the name length exceeds the %d characters limit", MaxFieldLength)
+	// ErrNamedStyleDuplicate defined the error message on the same name named
+	// cell style already exists.
+	ErrNamedStyleDuplicate = errors.New("the same name named style already exists")
+	// ErrOptionsCompressionLevel defined the error message for receiving an
+	// invalid compression level.
+	ErrOptionsCompressionLevel = errors.New("the value of CompressionLevel should be between -1 and 9")
 	// ErrOptionsUnzipSizeLimit defined the error message for receiving
 	// invalid UnzipSizeLimit and UnzipXMLSizeLimit.
 	ErrOptionsUnzipSizeLimit = errors.New("the value of UnzipSizeLimit should be greater than or equal to UnzipXMLSizeLimit")
@@ -91,6 +134,12 @@ var (
 	// ErrPageSetupAdjustTo defined the error message for receiving a page setup
 	// adjust to value exceeds limit.
 	ErrPageSetupAdjustTo = errors.New("adjust to value must be between 10 and 400")
+	// ErrPageSetupFitTo defined the error message for receiving a page setup
+	// fit to width or height value exceeds limit.
+	ErrPageSetupFitTo = errors.New("fit to pages value must be between 0 and 32767")
+	// ErrPaneSplit defined the error message on receiving the invalid split
+	// position of the panes.
+	ErrPaneSplit = errors.New("the split position of panes must be positive")
 	// ErrParameterInvalid defined the error message on receive the invalid
 	// parameter.
 	ErrParameterInvalid = errors.New("parameter is invalid")
@@ -103,6 +152,9 @@ var (
 	// ErrPivotTableClassicLayout defined the error message on enable
 	// ClassicLayout and CompactData in the same time.
 	ErrPivotTableClassicLayout = errors.New("cannot enable ClassicLayout and CompactData in the same time")
+	// ErrReadingOrder defined the error message on receiving the invalid
+	// reading order of the alignment.
+	ErrReadingOrder = errors.New("reading order must be 0, 1 or 2")
 	// ErrSave defined the error message for saving file.
 	ErrSave = errors.New("no path defined for file, consider File.WriteTo or File.Write")
 	// ErrSheetIdx defined the error message on receive the invalid worksheet
@@ -120,6 +172,9 @@ var (
 	// ErrSheetNameSingleQuote defined the error message on the first or last
 	// character of the sheet name was a single quote.
 	ErrSheetNameSingleQuote = errors.New("the first or last character of the sheet name can not be a single quote")
+	// ErrSheetState defined the error message on receiving the invalid sheet
+	// visible state.
+	ErrSheetState = errors.New("the sheet state must be one of visible, hidden or veryHidden")
 	// ErrSparkline defined the error message on receive the invalid sparkline
 	// parameters.
 	ErrSparkline = errors.New("must have the same number of 'Location' and 'Range' parameters")
@@ -138,9 +193,40 @@ var (
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
+	// ErrStreamCheckpoint defined the error message on checkpoint the stream
+	// writer with the workbook parts which can't be restored, or resume the
+	// stream writer with the invalid checkpoint.
+	ErrStreamCheckpoint = errors.New("the stream writer with tables or rich values can't be checkpointed, or the checkpoint is invalid or its temp file is truncated")
+	// ErrStreamChecksum defined the error message on the checksum of the temp
+	// file mismatched in stream writing mode.
+	ErrStreamChecksum = errors.New("the temp file of the stream writer is corrupted, checksum mismatch")
+	// ErrStreamRichValue defined the error message on add rich value in stream
+	// writing mode for the workbook which already contains the metadata.
+	ErrStreamRichValue = errors.New("adding rich values to the workbook which already contains the metadata is not supported")
+	// ErrStreamSetDefaultStyle defined the error message on set default style
+	// in stream writing mode.
+	ErrStreamSetDefaultStyle = errors.New("must call the SetDefaultStyle function before the SetRow function")
+	// ErrStreamSetOutlineSummary defined the error message on set the
+	// position of the outline summary rows and columns in stream writing mode.
+	ErrStreamSetOutlineSummary = errors.New("must call the SetOutlineSummary function before the SetRow function")
 	// ErrStreamSetPanes defined the error message on set panes in stream
 	// writing mode.
 	ErrStreamSetPanes = errors.New("must call the SetPanes function before the SetRow function")
+	// ErrStreamSetPageLayout defined the error message on set page layout in
+	// stream writing mode.
+	ErrStreamSetPageLayout = errors.New("must call the SetPageLayout function before the SetRow function")
+	// ErrStreamSetPrintOptions defined the error message on set print options
+	// in stream writing mode.
+	ErrStreamSetPrintOptions = errors.New("must call the SetPrintOptions function before the Flush function")
+	// ErrStreamSetSheetView defined the error message on set sheet view in
+	// stream writing mode.
+	ErrStreamSetSheetView = errors.New("must call the SetSheetView function before the SetRow function")
+	// ErrTextRotation defined the error message on receiving the invalid text
+	// rotation of the alignment.
+	ErrTextRotation = errors.New("text rotation must be between 0 and 180, or 255 for vertical text")
+	// ErrThemeNotExist defined the error message on the workbook has no
+	// theme part.
+	ErrThemeNotExist = errors.New("the workbook has no theme")
 	// ErrTotalSheetHyperlinks defined the error message on hyperlinks count
 	// overflow.
 	ErrTotalSheetHyperlinks = errors.New("over maximum limit hyperlinks in a worksheet")
@@ -174,6 +260,18 @@ var (
 	// ErrWorkbookPassword defined the error message on receiving the incorrect
 	// workbook password.
 	ErrWorkbookPassword = errors.New("the supplied open workbook password is not correct")
+	// ErrXLSBNoSheet defined the error message on closing the XLSB writer
+	// without any worksheet.
+	ErrXLSBNoSheet = errors.New("the XLSB workbook must contain at least one worksheet")
+	// ErrXLSBSheetNameDuplicate defined the error message on creating the
+	// XLSB stream writer with an existing worksheet name.
+	ErrXLSBSheetNameDuplicate = errors.New("the same name worksheet already exists in the XLSB workbook")
+	// ErrXLSBStreamFlushed defined the error message on writing or flushing
+	// the XLSB stream writer which has been flushed.
+	ErrXLSBStreamFlushed = errors.New("the XLSB stream writer has been flushed")
+	// ErrXLSBWriterClosed defined the error message on using the XLSB writer
+	// which has been closed.
+	ErrXLSBWriterClosed = errors.New("the XLSB writer has been closed")
 )
 
 // ErrSheetNotExist defined an error of sheet that does not exist.
@@ -186,6 +284,24 @@ func (err ErrSheetNotExist) Error() string {
 	return fmt.Sprintf("sheet %s does not exist", err.SheetName)
 }
 
+// ErrStreamTempFile defined an error of writing the temp file of the stream
+// writer, such as the disk is full. The data which failed to be written is
+// kept in memory, and the Err is the underlying error of the file system.
+type ErrStreamTempFile struct {
+	Err error
+}
+
+// Error returns the error message on writing the temp file of the stream
+// writer.
+func (err ErrStreamTempFile) Error() string {
+	return fmt.Sprintf("failed to write the temp file of the stream writer: %v", err.Err)
+}
+
+// Unwrap returns the underlying error of the file system.
+func (err ErrStreamTempFile) Unwrap() error {
+	return err.Err
+}
+
 // newCellNameToCoordinatesError defined the error message on converts
 // alphanumeric cell name to coordinates.
 func newCellNameToCoordinatesError(cell string, err error) error {
@@ -198,6 +314,12 @@ func newCoordinatesToCellNameError(col, row int) error {
 	return fmt.Errorf("invalid cell reference [%d, %d]", col, row)
 }
 
+// newInvalidCellErrorError defined the error message on receiving the invalid
+// cell error literal.
+func newInvalidCellErrorError(val string) error {
+	return fmt.Errorf("invalid cell error value %q", val)
+}
+
 // newFieldLengthError defined the error message on receiving the field length
 // overflow.
 func newFieldLengthError(name string) error {
@@ -258,6 +380,18 @@ func newInvalidPageLayoutValueError(name, value, msg string) error {
 	return fmt.Errorf("invalid %s value %q, acceptable value should be one of %s", name, value, msg)
 }
 
+// newInvalidPaneError defined the error message on receiving the invalid pane
+// type.
+func newInvalidPaneError(pane string) error {
+	return fmt.Errorf("invalid pane %q, acceptable value should be one of %s", pane, strings.Join(supportedPaneTypes, ", "))
+}
+
+// newPaneSelectionError defined the error message on receiving the active
+// cell of the selection outside of its pane in the frozen panes.
+func newPaneSelectionError(cell, pane string) error {
+	return fmt.Errorf("the active cell %s is not in the %s pane of the frozen panes", cell, pane)
+}
+
 // newInvalidRowNumberError defined the error message on receiving the invalid
 // row number.
 func newInvalidRowNumberError(row int) error {
@@ -276,6 +410,42 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newExistsTableStyleError defined the error message on receiving the
+// existing custom table style name.
+func newExistsTableStyleError(name string) error {
+	return fmt.Errorf("table style %s already exists", name)
+}
+
+// newInvalidTableStyleElementError defined the error message on receiving the
+// invalid table style element type.
+func newInvalidTableStyleElementError(typ string) error {
+	return fmt.Errorf("invalid table style element type %s", typ)
+}
+
+// newNoExistCfRuleError defined the error message on receiving the non
+// existing conditional formatting rule index of the range.
+func newNoExistCfRuleError(rangeRef string, index int) error {
+	return fmt.Errorf("conditional format rule %d of range %s does not exist", index, rangeRef)
+}
+
+// newNoExistChartError defined the error message on receiving the non existing
+// chart index or name of the worksheet.
+func newNoExistChartError(sheet, chart string) error {
+	return fmt.Errorf("chart %s does not exist on sheet %s", chart, sheet)
+}
+
+// newNoExistNamedStyleError defined the error message on receiving the non
+// existing named cell style name.
+func newNoExistNamedStyleError(name string) error {
+	return fmt.Errorf("named style %s does not exist", name)
+}
+
+// newNoExistPictureError defined the error message on receiving the non
+// existing picture index or name of the worksheet.
+func newNoExistPictureError(sheet, picture string) error {
+	return fmt.Errorf("picture %s does not exist on sheet %s", picture, sheet)
+}
+
 // newNoExistSlicerError defined the error message on receiving the non existing
 // slicer name.
 func newNoExistSlicerError(name string) error {
@@ -306,12 +476,56 @@ func newPivotTableRangeError(msg string) error {
 	return fmt.Errorf("parameter 'PivotTableRange' parsing error: %s", msg)
 }
 
+// newStreamColumnTypeError defined the error message on the stream writer
+// receiving the value which mismatches the declared column type.
+func newStreamColumnTypeError(cell string, val interface{}, colType ColumnType) error {
+	return fmt.Errorf("value %v of type %T in cell %s does not match the column type %s", val, val, cell, columnTypeNames[colType])
+}
+
+// newStreamMergeCellOverlapError defined the error message on the stream
+// writer receiving the overlapped merged cell ranges.
+func newStreamMergeCellOverlapError(ref1, ref2 string) error {
+	return fmt.Errorf("merged cell range %s overlaps with %s", ref2, ref1)
+}
+
+// newStreamMergeCellValueError defined the error message on the stream writer
+// receiving the value of the cell covered by a merged cell range in the strict
+// merged cells mode.
+func newStreamMergeCellValueError(cell, ref string) error {
+	return fmt.Errorf("cell %s is covered by merged cell range %s, only the top-left cell could carry a value", cell, ref)
+}
+
+// newStreamFormulaSheetRefError defined the error message on the stream
+// writer receiving the formula which references a worksheet that does not
+// exist in the formula references validation mode.
+func newStreamFormulaSheetRefError(cell, sheet string) error {
+	return fmt.Errorf("the formula of cell %s references the sheet %s which does not exist", cell, sheet)
+}
+
+// newStreamMapKeyError defined the error message on the stream writer
+// receiving the row key which is not in the header.
+func newStreamMapKeyError(key string, row int) error {
+	return fmt.Errorf("key %q of row %d is not in the header", key, row)
+}
+
 // newStreamSetRowError defined the error message on the stream writer
 // receiving the non-ascending row number.
 func newStreamSetRowError(row int) error {
 	return fmt.Errorf("row %d has already been written", row)
 }
 
+// newStreamTableHeaderError defined the error message on the stream writer
+// receiving the table range which header row has not been written.
+func newStreamTableHeaderError(row int) error {
+	return fmt.Errorf("table header row %d has not been written", row)
+}
+
+// newStreamTableRangeError defined the error message on the stream writer
+// receiving the table range exceeds the written rows.
+func newStreamTableRangeError(ref string, row int) error {
+	return fmt.Errorf("table range %s exceeds the last written row %d", ref, row)
+}
+
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
@@ -335,3 +549,16 @@ func newUnzipSizeLimitError(unzipSizeLimit int64) error {
 func newViewIdxError(viewIndex int) error {
 	return fmt.Errorf("view index %d out of range", viewIndex)
 }
+
+// newXLSBCellValueError defined the error message on receiving the cell value
+// or cell options unsupported in the XLSB workbook.
+func newXLSBCellValueError(col, row int) error {
+	cell, _ := CoordinatesToCellName(col, row)
+	return fmt.Errorf("unsupported value of cell %s in the XLSB workbook", cell)
+}
+
+// newXLSBFormulaError defined the error message on receiving the formula
+// token unsupported in the XLSB workbook.
+func newXLSBFormulaError(formula, token string) error {
+	return fmt.Errorf("unsupported token %q in formula %q of the XLSB workbook", token, formula)
+}
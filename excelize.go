@@ -22,16 +22,25 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"golang.org/x/net/html/charset"
 )
 
+// fileCount is the number of the created workbooks, which is used as the
+// identifier of the workbook.
+var fileCount uint64
+
 // File define a populated spreadsheet file struct.
 type File struct {
 	mu               sync.Mutex
 	checked          sync.Map
+	copiedStyles     map[uint64]map[int]int
 	formulaChecked   bool
+	id               uint64
 	options          *Options
+	quotePrefixes    map[int]int
+	richValues       []RichValue
 	sharedStringItem [][]uint
 	sharedStringsMap map[string]int
 	sharedStringTemp *os.File
@@ -96,19 +105,66 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // LongTimePattern specifies the long time number format code.
 //
 // CultureInfo specifies the country code for applying built-in language number
-// format code these effect by the system's local language settings.
+// format code these effect by the system's local language settings. The
+// culture also affects the decimal and thousands separators, the month and
+// day names, the AM/PM strings and the default currency symbol on applying
+// number format, the language ID prefix such as [$-409] in the number format
+// code takes precedence over the culture.
+//
+// CompactStyles specifies if deduplicate and remove the unused style records
+// by the CompactStyles function on saving the spreadsheet, the default value
+// is false.
+//
+// BoolAsText specifies if write the boolean cell values as the literal text
+// TRUE or FALSE instead of the native boolean type cells, this is useful for
+// the tools that don't understand the boolean cell type. The default value is
+// false, the boolean values will be written as the native boolean type cells
+// with the value 1 or 0, which keeps the fidelity of the spreadsheet
+// applications, such as the boolean values could be used in the formulas.
+//
+// ApostropheAsQuotePrefix specifies if treat the leading apostrophe of the
+// string cell values as the quote prefix, like the spreadsheet applications
+// on typing or pasting the text, the value will be stored without the
+// apostrophe, and the cell will be set with the quote prefix style, which
+// displays the value as text, such as "'123" will be stored as text "123".
+// The default value is false, the string values will be written as is.
+//
+// CompressionLevel specifies the DEFLATE compression level for the parts of
+// the spreadsheet package on saving, the value should be between 1 (best
+// speed) and 9 (best compression), or CompressionLevelStore to store the parts
+// without compression, which produces the largest files in the shortest time.
+// The default value CompressionLevelDefault uses the default compression
+// level.
+//
+// SVGRenderer specifies the renderer used to generate the raster fallback
+// picture on adding the SVG picture without the fallback picture, the
+// spreadsheet applications which don't support SVG display the fallback
+// picture.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations       uint
+	Password                string
+	RawCellValue            bool
+	UnzipSizeLimit          int64
+	UnzipXMLSizeLimit       int64
+	ShortDatePattern        string
+	LongDatePattern         string
+	LongTimePattern         string
+	CultureInfo             CultureName
+	CompactStyles           bool
+	BoolAsText              bool
+	CompressionLevel        int
+	ApostropheAsQuotePrefix bool
+	SVGRenderer             SVGRenderer
 }
 
+// This section defines the special compression levels for the
+// CompressionLevel option, the DEFLATE compression levels from 1 (best speed)
+// to 9 (best compression) can also be used.
+const (
+	CompressionLevelStore   = -1
+	CompressionLevelDefault = 0
+)
+
 // OpenFile take the name of a spreadsheet file and returns a populated
 // spreadsheet file struct for it. For example, open spreadsheet with
 // password protection:
@@ -135,6 +191,7 @@ func OpenFile(filename string, opts ...Options) (*File, error) {
 // newFile is object builder
 func newFile() *File {
 	return &File{
+		id:               atomic.AddUint64(&fileCount, 1),
 		options:          &Options{UnzipSizeLimit: UnzipSizeLimit, UnzipXMLSizeLimit: StreamChunkSize},
 		xmlAttr:          sync.Map{},
 		checked:          sync.Map{},
@@ -274,11 +331,9 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 		ws = worksheet.(*xlsxWorksheet)
 		return
 	}
-	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
-		if strings.HasPrefix(name, sheetType) {
-			err = newNotWorksheetError(sheet)
-			return
-		}
+	if isNotWorksheetPath(name) {
+		err = newNotWorksheetError(sheet)
+		return
 	}
 	ws = new(xlsxWorksheet)
 	if attrs, ok := f.xmlAttr.Load(name); !ok {
@@ -305,6 +360,17 @@ func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	return
 }
 
+// isNotWorksheetPath provides a function to check if the given sheet XML path
+// is a chart sheet, dialog sheet or macro sheet.
+func isNotWorksheetPath(path string) bool {
+	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
+		if strings.HasPrefix(path, sheetType) {
+			return true
+		}
+	}
+	return false
+}
+
 // checkSheet provides a function to fill each row element and make that is
 // continuous in a worksheet of XML.
 func (ws *xlsxWorksheet) checkSheet() {
@@ -418,7 +484,10 @@ func (f *File) setRels(rID, relPath, relType, target, targetMode string) int {
 // relationship type, target and target mode.
 func (f *File) addRels(relPath, relType, target, targetMode string) int {
 	uniqPart := map[string]string{
-		SourceRelationshipSharedStrings: "/xl/sharedStrings.xml",
+		SourceRelationshipSharedStrings:        "/xl/sharedStrings.xml",
+		SourceRelationshipSheetMetadata:        "metadata.xml",
+		SourceRelationshipRdRichValue:          "richData/rdrichvalue.xml",
+		SourceRelationshipRdRichValueStructure: "richData/rdrichvaluestructure.xml",
 	}
 	rels, _ := f.relsReader(relPath)
 	if rels == nil {
@@ -766,7 +766,13 @@ func TestSetCellStyleBorderErrors(t *testing.T) {
 
 	// Set border with invalid style index number
 	_, err = f.NewStyle(&Style{Border: []Border{{Type: "left", Color: "0000FF", Style: -1}, {Type: "top", Color: "00FF00", Style: 14}, {Type: "bottom", Color: "FFFF00", Style: 5}, {Type: "right", Color: "FF0000", Style: 6}, {Type: "diagonalDown", Color: "A020F0", Style: 9}, {Type: "diagonalUp", Color: "A020F0", Style: 8}}})
-	assert.NoError(t, err)
+	assert.Equal(t, ErrBorderStyle, err)
+	for _, style := range []int{-1, 14} {
+		_, err = f.NewStyle(&Style{Border: []Border{{Type: "diagonalUp", Color: "A020F0", Style: style}}})
+		assert.Equal(t, ErrBorderStyle, err)
+		_, err = f.NewConditionalStyle(&Style{Border: []Border{{Type: "left", Color: "0000FF", Style: style}}})
+		assert.Equal(t, ErrBorderStyle, err)
+	}
 }
 
 func TestSetCellStyleNumberFormat(t *testing.T) {
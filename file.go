@@ -14,6 +14,7 @@ package excelize
 import (
 	"archive/zip"
 	"bytes"
+	"compress/flate"
 	"encoding/xml"
 	"io"
 	"os"
@@ -123,6 +124,11 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 			return 0, err
 		}
 	}
+	if f.options != nil && f.options.CompactStyles {
+		if _, err := f.CompactStyles(); err != nil {
+			return 0, err
+		}
+	}
 	if f.options != nil && f.options.Password != "" {
 		buf, err := f.WriteToBuffer()
 		if err != nil {
@@ -140,7 +146,10 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 // and it allocates space in memory. Be careful when the file size is large.
 func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
-	zw := zip.NewWriter(buf)
+	zw, err := f.newZipWriter(buf)
+	if err != nil {
+		return buf, err
+	}
 
 	if err := f.writeToZip(zw); err != nil {
 		return buf, zw.Close()
@@ -163,7 +172,10 @@ func (f *File) WriteToBuffer() (*bytes.Buffer, error) {
 
 // writeDirectToWriter provides a function to write to io.Writer.
 func (f *File) writeDirectToWriter(w io.Writer) error {
-	zw := zip.NewWriter(w)
+	zw, err := f.newZipWriter(w)
+	if err != nil {
+		return err
+	}
 	if err := f.writeToZip(zw); err != nil {
 		_ = zw.Close()
 		return err
@@ -171,8 +183,39 @@ func (f *File) writeDirectToWriter(w io.Writer) error {
 	return zw.Close()
 }
 
+// newZipWriter provides a function to create the zip writer with the
+// compression level specified by the options.
+func (f *File) newZipWriter(w io.Writer) (*zip.Writer, error) {
+	zw := zip.NewWriter(w)
+	if f.options == nil {
+		return zw, nil
+	}
+	level := f.options.CompressionLevel
+	if level < CompressionLevelStore || level > flate.BestCompression {
+		return zw, ErrOptionsCompressionLevel
+	}
+	if level > CompressionLevelDefault {
+		zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+	}
+	return zw, nil
+}
+
+// createZipEntry provides a function to add a file to the zip writer by given
+// path, the file will be stored without compression if the compression level
+// option is CompressionLevelStore.
+func (f *File) createZipEntry(zw *zip.Writer, path string) (io.Writer, error) {
+	method := zip.Deflate
+	if f.options != nil && f.options.CompressionLevel == CompressionLevelStore {
+		method = zip.Store
+	}
+	return zw.CreateHeader(&zip.FileHeader{Name: path, Method: method})
+}
+
 // writeToZip provides a function to write to zip.Writer
 func (f *File) writeToZip(zw *zip.Writer) error {
+	f.appPropsWriter()
 	f.calcChainWriter()
 	f.commentsWriter()
 	f.contentTypesWriter()
@@ -188,7 +231,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	f.themeWriter()
 
 	for path, stream := range f.streams {
-		fi, err := zw.Create(path)
+		fi, err := f.createZipEntry(zw, path)
 		if err != nil {
 			return err
 		}
@@ -215,7 +258,7 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	sort.Sort(sort.Reverse(sort.StringSlice(files)))
 	for _, path := range files {
 		var fi io.Writer
-		if fi, err = zw.Create(path); err != nil {
+		if fi, err = f.createZipEntry(zw, path); err != nil {
 			break
 		}
 		content, _ := f.Pkg.Load(path)
@@ -231,10 +274,27 @@ func (f *File) writeToZip(zw *zip.Writer) error {
 	sort.Sort(sort.Reverse(sort.StringSlice(tempFiles)))
 	for _, path := range tempFiles {
 		var fi io.Writer
-		if fi, err = zw.Create(path); err != nil {
+		if fi, err = f.createZipEntry(zw, path); err != nil {
+			break
+		}
+		if err = f.copyTemp(fi, path); err != nil {
 			break
 		}
-		_, err = fi.Write(f.readBytes(path))
 	}
 	return err
 }
+
+// copyTemp provides a function to copy the file extracted to the system
+// temporary directory by given path to the writer without reading the whole
+// content into memory.
+func (f *File) copyTemp(w io.Writer, path string) error {
+	file, err := f.readTemp(path)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
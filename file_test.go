@@ -1,8 +1,10 @@
 package excelize
 
 import (
+	"archive/zip"
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -90,6 +92,50 @@ func TestWriteTo(t *testing.T) {
 	}
 }
 
+func TestWriteToCompressionLevel(t *testing.T) {
+	sizes := make(map[int]int)
+	for _, level := range []int{CompressionLevelStore, CompressionLevelDefault, 1, 9} {
+		f := NewFile()
+		sw, err := f.NewStreamWriter("Sheet1")
+		assert.NoError(t, err)
+		for r := 1; r <= 1000; r++ {
+			assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", r), []interface{}{"Data", r, float64(r) / 3}))
+		}
+		assert.NoError(t, sw.Flush())
+		f.options.CompressionLevel = level
+		buf, err := f.WriteToBuffer()
+		assert.NoError(t, err)
+		sizes[level] = buf.Len()
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.NoError(t, err)
+		for _, file := range zr.File {
+			if level == CompressionLevelStore {
+				assert.Equal(t, zip.Store, file.Method, file.Name)
+				continue
+			}
+			assert.Equal(t, zip.Deflate, file.Method, file.Name)
+		}
+		assert.NoError(t, f.Close())
+		f, err = OpenReader(buf)
+		assert.NoError(t, err)
+		val, err := f.GetCellValue("Sheet1", "B1000")
+		assert.NoError(t, err)
+		assert.Equal(t, "1000", val)
+		assert.NoError(t, f.Close())
+	}
+	assert.Greater(t, sizes[CompressionLevelStore], sizes[1])
+	assert.GreaterOrEqual(t, sizes[1], sizes[9])
+	// Test write with invalid compression level
+	for _, level := range []int{-2, 10} {
+		f := NewFile(Options{CompressionLevel: level})
+		_, err := f.WriteToBuffer()
+		assert.Equal(t, ErrOptionsCompressionLevel, err)
+		_, err = f.WriteTo(bufio.NewWriter(&bytes.Buffer{}))
+		assert.Equal(t, ErrOptionsCompressionLevel, err)
+		assert.NoError(t, f.Close())
+	}
+}
+
 func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
@@ -101,18 +101,29 @@ func (f *File) readXML(name string) []byte {
 	if content, _ := f.Pkg.Load(name); content != nil {
 		return content.([]byte)
 	}
-	if content, ok := f.streams[name]; ok {
+	if content, ok := f.streams[name]; ok && content.rawData.tmp == nil {
 		return content.rawData.buf.Bytes()
 	}
 	return []byte{}
 }
 
-// readBytes read file as bytes by given path.
+// readBytes read file as bytes by given path. The whole content of the
+// worksheet spilled to the temporary file by the stream writer or extracted
+// to the system temporary directory will be read into memory, use the
+// xmlDecoder function to parse these worksheets lazily instead.
 func (f *File) readBytes(name string) []byte {
 	content := f.readXML(name)
 	if len(content) != 0 {
 		return content
 	}
+	if stream, ok := f.streams[name]; ok {
+		r, err := stream.rawData.Reader()
+		if err != nil {
+			return content
+		}
+		content, _ = io.ReadAll(r)
+		return content
+	}
 	file, err := f.readTemp(name)
 	if err != nil {
 		return content
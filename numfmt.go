@@ -22,6 +22,15 @@ import (
 	"github.com/xuri/nfp"
 )
 
+// cultureInfo defined the locale conventions of the culture for apply number
+// format, including the language ID for the month names, day names and AM/PM
+// strings, the decimal and thousands separators, the default currency symbol
+// and the built-in number format codes which are different from the en-US.
+type cultureInfo struct {
+	localCode, decimalSep, thousandsSep, currency string
+	builtInNumFmt                                 map[int]string
+}
+
 // languageInfo defined the required fields of localization support for number
 // format.
 type languageInfo struct {
@@ -41,10 +50,11 @@ type numberFormat struct {
 	sectionIdx                                                               int
 	date1904, isNumeric, hours, seconds, useMillisecond, useGannen           bool
 	number                                                                   float64
-	ap, localCode, result, value, valueSectionType                           string
+	ap, color, localCode, result, value                                      string
 	switchArgument, currencyString                                           string
 	fracHolder, fracPadding, intHolder, intPadding, expBaseLen               int
-	percent                                                                  int
+	percent, thousandsScale                                                  int
+	thousandsScaleTokens                                                     map[int]bool
 	useCommaSep, useFraction, usePointer, usePositive, useScientificNotation bool
 }
 
@@ -61,6 +71,12 @@ const (
 	CultureNameKoKR
 	CultureNameZhCN
 	CultureNameZhTW
+	CultureNameDeDE
+	CultureNameEsES
+	CultureNameFrFR
+	CultureNameItIT
+	CultureNamePtBR
+	CultureNameRuRU
 )
 
 var (
@@ -210,6 +226,102 @@ var (
 			81: "d/m/bb",
 		},
 	}
+	// supportedCultureInfo defined the locale conventions of the supported
+	// cultures for apply number format.
+	supportedCultureInfo = map[CultureName]cultureInfo{
+		CultureNameEnUS: {localCode: "409", decimalSep: ".", thousandsSep: ",", currency: "$"},
+		CultureNameJaJP: {
+			localCode: "411", decimalSep: ".", thousandsSep: ",", currency: "¥",
+			builtInNumFmt: map[int]string{
+				14: "yyyy/m/d",
+				22: "yyyy/m/d h:mm",
+				42: "_ \"¥\"* #,##0_ ;_ \"¥\"* \\-#,##0_ ;_ \"¥\"* \"-\"_ ;_ @_ ",
+				44: "_ \"¥\"* #,##0.00_ ;_ \"¥\"* \\-#,##0.00_ ;_ \"¥\"* \"-\"??_ ;_ @_ ",
+			},
+		},
+		CultureNameKoKR: {
+			localCode: "412", decimalSep: ".", thousandsSep: ",", currency: "\u20a9",
+			builtInNumFmt: map[int]string{
+				42: "_-\"\u20a9\"* #,##0_-;\\-\"\u20a9\"* #,##0_-;_-\"\u20a9\"* \"-\"_-;_-@_-",
+				44: "_-\"\u20a9\"* #,##0.00_-;\\-\"\u20a9\"* #,##0.00_-;_-\"\u20a9\"* \"-\"??_-;_-@_-",
+			},
+		},
+		CultureNameZhCN: {
+			localCode: "804", decimalSep: ".", thousandsSep: ",", currency: "¥",
+			builtInNumFmt: map[int]string{
+				14: "yyyy/m/d",
+				22: "yyyy/m/d h:mm",
+				42: "_ \"¥\"* #,##0_ ;_ \"¥\"* \\-#,##0_ ;_ \"¥\"* \"-\"_ ;_ @_ ",
+				44: "_ \"¥\"* #,##0.00_ ;_ \"¥\"* \\-#,##0.00_ ;_ \"¥\"* \"-\"??_ ;_ @_ ",
+			},
+		},
+		CultureNameZhTW: {
+			localCode: "404", decimalSep: ".", thousandsSep: ",", currency: "NT$",
+			builtInNumFmt: map[int]string{
+				14: "yyyy/m/d",
+				22: "yyyy/m/d hh:mm",
+				42: "_-\"NT$\"* #,##0_-;\\-\"NT$\"* #,##0_-;_-\"NT$\"* \"-\"_-;_-@_-",
+				44: "_-\"NT$\"* #,##0.00_-;\\-\"NT$\"* #,##0.00_-;_-\"NT$\"* \"-\"??_-;_-@_-",
+			},
+		},
+		CultureNameDeDE: {
+			localCode: "407", decimalSep: ",", thousandsSep: ".", currency: "€",
+			builtInNumFmt: map[int]string{
+				14: "dd.mm.yyyy",
+				15: "dd. mmm yy",
+				16: "dd. mmm",
+				17: "mmm yy",
+				22: "dd.mm.yyyy hh:mm",
+				42: "_-* #,##0 \"€\"_-;\\-* #,##0 \"€\"_-;_-* \"-\" \"€\"_-;_-@_-",
+				44: "_-* #,##0.00 \"€\"_-;\\-* #,##0.00 \"€\"_-;_-* \"-\"?? \"€\"_-;_-@_-",
+			},
+		},
+		CultureNameEsES: {
+			localCode: "C0A", decimalSep: ",", thousandsSep: ".", currency: "€",
+			builtInNumFmt: map[int]string{
+				14: "dd/mm/yyyy",
+				22: "dd/mm/yyyy h:mm",
+				42: "_-* #,##0 \"€\"_-;\\-* #,##0 \"€\"_-;_-* \"-\" \"€\"_-;_-@_-",
+				44: "_-* #,##0.00 \"€\"_-;\\-* #,##0.00 \"€\"_-;_-* \"-\"?? \"€\"_-;_-@_-",
+			},
+		},
+		CultureNameFrFR: {
+			localCode: "40C", decimalSep: ",", thousandsSep: "\u00a0", currency: "€",
+			builtInNumFmt: map[int]string{
+				14: "dd/mm/yyyy",
+				22: "dd/mm/yyyy hh:mm",
+				42: "_-* #,##0 \"€\"_-;\\-* #,##0 \"€\"_-;_-* \"-\" \"€\"_-;_-@_-",
+				44: "_-* #,##0.00 \"€\"_-;\\-* #,##0.00 \"€\"_-;_-* \"-\"?? \"€\"_-;_-@_-",
+			},
+		},
+		CultureNameItIT: {
+			localCode: "410", decimalSep: ",", thousandsSep: ".", currency: "€",
+			builtInNumFmt: map[int]string{
+				14: "dd/mm/yyyy",
+				22: "dd/mm/yyyy hh:mm",
+				42: "_-* #,##0 \"€\"_-;\\-* #,##0 \"€\"_-;_-* \"-\" \"€\"_-;_-@_-",
+				44: "_-* #,##0.00 \"€\"_-;\\-* #,##0.00 \"€\"_-;_-* \"-\"?? \"€\"_-;_-@_-",
+			},
+		},
+		CultureNamePtBR: {
+			localCode: "416", decimalSep: ",", thousandsSep: ".", currency: "R$",
+			builtInNumFmt: map[int]string{
+				14: "dd/mm/yyyy",
+				22: "dd/mm/yyyy hh:mm",
+				42: "_-\"R$\" * #,##0_-;\\-\"R$\" * #,##0_-;_-\"R$\" * \"-\"_-;_-@_-",
+				44: "_-\"R$\" * #,##0.00_-;\\-\"R$\" * #,##0.00_-;_-\"R$\" * \"-\"??_-;_-@_-",
+			},
+		},
+		CultureNameRuRU: {
+			localCode: "419", decimalSep: ",", thousandsSep: "\u00a0", currency: "\u20bd",
+			builtInNumFmt: map[int]string{
+				14: "dd.mm.yyyy",
+				22: "dd.mm.yyyy h:mm",
+				42: "_-* #,##0 \"\u20bd\"_-;\\-* #,##0 \"\u20bd\"_-;_-* \"-\" \"\u20bd\"_-;_-@_-",
+				44: "_-* #,##0.00 \"\u20bd\"_-;\\-* #,##0.00 \"\u20bd\"_-;_-* \"-\"?? \"\u20bd\"_-;_-@_-",
+			},
+		},
+	}
 	// currencyNumFmt defined the currency number format map.
 	currencyNumFmt = map[int]string{
 		164: "\"¥\"#,##0.00",
@@ -690,6 +802,7 @@ var (
 		nfp.TokenSubTypeCurrencyString,
 		nfp.TokenSubTypeLanguageInfo,
 		nfp.TokenTypeColor,
+		nfp.TokenTypeCondition,
 		nfp.TokenTypeCurrencyLanguage,
 		nfp.TokenTypeDateTimes,
 		nfp.TokenTypeDecimalPoint,
@@ -4687,8 +4800,9 @@ func getSupportedLanguageInfo(lang string) (languageInfo, bool) {
 }
 
 // applyBuiltInNumFmt provides a function to returns a value after formatted
-// with built-in number format code, or specified sort date format code.
-func (f *File) applyBuiltInNumFmt(c *xlsxC, fmtCode string, numFmtID int, date1904 bool, cellType CellType) string {
+// with built-in number format code, or specified sort date format code, and
+// the color name of the applied number format section.
+func (f *File) applyBuiltInNumFmt(c *xlsxC, fmtCode string, numFmtID int, date1904 bool, cellType CellType) (string, string) {
 	if f.options != nil && f.options.ShortDatePattern != "" {
 		if numFmtID == 14 {
 			fmtCode = f.options.ShortDatePattern
@@ -4697,7 +4811,7 @@ func (f *File) applyBuiltInNumFmt(c *xlsxC, fmtCode string, numFmtID int, date19
 			fmtCode = fmt.Sprintf("%s hh:mm", f.options.ShortDatePattern)
 		}
 	}
-	return format(c.V, fmtCode, date1904, cellType, f.options)
+	return formatWithColor(c.V, fmtCode, date1904, cellType, f.options)
 }
 
 // langNumFmtFuncEnUS returns number format code by given date and time pattern
@@ -4846,6 +4960,9 @@ func (f *File) extractNumFmtDecimal(fmtCode string) int {
 // getBuiltInNumFmtCode convert number format index to number format code with
 // specified locale and language.
 func (f *File) getBuiltInNumFmtCode(numFmtID int) (string, bool) {
+	if fmtCode, ok := supportedCultureInfo[f.options.CultureInfo].builtInNumFmt[numFmtID]; ok {
+		return fmtCode, true
+	}
 	if fmtCode, ok := builtInNumFmt[numFmtID]; ok {
 		return fmtCode, true
 	}
@@ -4872,26 +4989,58 @@ func (nf *numberFormat) prepareNumberic(value string) {
 // expression. If the given number format is not supported, this will return
 // the original cell value.
 func format(value, numFmt string, date1904 bool, cellType CellType, opts *Options) string {
+	result, _ := formatWithColor(value, numFmt, date1904, cellType, opts)
+	return result
+}
+
+// formatWithColor provides a function to return a string parse by number
+// format expression, and the color name of the number format section which
+// applied for the value, such as "Red" or "Color10". The color name will be
+// empty if the section doesn't specify a color.
+func formatWithColor(value, numFmt string, date1904 bool, cellType CellType, opts *Options) (string, string) {
 	p := nfp.NumberFormatParser()
 	nf := numberFormat{opts: opts, section: p.Parse(numFmt), value: value, date1904: date1904, cellType: cellType}
-	nf.number, nf.valueSectionType = nf.getValueSectionType(value)
+	if opts != nil {
+		nf.localCode = supportedCultureInfo[opts.CultureInfo].localCode
+	}
+	nf.prepareColorTokens()
+	if nf.number, nf.sectionIdx = nf.getValueSectionIdx(value); nf.sectionIdx == -1 {
+		return value, ""
+	}
 	nf.prepareNumberic(value)
-	for i, section := range nf.section {
-		nf.sectionIdx = i
-		if section.Type != nf.valueSectionType {
-			continue
+	section := nf.section[nf.sectionIdx]
+	for _, token := range section.Items {
+		if token.TType == nfp.TokenTypeColor && token.TValue != "" {
+			nf.color = strings.ToUpper(token.TValue[:1]) + strings.ToLower(token.TValue[1:])
 		}
-		if nf.isNumeric {
-			switch section.Type {
-			case nfp.TokenSectionPositive:
-				return nf.alignmentHandler(nf.positiveHandler())
-			default:
-				return nf.alignmentHandler(nf.negativeHandler())
+	}
+	if len(section.Items) == 0 {
+		return "", nf.color
+	}
+	if !nf.isNumeric {
+		return nf.alignmentHandler(nf.textHandler()), nf.color
+	}
+	if section.Type == nfp.TokenSectionPositive || nf.number >= 0 {
+		return nf.alignmentHandler(nf.positiveHandler()), nf.color
+	}
+	return nf.alignmentHandler(nf.negativeHandler()), nf.color
+}
+
+// prepareColorTokens convert the color tokens in the form of [ColorN] in the
+// number format expression sections, which the parser doesn't recognize, to
+// the color type tokens.
+func (nf *numberFormat) prepareColorTokens() {
+	for i := range nf.section {
+		for j, token := range nf.section[i].Items {
+			if token.TType != nfp.TokenTypeUnknown || len(token.TValue) <= 5 ||
+				!strings.EqualFold(token.TValue[:5], "color") {
+				continue
+			}
+			if idx, err := strconv.Atoi(token.TValue[5:]); err == nil && idx >= 1 && idx <= 56 {
+				nf.section[i].Items[j].TType = nfp.TokenTypeColor
 			}
 		}
-		return nf.alignmentHandler(nf.textHandler())
 	}
-	return value
 }
 
 // getNumberPartLen returns the length of integer and fraction parts for the
@@ -4921,7 +5070,8 @@ func (nf *numberFormat) getNumberPartLen() (int, int) {
 // getNumberFmtConf generate the number format padding and placeholder
 // configurations.
 func (nf *numberFormat) getNumberFmtConf() {
-	for _, token := range nf.section[nf.sectionIdx].Items {
+	nf.thousandsScale, nf.thousandsScaleTokens = getThousandsScaling(nf.section[nf.sectionIdx].Items)
+	for i, token := range nf.section[nf.sectionIdx].Items {
 		if token.TType == nfp.TokenTypeHashPlaceHolder {
 			if nf.usePointer {
 				nf.fracHolder += len(token.TValue)
@@ -4932,7 +5082,7 @@ func (nf *numberFormat) getNumberFmtConf() {
 		if token.TType == nfp.TokenTypeExponential {
 			nf.useScientificNotation = true
 		}
-		if token.TType == nfp.TokenTypeThousandsSeparator {
+		if token.TType == nfp.TokenTypeThousandsSeparator && !nf.thousandsScaleTokens[i] {
 			nf.useCommaSep = true
 		}
 		if token.TType == nfp.TokenTypePercent {
@@ -4962,6 +5112,38 @@ func (nf *numberFormat) getNumberFmtConf() {
 	}
 }
 
+// getThousandsScaling returns the number of thousands separators which
+// immediately follow the last digit placeholder in the number format
+// expression section tokens, and the indexes of these tokens. Each of these
+// separators scales the number by 1,000 and will not be printed.
+func getThousandsScaling(tokens []nfp.Token) (int, map[int]bool) {
+	var (
+		scale   int
+		last    = -1
+		indexes = map[int]bool{}
+	)
+	for i, token := range tokens {
+		if token.TType == nfp.TokenTypeHashPlaceHolder || token.TType == nfp.TokenTypeZeroPlaceHolder ||
+			token.TType == nfp.TokenTypeDigitalPlaceHolder {
+			last = i
+		}
+	}
+	if last == -1 {
+		return scale, indexes
+	}
+	for i := last + 1; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.TType == nfp.TokenTypeThousandsSeparator ||
+			(token.TType == nfp.TokenTypeLiteral && token.TValue != "" && strings.Trim(token.TValue, ",") == "") {
+			scale += strings.Count(token.TValue, ",")
+			indexes[i] = true
+			continue
+		}
+		break
+	}
+	return scale, indexes
+}
+
 // printNumberLiteral apply literal tokens for the pre-formatted text.
 func (nf *numberFormat) printNumberLiteral(text string) string {
 	var (
@@ -4972,14 +5154,18 @@ func (nf *numberFormat) printNumberLiteral(text string) string {
 	if nf.usePositive {
 		result += "-"
 	}
-	for _, token := range nf.section[nf.sectionIdx].Items {
+	tokens := nf.section[nf.sectionIdx].Items
+	for i, token := range tokens {
+		if token.TType == nfp.TokenTypeAlignment && i > 0 && i < len(tokens)-1 {
+			result += nfp.Whitespace
+		}
 		if token.TType == nfp.TokenTypeCurrencyLanguage {
 			if changeNumFmtCode, err := nf.currencyLanguageHandler(token); err != nil || changeNumFmtCode {
 				return nf.value
 			}
 			result += nf.currencyString
 		}
-		if token.TType == nfp.TokenTypeLiteral {
+		if token.TType == nfp.TokenTypeLiteral && !nf.thousandsScaleTokens[i] {
 			if usePlaceHolder {
 				useLiteral = true
 			}
@@ -5049,6 +5235,19 @@ func printCommaSep(text string) string {
 	return target.String()
 }
 
+// localSeparators replace the decimal and thousands separators in the
+// formatted number with the separators of the culture.
+func (nf *numberFormat) localSeparators(text string) string {
+	if nf.opts == nil {
+		return text
+	}
+	info, ok := supportedCultureInfo[nf.opts.CultureInfo]
+	if !ok {
+		return text
+	}
+	return strings.NewReplacer(".", info.decimalSep, ",", info.thousandsSep).Replace(text)
+}
+
 // printSwitchArgument format number with switch argument.
 func (nf *numberFormat) printSwitchArgument(text string) string {
 	if nf.switchArgument == "" {
@@ -5094,14 +5293,17 @@ func (nf *numberFormat) printBigNumber(decimal float64, fracLen int) string {
 // numeric.
 func (nf *numberFormat) numberHandler() string {
 	nf.getNumberFmtConf()
+	if nf.thousandsScale > 0 {
+		nf.number /= math.Pow(1000, float64(nf.thousandsScale))
+	}
 	var (
 		num             = nf.number
 		intLen, fracLen = nf.getNumberPartLen()
 		result          string
 	)
-	if isNum, precision, decimal := isNumeric(nf.value); isNum {
+	if isNum, precision, decimal := isNumeric(nf.value); isNum && nf.thousandsScale == 0 {
 		if precision > 15 && intLen+fracLen > 15 && !nf.useScientificNotation {
-			return nf.printNumberLiteral(nf.printBigNumber(decimal, fracLen))
+			return nf.printNumberLiteral(nf.localSeparators(nf.printBigNumber(decimal, fracLen)))
 		}
 	}
 	paddingLen := intLen + fracLen
@@ -5128,7 +5330,7 @@ func (nf *numberFormat) numberHandler() string {
 	if result = fmt.Sprintf(fmtCode, math.Abs(num)); nf.useCommaSep {
 		result = printCommaSep(result)
 	}
-	return nf.printNumberLiteral(result)
+	return nf.printNumberLiteral(nf.localSeparators(result))
 }
 
 // dateTimeHandler handling data and time number format expression for a
@@ -5156,7 +5358,7 @@ func (nf *numberFormat) dateTimeHandler() string {
 			continue
 		}
 		if token.TType == nfp.TokenTypeDecimalPoint {
-			nf.result += "."
+			nf.result += nf.localSeparators(".")
 		}
 		if token.TType == nfp.TokenTypeSwitchArgument {
 			nf.switchArgument = token.TValue
@@ -7256,7 +7458,11 @@ func (nf *numberFormat) negativeHandler() (result string) {
 
 // textHandler will be handling text selection for a number format expression.
 func (nf *numberFormat) textHandler() (result string) {
-	for _, token := range nf.section[nf.sectionIdx].Items {
+	tokens := nf.section[nf.sectionIdx].Items
+	for i, token := range tokens {
+		if token.TType == nfp.TokenTypeAlignment && i > 0 && i < len(tokens)-1 {
+			result += nfp.Whitespace
+		}
 		if token.TType == nfp.TokenTypeLiteral {
 			result += token.TValue
 		}
@@ -7267,29 +7473,89 @@ func (nf *numberFormat) textHandler() (result string) {
 	return result
 }
 
-// getValueSectionType returns its applicable number format expression section
-// based on the given value.
-func (nf *numberFormat) getValueSectionType(value string) (float64, string) {
-	if nf.cellType != CellTypeNumber && nf.cellType != CellTypeDate {
-		return 0, nfp.TokenSectionText
+// getSectionCondition returns the comparison operator and operand of the
+// condition in the given number format expression section.
+func getSectionCondition(section nfp.Section) (string, float64, bool) {
+	for _, token := range section.Items {
+		if token.TType != nfp.TokenTypeCondition || len(token.Parts) != 2 {
+			continue
+		}
+		operand, err := strconv.ParseFloat(token.Parts[1].Token.TValue, 64)
+		if err != nil {
+			return "", 0, false
+		}
+		return token.Parts[0].Token.TValue, operand, true
+	}
+	return "", 0, false
+}
+
+// matchSectionCondition returns if the given number matches the condition of
+// the number format expression section.
+func matchSectionCondition(number float64, operator string, operand float64) bool {
+	switch operator {
+	case "<":
+		return number < operand
+	case "<=":
+		return number <= operand
+	case ">":
+		return number > operand
+	case ">=":
+		return number >= operand
+	case "=":
+		return number == operand
+	case "<>":
+		return number != operand
+	}
+	return false
+}
+
+// getValueSectionIdx returns the number and the index of applicable number
+// format expression section based on the given value. The first section
+// applies for positive numbers, the second for negative numbers, the third
+// for zeros and the text section for non-numeric values by default, the
+// conditions in the first two sections override the default selection. This
+// function will return -1 if there is no applicable section for the value.
+func (nf *numberFormat) getValueSectionIdx(value string) (float64, int) {
+	var numeric, text []int
+	for i, section := range nf.section {
+		if section.Type == nfp.TokenSectionText {
+			text = append(text, i)
+			continue
+		}
+		numeric = append(numeric, i)
 	}
-	isNum, _, _ := isNumeric(value)
-	if !isNum {
-		return 0, nfp.TokenSectionText
+	if isNum, _, _ := isNumeric(value); !isNum || (nf.cellType != CellTypeNumber && nf.cellType != CellTypeDate) {
+		if len(text) == 0 {
+			return 0, -1
+		}
+		return 0, text[0]
 	}
 	number, _ := strconv.ParseFloat(value, 64)
-	if number >= 0 {
-		return number, nfp.TokenSectionPositive
-	}
-	var hasNeg bool
-	for _, sec := range nf.section {
-		if sec.Type == nfp.TokenSectionNegative {
-			hasNeg = true
+	if len(numeric) == 0 {
+		return number, -1
+	}
+	idx := 0
+	if len(numeric) > 1 {
+		op1, operand1, ok1 := getSectionCondition(nf.section[numeric[0]])
+		op2, operand2, ok2 := getSectionCondition(nf.section[numeric[1]])
+		switch {
+		case ok1 && matchSectionCondition(number, op1, operand1):
+			idx = 0
+		case ok2 && matchSectionCondition(number, op2, operand2):
+			idx = 1
+		case ok1 && ok2:
+			idx = 2
+		case ok1 || ok2:
+			idx = 1
+		case number < 0:
+			idx = 1
+		case number == 0:
+			idx = 2
+		}
+		if idx >= len(numeric) {
+			idx = 0
 		}
 	}
-	if !hasNeg {
-		nf.usePositive = true
-		return number, nfp.TokenSectionPositive
-	}
-	return number, nfp.TokenSectionNegative
+	nf.usePositive = number < 0 && len(numeric) == 1
+	return number, numeric[idx]
 }
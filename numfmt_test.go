@@ -3675,6 +3675,8 @@ func TestNumFmt(t *testing.T) {
 			{"1234.5678", "\"¥\"#,##0.00_);\\(\"¥\"#,##0.00\\)", "1234.5678"},
 			{"1234.5678", "0_);[Red]\\(0\\)", "1234.5678"},
 			{"1234.5678", "\"text\"@", "text1234.5678"},
+			{"abc", "[Green]#,##0;[Red](#,##0);\"-\";\"note: \"@", "note: abc"},
+			{"abc", "0;-0;;", ""},
 		} {
 			result := format(item[0], item[1], false, cellType, nil)
 			assert.Equal(t, item[2], result, item)
@@ -3685,3 +3687,82 @@ func TestNumFmt(t *testing.T) {
 	assert.Equal(t, ErrUnsupportedNumberFormat, err)
 	assert.False(t, changeNumFmtCode)
 }
+
+func TestNumFmtSections(t *testing.T) {
+	for _, item := range [][]string{
+		{"1234567", "[Green]#,##0;[Red](#,##0);\"-\";@", "1,234,567", "Green"},
+		{"-1234.5", "[Green]#,##0;[Red](#,##0);\"-\";@", "(1,235)", "Red"},
+		{"0", "[Green]#,##0;[Red](#,##0);\"-\";@", "-", ""},
+		{"-0.4", "[Green]#,##0;[Red](#,##0);\"-\";@", "(0)", "Red"},
+		{"0", "0;-0;", "", ""},
+		{"1234567", "[>=1000]#,##0,\"K\";0", "1,235K", ""},
+		{"500", "[>=1000]#,##0,\"K\";0", "500", ""},
+		{"-1234.5", "[>=1000]#,##0,\"K\";0", "1235", ""},
+		{"-1234.5", "[<=-1]\"neg\";[>100]\"big\";0", "neg", ""},
+		{"500", "[<=-1]\"neg\";[>100]\"big\";0", "big", ""},
+		{"50", "[<=-1]\"neg\";[>100]\"big\";0", "50", ""},
+		{"-5", "[<0]\"n\"0;\"p\"0", "n5", ""},
+		{"0", "[=0]\"zero\";0", "zero", ""},
+		{"5", "[<>5]0;\"five\"", "five", ""},
+		{"6", "[<5]0;[>5]\"six\"", "six", ""},
+		{"5", "[<5]0;[>5]\"six\"", "5", ""},
+		{"5", "[Blue][<5]0;[Magenta][>5]0;[Color10]0", "5", "Color10"},
+		{"1234567", "#,##0,,\"M\"", "1M", ""},
+		{"-1234.5", "#,##0,,\"M\"", "-0M", ""},
+		{"1234567", "0.0,", "1234.6", ""},
+		{"500", "0.0,", "0.5", ""},
+		{"1234567", "0\\ \"x\"_)* ", "1234567 x ", ""},
+		{"-5", "0\\ \"x\"_)* ", "-5 x ", ""},
+		{"1234567", "[Color10]0;0;\"zero\"", "1234567", "Color10"},
+		{"0", "[Color10]0;0;\"zero\"", "zero", ""},
+	} {
+		result, color := formatWithColor(item[0], item[1], false, CellTypeNumber, nil)
+		assert.Equal(t, item[2], result, item)
+		assert.Equal(t, item[3], color, item)
+	}
+}
+
+func TestCultureNumFmt(t *testing.T) {
+	cells := []struct {
+		value     float64
+		numFmt    int
+		customFmt string
+	}{
+		{45162.5, 14, ""}, {45162.5, 15, ""}, {45162.5, 22, ""}, {45162.5, 18, ""},
+		{-1234567.5, 4, ""}, {1234.5, 44, ""}, {0.25, 10, ""},
+		{45162.5, 0, "mmmm dddd"}, {45162.5, 0, "[$-409]mmmm dddd"},
+	}
+	for lang, expected := range map[CultureName][]string{
+		CultureNameDeDE: {"24.08.2023", "24. Aug 23", "24.08.2023 12:00", "12:00 PM", "-1.234.567,50", " 1.234,50 € ", "25,00%", "August Donnerstag", "August Thursday"},
+		CultureNameEsES: {"24/08/2023", "24-ago-23", "24/08/2023 12:00", "12:00 PM", "-1.234.567,50", " 1.234,50 € ", "25,00%", "agosto jueves", "August Thursday"},
+		CultureNameFrFR: {"24/08/2023", "24-août-23", "24/08/2023 12:00", "12:00 PM", "-1\u00a0234\u00a0567,50", " 1\u00a0234,50 € ", "25,00%", "août jeudi", "August Thursday"},
+		CultureNameItIT: {"24/08/2023", "24-ago-23", "24/08/2023 12:00", "12:00 PM", "-1.234.567,50", " 1.234,50 € ", "25,00%", "agosto giovedì", "August Thursday"},
+		CultureNamePtBR: {"24/08/2023", "24-ago-23", "24/08/2023 12:00", "12:00 PM", "-1.234.567,50", " R$ 1.234,50 ", "25,00%", "agosto quinta-feira", "August Thursday"},
+		CultureNameRuRU: {"24.08.2023", "24-авг.-23", "24.08.2023 12:00", "12:00 PM", "-1\u00a0234\u00a0567,50", " 1\u00a0234,50 ₽ ", "25,00%", "август четверг", "August Thursday"},
+		CultureNameJaJP: {"2023/8/24", "24-8月-23", "2023/8/24 12:00", "12:00 午後", "-1,234,567.50", " ¥1,234.50 ", "25.00%", "8月 木曜日", "August Thursday"},
+		CultureNameZhCN: {"2023/8/24", "24-8月-23", "2023/8/24 12:00", "12:00 下午", "-1,234,567.50", " ¥1,234.50 ", "25.00%", "八月 星期四", "August Thursday"},
+		CultureNameZhTW: {"2023/8/24", "24-8月-23", "2023/8/24 12:00", "12:00 下午", "-1,234,567.50", " NT$1,234.50 ", "25.00%", "8月 星期四", "August Thursday"},
+	} {
+		f := NewFile(Options{CultureInfo: lang})
+		for i, c := range cells {
+			cell, err := CoordinatesToCellName(1, i+1)
+			assert.NoError(t, err)
+			assert.NoError(t, f.SetCellValue("Sheet1", cell, c.value))
+			style := &Style{NumFmt: c.numFmt}
+			if c.customFmt != "" {
+				style = &Style{CustomNumFmt: &cells[i].customFmt}
+			}
+			styleID, err := f.NewStyle(style)
+			assert.NoError(t, err)
+			assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, styleID))
+			result, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected[i], result, lang, cell)
+		}
+		assert.NoError(t, f.Close())
+	}
+	// Test format number with the separators of the culture in big number
+	assert.Equal(t, "1.234.567.890.123.456,00", format("1234567890123456", "#,##0.00", false, CellTypeNumber, &Options{CultureInfo: CultureNameDeDE}))
+	// Test format date time with the decimal separator of the culture
+	assert.Equal(t, "00:00,5", format("0.0000058", "mm:ss.0", false, CellTypeNumber, &Options{CultureInfo: CultureNameFrFR}))
+}
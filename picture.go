@@ -13,9 +13,11 @@ package excelize
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/xml"
 	"image"
 	"io"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -35,6 +37,14 @@ const (
 	PictureInsertTypeDISPIMG
 )
 
+// SVGRenderer is the interface to render the SVG picture as the raster
+// picture, which is used as the fallback picture of the SVG picture. The
+// Render function returns the content of the raster picture in a format which
+// could be decoded by the image.DecodeConfig function, such as PNG.
+type SVGRenderer interface {
+	Render(svg []byte) ([]byte, error)
+}
+
 // parseGraphicOptions provides a function to parse the format settings of
 // the picture with default value.
 func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
@@ -144,6 +154,18 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // the image and ignore its aspect ratio, the default value of that is 'false'.
 // This option only works when the "AutoFit" is enabled.
 //
+// The optional parameter "AutoFitCell" specifies if resize the row height and
+// column width of the cell to fit the picture at its native size, the default
+// value of that is 'false'. The native size honors the DPI (dots per inch)
+// metadata in the PNG and JPEG pictures, and the row height and column width
+// are capped at the "MaxRowHeight" and "MaxColumnWidth", the picture will be
+// scaled down to fit the cell when the cell size has been capped. This option
+// takes precedence over the "AutoFit". For example, insert a picture in cell
+// B2 and fit the cell to it:
+//
+//	err := f.AddPicture("Sheet1", "B2", "image.png",
+//	    &excelize.GraphicOptions{AutoFitCell: true, Positioning: "oneCell"})
+//
 // The optional parameter "OffsetX" specifies the horizontal offset of the graph
 // object with the cell, the default value of that is 0.
 //
@@ -162,13 +184,35 @@ func parseGraphicOptions(opts *GraphicOptions) *GraphicOptions {
 // The optional parameter "HyperlinkType" defines two types of
 // hyperlink "External" for website or "Location" for moving to one of the
 // cells in this workbook. When the "HyperlinkType" is "Location",
-// coordinates need to start with "#".
+// coordinates need to start with "#", and the "#" will be added if the
+// coordinates don't start with it.
+//
+// The optional parameter "HyperlinkTooltip" specifies the tooltip of the
+// hyperlink of the graph object.
 //
 // The optional parameter "Positioning" defines 3 types of the position of a
 // graph object in a spreadsheet: "oneCell" (Move but don't size with
 // cells), "twoCell" (Move and size with cells), and "absolute" (Don't move or
 // size with cells). If you don't set this parameter, the default positioning
 // is to move and size with cells.
+//
+// The optional parameter "ToCell" specifies the cell reference of the bottom
+// right corner of the picture, the picture will be anchored over the cell range
+// from the given cell to this cell and stretched to fill the range, the
+// "AutoFit", "AutoFitCell", "ScaleX" and "ScaleY" parameters will be ignored
+// if this parameter has been set. Combined with the "Positioning" parameter, the picture will be
+// stretched or moved with the cells when resizing the rows or columns. For
+// example, insert a picture over the range B2:F10 and stretch to fill it:
+//
+//	err := f.AddPicture("Sheet1", "B2", "image.png",
+//	    &excelize.GraphicOptions{ToCell: "F10", Positioning: "twoCell"})
+//
+// The optional parameter "Fallback" specifies the raster picture (such as PNG)
+// used as the fallback picture of the SVG picture, the spreadsheet
+// applications which don't support SVG pictures will display the fallback
+// picture. If you don't set this parameter on adding the SVG picture, the
+// fallback picture will be generated by the "SVGRenderer" in the options of
+// the workbook, and an error will be returned if the renderer isn't set.
 func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error {
 	var err error
 	// Check picture exists first.
@@ -226,8 +270,6 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 //	    }
 //	}
 func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
-	var drawingHyperlinkRID int
-	var hyperlinkType string
 	ext, ok := supportedImageTypes[strings.ToLower(pic.Extension)]
 	if !ok {
 		return ErrImgExt
@@ -236,10 +278,24 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 		return ErrParameterInvalid
 	}
 	options := parseGraphicOptions(pic.Format)
-	img, _, err := image.DecodeConfig(bytes.NewReader(pic.File))
-	if err != nil {
+	var (
+		img         image.Config
+		fallback    []byte
+		fallbackExt string
+		err         error
+	)
+	if ext == ".svg" {
+		if img, fallback, fallbackExt, err = f.getSVGFallback(pic.File, options); err != nil {
+			return err
+		}
+	} else if img, _, err = image.DecodeConfig(bytes.NewReader(pic.File)); err != nil {
 		return err
 	}
+	if options.AutoFitCell && options.ToCell == "" {
+		dpiX, dpiY := getImageDPI(pic.File, ext)
+		img.Width = int(math.Round(float64(img.Width) * defaultImageDPI / dpiX))
+		img.Height = int(math.Round(float64(img.Height) * defaultImageDPI / dpiY))
+	}
 	// Read sheet data
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -254,36 +310,173 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
 	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
-	mediaStr := ".." + strings.TrimPrefix(f.addMedia(pic.File, ext), "xl")
-	var drawingRID int
+	var drawingRID, drawingSVGRID int
+	if fallback != nil {
+		drawingSVGRID = f.addDrawingImageRels(drawingRels, pic.File, ext)
+		drawingRID = f.addDrawingImageRels(drawingRels, fallback, fallbackExt)
+	} else {
+		drawingRID = f.addDrawingImageRels(drawingRels, pic.File, ext)
+	}
+	// Add picture with hyperlink.
+	drawingHyperlinkRID := f.addDrawingHyperlinkRels(drawingRels, options)
+	ws.mu.Unlock()
+	err = f.addDrawingPicture(sheet, drawingXML, cell, ext, drawingRID, drawingSVGRID, drawingHyperlinkRID, img, options)
+	if err != nil {
+		return err
+	}
+	if err = f.addContentTypePart(drawingID, "drawings"); err != nil {
+		return err
+	}
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	return err
+}
+
+// addDrawingImageRels provides a function to add the picture into the media
+// folder, and add the relationship of the picture to the drawing
+// relationships by given drawing relationships path, picture file and
+// extension. The existing relationship will be reused if the picture has been
+// referenced by the drawing.
+func (f *File) addDrawingImageRels(drawingRels string, file []byte, ext string) int {
+	var rID int
+	mediaStr := ".." + strings.TrimPrefix(f.addMedia(file, ext), "xl")
 	if rels, _ := f.relsReader(drawingRels); rels != nil {
 		for _, rel := range rels.Relationships {
 			if rel.Type == SourceRelationshipImage && rel.Target == mediaStr {
-				drawingRID, _ = strconv.Atoi(strings.TrimPrefix(rel.ID, "rId"))
+				rID, _ = strconv.Atoi(strings.TrimPrefix(rel.ID, "rId"))
 				break
 			}
 		}
 	}
-	if drawingRID == 0 {
-		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, mediaStr, hyperlinkType)
+	if rID == 0 {
+		rID = f.addRels(drawingRels, SourceRelationshipImage, mediaStr, "")
 	}
-	// Add picture with hyperlink.
-	if options.Hyperlink != "" && options.HyperlinkType != "" {
-		if options.HyperlinkType == "External" {
-			hyperlinkType = options.HyperlinkType
+	return rID
+}
+
+// addDrawingHyperlinkRels provides a function to add the relationship of the
+// hyperlink of the drawing object to the drawing relationships by given
+// drawing relationships path and graphic options, returns 0 if the hyperlink
+// hasn't been set. The location of the "Location" type hyperlink will be
+// prefixed with "#" if it isn't.
+func (f *File) addDrawingHyperlinkRels(drawingRels string, opts *GraphicOptions) int {
+	if opts.Hyperlink == "" || opts.HyperlinkType == "" {
+		return 0
+	}
+	if opts.HyperlinkType == "External" {
+		return f.addRels(drawingRels, SourceRelationshipHyperLink, opts.Hyperlink, opts.HyperlinkType)
+	}
+	target := opts.Hyperlink
+	if !strings.HasPrefix(target, "#") {
+		target = "#" + target
+	}
+	return f.addRels(drawingRels, SourceRelationshipHyperLink, target, "")
+}
+
+// getDrawingHyperlink provides a function to set the hyperlink settings of
+// the graphic options by given drawing relationships path, the relationship
+// ID and the tooltip of the hyperlink.
+func (f *File) getDrawingHyperlink(opts *GraphicOptions, drawingRelationships, rID, tooltip string) {
+	if rID == "" {
+		return
+	}
+	if rel := f.getDrawingRelationships(drawingRelationships, rID); rel != nil {
+		opts.Hyperlink, opts.HyperlinkType, opts.HyperlinkTooltip = rel.Target, "Location", tooltip
+		if rel.TargetMode == "External" {
+			opts.HyperlinkType = rel.TargetMode
 		}
-		drawingHyperlinkRID = f.addRels(drawingRels, SourceRelationshipHyperLink, options.Hyperlink, hyperlinkType)
 	}
-	ws.mu.Unlock()
-	err = f.addDrawingPicture(sheet, drawingXML, cell, ext, drawingRID, drawingHyperlinkRID, img, options)
+}
+
+// getSVGFallback provides a function to get the raster fallback picture of
+// the SVG picture by given SVG file and graphic options. The fallback picture
+// in the graphic options will be used first, otherwise the fallback picture
+// will be rendered by the SVG renderer in the options of the workbook. This
+// function returns the size of the picture, the fallback picture and the
+// extension of the fallback picture.
+func (f *File) getSVGFallback(svg []byte, opts *GraphicOptions) (image.Config, []byte, string, error) {
+	fallback := opts.Fallback
+	if fallback == nil {
+		if f.options == nil || f.options.SVGRenderer == nil {
+			return image.Config{}, nil, "", ErrSVGFallback
+		}
+		var err error
+		if fallback, err = f.options.SVGRenderer.Render(svg); err != nil {
+			return image.Config{}, nil, "", err
+		}
+	}
+	img, format, err := image.DecodeConfig(bytes.NewReader(fallback))
 	if err != nil {
-		return err
+		return img, nil, "", err
 	}
-	if err = f.addContentTypePart(drawingID, "drawings"); err != nil {
-		return err
+	ext, ok := supportedImageTypes["."+strings.ToLower(format)]
+	if !ok || ext == ".svg" {
+		return img, nil, "", ErrImgExt
+	}
+	if width, height, ok := getSVGSize(svg); ok {
+		img.Width, img.Height = width, height
+	}
+	return img, fallback, ext, err
+}
+
+// getSVGSize provides a function to get the size of the SVG picture in
+// pixels by the width and height attributes of the root element, or the view
+// box of the root element if the width or height attribute is missing.
+func getSVGSize(svg []byte) (int, int, bool) {
+	decoder := xml.NewDecoder(bytes.NewReader(svg))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, 0, false
+		}
+		root, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root.Name.Local != "svg" {
+			return 0, 0, false
+		}
+		var width, height float64
+		var viewBox []string
+		for _, attr := range root.Attr {
+			switch attr.Name.Local {
+			case "width":
+				width = parseSVGLength(attr.Value)
+			case "height":
+				height = parseSVGLength(attr.Value)
+			case "viewBox":
+				viewBox = strings.Fields(strings.ReplaceAll(attr.Value, ",", " "))
+			}
+		}
+		if (width <= 0 || height <= 0) && len(viewBox) == 4 {
+			width, _ = strconv.ParseFloat(viewBox[2], 64)
+			height, _ = strconv.ParseFloat(viewBox[3], 64)
+		}
+		if width <= 0 || height <= 0 {
+			return 0, 0, false
+		}
+		return int(math.Round(width)), int(math.Round(height)), true
 	}
-	f.addSheetNameSpace(sheet, SourceRelationship)
-	return err
+}
+
+// parseSVGLength provides a function to convert the SVG length with the
+// absolute unit to pixels. It returns 0 for the relative or invalid length.
+func parseSVGLength(val string) float64 {
+	val = strings.TrimSpace(val)
+	units := map[string]float64{
+		"px": 1, "pt": 96.0 / 72, "pc": 16, "in": 96, "cm": 96 / 2.54, "mm": 96 / 25.4,
+	}
+	scale := 1.0
+	if len(val) > 2 {
+		if s, ok := units[val[len(val)-2:]]; ok {
+			val, scale = val[:len(val)-2], s
+		}
+	}
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0
+	}
+	return num * scale
 }
 
 // addSheetLegacyDrawing provides a function to add legacy drawing element to
@@ -349,7 +542,7 @@ func (f *File) countDrawings() int {
 // addDrawingPicture provides a function to add picture by given sheet,
 // drawingXML, cell, file name, width, height relationship index and format
 // sets.
-func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyperlinkRID int, img image.Config, opts *GraphicOptions) error {
+func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, svgRID, hyperlinkRID int, img image.Config, opts *GraphicOptions) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -358,7 +551,11 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 		return ErrParameterInvalid
 	}
 	width, height := img.Width, img.Height
-	if opts.AutoFit {
+	if opts.AutoFitCell && opts.ToCell == "" {
+		if width, height, err = f.drawingAutoFitCell(sheet, col, row, width, height, opts); err != nil {
+			return err
+		}
+	} else if opts.AutoFit {
 		if width, height, col, row, err = f.drawingResize(sheet, cell, float64(width), float64(height), opts); err != nil {
 			return err
 		}
@@ -367,6 +564,11 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 		height = int(float64(height) * opts.ScaleY)
 	}
 	colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, col, row, opts.OffsetX, opts.OffsetY, width, height)
+	if opts.ToCell != "" {
+		if colEnd, rowEnd, x2, y2, err = f.getDrawingToCellPosition(sheet, col, row, opts.ToCell); err != nil {
+			return err
+		}
+	}
 	content, cNvPrID, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
@@ -392,20 +594,21 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	pic.NvPicPr.CNvPr.Name = "Picture " + strconv.Itoa(cNvPrID)
 	if hyperlinkRID != 0 {
 		pic.NvPicPr.CNvPr.HlinkClick = &xlsxHlinkClick{
-			R:   SourceRelationship.Value,
-			RID: "rId" + strconv.Itoa(hyperlinkRID),
+			R:       SourceRelationship.Value,
+			RID:     "rId" + strconv.Itoa(hyperlinkRID),
+			Tooltip: opts.HyperlinkTooltip,
 		}
 	}
 	pic.BlipFill.Blip.R = SourceRelationship.Value
 	pic.BlipFill.Blip.Embed = "rId" + strconv.Itoa(rID)
-	if ext == ".svg" {
+	if ext == ".svg" && svgRID != 0 {
 		pic.BlipFill.Blip.ExtList = &xlsxEGOfficeArtExtensionList{
 			Ext: []xlsxCTOfficeArtExtension{
 				{
 					URI: ExtURISVG,
 					SVGBlip: xlsxCTSVGBlip{
 						XMLNSaAVG: NameSpaceDrawing2016SVG.Value,
-						Embed:     pic.BlipFill.Blip.Embed,
+						Embed:     "rId" + strconv.Itoa(svgRID),
 					},
 				},
 			},
@@ -425,6 +628,21 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	return err
 }
 
+// getDrawingToCellPosition provides a function to get the end position of the
+// drawing object which stretched to fill the bottom right corner of the given
+// cell. The column and row number of the end cell should not be less than the
+// column and row number of the start cell.
+func (f *File) getDrawingToCellPosition(sheet string, col, row int, toCell string) (int, int, int, int, error) {
+	toCol, toRow, err := CellNameToCoordinates(toCell)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if toCol < col || toRow < row {
+		return 0, 0, 0, 0, ErrParameterInvalid
+	}
+	return toCol - 1, toRow - 1, f.getColWidth(sheet, toCol), f.getRowHeight(sheet, toRow), err
+}
+
 // countMedia provides a function to get media files count storage in the
 // folder xl/media/image.
 func (f *File) countMedia() int {
@@ -548,55 +766,269 @@ func (f *File) GetPictureCells(sheet string) ([]string, error) {
 	return append(embeddedImageCells, imageCells...), err
 }
 
+// GetSheetPictures provides a function to get all pictures placed over the
+// cells in a worksheet by given worksheet name, including the pictures
+// anchored over a cell range, anchored to a cell and floating absolutely.
+// Each placement of a picture returns a Picture with the Anchor, which
+// specifies the anchor type, the drawing object name and the anchor cells
+// with offsets in pixels, and the placements of the same image share the
+// same content. The anchor type is one of "twoCell", "oneCell" and
+// "absolute", and the offsets of the starting anchor of the absolute anchored
+// picture are the position of the picture in the worksheet, without the
+// anchor cells. Set the HeaderFooter option to return the pictures in the
+// header and footer with the anchor type "headerFooter", and the drawing
+// object name is the position of the picture, such as "LH" for the left
+// header and "CFFIRST" for the center footer of the first page. Set the
+// InCell option to return the pictures in the cells with the anchor type
+// "cell", and the starting anchor is the cell reference. For example, get all
+// pictures in the worksheet named Sheet1:
+//
+//	pics, err := f.GetSheetPictures("Sheet1", excelize.SheetPicturesOptions{
+//	    HeaderFooter: true,
+//	    InCell:       true,
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for idx, pic := range pics {
+//	    name := fmt.Sprintf("%s%d%s", pic.Anchor.Type, idx+1, pic.Extension)
+//	    if err := os.WriteFile(name, pic.File, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetSheetPictures(sheet string, opts ...SheetPicturesOptions) ([]Picture, error) {
+	var options SheetPicturesOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	var pics []Picture
+	if ws.Drawing != nil {
+		target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+		drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+		if pics, err = f.getDrawingPictures(drawingXML, "xl/drawings/_rels/"+filepath.Base(drawingXML)+".rels"); err != nil {
+			return nil, err
+		}
+	}
+	if options.InCell {
+		cells, err := f.getImageCells(sheet)
+		if err != nil {
+			return nil, err
+		}
+		for idx, cell := range cells {
+			if inStrSlice(cells[:idx], cell, true) != -1 {
+				continue
+			}
+			imgs, err := f.getCellImages(sheet, cell)
+			if err != nil {
+				return nil, err
+			}
+			for i := range imgs {
+				imgs[i].Anchor = &PictureAnchor{Type: "cell", From: cell}
+			}
+			pics = append(pics, imgs...)
+		}
+	}
+	if options.HeaderFooter && ws.LegacyDrawingHF != nil {
+		imgs, err := f.getHeaderFooterPictures(sheet, ws.LegacyDrawingHF.RID)
+		if err != nil {
+			return nil, err
+		}
+		pics = append(pics, imgs...)
+	}
+	return pics, err
+}
+
 // DeletePicture provides a function to delete all pictures in a cell by given
-// worksheet name and cell reference.
+// worksheet name and cell reference. The media part of the picture will be
+// removed if it's no longer referenced by other parts of the workbook.
 func (f *File) DeletePicture(sheet, cell string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	col--
-	row--
+	return f.deleteSheetPictures(sheet, nil, func(_ int, pic *anchorPicture) bool {
+		return pic.col == col-1 && pic.row == row-1
+	})
+}
+
+// DeletePictureByName provides a function to delete the pictures placed over
+// the cells by given worksheet name and the object name of the pictures, such
+// as "Picture 2". The media part of the picture will be removed if it's no
+// longer referenced by other parts of the workbook. An error will be returned
+// if the picture with the given name doesn't exist.
+func (f *File) DeletePictureByName(sheet, name string) error {
+	return f.deleteSheetPictures(sheet, newNoExistPictureError(sheet, name), func(_ int, pic *anchorPicture) bool {
+		return pic.name == name
+	})
+}
+
+// DeletePictureByIndex provides a function to delete the picture placed over
+// the cells by given worksheet name and the zero-based index of the picture,
+// the index is the same as the order of the pictures placed over the cells in
+// the result of the GetSheetPictures function. The media part of the picture
+// will be removed if it's no longer referenced by other parts of the workbook.
+// An error will be returned if the index is out of range.
+func (f *File) DeletePictureByIndex(sheet string, idx int) error {
+	if idx < 0 {
+		return ErrParameterInvalid
+	}
+	return f.deleteSheetPictures(sheet, newNoExistPictureError(sheet, strconv.Itoa(idx)), func(i int, _ *anchorPicture) bool {
+		return i == idx
+	})
+}
+
+// anchorPicture defines the object name, the start cell coordinates and the
+// relationship IDs of the picture in the drawing cell anchor.
+type anchorPicture struct {
+	name     string
+	col, row int
+	rIDs     []string
+}
+
+// getAnchorPictureRels provides a function to get the object name, the start
+// cell coordinates and the relationship IDs of the picture by given drawing
+// cell anchor, returns nil if the drawing object is not a picture.
+func (f *File) getAnchorPictureRels(anchor *xdrCellAnchor) *anchorPicture {
+	pic := &anchorPicture{col: -1, row: -1}
+	if anchor.GraphicFrame == "" {
+		if anchor.Pic == nil {
+			return nil
+		}
+		pic.name = anchor.Pic.NvPicPr.CNvPr.Name
+		pic.rIDs = []string{anchor.Pic.BlipFill.Blip.Embed, getBlipSVGEmbed(&anchor.Pic.BlipFill.Blip)}
+		if anchor.Pic.NvPicPr.CNvPr.HlinkClick != nil {
+			pic.rIDs = append(pic.rIDs, anchor.Pic.NvPicPr.CNvPr.HlinkClick.RID)
+		}
+		if anchor.From != nil {
+			pic.col, pic.row = anchor.From.Col, anchor.From.Row
+		}
+		return pic
+	}
+	deCellAnchor := new(decodeCellAnchor)
+	_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(&deCellAnchor)
+	if deCellAnchor.Pic == nil {
+		return nil
+	}
+	pic.name = deCellAnchor.Pic.NvPicPr.CNvPr.Name
+	pic.rIDs = []string{deCellAnchor.Pic.BlipFill.Blip.Embed, getDecodeBlipSVGEmbed(&deCellAnchor.Pic.BlipFill.Blip)}
+	if deCellAnchor.Pic.NvPicPr.CNvPr.HlinkClick != nil {
+		pic.rIDs = append(pic.rIDs, deCellAnchor.Pic.NvPicPr.CNvPr.HlinkClick.RID)
+	}
+	if deCellAnchor.From != nil {
+		pic.col, pic.row = deCellAnchor.From.Col, deCellAnchor.From.Row
+	}
+	return pic
+}
+
+// deleteSheetPictures provides a function to delete the pictures placed over
+// the cells which satisfy the given condition by given worksheet name, and
+// remove the relationships and the media parts which no longer referenced.
+// The given not exist error will be returned if no picture has been deleted.
+func (f *File) deleteSheetPictures(sheet string, notExistErr error, cond func(idx int, pic *anchorPicture) bool) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
 	if ws.Drawing == nil {
-		return err
+		return notExistErr
 	}
 	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
 	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
-	rID, err := f.deleteDrawing(col, row, drawingXML, "Pic")
+	wsDr, _, err := f.drawingParser(drawingXML)
 	if err != nil {
 		return err
 	}
-	rels := f.getDrawingRelationships(drawingRels, rID)
-	if rels == nil {
-		return err
+	wsDr.mu.Lock()
+	var (
+		idx               int
+		deleted, usedRIDs []string
+	)
+	for _, anchors := range []*[]*xdrCellAnchor{&wsDr.TwoCellAnchor, &wsDr.OneCellAnchor, &wsDr.AbsoluteAnchor} {
+		var kept []*xdrCellAnchor
+		for _, anchor := range *anchors {
+			pic := f.getAnchorPictureRels(anchor)
+			if pic == nil {
+				kept = append(kept, anchor)
+				continue
+			}
+			if cond(idx, pic) {
+				deleted = append(deleted, pic.rIDs...)
+			} else {
+				kept = append(kept, anchor)
+				usedRIDs = append(usedRIDs, pic.rIDs...)
+			}
+			idx++
+		}
+		*anchors = kept
 	}
+	wsDr.mu.Unlock()
+	if len(deleted) == 0 {
+		return notExistErr
+	}
+	for _, rID := range deleted {
+		if rID == "" || inStrSlice(usedRIDs, rID, true) != -1 {
+			continue
+		}
+		rel := f.getDrawingRelationships(drawingRels, rID)
+		if rel == nil {
+			continue
+		}
+		f.deleteDrawingRels(drawingRels, rID)
+		if rel.Type == SourceRelationshipImage && rel.TargetMode != "External" {
+			f.deleteUnusedMedia(getDrawingRelTarget(rel.Target))
+		}
+	}
+	return err
+}
+
+// deleteUnusedMedia provides a function to delete the media part by given
+// part path if it's no longer referenced by the relationships of the
+// drawings, the VML drawings of headers and footers, the worksheets and the
+// workbook.
+func (f *File) deleteUnusedMedia(media string) {
 	var used bool
-	checkPicRef := func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/drawings/_rels/drawing") {
-			r, err := f.relsReader(k.(string))
-			if err != nil {
-				return true
+	checkMediaRef := func(k, v interface{}) bool {
+		relsPath := k.(string)
+		if !strings.HasSuffix(relsPath, ".rels") {
+			return true
+		}
+		rels, err := f.relsReader(relsPath)
+		if err != nil || rels == nil {
+			return true
+		}
+		rels.mu.Lock()
+		defer rels.mu.Unlock()
+		dir := path.Dir(path.Dir(relsPath))
+		for _, rel := range rels.Relationships {
+			if rel.TargetMode == "External" {
+				continue
 			}
-			for _, rel := range r.Relationships {
-				if rel.ID != rels.ID && rel.Type == SourceRelationshipImage &&
-					filepath.Base(rel.Target) == filepath.Base(rels.Target) {
-					used = true
-				}
+			target := path.Join(dir, rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			if target == media {
+				used = true
+				return false
 			}
 		}
 		return true
 	}
-	f.Relationships.Range(checkPicRef)
-	f.Pkg.Range(checkPicRef)
+	f.Relationships.Range(checkMediaRef)
 	if !used {
-		f.Pkg.Delete(strings.Replace(rels.Target, "../", "xl/", -1))
+		f.Pkg.Range(checkMediaRef)
+	}
+	if !used {
+		f.Pkg.Delete(media)
 	}
-	f.deleteDrawingRels(drawingRels, rID)
-	return err
 }
 
 // getPicture provides a function to get picture base name and raw content
@@ -611,10 +1043,14 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	cond := func(from *xlsxFrom) bool { return from.Col == col && from.Row == row }
 	cond2 := func(from *decodeFrom) bool { return from.Col == col && from.Row == row }
 	cb := func(a *xdrCellAnchor, r *xlsxRelationship) {
-		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
+		pic := Picture{Extension: filepath.Ext(r.Target), Format: &GraphicOptions{Positioning: a.EditAs}, InsertType: PictureInsertTypePlaceOverCells}
 		if buffer, _ := f.Pkg.Load(filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			f.getSVGPicture(&pic, drawingRelationships, getBlipSVGEmbed(&a.Pic.BlipFill.Blip))
+			if link := a.Pic.NvPicPr.CNvPr.HlinkClick; link != nil {
+				f.getDrawingHyperlink(pic.Format, drawingRelationships, link.RID, link.Tooltip)
+			}
 			pics = append(pics, pic)
 		}
 	}
@@ -626,10 +1062,14 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 			target = filepath.ToSlash(filepath.Clean("xl/drawings/" + r.Target))
 		}
 
-		pic := Picture{Extension: filepath.Ext(target), Format: &GraphicOptions{}, InsertType: PictureInsertTypePlaceOverCells}
+		pic := Picture{Extension: filepath.Ext(target), Format: &GraphicOptions{Positioning: a.EditAs}, InsertType: PictureInsertTypePlaceOverCells}
 		if buffer, _ := f.Pkg.Load(target); buffer != nil {
 			pic.File = buffer.([]byte)
 			pic.Format.AltText = a.Pic.NvPicPr.CNvPr.Descr
+			f.getSVGPicture(&pic, drawingRelationships, getDecodeBlipSVGEmbed(&a.Pic.BlipFill.Blip))
+			if link := a.Pic.NvPicPr.CNvPr.HlinkClick; link != nil {
+				f.getDrawingHyperlink(pic.Format, drawingRelationships, link.RID, link.Tooltip)
+			}
 			pics = append(pics, pic)
 		}
 	}
@@ -642,6 +1082,211 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 	return
 }
 
+// getDrawingRelTarget provides a function to get the part path of the target
+// by given target of the drawing relationship.
+func getDrawingRelTarget(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return filepath.ToSlash(filepath.Clean("xl/drawings/" + target))
+}
+
+// getBlipSVGEmbed provides a function to get the relationship ID of the SVG
+// picture by given blip.
+func getBlipSVGEmbed(blip *xlsxBlip) string {
+	if blip.ExtList != nil {
+		for _, ext := range blip.ExtList.Ext {
+			if ext.URI == ExtURISVG {
+				return ext.SVGBlip.Embed
+			}
+		}
+	}
+	return ""
+}
+
+// getDecodeBlipSVGEmbed provides a function to get the relationship ID of the
+// SVG picture by given decoded blip.
+func getDecodeBlipSVGEmbed(blip *decodeBlip) string {
+	if blip.ExtLst != nil {
+		for _, ext := range blip.ExtLst.Ext {
+			if ext.URI == ExtURISVG && ext.SVGBlip != nil {
+				return ext.SVGBlip.Embed
+			}
+		}
+	}
+	return ""
+}
+
+// getSVGPicture provides a function to replace the picture file with the SVG
+// picture by given relationship ID of the SVG picture, and the original
+// picture file will be used as the fallback picture.
+func (f *File) getSVGPicture(pic *Picture, drawingRelationships, svgEmbed string) {
+	if svgEmbed == "" {
+		return
+	}
+	drawRel := f.getDrawingRelationships(drawingRelationships, svgEmbed)
+	if drawRel == nil {
+		return
+	}
+	target := getDrawingRelTarget(drawRel.Target)
+	if buffer, _ := f.Pkg.Load(target); buffer != nil {
+		pic.Format.Fallback, pic.File, pic.Extension = pic.File, buffer.([]byte), filepath.Ext(target)
+	}
+}
+
+// getDrawingPictures provides a function to get all pictures with the anchors
+// by given drawing part path and drawing relationships path.
+func (f *File) getDrawingPictures(drawingXML, drawingRelationships string) ([]Picture, error) {
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	var pics []Picture
+	for _, group := range []struct {
+		anchorType string
+		anchors    []*xdrCellAnchor
+	}{
+		{anchorType: "twoCell", anchors: wsDr.TwoCellAnchor},
+		{anchorType: "oneCell", anchors: wsDr.OneCellAnchor},
+		{anchorType: "absolute", anchors: wsDr.AbsoluteAnchor},
+	} {
+		for _, anchor := range group.anchors {
+			if pic, ok := f.getAnchorPicture(anchor, drawingRelationships); ok {
+				pic.Anchor.Type = group.anchorType
+				pics = append(pics, pic)
+			}
+		}
+	}
+	return pics, err
+}
+
+// getAnchorPicture provides a function to get the picture and the anchor by
+// given drawing cell anchor and drawing relationships path, returns false if
+// the drawing object is not a picture.
+func (f *File) getAnchorPicture(anchor *xdrCellAnchor, drawingRelationships string) (Picture, bool) {
+	pic := Picture{
+		Format:     &GraphicOptions{Positioning: anchor.EditAs},
+		InsertType: PictureInsertTypePlaceOverCells,
+		Anchor:     &PictureAnchor{},
+	}
+	var embed, svgEmbed, linkRID, linkTooltip string
+	var from, to, pos []int
+	if anchor.GraphicFrame == "" {
+		if anchor.Pic == nil {
+			return pic, false
+		}
+		embed, pic.Anchor.Name, pic.Format.AltText = anchor.Pic.BlipFill.Blip.Embed,
+			anchor.Pic.NvPicPr.CNvPr.Name, anchor.Pic.NvPicPr.CNvPr.Descr
+		svgEmbed = getBlipSVGEmbed(&anchor.Pic.BlipFill.Blip)
+		if link := anchor.Pic.NvPicPr.CNvPr.HlinkClick; link != nil {
+			linkRID, linkTooltip = link.RID, link.Tooltip
+		}
+		if anchor.From != nil {
+			from = []int{anchor.From.Col, anchor.From.ColOff, anchor.From.Row, anchor.From.RowOff}
+		}
+		if anchor.To != nil {
+			to = []int{anchor.To.Col, anchor.To.ColOff, anchor.To.Row, anchor.To.RowOff}
+		}
+		if anchor.Pos != nil {
+			pos = []int{anchor.Pos.X, anchor.Pos.Y}
+		}
+	} else {
+		deCellAnchor := new(decodeCellAnchor)
+		_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(&deCellAnchor)
+		if deCellAnchor.Pic == nil {
+			return pic, false
+		}
+		embed, pic.Anchor.Name, pic.Format.AltText = deCellAnchor.Pic.BlipFill.Blip.Embed,
+			deCellAnchor.Pic.NvPicPr.CNvPr.Name, deCellAnchor.Pic.NvPicPr.CNvPr.Descr
+		svgEmbed = getDecodeBlipSVGEmbed(&deCellAnchor.Pic.BlipFill.Blip)
+		if link := deCellAnchor.Pic.NvPicPr.CNvPr.HlinkClick; link != nil {
+			linkRID, linkTooltip = link.RID, link.Tooltip
+		}
+		if deCellAnchor.From != nil {
+			from = []int{deCellAnchor.From.Col, deCellAnchor.From.ColOff, deCellAnchor.From.Row, deCellAnchor.From.RowOff}
+		}
+		if deCellAnchor.To != nil {
+			to = []int{deCellAnchor.To.Col, deCellAnchor.To.ColOff, deCellAnchor.To.Row, deCellAnchor.To.RowOff}
+		}
+		if deCellAnchor.Pos != nil {
+			pos = []int{deCellAnchor.Pos.X, deCellAnchor.Pos.Y}
+		}
+	}
+	drawRel := f.getDrawingRelationships(drawingRelationships, embed)
+	if drawRel == nil {
+		return pic, false
+	}
+	target := getDrawingRelTarget(drawRel.Target)
+	if _, ok := supportedImageTypes[strings.ToLower(filepath.Ext(target))]; !ok {
+		return pic, false
+	}
+	buffer, _ := f.Pkg.Load(target)
+	if buffer == nil {
+		return pic, false
+	}
+	pic.Extension, pic.File = filepath.Ext(target), buffer.([]byte)
+	f.getSVGPicture(&pic, drawingRelationships, svgEmbed)
+	f.getDrawingHyperlink(pic.Format, drawingRelationships, linkRID, linkTooltip)
+	if from != nil {
+		pic.Anchor.From, _ = CoordinatesToCellName(from[0]+1, from[2]+1)
+		pic.Anchor.FromOffsetX, pic.Anchor.FromOffsetY = from[1]/EMU, from[3]/EMU
+	}
+	if to != nil {
+		pic.Anchor.To, _ = CoordinatesToCellName(to[0]+1, to[2]+1)
+		pic.Anchor.ToOffsetX, pic.Anchor.ToOffsetY = to[1]/EMU, to[3]/EMU
+	}
+	if pos != nil {
+		pic.Anchor.FromOffsetX, pic.Anchor.FromOffsetY = pos[0]/EMU, pos[1]/EMU
+	}
+	return pic, true
+}
+
+// getHeaderFooterPictures provides a function to get the pictures in the
+// header and footer by given worksheet name and the relationship ID of the
+// legacy drawing of the header and footer.
+func (f *File) getHeaderFooterPictures(sheet, rID string) ([]Picture, error) {
+	target := f.getSheetRelationshipsTargetByID(sheet, rID)
+	drawingVML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingVMLRels := "xl/drawings/_rels/" + filepath.Base(drawingVML) + ".rels"
+	var ids, vals []string
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, shape := range vml.Shape {
+			ids, vals = append(ids, shape.ID), append(vals, shape.Val)
+		}
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			for _, shape := range d.Shape {
+				ids, vals = append(ids, shape.ID), append(vals, shape.Val)
+			}
+		}
+	}
+	var pics []Picture
+	for i, val := range vals {
+		var shapeVal decodeShapeVal
+		if err := f.xmlNewDecoder(strings.NewReader("<shape>" + val + "</shape>")).Decode(&shapeVal); err != nil || shapeVal.ImageData == nil {
+			continue
+		}
+		r := f.getDrawingRelationships(drawingVMLRels, shapeVal.ImageData.RelID)
+		if r == nil {
+			continue
+		}
+		if buffer, _ := f.Pkg.Load(strings.TrimPrefix(strings.ReplaceAll(r.Target, "..", "xl"), "/")); buffer != nil {
+			pics = append(pics, Picture{
+				Extension: filepath.Ext(r.Target), File: buffer.([]byte),
+				Format: &GraphicOptions{AltText: shapeVal.ImageData.Title},
+				Anchor: &PictureAnchor{Type: "headerFooter", Name: ids[i]},
+			})
+		}
+	}
+	return pics, nil
+}
+
 // extractCellAnchor extract drawing object from cell anchor by giving drawing
 // cell anchor, drawing relationships part path, conditional and callback
 // function.
@@ -677,6 +1322,7 @@ func (f *File) extractDecodeCellAnchor(anchor *xdrCellAnchor, drawingRelationshi
 		deCellAnchor = new(decodeCellAnchor)
 	)
 	_ = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).Decode(&deCellAnchor)
+	deCellAnchor.EditAs = anchor.EditAs
 	if deCellAnchor.From != nil && deCellAnchor.Pic != nil {
 		if cond(deCellAnchor.From) {
 			if drawRel = f.getDrawingRelationships(drawingRelationships, deCellAnchor.Pic.BlipFill.Blip.Embed); drawRel != nil {
@@ -764,6 +1410,103 @@ func (f *File) drawingResize(sheet, cell string, width, height float64, opts *Gr
 	return
 }
 
+// drawingAutoFitCell provides a function to resize the row height and column
+// width of the cell to fit the picture by given worksheet name, cell
+// coordinates, picture width and height in pixels and format sets. The row
+// height and column width are capped at the maximum value, and returns the
+// width and height of the picture after scaled down to fit the cell.
+func (f *File) drawingAutoFitCell(sheet string, col, row, width, height int, opts *GraphicOptions) (w, h int, err error) {
+	colName, err := ColumnNumberToName(col)
+	if err != nil {
+		return
+	}
+	maxDigitWidth, _ := f.getDefaultFontMetrics()
+	w, h = int(float64(width)*opts.ScaleX), int(float64(height)*opts.ScaleY)
+	colWidth := math.Min(convertPixelsToColWidth(float64(w+opts.OffsetX), maxDigitWidth), MaxColumnWidth)
+	rowHeight := math.Min(convertPixelsToRowHeight(float64(h+opts.OffsetY)), MaxRowHeight)
+	if err = f.SetColWidth(sheet, colName, colName, colWidth); err != nil {
+		return
+	}
+	if err = f.SetRowHeight(sheet, row, rowHeight); err != nil {
+		return
+	}
+	cellWidth, cellHeight := f.getColWidth(sheet, col)-opts.OffsetX, f.getRowHeight(sheet, row)-opts.OffsetY
+	if w > cellWidth {
+		w, h = cellWidth, int(float64(h)*float64(cellWidth)/float64(w))
+	}
+	if h > cellHeight {
+		w, h = int(float64(w)*float64(cellHeight)/float64(h)), cellHeight
+	}
+	return
+}
+
+// getImageDPI provides a function to get the horizontal and vertical
+// resolution in DPI (dots per inch) of the PNG or JPEG picture by given
+// picture file and extension. The default resolution 96 DPI will be returned
+// if the picture doesn't contain the resolution metadata.
+func getImageDPI(file []byte, ext string) (dpiX, dpiY float64) {
+	dpiX, dpiY = defaultImageDPI, defaultImageDPI
+	switch ext {
+	case ".png":
+		if x, y := getPNGDPI(file); x > 0 && y > 0 {
+			dpiX, dpiY = x, y
+		}
+	case ".jpg", ".jpeg":
+		if x, y := getJPEGDPI(file); x > 0 && y > 0 {
+			dpiX, dpiY = x, y
+		}
+	}
+	return
+}
+
+// getPNGDPI provides a function to get the resolution in DPI from the physical
+// pixel dimensions chunk of the PNG picture, returns zero if the chunk doesn't
+// exist or the unit isn't meter.
+func getPNGDPI(file []byte) (dpiX, dpiY float64) {
+	for idx := 8; idx+8 <= len(file); {
+		length := int(binary.BigEndian.Uint32(file[idx : idx+4]))
+		typ, data := string(file[idx+4:idx+8]), idx+8
+		if data+length > len(file) || typ == "IDAT" {
+			return
+		}
+		if typ == "pHYs" && length == 9 {
+			if file[data+8] == 1 {
+				dpiX = float64(binary.BigEndian.Uint32(file[data:data+4])) * 0.0254
+				dpiY = float64(binary.BigEndian.Uint32(file[data+4:data+8])) * 0.0254
+			}
+			return
+		}
+		idx = data + length + 4
+	}
+	return
+}
+
+// getJPEGDPI provides a function to get the resolution in DPI from the JFIF
+// APP0 segment of the JPEG picture, returns zero if the segment doesn't exist
+// or the density unit isn't specified.
+func getJPEGDPI(file []byte) (dpiX, dpiY float64) {
+	for idx := 2; idx+4 <= len(file) && file[idx] == 0xFF; {
+		marker, length := file[idx+1], int(binary.BigEndian.Uint16(file[idx+2:idx+4]))
+		data := idx + 4
+		if marker == 0xDA || length < 2 || idx+2+length > len(file) {
+			return
+		}
+		if marker == 0xE0 && length >= 14 && string(file[data:data+5]) == "JFIF\x00" {
+			x := float64(binary.BigEndian.Uint16(file[data+8 : data+10]))
+			y := float64(binary.BigEndian.Uint16(file[data+10 : data+12]))
+			switch file[data+7] {
+			case 1:
+				dpiX, dpiY = x, y
+			case 2:
+				dpiX, dpiY = x*2.54, y*2.54
+			}
+			return
+		}
+		idx += 2 + length
+	}
+	return
+}
+
 // getPictureCells provides a function to get all picture cell references in a
 // worksheet by given drawing part path and drawing relationships path.
 func (f *File) getPictureCells(drawingXML, drawingRelationships string) ([]string, error) {
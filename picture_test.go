@@ -1,7 +1,11 @@
 package excelize
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
@@ -140,7 +144,9 @@ func TestAddPictureErrors(t *testing.T) {
 		image.RegisterFormat(ext, "", decode, decodeConfig)
 		assert.NoError(t, f.AddPicture("Sheet1", cell, filepath.Join("test", "images", fmt.Sprintf("excel.%s", ext)), nil))
 	}
-	assert.NoError(t, f.AddPicture("Sheet1", "Q25", "excelize.svg", &GraphicOptions{ScaleX: 2.8}))
+	fallback, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "Q25", "excelize.svg", &GraphicOptions{ScaleX: 2.8, Fallback: fallback}))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPicture2.xlsx")))
 	assert.NoError(t, f.Close())
 }
@@ -288,13 +294,176 @@ func TestAddDrawingPicture(t *testing.T) {
 	// Test addDrawingPicture with illegal cell reference
 	f := NewFile()
 	opts := &GraphicOptions{PrintObject: boolPtr(true), Locked: boolPtr(false)}
-	assert.EqualError(t, f.addDrawingPicture("sheet1", "", "A", "", 0, 0, image.Config{}, opts), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.addDrawingPicture("sheet1", "", "A", "", 0, 0, 0, image.Config{}, opts), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test addDrawingPicture with invalid positioning types
-	assert.Equal(t, f.addDrawingPicture("sheet1", "", "A1", "", 0, 0, image.Config{}, &GraphicOptions{Positioning: "x"}), ErrParameterInvalid)
+	assert.Equal(t, f.addDrawingPicture("sheet1", "", "A1", "", 0, 0, 0, image.Config{}, &GraphicOptions{Positioning: "x"}), ErrParameterInvalid)
 
 	path := "xl/drawings/drawing1.xml"
 	f.Pkg.Store(path, MacintoshCyrillicCharset)
-	assert.EqualError(t, f.addDrawingPicture("sheet1", path, "A1", "", 0, 0, image.Config{}, opts), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.addDrawingPicture("sheet1", path, "A1", "", 0, 0, 0, image.Config{}, opts), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestAddPictureHyperlink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External", HyperlinkTooltip: "Excelize"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "A10", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{Hyperlink: "Sheet1!D4", HyperlinkType: "Location"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureHyperlink.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestAddPictureHyperlink.xlsx"))
+	assert.NoError(t, err)
+	for cell, expected := range map[string][]string{
+		"A1":  {"https://github.com/xuri/excelize", "External", "Excelize"},
+		"A10": {"#Sheet1!D4", "Location", ""},
+	} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, expected, []string{pics[0].Format.Hyperlink, pics[0].Format.HyperlinkType, pics[0].Format.HyperlinkTooltip})
+	}
+	pics, err := f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 2)
+	assert.Equal(t, "Excelize", pics[0].Format.HyperlinkTooltip)
+	// Test delete picture with the hyperlink relationship
+	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, "https://github.com/xuri/excelize", rel.Target)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPictureToCell(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "F", "F", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 10, 30))
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"),
+		&GraphicOptions{ToCell: "F10", Positioning: "oneCell", OffsetX: 10, OffsetY: 5}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	assert.Equal(t, "oneCell", anchor.EditAs)
+	assert.Equal(t, &xlsxFrom{Col: 1, ColOff: 10 * EMU, Row: 1, RowOff: 5 * EMU}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 5, ColOff: 146 * EMU, Row: 9, RowOff: 36 * EMU}, anchor.To)
+	// Test get pictures with the anchor mode
+	pics, err := f.GetPictures("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, "oneCell", pics[0].Format.Positioning)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureToCell.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddPictureToCell.xlsx"))
+	assert.NoError(t, err)
+	pics, err = f.GetPictures("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, "oneCell", pics[0].Format.Positioning)
+	// Test add picture with invalid end cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")),
+		f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), &GraphicOptions{ToCell: "A"}))
+	// Test add picture with the end cell before the start cell
+	assert.Equal(t, ErrParameterInvalid,
+		f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), &GraphicOptions{ToCell: "A10"}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPictureAutoFitCell(t *testing.T) {
+	f := NewFile()
+	pngFile, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	// Insert the physical pixel dimensions chunk with 192 DPI after the header
+	pHYs := make([]byte, 21)
+	binary.BigEndian.PutUint32(pHYs, 9)
+	copy(pHYs[4:], "pHYs")
+	binary.BigEndian.PutUint32(pHYs[8:], 7559)
+	binary.BigEndian.PutUint32(pHYs[12:], 7559)
+	pHYs[16] = 1
+	binary.BigEndian.PutUint32(pHYs[17:], crc32.ChecksumIEEE(pHYs[4:17]))
+	pngFile = append(append(append([]byte{}, pngFile[:33]...), pHYs...), pngFile[33:]...)
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "B2", &Picture{
+		Extension: ".png", File: pngFile, Format: &GraphicOptions{AutoFitCell: true, Positioning: "oneCell"},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	assert.Equal(t, 100, f.getColWidth("Sheet1", 2))
+	assert.Equal(t, 64, f.getRowHeight("Sheet1", 2))
+	assert.Equal(t, &xlsxTo{Col: 2, Row: 2}, anchor.To)
+
+	// Test add picture with auto fit cell by the JPEG picture with offset
+	jpgFile, err := os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	img, _, err := image.DecodeConfig(bytes.NewReader(jpgFile))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "D4", &Picture{
+		Extension: ".jpg", File: jpgFile, Format: &GraphicOptions{AutoFitCell: true, OffsetX: 10, OffsetY: 5},
+	}))
+	anchor = drawing.(*xlsxWsDr).TwoCellAnchor[1]
+	assert.Equal(t, img.Width+10, f.getColWidth("Sheet1", 4))
+	assert.Equal(t, img.Height+5, f.getRowHeight("Sheet1", 4))
+	assert.Equal(t, &xlsxFrom{Col: 3, ColOff: 10 * EMU, Row: 3, RowOff: 5 * EMU}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 4, Row: 4}, anchor.To)
+
+	// Test add picture with auto fit cell exceeds the maximum cell size
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "F6", &Picture{
+		Extension: ".png", File: pngFile, Format: &GraphicOptions{AutoFitCell: true, ScaleX: 100, ScaleY: 100},
+	}))
+	width, err := f.GetColWidth("Sheet1", "F")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(MaxColumnWidth), width)
+	height, err := f.GetRowHeight("Sheet1", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(MaxRowHeight), height)
+	anchor = drawing.(*xlsxWsDr).TwoCellAnchor[2]
+	assert.Equal(t, &xlsxTo{Col: 5, ColOff: 753 * EMU, Row: 6}, anchor.To)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureAutoFitCell.xlsx")))
+
+	// Test auto fit cell with invalid column number, worksheet name and row number
+	opts := parseGraphicOptions(nil)
+	_, _, err = f.drawingAutoFitCell("Sheet1", 0, 1, 10, 10, opts)
+	assert.Equal(t, ErrColumnNumber, err)
+	_, _, err = f.drawingAutoFitCell("SheetN", 1, 1, 10, 10, opts)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, _, err = f.drawingAutoFitCell("Sheet1", 1, 0, 10, 10, opts)
+	assert.Equal(t, newInvalidRowNumberError(0), err)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetImageDPI(t *testing.T) {
+	dpiX, dpiY := getImageDPI([]byte{}, ".gif")
+	assert.Equal(t, []float64{96, 96}, []float64{dpiX, dpiY})
+	// Test get DPI of the PNG picture without the physical pixel dimensions
+	pngFile, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	dpiX, dpiY = getImageDPI(pngFile, ".png")
+	assert.Equal(t, []float64{96, 96}, []float64{dpiX, dpiY})
+	// Test get DPI of the PNG picture with unknown unit and truncated chunk
+	pHYs := append(append([]byte{}, pngFile[:33]...), 0, 0, 0, 9, 'p', 'H', 'Y', 's', 0, 0, 0, 1, 0, 0, 0, 1, 0)
+	dpiX, dpiY = getPNGDPI(pHYs)
+	assert.Equal(t, []float64{0, 0}, []float64{dpiX, dpiY})
+	dpiX, dpiY = getPNGDPI(pHYs[:40])
+	assert.Equal(t, []float64{0, 0}, []float64{dpiX, dpiY})
+	// Test get DPI of the JPEG picture with the density unit in dots per inch and centimeter
+	jpgFile, err := os.ReadFile(filepath.Join("test", "images", "excel.jpg"))
+	assert.NoError(t, err)
+	dpiX, dpiY = getImageDPI(jpgFile, ".jpg")
+	assert.Equal(t, []float64{96, 96}, []float64{dpiX, dpiY})
+	jpgFile[13], jpgFile[15], jpgFile[17] = 1, 72, 144
+	dpiX, dpiY = getImageDPI(jpgFile, ".jpeg")
+	assert.Equal(t, []float64{72, 144}, []float64{dpiX, dpiY})
+	jpgFile[13] = 2
+	dpiX, dpiY = getJPEGDPI(jpgFile)
+	assert.Equal(t, []float64{72 * 2.54, 144 * 2.54}, []float64{dpiX, dpiY})
+	// Test get DPI of the JPEG picture without the JFIF segment
+	dpiX, dpiY = getJPEGDPI([]byte{0xFF, 0xD8, 0xFF, 0xDA, 0x00, 0x02})
+	assert.Equal(t, []float64{0, 0}, []float64{dpiX, dpiY})
+	dpiX, dpiY = getJPEGDPI([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x02, 0xFF, 0xE0, 0x00, 0x20})
+	assert.Equal(t, []float64{0, 0}, []float64{dpiX, dpiY})
 }
 
 func TestAddPictureFromBytes(t *testing.T) {
@@ -317,6 +486,88 @@ func TestAddPictureFromBytes(t *testing.T) {
 	assert.EqualError(t, f.AddPictureFromBytes("Sheet:1", fmt.Sprint("A", 1), &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "logo"}}), ErrSheetNameInvalid.Error())
 }
 
+type testSVGRenderer struct {
+	raster []byte
+	err    error
+}
+
+func (r *testSVGRenderer) Render(svg []byte) ([]byte, error) {
+	return r.raster, r.err
+}
+
+func TestAddSVGPicture(t *testing.T) {
+	svg, err := os.ReadFile("excelize.svg")
+	assert.NoError(t, err)
+	fallback, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+
+	f := NewFile()
+	// Test add SVG picture without the fallback picture and renderer
+	assert.Equal(t, ErrSVGFallback, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".svg", File: svg}))
+	// Test add SVG picture with the fallback picture
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".svg", File: svg, Format: &GraphicOptions{AltText: "logo", Fallback: fallback}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchor := drawing.(*xlsxWsDr).TwoCellAnchor[0]
+	assert.Equal(t, "rId2", anchor.Pic.BlipFill.Blip.Embed)
+	assert.Equal(t, "rId1", anchor.Pic.BlipFill.Blip.ExtList.Ext[0].SVGBlip.Embed)
+	// The size of the picture should be the size of the view box of the SVG
+	assert.Equal(t, &xlsxTo{Col: 90, ColOff: 31 * EMU, Row: 131, RowOff: 12 * EMU}, anchor.To)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSVGPicture.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddSVGPicture.xlsx"))
+	assert.NoError(t, err)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, contentTypes.Defaults, xlsxDefault{Extension: "svg", ContentType: "image/svg+xml"})
+	assert.Contains(t, contentTypes.Defaults, xlsxDefault{Extension: "png", ContentType: "image/png"})
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".svg", pics[0].Extension)
+	assert.Equal(t, svg, pics[0].File)
+	assert.Equal(t, fallback, pics[0].Format.Fallback)
+	assert.Equal(t, "logo", pics[0].Format.AltText)
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".svg", pics[0].Extension)
+	assert.Equal(t, fallback, pics[0].Format.Fallback)
+	assert.NoError(t, f.Close())
+
+	// Test add SVG picture with the renderer
+	renderer := &testSVGRenderer{raster: fallback}
+	f = NewFile(Options{SVGRenderer: renderer})
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", "excelize.svg", nil))
+	pics, err = f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".svg", pics[0].Extension)
+	assert.Equal(t, fallback, pics[0].Format.Fallback)
+	// Test add SVG picture with the renderer error
+	renderer.err = ErrParameterInvalid
+	assert.Equal(t, ErrParameterInvalid, f.AddPicture("Sheet1", "A1", "excelize.svg", nil))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetSVGSize(t *testing.T) {
+	for svg, expected := range map[string][]int{
+		`<svg width="96px" height="1in"/>`:                      {96, 96},
+		`<svg width="72pt" height="2.54cm"/>`:                   {96, 96},
+		`<svg width="6pc" height="25.4mm"/>`:                    {96, 96},
+		`<svg width="100%" height="100%" viewBox="0 0 40,30"/>`: {40, 30},
+	} {
+		width, height, ok := getSVGSize([]byte(svg))
+		assert.True(t, ok)
+		assert.Equal(t, expected, []int{width, height})
+	}
+	for _, svg := range []string{"", "<g/>", `<svg width="100%"/>`} {
+		_, _, ok := getSVGSize([]byte(svg))
+		assert.False(t, ok)
+	}
+}
+
 func TestDeletePicture(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -376,6 +627,82 @@ func TestDeletePicture(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestDeletePictureByNameAndIndex(t *testing.T) {
+	img, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	svg, err := os.ReadFile("excelize.svg")
+	assert.NoError(t, err)
+	f := NewFile()
+	for _, cell := range []string{"A1", "A1", "D1"} {
+		assert.NoError(t, f.AddPictureFromBytes("Sheet1", cell, &Picture{Extension: ".png", File: img}))
+	}
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "G1", &Picture{Extension: ".svg", File: svg, Format: &GraphicOptions{Fallback: img}}))
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{File: img, Extension: ".png", Width: "50pt", Height: "32pt"}))
+	// Test delete picture by name, the media should be preserved
+	assert.NoError(t, f.DeletePictureByName("Sheet1", "Picture 3"))
+	pics, err := f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 3)
+	for _, pic := range pics {
+		assert.NotEqual(t, "Picture 3", pic.Anchor.Name)
+	}
+	// Test delete picture by index
+	assert.NoError(t, f.DeletePictureByIndex("Sheet1", 2))
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 2)
+	_, ok := f.Pkg.Load("xl/media/image2.svg")
+	assert.False(t, ok)
+	// Test delete the pictures in the cell which contains multiple pictures
+	assert.NoError(t, f.DeletePicture("Sheet1", "A1"))
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, "D1", pics[0].Anchor.From)
+	assert.NoError(t, f.DeletePictureByName("Sheet1", pics[0].Anchor.Name))
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, pics)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Empty(t, rels.Relationships)
+	// Test the media still referenced by the header and footer picture
+	_, ok = f.Pkg.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	// Test delete picture by not exists name and index
+	assert.Equal(t, newNoExistPictureError("Sheet1", "Picture 2"), f.DeletePictureByName("Sheet1", "Picture 2"))
+	assert.Equal(t, newNoExistPictureError("Sheet1", "1"), f.DeletePictureByIndex("Sheet1", 1))
+	assert.EqualError(t, f.DeletePictureByIndex("Sheet1", 0), "picture 0 does not exist on sheet Sheet1")
+	// Test delete picture by invalid index
+	assert.Equal(t, ErrParameterInvalid, f.DeletePictureByIndex("Sheet1", -1))
+	// Test delete picture on not exists worksheet
+	assert.EqualError(t, f.DeletePictureByName("SheetN", "Picture 2"), "sheet SheetN does not exist")
+	// Test delete picture on the worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, newNoExistPictureError("Sheet2", "Picture 1"), f.DeletePictureByName("Sheet2", "Picture 1"))
+	assert.Equal(t, newNoExistPictureError("Sheet2", "0"), f.DeletePictureByIndex("Sheet2", 0))
+	assert.NoError(t, f.DeletePicture("Sheet2", "A1"))
+	assert.NoError(t, f.Close())
+
+	// Test the saved workbook doesn't contain the unreferenced picture
+	f = NewFile()
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: img}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".png", File: img}))
+	assert.NoError(t, f.DeletePictureByIndex("Sheet1", 0))
+	_, ok = f.Pkg.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	assert.NoError(t, f.DeletePictureByIndex("Sheet1", 0))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeletePictureByIndex.xlsx")))
+	assert.NoError(t, f.Close())
+	zr, err := zip.OpenReader(filepath.Join("test", "TestDeletePictureByIndex.xlsx"))
+	assert.NoError(t, err)
+	for _, file := range zr.File {
+		assert.False(t, strings.HasPrefix(file.Name, "xl/media/"), file.Name)
+	}
+	assert.NoError(t, zr.Close())
+}
+
 func TestDrawingResize(t *testing.T) {
 	f := NewFile()
 	// Test calculate drawing resize on not exists worksheet
@@ -451,6 +778,83 @@ func TestGetPictureCells(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetSheetPictures(t *testing.T) {
+	f := NewFile()
+	// Test get pictures on a worksheet which not contains any pictures
+	pics, err := f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, pics)
+	img, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPicture("Sheet1", "B2", filepath.Join("test", "images", "excel.png"), &GraphicOptions{AltText: "Excel", OffsetX: 10, OffsetY: 5}))
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "H10", &Picture{Extension: ".png", File: img, Format: &GraphicOptions{Positioning: "oneCell"}}))
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{File: img, Extension: ".png", IsFooter: true, Width: "50pt", Height: "32pt"}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=_xlfn.DISPIMG(\"ID_********************************\",1)"))
+	check := func(pics []Picture) {
+		assert.Len(t, pics, 2)
+		assert.Equal(t, &PictureAnchor{Type: "twoCell", Name: "Picture 2", From: "B2", FromOffsetX: 10, FromOffsetY: 5, To: "E9", ToOffsetX: 18, ToOffsetY: 7}, pics[0].Anchor)
+		assert.Equal(t, "Excel", pics[0].Format.AltText)
+		assert.Equal(t, &PictureAnchor{Type: "twoCell", Name: "Picture 3", From: "H10", To: "K17", ToOffsetX: 8, ToOffsetY: 2}, pics[1].Anchor)
+		assert.Equal(t, "oneCell", pics[1].Format.Positioning)
+		for _, pic := range pics {
+			assert.Equal(t, ".png", pic.Extension)
+			assert.Equal(t, img, pic.File)
+			assert.Equal(t, PictureInsertTypePlaceOverCells, pic.InsertType)
+		}
+	}
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	check(pics)
+	// Test get pictures with the pictures in the header and footer and cells
+	pics, err = f.GetSheetPictures("Sheet1", SheetPicturesOptions{HeaderFooter: true, InCell: true})
+	assert.NoError(t, err)
+	assert.Len(t, pics, 3)
+	assert.Equal(t, &PictureAnchor{Type: "headerFooter", Name: "LF"}, pics[2].Anchor)
+	assert.Equal(t, img, pics[2].File)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSheetPictures.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test get pictures from the saved workbook
+	f, err = OpenFile(filepath.Join("test", "TestGetSheetPictures.xlsx"))
+	assert.NoError(t, err)
+	pics, err = f.GetSheetPictures("Sheet1", SheetPicturesOptions{HeaderFooter: true})
+	assert.NoError(t, err)
+	check(pics[:2])
+	assert.Equal(t, &PictureAnchor{Type: "headerFooter", Name: "LF"}, pics[2].Anchor)
+	// Test get the absolute anchored picture
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", []byte(`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><xdr:absoluteAnchor><xdr:pos x="95250" y="190500"/><xdr:ext cx="952500" cy="952500"/><xdr:pic><xdr:nvPicPr><xdr:cNvPr id="2" name="Logo"/><xdr:cNvPicPr/></xdr:nvPicPr><xdr:blipFill><a:blip r:embed="rId1"/></xdr:blipFill><xdr:spPr/></xdr:pic><xdr:clientData/></xdr:absoluteAnchor><xdr:oneCellAnchor><xdr:from><xdr:col>1</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>1</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from><xdr:ext cx="952500" cy="952500"/><xdr:sp><xdr:nvSpPr><xdr:cNvPr id="3" name="Shape"/><xdr:cNvSpPr/></xdr:nvSpPr></xdr:sp><xdr:clientData/></xdr:oneCellAnchor></xdr:wsDr>`))
+	pics, err = f.GetSheetPictures("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, &PictureAnchor{Type: "absolute", Name: "Logo", FromOffsetX: 10, FromOffsetY: 20}, pics[0].Anchor)
+	assert.NoError(t, f.Close())
+
+	// Test get pictures on not exists worksheet
+	f = NewFile()
+	_, err = f.GetSheetPictures("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get pictures with unsupported charset drawing
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetSheetPictures("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test get pictures with invalid cell picture formula
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=_xlfn.DISPIMG()"))
+	_, err = f.GetSheetPictures("Sheet1", SheetPicturesOptions{InCell: true})
+	assert.EqualError(t, err, "DISPIMG requires 2 numeric arguments")
+	// Test get pictures with unsupported charset header and footer drawing
+	assert.NoError(t, f.AddHeaderFooterImage("Sheet1", &HeaderFooterImageOptions{File: img, Extension: ".png", Width: "50pt", Height: "32pt"}))
+	delete(f.VMLDrawing, "xl/drawings/vmlDrawing1.vml")
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.GetSheetPictures("Sheet1", SheetPicturesOptions{HeaderFooter: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestExtractDecodeCellAnchor(t *testing.T) {
 	f := NewFile()
 	cond := func(a *decodeFrom) bool { return true }
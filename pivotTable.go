@@ -27,7 +27,8 @@ import (
 
 // PivotTableOptions directly maps the format settings of the pivot table.
 //
-// PivotTableStyleName: The built-in pivot table style names
+// PivotTableStyleName: The built-in pivot table style names, or the name of
+// the custom PivotTable style created by the NewTableStyle function
 //
 //	PivotStyleLight1 - PivotStyleLight28
 //	PivotStyleMedium1 - PivotStyleMedium28
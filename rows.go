@@ -245,8 +245,12 @@ func (rows *Rows) rowXMLHandler(rowIterator *rowXMLIterator, xmlElement *xml.Sta
 }
 
 // Rows returns a rows iterator, used for streaming reading data for a
-// worksheet with a large data. This function is concurrency safe. For
-// example:
+// worksheet with a large data. This function is concurrency safe. The
+// worksheet XML which exceeds the UnzipXMLSizeLimit will be extracted to the
+// system temporary directory on open the spreadsheet, and the worksheet
+// written by the stream writer will be read from the in-memory chunks or the
+// temporary file it spilled to, the rows will be parsed lazily without
+// holding the whole worksheet in memory. For example:
 //
 //	rows, err := f.Rows("Sheet1")
 //	if err != nil {
@@ -339,13 +343,22 @@ func (f *File) getFromStringItem(index int) string {
 }
 
 // xmlDecoder creates XML decoder by given path in the zip from memory data
-// or system temporary file.
+// or system temporary file. The worksheet spilled to the temporary file by the
+// stream writer or extracted to the system temporary directory will be
+// decoded lazily from the file without reading the whole content into memory.
 func (f *File) xmlDecoder(name string) (bool, *xml.Decoder, *os.File, error) {
 	var (
 		content  []byte
 		err      error
 		tempFile *os.File
 	)
+	if stream, ok := f.streams[name]; ok {
+		var r io.Reader
+		if r, err = stream.rawData.Reader(); err != nil {
+			return false, nil, tempFile, err
+		}
+		return false, f.xmlNewDecoder(r), tempFile, err
+	}
 	if content = f.readXML(name); len(content) > 0 {
 		return false, f.xmlNewDecoder(bytes.NewReader(content)), tempFile, err
 	}
@@ -403,6 +416,9 @@ func (f *File) getRowHeight(sheet string, row int) int {
 		return int(convertRowHeightToPixels(ws.SheetFormatPr.DefaultRowHeight))
 	}
 	// Optimization for when the row heights haven't changed.
+	if _, rowHeight := f.getDefaultFontMetrics(); rowHeight != defaultRowHeight {
+		return int(convertRowHeightToPixels(rowHeight))
+	}
 	return int(defaultRowHeightPixels)
 }
 
@@ -419,7 +435,7 @@ func (f *File) GetRowHeight(sheet string, row int) (float64, error) {
 	if err != nil {
 		return ht, err
 	}
-	if ws.SheetFormatPr != nil && ws.SheetFormatPr.CustomHeight {
+	if ws.SheetFormatPr != nil && (ws.SheetFormatPr.CustomHeight || ws.SheetFormatPr.DefaultRowHeight > 0) {
 		ht = ws.SheetFormatPr.DefaultRowHeight
 	}
 	if row > len(ws.SheetData.Row) {
@@ -596,11 +612,18 @@ func (f *File) RemoveRow(sheet string, row int) error {
 //
 //	err := f.InsertRows("Sheet1", 3, 2)
 //
+// Create two rows before row 3 in Sheet1, which inherit the formatting of
+// the row above:
+//
+//	err := f.InsertRows("Sheet1", 3, 2, excelize.InsertRowsOptions{
+//	    InheritStyle: excelize.InheritFromAbove,
+//	})
+//
 // Use this method with caution, which will affect changes in references such
 // as formulas, charts, and so on. If there is any referenced value of the
 // worksheet, it will cause a file error when you open it. The excelize only
 // partially updates these references currently.
-func (f *File) InsertRows(sheet string, row, n int) error {
+func (f *File) InsertRows(sheet string, row, n int, opts ...InsertRowsOptions) error {
 	if row < 1 {
 		return newInvalidRowNumberError(row)
 	}
@@ -610,7 +633,61 @@ func (f *File) InsertRows(sheet string, row, n int) error {
 	if n < 1 {
 		return ErrParameterInvalid
 	}
-	return f.adjustHelper(sheet, rows, row, n)
+	if err := f.adjustHelper(sheet, rows, row, n); err != nil {
+		return err
+	}
+	var options InsertRowsOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	return f.inheritRowsStyle(sheet, row, n, options.InheritStyle)
+}
+
+// inheritRowsStyle provides a function to copy the row style, height,
+// outline level and the cell styles of the neighbor row to the inserted rows
+// by given formatting inheritance type.
+func (f *File) inheritRowsStyle(sheet string, row, n int, inherit InheritStyleType) error {
+	src := row - 1
+	if inherit == InheritFromBelow {
+		src = row + n
+	}
+	if inherit == InheritNone || src < 1 {
+		return nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if src > len(ws.SheetData.Row) {
+		return nil
+	}
+	srcRow := ws.SheetData.Row[src-1]
+	if srcRow.S == 0 && !srcRow.CustomHeight && srcRow.OutlineLevel == 0 && srcRow.C == nil {
+		return nil
+	}
+	ws.prepareSheetXML(0, row+n-1)
+	for r := row; r < row+n; r++ {
+		rowData := &ws.SheetData.Row[r-1]
+		rowData.S, rowData.CustomFormat = srcRow.S, srcRow.CustomFormat
+		if rowData.CustomHeight = srcRow.CustomHeight; srcRow.Ht != nil {
+			rowData.Ht = float64Ptr(*srcRow.Ht)
+		}
+		rowData.OutlineLevel = srcRow.OutlineLevel
+		for _, c := range srcRow.C {
+			if c.S == 0 {
+				continue
+			}
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			fillColumns(rowData, col, r)
+			rowData.C[col-1].S = c.S
+		}
+	}
+	return nil
 }
 
 // DuplicateRow inserts a copy of specified row (by its Excel row number) below
@@ -737,6 +814,7 @@ func (f *File) duplicateConditionalFormat(ws *xlsxWorksheet, sheet string, row,
 		}
 	}
 	ws.ConditionalFormatting = append(ws.ConditionalFormatting, cfs...)
+	ws.setCfRulePriority(nil, 0)
 	return nil
 }
 
@@ -949,3 +1027,31 @@ func convertRowHeightToPixels(height float64) float64 {
 	}
 	return math.Ceil(4.0 / 3.4 * height)
 }
+
+// convertPixelsToRowHeight provides a function to convert the height of a
+// cell from pixels to user's units, it's the inverse of the
+// convertRowHeightToPixels function.
+func convertPixelsToRowHeight(pixels float64) float64 {
+	if pixels = math.Ceil(pixels); pixels <= 0 {
+		return 0
+	}
+	return math.Round((pixels-0.25)*3.4/4*100) / 100
+}
+
+// RowHeightToPixels provides a function to convert the row height in points
+// to pixels. For example, get the pixels of the row height 30 points:
+//
+//	pixels := excelize.RowHeightToPixels(30)
+func RowHeightToPixels(height float64) float64 {
+	return convertRowHeightToPixels(height)
+}
+
+// PixelsToRowHeight provides a function to convert the pixels to the row
+// height in points. The returned height could be used as the row height of
+// the SetRowHeight function to get the given pixels. For example, set the
+// height of the first row in Sheet1 to 100 pixels:
+//
+//	err := f.SetRowHeight("Sheet1", 1, excelize.PixelsToRowHeight(100))
+func PixelsToRowHeight(pixels float64) float64 {
+	return convertPixelsToRowHeight(pixels)
+}
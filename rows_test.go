@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -73,12 +74,54 @@ func TestRows(t *testing.T) {
 	assert.NoError(t, f.Close())
 
 	// Test rows iterator with unsupported charset shared strings table
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
 	f.SharedStrings = nil
 	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
 	rows, err = f.Rows(sheet2)
 	assert.NoError(t, err)
 	_, err = rows.Columns()
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, rows.Close())
+	assert.NoError(t, f.Close())
+}
+
+func TestRowsReadTempWorksheet(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	// Test the worksheets extracted to the system temporary directory are
+	// read lazily without loading into memory
+	result, err := f.SearchSheet("Sheet1", "Total:")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A19"}, result)
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, rows.Next())
+	assert.NoError(t, rows.Close())
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+	_, ok := f.Pkg.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	value, err := f.GetCellValue("Sheet1", "A19")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total:", value)
+	assert.NoError(t, f.Close())
+
+	// Test copy the worksheet from the removed temporary file
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	path, ok := f.tempFiles.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, os.Remove(path.(string)))
+	_, err = f.SearchSheet("Sheet1", "Total:")
+	assert.Error(t, err)
+	assert.Error(t, f.Write(&buf))
+	f.tempFiles.Delete("xl/worksheets/sheet1.xml")
+	assert.NoError(t, f.Close())
 }
 
 func TestRowsIterator(t *testing.T) {
@@ -211,7 +254,12 @@ func TestRowHeight(t *testing.T) {
 		t.FailNow()
 	}
 
-	assert.Equal(t, 0.0, convertColWidthToPixels(0))
+	assert.Equal(t, 0.0, convertColWidthToPixels(0, defaultMaxDigitWidth))
+	assert.Equal(t, 36.0, RowHeightToPixels(30))
+	for _, pixels := range []float64{1, 20, 64, 481} {
+		assert.Equal(t, pixels, RowHeightToPixels(PixelsToRowHeight(pixels)))
+	}
+	assert.Equal(t, 0.0, PixelsToRowHeight(0))
 }
 
 func TestColumns(t *testing.T) {
@@ -425,6 +473,64 @@ func TestInsertRowsInEmptyFile(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestInsertRowInEmptyFile.xlsx")))
 }
 
+func TestInsertRowsInheritStyle(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	cellStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowStyle("Sheet1", 2, 2, style))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 2, 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", "B2"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", cellStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "A3"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A2", "A3"))
+	// Test insert rows with default formatting inheritance
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 1))
+	styleID, err := f.GetCellStyle("Sheet1", "C3")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	// Test insert rows inherit formatting from the row above
+	assert.NoError(t, f.InsertRows("Sheet1", 3, 2, InsertRowsOptions{InheritStyle: InheritFromAbove}))
+	for _, row := range []int{3, 4} {
+		height, err := f.GetRowHeight("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, 30.0, height)
+		level, err := f.GetRowOutlineLevel("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(1), level)
+		for cell, expected := range map[string]int{"A": style, "B": style, "C": cellStyle, "D": style} {
+			styleID, err := f.GetCellStyle("Sheet1", fmt.Sprint(cell, row))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, styleID)
+		}
+		val, err := f.GetCellValue("Sheet1", fmt.Sprint("B", row))
+		assert.NoError(t, err)
+		assert.Empty(t, val)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A2:A6", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	// Test insert rows inherit formatting from the row below
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 1, InsertRowsOptions{InheritStyle: InheritFromBelow}))
+	styleID, err = f.GetCellStyle("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, cellStyle, styleID)
+	// Test insert rows inherit formatting from the nonexistent row
+	assert.NoError(t, f.InsertRows("Sheet1", 1, 1, InsertRowsOptions{InheritStyle: InheritFromAbove}))
+	assert.NoError(t, f.InsertRows("Sheet1", 100, 1, InsertRowsOptions{InheritStyle: InheritFromBelow}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row, 8)
+	// Test insert rows inherit formatting with invalid cell reference
+	ws.SheetData.Row[7].C = []xlsxC{{R: "A", S: style}}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.inheritRowsStyle("Sheet1", 9, 1, InheritFromAbove))
+	// Test insert rows inherit formatting with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.inheritRowsStyle("Sheet:1", 2, 1, InheritFromAbove))
+}
+
 func prepareTestBook2() (*File, error) {
 	f := NewFile()
 	for cell, val := range map[string]string{
@@ -915,6 +1021,9 @@ func TestDuplicateRow(t *testing.T) {
 	cfs, err := f.GetConditionalFormats("Sheet1")
 	assert.NoError(t, err)
 	assert.Len(t, cfs, 2)
+	expected[0].Priority = 2
+	expected[0].FormatStyle, err = f.GetConditionalStyle(format)
+	assert.NoError(t, err)
 	assert.Equal(t, expected, cfs["A10:A10"])
 
 	dvs, err := f.GetDataValidations("Sheet1")
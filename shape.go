@@ -12,6 +12,9 @@
 package excelize
 
 import (
+	"bytes"
+	"io"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -46,7 +49,39 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 	if opts.Line.Width == nil {
 		opts.Line.Width = float64Ptr(defaultShapeLineWidth)
 	}
-	return opts, nil
+	if opts.Line.Dash != "" && inStrSlice(supportedDrawingLineDash, opts.Line.Dash, true) == -1 {
+		return nil, ErrParameterInvalid
+	}
+	return opts, checkShapeText(opts)
+}
+
+// checkShapeText provides a function to check the paragraphs and the text
+// body settings of the shape.
+func checkShapeText(opts *Shape) error {
+	for _, p := range opts.Paragraphs {
+		if _, ok := supportedDrawingTextAlignment[p.Alignment]; p.Alignment != "" && !ok {
+			return ErrParameterInvalid
+		}
+		if p.Bullet != "" && inStrSlice(supportedDrawingTextBullet, p.Bullet, true) == -1 {
+			return ErrParameterInvalid
+		}
+		if p.IndentLevel < 0 || p.IndentLevel > 8 || p.SpaceBefore < 0 || p.SpaceAfter < 0 || p.LineSpacing < 0 {
+			return ErrParameterInvalid
+		}
+	}
+	body := opts.TextBody
+	if _, ok := supportedDrawingTextAnchor[body.VerticalAlign]; body.VerticalAlign != "" && !ok {
+		return ErrParameterInvalid
+	}
+	if body.Autofit != "" && inStrSlice(supportedDrawingTextAutofit, body.Autofit, true) == -1 {
+		return ErrParameterInvalid
+	}
+	for _, margin := range []*float64{body.LeftMargin, body.TopMargin, body.RightMargin, body.BottomMargin} {
+		if margin != nil && *margin < 0 {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
 }
 
 // AddShape provides the method to add shape in a sheet by given worksheet
@@ -78,6 +113,65 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	    },
 //	)
 //
+// Set the "Hyperlink", "HyperlinkType" and "HyperlinkTooltip" of the "Format"
+// to add the hyperlink to the shape, the "HyperlinkType" defines two types of
+// hyperlink "External" for website or "Location" for moving to one of the
+// cells in this workbook, for example:
+//
+//	err := f.AddShape("Sheet1", &excelize.Shape{
+//	    Cell: "G6",
+//	    Type: "rect",
+//	    Format: excelize.GraphicOptions{
+//	        Hyperlink:        "Sheet2!A1",
+//	        HyperlinkType:    "Location",
+//	        HyperlinkTooltip: "Go to Sheet2",
+//	    },
+//	})
+//
+// Set the "Paragraphs" to add the paragraphs which contain multiple rich text
+// runs with the paragraph properties, the "Paragraph" will be ignored in this
+// case. The optional "Alignment" specifies the horizontal alignment of the
+// paragraph, the possible values are "left", "center", "right", "justify" and
+// "distributed". The optional "Bullet" specifies the bullet type of the
+// paragraph, the possible values are "none", "char" (the character bullet,
+// defaults by "•", which specified by "BulletChar") and "autoNum" (the auto
+// numbered bullet, defaults by "arabicPeriod", which specified by
+// "BulletNumberType"). The "IndentLevel" specifies the indent level of the
+// paragraph from 0 to 8. The "SpaceBefore" and "SpaceAfter" specify the space
+// before and after the paragraph in points, and the "LineSpacing" specifies
+// the line spacing in multiples of the single line spacing.
+//
+// Set the "TextBody" to specify the text body settings of the shape. The
+// optional "Autofit" specifies the text autofit type, the possible values are
+// "none", "shrink" (shrink text on overflow) and "resize" (resize shape to fit
+// text). The optional "VerticalAlign" specifies the vertical alignment of the
+// text, the possible values are "top", "center", "bottom", "justify" and
+// "distributed". The "WordWrap" specifies if wrap text in the shape, and the
+// "LeftMargin", "TopMargin", "RightMargin" and "BottomMargin" specify the
+// margins of the text body in points. For example, add a callout box with a
+// title and a bulleted list:
+//
+//	err := f.AddShape("Sheet1", &excelize.Shape{
+//	    Cell: "G6",
+//	    Type: "wedgeRectCallout",
+//	    Paragraphs: []excelize.ShapeParagraph{
+//	        {
+//	            Runs:       []excelize.RichTextRun{{Text: "Summary", Font: &excelize.Font{Bold: true}}},
+//	            Alignment:  "center",
+//	            SpaceAfter: 6,
+//	        },
+//	        {Runs: []excelize.RichTextRun{{Text: "First item"}}, Bullet: "char"},
+//	        {Runs: []excelize.RichTextRun{{Text: "Second item"}}, Bullet: "char"},
+//	    },
+//	    TextBody: excelize.ShapeTextBody{
+//	        Autofit:       "shrink",
+//	        VerticalAlign: "center",
+//	        WordWrap:      true,
+//	    },
+//	    Width:  180,
+//	    Height: 120,
+//	})
+//
 // The following shows the type of shape supported by excelize:
 //
 //	accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -322,6 +416,178 @@ func (f *File) AddShape(sheet string, opts *Shape) error {
 	return f.addContentTypePart(drawingID, "drawings")
 }
 
+// parseConnectorOptions provides a function to parse the settings of the
+// connector with default value.
+func parseConnectorOptions(opts *ConnectorOptions) (*ConnectorOptions, error) {
+	if opts == nil {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Type == "" {
+		opts.Type = "straight"
+	}
+	if _, ok := supportedConnectorTypes[opts.Type]; !ok {
+		return nil, ErrParameterInvalid
+	}
+	if opts.FromOffsetX < 0 || opts.FromOffsetY < 0 || opts.ToOffsetX < 0 || opts.ToOffsetY < 0 {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Line.Dash != "" && inStrSlice(supportedDrawingLineDash, opts.Line.Dash, true) == -1 {
+		return nil, ErrParameterInvalid
+	}
+	for _, arrow := range []ConnectorArrow{opts.BeginArrow, opts.EndArrow} {
+		if arrow.Type != "" && inStrSlice(supportedConnectorArrowTypes, arrow.Type, true) == -1 {
+			return nil, ErrParameterInvalid
+		}
+		for _, size := range []string{arrow.Width, arrow.Length} {
+			if size != "" && inStrSlice(supportedConnectorArrowSizes, size, true) == -1 {
+				return nil, ErrParameterInvalid
+			}
+		}
+	}
+	return opts, nil
+}
+
+// AddConnector provides the method to add a connector or line in a sheet by
+// given worksheet name and connector settings. The connector will be drawn
+// from the start point to the end point, which are specified by the cell
+// reference and the offsets in pixels from the top-left corner of the cell,
+// the connector will be moved and sized with the cells. For example, add a
+// dashed elbow connector with an arrowhead at the end from cell B3 to cell E6
+// in Sheet1:
+//
+//	lineWidth := 1.5
+//	err := f.AddConnector("Sheet1", &excelize.ConnectorOptions{
+//	    From:        "B3",
+//	    FromOffsetX: 32,
+//	    To:          "E6",
+//	    ToOffsetY:   10,
+//	    Type:        "bent",
+//	    Line:        excelize.ShapeLine{Color: "4286F4", Width: &lineWidth, Dash: "dash"},
+//	    EndArrow:    excelize.ConnectorArrow{Type: "triangle", Width: "med", Length: "med"},
+//	})
+//
+// The following shows the type of connector supported by excelize:
+//
+//	straight (Straight Connector)
+//	bent (Elbow Connector)
+//	curved (Curved Connector)
+//
+// The following shows the type of line dash supported by excelize:
+//
+//	solid
+//	dot
+//	dash
+//	lgDash
+//	dashDot
+//	lgDashDot
+//	lgDashDotDot
+//	sysDash
+//	sysDot
+//	sysDashDot
+//	sysDashDotDot
+//
+// The following shows the type of arrowhead supported by excelize, and the
+// width and length of the arrowhead can be "sm", "med" or "lg":
+//
+//	none
+//	triangle
+//	stealth
+//	diamond
+//	oval
+//	arrow
+func (f *File) AddConnector(sheet string, opts *ConnectorOptions) error {
+	options, err := parseConnectorOptions(opts)
+	if err != nil {
+		return err
+	}
+	fromCol, fromRow, err := CellNameToCoordinates(options.From)
+	if err != nil {
+		return err
+	}
+	toCol, toRow, err := CellNameToCoordinates(options.To)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if err = f.addDrawingConnector(sheet, drawingXML, []int{fromCol, fromRow, toCol, toRow}, options); err != nil {
+		return err
+	}
+	return f.addContentTypePart(drawingID, "drawings")
+}
+
+// addDrawingConnector provides a function to add the connection shape by
+// given sheet, drawingXML, the coordinates of the start and end cells and
+// the connector settings. The two cell anchor of the connector is sorted from
+// the top-left to the bottom-right, and the connector will be flipped if the
+// start point isn't the top-left point.
+func (f *File) addDrawingConnector(sheet, drawingXML string, coordinates []int, opts *ConnectorOptions) error {
+	_, _, fromCol, fromRow, fromX, fromY := f.positionObjectPixels(sheet, coordinates[0], coordinates[1], opts.FromOffsetX, opts.FromOffsetY, 0, 0)
+	_, _, toCol, toRow, toX, toY := f.positionObjectPixels(sheet, coordinates[2], coordinates[3], opts.ToOffsetX, opts.ToOffsetY, 0, 0)
+	content, cNvPrID, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	var xfrm xlsxXfrm
+	if toCol < fromCol || (toCol == fromCol && toX < fromX) {
+		xfrm.FlipH = true
+		fromCol, fromX, toCol, toX = toCol, toX, fromCol, fromX
+	}
+	if toRow < fromRow || (toRow == fromRow && toY < fromY) {
+		xfrm.FlipV = true
+		fromRow, fromY, toRow, toY = toRow, toY, fromRow, fromY
+	}
+	ln := xlsxLineProperties{HeadEnd: getConnectorArrow(opts.BeginArrow), TailEnd: getConnectorArrow(opts.EndArrow)}
+	if opts.Line.Width != nil {
+		ln.W = f.ptToEMUs(*opts.Line.Width)
+	}
+	if srgbClr := strings.ReplaceAll(strings.ToUpper(opts.Line.Color), "#", ""); srgbClr != "" {
+		ln.SolidFill = &xlsxInnerXML{Content: `<a:srgbClr val="` + srgbClr + `"/>`}
+	}
+	if opts.Line.Dash != "" {
+		ln.PrstDash = &attrValString{Val: stringPtr(opts.Line.Dash)}
+	}
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &xdrCellAnchor{
+		From: &xlsxFrom{Col: fromCol, ColOff: fromX * EMU, Row: fromRow, RowOff: fromY * EMU},
+		To:   &xlsxTo{Col: toCol, ColOff: toX * EMU, Row: toRow, RowOff: toY * EMU},
+		CxnSp: &xdrCxnSp{
+			Macro: opts.Macro,
+			NvCxnSpPr: &xdrNvCxnSpPr{
+				CNvPr: &xlsxCNvPr{ID: cNvPrID, Name: "Connector " + strconv.Itoa(cNvPrID)},
+			},
+			SpPr: &xlsxSpPr{
+				Xfrm:     xfrm,
+				PrstGeom: xlsxPrstGeom{Prst: supportedConnectorTypes[opts.Type]},
+				Ln:       ln,
+			},
+			Style: &xdrStyle{
+				LnRef:     &aRef{Idx: 1, SchemeClr: &attrValString{Val: stringPtr("accent1")}},
+				FillRef:   &aRef{Idx: 0, SchemeClr: &attrValString{Val: stringPtr("accent1")}},
+				EffectRef: &aRef{Idx: 0, SchemeClr: &attrValString{Val: stringPtr("accent1")}},
+				FontRef:   &aFontRef{Idx: "minor", SchemeClr: &attrValString{Val: stringPtr("tx1")}},
+			},
+		},
+		ClientData: &xdrClientData{FLocksWithSheet: true, FPrintsWithSheet: true},
+	})
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
+// getConnectorArrow provides a function to get the line end properties of
+// the connector by given arrowhead settings.
+func getConnectorArrow(arrow ConnectorArrow) *aLineEnd {
+	if arrow.Type == "" || arrow.Type == "none" {
+		return nil
+	}
+	return &aLineEnd{Type: arrow.Type, W: arrow.Width, Len: arrow.Length}
+}
+
 // twoCellAnchorShape create a two cell anchor shape size placeholder for a
 // group, a shape, or a drawing element.
 func (f *File) twoCellAnchorShape(sheet, drawingXML, cell string, width, height uint, format GraphicOptions) (*xlsxWsDr, *xdrCellAnchor, int, error) {
@@ -392,77 +658,56 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 				},
 			},
 		},
-		TxBody: &xdrTxBody{
-			BodyPr: &aBodyPr{
-				VertOverflow: "clip",
-				HorzOverflow: "clip",
-				Wrap:         "none",
-				RtlCol:       false,
-				Anchor:       "t",
-			},
-		},
+		TxBody: &xdrTxBody{BodyPr: drawShapeBodyPr(&opts.TextBody)},
+	}
+	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+	if rID := f.addDrawingHyperlinkRels(drawingRels, &opts.Format); rID != 0 {
+		shape.NvSpPr.CNvPr.HlinkClick = &xlsxHlinkClick{
+			R:       SourceRelationship.Value,
+			RID:     "rId" + strconv.Itoa(rID),
+			Tooltip: opts.Format.HyperlinkTooltip,
+		}
 	}
 	if *opts.Line.Width != 1 {
 		shape.SpPr.Ln = xlsxLineProperties{
 			W: f.ptToEMUs(*opts.Line.Width),
 		}
 	}
+	if opts.Line.Dash != "" {
+		shape.SpPr.Ln.PrstDash = &attrValString{Val: stringPtr(opts.Line.Dash)}
+	}
 	defaultFont, err := f.GetDefaultFont()
 	if err != nil {
 		return err
 	}
-	if len(opts.Paragraph) < 1 {
-		opts.Paragraph = []RichTextRun{
-			{
-				Font: &Font{
-					Bold:      false,
-					Italic:    false,
-					Underline: "none",
-					Family:    defaultFont,
-					Size:      11,
-					Color:     "000000",
+	paragraphs := opts.Paragraphs
+	if len(paragraphs) == 0 {
+		if len(opts.Paragraph) < 1 {
+			opts.Paragraph = []RichTextRun{
+				{
+					Font: &Font{
+						Bold:      false,
+						Italic:    false,
+						Underline: "none",
+						Family:    defaultFont,
+						Size:      11,
+						Color:     "000000",
+					},
+					Text: " ",
 				},
-				Text: " ",
-			},
-		}
-	}
-	for _, p := range opts.Paragraph {
-		u := "none"
-		font := &Font{}
-		if p.Font != nil {
-			font = p.Font
-		}
-		if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
-			u = supportedDrawingUnderlineTypes[idx]
+			}
 		}
-		text := p.Text
-		if text == "" {
-			text = " "
+		for _, run := range opts.Paragraph {
+			paragraphs = append(paragraphs, ShapeParagraph{Runs: []RichTextRun{run}})
 		}
+	}
+	for i := range paragraphs {
 		paragraph := &aP{
-			R: &aR{
-				RPr: aRPr{
-					I:       font.Italic,
-					B:       font.Bold,
-					Lang:    "en-US",
-					AltLang: "en-US",
-					U:       u,
-					Sz:      font.Size * 100,
-					Latin:   &xlsxCTTextFont{Typeface: font.Family},
-				},
-				T: text,
-			},
-			EndParaRPr: &aEndParaRPr{
-				Lang: "en-US",
-			},
+			PPr:        drawShapeParagraphPr(&paragraphs[i]),
+			EndParaRPr: &aEndParaRPr{Lang: "en-US"},
 		}
-		srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
-		if len(srgbClr) == 6 {
-			paragraph.R.RPr.SolidFill = &aSolidFill{
-				SrgbClr: &attrValString{
-					Val: stringPtr(srgbClr),
-				},
-			}
+		for _, run := range paragraphs[i].Runs {
+			paragraph.R = append(paragraph.R, drawShapeRun(run))
 		}
 		shape.TxBody.P = append(shape.TxBody.P, paragraph)
 	}
@@ -496,3 +741,345 @@ func setShapeRef(color string, i int) *aRef {
 		},
 	}
 }
+
+// drawShapeBodyPr provides a function to draw the a:bodyPr element of the
+// shape by given text body settings.
+func drawShapeBodyPr(opts *ShapeTextBody) *aBodyPr {
+	bodyPr := &aBodyPr{
+		VertOverflow: "clip",
+		HorzOverflow: "clip",
+		Wrap:         "none",
+		RtlCol:       false,
+		Anchor:       "t",
+	}
+	if opts.WordWrap {
+		bodyPr.Wrap = "square"
+	}
+	if anchor, ok := supportedDrawingTextAnchor[opts.VerticalAlign]; ok {
+		bodyPr.Anchor = anchor
+	}
+	bodyPr.LIns, bodyPr.TIns = getShapeTextInset(opts.LeftMargin), getShapeTextInset(opts.TopMargin)
+	bodyPr.RIns, bodyPr.BIns = getShapeTextInset(opts.RightMargin), getShapeTextInset(opts.BottomMargin)
+	switch opts.Autofit {
+	case "none":
+		bodyPr.NoAutofit = stringPtr("")
+	case "shrink":
+		bodyPr.NormAutofit = stringPtr("")
+	case "resize":
+		bodyPr.SpAutoFit = stringPtr("")
+	}
+	return bodyPr
+}
+
+// getShapeTextInset provides a function to convert the text body margin of
+// the shape in points to the inset in EMUs.
+func getShapeTextInset(margin *float64) *int {
+	if margin == nil {
+		return nil
+	}
+	return intPtr(int(*margin * 12700))
+}
+
+// drawShapeParagraphPr provides a function to draw the a:pPr element of the
+// shape by given paragraph settings. It returns nil if no paragraph
+// properties were specified.
+func drawShapeParagraphPr(p *ShapeParagraph) *aPPr {
+	if p.Alignment == "" && p.Bullet == "" && p.IndentLevel == 0 &&
+		p.SpaceBefore == 0 && p.SpaceAfter == 0 && p.LineSpacing == 0 {
+		return nil
+	}
+	pPr := &aPPr{Algn: supportedDrawingTextAlignment[p.Alignment], Lvl: p.IndentLevel, MarL: p.IndentLevel * 457200}
+	if p.LineSpacing > 0 {
+		pPr.LnSpc = &aTextSpacing{SpcPct: &attrValInt{Val: intPtr(int(p.LineSpacing * 100000))}}
+	}
+	if p.SpaceBefore > 0 {
+		pPr.SpcBef = &aTextSpacing{SpcPts: &attrValInt{Val: intPtr(int(p.SpaceBefore * 100))}}
+	}
+	if p.SpaceAfter > 0 {
+		pPr.SpcAft = &aTextSpacing{SpcPts: &attrValInt{Val: intPtr(int(p.SpaceAfter * 100))}}
+	}
+	switch p.Bullet {
+	case "none":
+		pPr.BuNone = stringPtr("")
+	case "char":
+		bulletChar := p.BulletChar
+		if bulletChar == "" {
+			bulletChar = "\u2022"
+		}
+		pPr.MarL, pPr.Indent = pPr.MarL+171450, -171450
+		pPr.BuFont = &xlsxCTTextFont{Typeface: "Arial", Panose: "020B0604020202020204", PitchFamily: "34"}
+		pPr.BuChar = &aTextCharBullet{Char: bulletChar}
+	case "autoNum":
+		numberType := p.BulletNumberType
+		if numberType == "" {
+			numberType = "arabicPeriod"
+		}
+		pPr.MarL, pPr.Indent = pPr.MarL+228600, -228600
+		pPr.BuAutoNum = &aTextAutoNumber{Type: numberType}
+	}
+	return pPr
+}
+
+// drawShapeRun provides a function to draw the a:r element of the shape by
+// given rich text run.
+func drawShapeRun(run RichTextRun) *aR {
+	u := "none"
+	font := &Font{}
+	if run.Font != nil {
+		font = run.Font
+	}
+	if idx := inStrSlice(supportedDrawingUnderlineTypes, font.Underline, true); idx != -1 {
+		u = supportedDrawingUnderlineTypes[idx]
+	}
+	text := run.Text
+	if text == "" {
+		text = " "
+	}
+	r := &aR{
+		RPr: aRPr{
+			I:       font.Italic,
+			B:       font.Bold,
+			Lang:    "en-US",
+			AltLang: "en-US",
+			U:       u,
+			Sz:      font.Size * 100,
+			Latin:   &xlsxCTTextFont{Typeface: font.Family},
+		},
+		T: text,
+	}
+	srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
+	if len(srgbClr) == 6 {
+		r.RPr.SolidFill = &aSolidFill{
+			SrgbClr: &attrValString{
+				Val: stringPtr(srgbClr),
+			},
+		}
+	}
+	return r
+}
+
+// GetShapes provides a function to get the shapes in a worksheet by given
+// worksheet name. This function returns the preset geometry type, anchor,
+// size, fill and line formatting, hyperlink and the paragraphs of each shape
+// and connector in the worksheet. The name and the placement of the shape are
+// returned in the "Anchor", and the shapes in the group shape are flattened
+// with the name of the innermost group shape in the "GroupName", which use the
+// placement of the group shape. For example, get all text of the shapes in
+// Sheet1:
+//
+//	shapes, err := f.GetShapes("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, shape := range shapes {
+//	    for _, p := range shape.Paragraphs {
+//	        for _, run := range p.Runs {
+//	            fmt.Println(shape.Anchor.Name, run.Text)
+//	        }
+//	    }
+//	}
+func (f *File) GetShapes(sheet string) ([]Shape, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRels := "xl/drawings/_rels/" + filepath.Base(drawingXML) + ".rels"
+	var wsDr decodeShapeWsDr
+	if err = f.xmlNewDecoder(bytes.NewReader(f.getDrawingContent(drawingXML))).
+		Decode(&wsDr); err != nil && err != io.EOF {
+		return nil, err
+	}
+	var shapes []Shape
+	for anchorType, anchors := range [][]*decodeShapeAnchor{wsDr.TwoCellAnchor, wsDr.OneCellAnchor, wsDr.AbsoluteAnchor} {
+		for _, anchor := range anchors {
+			for _, obj := range anchor.Objects {
+				shapes = f.getDrawingShapes(shapes, sheet, drawingRels, []string{"twoCell", "oneCell", "absolute"}[anchorType], "", anchor, obj)
+			}
+		}
+	}
+	return shapes, nil
+}
+
+// getDrawingShapes provides a function to append the shapes to the given
+// shapes by given worksheet name, drawing relationships part path, anchor
+// type, the name of the group shape, the decoded cell anchor and the drawing
+// object. The child objects of the group shape will be flattened.
+func (f *File) getDrawingShapes(shapes []Shape, sheet, drawingRels, anchorType, groupName string, anchor *decodeShapeAnchor, obj *decodeDrawingShape) []Shape {
+	switch obj.XMLName.Local {
+	case "sp", "cxnSp":
+		shapes = append(shapes, f.getDrawingShape(sheet, drawingRels, anchorType, anchor, obj))
+		shapes[len(shapes)-1].GroupName = groupName
+	case "grpSp":
+		if obj.NvGrpSpPr != nil && obj.NvGrpSpPr.CNvPr != nil {
+			groupName = obj.NvGrpSpPr.CNvPr.Name
+		}
+		for _, child := range obj.Objects {
+			shapes = f.getDrawingShapes(shapes, sheet, drawingRels, anchorType, groupName, anchor, child)
+		}
+	}
+	return shapes
+}
+
+// getDrawingShape provides a function to get the shape by given worksheet
+// name, drawing relationships part path, anchor type, the decoded cell anchor
+// and the decoded shape.
+func (f *File) getDrawingShape(sheet, drawingRels, anchorType string, anchor *decodeShapeAnchor, obj *decodeDrawingShape) Shape {
+	chartAnchor := f.getChartAnchor(sheet, anchorType, &decodeChartAnchor{Pos: anchor.Pos, From: anchor.From, To: anchor.To, Ext: anchor.Ext})
+	shape := Shape{
+		Cell:   chartAnchor.From,
+		Macro:  obj.Macro,
+		Width:  uint(chartAnchor.Width),
+		Height: uint(chartAnchor.Height),
+		Format: GraphicOptions{OffsetX: chartAnchor.OffsetX, OffsetY: chartAnchor.OffsetY, Positioning: anchor.EditAs},
+		Anchor: &PictureAnchor{
+			Type: anchorType, From: chartAnchor.From, To: chartAnchor.To,
+			FromOffsetX: chartAnchor.OffsetX, FromOffsetY: chartAnchor.OffsetY,
+		},
+	}
+	if anchor.Pos != nil {
+		shape.Anchor.FromOffsetX, shape.Anchor.FromOffsetY = chartAnchor.X, chartAnchor.Y
+	}
+	if anchor.To != nil {
+		shape.Anchor.ToOffsetX, shape.Anchor.ToOffsetY = anchor.To.ColOff/EMU, anchor.To.RowOff/EMU
+	}
+	if anchor.ClientData != nil {
+		shape.Format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+		shape.Format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+	}
+	nvSpPr := obj.NvSpPr
+	if nvSpPr == nil {
+		nvSpPr = obj.NvCxnSpPr
+	}
+	if nvSpPr != nil && nvSpPr.CNvPr != nil {
+		shape.Anchor.Name, shape.Format.AltText = nvSpPr.CNvPr.Name, nvSpPr.CNvPr.Descr
+		if link := nvSpPr.CNvPr.HlinkClick; link != nil {
+			f.getDrawingHyperlink(&shape.Format, drawingRels, link.RID, link.Tooltip)
+		}
+	}
+	var fillRef, lnRef *decodeShapeColor
+	if obj.Style != nil {
+		fillRef, lnRef = obj.Style.FillRef, obj.Style.LnRef
+	}
+	if spPr := obj.SpPr; spPr != nil {
+		if spPr.Xfrm != nil && spPr.Xfrm.Ext.Cx > 0 && spPr.Xfrm.Ext.Cy > 0 {
+			shape.Width, shape.Height = uint(spPr.Xfrm.Ext.Cx/EMU), uint(spPr.Xfrm.Ext.Cy/EMU)
+		}
+		if spPr.PrstGeom != nil {
+			shape.Type = spPr.PrstGeom.Prst
+		}
+		if spPr.SolidFill != nil {
+			fillRef = spPr.SolidFill
+		}
+		if ln := spPr.Ln; ln != nil {
+			if ln.W > 0 {
+				shape.Line.Width = float64Ptr(float64(ln.W) / 12700)
+			}
+			if ln.SolidFill != nil {
+				lnRef = ln.SolidFill
+			}
+			if ln.PrstDash != nil && ln.PrstDash.Val != nil {
+				shape.Line.Dash = *ln.PrstDash.Val
+			}
+		}
+	}
+	if color := getShapeColor(fillRef); color != "" {
+		shape.Fill = Fill{Type: "pattern", Color: []string{color}, Pattern: 1}
+	}
+	shape.Line.Color = getShapeColor(lnRef)
+	if obj.TxBody != nil {
+		shape.TextBody, shape.Paragraphs = getShapeText(obj.TxBody)
+	}
+	return shape
+}
+
+// getShapeColor provides a function to get the RGB color by given decoded
+// element which contains the color.
+func getShapeColor(clr *decodeShapeColor) string {
+	if clr == nil || clr.SrgbClr == nil || clr.SrgbClr.Val == nil {
+		return ""
+	}
+	return *clr.SrgbClr.Val
+}
+
+// getShapeText provides a function to get the text body settings and the
+// paragraphs of the shape by given decoded text body.
+func getShapeText(txBody *decodeShapeTxBody) (ShapeTextBody, []ShapeParagraph) {
+	var (
+		body       ShapeTextBody
+		paragraphs []ShapeParagraph
+	)
+	getMargin := func(inset *int) *float64 {
+		if inset == nil {
+			return nil
+		}
+		return float64Ptr(float64(*inset) / 12700)
+	}
+	if bodyPr := txBody.BodyPr; bodyPr != nil {
+		body.WordWrap = bodyPr.Wrap == "square"
+		for verticalAlign, anchor := range supportedDrawingTextAnchor {
+			if bodyPr.Anchor == anchor {
+				body.VerticalAlign = verticalAlign
+			}
+		}
+		body.LeftMargin, body.TopMargin = getMargin(bodyPr.LIns), getMargin(bodyPr.TIns)
+		body.RightMargin, body.BottomMargin = getMargin(bodyPr.RIns), getMargin(bodyPr.BIns)
+		switch {
+		case bodyPr.NoAutofit != nil:
+			body.Autofit = "none"
+		case bodyPr.NormAutofit != nil:
+			body.Autofit = "shrink"
+		case bodyPr.SpAutoFit != nil:
+			body.Autofit = "resize"
+		}
+	}
+	for _, p := range txBody.P {
+		paragraph := getShapeParagraphPr(p.PPr)
+		for _, r := range p.R {
+			paragraph.Runs = append(paragraph.Runs, RichTextRun{Text: r.T, Font: getChartFont(r.RPr)})
+		}
+		paragraphs = append(paragraphs, paragraph)
+	}
+	return body, paragraphs
+}
+
+// getShapeParagraphPr provides a function to get the paragraph settings of the
+// shape by given decoded paragraph properties.
+func getShapeParagraphPr(pPr *decodeShapePPr) ShapeParagraph {
+	var p ShapeParagraph
+	if pPr == nil {
+		return p
+	}
+	for alignment, algn := range supportedDrawingTextAlignment {
+		if pPr.Algn == algn {
+			p.Alignment = alignment
+		}
+	}
+	p.IndentLevel = pPr.Lvl
+	if pPr.LnSpc != nil && pPr.LnSpc.SpcPct != nil && pPr.LnSpc.SpcPct.Val != nil {
+		p.LineSpacing = float64(*pPr.LnSpc.SpcPct.Val) / 100000
+	}
+	if pPr.SpcBef != nil && pPr.SpcBef.SpcPts != nil && pPr.SpcBef.SpcPts.Val != nil {
+		p.SpaceBefore = float64(*pPr.SpcBef.SpcPts.Val) / 100
+	}
+	if pPr.SpcAft != nil && pPr.SpcAft.SpcPts != nil && pPr.SpcAft.SpcPts.Val != nil {
+		p.SpaceAfter = float64(*pPr.SpcAft.SpcPts.Val) / 100
+	}
+	switch {
+	case pPr.BuNone != nil:
+		p.Bullet = "none"
+	case pPr.BuChar != nil:
+		p.Bullet, p.BulletChar = "char", pPr.BuChar.Char
+	case pPr.BuAutoNum != nil:
+		p.Bullet, p.BulletNumberType = "autoNum", pPr.BuAutoNum.Type
+	}
+	return p
+}
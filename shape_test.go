@@ -1,7 +1,9 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -98,6 +100,174 @@ func TestAddShape(t *testing.T) {
 	assert.EqualError(t, f.AddShape("Sheet1", &Shape{Cell: "B30", Type: "rect", Paragraph: []RichTextRun{{Text: "Rectangle"}, {}}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddShapeParagraphs(t *testing.T) {
+	f := NewFile()
+	leftMargin, topMargin := 3.6, 0.0
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A1",
+		Type: "wedgeRectCallout",
+		Paragraphs: []ShapeParagraph{
+			{
+				Runs:       []RichTextRun{{Text: "Summary: ", Font: &Font{Bold: true}}, {Text: "Q1"}},
+				Alignment:  "center",
+				SpaceAfter: 6,
+			},
+			{Runs: []RichTextRun{{Text: "First"}}, Bullet: "char"},
+			{Runs: []RichTextRun{{Text: "Second"}}, Bullet: "char", BulletChar: "-", IndentLevel: 1},
+			{Runs: []RichTextRun{{Text: "Step"}}, Bullet: "autoNum", SpaceBefore: 3, LineSpacing: 1.5},
+			{Runs: []RichTextRun{{Text: "Note"}}, Bullet: "none", Alignment: "right"},
+			{},
+		},
+		TextBody: ShapeTextBody{
+			Autofit: "shrink", VerticalAlign: "center", WordWrap: true,
+			LeftMargin: &leftMargin, TopMargin: &topMargin,
+		},
+		Width: 180, Height: 120,
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	txBody, err := xml.Marshal(drawing.(*xlsxWsDr).TwoCellAnchor[0].Sp.TxBody)
+	assert.NoError(t, err)
+	// Test the text body markup with the same structure as the text box
+	// authored by Excel
+	assert.Equal(t, `<xdrTxBody>`+
+		`<a:bodyPr anchor="ctr" anchorCtr="false" rot="0" horzOverflow="clip" lIns="45720" spcFirstLastPara="false" tIns="0" vertOverflow="clip" wrap="square"><a:normAutofit></a:normAutofit></a:bodyPr>`+
+		`<a:p><a:pPr algn="ctr"><a:spcAft><a:spcPts val="600"></a:spcPts></a:spcAft></a:pPr>`+
+		`<a:r><a:rPr altLang="en-US" b="true" baseline="0" i="false" kern="0" lang="en-US" spc="0" u="none"><a:latin typeface=""></a:latin></a:rPr><a:t>Summary: </a:t></a:r>`+
+		`<a:r><a:rPr altLang="en-US" b="false" baseline="0" i="false" kern="0" lang="en-US" spc="0" u="none"><a:latin typeface=""></a:latin></a:rPr><a:t>Q1</a:t></a:r>`+
+		`<a:endParaRPr lang="en-US"></a:endParaRPr></a:p>`+
+		`<a:p><a:pPr indent="-171450" marL="171450"><a:buFont typeface="Arial" panose="020B0604020202020204" pitchFamily="34"></a:buFont><a:buChar char="•"></a:buChar></a:pPr>`+
+		`<a:r><a:rPr altLang="en-US" b="false" baseline="0" i="false" kern="0" lang="en-US" spc="0" u="none"><a:latin typeface=""></a:latin></a:rPr><a:t>First</a:t></a:r>`+
+		`<a:endParaRPr lang="en-US"></a:endParaRPr></a:p>`+
+		`<a:p><a:pPr indent="-171450" lvl="1" marL="628650"><a:buFont typeface="Arial" panose="020B0604020202020204" pitchFamily="34"></a:buFont><a:buChar char="-"></a:buChar></a:pPr>`+
+		`<a:r><a:rPr altLang="en-US" b="false" baseline="0" i="false" kern="0" lang="en-US" spc="0" u="none"><a:latin typeface=""></a:latin></a:rPr><a:t>Second</a:t></a:r>`+
+		`<a:endParaRPr lang="en-US"></a:endParaRPr></a:p>`+
+		`<a:p><a:pPr indent="-228600" marL="228600"><a:lnSpc><a:spcPct val="150000"></a:spcPct></a:lnSpc><a:spcBef><a:spcPts val="300"></a:spcPts></a:spcBef><a:buAutoNum type="arabicPeriod"></a:buAutoNum></a:pPr>`+
+		`<a:r><a:rPr altLang="en-US" b="false" baseline="0" i="false" kern="0" lang="en-US" spc="0" u="none"><a:latin typeface=""></a:latin></a:rPr><a:t>Step</a:t></a:r>`+
+		`<a:endParaRPr lang="en-US"></a:endParaRPr></a:p>`+
+		`<a:p><a:pPr algn="r"><a:buNone></a:buNone></a:pPr>`+
+		`<a:r><a:rPr altLang="en-US" b="false" baseline="0" i="false" kern="0" lang="en-US" spc="0" u="none"><a:latin typeface=""></a:latin></a:rPr><a:t>Note</a:t></a:r>`+
+		`<a:endParaRPr lang="en-US"></a:endParaRPr></a:p>`+
+		`<a:p><a:endParaRPr lang="en-US"></a:endParaRPr></a:p>`+
+		`</xdrTxBody>`, string(txBody))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A10", Type: "rect", TextBody: ShapeTextBody{Autofit: "resize", VerticalAlign: "bottom"},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A20", Type: "rect", TextBody: ShapeTextBody{Autofit: "none"}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeParagraphs.xlsx")))
+	// Test add shape with invalid paragraph and text body settings
+	for _, shape := range []*Shape{
+		{Paragraphs: []ShapeParagraph{{Alignment: "top"}}},
+		{Paragraphs: []ShapeParagraph{{Bullet: "star"}}},
+		{Paragraphs: []ShapeParagraph{{IndentLevel: 9}}},
+		{Paragraphs: []ShapeParagraph{{SpaceBefore: -1}}},
+		{TextBody: ShapeTextBody{VerticalAlign: "left"}},
+		{TextBody: ShapeTextBody{Autofit: "fit"}},
+		{TextBody: ShapeTextBody{BottomMargin: float64Ptr(-1)}},
+	} {
+		shape.Cell, shape.Type = "A1", "rect"
+		assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", shape))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddConnector(t *testing.T) {
+	f := NewFile()
+	lineWidth := 1.5
+	assert.NoError(t, f.AddConnector("Sheet1", &ConnectorOptions{
+		From: "B3", FromOffsetX: 32, To: "E6", ToOffsetY: 10, Type: "bent",
+		Line:       ShapeLine{Color: "#4286f4", Width: &lineWidth, Dash: "dash"},
+		BeginArrow: ConnectorArrow{Type: "oval"},
+		EndArrow:   ConnectorArrow{Type: "triangle", Width: "med", Length: "lg"},
+	}))
+	// Test add connector from the bottom-right point to the top-left point
+	assert.NoError(t, f.AddConnector("Sheet1", &ConnectorOptions{From: "D10", To: "B8", EndArrow: ConnectorArrow{Type: "none"}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchors := drawing.(*xlsxWsDr).TwoCellAnchor
+	assert.Len(t, anchors, 2)
+	assert.Equal(t, &xlsxFrom{Col: 1, ColOff: 32 * EMU, Row: 2}, anchors[0].From)
+	assert.Equal(t, &xlsxTo{Col: 4, Row: 5, RowOff: 10 * EMU}, anchors[0].To)
+	cxnSp := anchors[0].CxnSp
+	assert.Equal(t, "bentConnector3", cxnSp.SpPr.PrstGeom.Prst)
+	assert.Equal(t, xlsxLineProperties{
+		W:         19050,
+		SolidFill: &xlsxInnerXML{Content: `<a:srgbClr val="4286F4"/>`},
+		PrstDash:  &attrValString{Val: stringPtr("dash")},
+		HeadEnd:   &aLineEnd{Type: "oval"},
+		TailEnd:   &aLineEnd{Type: "triangle", W: "med", Len: "lg"},
+	}, cxnSp.SpPr.Ln)
+	assert.False(t, cxnSp.SpPr.Xfrm.FlipH || cxnSp.SpPr.Xfrm.FlipV)
+	assert.Equal(t, &xlsxFrom{Col: 1, Row: 7}, anchors[1].From)
+	assert.Equal(t, &xlsxTo{Col: 3, Row: 9}, anchors[1].To)
+	assert.Equal(t, "straightConnector1", anchors[1].CxnSp.SpPr.PrstGeom.Prst)
+	assert.True(t, anchors[1].CxnSp.SpPr.Xfrm.FlipH && anchors[1].CxnSp.SpPr.Xfrm.FlipV)
+	assert.Nil(t, anchors[1].CxnSp.SpPr.Ln.TailEnd)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddConnector.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test keep the connectors after re-saving the workbook
+	f, err := OpenFile(filepath.Join("test", "TestAddConnector.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "G1", Type: "rect"}))
+	assert.NoError(t, f.AddConnector("Sheet1", &ConnectorOptions{From: "G5", To: "H9", Type: "curved"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddConnector.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestAddConnector.xlsx"))
+	assert.NoError(t, err)
+	content := string(f.readXML("xl/drawings/drawing1.xml"))
+	assert.Contains(t, content, `<a:headEnd type="oval"></a:headEnd><a:tailEnd type="triangle" w="med" len="lg"></a:tailEnd>`)
+	assert.Contains(t, content, `<a:prstGeom prst="curvedConnector3"></a:prstGeom>`)
+	assert.Equal(t, 3, strings.Count(content, "<xdr:cxnSp "))
+
+	// Test add connector with invalid settings
+	for _, opts := range []*ConnectorOptions{
+		nil,
+		{From: "A1", To: "B2", Type: "elbow"},
+		{From: "A1", To: "B2", FromOffsetX: -1},
+		{From: "A1", To: "B2", Line: ShapeLine{Dash: "dashed"}},
+		{From: "A1", To: "B2", BeginArrow: ConnectorArrow{Type: "circle"}},
+		{From: "A1", To: "B2", EndArrow: ConnectorArrow{Type: "arrow", Width: "large"}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", opts))
+	}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddConnector("Sheet1", &ConnectorOptions{From: "A", To: "B2"}))
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")), f.AddConnector("Sheet1", &ConnectorOptions{From: "A1", To: "B"}))
+	assert.EqualError(t, f.AddConnector("SheetN", &ConnectorOptions{From: "A1", To: "B2"}), "sheet SheetN does not exist")
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect", Line: ShapeLine{Dash: "dashed"}}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect", Line: ShapeLine{Dash: "sysDot"}}))
+	// Test add connector with unsupported charset drawing
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddConnector("Sheet1", &ConnectorOptions{From: "A1", To: "B2"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeHyperlink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A1", Type: "rect",
+		Format: GraphicOptions{Hyperlink: "Sheet1!D4", HyperlinkType: "Location", HyperlinkTooltip: "Tooltip"},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "A10", Type: "rect",
+		Format: GraphicOptions{Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External"},
+	}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchors := drawing.(*xlsxWsDr).TwoCellAnchor
+	assert.Equal(t, &xlsxHlinkClick{R: SourceRelationship.Value, RID: "rId1", Tooltip: "Tooltip"}, anchors[0].Sp.NvSpPr.CNvPr.HlinkClick)
+	assert.Equal(t, &xlsxHlinkClick{R: SourceRelationship.Value, RID: "rId2"}, anchors[1].Sp.NvSpPr.CNvPr.HlinkClick)
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipHyperLink, Target: "#Sheet1!D4"},
+		{ID: "rId2", Type: SourceRelationshipHyperLink, Target: "https://github.com/xuri/excelize", TargetMode: "External"},
+	}, rels.Relationships)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeHyperlink.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
 func TestAddDrawingShape(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/drawing1.xml"
@@ -113,3 +283,98 @@ func TestAddDrawingShape(t *testing.T) {
 		},
 	), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestGetShapes(t *testing.T) {
+	f := NewFile()
+	lineWidth, margin := 1.5, 3.6
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{
+		Cell: "B2", Type: "wedgeRectCallout", Macro: "Button1_Click", Width: 180, Height: 120,
+		Format: GraphicOptions{OffsetX: 10, OffsetY: 5, Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External", HyperlinkTooltip: "Excelize"},
+		Fill:   Fill{Type: "pattern", Color: []string{"8EB9FF"}, Pattern: 1},
+		Line:   ShapeLine{Color: "4286F4", Width: &lineWidth, Dash: "dash"},
+		Paragraphs: []ShapeParagraph{
+			{Runs: []RichTextRun{{Text: "Summary", Font: &Font{Bold: true, Size: 14, Color: "777777", Family: "Arial", Underline: "sng"}}}, Alignment: "center", SpaceAfter: 6},
+			{Runs: []RichTextRun{{Text: "First"}, {Text: " item"}}, Bullet: "char", IndentLevel: 1, LineSpacing: 1.5},
+			{Runs: []RichTextRun{{Text: "Second item"}}, Bullet: "autoNum", SpaceBefore: 3},
+		},
+		TextBody: ShapeTextBody{Autofit: "shrink", VerticalAlign: "center", WordWrap: true, LeftMargin: &margin},
+	}))
+	assert.NoError(t, f.AddConnector("Sheet1", &ConnectorOptions{From: "F8", To: "D3", Type: "bent"}))
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 2)
+	shape := shapes[0]
+	assert.Equal(t, &PictureAnchor{Type: "twoCell", Name: "Shape 2", From: "B2", FromOffsetX: 10, FromOffsetY: 5, To: "D8", ToOffsetX: 62, ToOffsetY: 17}, shape.Anchor)
+	assert.Equal(t, "B2", shape.Cell)
+	assert.Equal(t, "wedgeRectCallout", shape.Type)
+	assert.Equal(t, "Button1_Click", shape.Macro)
+	assert.Equal(t, uint(180), shape.Width)
+	assert.Equal(t, uint(120), shape.Height)
+	assert.Equal(t, GraphicOptions{
+		PrintObject: boolPtr(true), Locked: boolPtr(false), OffsetX: 10, OffsetY: 5,
+		Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External", HyperlinkTooltip: "Excelize",
+	}, shape.Format)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"8EB9FF"}, Pattern: 1}, shape.Fill)
+	assert.Equal(t, ShapeLine{Color: "4286F4", Width: &lineWidth, Dash: "dash"}, shape.Line)
+	assert.Equal(t, ShapeTextBody{Autofit: "shrink", VerticalAlign: "center", WordWrap: true, LeftMargin: &margin}, shape.TextBody)
+	assert.Equal(t, []ShapeParagraph{
+		{Runs: []RichTextRun{{Text: "Summary", Font: &Font{Bold: true, Size: 14, Color: "777777", Family: "Arial", Underline: "sng"}}}, Alignment: "center", SpaceAfter: 6},
+		{Runs: []RichTextRun{{Text: "First", Font: &Font{Underline: "none"}}, {Text: " item", Font: &Font{Underline: "none"}}}, Bullet: "char", BulletChar: "•", IndentLevel: 1, LineSpacing: 1.5},
+		{Runs: []RichTextRun{{Text: "Second item", Font: &Font{Underline: "none"}}}, Bullet: "autoNum", BulletNumberType: "arabicPeriod", SpaceBefore: 3},
+	}, shape.Paragraphs)
+	assert.Equal(t, &PictureAnchor{Type: "twoCell", Name: "Connector 3", From: "D3", To: "F8"}, shapes[1].Anchor)
+	assert.Equal(t, "bentConnector3", shapes[1].Type)
+	assert.Empty(t, shapes[1].Paragraphs)
+	// Test get shapes after saving the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetShapes.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetShapes.xlsx"))
+	assert.NoError(t, err)
+	clones, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, shapes, clones)
+	// Test get shapes on the worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	shapes, err = f.GetShapes("Sheet2")
+	assert.NoError(t, err)
+	assert.Nil(t, shapes)
+	// Test get shapes with invalid sheet name
+	_, err = f.GetShapes("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get shapes on not exists worksheet
+	_, err = f.GetShapes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get shapes with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetShapes("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test get shapes in the group shape with absolute and one cell anchor
+	f = NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", &Shape{Cell: "A1", Type: "rect"}))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", []byte(`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><xdr:absoluteAnchor><xdr:pos x="95250" y="190500"/><xdr:ext cx="952500" cy="476250"/><xdr:grpSp><xdr:nvGrpSpPr><xdr:cNvPr id="2" name="Group 1"/><xdr:cNvGrpSpPr/></xdr:nvGrpSpPr><xdr:grpSpPr/><xdr:sp macro="" textlink=""><xdr:nvSpPr><xdr:cNvPr id="3" name="Oval 2" descr="Oval"/><xdr:cNvSpPr/></xdr:nvSpPr><xdr:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="476250" cy="476250"/></a:xfrm><a:prstGeom prst="ellipse"><a:avLst/></a:prstGeom><a:solidFill><a:srgbClr val="FF0000"/></a:solidFill><a:ln w="25400"><a:solidFill><a:srgbClr val="00FF00"/></a:solidFill></a:ln></xdr:spPr><xdr:txBody><a:bodyPr wrap="none" anchor="b"><a:spAutoFit/></a:bodyPr><a:p><a:pPr algn="r"><a:buNone/></a:pPr><a:r><a:t>Text</a:t></a:r></a:p></xdr:txBody></xdr:sp><xdr:grpSp><xdr:nvGrpSpPr><xdr:cNvPr id="4" name="Group 3"/><xdr:cNvGrpSpPr/></xdr:nvGrpSpPr><xdr:grpSpPr/><xdr:sp macro="" textlink=""><xdr:nvSpPr><xdr:cNvPr id="5" name="Rectangle 4"/><xdr:cNvSpPr/></xdr:nvSpPr><xdr:spPr><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></xdr:spPr><xdr:txBody><a:bodyPr><a:noAutofit/></a:bodyPr><a:p/></xdr:txBody></xdr:sp></xdr:grpSp></xdr:grpSp><xdr:clientData/></xdr:absoluteAnchor><xdr:oneCellAnchor><xdr:from><xdr:col>1</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>1</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from><xdr:ext cx="95250" cy="95250"/><xdr:sp macro="" textlink=""><xdr:nvSpPr><xdr:cNvPr id="6" name="Rectangle 5"/><xdr:cNvSpPr/></xdr:nvSpPr><xdr:spPr><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></xdr:spPr></xdr:sp><xdr:clientData/></xdr:oneCellAnchor></xdr:wsDr>`))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 3)
+	assert.Equal(t, &PictureAnchor{Type: "oneCell", Name: "Rectangle 5", From: "B2"}, shapes[0].Anchor)
+	assert.Equal(t, uint(10), shapes[0].Width)
+	assert.Equal(t, &PictureAnchor{Type: "absolute", Name: "Oval 2", FromOffsetX: 10, FromOffsetY: 20}, shapes[1].Anchor)
+	assert.Equal(t, "Group 1", shapes[1].GroupName)
+	assert.Equal(t, "ellipse", shapes[1].Type)
+	assert.Equal(t, "Oval", shapes[1].Format.AltText)
+	assert.Equal(t, uint(50), shapes[1].Width)
+	assert.Equal(t, []string{"FF0000"}, shapes[1].Fill.Color)
+	assert.Equal(t, ShapeLine{Color: "00FF00", Width: float64Ptr(2)}, shapes[1].Line)
+	assert.Equal(t, ShapeTextBody{Autofit: "resize", VerticalAlign: "bottom"}, shapes[1].TextBody)
+	assert.Equal(t, []ShapeParagraph{{Runs: []RichTextRun{{Text: "Text"}}, Alignment: "right", Bullet: "none"}}, shapes[1].Paragraphs)
+	assert.Equal(t, "Group 3", shapes[2].GroupName)
+	assert.Equal(t, "Rectangle 4", shapes[2].Anchor.Name)
+	assert.Equal(t, uint(100), shapes[2].Width)
+	assert.Equal(t, "none", shapes[2].TextBody.Autofit)
+	assert.Equal(t, []ShapeParagraph{{}}, shapes[2].Paragraphs)
+	assert.NoError(t, f.Close())
+}
@@ -271,7 +271,10 @@ func replaceRelationshipsBytes(content []byte) []byte {
 // SetActiveSheet provides a function to set the default active sheet of the
 // workbook by a given index. Note that the active index is different from the
 // ID returned by function GetSheetMap(). It should be greater than or equal to 0
-// and less than the total worksheet numbers.
+// and less than the total worksheet numbers. The selected state of the sheet
+// tab of the worksheet created by the stream writer will be written with the
+// first row, so please set the active sheet before writing rows with the
+// stream writer.
 func (f *File) SetActiveSheet(index int) {
 	if index < 0 {
 		index = 0
@@ -292,6 +295,13 @@ func (f *File) SetActiveSheet(index int) {
 		}
 	}
 	for idx, name := range f.GetSheetList() {
+		if sheetXMLPath, ok := f.getSheetXMLPath(name); ok {
+			// Skip the worksheet which sheet views have been written by the
+			// stream writer
+			if sw, ok := f.streams[sheetXMLPath]; ok && sw.sheetWritten {
+				continue
+			}
+		}
 		ws, err := f.workSheetReader(name)
 		if err != nil {
 			// Chartsheet, macrosheet or dialogsheet
@@ -773,6 +783,19 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// streamWorkSheetReader provides a function to get the pointer to the
+// worksheet structure by given worksheet name, the worksheet of the stream
+// writer will be returned without reading the streamed data if the worksheet
+// is being written or has been written by the stream writer.
+func (f *File) streamWorkSheetReader(sheet string) (*xlsxWorksheet, error) {
+	if name, ok := f.getSheetXMLPath(sheet); ok {
+		if sw, ok := f.streams[name]; ok {
+			return sw.worksheet, nil
+		}
+	}
+	return f.workSheetReader(sheet)
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -813,7 +836,7 @@ func (f *File) SetSheetVisible(sheet string, visible bool, veryHidden ...bool) e
 		}
 	}
 	for k, v := range wb.Sheets.Sheet {
-		ws, err := f.workSheetReader(v.Name)
+		ws, err := f.streamWorkSheetReader(v.Name)
 		if err != nil {
 			return err
 		}
@@ -838,6 +861,9 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	if panes == nil {
 		return ErrParameterInvalid
 	}
+	if err := checkPanes(panes); err != nil {
+		return err
+	}
 	p := &xlsxPane{
 		ActivePane:  panes.ActivePane,
 		TopLeftCell: panes.TopLeftCell,
@@ -846,6 +872,12 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 	}
 	if panes.Freeze {
 		p.State = "frozen"
+		if p.TopLeftCell == "" {
+			p.TopLeftCell, _ = CoordinatesToCellName(panes.XSplit+1, panes.YSplit+1)
+		}
+	}
+	if p.ActivePane == "" {
+		p.ActivePane = getActivePane(panes.XSplit, panes.YSplit)
 	}
 	if ws.SheetViews == nil {
 		ws.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{}}}
@@ -856,18 +888,91 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 			ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Pane = nil
 		}
 	}
-	var s []*xlsxSelection
-	for _, p := range panes.Selection {
+	var (
+		s          []*xlsxSelection
+		activePane bool
+	)
+	for _, sel := range panes.Selection {
+		sqRef := sel.SQRef
+		if sqRef == "" {
+			sqRef = sel.ActiveCell
+		}
+		activePane = activePane || sel.Pane == p.ActivePane
 		s = append(s, &xlsxSelection{
-			ActiveCell: p.ActiveCell,
-			Pane:       p.Pane,
-			SQRef:      p.SQRef,
+			ActiveCell: sel.ActiveCell,
+			Pane:       sel.Pane,
+			SQRef:      sqRef,
 		})
 	}
+	if panes.Freeze && !activePane {
+		s = append(s, &xlsxSelection{ActiveCell: p.TopLeftCell, Pane: p.ActivePane, SQRef: p.TopLeftCell})
+	}
 	ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].Selection = s
 	return nil
 }
 
+// checkPanes provides a function to validate the split positions, the top
+// left visible cell and the pane types of the panes options.
+func checkPanes(panes *Panes) error {
+	if panes.Freeze || panes.Split {
+		if panes.XSplit < 0 || panes.YSplit < 0 || (panes.XSplit == 0 && panes.YSplit == 0) {
+			return ErrPaneSplit
+		}
+		if panes.TopLeftCell != "" {
+			if _, _, err := CellNameToCoordinates(panes.TopLeftCell); err != nil {
+				return err
+			}
+		}
+		if panes.ActivePane != "" && inStrSlice(supportedPaneTypes, panes.ActivePane, true) == -1 {
+			return newInvalidPaneError(panes.ActivePane)
+		}
+	}
+	for _, s := range panes.Selection {
+		if s.Pane != "" && inStrSlice(supportedPaneTypes, s.Pane, true) == -1 {
+			return newInvalidPaneError(s.Pane)
+		}
+		if panes.Freeze && s.ActiveCell != "" {
+			if err := checkPaneSelection(panes, &s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkPaneSelection provides a function to check if the active cell of the
+// selection is placed in its pane of the frozen panes, the frozen columns and
+// rows belong to the left and top panes.
+func checkPaneSelection(panes *Panes, s *Selection) error {
+	col, row, err := CellNameToCoordinates(s.ActiveCell)
+	if err != nil {
+		return err
+	}
+	pane := s.Pane
+	if pane == "" {
+		pane = "topLeft"
+	}
+	right, bottom := strings.HasSuffix(pane, "Right"), strings.HasPrefix(pane, "bottom")
+	if (panes.XSplit > 0 && right != (col > panes.XSplit)) ||
+		(panes.YSplit > 0 && bottom != (row > panes.YSplit)) {
+		return newPaneSelectionError(s.ActiveCell, pane)
+	}
+	return nil
+}
+
+// getActivePane returns the default active pane by given split positions,
+// the bottom right pane will be used when both vertical and horizontal splits
+// are applied.
+func getActivePane(xSplit, ySplit int) string {
+	if xSplit > 0 && ySplit > 0 {
+		return "bottomRight"
+	}
+	if ySplit > 0 {
+		return "bottomLeft"
+	}
+	return "topRight"
+}
+
 // SetPanes provides a function to create and remove freeze panes and split panes
 // by given worksheet name and panes options.
 //
@@ -931,8 +1036,32 @@ func (ws *xlsxWorksheet) setPanes(panes *Panes) error {
 // TopLeftCell: Location of the top left visible cell in the bottom right pane
 // (when in Left-To-Right mode).
 //
+// The freeze panes and split panes use the split positions differently. For
+// the freeze panes, the XSplit and YSplit are the number of the frozen columns
+// and rows, and the TopLeftCell will be the first cell after the frozen
+// columns and rows if it is empty. For the split panes, the XSplit and YSplit
+// are the positions of the split bars in 1/20th of a point, for example, 3270
+// means the vertical split bar is placed at 163.5 points from the left edge.
+// The split positions can't be negative, and at least one of them should be
+// positive. If the ActivePane is empty, the bottom right pane will be active
+// when both vertical and horizontal splits are applied, otherwise the bottom
+// left pane or the top right pane will be active. Use the SetSplitPanes
+// function to create split panes with the synchronized scroll positions.
+//
 // SQRef (Sequence of References): Range of the selection. Can be non-contiguous
-// set of ranges.
+// set of ranges. The active cell will be used as the range of the selection if
+// it is empty.
+//
+// For the freeze panes, the active cell of each selection must be placed in its
+// pane, the frozen columns and rows belong to the left and top panes, and the
+// selection without pane type belongs to the top left pane. If there is no
+// selection for the active pane, the selection on the TopLeftCell of the active
+// pane will be created, so that the cursor will be placed in the unfrozen pane.
+//
+// An example of how to freeze the first row in the Sheet1, the TopLeftCell and
+// the ActivePane will be set as A2 and bottomLeft:
+//
+//	err := f.SetPanes("Sheet1", &excelize.Panes{Freeze: true, YSplit: 1})
 //
 // An example of how to freeze column A in the Sheet1 and set the active cell on
 // Sheet1!K16:
@@ -993,6 +1122,87 @@ func (f *File) SetPanes(sheet string, panes *Panes) error {
 	return ws.setPanes(panes)
 }
 
+// SetSplitPanes provides a function to create split panes with the
+// synchronized scroll positions by given worksheet name and split panes
+// options. The panes on the same side of the split bar scroll together, so
+// the top left visible cell of each pane is determined by the top left
+// visible cell of the top left pane and the bottom right pane: the top right
+// pane shows the rows of the top left pane and the columns of the bottom right
+// pane, and the bottom left pane shows the columns of the top left pane and
+// the rows of the bottom right pane. The selection of each pane will be placed
+// on its top left visible cell, except the active pane which uses the
+// ActiveCell if it is specified. For example, create split panes at 163.5
+// points from the left edge and 90 points from the top edge in the Sheet1,
+// the top left pane starts with cell A1 and the bottom right pane starts with
+// cell N57:
+//
+//	err := f.SetSplitPanes("Sheet1", &excelize.SplitPanesOptions{
+//	    XSplit:          3270,
+//	    YSplit:          1800,
+//	    TopLeftCell:     "A1",
+//	    BottomRightCell: "N57",
+//	    ActiveCell:      "O60",
+//	})
+func (f *File) SetSplitPanes(sheet string, opts *SplitPanesOptions) error {
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	topLeftCell, bottomRightCell := opts.TopLeftCell, opts.BottomRightCell
+	if topLeftCell == "" {
+		topLeftCell = "A1"
+	}
+	if bottomRightCell == "" {
+		bottomRightCell = topLeftCell
+	}
+	col1, row1, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	col2, row2, err := CellNameToCoordinates(bottomRightCell)
+	if err != nil {
+		return err
+	}
+	if opts.ActiveCell != "" {
+		if _, _, err = CellNameToCoordinates(opts.ActiveCell); err != nil {
+			return err
+		}
+	}
+	panes := &Panes{
+		Split:       true,
+		XSplit:      opts.XSplit,
+		YSplit:      opts.YSplit,
+		TopLeftCell: opts.BottomRightCell,
+		ActivePane:  getActivePane(opts.XSplit, opts.YSplit),
+	}
+	for _, p := range []struct {
+		pane     string
+		col, row int
+		visible  bool
+	}{
+		{pane: "topRight", col: col2, row: row1, visible: opts.XSplit > 0},
+		{pane: "bottomLeft", col: col1, row: row2, visible: opts.YSplit > 0},
+		{pane: "bottomRight", col: col2, row: row2, visible: opts.XSplit > 0 && opts.YSplit > 0},
+	} {
+		if !p.visible {
+			continue
+		}
+		cell, _ := CoordinatesToCellName(p.col, p.row)
+		if p.pane == panes.ActivePane && opts.ActiveCell != "" {
+			cell = opts.ActiveCell
+		}
+		panes.Selection = append(panes.Selection, Selection{SQRef: cell, ActiveCell: cell, Pane: p.pane})
+	}
+	if err = ws.setPanes(panes); err != nil {
+		return err
+	}
+	ws.SheetViews.SheetView[len(ws.SheetViews.SheetView)-1].TopLeftCell = opts.TopLeftCell
+	return err
+}
+
 // getPanes returns freeze panes, split panes, and views of the worksheet.
 func (ws *xlsxWorksheet) getPanes() Panes {
 	var (
@@ -1107,7 +1317,13 @@ func (f *File) searchSheet(name, value string, regSearch bool) (result []string,
 		return
 	}
 	regex := regexp.MustCompile(value)
-	decoder := f.xmlNewDecoder(bytes.NewReader(f.readBytes(name)))
+	needClose, decoder, tempFile, err := f.xmlDecoder(name)
+	if err != nil {
+		return
+	}
+	if needClose {
+		defer tempFile.Close()
+	}
 	for {
 		var token xml.Token
 		token, err = decoder.Token()
@@ -1321,16 +1537,26 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 		ws.HeaderFooter = nil
 		return err
 	}
+	headerFooter, err := newHeaderFooter(opts)
+	if err != nil {
+		return err
+	}
+	ws.HeaderFooter = headerFooter
+	return err
+}
 
+// newHeaderFooter provides a function to check the header and footer options
+// and create the header and footer settings of the worksheet or chartsheet.
+func newHeaderFooter(opts *HeaderFooterOptions) (*xlsxHeaderFooter, error) {
 	v := reflect.ValueOf(*opts)
 	// Check 6 string type fields: OddHeader, OddFooter, EvenHeader, EvenFooter,
 	// FirstFooter, FirstHeader
 	for i := 4; i < v.NumField()-1; i++ {
 		if len(utf16.Encode([]rune(v.Field(i).String()))) > MaxFieldLength {
-			return newFieldLengthError(v.Type().Field(i).Name)
+			return nil, newFieldLengthError(v.Type().Field(i).Name)
 		}
 	}
-	ws.HeaderFooter = &xlsxHeaderFooter{
+	return &xlsxHeaderFooter{
 		AlignWithMargins: opts.AlignWithMargins,
 		DifferentFirst:   opts.DifferentFirst,
 		DifferentOddEven: opts.DifferentOddEven,
@@ -1341,8 +1567,7 @@ func (f *File) SetHeaderFooter(sheet string, opts *HeaderFooterOptions) error {
 		EvenFooter:       opts.EvenFooter,
 		FirstFooter:      opts.FirstFooter,
 		FirstHeader:      opts.FirstHeader,
-	}
-	return err
+	}, nil
 }
 
 // GetHeaderFooter provides a function to get worksheet header and footer by
@@ -1481,7 +1706,11 @@ func checkSheetName(name string) error {
 	return nil
 }
 
-// SetPageLayout provides a function to sets worksheet page layout.
+// SetPageLayout provides a function to sets worksheet page layout. The print
+// scaling 'AdjustTo' is restricted to value ranging from 10 to 400, and the
+// 'FitToWidth' and 'FitToHeight' are restricted to value ranging from 0 to
+// 32767, the fit to page print option of the worksheet will be enabled if any
+// of them is specified, and be disabled if only the 'AdjustTo' is specified.
 //
 // The following shows the paper size sorted by excelize index number:
 //
@@ -1647,6 +1876,11 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) error {
 		ws.newPageSetUp()
 		ws.PageSetUp.Scale = int(*opts.AdjustTo)
 	}
+	for _, fitTo := range []*int{opts.FitToHeight, opts.FitToWidth} {
+		if fitTo != nil && (*fitTo < 0 || 32767 < *fitTo) {
+			return ErrPageSetupFitTo
+		}
+	}
 	if opts.FitToHeight != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.FitToHeight = opts.FitToHeight
@@ -1655,6 +1889,15 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) error {
 		ws.newPageSetUp()
 		ws.PageSetUp.FitToWidth = opts.FitToWidth
 	}
+	if opts.FitToHeight != nil || opts.FitToWidth != nil {
+		ws.prepareSheetPr()
+		if ws.SheetPr.PageSetUpPr == nil {
+			ws.SheetPr.PageSetUpPr = new(xlsxPageSetUpPr)
+		}
+		ws.SheetPr.PageSetUpPr.FitToPage = true
+	} else if opts.AdjustTo != nil && ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil {
+		ws.SheetPr.PageSetUpPr.FitToPage = false
+	}
 	if opts.BlackAndWhite != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
@@ -1669,6 +1912,32 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) error {
 	return nil
 }
 
+// setPageSetUp set page setup settings for the chartsheet by given options.
+// The print scaling and page order settings are not applicable to the
+// chartsheet, and will be ignored.
+func (cs *xlsxChartsheet) setPageSetUp(opts *PageLayoutOptions) error {
+	if cs.PageSetup == nil {
+		cs.PageSetup = new(xlsxPageSetUp)
+	}
+	if opts.Size != nil {
+		cs.PageSetup.PaperSize = opts.Size
+	}
+	if opts.Orientation != nil {
+		if inStrSlice(supportedPageOrientation, *opts.Orientation, true) == -1 {
+			return newInvalidPageLayoutValueError("Orientation", *opts.Orientation, strings.Join(supportedPageOrientation, ", "))
+		}
+		cs.PageSetup.Orientation = *opts.Orientation
+	}
+	if opts.FirstPageNumber != nil && *opts.FirstPageNumber > 0 {
+		cs.PageSetup.FirstPageNumber = strconv.Itoa(int(*opts.FirstPageNumber))
+		cs.PageSetup.UseFirstPageNumber = true
+	}
+	if opts.BlackAndWhite != nil {
+		cs.PageSetup.BlackAndWhite = *opts.BlackAndWhite
+	}
+	return nil
+}
+
 // GetPageLayout provides a function to gets worksheet page layout.
 func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 	opts := PageLayoutOptions{
@@ -1872,7 +2141,10 @@ func (f *File) UngroupSheets() error {
 		if activeSheet == index {
 			continue
 		}
-		ws, _ := f.workSheetReader(sheet)
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			continue
+		}
 		sheetViews := ws.SheetViews.SheetView
 		for idx := range sheetViews {
 			ws.SheetViews.SheetView[idx].TabSelected = false
@@ -2099,6 +2371,9 @@ func (f *File) SetSheetDimension(sheet string, rangeRef string) error {
 	}
 	_ = sortCoordinates(coordinates)
 	ref, err := coordinatesToRangeRef(coordinates)
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		ref, err = CoordinatesToCellName(coordinates[0], coordinates[1])
+	}
 	ws.Dimension = &xlsxDimension{Ref: ref}
 	return err
 }
@@ -95,6 +95,51 @@ func TestPanes(t *testing.T) {
 		},
 	))
 	assert.EqualError(t, f.SetPanes("Panes 4", nil), ErrParameterInvalid.Error())
+	// Test set freeze panes with default top left cell, active pane and selection
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, YSplit: 1}))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft", Selection: []Selection{{SQRef: "A2", ActiveCell: "A2", Pane: "bottomLeft"}}}, panes)
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: 2, YSplit: 3}))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{Freeze: true, XSplit: 2, YSplit: 3, TopLeftCell: "C4", ActivePane: "bottomRight", Selection: []Selection{{SQRef: "C4", ActiveCell: "C4", Pane: "bottomRight"}}}, panes)
+	// Test set freeze panes with the active cell in the unfrozen pane
+	assert.NoError(t, f.SetPanes("Panes 4", &Panes{
+		Freeze: true, XSplit: 1, YSplit: 1,
+		Selection: []Selection{{Pane: "topRight"}, {Pane: "bottomLeft"}, {ActiveCell: "D10", Pane: "bottomRight"}},
+	}))
+	panes, err = f.GetPanes("Panes 4")
+	assert.NoError(t, err)
+	assert.Equal(t, []Selection{{Pane: "topRight"}, {Pane: "bottomLeft"}, {SQRef: "D10", ActiveCell: "D10", Pane: "bottomRight"}}, panes.Selection)
+	sheet, err := f.workSheetReader("Panes 4")
+	assert.NoError(t, err)
+	output, err := xml.Marshal(sheet.SheetViews)
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<selection pane="topRight"></selection><selection pane="bottomLeft"></selection><selection activeCell="D10" pane="bottomRight" sqref="D10"></selection>`)
+	// Test set freeze panes with the active cell outside of its pane
+	for _, sel := range []Selection{
+		{ActiveCell: "A1", Pane: "bottomRight"},
+		{ActiveCell: "B1", Pane: "bottomLeft"},
+		{ActiveCell: "A2", Pane: "topRight"},
+		{ActiveCell: "B2"},
+	} {
+		assert.Equal(t, newPaneSelectionError(sel.ActiveCell, map[string]string{"": "topLeft"}[sel.Pane]+sel.Pane), f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: 1, YSplit: 1, Selection: []Selection{sel}}))
+	}
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: 1, Selection: []Selection{{ActiveCell: "A"}}}))
+	// Test set panes with invalid split positions
+	for _, opts := range []*Panes{
+		{Freeze: true},
+		{Split: true, XSplit: -1, YSplit: 1800},
+		{Freeze: true, XSplit: 1, YSplit: -1},
+	} {
+		assert.Equal(t, ErrPaneSplit, f.SetPanes("Panes 4", opts))
+	}
+	// Test set panes with invalid top left cell
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetPanes("Panes 4", &Panes{Freeze: true, XSplit: 1, TopLeftCell: "A"}))
+	// Test set panes with invalid pane types
+	assert.EqualError(t, f.SetPanes("Panes 4", &Panes{Split: true, XSplit: 1, ActivePane: "right"}), "invalid pane \"right\", acceptable value should be one of bottomLeft, bottomRight, topLeft, topRight")
+	assert.Equal(t, newInvalidPaneError("left"), f.SetPanes("Panes 4", &Panes{Selection: []Selection{{SQRef: "A1", ActiveCell: "A1", Pane: "left"}}}))
 	assert.EqualError(t, f.SetPanes("SheetN", nil), "sheet SheetN does not exist")
 	// Test set panes with invalid sheet name
 	assert.EqualError(t, f.SetPanes("Sheet:1", &Panes{Freeze: false, Split: false}), ErrSheetNameInvalid.Error())
@@ -139,6 +184,56 @@ func TestPanes(t *testing.T) {
 	))
 }
 
+func TestSetSplitPanes(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPanesOptions{
+		XSplit:          3270,
+		YSplit:          1800,
+		TopLeftCell:     "B2",
+		BottomRightCell: "N57",
+		ActiveCell:      "O60",
+	}))
+	panes, err := f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		XSplit:      3270,
+		YSplit:      1800,
+		TopLeftCell: "N57",
+		ActivePane:  "bottomRight",
+		Selection: []Selection{
+			{SQRef: "N2", ActiveCell: "N2", Pane: "topRight"},
+			{SQRef: "B57", ActiveCell: "B57", Pane: "bottomLeft"},
+			{SQRef: "O60", ActiveCell: "O60", Pane: "bottomRight"},
+		},
+	}, panes)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", ws.SheetViews.SheetView[0].TopLeftCell)
+	assert.Equal(t, "", ws.SheetViews.SheetView[0].Pane.State)
+	// Test set split panes with horizontal split only
+	assert.NoError(t, f.SetSplitPanes("Sheet1", &SplitPanesOptions{YSplit: 1800}))
+	panes, err = f.GetPanes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Panes{
+		YSplit:     1800,
+		ActivePane: "bottomLeft",
+		Selection:  []Selection{{SQRef: "A1", ActiveCell: "A1", Pane: "bottomLeft"}},
+	}, panes)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSplitPanes.xlsx")))
+	// Test set split panes with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.SetSplitPanes("Sheet1", nil))
+	assert.Equal(t, ErrPaneSplit, f.SetSplitPanes("Sheet1", &SplitPanesOptions{}))
+	for _, opts := range []*SplitPanesOptions{
+		{XSplit: 1, TopLeftCell: "A"},
+		{XSplit: 1, BottomRightCell: "A"},
+		{XSplit: 1, ActiveCell: "A"},
+	} {
+		assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetSplitPanes("Sheet1", opts))
+	}
+	// Test set split panes with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.SetSplitPanes("Sheet:1", &SplitPanesOptions{XSplit: 1}))
+}
+
 func TestSearchSheet(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "SharedStrings.xlsx"))
 	if !assert.NoError(t, err) {
@@ -216,6 +311,14 @@ func TestSetPageLayout(t *testing.T) {
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *props.FitToPage)
+	// Test set page layout with print scaling only to disable fit to page
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(80)}))
+	props, err = f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, *props.FitToPage)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
@@ -224,6 +327,8 @@ func TestSetPageLayout(t *testing.T) {
 	assert.EqualError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{
 		AdjustTo: uintPtr(5),
 	}), "adjust to value must be between 10 and 400")
+	assert.Equal(t, ErrPageSetupFitTo, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToWidth: intPtr(-1)}))
+	assert.Equal(t, ErrPageSetupFitTo, f.SetPageLayout("Sheet1", &PageLayoutOptions{FitToHeight: intPtr(32768)}))
 	assert.EqualError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{
 		Orientation: stringPtr("x"),
 	}), "invalid Orientation value \"x\", acceptable value should be one of portrait, landscape")
@@ -795,6 +900,11 @@ func TestSheetDimension(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, strings.ToUpper(excepted), dimension)
 	}
+	// Test set the single-cell worksheet dimension
+	assert.NoError(t, f.SetSheetDimension(sheetName, "B2:B2"))
+	dimension, err = f.GetSheetDimension(sheetName)
+	assert.NoError(t, err)
+	assert.Equal(t, "B2", dimension)
 	// Test set the worksheet dimension with invalid range reference or no exists worksheet
 	for _, c := range []struct {
 		sheetName string
@@ -642,8 +642,8 @@ func (f *File) addDrawingSlicer(sheet, slicerName string, ns xml.Attr, opts *Sli
 		TxBody: &xdrTxBody{
 			BodyPr: &aBodyPr{VertOverflow: "clip", HorzOverflow: "clip"},
 			P: []*aP{
-				{R: &aR{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}},
-				{R: &aR{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}},
+				{R: []*aR{{T: "This shape represents a table slicer. Table slicers are not supported in this version of Excel."}}},
+				{R: []*aR{{T: "If the shape was modified in an earlier version of Excel, or if the workbook was saved in Excel 2007 or earlier, the slicer can't be used."}}},
 			},
 		},
 	}
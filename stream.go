@@ -12,15 +12,23 @@
 package excelize
 
 import (
+	"bufio"
 	"bytes"
+	"database/sql"
 	"encoding/xml"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"math"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+
+	"github.com/xuri/efp"
 )
 
 // StreamWriter defined the type of stream writer.
@@ -29,13 +37,147 @@ type StreamWriter struct {
 	Sheet           string
 	SheetID         int
 	sheetWritten    bool
-	cols            strings.Builder
+	flushed         bool
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
+	lastRow         int
+	dimension       []int
+	dimensionOffset int
 	mergeCellsCount int
 	mergeCells      strings.Builder
-	tableParts      string
+	tableParts      []string
+	colWidths       map[int]float64
+	fixedCols       []xlsxCol
+	defaultStyleID  int
+	columnTypes     []ColumnType
+	dateStyleID     int
+	date1904        bool
+	sheetHead       []byte
+	hyperlinkCols   map[int]bool
+	hyperlinkRIDs   map[string]string
+	numFmtStyles    map[cellNumFmt]int
+	rowBorders      *RowOpts
+	rowBorderStyles map[rowBorderStyle]int
+	rowColorStyles  map[rowColorStyle]int
+	hasFormula      bool
+	lastRowRef      int
+	lastDataRow     int
+	strictMerge     bool
+	validateRefs    bool
+	mergeRanges     []streamMergeRange
+	appendRows      []xlsxRow
+}
+
+// streamMergeRange defines the merged cell range tracked in the strict merged
+// cells mode of the stream writer, the late range is merged after some of its
+// rows have been written, and its covered cells will be checked on ending the
+// streaming writing.
+type streamMergeRange struct {
+	rect []int
+	late bool
+}
+
+// cellNumFmt is the key of the styles cache created for the cells with
+// custom number format codes in the stream writer.
+type cellNumFmt struct {
+	styleID int
+	numFmt  string
+}
+
+// rowBorderStyle is the key of the styles cache created for the rows and cells
+// with the row borders in the stream writer.
+type rowBorderStyle struct {
+	styleID     int
+	top, bottom RowBorderType
+	color       string
+}
+
+// rowColorStyle is the key of the styles cache created for the rows and cells
+// with the row fill and font colors in the stream writer.
+type rowColorStyle struct {
+	styleID    int
+	fill, font string
+}
+
+// streamCheckpoint defines the serialized progress of the stream writer,
+// which is used to resume the stream writer in another process.
+type streamCheckpoint struct {
+	XMLName         xml.Name                   `xml:"streamCheckpoint"`
+	TempFile        string                     `xml:"tempFile"`
+	Size            int64                      `xml:"size"`
+	Sum             uint32                     `xml:"sum"`
+	Verify          bool                       `xml:"verify"`
+	Rows            int                        `xml:"rows"`
+	LastRow         int                        `xml:"lastRow"`
+	Dimension       []int                      `xml:"dimension"`
+	DimensionOffset int                        `xml:"dimensionOffset"`
+	MergeCellsCount int                        `xml:"mergeCellsCount"`
+	MergeCells      string                     `xml:"mergeCells"`
+	AutoFitColWidth bool                       `xml:"autoFitColWidth"`
+	ColWidths       []streamCheckpointColWidth `xml:"colWidth"`
+	FixedCols       []xlsxCol                  `xml:"col"`
+	DefaultStyleID  int                        `xml:"defaultStyleID"`
+	ColumnTypes     []int                      `xml:"columnType"`
+	DateStyleID     int                        `xml:"dateStyleID"`
+	Date1904        bool                       `xml:"date1904"`
+	SheetHead       []byte                     `xml:"sheetHead"`
+	HyperlinkCols   []streamCheckpointLinkCol  `xml:"hyperlinkCol"`
+	HyperlinkRIDs   []streamCheckpointLinkRID  `xml:"hyperlinkRID"`
+	Hyperlinks      []xlsxHyperlink            `xml:"hyperlink"`
+	NumFmtStyles    []streamCheckpointStyle    `xml:"numFmtStyle"`
+	RowBorders      *RowOpts                   `xml:"rowBorders"`
+	RowBorderStyles []streamCheckpointStyle    `xml:"rowBorderStyle"`
+	RowColorStyles  []streamCheckpointStyle    `xml:"rowColorStyle"`
+	HasFormula      bool                       `xml:"hasFormula"`
+	LastRowRef      int                        `xml:"lastRowRef"`
+	LastDataRow     int                        `xml:"lastDataRow"`
+	StrictMerge     bool                       `xml:"strictMerge"`
+	ValidateRefs    bool                       `xml:"validateRefs"`
+	MergeRanges     []streamCheckpointMerge    `xml:"mergeRange"`
+	Styles          *xlsxStyleSheet            `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main styleSheet"`
+}
+
+// streamCheckpointColWidth defines the estimated width of the column in the
+// auto fit column width mode of the stream writer checkpoint.
+type streamCheckpointColWidth struct {
+	Col   int     `xml:"col,attr"`
+	Width float64 `xml:"width,attr"`
+}
+
+// streamCheckpointLinkCol defines the hyperlink column of the stream writer
+// checkpoint.
+type streamCheckpointLinkCol struct {
+	Col     int  `xml:"col,attr"`
+	Display bool `xml:"display,attr"`
+}
+
+// streamCheckpointLinkRID defines the relationship ID of the hyperlink URL of
+// the stream writer checkpoint.
+type streamCheckpointLinkRID struct {
+	Link string `xml:"link,attr"`
+	RID  string `xml:"rId,attr"`
+}
+
+// streamCheckpointStyle defines the cached style of the stream writer
+// checkpoint, which is created for the custom number format or the row
+// borders.
+type streamCheckpointStyle struct {
+	StyleID int           `xml:"styleID,attr"`
+	NumFmt  string        `xml:"numFmt,attr,omitempty"`
+	Top     RowBorderType `xml:"top,attr,omitempty"`
+	Bottom  RowBorderType `xml:"bottom,attr,omitempty"`
+	Color   string        `xml:"color,attr,omitempty"`
+	Fill    string        `xml:"fill,attr,omitempty"`
+	Font    string        `xml:"font,attr,omitempty"`
+	ID      int           `xml:"id,attr"`
+}
+
+// streamCheckpointMerge defines the merged cell range tracked in the strict
+// merged cells mode of the stream writer checkpoint.
+type streamCheckpointMerge struct {
+	Ref  string `xml:"ref,attr"`
+	Late bool   `xml:"late,attr,omitempty"`
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -46,8 +188,11 @@ type StreamWriter struct {
 // mode functions and stream mode functions can not be work mixed to writing
 // data on the worksheets. The stream writer will try to use temporary files on
 // disk to reduce the memory usage when in-memory chunks data over 16MB, and
-// you can't get cell value at this time. For example, set data for worksheet
-// of size 102400 rows x 50 columns with numbers and style:
+// you can't get cell value at this time. The stream writer writes the
+// worksheet part in the XML format, use the XLSB stream writer created by the
+// NewXLSBWriter function to write the worksheet in the binary records of the
+// XLSB workbook. For example, set data for worksheet of size 102400 rows x 50
+// columns with numbers and style:
 //
 //	f := excelize.NewFile()
 //	defer func() {
@@ -112,7 +257,14 @@ type StreamWriter struct {
 //	err := sw.SetRow("A1", []interface{}{
 //	    excelize.Cell{Value: 1}},
 //	    excelize.RowOpts{StyleID: styleID, Height: 20, Hidden: false});
-func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
+//
+// Create a stream writer which only declares the main and relationships
+// namespaces on the worksheet root element for the stricter parsers:
+//
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamOptions{
+//	    Namespaces: []xml.Attr{excelize.NameSpaceSpreadSheet, excelize.SourceRelationship},
+//	})
+func (f *File) NewStreamWriter(sheet string, opts ...StreamOptions) (*StreamWriter, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -125,23 +277,178 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 		Sheet:   sheet,
 		SheetID: sheetID,
 	}
+	options := parseStreamOptions(opts...)
+	header := xml.Header
+	if options.XMLHeader != nil {
+		if header = *options.XMLHeader; header != "" && !strings.HasPrefix(header, "<?xml ") {
+			return nil, ErrParameterInvalid
+		}
+	}
 	var err error
 	sw.worksheet, err = f.workSheetReader(sheet)
 	if err != nil {
 		return nil, err
 	}
 
+	if options.Append {
+		sw.loadAppendRows()
+	}
+
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	if f.streams == nil {
 		f.streams = make(map[string]*StreamWriter)
 	}
+	if stream, ok := f.streams[sheetXMLPath]; ok {
+		_ = stream.rawData.Close()
+	}
 	f.streams[sheetXMLPath] = sw
 
-	_, _ = sw.rawData.WriteString(xml.Header + `<worksheet` + templateNamespaceIDMap)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 2, 3)
+	if options.AutoFitColWidth {
+		sw.colWidths = make(map[int]float64)
+	}
+	sw.rawData.verify, sw.strictMerge = options.VerifyTempFile, options.StrictMergeCells
+	sw.validateRefs = options.ValidateFormulaRefs
+	_, _ = sw.rawData.WriteString(header + `<worksheet`)
+	if len(options.Namespaces) == 0 {
+		_, _ = sw.rawData.WriteString(templateNamespaceIDMap)
+	} else {
+		_, _ = sw.rawData.WriteString(" " + genXMLNamespace(options.Namespaces))
+	}
 	return sw, err
 }
 
+// NewStreamWriterForNewSheet provides a function to create a worksheet by
+// given worksheet name if it doesn't exist, and return the stream writer for
+// the worksheet. The stream options are the same as the NewStreamWriter
+// function. For example, create a stream writer for a new worksheet named
+// Sheet2:
+//
+//	sw, err := f.NewStreamWriterForNewSheet("Sheet2")
+func (f *File) NewStreamWriterForNewSheet(name string, opts ...StreamOptions) (*StreamWriter, error) {
+	if _, err := f.NewSheet(name); err != nil {
+		return nil, err
+	}
+	return f.NewStreamWriter(name, opts...)
+}
+
+// dimensionElement returns the worksheet dimension XML element by given range
+// reference, the element will be padded with spaces to the length of the
+// element with the largest range reference, so that it can be overwritten
+// with the used range of the worksheet on ending the streaming writing.
+func dimensionElement(ref string) string {
+	elem := `<dimension ref="` + ref + `"/>`
+	if pad := len(`<dimension ref="XFD1048576:XFD1048576"/>`) - len(elem); pad > 0 {
+		return elem + strings.Repeat(" ", pad)
+	}
+	return elem
+}
+
+// StreamOptions define the options for the stream writer, it can be used
+// directly in File.NewStreamWriter to specify the properties of the stream
+// writer.
+//
+// Namespaces specifies the namespace declarations and the markup
+// compatibility attributes of the worksheet root element. The stream writer
+// declares the transitional namespaces with the ignorable extension
+// namespaces by default. Note that the main spreadsheet namespace and the
+// relationships namespace should be included when the custom namespaces
+// are specified, the tables and other parts of the worksheet depend on them.
+//
+// AutoFitColWidth specifies if the stream writer tracks the widest rendered
+// cell value of each column as rows are written, and writes the computed
+// column widths on ending the streaming writing. The width is estimated by
+// the number of characters in the longest line of the formatted cell value,
+// East Asian wide characters are counted as two characters, plus the cell
+// padding of the default font, so that the result is an approximation of
+// the Excel AutoFit, which measures the rendered glyphs. The widths set by
+// the SetColWidth function take precedence over the computed widths.
+//
+// VerifyTempFile specifies if the stream writer computes a running checksum
+// of the data written to the temp file, which is used when the written data
+// exceeds the StreamChunkSize, and verifies the temp file before it is read
+// back, for example on adding a table or ending the streaming writing, the
+// ErrStreamChecksum will be returned on mismatch. Note that each verification
+// reads the whole temp file.
+//
+// XMLHeader specifies the XML declaration written at the start of the
+// worksheet part, the standard header of the encoding/xml package will be used
+// by default. Set it as an empty string to omit the XML declaration, a custom
+// declaration must start with "<?xml ". The worksheet without the declaration
+// is still a valid XML document of version 1.0 in the UTF-8 encoding, but a
+// custom declaration should not specify the encoding other than UTF-8, since
+// the worksheet is always written in UTF-8, otherwise the workbook may be
+// corrupted for the applications and tools which read the part standalone.
+//
+// StrictMergeCells specifies if the stream writer validates that only the
+// top-left cell of each merged cell range carries a value. In this mode, the
+// MergeCell and MergeCells functions return an error when the range overlaps
+// with a range merged before, and writing a value or formula on a cell
+// covered by a merged range returns an error. The cells of the ranges merged
+// after their rows have been written will be checked on ending the streaming
+// writing, which reads the written rows back.
+//
+// ValidateFormulaRefs specifies if the stream writer validates that the
+// worksheets referenced by the cell formulas exist, such as Sheet2 in the
+// formula "Sheet2!A1*2", an error will be returned if the worksheet does not
+// exist. The references to the external workbooks are not validated. The
+// formulas will be parsed on writing, so this mode is slower, and the
+// referenced worksheets should be created before writing the formulas.
+//
+// Append specifies if the stream writer keeps the existing rows of the
+// worksheet and continues writing after the last existing row, instead of
+// replacing the worksheet data. It enables the incremental snapshot exports,
+// flush the stream writer and save the workbook periodically, and then create
+// a new stream writer in append mode for the same worksheet in the same
+// session, or after reopening the saved workbook, to continue appending rows.
+// Note that:
+//
+//  1. The existing rows will be loaded into memory on creating the stream
+//     writer, and only the rows after the last existing row can be written.
+//  2. The existing merged cells, columns, hyperlinks and tables of the
+//     worksheet will be kept, the column widths set by SetColWidth should not
+//     overlap the existing columns, and the strict merged cells mode doesn't
+//     check the existing merged cells.
+//  3. The column widths in the auto fit column width mode will be estimated by
+//     the appended rows only.
+//
+// For example, continue appending rows to the saved snapshot:
+//
+//	f, err := excelize.OpenFile("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamOptions{Append: true})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	cell, err := excelize.CoordinatesToCellName(1, sw.LastRow()+1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.SetRow(cell, []interface{}{"Data"})
+type StreamOptions struct {
+	Namespaces          []xml.Attr
+	AutoFitColWidth     bool
+	VerifyTempFile      bool
+	XMLHeader           *string
+	StrictMergeCells    bool
+	ValidateFormulaRefs bool
+	Append              bool
+}
+
+// parseStreamOptions provides a function to parse the optional settings for
+// File.NewStreamWriter.
+func parseStreamOptions(opts ...StreamOptions) *StreamOptions {
+	options := &StreamOptions{}
+	for _, opt := range opts {
+		options = &opt
+	}
+	return options
+}
+
 // AddTable creates an Excel table for the StreamWriter using the given
 // cell range and format set. For example, create a table of A1:D5:
 //
@@ -161,7 +468,9 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 //	})
 //
 // Note that the table must be at least two lines including the header. The
-// header cells must contain strings and must be unique.
+// header cells must contain strings and must be unique. The table range must
+// lie within the written rows, and the header row must be written, otherwise
+// an error will be returned.
 //
 // Currently, only one table is allowed for a StreamWriter. AddTable must be
 // called after the rows are written but before Flush.
@@ -189,6 +498,14 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 		return err
 	}
 
+	// The table range must lie within the written rows
+	if coordinates[3] > sw.rows {
+		return newStreamTableRangeError(ref, sw.rows)
+	}
+	if coordinates[1] > sw.lastRow {
+		return newStreamTableHeaderError(coordinates[1])
+	}
+
 	// create table columns using the first row
 	tableHeaders, err := sw.getRowValues(coordinates[1], coordinates[0], coordinates[2])
 	if err != nil {
@@ -201,6 +518,9 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 			Name: name,
 		}
 	}
+	if err = sw.file.setTableColumnsStyle(tableColumn, options.Columns); err != nil {
+		return err
+	}
 
 	tableID := sw.file.countTables() + 1
 
@@ -239,7 +559,7 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
 	rID := sw.file.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
 
-	sw.tableParts = fmt.Sprintf(`<tableParts count="1"><tablePart r:id="rId%d"></tablePart></tableParts>`, rID)
+	sw.tableParts = append(sw.tableParts, "rId"+strconv.Itoa(rID))
 
 	if err = sw.file.addContentTypePart(tableID, "table"); err != nil {
 		return err
@@ -249,6 +569,29 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	return err
 }
 
+// AddChart provides the method to add chart in the worksheet of the
+// StreamWriter by given chart format set (such as offset, scale, aspect ratio
+// setting and print settings) and properties set, the chart series can
+// reference the cell ranges in the streamed worksheet. For example, create a
+// line chart for the data in the range A1:D5:
+//
+//	err := sw.AddChart("F1", &excelize.Chart{
+//	    Type: excelize.Line,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Name:       "Sheet1!$A$2",
+//	            Categories: "Sheet1!$B$1:$D$1",
+//	            Values:     "Sheet1!$B$2:$D$2",
+//	        },
+//	    },
+//	})
+//
+// AddChart must be called before Flush. See File.AddChart for details on the
+// chart format.
+func (sw *StreamWriter) AddChart(cell string, chart *Chart, combo ...*Chart) error {
+	return sw.file.AddChart(sw.Sheet, cell, chart, combo...)
+}
+
 // Extract values from a row in the StreamWriter.
 func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err error) {
 	res = make([]string, vCol-hCol+1)
@@ -262,15 +605,18 @@ func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err er
 	for {
 		token, err := dec.Token()
 		if err == io.EOF {
-			return res, nil
+			return nil, newStreamTableHeaderError(hRow)
 		}
 		if err != nil {
 			return nil, err
 		}
-		startElement, ok := getRowElement(token, hRow)
-		if !ok {
+		startElement, rowNum, ok := getRowElement(token)
+		if !ok || rowNum < hRow {
 			continue
 		}
+		if rowNum > hRow {
+			return nil, newStreamTableHeaderError(hRow)
+		}
 		// decode cells
 		var row xlsxRow
 		if err := dec.DecodeElement(&row, &startElement); err != nil {
@@ -290,8 +636,8 @@ func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err er
 	}
 }
 
-// Check if the token is an worksheet row with the matching row number.
-func getRowElement(token xml.Token, hRow int) (startElement xml.StartElement, ok bool) {
+// Check if the token is an worksheet row, and returns the row number.
+func getRowElement(token xml.Token) (startElement xml.StartElement, row int, ok bool) {
 	startElement, ok = token.(xml.StartElement)
 	if !ok {
 		return
@@ -305,30 +651,170 @@ func getRowElement(token xml.Token, hRow int) (startElement xml.StartElement, ok
 		if attr.Name.Local != "r" {
 			continue
 		}
-		row, _ := strconv.Atoi(attr.Value)
-		if row == hRow {
-			ok = true
-			return
-		}
+		row, _ = strconv.Atoi(attr.Value)
+		ok = true
+		return
 	}
 	return
 }
 
 // Cell can be used directly in StreamWriter.SetRow to specify a style and
-// a value.
+// a value. Set ForceText to true to write the cell value as text even if the
+// value is a number or boolean, such as the numbers with leading zeros or
+// phone numbers.
+//
+// NumFmt specifies a custom number format code for the cell, the format code
+// will be written to the workbook as is, and combined with the style of the
+// cell specified by StyleID. The builtin number formats, such as the NumFmt
+// 22 used for the time values by default, are rendered by the locale of the
+// application which opens the workbook. Use the format code with an explicit
+// locale marker to render the dates and currencies by the given locale
+// regardless of the locale of the application, for example:
+//
+//	err := sw.SetRow("A1", []interface{}{
+//	    excelize.Cell{Value: time.Now(), NumFmt: "[$-409]mmmm d, yyyy;@"},
+//	    excelize.Cell{Value: 1234.5, NumFmt: "[$€-407]#,##0.00"},
+//	})
+//
+// The stream writer creates one style for each distinct pair of StyleID and
+// NumFmt, and reuses it for the subsequent cells.
+//
+// DisplayText specifies the text to be displayed in the cell instead of the
+// numeric value, and the value will be stored in the cell as the number, so
+// that the formulas and sorting use the numeric value. The display text is
+// written as the literal custom number format, which shares the styles cache
+// with the NumFmt, so the NumFmt and DisplayText can't be used together. For
+// example, show the value in millions with the unit:
+//
+//	err := sw.SetRow("A1", []interface{}{
+//	    excelize.Cell{Value: 1520000, DisplayText: "1.52M USD"},
+//	})
+//
+// The time.Duration value without style will be written with the builtin
+// elapsed time number format, such as "[h]:mm:ss" for the durations of 24
+// hours or longer. Specify the NumFmt to display the durations in the given
+// format, for example, show the elapsed hours over 24 hours and minutes:
+//
+//	err := sw.SetRow("A1", []interface{}{
+//	    excelize.Cell{Value: 30 * time.Hour, NumFmt: "[h]:mm"},
+//	})
+//
+// RichValueID specifies the ID of the rich value returned by the AddRichValue
+// function, the value of the cell will be ignored and written as #VALUE! as
+// the fallback for the applications which not support the rich values.
+//
+// ResultType specifies the expected type of the formula result. The formula
+// cell will be written without the cell type by default, which is used for
+// the formulas that return numbers. The supported types are CellTypeUnset,
+// CellTypeNumber, CellTypeDate, CellTypeBool, CellTypeError,
+// CellTypeInlineString and CellTypeSharedString, the last two of them are
+// both written as the formula string type. The formula cell returns date
+// without style will be set with the date number format. The type of the
+// cell value will be used if the cached formula result was specified by the
+// Value. The workbook will be marked to recalculate all formulas on the next
+// load after flushing the stream writer which has written formulas, so that
+// the spreadsheet applications show the results without manual recalculation,
+// for example:
+//
+//	err := sw.SetRow("C1", []interface{}{
+//	    excelize.Cell{Formula: "A1+B1"},
+//	    excelize.Cell{Formula: "TODAY()", ResultType: excelize.CellTypeDate},
+//	    excelize.Cell{Formula: "A1>B1", ResultType: excelize.CellTypeBool},
+//	    excelize.Cell{Formula: "A1&B1", ResultType: excelize.CellTypeInlineString},
+//	})
+//
+// The Formula could contain the placeholder {LASTROW}, which will be replaced
+// with the number of the last written row on flushing the stream writer, so
+// that the formula could reference the full data extent without knowing the
+// number of rows up front. The rows which have the formulas containing the
+// placeholder are excluded, so the placeholder could be used in the totals
+// row above or below the data rows, and it will be replaced with 1 if there
+// are no other rows. For example, write the totals row above the data rows:
+//
+//	err := sw.SetRow("B1", []interface{}{
+//	    excelize.Cell{Formula: "SUM(B3:B{LASTROW})"},
+//	})
 type Cell struct {
-	StyleID int
-	Formula string
-	Value   interface{}
+	StyleID     int
+	Formula     string
+	Value       interface{}
+	ForceText   bool
+	NumFmt      string
+	DisplayText string
+	RichValueID int
+	ResultType  CellType
+}
+
+// CellError can be used directly in StreamWriter.SetRow or as the value of
+// the Cell to write an error type cell, such as "#N/A" or "#DIV/0!". The
+// value must be one of the standard error literals: #NULL!, #DIV/0!,
+// #VALUE!, #REF!, #NAME?, #NUM!, #N/A, #GETTING_DATA, #SPILL! or #CALC!.
+type CellError string
+
+// RowBorderType is the type of the row border styles for the stream writer.
+type RowBorderType byte
+
+// This section defines the currently supported row border styles enumeration.
+const (
+	RowBorderNone RowBorderType = iota
+	RowBorderThin
+	RowBorderMedium
+	RowBorderThick
+	RowBorderDouble
+)
+
+// rowBorderTypes defined the border style index of the row border styles.
+var rowBorderTypes = map[RowBorderType]int{
+	RowBorderNone:   0,
+	RowBorderThin:   1,
+	RowBorderMedium: 2,
+	RowBorderThick:  5,
+	RowBorderDouble: 6,
 }
 
 // RowOpts define the options for the set row, it can be used directly in
-// StreamWriter.SetRow to specify the style and properties of the row.
+// StreamWriter.SetRow to specify the style and properties of the row. Set
+// ValidateFirst to true to validate the row number, row options and all cells
+// of the row before writing any cell, including the cell values, the number
+// format, formula result type, formula references and rich value of the Cell,
+// and the cells covered by the merged cells in the strict merged cells mode,
+// so that an invalid cell aborts the row without emitting a partially written
+// row.
+//
+// TopBorder and BottomBorder are the shortcuts to set the top and bottom
+// border of the row and all written cells of the row without creating the
+// border style, such as the thick top border of the section-separating rows.
+// The supported border styles are RowBorderNone, RowBorderThin,
+// RowBorderMedium, RowBorderThick and RowBorderDouble. BorderColor specifies
+// the hex color of the borders, the default color is black. The borders are
+// combined with the style of the row and each cell, and the rows and cells
+// with the same style and borders share the same style ID. For example, write
+// a row with a thick top border:
+//
+//	err := sw.SetRow("A10", []interface{}{"Total", 100},
+//	    excelize.RowOpts{TopBorder: excelize.RowBorderThick})
+//
+// FillColor and FontColor are the shortcuts to set the hex background color
+// and font color of the row without creating the style, such as the status
+// colors of the rows in a report. The colors are combined with the style of
+// the row, and applied to the row and the cells of the row without an
+// explicit style ID, the cells with the style ID specified by the Cell keep
+// their own style. The rows with the same style and colors share the same
+// style ID. For example, write a row with the green background:
+//
+//	err := sw.SetRow("A11", []interface{}{"Passed", 100},
+//	    excelize.RowOpts{FillColor: "C6EFCE", FontColor: "006100"})
 type RowOpts struct {
-	Height       float64
-	Hidden       bool
-	StyleID      int
-	OutlineLevel int
+	Height        float64
+	Hidden        bool
+	StyleID       int
+	OutlineLevel  int
+	ValidateFirst bool
+	TopBorder     RowBorderType
+	BottomBorder  RowBorderType
+	BorderColor   string
+	FillColor     string
+	FontColor     string
 }
 
 // marshalAttrs prepare attributes of the row.
@@ -348,6 +834,14 @@ func (r *RowOpts) marshalAttrs() (strings.Builder, error) {
 		err = ErrOutlineLevel
 		return attrs, err
 	}
+	if _, ok := rowBorderTypes[r.TopBorder]; !ok {
+		err = ErrParameterInvalid
+		return attrs, err
+	}
+	if _, ok := rowBorderTypes[r.BottomBorder]; !ok {
+		err = ErrParameterInvalid
+		return attrs, err
+	}
 	if r.StyleID > 0 {
 		attrs.WriteString(` s="`)
 		attrs.WriteString(strconv.Itoa(r.StyleID))
@@ -384,312 +878,2521 @@ func parseRowOpts(opts ...RowOpts) *RowOpts {
 // to end the streaming writing process.
 //
 // As a special case, if Cell is used as a value, then the Cell.StyleID will be
-// applied to that cell.
+// applied to that cell. Use the CellError type value to write an error type
+// cell, for example:
+//
+//	err := sw.SetRow("A1", []interface{}{excelize.CellError("#N/A")})
+//
+// The stream can't be rolled back after the data has been written, if a cell
+// value is invalid, the cells before it will be kept in a partially written
+// row and the error will be returned. Set the RowOpts.ValidateFirst to true to
+// validate all values of the row before writing any cell, this makes SetRow
+// atomic per row at the cost of converting each value twice. For example:
+//
+//	err := sw.SetRow("A1", []interface{}{"Data", 1}, excelize.RowOpts{ValidateFirst: true})
 func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpts) error {
-	col, row, err := CellNameToCoordinates(cell)
+	return sw.setRow(cell, len(values), func(i int) interface{} { return values[i] }, opts...)
+}
+
+// FillRow writes the same value to the given number of cells of a stream row
+// by giving starting cell reference, value and the number of cells, without
+// building a slice of identical values. It works like the SetRow function,
+// the Cell can be used as the value to specify the style, formula and number
+// format of the cells, and the nil value will be skipped. For example, write a
+// separator row filled with 10 styled cells:
+//
+//	err := sw.FillRow("A1", excelize.Cell{StyleID: styleID, Value: "-"}, 10)
+func (sw *StreamWriter) FillRow(cell string, value interface{}, count int, opts ...RowOpts) error {
+	col, _, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	if row <= sw.rows {
-		return newStreamSetRowError(row)
+	if count < 0 {
+		return ErrParameterInvalid
 	}
-	sw.rows = row
-	sw.writeSheetData()
-	options := parseRowOpts(opts...)
-	attrs, err := options.marshalAttrs()
+	if value != nil && col+count-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	return sw.setRow(cell, count, func(int) interface{} { return value }, opts...)
+}
+
+// setRow provides a function to write the given number of cells to a stream
+// row by giving starting cell reference, the function to get the cell value
+// by index and the row options.
+func (sw *StreamWriter) setRow(cell string, count int, valueAt func(i int) interface{}, opts ...RowOpts) error {
+	if options := parseRowOpts(opts...); options.ValidateFirst {
+		if err := sw.validateRow(cell, options, count, valueAt); err != nil {
+			return err
+		}
+	}
+	col, row, options, err := sw.writeRowStart(cell, opts...)
 	if err != nil {
 		return err
 	}
-	_, _ = sw.rawData.WriteString(`<row r="`)
-	_, _ = sw.rawData.WriteString(strconv.Itoa(row))
-	_, _ = sw.rawData.WriteString(`"`)
-	_, _ = sw.rawData.WriteString(attrs.String())
-	_, _ = sw.rawData.WriteString(`>`)
-	for i, val := range values {
+	for i := 0; i < count; i++ {
+		val := valueAt(i)
 		if val == nil {
 			continue
 		}
 		ref, err := CoordinatesToCellName(col+i, row)
 		if err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
 			return err
 		}
-		c := xlsxC{R: ref, S: options.StyleID}
-		if v, ok := val.(Cell); ok {
-			c.S = v.StyleID
-			val = v.Value
-			setCellFormula(&c, v.Formula)
-		} else if v, ok := val.(*Cell); ok && v != nil {
-			c.S = v.StyleID
-			val = v.Value
-			setCellFormula(&c, v.Formula)
-		}
-		if err = sw.setCellValFunc(&c, val); err != nil {
-			_, _ = sw.rawData.WriteString(`</row>`)
+		c, forceText := xlsxC{R: ref, S: options.StyleID}, false
+		if v, ok := getCell(val); ok {
+			c.S, forceText = sw.getCellStyle(v.StyleID, options), v.ForceText
+			if val, err = sw.setCellOptions(&c, v); err != nil {
+				return err
+			}
+		}
+		if err = sw.writeCellValue(&c, col+i, val, forceText); err != nil {
 			return err
 		}
-		writeCell(&sw.rawData, c)
+		sw.extendDimension(col+i, row)
 	}
 	_, _ = sw.rawData.WriteString(`</row>`)
 	return sw.rawData.Sync()
 }
 
-// SetColWidth provides a function to set the width of a single column or
-// multiple columns for the StreamWriter. Note that you must call
-// the 'SetColWidth' function before the 'SetRow' function. For example set
-// the width column B:C as 20:
+// SetRowCells writes an array of Cell to stream rows by giving starting cell
+// reference and the cells. It works like the SetRow function, but accepts
+// the Cell slice directly to avoid boxing each cell into an interface, the
+// cell without style, formula and value will be skipped. Note that you must
+// call the 'Flush' function to end the streaming writing process. For
+// example:
 //
-//	err := sw.SetColWidth(2, 3, 20)
-func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
-	if sw.sheetWritten {
-		return ErrStreamSetColWidth
-	}
-	if minVal < MinColumns || minVal > MaxColumns || maxVal < MinColumns || maxVal > MaxColumns {
-		return ErrColumnNumber
+//	err := sw.SetRowCells("A1", []excelize.Cell{
+//	    {StyleID: styleID, Value: "Data"},
+//	    {Value: 1},
+//	    {Formula: "SUM(B1,B1)"},
+//	}, excelize.RowOpts{Height: 20})
+func (sw *StreamWriter) SetRowCells(cell string, cells []Cell, opts ...RowOpts) error {
+	if options := parseRowOpts(opts...); options.ValidateFirst {
+		if err := sw.validateRow(cell, options, len(cells), func(i int) interface{} {
+			return &cells[i]
+		}); err != nil {
+			return err
+		}
 	}
-	if width > MaxColumnWidth {
-		return ErrColumnWidth
+	col, row, options, err := sw.writeRowStart(cell, opts...)
+	if err != nil {
+		return err
 	}
-	if minVal > maxVal {
-		minVal, maxVal = maxVal, minVal
+	for i := range cells {
+		v := &cells[i]
+		if v.StyleID == 0 && v.Formula == "" && v.Value == nil && v.RichValueID == 0 {
+			continue
+		}
+		ref, err := CoordinatesToCellName(col+i, row)
+		if err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
+		c := xlsxC{R: ref, S: sw.getCellStyle(v.StyleID, options)}
+		val, err := sw.setCellOptions(&c, v)
+		if err != nil {
+			return err
+		}
+		if err = sw.writeCellValue(&c, col+i, val, v.ForceText); err != nil {
+			return err
+		}
+		sw.extendDimension(col+i, row)
 	}
-
-	sw.cols.WriteString(`<col min="`)
-	sw.cols.WriteString(strconv.Itoa(minVal))
-	sw.cols.WriteString(`" max="`)
-	sw.cols.WriteString(strconv.Itoa(maxVal))
-	sw.cols.WriteString(`" width="`)
-	sw.cols.WriteString(strconv.FormatFloat(width, 'f', -1, 64))
-	sw.cols.WriteString(`" customWidth="1"/>`)
-	return nil
-}
-
-// InsertPageBreak creates a page break to determine where the printed page ends
-// and where begins the next one by a given cell reference, the content before
-// the page break will be printed on one page and after the page break on
-// another.
-func (sw *StreamWriter) InsertPageBreak(cell string) error {
-	return sw.worksheet.insertPageBreak(cell)
+	_, _ = sw.rawData.WriteString(`</row>`)
+	return sw.rawData.Sync()
 }
 
-// SetPanes provides a function to create and remove freeze panes and split
-// panes by giving panes options for the StreamWriter. Note that you must call
-// the 'SetPanes' function before the 'SetRow' function.
-func (sw *StreamWriter) SetPanes(panes *Panes) error {
-	if sw.sheetWritten {
-		return ErrStreamSetPanes
+// SetStringRow writes an array of strings to stream rows by giving starting
+// cell reference and the strings. It works like the SetRow function, but
+// writes the values as string cells directly without converting each value
+// by type, which is faster for the rows contain strings only. Note that you
+// must call the 'Flush' function to end the streaming writing process. For
+// example:
+//
+//	err := sw.SetStringRow("A1", []string{"Date", "Level", "Message"},
+//	    excelize.RowOpts{StyleID: styleID})
+func (sw *StreamWriter) SetStringRow(cell string, values []string, opts ...RowOpts) error {
+	if options := parseRowOpts(opts...); options.ValidateFirst {
+		if err := sw.validateRow(cell, options, len(values), func(i int) interface{} {
+			return values[i]
+		}); err != nil {
+			return err
+		}
 	}
-	return sw.worksheet.setPanes(panes)
-}
-
-// MergeCell provides a function to merge cells by a given range reference for
-// the StreamWriter. Don't create a merged cell that overlaps with another
-// existing merged cell.
-func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
-	_, err := cellRefsToCoordinates(topLeftCell, bottomRightCell)
+	col, _, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
 	}
-	sw.mergeCellsCount++
-	_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
-	_, _ = sw.mergeCells.WriteString(topLeftCell)
-	_, _ = sw.mergeCells.WriteString(`:`)
-	_, _ = sw.mergeCells.WriteString(bottomRightCell)
-	_, _ = sw.mergeCells.WriteString(`"/>`)
-	return nil
-}
-
-// setCellFormula provides a function to set formula of a cell.
-func setCellFormula(c *xlsxC, formula string) {
-	if formula != "" {
-		c.T, c.F = "str", &xlsxF{Content: formula}
+	if col+len(values)-1 > MaxColumns {
+		return ErrColumnNumber
 	}
-}
-
-// setCellTime provides a function to set number of a cell with a time.
-func (sw *StreamWriter) setCellTime(c *xlsxC, val time.Time) error {
-	var date1904, isNum bool
-	wb, err := sw.file.workbookReader()
+	col, row, options, err := sw.writeRowStart(cell, opts...)
 	if err != nil {
 		return err
 	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
-	if isNum, err = c.setCellTime(val, date1904); err == nil && isNum && c.S == 0 {
-		style, _ := sw.file.NewStyle(&Style{NumFmt: 22})
-		c.S = style
+	for i, text := range values {
+		ref, err := CoordinatesToCellName(col+i, row)
+		if err != nil {
+			return err
+		}
+		c := xlsxC{R: ref, S: options.StyleID}
+		if col+i <= len(sw.columnTypes) && sw.columnTypes[col+i-1] != ColumnTypeAuto {
+			// Convert the value by the column type
+			if err = sw.writeCellValue(&c, col+i, text, false); err != nil {
+				return err
+			}
+			sw.extendDimension(col+i, row)
+			continue
+		}
+		quotePrefix := sw.file.options.ApostropheAsQuotePrefix && strings.HasPrefix(text, "'")
+		if quotePrefix {
+			text = text[1:]
+		}
+		c.setInlineStr(text)
+		if err = sw.writeCellWithOptions(&c, col+i, text, false, quotePrefix); err != nil {
+			return err
+		}
+		sw.extendDimension(col+i, row)
 	}
-	return nil
+	_, _ = sw.rawData.WriteString(`</row>`)
+	return sw.rawData.Sync()
 }
 
-// setCellValFunc provides a function to set value of a cell.
-func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
-	var err error
-	switch val := val.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		setCellIntFunc(c, val)
-	case float32:
-		c.setCellFloat(float64(val), -1, 32)
-	case float64:
-		c.setCellFloat(val, -1, 64)
-	case string:
+// WriteMapsOptions define the options for the StreamWriter.WriteMaps. Set
+// IgnoreUnknownKeys to true to ignore the keys of the rows which are not in
+// the header, otherwise an error will be returned.
+type WriteMapsOptions struct {
+	IgnoreUnknownKeys bool
+}
+
+// WriteMaps provides a function to write a header row and the rows produced by
+// the given iterator of maps, each value is written in the column of its key
+// in the header, and the missing keys produce blank cells. The header will be
+// written in column A of the next row after the last written row. If the
+// header is empty, it will be derived from the keys of the first row in the
+// ascending order. The row iterator has the same signature as iter.Seq in Go
+// 1.23, so that it can be a range-over-func iterator. Note that the values
+// are written by SetRow, the Cell can be used as a value to specify a style.
+// For example, write the decoded JSON array of objects:
+//
+//	var records []map[string]interface{}
+//	if err := json.Unmarshal(data, &records); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err := sw.WriteMaps([]string{"ID", "Name"}, func(yield func(map[string]interface{}) bool) {
+//	    for _, record := range records {
+//	        if !yield(record) {
+//	            return
+//	        }
+//	    }
+//	})
+func (sw *StreamWriter) WriteMaps(header []string, rows func(yield func(map[string]interface{}) bool), opts ...WriteMapsOptions) error {
+	options := &WriteMapsOptions{}
+	for _, opt := range opts {
+		options = &opt
+	}
+	var (
+		err     error
+		columns map[string]int
+	)
+	writeHeader := func() error {
+		columns = make(map[string]int, len(header))
+		values := make([]interface{}, len(header))
+		for i, key := range header {
+			columns[key], values[i] = i, key
+		}
+		cell, err := CoordinatesToCellName(1, sw.rows+1)
+		if err != nil {
+			return err
+		}
+		return sw.SetRow(cell, values)
+	}
+	if len(header) > 0 {
+		if err = writeHeader(); err != nil {
+			return err
+		}
+	}
+	rows(func(record map[string]interface{}) bool {
+		if columns == nil {
+			for key := range record {
+				header = append(header, key)
+			}
+			sort.Strings(header)
+			if err = writeHeader(); err != nil {
+				return false
+			}
+		}
+		var unknownKeys []string
+		values := make([]interface{}, len(header))
+		for key, value := range record {
+			if idx, ok := columns[key]; ok {
+				values[idx] = value
+				continue
+			}
+			unknownKeys = append(unknownKeys, key)
+		}
+		if len(unknownKeys) > 0 && !options.IgnoreUnknownKeys {
+			sort.Strings(unknownKeys)
+			err = newStreamMapKeyError(unknownKeys[0], sw.rows+1)
+			return false
+		}
+		var cell string
+		if cell, err = CoordinatesToCellName(1, sw.rows+1); err != nil {
+			return false
+		}
+		err = sw.SetRow(cell, values)
+		return err == nil
+	})
+	return err
+}
+
+// SQLExportOptions define the options for the StreamWriter.WriteSQLRows. Set
+// SkipHeader to true to skip writing the header row of the column names, and
+// HeaderStyleID specifies the style of the header row. The Converter will be
+// called with the column type and the scanned value of each cell if it was
+// set, it can be used to convert the driver-specific types to the supported
+// cell value types, the converted nil value will be skipped.
+type SQLExportOptions struct {
+	SkipHeader    bool
+	HeaderStyleID int
+	Converter     func(column *sql.ColumnType, value interface{}) (interface{}, error)
+}
+
+// WriteSQLRows provides a function to write the result set of the database
+// query to stream rows by giving starting cell reference, the rows and export
+// options. The header row will be written with the column names first, and
+// then each row of the result set will be written by SetRow. The driver
+// values of type int64, float64, bool, []byte, string and time.Time will be
+// written as the corresponding cell types, the NULL value will be written as
+// a blank cell. Note that the rows will not be closed by this function. For
+// example:
+//
+//	rows, err := db.Query("SELECT id, name, created_at FROM users")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer rows.Close()
+//	err = sw.WriteSQLRows("A1", rows, excelize.SQLExportOptions{})
+func (sw *StreamWriter) WriteSQLRows(startCell string, rows *sql.Rows, opts SQLExportOptions) error {
+	if rows == nil {
+		return ErrParameterInvalid
+	}
+	col, row, err := CellNameToCoordinates(startCell)
+	if err != nil {
+		return err
+	}
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	writeRow := func(opts ...RowOpts) error {
+		cell, err := CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		row++
+		return sw.SetRow(cell, values, opts...)
+	}
+	if !opts.SkipHeader {
+		for i, column := range columns {
+			values[i] = column.Name()
+		}
+		if err = writeRow(RowOpts{StyleID: opts.HeaderStyleID}); err != nil {
+			return err
+		}
+	}
+	dest := make([]interface{}, len(columns))
+	for i := range dest {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		for i := range values {
+			values[i] = nil
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+		if opts.Converter != nil {
+			for i, column := range columns {
+				if values[i], err = opts.Converter(column, values[i]); err != nil {
+					return err
+				}
+			}
+		}
+		if err = writeRow(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// LastRow provides a function to get the number of the last row which has been
+// written or skipped in the stream, including the existing rows loaded in the
+// append mode, the subsequent SetRow must start after this row.
+func (sw *StreamWriter) LastRow() int {
+	return sw.rows
+}
+
+// KeepInMemory provides a function to move the written data of the stream
+// writer from the temp file into memory and remove the temp file, and keep
+// the subsequent data in memory without using the temp file. The SetRow and
+// SetRowCells function return the ErrStreamTempFile error if the temp file
+// can't be created or written, such as the disk is full, the row has been
+// written in memory, and the partially written temp file is removed with its
+// data moved into memory. Use this function to continue writing in memory, or
+// call the Close function of the workbook to abort the stream writer. The
+// stream writer can't be recovered if the error is returned by the Flush
+// function.
+// For example:
+//
+//	err := sw.SetRow(cell, row)
+//	var tempErr excelize.ErrStreamTempFile
+//	if errors.As(err, &tempErr) {
+//	    err = sw.KeepInMemory()
+//	}
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+func (sw *StreamWriter) KeepInMemory() error {
+	return sw.rawData.keepInMemory()
+}
+
+// SkipRows provides a function to reserve the given number of blank rows after
+// the last written row in the stream, the subsequent SetRow must start after
+// the skipped rows. For example, leave 2 blank rows after the row 5 which has
+// been written, the next row should start from the row 8:
+//
+//	err := sw.SkipRows(2)
+func (sw *StreamWriter) SkipRows(n int) error {
+	if n < 0 {
+		return ErrParameterInvalid
+	}
+	if sw.rows+n > TotalRows {
+		return ErrMaxRows
+	}
+	sw.rows += n
+	return nil
+}
+
+// validateRow provides a function to validate the row number, row options and
+// all cells of the row by given starting cell reference, row options, the
+// number of cells and the function to get the cell value by index without
+// writing anything to the stream. The number format, formula and rich value
+// settings of the Cell values, and the merged cells in the strict merged cells
+// mode are checked in the same way as writing the row.
+func (sw *StreamWriter) validateRow(cell string, options *RowOpts, count int, valueAt func(i int) interface{}) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if row <= sw.rows {
+		return newStreamSetRowError(row)
+	}
+	if _, err = options.marshalAttrs(); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		val := valueAt(i)
+		if val == nil {
+			continue
+		}
+		c := xlsxC{S: options.StyleID}
+		if c.R, err = CoordinatesToCellName(col+i, row); err != nil {
+			return err
+		}
+		if v, ok := getCell(val); ok {
+			if val, err = sw.checkCellOptions(&c, v); err != nil {
+				return err
+			}
+		}
+		if err = sw.setCellTypedValFunc(&c, col+i, val); err != nil {
+			return err
+		}
+		if sw.strictMerge {
+			if err = checkMergedCellValue(&c, col+i, row, sw.mergeRanges); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// writeRowStart provides a function to check the row number and write the
+// row XML start element by given starting cell reference and row options.
+func (sw *StreamWriter) writeRowStart(cell string, opts ...RowOpts) (int, int, *RowOpts, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return col, row, nil, err
+	}
+	if row <= sw.rows {
+		return col, row, nil, newStreamSetRowError(row)
+	}
+	sw.rows = row
+	sw.writeSheetData()
+	options := parseRowOpts(opts...)
+	options.StyleID = sw.styleOrDefault(options.StyleID)
+	attrs, err := options.marshalAttrs()
+	if err != nil {
+		return col, row, options, err
+	}
+	if options.FillColor != "" || options.FontColor != "" {
+		if options.StyleID, err = sw.getRowColorStyle(options.StyleID, options); err != nil {
+			return col, row, options, err
+		}
+		attrs, _ = options.marshalAttrs()
+	}
+	sw.rowBorders = nil
+	if options.TopBorder != RowBorderNone || options.BottomBorder != RowBorderNone {
+		sw.rowBorders = options
+		rowOpts := *options
+		if rowOpts.StyleID, err = sw.getRowBorderStyle(options.StyleID); err != nil {
+			return col, row, options, err
+		}
+		attrs, _ = rowOpts.marshalAttrs()
+	}
+	sw.trackLastDataRow()
+	sw.lastRow = row
+	_, _ = sw.rawData.WriteString(`<row r="`)
+	_, _ = sw.rawData.WriteString(strconv.Itoa(row))
+	_, _ = sw.rawData.WriteString(`"`)
+	_, _ = sw.rawData.WriteString(attrs.String())
+	_, _ = sw.rawData.WriteString(`>`)
+	return col, row, options, err
+}
+
+// extendDimension provides a function to extend the used range of the
+// worksheet by given written cell coordinates.
+func (sw *StreamWriter) extendDimension(col, row int) {
+	if sw.dimension == nil {
+		sw.dimension = []int{col, row, col, row}
+		return
+	}
+	if col < sw.dimension[0] {
+		sw.dimension[0] = col
+	}
+	if col > sw.dimension[2] {
+		sw.dimension[2] = col
+	}
+	sw.dimension[3] = row
+}
+
+// writeCellValue provides a function to set the value of a cell and write the
+// cell XML to the buffer, the row XML element will be closed on error.
+func (sw *StreamWriter) writeCellValue(c *xlsxC, col int, val interface{}, forceText bool) error {
+	text, ok := val.(string)
+	quotePrefix := ok && c.F == nil && sw.file.options.ApostropheAsQuotePrefix && strings.HasPrefix(text, "'")
+	if quotePrefix {
+		val = text[1:]
+	}
+	if err := sw.setCellTypedValFunc(c, col, val); err != nil {
+		_, _ = sw.rawData.WriteString(`</row>`)
+		return err
+	}
+	link, _ := val.(string)
+	return sw.writeCellWithOptions(c, col, link, forceText, quotePrefix)
+}
+
+// writeCellWithOptions provides a function to apply the merged cells checking,
+// force text, row borders, quote prefix, column width tracking and hyperlink
+// settings of the stream writer to the cell which value has been set, and
+// write the cell into the stream row.
+func (sw *StreamWriter) writeCellWithOptions(c *xlsxC, col int, link string, forceText, quotePrefix bool) error {
+	if sw.strictMerge {
+		if err := checkMergedCellValue(c, col, sw.lastRow, sw.mergeRanges); err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
+	}
+	if forceText {
+		setCellForceText(c)
+	}
+	if sw.rowBorders != nil {
+		var err error
+		if c.S, err = sw.getRowBorderStyle(c.S); err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
+	}
+	if quotePrefix {
+		var err error
+		if c.S, err = sw.file.getQuotePrefixStyle(c.S); err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
+	}
+	if sw.colWidths != nil {
+		sw.trackColWidth(c)
+	}
+	if sw.hyperlinkCols != nil {
+		if err := sw.setCellHyperlink(c, link); err != nil {
+			_, _ = sw.rawData.WriteString(`</row>`)
+			return err
+		}
+	}
+	writeCell(&sw.rawData, *c)
+	return nil
+}
+
+// SetColHyperlink provides a function to turn the non-empty string values in
+// the given column number into external hyperlinks for the StreamWriter, the
+// link target of each hyperlink is the cell value. The display text of the
+// hyperlink will be set as the cell value if the display is true. It affects
+// the rows written after calling this function. For example, turn the URLs in
+// column B into hyperlinks:
+//
+//	err := sw.SetColHyperlink(2, true)
+//
+// Each distinct URL adds one relationship in the worksheet relationships part,
+// and the identical URLs share the same relationship ID, so a high-cardinality
+// column consumes one relationship ID per distinct value. The hyperlinks are
+// kept in memory until the 'Flush' function is called, and a worksheet can
+// contain at most 65530 hyperlinks.
+func (sw *StreamWriter) SetColHyperlink(col int, display bool) error {
+	if col < MinColumns || col > MaxColumns {
+		return ErrColumnNumber
+	}
+	if sw.hyperlinkCols == nil {
+		sw.hyperlinkCols, sw.hyperlinkRIDs = make(map[int]bool), make(map[string]string)
+	}
+	sw.hyperlinkCols[col] = display
+	return nil
+}
+
+// setCellHyperlink provides a function to add the external hyperlink for the
+// cell in the hyperlink column by given cell value.
+func (sw *StreamWriter) setCellHyperlink(c *xlsxC, link string) error {
+	if link == "" {
+		return nil
+	}
+	col, _, err := CellNameToCoordinates(c.R)
+	if err != nil {
+		return err
+	}
+	display, ok := sw.hyperlinkCols[col]
+	if !ok {
+		return nil
+	}
+	if sw.worksheet.Hyperlinks == nil {
+		sw.worksheet.Hyperlinks = new(xlsxHyperlinks)
+	}
+	if len(sw.worksheet.Hyperlinks.Hyperlink) > TotalSheetHyperlinks {
+		return ErrTotalSheetHyperlinks
+	}
+	rID, ok := sw.hyperlinkRIDs[link]
+	if !ok {
+		sheetPath := sw.file.sheetMap[sw.Sheet]
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+		rID = "rId" + strconv.Itoa(sw.file.addRels(sheetRels, SourceRelationshipHyperLink, link, "External"))
+		sw.hyperlinkRIDs[link] = rID
+	}
+	linkData := xlsxHyperlink{Ref: c.R, RID: rID}
+	if display {
+		linkData.Display = link
+	}
+	sw.worksheet.Hyperlinks.Hyperlink = append(sw.worksheet.Hyperlinks.Hyperlink, linkData)
+	return nil
+}
+
+// trackColWidth provides a function to update the widest estimated width of
+// the column by given cell for the auto fit column width mode.
+func (sw *StreamWriter) trackColWidth(c *xlsxC) {
+	col, _, err := CellNameToCoordinates(c.R)
+	if err != nil {
+		return
+	}
+	var text string
+	switch c.T {
+	case "inlineStr":
+		if c.IS != nil {
+			if c.IS.T != nil {
+				text = c.IS.T.Val
+			}
+			for _, r := range c.IS.R {
+				if r.T != nil {
+					text += r.T.Val
+				}
+			}
+		}
+	case "b":
+		text = "FALSE"
+		if c.V == "1" {
+			text = "TRUE"
+		}
+	case "e", "str":
+		text = c.V
+	default:
+		text, _ = sw.file.formattedValue(c, false, CellTypeNumber)
+	}
+	if width := estimateColWidth(text); width > sw.colWidths[col] {
+		sw.colWidths[col] = width
+	}
+}
+
+// estimateColWidth returns the approximate column width in characters of the
+// default font to display the given text. The width of the longest line is
+// counted in characters, East Asian wide characters are counted as two
+// characters, and the cell padding is added to the result.
+func estimateColWidth(text string) float64 {
+	var width, lineWidth float64
+	for _, r := range text {
+		if r == '\n' {
+			lineWidth = 0
+			continue
+		}
+		lineWidth++
+		if unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana) ||
+			(r >= 0xFF01 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6) {
+			lineWidth++
+		}
+		if lineWidth > width {
+			width = lineWidth
+		}
+	}
+	if width == 0 {
+		return 0
+	}
+	if width += 0.71; width > MaxColumnWidth {
+		return MaxColumnWidth
+	}
+	return width
+}
+
+// SetColWidth provides a function to set the width of a single column or
+// multiple columns for the StreamWriter. Note that you must call
+// the 'SetColWidth' function before the 'SetRow' function. For example set
+// the width column B:C as 20:
+//
+//	err := sw.SetColWidth(2, 3, 20)
+func (sw *StreamWriter) SetColWidth(minVal, maxVal int, width float64) error {
+	if sw.sheetWritten {
+		return ErrStreamSetColWidth
+	}
+	if minVal < MinColumns || minVal > MaxColumns || maxVal < MinColumns || maxVal > MaxColumns {
+		return ErrColumnNumber
+	}
+	if width > MaxColumnWidth {
+		return ErrColumnWidth
+	}
+	if minVal > maxVal {
+		minVal, maxVal = maxVal, minVal
+	}
+	col := xlsxCol{Min: minVal, Max: maxVal, Width: float64Ptr(width), CustomWidth: true}
+	sw.fixedCols = append(sw.fixedCols, col)
+	return nil
+}
+
+// SetDefaultStyle provides a function to set the default style of the
+// worksheet for the StreamWriter by given style ID, such as set a base font for
+// all cells without stamping the style on each cell. Note that you must call
+// the 'SetDefaultStyle' function before the 'SetRow' function. The default
+// style will be applied to the columns and the written rows, and the written
+// cells without style. The StyleID of the RowOpts, Cell or the cell style
+// specified by the number format overrides the default style, and the cell
+// with StyleID 0 always uses the default style. For example, set the font of
+// all cells as Calibri 10:
+//
+//	styleID, err := f.NewStyle(&excelize.Style{
+//	    Font: &excelize.Font{Family: "Calibri", Size: 10},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.SetDefaultStyle(styleID)
+func (sw *StreamWriter) SetDefaultStyle(styleID int) error {
+	if sw.sheetWritten {
+		return ErrStreamSetDefaultStyle
+	}
+	s, err := sw.file.stylesReader()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+		return newInvalidStyleID(styleID)
+	}
+	sw.defaultStyleID = styleID
+	return nil
+}
+
+// styleOrDefault returns the default style ID of the worksheet if the given
+// style ID is 0.
+func (sw *StreamWriter) styleOrDefault(styleID int) int {
+	if styleID == 0 {
+		return sw.defaultStyleID
+	}
+	return styleID
+}
+
+// getCellStyle provides a function to get the style ID of the cell by given
+// style ID of the Cell and the row options, the cells without the style ID in
+// the row with the fill or font colors use the style of the row.
+func (sw *StreamWriter) getCellStyle(styleID int, opts *RowOpts) int {
+	if styleID == 0 && (opts.FillColor != "" || opts.FontColor != "") {
+		return opts.StyleID
+	}
+	return sw.styleOrDefault(styleID)
+}
+
+// defaultStyleCols provides a function to set the default style for the
+// given columns in ascending order, and fill the gaps between them by the
+// columns with default width, so that all columns use the default style.
+func (sw *StreamWriter) defaultStyleCols(cols []xlsxCol) []xlsxCol {
+	var result []xlsxCol
+	next := MinColumns
+	for _, c := range cols {
+		if c.Min > next {
+			result = append(result, xlsxCol{Min: next, Max: c.Min - 1, Width: float64Ptr(defaultColWidth), Style: sw.defaultStyleID})
+		}
+		c.Style = sw.defaultStyleID
+		result = append(result, c)
+		if c.Max >= next {
+			next = c.Max + 1
+		}
+	}
+	if next <= MaxColumns {
+		result = append(result, xlsxCol{Min: next, Max: MaxColumns, Width: float64Ptr(defaultColWidth), Style: sw.defaultStyleID})
+	}
+	return result
+}
+
+// writeCols provides a function to write the columns element by given
+// columns in ascending order, the adjacent columns with the same width and
+// style will be merged into one column range.
+func (sw *StreamWriter) writeCols(rawData *bufferedWriter, cols []xlsxCol) {
+	sort.SliceStable(cols, func(i, j int) bool { return cols[i].Min < cols[j].Min })
+	if sw.defaultStyleID > 0 {
+		cols = sw.defaultStyleCols(cols)
+	}
+	if len(cols) == 0 {
+		return
+	}
+	_, _ = rawData.WriteString("<cols>")
+	for _, c := range coalesceCols(cols) {
+		_, _ = rawData.WriteString(colElement(c))
+	}
+	_, _ = rawData.WriteString("</cols>")
+}
+
+// coalesceCols provides a function to merge the adjacent columns in ascending
+// order with the same attributes into one column range.
+func coalesceCols(cols []xlsxCol) []xlsxCol {
+	var result []xlsxCol
+	for _, c := range cols {
+		if n := len(result); n > 0 && result[n-1].Max+1 == c.Min {
+			last, next := result[n-1], c
+			last.Min, last.Max, last.Width = 0, 0, nil
+			next.Min, next.Max, next.Width = 0, 0, nil
+			if last == next && *result[n-1].Width == *c.Width {
+				result[n-1].Max = c.Max
+				continue
+			}
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// colElement returns the column XML element by given column range and width.
+func colElement(c xlsxCol) string {
+	var style, customWidth string
+	if c.Style > 0 {
+		style = `" style="` + strconv.Itoa(c.Style)
+	}
+	if c.CustomWidth {
+		customWidth = ` customWidth="1"`
+	}
+	return `<col min="` + strconv.Itoa(c.Min) + `" max="` + strconv.Itoa(c.Max) +
+		`" width="` + strconv.FormatFloat(*c.Width, 'f', -1, 64) + style + `"` + customWidth + `/>`
+}
+
+// InsertPageBreak creates a page break to determine where the printed page ends
+// and where begins the next one by a given cell reference, the content before
+// the page break will be printed on one page and after the page break on
+// another.
+func (sw *StreamWriter) InsertPageBreak(cell string) error {
+	return sw.worksheet.insertPageBreak(cell)
+}
+
+// SetPrintOptions provides a function to set the print options of the
+// worksheet for the StreamWriter, it specifies whether print the gridlines and
+// the row and column headings, and whether center the data on the printed
+// page horizontally and vertically. Note that you must call the
+// 'SetPrintOptions' function before the 'Flush' function. For example, print
+// the gridlines and headings:
+//
+//	err := sw.SetPrintOptions(true, true, false, false)
+func (sw *StreamWriter) SetPrintOptions(gridLines, headings, horizontalCentered, verticalCentered bool) error {
+	if sw.flushed {
+		return ErrStreamSetPrintOptions
+	}
+	if !gridLines && !headings && !horizontalCentered && !verticalCentered {
+		sw.worksheet.PrintOptions = nil
+		return nil
+	}
+	sw.worksheet.PrintOptions = &xlsxPrintOptions{
+		GridLines:          gridLines,
+		Headings:           headings,
+		HorizontalCentered: horizontalCentered,
+		VerticalCentered:   verticalCentered,
+	}
+	return nil
+}
+
+// SetPageLayout provides a function to set the page layout of the worksheet
+// for the StreamWriter, such as the paper size, orientation and print scaling.
+// The fit to page print option of the worksheet will be enabled if the
+// 'FitToWidth' or 'FitToHeight' is specified. Note that you must call the
+// 'SetPageLayout' function before the 'SetRow' function. For example, print
+// the worksheet on a single page:
+//
+//	pages := 1
+//	err := sw.SetPageLayout(&excelize.PageLayoutOptions{
+//	    FitToWidth:  &pages,
+//	    FitToHeight: &pages,
+//	})
+func (sw *StreamWriter) SetPageLayout(opts *PageLayoutOptions) error {
+	if sw.sheetWritten {
+		return ErrStreamSetPageLayout
+	}
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	return sw.worksheet.setPageSetUp(opts)
+}
+
+// SetPanes provides a function to create and remove freeze panes and split
+// panes by giving panes options for the StreamWriter. Note that you must call
+// the 'SetPanes' function before the 'SetRow' function.
+func (sw *StreamWriter) SetPanes(panes *Panes) error {
+	if sw.sheetWritten {
+		return ErrStreamSetPanes
+	}
+	return sw.worksheet.setPanes(panes)
+}
+
+// SetOutlineSummary provides a function to set the position of the summary
+// rows and columns of the outline for the StreamWriter. The summary rows are
+// below the detail rows if the 'below' is true, otherwise above them, and the
+// summary columns are to the right of the detail columns if the 'right' is
+// true, otherwise to the left of them. By default, the summary rows are below
+// the detail rows, and the summary columns are to the right of the detail
+// columns. Note that you must call the 'SetOutlineSummary' function before the
+// 'SetRow' function. For example, set the summary rows above the grouped
+// detail rows:
+//
+//	err := sw.SetOutlineSummary(false, true)
+func (sw *StreamWriter) SetOutlineSummary(below, right bool) error {
+	if sw.sheetWritten {
+		return ErrStreamSetOutlineSummary
+	}
+	if sw.worksheet.SheetPr == nil {
+		sw.worksheet.SheetPr = new(xlsxSheetPr)
+	}
+	sw.worksheet.SheetPr.OutlinePr = &xlsxOutlinePr{SummaryBelow: boolPtr(below), SummaryRight: boolPtr(right)}
+	return nil
+}
+
+// SetSheetView provides a function to set the options of the last sheet view
+// for the StreamWriter, such as hide zero values by the 'ShowZeros' option.
+// Note that you must call the 'SetSheetView' function before the 'SetRow'
+// function. For example, suppress the display of zero values:
+//
+//	hide := false
+//	err := sw.SetSheetView(&excelize.ViewOptions{ShowZeros: &hide})
+func (sw *StreamWriter) SetSheetView(opts *ViewOptions) error {
+	if sw.sheetWritten {
+		return ErrStreamSetSheetView
+	}
+	if opts == nil {
+		return ErrParameterInvalid
+	}
+	if sw.worksheet.SheetViews == nil || len(sw.worksheet.SheetViews.SheetView) == 0 {
+		sw.worksheet.SheetViews = &xlsxSheetViews{SheetView: []xlsxSheetView{{}}}
+	}
+	sw.worksheet.SheetViews.SheetView[len(sw.worksheet.SheetViews.SheetView)-1].setSheetView(opts)
+	return nil
+}
+
+// SetSheetState provides a function to set the visible state of the worksheet
+// for the StreamWriter, the state will be written to the workbook, so that it
+// can be called at any time before the workbook is saved, even after Flush.
+// The available states are "visible", "hidden" and "veryHidden". A workbook
+// must contain at least one visible worksheet, and the state of the activated
+// worksheet will not be changed, the same as File.SetSheetVisible. Set the
+// tab color with File.SetSheetProps before creating the stream writer if
+// needed. For example, hide the lookup worksheet:
+//
+//	err := sw.SetSheetState("veryHidden")
+func (sw *StreamWriter) SetSheetState(state string) error {
+	switch state {
+	case "visible":
+		return sw.file.SetSheetVisible(sw.Sheet, true)
+	case "hidden", "veryHidden":
+		return sw.file.SetSheetVisible(sw.Sheet, false, state == "veryHidden")
+	}
+	return ErrSheetState
+}
+
+// MergeCell provides a function to merge cells by a given range reference for
+// the StreamWriter. Don't create a merged cell that overlaps with another
+// existing merged cell.
+func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
+	coordinates, err := cellRefsToCoordinates(topLeftCell, bottomRightCell)
+	if err != nil {
+		return err
+	}
+	if sw.strictMerge {
+		_ = sortCoordinates(coordinates)
+		if err = sw.trackMergeRanges([]string{topLeftCell + ":" + bottomRightCell}, [][]int{coordinates}); err != nil {
+			return err
+		}
+	}
+	sw.mergeCellsCount++
+	_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+	_, _ = sw.mergeCells.WriteString(topLeftCell)
+	_, _ = sw.mergeCells.WriteString(`:`)
+	_, _ = sw.mergeCells.WriteString(bottomRightCell)
+	_, _ = sw.mergeCells.WriteString(`"/>`)
+	return nil
+}
+
+// SetConditionalFormat provides a function to set conditional formatting rules
+// for the cell range by given range reference and the conditional format
+// settings in the StreamWriter. It supports the same rule types and settings
+// as the SetConditionalFormat function of the File, such as the icon sets
+// with custom thresholds, the conditional formats and the worksheet extension
+// list which required by the rules will be created, and the rules will be
+// written on ending the streaming writing. Note that the x14 namespace should
+// be included when the custom namespaces of the stream writer are specified
+// for the data bar rules. For example, show the 3 arrows icon set for the
+// cell range A2:A100, with the down arrow for the values less than 0 and the
+// up arrow for the values greater than 100:
+//
+//	err := sw.SetConditionalFormat("A2:A100",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:      "icon_set",
+//	            IconStyle: "3Arrows",
+//	            IconThresholds: []excelize.ConditionalFormatIconThreshold{
+//	                {Type: "num", Value: "0"},
+//	                {Type: "num", Value: "100", GreaterThan: true},
+//	            },
+//	        },
+//	    },
+//	)
+func (sw *StreamWriter) SetConditionalFormat(rangeRef string, opts []ConditionalFormatOptions) error {
+	return sw.file.SetConditionalFormat(sw.Sheet, rangeRef, opts)
+}
+
+// MergeCells provides a function to merge cells by the given range references
+// for the StreamWriter, such as "A1:B1". It works like the MergeCell function
+// but validates all the ranges and checks the ranges don't overlap each other
+// before merging any of them, and returns the error with the first
+// conflicting pair of ranges in the given order. Note that the ranges merged
+// by previous calls are not checked, unless the StrictMergeCells option of the
+// stream writer is enabled. For example:
+//
+//	err := sw.MergeCells([]string{"A1:C1", "D1:F1", "A2:A3"})
+func (sw *StreamWriter) MergeCells(ranges []string) error {
+	rects := make([][]int, len(ranges))
+	for i, ref := range ranges {
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		rects[i] = coordinates
+	}
+	order := make([]int, len(rects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return rects[order[i]][1] < rects[order[j]][1] })
+	first, second := -1, -1
+	for i, idx := range order {
+		for _, next := range order[i+1:] {
+			if rects[next][1] > rects[idx][3] {
+				break
+			}
+			if !isOverlap(rects[idx], rects[next]) {
+				continue
+			}
+			a, b := idx, next
+			if a > b {
+				a, b = b, a
+			}
+			if second == -1 || b < second || (b == second && a < first) {
+				first, second = a, b
+			}
+		}
+	}
+	if second != -1 {
+		return newStreamMergeCellOverlapError(ranges[first], ranges[second])
+	}
+	if sw.strictMerge {
+		if err := sw.trackMergeRanges(ranges, rects); err != nil {
+			return err
+		}
+	}
+	for _, rect := range rects {
+		ref, _ := coordinatesToRangeRef(rect)
+		_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+		_, _ = sw.mergeCells.WriteString(ref)
+		_, _ = sw.mergeCells.WriteString(`"/>`)
+	}
+	sw.mergeCellsCount += len(rects)
+	return nil
+}
+
+// trackMergeRanges provides a function to check the given merged cell ranges
+// don't overlap with the ranges merged before in the strict merged cells mode
+// of the stream writer, and track the given ranges.
+func (sw *StreamWriter) trackMergeRanges(refs []string, rects [][]int) error {
+	for i, rect := range rects {
+		for _, mergeRange := range sw.mergeRanges {
+			if isOverlap(mergeRange.rect, rect) {
+				ref, _ := coordinatesToRangeRef(mergeRange.rect)
+				return newStreamMergeCellOverlapError(ref, refs[i])
+			}
+		}
+	}
+	for _, rect := range rects {
+		sw.mergeRanges = append(sw.mergeRanges, streamMergeRange{rect: rect, late: rect[1] <= sw.lastRow})
+	}
+	return nil
+}
+
+// checkMergedCellValue provides a function to check the cell with value or
+// formula is not covered by the given merged cell ranges in the strict merged
+// cells mode of the stream writer, only the top-left cell of the merged cell
+// range could carry a value.
+func checkMergedCellValue(c *xlsxC, col, row int, ranges []streamMergeRange) error {
+	if c.V == "" && c.IS == nil && c.F == nil {
+		return nil
+	}
+	for _, mergeRange := range ranges {
+		rect := mergeRange.rect
+		if cellInRange([]int{col, row}, rect) && (col != rect[0] || row != rect[1]) {
+			ref, _ := coordinatesToRangeRef(rect)
+			return newStreamMergeCellValueError(c.R, ref)
+		}
+	}
+	return nil
+}
+
+// checkLateMergeRanges provides a function to read the written rows back and
+// check the cells covered by the ranges merged after their rows have been
+// written in the strict merged cells mode of the stream writer.
+func (sw *StreamWriter) checkLateMergeRanges() error {
+	var late []streamMergeRange
+	for _, mergeRange := range sw.mergeRanges {
+		if mergeRange.late {
+			late = append(late, mergeRange)
+		}
+	}
+	if len(late) == 0 {
+		return nil
+	}
+	r, err := sw.rawData.Reader()
+	if err != nil {
+		return err
+	}
+	dec := sw.file.xmlNewDecoder(r)
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if endElement, ok := token.(xml.EndElement); ok && endElement.Name.Local == "sheetData" {
+			return nil
+		}
+		startElement, rowNum, ok := getRowElement(token)
+		if !ok {
+			continue
+		}
+		var row xlsxRow
+		if err := dec.DecodeElement(&row, &startElement); err != nil {
+			return err
+		}
+		for i := range row.C {
+			col, _, err := CellNameToCoordinates(row.C[i].R)
+			if err != nil {
+				return err
+			}
+			if err = checkMergedCellValue(&row.C[i], col, rowNum, late); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// getCell provides a function to get the Cell by given value of the stream
+// row, the value could be the Cell or the non-nil pointer of the Cell.
+func getCell(val interface{}) (*Cell, bool) {
+	if v, ok := val.(Cell); ok {
+		return &v, true
+	}
+	if v, ok := val.(*Cell); ok && v != nil {
+		return v, true
+	}
+	return nil, false
+}
+
+// setCellOptions provides a function to set the number format, formula and
+// rich value of a cell by given Cell, and returns the value to be written in
+// the cell. The row XML element will be closed on error.
+func (sw *StreamWriter) setCellOptions(c *xlsxC, v *Cell) (interface{}, error) {
+	val, err := v.Value, sw.setCellNumFmt(c, v.NumFmt, v.DisplayText)
+	if err == nil {
+		err = sw.setCellFormula(c, v.Formula, v.ResultType)
+	}
+	if err == nil {
+		val, err = sw.setCellRichValue(c, v.RichValueID, v.Value)
+	}
+	if err != nil {
+		_, _ = sw.rawData.WriteString(`</row>`)
+	}
+	return val, err
+}
+
+// checkCellOptions provides a function to check the number format, formula
+// and rich value settings of the given Cell without writing anything to the
+// stream, and returns the value to be written in the cell.
+func (sw *StreamWriter) checkCellOptions(c *xlsxC, v *Cell) (interface{}, error) {
+	if v.NumFmt != "" && v.DisplayText != "" {
+		return nil, ErrParameterInvalid
+	}
+	if v.Formula != "" {
+		if _, ok := formulaResultTypes[v.ResultType]; !ok {
+			return nil, ErrParameterInvalid
+		}
+		if sw.validateRefs {
+			if err := sw.checkFormulaSheetRefs(c.R, v.Formula); err != nil {
+				return nil, err
+			}
+		}
+		c.F = &xlsxF{Content: v.Formula}
+	}
+	if v.RichValueID == 0 {
+		return v.Value, nil
+	}
+	if v.RichValueID < 0 || v.RichValueID > len(sw.file.richValues) {
+		return nil, ErrParameterInvalid
+	}
+	return CellError(formulaErrorVALUE), nil
+}
+
+// formulaResultTypes defined the cell types of the formula cells by the
+// expected types of the formula result.
+var formulaResultTypes = map[CellType]string{
+	CellTypeUnset:        "",
+	CellTypeNumber:       "",
+	CellTypeDate:         "",
+	CellTypeBool:         "b",
+	CellTypeError:        "e",
+	CellTypeInlineString: "str",
+	CellTypeSharedString: "str",
+}
+
+// setCellFormula provides a function to set formula of a cell, and set the
+// cell type by the expected type of the formula result.
+func (sw *StreamWriter) setCellFormula(c *xlsxC, formula string, resultType CellType) error {
+	if formula == "" {
+		return nil
+	}
+	t, ok := formulaResultTypes[resultType]
+	if !ok {
+		return ErrParameterInvalid
+	}
+	if sw.validateRefs {
+		if err := sw.checkFormulaSheetRefs(c.R, formula); err != nil {
+			return err
+		}
+	}
+	c.T, c.F = t, &xlsxF{Content: formula}
+	sw.hasFormula = true
+	if strings.Contains(formula, lastRowPlaceholder) {
+		sw.lastRowRef = sw.lastRow
+	}
+	if resultType == CellTypeDate && c.S == 0 {
+		style, _ := sw.file.NewStyle(&Style{NumFmt: 22})
+		c.S = style
+	}
+	return nil
+}
+
+// checkFormulaSheetRefs provides a function to check if the worksheets
+// referenced by the formula exist by given cell reference and formula. The
+// references to the external workbooks will be skipped.
+func (sw *StreamWriter) checkFormulaSheetRefs(cell, formula string) error {
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		idx := strings.LastIndex(token.TValue, "!")
+		if idx == -1 || strings.HasPrefix(token.TValue, "[") {
+			continue
+		}
+		for _, sheet := range strings.Split(token.TValue[:idx], ":") {
+			if _, ok := sw.file.getSheetXMLPath(sheet); !ok {
+				return newStreamFormulaSheetRefError(cell, sheet)
+			}
+		}
+	}
+	return nil
+}
+
+// setCellForceText provides a function to convert the number and boolean
+// value of a cell to the text type.
+func setCellForceText(c *xlsxC) {
+	switch c.T {
+	case "", "n":
+		if c.V != "" {
+			c.setCellValue(c.V)
+		}
+	case "b":
+		val := "FALSE"
+		if c.V == "1" {
+			val = "TRUE"
+		}
+		c.setCellValue(val)
+	}
+}
+
+// setCellNumFmt provides a function to set the style of a cell with the
+// given custom number format code or the display text, the style is derived
+// from the style of the cell and cached for reuse.
+func (sw *StreamWriter) setCellNumFmt(c *xlsxC, numFmt, displayText string) error {
+	if displayText != "" {
+		if numFmt != "" {
+			return ErrParameterInvalid
+		}
+		numFmt = getDisplayTextNumFmt(displayText)
+	}
+	if numFmt == "" {
+		return nil
+	}
+	key := cellNumFmt{styleID: c.S, numFmt: numFmt}
+	if styleID, ok := sw.numFmtStyles[key]; ok {
+		c.S = styleID
+		return nil
+	}
+	style := &Style{}
+	if c.S != 0 {
+		var err error
+		if style, err = sw.file.GetStyle(c.S); err != nil {
+			return err
+		}
+	}
+	style.NumFmt, style.CustomNumFmt = 0, &numFmt
+	styleID, err := sw.file.NewStyle(style)
+	if err != nil {
+		return err
+	}
+	if sw.numFmtStyles == nil {
+		sw.numFmtStyles = make(map[cellNumFmt]int)
+	}
+	sw.numFmtStyles[key], c.S = styleID, styleID
+	return nil
+}
+
+// getDisplayTextNumFmt provides a function to get the custom number format
+// code which displays the given text as the literal for the positive,
+// negative and zero numbers.
+func getDisplayTextNumFmt(text string) string {
+	section := `"` + strings.ReplaceAll(text, `"`, `"\""`) + `"`
+	return section + ";" + section + ";" + section
+}
+
+// setCellDurationStyle provides a function to set the style of a duration
+// cell without style with the builtin number format of the duration, the
+// style is cached for reuse.
+func (sw *StreamWriter) setCellDurationStyle(c *xlsxC, d time.Duration) error {
+	numFmt := getDurationNumFmt(d)
+	key := cellNumFmt{numFmt: builtInNumFmt[numFmt]}
+	if styleID, ok := sw.numFmtStyles[key]; ok {
+		c.S = styleID
+		return nil
+	}
+	styleID, err := sw.file.NewStyle(&Style{NumFmt: numFmt})
+	if err != nil {
+		return err
+	}
+	if sw.numFmtStyles == nil {
+		sw.numFmtStyles = make(map[cellNumFmt]int)
+	}
+	sw.numFmtStyles[key], c.S = styleID, styleID
+	return nil
+}
+
+// getRowBorderStyle provides a function to get the style ID derived from the
+// given style ID with the borders of the current row, the style is cached for
+// reuse.
+func (sw *StreamWriter) getRowBorderStyle(styleID int) (int, error) {
+	opts := sw.rowBorders
+	key := rowBorderStyle{styleID: styleID, top: opts.TopBorder, bottom: opts.BottomBorder, color: opts.BorderColor}
+	if ID, ok := sw.rowBorderStyles[key]; ok {
+		return ID, nil
+	}
+	style := &Style{}
+	if styleID != 0 {
+		var err error
+		if style, err = sw.file.GetStyle(styleID); err != nil {
+			return styleID, err
+		}
+	}
+	color := opts.BorderColor
+	if color == "" {
+		color = "000000"
+	}
+	var borders []Border
+	for _, border := range style.Border {
+		if (border.Type != "top" || opts.TopBorder == RowBorderNone) &&
+			(border.Type != "bottom" || opts.BottomBorder == RowBorderNone) {
+			borders = append(borders, border)
+		}
+	}
+	if opts.TopBorder != RowBorderNone {
+		borders = append(borders, Border{Type: "top", Color: color, Style: rowBorderTypes[opts.TopBorder]})
+	}
+	if opts.BottomBorder != RowBorderNone {
+		borders = append(borders, Border{Type: "bottom", Color: color, Style: rowBorderTypes[opts.BottomBorder]})
+	}
+	style.Border = borders
+	ID, err := sw.file.NewStyle(style)
+	if err != nil {
+		return styleID, err
+	}
+	if sw.rowBorderStyles == nil {
+		sw.rowBorderStyles = make(map[rowBorderStyle]int)
+	}
+	sw.rowBorderStyles[key] = ID
+	return ID, nil
+}
+
+// getRowColorStyle provides a function to get the style ID derived from the
+// given style ID with the fill and font colors of the row options, the style
+// is cached for reuse.
+func (sw *StreamWriter) getRowColorStyle(styleID int, opts *RowOpts) (int, error) {
+	key := rowColorStyle{
+		styleID: styleID,
+		fill:    strings.ToUpper(strings.TrimPrefix(opts.FillColor, "#")),
+		font:    strings.ToUpper(strings.TrimPrefix(opts.FontColor, "#")),
+	}
+	if ID, ok := sw.rowColorStyles[key]; ok {
+		return ID, nil
+	}
+	style := &Style{}
+	if styleID != 0 {
+		var err error
+		if style, err = sw.file.GetStyle(styleID); err != nil {
+			return styleID, err
+		}
+	}
+	if key.fill != "" {
+		style.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{key.fill}}
+	}
+	if key.font != "" {
+		if style.Font == nil {
+			style.Font = &Font{}
+		}
+		style.Font.Color = key.font
+	}
+	ID, err := sw.file.NewStyle(style)
+	if err != nil {
+		return styleID, err
+	}
+	if sw.rowColorStyles == nil {
+		sw.rowColorStyles = make(map[rowColorStyle]int)
+	}
+	sw.rowColorStyles[key] = ID
+	return ID, nil
+}
+
+// AddRichValue provides a function to register a rich value record in the
+// workbook and returns the ID of the rich value, which can be referenced by
+// the RichValueID of the Cell, the cells with the same ID share the rich
+// value. The metadata and rich data parts of the workbook will be written on
+// calling the 'Flush' function. For example, write a cell of the entity data
+// type with the display string and a numeric property:
+//
+//	id, err := sw.AddRichValue(&excelize.RichValue{
+//	    Type: "_entity",
+//	    Fields: []excelize.RichValueField{
+//	        {Name: "_DisplayString", Value: "Contoso"},
+//	        {Name: "Price", Value: 123.45},
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.SetRow("A1", []interface{}{excelize.Cell{RichValueID: id}})
+//
+// This function only supports a subset of the rich data: the structure type
+// and the keys of the rich value are written as is, each distinct set of the
+// type and keys will be written as a rich value structure, the values must be
+// string, boolean, integer or float number, and the rich value types, arrays
+// and the supporting property bags are not supported. Excel recognizes the
+// linked data types, such as stocks and geography, only if the keys required
+// by the data provider are given, for example "%EntityServiceId",
+// "%EntityId" and "%EntityCulture" of the "_linkedentity" type. Adding rich
+// values to the workbook which already contains the metadata part is not
+// supported.
+func (sw *StreamWriter) AddRichValue(value *RichValue) (int, error) {
+	if value == nil || value.Type == "" || len(value.Fields) == 0 {
+		return 0, ErrParameterInvalid
+	}
+	f := sw.file
+	if len(f.richValues) == 0 && len(f.readXML(defaultXMLMetadata)) > 0 {
+		return 0, ErrStreamRichValue
+	}
+	names := make(map[string]bool, len(value.Fields))
+	for _, field := range value.Fields {
+		if _, _, ok := richValueKey(field.Value); !ok || field.Name == "" || names[field.Name] {
+			return 0, ErrParameterInvalid
+		}
+		names[field.Name] = true
+	}
+	f.richValues = append(f.richValues, RichValue{
+		Type: value.Type, Fields: append([]RichValueField(nil), value.Fields...),
+	})
+	return len(f.richValues), nil
+}
+
+// richValueKey returns the value type of the rich value key and the value in
+// the string by given value.
+func richValueKey(val interface{}) (string, string, bool) {
+	switch val := val.(type) {
+	case string:
+		return "s", val, true
+	case bool:
+		if val {
+			return "b", "1", true
+		}
+		return "b", "0", true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "i", fmt.Sprint(val), true
+	case float32:
+		return "", strconv.FormatFloat(float64(val), 'f', -1, 32), true
+	case float64:
+		return "", strconv.FormatFloat(val, 'f', -1, 64), true
+	}
+	return "", "", false
+}
+
+// setCellRichValue provides a function to reference the rich value by given
+// rich value ID in the cell, and returns the value to be written in the cell.
+func (sw *StreamWriter) setCellRichValue(c *xlsxC, id int, val interface{}) (interface{}, error) {
+	if id == 0 {
+		return val, nil
+	}
+	if id < 0 || id > len(sw.file.richValues) {
+		return val, ErrParameterInvalid
+	}
+	c.Vm = uintPtr(uint(id))
+	return CellError(formulaErrorVALUE), nil
+}
+
+// writeRichValues provides a function to write the metadata and rich data
+// parts of the workbook by the registered rich values.
+func (f *File) writeRichValues() error {
+	var (
+		metadata   = xlsxMetadata{XMLNS: NameSpaceSpreadSheet.Value}
+		richValues = xlsxRichValueData{XMLNS: NameSpaceSpreadSheetXLRD.Value}
+		structures = xlsxRichValueStructures{XMLNS: NameSpaceSpreadSheetXLRD.Value}
+		structIdx  = make(map[string]int)
+		future     = xlsxFutureMetadata{Name: "XLRICHVALUE"}
+	)
+	metadata.MetadataTypes = &xlsxInnerXML{Content: `<metadataType name="XLRICHVALUE" minSupportedVersion="120000" copy="1" pasteAll="1" pasteValues="1" merge="1" splitFirst="1" rowColShift="1" clearFormats="1" clearComments="1" assign="1" coerce="1"/>`}
+	metadata.ValueMetadata = &xlsxMetadataBlocks{}
+	for i, value := range f.richValues {
+		structure := xlsxRichValueStructure{T: value.Type}
+		rv := xlsxRichValue{}
+		for _, field := range value.Fields {
+			t, v, _ := richValueKey(field.Value)
+			structure.K = append(structure.K, xlsxRichValueKey{N: field.Name, T: t})
+			rv.V = append(rv.V, v)
+		}
+		key, _ := xml.Marshal(structure)
+		idx, ok := structIdx[string(key)]
+		if !ok {
+			idx = len(structures.S)
+			structIdx[string(key)] = idx
+			structures.S = append(structures.S, structure)
+		}
+		rv.S = idx
+		richValues.Rv = append(richValues.Rv, rv)
+		future.Bk = append(future.Bk, xlsxFutureMetadataBlock{
+			ExtLst: &xlsxInnerXML{Content: `<ext uri="` + ExtURIRichValueBlock + `" xmlns:xlrd="` + NameSpaceSpreadSheetXLRD.Value + `"><xlrd:rvb i="` + strconv.Itoa(i) + `"/></ext>`},
+		})
+		metadata.ValueMetadata.Bk = append(metadata.ValueMetadata.Bk, xlsxMetadataBlock{
+			Rc: []xlsxMetadataRecord{{T: 1, V: i}},
+		})
+	}
+	future.Count, richValues.Count, structures.Count = len(future.Bk), len(richValues.Rv), len(structures.S)
+	metadata.FutureMetadata, metadata.ValueMetadata.Count = []xlsxFutureMetadata{future}, len(f.richValues)
+	for path, v := range map[string]interface{}{
+		defaultXMLMetadata:             metadata,
+		defaultXMLRdRichValuePart:      richValues,
+		defaultXMLRdRichValueStructure: structures,
+	} {
+		output, _ := xml.Marshal(v)
+		f.saveFileList(path, output)
+	}
+	for _, rel := range [][]string{
+		{SourceRelationshipSheetMetadata, "metadata.xml"},
+		{SourceRelationshipRdRichValue, "richData/rdrichvalue.xml"},
+		{SourceRelationshipRdRichValueStructure, "richData/rdrichvaluestructure.xml"},
+	} {
+		f.addRels(f.getWorkbookRelsPath(), rel[0], rel[1], "")
+	}
+	for _, contentType := range []string{"metadata", "richValue", "richValueStr"} {
+		if err := f.addContentTypePart(0, contentType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCellTime provides a function to set number of a cell with a time.
+func (sw *StreamWriter) setCellTime(c *xlsxC, val time.Time) error {
+	var date1904, isNum bool
+	wb, err := sw.file.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb != nil && wb.WorkbookPr != nil {
+		date1904 = wb.WorkbookPr.Date1904
+	}
+	if isNum, err = c.setCellTime(val, date1904); err == nil && isNum && c.S == 0 {
+		style, _ := sw.file.NewStyle(&Style{NumFmt: 22})
+		c.S = style
+	}
+	return nil
+}
+
+// ColumnType is the type of the column value types for the stream writer.
+type ColumnType byte
+
+// This section defines the column value types enumeration for the stream
+// writer.
+const (
+	ColumnTypeAuto ColumnType = iota
+	ColumnTypeInt
+	ColumnTypeFloat
+	ColumnTypeDate
+	ColumnTypeText
+	ColumnTypeBool
+)
+
+// columnTypeNames defined the names of the column value types.
+var columnTypeNames = map[ColumnType]string{
+	ColumnTypeAuto:  "auto",
+	ColumnTypeInt:   "int",
+	ColumnTypeFloat: "float",
+	ColumnTypeDate:  "date",
+	ColumnTypeText:  "text",
+	ColumnTypeBool:  "bool",
+}
+
+// SetColumnTypes provides a function to declare the value types of the
+// columns for the StreamWriter, the first type is for column A, and so on.
+// The values in the columns with declared type will be written by the type
+// without guessing the type of each value, and an error will be returned if
+// the value mismatches the column type. It affects the rows written after
+// calling this function, and the ColumnTypeAuto column and the columns
+// without declared type guess the type of each value as usual. The supported
+// column types are:
+//
+//	 Type            | Value
+//	-----------------+-------------------------------------------
+//	 ColumnTypeAuto  | any supported value
+//	 ColumnTypeInt   | int, int8, int16, int32, int64, uint, uint8, uint16,
+//	                 | uint32 or uint64
+//	 ColumnTypeFloat | float32, float64 or the integer types
+//	 ColumnTypeDate  | time.Time
+//	 ColumnTypeText  | string or []byte
+//	 ColumnTypeBool  | bool
+//
+// The nil values are written as blank cells. The values of the date column
+// share one style with the date and time number format, unless the cell or
+// the row has a style. For example, declare an ID, name, price and date
+// columns:
+//
+//	err := sw.SetColumnTypes([]excelize.ColumnType{
+//	    excelize.ColumnTypeInt, excelize.ColumnTypeText,
+//	    excelize.ColumnTypeFloat, excelize.ColumnTypeDate,
+//	})
+func (sw *StreamWriter) SetColumnTypes(types []ColumnType) error {
+	if len(types) > MaxColumns {
+		return ErrColumnNumber
+	}
+	for _, colType := range types {
+		if _, ok := columnTypeNames[colType]; !ok {
+			return ErrParameterInvalid
+		}
+		if colType == ColumnTypeDate && sw.dateStyleID == 0 {
+			wb, err := sw.file.workbookReader()
+			if err != nil {
+				return err
+			}
+			if wb != nil && wb.WorkbookPr != nil {
+				sw.date1904 = wb.WorkbookPr.Date1904
+			}
+			if sw.dateStyleID, err = sw.file.NewStyle(&Style{NumFmt: 22}); err != nil {
+				return err
+			}
+		}
+	}
+	sw.columnTypes = append([]ColumnType(nil), types...)
+	return nil
+}
+
+// setCellTypedValFunc provides a function to set value of a cell by the
+// declared type of the given column number, the value type will be guessed
+// if the type of the column has not been declared.
+func (sw *StreamWriter) setCellTypedValFunc(c *xlsxC, col int, val interface{}) error {
+	if col > len(sw.columnTypes) || sw.columnTypes[col-1] == ColumnTypeAuto || val == nil {
+		return sw.setCellValFunc(c, val)
+	}
+	colType, ok := sw.columnTypes[col-1], false
+	switch colType {
+	case ColumnTypeInt:
+		switch val.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			setCellIntFunc(c, val)
+			ok = true
+		}
+	case ColumnTypeFloat:
+		switch v := val.(type) {
+		case float64:
+			c.setCellFloat(v, -1, 64)
+			ok = true
+		case float32:
+			c.setCellFloat(float64(v), -1, 32)
+			ok = true
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			setCellIntFunc(c, val)
+			ok = true
+		}
+	case ColumnTypeDate:
+		var v time.Time
+		if v, ok = val.(time.Time); ok {
+			isNum, err := c.setCellTime(v, sw.date1904)
+			if err != nil {
+				return err
+			}
+			if isNum && c.S == 0 {
+				c.S = sw.dateStyleID
+			}
+		}
+	case ColumnTypeText:
+		switch v := val.(type) {
+		case string:
+			c.setCellValue(v)
+			ok = true
+		case []byte:
+			c.setCellValue(string(v))
+			ok = true
+		}
+	case ColumnTypeBool:
+		var v bool
+		if v, ok = val.(bool); ok {
+			if sw.file.options.BoolAsText {
+				c.setCellValue(strings.ToUpper(strconv.FormatBool(v)))
+				break
+			}
+			c.T, c.V = setCellBool(v)
+		}
+	}
+	if !ok {
+		return newStreamColumnTypeError(c.R, val, colType)
+	}
+	return nil
+}
+
+// setCellValFunc provides a function to set value of a cell.
+func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
+	var err error
+	switch val := val.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		setCellIntFunc(c, val)
+	case float32:
+		c.setCellFloat(float64(val), -1, 32)
+	case float64:
+		c.setCellFloat(val, -1, 64)
+	case string:
 		c.setCellValue(val)
 	case []byte:
 		c.setCellValue(string(val))
 	case time.Duration:
 		c.T, c.V = setCellDuration(val)
+		if c.S == 0 {
+			err = sw.setCellDurationStyle(c, val)
+		}
 	case time.Time:
 		err = sw.setCellTime(c, val)
 	case bool:
+		if sw.file.options.BoolAsText {
+			c.setCellValue(strings.ToUpper(strconv.FormatBool(val)))
+			break
+		}
 		c.T, c.V = setCellBool(val)
 	case nil:
 		return err
 	case []RichTextRun:
 		c.T, c.IS = "inlineStr", &xlsxSI{}
 		c.IS.R, err = setRichText(val)
+	case CellError:
+		err = setCellError(c, val)
 	default:
 		c.setCellValue(fmt.Sprint(val))
 	}
-	return err
-}
-
-// setCellIntFunc is a wrapper of SetCellInt.
-func setCellIntFunc(c *xlsxC, val interface{}) {
-	switch val := val.(type) {
-	case int:
-		c.T, c.V = setCellInt(val)
-	case int8:
-		c.T, c.V = setCellInt(int(val))
-	case int16:
-		c.T, c.V = setCellInt(int(val))
-	case int32:
-		c.T, c.V = setCellInt(int(val))
-	case int64:
-		c.T, c.V = setCellInt(int(val))
-	case uint:
-		c.T, c.V = setCellUint(uint64(val))
-	case uint8:
-		c.T, c.V = setCellUint(uint64(val))
-	case uint16:
-		c.T, c.V = setCellUint(uint64(val))
-	case uint32:
-		c.T, c.V = setCellUint(uint64(val))
-	case uint64:
-		c.T, c.V = setCellUint(val)
+	return err
+}
+
+// setCellError provides a function to set the error type cell value by given
+// error literal.
+func setCellError(c *xlsxC, val CellError) error {
+	if inStrSlice([]string{
+		formulaErrorNULL, formulaErrorDIV, formulaErrorVALUE, formulaErrorREF,
+		formulaErrorNAME, formulaErrorNUM, formulaErrorNA, formulaErrorGETTINGDATA,
+		formulaErrorSPILL, formulaErrorCALC,
+	}, string(val), true) == -1 {
+		return newInvalidCellErrorError(string(val))
+	}
+	c.T, c.V = "e", string(val)
+	return nil
+}
+
+// setCellIntFunc is a wrapper of SetCellInt.
+func setCellIntFunc(c *xlsxC, val interface{}) {
+	switch val := val.(type) {
+	case int:
+		c.T, c.V = setCellInt(val)
+	case int8:
+		c.T, c.V = setCellInt(int(val))
+	case int16:
+		c.T, c.V = setCellInt(int(val))
+	case int32:
+		c.T, c.V = setCellInt(int(val))
+	case int64:
+		c.T, c.V = setCellInt(int(val))
+	case uint:
+		c.T, c.V = setCellUint(uint64(val))
+	case uint8:
+		c.T, c.V = setCellUint(uint64(val))
+	case uint16:
+		c.T, c.V = setCellUint(uint64(val))
+	case uint32:
+		c.T, c.V = setCellUint(uint64(val))
+	case uint64:
+		c.T, c.V = setCellUint(val)
+	}
+}
+
+// writeCell constructs a cell XML and writes it to the buffer.
+func writeCell(buf *bufferedWriter, c xlsxC) {
+	_, _ = buf.WriteString(`<c`)
+	if c.XMLSpace.Value != "" {
+		_, _ = buf.WriteString(` xml:`)
+		_, _ = buf.WriteString(c.XMLSpace.Name.Local)
+		_, _ = buf.WriteString(`="`)
+		_, _ = buf.WriteString(c.XMLSpace.Value)
+		_, _ = buf.WriteString(`"`)
+	}
+	_, _ = buf.WriteString(` r="`)
+	_, _ = buf.WriteString(c.R)
+	_, _ = buf.WriteString(`"`)
+	if c.S != 0 {
+		_, _ = buf.WriteString(` s="`)
+		_, _ = buf.WriteString(strconv.Itoa(c.S))
+		_, _ = buf.WriteString(`"`)
+	}
+	if c.T != "" {
+		_, _ = buf.WriteString(` t="`)
+		_, _ = buf.WriteString(c.T)
+		_, _ = buf.WriteString(`"`)
+	}
+	if c.Vm != nil {
+		_, _ = buf.WriteString(` vm="`)
+		_, _ = buf.WriteString(strconv.FormatUint(uint64(*c.Vm), 10))
+		_, _ = buf.WriteString(`"`)
+	}
+	_, _ = buf.WriteString(`>`)
+	if c.F != nil {
+		_, _ = buf.WriteString(`<f>`)
+		_ = xml.EscapeText(buf, []byte(c.F.Content))
+		_, _ = buf.WriteString(`</f>`)
+	}
+	if c.V != "" {
+		_, _ = buf.WriteString(`<v>`)
+		_ = xml.EscapeText(buf, []byte(c.V))
+		_, _ = buf.WriteString(`</v>`)
+	}
+	if c.IS != nil {
+		if len(c.IS.R) > 0 {
+			is, _ := xml.Marshal(c.IS.R)
+			_, _ = buf.WriteString(`<is>`)
+			_, _ = buf.Write(is)
+			_, _ = buf.WriteString(`</is>`)
+		}
+		if c.IS.T != nil {
+			_, _ = buf.WriteString(`<is><t`)
+			if c.IS.T.Space.Value != "" {
+				_, _ = buf.WriteString(` xml:`)
+				_, _ = buf.WriteString(c.IS.T.Space.Name.Local)
+				_, _ = buf.WriteString(`="`)
+				_, _ = buf.WriteString(c.IS.T.Space.Value)
+				_, _ = buf.WriteString(`"`)
+			}
+			_, _ = buf.WriteString(`>`)
+			_, _ = buf.Write([]byte(c.IS.T.Val))
+			_, _ = buf.WriteString(`</t></is>`)
+		}
+	}
+	_, _ = buf.WriteString(`</c>`)
+}
+
+// writeSheetData prepares the element preceding sheetData and writes the
+// sheetData XML start element to the buffer.
+func (sw *StreamWriter) writeSheetData() {
+	if !sw.sheetWritten {
+		bulkAppendFields(&sw.rawData, sw.worksheet, 2, 2)
+		ref := "A1"
+		if sw.worksheet.Dimension != nil {
+			ref = sw.worksheet.Dimension.Ref
+		}
+		sw.dimensionOffset = sw.rawData.buf.Len()
+		_, _ = sw.rawData.WriteString(dimensionElement(ref))
+		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
+		if sw.colWidths != nil {
+			// The columns will be written on ending the streaming writing
+			sw.sheetHead = append([]byte(nil), sw.rawData.buf.Bytes()...)
+			sw.rawData.buf.Reset()
+		} else {
+			sw.writeCols(&sw.rawData, append([]xlsxCol{}, sw.fixedCols...))
+		}
+		_, _ = sw.rawData.WriteString(`<sheetData>`)
+		sw.writeAppendRows()
+		sw.sheetWritten = true
+	}
+}
+
+// loadAppendRows provides a function to load the existing rows, merged cells,
+// columns and tables of the worksheet in the append mode of the stream
+// writer, the rows will be written after writing the sheetData element.
+func (sw *StreamWriter) loadAppendRows() {
+	ws := sw.worksheet
+	sw.appendRows, ws.SheetData.Row = ws.SheetData.Row, nil
+	for _, row := range sw.appendRows {
+		sw.rows, sw.lastRow = row.R, row.R
+		for _, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil {
+				sw.extendDimension(col, row.R)
+			}
+		}
+	}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			sw.mergeCellsCount++
+			_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+			_, _ = sw.mergeCells.WriteString(mergeCell.Ref)
+			_, _ = sw.mergeCells.WriteString(`"/>`)
+		}
+	}
+	if ws.Cols != nil {
+		sw.fixedCols = append(sw.fixedCols, ws.Cols.Col...)
+	}
+}
+
+// writeAppendRows provides a function to write the existing rows of the
+// worksheet loaded in the append mode of the stream writer.
+func (sw *StreamWriter) writeAppendRows() {
+	enc := xml.NewEncoder(&sw.rawData)
+	for i := range sw.appendRows {
+		_ = enc.EncodeElement(sw.appendRows[i], xml.StartElement{Name: xml.Name{Local: "row"}})
+	}
+	_ = enc.Flush()
+	sw.appendRows = nil
+}
+
+// Flush ending the streaming writing process.
+func (sw *StreamWriter) Flush() error {
+	// The temp file of the checkpointed stream writer can't be resumed after
+	// ending the streaming writing, remove it on closing the workbook
+	sw.rawData.keep = false
+	sw.flushed = true
+	sw.writeSheetData()
+	if sw.colWidths != nil {
+		if err := sw.writeAutoFitCols(); err != nil {
+			return err
+		}
+	}
+	if sw.lastRowRef > 0 {
+		if err := sw.writeLastRowFormulas(); err != nil {
+			return err
+		}
+	}
+	_, _ = sw.rawData.WriteString(`</sheetData>`)
+	if err := sw.checkLateMergeRanges(); err != nil {
+		return err
+	}
+	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 15)
+	mergeCells := strings.Builder{}
+	if sw.mergeCellsCount > 0 {
+		_, _ = mergeCells.WriteString(`<mergeCells count="`)
+		_, _ = mergeCells.WriteString(strconv.Itoa(sw.mergeCellsCount))
+		_, _ = mergeCells.WriteString(`">`)
+		_, _ = mergeCells.WriteString(sw.mergeCells.String())
+		_, _ = mergeCells.WriteString(`</mergeCells>`)
+	}
+	_, _ = sw.rawData.WriteString(mergeCells.String())
+	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 39)
+	// Keep the existing tables of the worksheet, and append the tables which
+	// added by the stream writer
+	var tableParts []string
+	if sw.worksheet.TableParts != nil {
+		for _, tablePart := range sw.worksheet.TableParts.TableParts {
+			if tablePart != nil {
+				tableParts = append(tableParts, tablePart.RID)
+			}
+		}
+	}
+	tableParts = append(tableParts, sw.tableParts...)
+	if len(tableParts) > 0 {
+		_, _ = sw.rawData.WriteString(`<tableParts count="`)
+		_, _ = sw.rawData.WriteString(strconv.Itoa(len(tableParts)))
+		_, _ = sw.rawData.WriteString(`">`)
+		for _, rID := range tableParts {
+			_, _ = sw.rawData.WriteString(`<tablePart r:id="`)
+			_, _ = sw.rawData.WriteString(rID)
+			_, _ = sw.rawData.WriteString(`"></tablePart>`)
+		}
+		_, _ = sw.rawData.WriteString(`</tableParts>`)
+	}
+	bulkAppendFields(&sw.rawData, sw.worksheet, 41, 41)
+	_, _ = sw.rawData.WriteString(`</worksheet>`)
+	if err := sw.writeDimension(); err != nil {
+		return err
+	}
+	if len(sw.file.richValues) > 0 {
+		if err := sw.file.writeRichValues(); err != nil {
+			return err
+		}
+	}
+	if sw.hasFormula {
+		if err := sw.file.setFullCalcOnLoad(); err != nil {
+			return err
+		}
+	}
+	if err := sw.rawData.Flush(); err != nil {
+		return err
+	}
+
+	sheetPath := sw.file.sheetMap[sw.Sheet]
+	sw.file.Sheet.Delete(sheetPath)
+	sw.file.checked.Delete(sheetPath)
+	sw.file.Pkg.Delete(sheetPath)
+
+	return nil
+}
+
+// setFullCalcOnLoad provides a function to set the workbook to recalculate all
+// formulas on the next load, since the stream writer doesn't calculate the
+// formulas and build the calculation chain.
+func (f *File) setFullCalcOnLoad() error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if wb.CalcPr == nil {
+		wb.CalcPr = &xlsxCalcPr{}
+	}
+	wb.CalcPr.FullCalcOnLoad = true
+	return err
+}
+
+// Checkpoint provides a function to save the progress of the stream writer,
+// and returns the serialized state which can be used to resume the stream
+// writer by the ResumeStreamWriter function in another process, for example,
+// continue the multi-hours exporting after the process restart. The written
+// rows will be kept in the temp file of the stream writer, which will be
+// synchronized to the disk on checkpoint. Note that:
+//
+//  1. The temp file of the checkpointed stream writer will be kept on closing
+//     the workbook, so that the stream writer can be resumed after the
+//     workbook has been closed or the process has been restarted. The temp
+//     file will be removed on closing the workbook after the stream writer or
+//     the resumed stream writer has been flushed. Call the
+//     RemoveStreamCheckpoint function to remove the temp file if the streaming
+//     writing won't be resumed anymore.
+//  2. The sheetData element will be written on checkpoint, so the functions
+//     should be called before the SetRow function, such as SetColWidth and
+//     SetPanes can't be called after the checkpoint.
+//  3. The styles part of the workbook and the hyperlinks written by the stream
+//     writer will be restored on resuming, but the other workbook parts will
+//     not, the stream writer with tables or rich values can't be checkpointed,
+//     and the worksheet settings which written on ending the streaming
+//     writing, such as the conditional formats, page breaks and charts should
+//     be set after resuming.
+//
+// For example, checkpoint the stream writer every 100000 rows:
+//
+//	if rowID%100000 == 0 {
+//	    state, err := sw.Checkpoint()
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	    if err := os.WriteFile("export.checkpoint", state, 0o600); err != nil {
+//	        fmt.Println(err)
+//	        return
+//	    }
+//	}
+func (sw *StreamWriter) Checkpoint() ([]byte, error) {
+	if len(sw.tableParts) > 0 || len(sw.file.richValues) > 0 {
+		return nil, ErrStreamCheckpoint
+	}
+	sw.writeSheetData()
+	var err error
+	if sw.rawData.tmp == nil {
+		if sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-"); err != nil {
+			return nil, err
+		}
+	}
+	if err = sw.rawData.Flush(); err != nil {
+		return nil, err
+	}
+	if err = sw.rawData.tmp.Sync(); err != nil {
+		return nil, err
+	}
+	sw.rawData.keep = true
+	fi, err := sw.rawData.tmp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	state := streamCheckpoint{
+		TempFile: sw.rawData.tmp.Name(), Size: fi.Size(), Sum: sw.rawData.sum, Verify: sw.rawData.verify,
+		Rows: sw.rows, LastRow: sw.lastRow, Dimension: sw.dimension, DimensionOffset: sw.dimensionOffset,
+		MergeCellsCount: sw.mergeCellsCount, MergeCells: sw.mergeCells.String(),
+		AutoFitColWidth: sw.colWidths != nil, FixedCols: sw.fixedCols, DefaultStyleID: sw.defaultStyleID,
+		DateStyleID: sw.dateStyleID, Date1904: sw.date1904, SheetHead: sw.sheetHead,
+		RowBorders: sw.rowBorders, HasFormula: sw.hasFormula, LastRowRef: sw.lastRowRef,
+		LastDataRow: sw.lastDataRow, StrictMerge: sw.strictMerge, ValidateRefs: sw.validateRefs,
+	}
+	for _, mergeRange := range sw.mergeRanges {
+		ref, _ := coordinatesToRangeRef(mergeRange.rect)
+		state.MergeRanges = append(state.MergeRanges, streamCheckpointMerge{Ref: ref, Late: mergeRange.late})
+	}
+	for col, width := range sw.colWidths {
+		state.ColWidths = append(state.ColWidths, streamCheckpointColWidth{Col: col, Width: width})
+	}
+	for _, colType := range sw.columnTypes {
+		state.ColumnTypes = append(state.ColumnTypes, int(colType))
+	}
+	for col, display := range sw.hyperlinkCols {
+		state.HyperlinkCols = append(state.HyperlinkCols, streamCheckpointLinkCol{Col: col, Display: display})
+	}
+	for link, rID := range sw.hyperlinkRIDs {
+		state.HyperlinkRIDs = append(state.HyperlinkRIDs, streamCheckpointLinkRID{Link: link, RID: rID})
+	}
+	sort.Slice(state.HyperlinkRIDs, func(i, j int) bool {
+		return state.HyperlinkRIDs[i].RID < state.HyperlinkRIDs[j].RID
+	})
+	if sw.worksheet.Hyperlinks != nil {
+		state.Hyperlinks = sw.worksheet.Hyperlinks.Hyperlink
+	}
+	for key, ID := range sw.numFmtStyles {
+		state.NumFmtStyles = append(state.NumFmtStyles, streamCheckpointStyle{StyleID: key.styleID, NumFmt: key.numFmt, ID: ID})
+	}
+	for key, ID := range sw.rowBorderStyles {
+		state.RowBorderStyles = append(state.RowBorderStyles, streamCheckpointStyle{
+			StyleID: key.styleID, Top: key.top, Bottom: key.bottom, Color: key.color, ID: ID,
+		})
+	}
+	for key, ID := range sw.rowColorStyles {
+		state.RowColorStyles = append(state.RowColorStyles, streamCheckpointStyle{
+			StyleID: key.styleID, Fill: key.fill, Font: key.font, ID: ID,
+		})
+	}
+	if state.Styles, err = sw.file.stylesReader(); err != nil {
+		return nil, err
+	}
+	return xml.Marshal(state)
+}
+
+// ResumeStreamWriter provides a function to resume the stream writer by given
+// worksheet name and the serialized state which returned by the Checkpoint
+// function of the stream writer. The rows written after the checkpoint will be
+// discarded, so continue writing from the row after the last row of the
+// checkpoint. The workbook should be in the same state as the workbook of the
+// checkpointed stream writer, for example, opened from the same file or
+// created by the same steps, and the styles part of the workbook will be
+// replaced by the checkpointed styles. For example, resume the stream writer
+// for the worksheet named Sheet1:
+//
+//	state, err := os.ReadFile("export.checkpoint")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	sw, err := f.ResumeStreamWriter("Sheet1", state)
+func (f *File) ResumeStreamWriter(sheet string, state []byte) (*StreamWriter, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	sheetID := f.getSheetID(sheet)
+	if sheetID == -1 {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	var checkpoint streamCheckpoint
+	if err := xml.Unmarshal(state, &checkpoint); err != nil {
+		return nil, err
+	}
+	if err := checkpoint.validate(); err != nil {
+		return nil, err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.OpenFile(checkpoint.TempFile, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	sw := &StreamWriter{
+		file: f, Sheet: sheet, SheetID: sheetID, sheetWritten: true, worksheet: ws,
+		rawData: bufferedWriter{tmp: tmp, verify: checkpoint.Verify, sum: checkpoint.Sum, keep: true},
+		rows:    checkpoint.Rows, lastRow: checkpoint.LastRow, dimension: checkpoint.Dimension,
+		dimensionOffset: checkpoint.DimensionOffset, mergeCellsCount: checkpoint.MergeCellsCount,
+		fixedCols: checkpoint.FixedCols, defaultStyleID: checkpoint.DefaultStyleID,
+		dateStyleID: checkpoint.DateStyleID, date1904: checkpoint.Date1904, sheetHead: checkpoint.SheetHead,
+		rowBorders: checkpoint.RowBorders, hasFormula: checkpoint.HasFormula,
+		lastRowRef: checkpoint.LastRowRef, lastDataRow: checkpoint.LastDataRow,
+		strictMerge: checkpoint.StrictMerge, validateRefs: checkpoint.ValidateRefs,
+	}
+	if err = sw.resumeTempFile(checkpoint.Size); err != nil {
+		_ = tmp.Close()
+		return nil, err
 	}
+	_, _ = sw.mergeCells.WriteString(checkpoint.MergeCells)
+	for _, mergeRange := range checkpoint.MergeRanges {
+		rect, err := rangeRefToCoordinates(mergeRange.Ref)
+		if err != nil {
+			_ = tmp.Close()
+			return nil, err
+		}
+		sw.mergeRanges = append(sw.mergeRanges, streamMergeRange{rect: rect, late: mergeRange.Late})
+	}
+	if checkpoint.AutoFitColWidth {
+		sw.colWidths = make(map[int]float64, len(checkpoint.ColWidths))
+	}
+	for _, colWidth := range checkpoint.ColWidths {
+		sw.colWidths[colWidth.Col] = colWidth.Width
+	}
+	for _, colType := range checkpoint.ColumnTypes {
+		sw.columnTypes = append(sw.columnTypes, ColumnType(colType))
+	}
+	if len(checkpoint.HyperlinkCols) > 0 {
+		sw.hyperlinkCols, sw.hyperlinkRIDs = make(map[int]bool), make(map[string]string)
+	}
+	for _, linkCol := range checkpoint.HyperlinkCols {
+		sw.hyperlinkCols[linkCol.Col] = linkCol.Display
+	}
+	sw.resumeHyperlinks(&checkpoint)
+	if len(checkpoint.NumFmtStyles) > 0 {
+		sw.numFmtStyles = make(map[cellNumFmt]int)
+	}
+	for _, style := range checkpoint.NumFmtStyles {
+		sw.numFmtStyles[cellNumFmt{styleID: style.StyleID, numFmt: style.NumFmt}] = style.ID
+	}
+	if len(checkpoint.RowBorderStyles) > 0 {
+		sw.rowBorderStyles = make(map[rowBorderStyle]int)
+	}
+	for _, style := range checkpoint.RowBorderStyles {
+		sw.rowBorderStyles[rowBorderStyle{styleID: style.StyleID, top: style.Top, bottom: style.Bottom, color: style.Color}] = style.ID
+	}
+	if len(checkpoint.RowColorStyles) > 0 {
+		sw.rowColorStyles = make(map[rowColorStyle]int)
+	}
+	for _, style := range checkpoint.RowColorStyles {
+		sw.rowColorStyles[rowColorStyle{styleID: style.StyleID, fill: style.Fill, font: style.Font}] = style.ID
+	}
+	if checkpoint.Styles != nil {
+		f.Styles, f.quotePrefixes = checkpoint.Styles, nil
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if f.streams == nil {
+		f.streams = make(map[string]*StreamWriter)
+	}
+	f.streams[sheetXMLPath] = sw
+	return sw, err
 }
 
-// writeCell constructs a cell XML and writes it to the buffer.
-func writeCell(buf *bufferedWriter, c xlsxC) {
-	_, _ = buf.WriteString(`<c`)
-	if c.XMLSpace.Value != "" {
-		_, _ = buf.WriteString(` xml:`)
-		_, _ = buf.WriteString(c.XMLSpace.Name.Local)
-		_, _ = buf.WriteString(`="`)
-		_, _ = buf.WriteString(c.XMLSpace.Value)
-		_, _ = buf.WriteString(`"`)
+// validate provides a function to validate the serialized state of the stream
+// writer checkpoint, the ErrStreamCheckpoint error will be returned if the
+// state is out of range or inconsistent.
+func (checkpoint *streamCheckpoint) validate() error {
+	if checkpoint.Size < 0 || checkpoint.DimensionOffset < 0 || int64(checkpoint.DimensionOffset) > checkpoint.Size ||
+		checkpoint.MergeCellsCount < 0 || checkpoint.DefaultStyleID < 0 || checkpoint.DateStyleID < 0 {
+		return ErrStreamCheckpoint
 	}
-	_, _ = buf.WriteString(` r="`)
-	_, _ = buf.WriteString(c.R)
-	_, _ = buf.WriteString(`"`)
-	if c.S != 0 {
-		_, _ = buf.WriteString(` s="`)
-		_, _ = buf.WriteString(strconv.Itoa(c.S))
-		_, _ = buf.WriteString(`"`)
+	for _, row := range []int{checkpoint.Rows, checkpoint.LastRow, checkpoint.LastRowRef, checkpoint.LastDataRow} {
+		if row < 0 || row > TotalRows {
+			return ErrStreamCheckpoint
+		}
 	}
-	if c.T != "" {
-		_, _ = buf.WriteString(` t="`)
-		_, _ = buf.WriteString(c.T)
-		_, _ = buf.WriteString(`"`)
+	if len(checkpoint.Dimension) != 0 && len(checkpoint.Dimension) != 4 {
+		return ErrStreamCheckpoint
 	}
-	_, _ = buf.WriteString(`>`)
-	if c.F != nil {
-		_, _ = buf.WriteString(`<f>`)
-		_ = xml.EscapeText(buf, []byte(c.F.Content))
-		_, _ = buf.WriteString(`</f>`)
+	for i, num := range checkpoint.Dimension {
+		if limit := []int{MaxColumns, TotalRows}[i%2]; num < 1 || num > limit {
+			return ErrStreamCheckpoint
+		}
 	}
-	if c.V != "" {
-		_, _ = buf.WriteString(`<v>`)
-		_ = xml.EscapeText(buf, []byte(c.V))
-		_, _ = buf.WriteString(`</v>`)
+	if len(checkpoint.ColWidths) > 0 && !checkpoint.AutoFitColWidth {
+		return ErrStreamCheckpoint
 	}
-	if c.IS != nil {
-		if len(c.IS.R) > 0 {
-			is, _ := xml.Marshal(c.IS.R)
-			_, _ = buf.WriteString(`<is>`)
-			_, _ = buf.Write(is)
-			_, _ = buf.WriteString(`</is>`)
+	for _, colWidth := range checkpoint.ColWidths {
+		if colWidth.Col < 1 || colWidth.Col > MaxColumns || colWidth.Width < 0 {
+			return ErrStreamCheckpoint
 		}
-		if c.IS.T != nil {
-			_, _ = buf.WriteString(`<is><t`)
-			if c.IS.T.Space.Value != "" {
-				_, _ = buf.WriteString(` xml:`)
-				_, _ = buf.WriteString(c.IS.T.Space.Name.Local)
-				_, _ = buf.WriteString(`="`)
-				_, _ = buf.WriteString(c.IS.T.Space.Value)
-				_, _ = buf.WriteString(`"`)
-			}
-			_, _ = buf.WriteString(`>`)
-			_, _ = buf.Write([]byte(c.IS.T.Val))
-			_, _ = buf.WriteString(`</t></is>`)
+	}
+	for _, colType := range checkpoint.ColumnTypes {
+		if _, ok := columnTypeNames[ColumnType(colType)]; !ok || colType < 0 || colType > math.MaxUint8 {
+			return ErrStreamCheckpoint
 		}
 	}
-	_, _ = buf.WriteString(`</c>`)
+	if len(checkpoint.HyperlinkRIDs) > 0 && len(checkpoint.HyperlinkCols) == 0 {
+		return ErrStreamCheckpoint
+	}
+	for _, linkCol := range checkpoint.HyperlinkCols {
+		if linkCol.Col < 1 || linkCol.Col > MaxColumns {
+			return ErrStreamCheckpoint
+		}
+	}
+	return nil
 }
 
-// writeSheetData prepares the element preceding sheetData and writes the
-// sheetData XML start element to the buffer.
-func (sw *StreamWriter) writeSheetData() {
-	if !sw.sheetWritten {
-		bulkAppendFields(&sw.rawData, sw.worksheet, 4, 5)
-		if sw.cols.Len() > 0 {
-			_, _ = sw.rawData.WriteString("<cols>")
-			_, _ = sw.rawData.WriteString(sw.cols.String())
-			_, _ = sw.rawData.WriteString("</cols>")
-		}
-		_, _ = sw.rawData.WriteString(`<sheetData>`)
-		sw.sheetWritten = true
+// RemoveStreamCheckpoint provides a function to remove the temp file of the
+// checkpointed stream writer by given serialized state which returned by the
+// Checkpoint function of the stream writer. The temp file of the checkpointed
+// stream writer will be kept on closing the workbook until the stream writer
+// has been flushed, so call this function to remove it if the streaming
+// writing won't be resumed anymore. For example:
+//
+//	state, err := os.ReadFile("export.checkpoint")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = excelize.RemoveStreamCheckpoint(state)
+func RemoveStreamCheckpoint(state []byte) error {
+	var checkpoint streamCheckpoint
+	if err := xml.Unmarshal(state, &checkpoint); err != nil {
+		return err
 	}
+	return os.Remove(checkpoint.TempFile)
 }
 
-// Flush ending the streaming writing process.
-func (sw *StreamWriter) Flush() error {
-	sw.writeSheetData()
-	_, _ = sw.rawData.WriteString(`</sheetData>`)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 8, 15)
-	mergeCells := strings.Builder{}
-	if sw.mergeCellsCount > 0 {
-		_, _ = mergeCells.WriteString(`<mergeCells count="`)
-		_, _ = mergeCells.WriteString(strconv.Itoa(sw.mergeCellsCount))
-		_, _ = mergeCells.WriteString(`">`)
-		_, _ = mergeCells.WriteString(sw.mergeCells.String())
-		_, _ = mergeCells.WriteString(`</mergeCells>`)
+// resumeTempFile provides a function to discard the data written after the
+// checkpoint in the temp file of the resumed stream writer by given the size
+// of the temp file on checkpoint, and verify the temp file if required.
+func (sw *StreamWriter) resumeTempFile(size int64) error {
+	fi, err := sw.rawData.tmp.Stat()
+	if err != nil {
+		return err
 	}
-	_, _ = sw.rawData.WriteString(mergeCells.String())
-	bulkAppendFields(&sw.rawData, sw.worksheet, 17, 38)
-	_, _ = sw.rawData.WriteString(sw.tableParts)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 40, 40)
-	_, _ = sw.rawData.WriteString(`</worksheet>`)
-	if err := sw.rawData.Flush(); err != nil {
+	if fi.Size() < size {
+		return ErrStreamCheckpoint
+	}
+	if err = sw.rawData.tmp.Truncate(size); err != nil {
+		return err
+	}
+	if _, err = sw.rawData.tmp.Seek(size, io.SeekStart); err != nil {
 		return err
 	}
+	if sw.rawData.verify {
+		_, err = sw.rawData.checksum(nil, 0)
+	}
+	return err
+}
 
+// resumeHyperlinks provides a function to add the relationships of the
+// hyperlinks written before the checkpoint for the resumed stream writer, and
+// update the relationship IDs of the hyperlinks.
+func (sw *StreamWriter) resumeHyperlinks(checkpoint *streamCheckpoint) {
+	if len(checkpoint.HyperlinkRIDs) == 0 {
+		return
+	}
 	sheetPath := sw.file.sheetMap[sw.Sheet]
-	sw.file.Sheet.Delete(sheetPath)
-	sw.file.checked.Delete(sheetPath)
-	sw.file.Pkg.Delete(sheetPath)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
+	rIDs := make(map[string]string, len(checkpoint.HyperlinkRIDs))
+	for _, linkRID := range checkpoint.HyperlinkRIDs {
+		rID := "rId" + strconv.Itoa(sw.file.addRels(sheetRels, SourceRelationshipHyperLink, linkRID.Link, "External"))
+		sw.hyperlinkRIDs[linkRID.Link], rIDs[linkRID.RID] = rID, rID
+	}
+	if len(checkpoint.Hyperlinks) > 0 {
+		sw.worksheet.Hyperlinks = &xlsxHyperlinks{}
+	}
+	for _, link := range checkpoint.Hyperlinks {
+		link.RID = rIDs[link.RID]
+		sw.worksheet.Hyperlinks.Hyperlink = append(sw.worksheet.Hyperlinks.Hyperlink, link)
+	}
+}
+
+// writeAutoFitCols provides a function to rebuild the buffered worksheet with
+// the columns element of the computed column widths for the auto fit column
+// width mode. The worksheet elements preceding the columns are kept with the
+// same length, so the offset of the dimension element is unchanged.
+func (sw *StreamWriter) writeAutoFitCols() error {
+	rawData := bufferedWriter{verify: sw.rawData.verify, memory: sw.rawData.memory}
+	_, _ = rawData.Write(sw.sheetHead)
+	cols := append([]xlsxCol{}, sw.fixedCols...)
+	for col, width := range sw.colWidths {
+		fixed := false
+		for _, c := range sw.fixedCols {
+			if col >= c.Min && col <= c.Max {
+				fixed = true
+				break
+			}
+		}
+		if !fixed {
+			cols = append(cols, xlsxCol{Min: col, Max: col, Width: float64Ptr(math.Round(width*100) / 100), CustomWidth: true})
+		}
+	}
+	sw.writeCols(&rawData, cols)
+	r, err := sw.rawData.Reader()
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, StreamChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			_, _ = rawData.Write(buf[:n])
+			if err := rawData.Sync(); err != nil {
+				_ = rawData.Close()
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = rawData.Close()
+			return err
+		}
+	}
+	if err = sw.rawData.Close(); err != nil {
+		return err
+	}
+	sw.rawData = rawData
+	return nil
+}
+
+// trackLastDataRow provides a function to track the last written row which
+// doesn't contain the formulas with the last row placeholder, it should be
+// called before starting a new row and on ending the streaming writing.
+func (sw *StreamWriter) trackLastDataRow() {
+	if sw.lastRow != sw.lastRowRef {
+		sw.lastDataRow = sw.lastRow
+	}
+}
 
+// writeLastRowFormulas provides a function to replace the last row
+// placeholder in the written formulas with the number of the last written
+// row which doesn't contain the placeholder, or 1 if there is no such row.
+// Only the text of the formula elements will be replaced, the cell values
+// containing the placeholder will be kept.
+func (sw *StreamWriter) writeLastRowFormulas() error {
+	sw.trackLastDataRow()
+	lastRow := []byte(strconv.Itoa(sw.lastDataRow))
+	if sw.lastDataRow == 0 {
+		lastRow = []byte("1")
+	}
+	r, err := sw.rawData.Reader()
+	if err != nil {
+		return err
+	}
+	rawData := bufferedWriter{verify: sw.rawData.verify, memory: sw.rawData.memory}
+	br, placeholder := bufio.NewReaderSize(r, StreamChunkSize), []byte(lastRowPlaceholder)
+	for inFormula := false; ; {
+		seg, err := br.ReadSlice('<')
+		if inFormula {
+			_, _ = rawData.Write(bytes.ReplaceAll(seg, placeholder, lastRow))
+		} else {
+			_, _ = rawData.Write(seg)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			err = rawData.Sync()
+		}
+		if err != nil {
+			_ = rawData.Close()
+			return err
+		}
+		tag, _ := br.Peek(2)
+		inFormula = len(tag) == 2 && tag[0] == 'f' && (tag[1] == '>' || tag[1] == ' ')
+	}
+	if err = sw.rawData.Close(); err != nil {
+		return err
+	}
+	sw.rawData = rawData
 	return nil
 }
 
+// writeDimension provides a function to overwrite the worksheet dimension
+// with the used range of the written cells, the single-cell used range will be
+// written as the cell reference, such as "A1" instead of "A1:A1".
+func (sw *StreamWriter) writeDimension() error {
+	if sw.dimension == nil {
+		return nil
+	}
+	var (
+		ref string
+		err error
+	)
+	if sw.dimension[0] == sw.dimension[2] && sw.dimension[1] == sw.dimension[3] {
+		ref, err = CoordinatesToCellName(sw.dimension[0], sw.dimension[1])
+	} else {
+		ref, err = coordinatesToRangeRef(sw.dimension)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = sw.rawData.WriteAt([]byte(dimensionElement(ref)), int64(sw.dimensionOffset))
+	return err
+}
+
 // bulkAppendFields bulk-appends fields in a worksheet by specified field
-// names order range.
+// names order range. The fields will be encoded with the element names in
+// the field tags.
 func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 	s := reflect.ValueOf(ws).Elem()
 	enc := xml.NewEncoder(w)
 	for i := 0; i < s.NumField(); i++ {
 		if from <= i && i <= to {
-			_ = enc.Encode(s.Field(i).Interface())
+			name, _, _ := strings.Cut(s.Type().Field(i).Tag.Get("xml"), ",")
+			_ = enc.EncodeElement(s.Field(i).Interface(), xml.StartElement{Name: xml.Name{Local: name}})
 		}
 	}
 }
@@ -697,10 +3400,17 @@ func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 // bufferedWriter uses a temp file to store an extended buffer. Writes are
 // always made to an in-memory buffer, which will always succeed. The buffer
 // is written to the temp file with Sync, which may return an error.
-// Therefore, Sync should be periodically called and the error checked.
+// Therefore, Sync should be periodically called and the error checked. If
+// verify is true, the running checksum of the data written to the temp file
+// is kept for verifying the temp file before it is read. If memory is true,
+// the buffer will not be written to a new temp file by Sync.
 type bufferedWriter struct {
-	tmp *os.File
-	buf bytes.Buffer
+	tmp    *os.File
+	buf    bytes.Buffer
+	verify bool
+	sum    uint32
+	memory bool
+	keep   bool
 }
 
 // Write to the in-memory buffer. The error is always nil.
@@ -713,6 +3423,63 @@ func (bw *bufferedWriter) WriteString(p string) (n int, err error) {
 	return bw.buf.WriteString(p)
 }
 
+// WriteAt overwrites the already written bytes of the underlying buffer or
+// temp file at the given offset.
+func (bw *bufferedWriter) WriteAt(p []byte, off int64) (int, error) {
+	if bw.tmp == nil {
+		return copy(bw.buf.Bytes()[off:], p), nil
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	if !bw.verify {
+		return bw.tmp.WriteAt(p, off)
+	}
+	sum, err := bw.checksum(p, off)
+	if err != nil {
+		return 0, err
+	}
+	n, err := bw.tmp.WriteAt(p, off)
+	if err == nil {
+		bw.sum = sum
+	}
+	return n, err
+}
+
+// checksum provides a function to verify the temp file by the running
+// checksum, and returns the checksum of the temp file content with the bytes
+// at the given offset to be overwritten by the given bytes.
+func (bw *bufferedWriter) checksum(p []byte, off int64) (uint32, error) {
+	var sum, patchedSum uint32
+	buf := make([]byte, StreamChunkSize)
+	for pos := int64(0); ; {
+		n, err := bw.tmp.ReadAt(buf, pos)
+		if n > 0 {
+			chunk := buf[:n]
+			sum = crc32.Update(sum, crc32.IEEETable, chunk)
+			if off < pos+int64(n) && off+int64(len(p)) > pos {
+				start := int64(0)
+				if off > pos {
+					start = off - pos
+				}
+				copy(chunk[start:], p[pos+start-off:])
+			}
+			patchedSum = crc32.Update(patchedSum, crc32.IEEETable, chunk)
+			pos += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if sum != bw.sum {
+		return 0, ErrStreamChecksum
+	}
+	return patchedSum, nil
+}
+
 // Reader provides read-access to the underlying buffer/file.
 func (bw *bufferedWriter) Reader() (io.Reader, error) {
 	if bw.tmp == nil {
@@ -721,6 +3488,11 @@ func (bw *bufferedWriter) Reader() (io.Reader, error) {
 	if err := bw.Flush(); err != nil {
 		return nil, err
 	}
+	if bw.verify {
+		if _, err := bw.checksum(nil, 0); err != nil {
+			return nil, err
+		}
+	}
 	fi, err := bw.tmp.Stat()
 	if err != nil {
 		return nil, err
@@ -733,39 +3505,95 @@ func (bw *bufferedWriter) Reader() (io.Reader, error) {
 // buffer has grown large enough. Any error will be returned.
 func (bw *bufferedWriter) Sync() (err error) {
 	// Try to use local storage
-	if bw.buf.Len() < StreamChunkSize {
+	if bw.memory || bw.buf.Len() < StreamChunkSize {
 		return nil
 	}
 	if bw.tmp == nil {
-		bw.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
-		if err != nil {
-			// can not use local storage
-			return nil
+		if bw.tmp, err = os.CreateTemp(os.TempDir(), "excelize-"); err != nil {
+			return ErrStreamTempFile{Err: err}
 		}
 	}
 	return bw.Flush()
 }
 
 // Flush the entire in-memory buffer to the temp file, if a temp file is being
-// used.
+// used. If the temp file write fails, such as the disk is full, the data
+// which has been written to the temp file will be moved back into the
+// in-memory buffer, and the partially written temp file will be closed and
+// removed. The temp file kept for the checkpoint will be truncated to the
+// written data instead of being removed.
 func (bw *bufferedWriter) Flush() error {
 	if bw.tmp == nil {
 		return nil
 	}
-	_, err := bw.buf.WriteTo(bw.tmp)
+	off, err := bw.tmp.Seek(0, io.SeekCurrent)
 	if err != nil {
-		return err
+		return ErrStreamTempFile{Err: err}
+	}
+	if _, err = bw.tmp.Write(bw.buf.Bytes()); err != nil {
+		_ = bw.tmp.Truncate(off)
+		_, _ = bw.tmp.Seek(off, io.SeekStart)
+		if !bw.keep {
+			err = bw.removeTempFile(off, err)
+		}
+		return ErrStreamTempFile{Err: err}
+	}
+	if bw.verify {
+		bw.sum = crc32.Update(bw.sum, crc32.IEEETable, bw.buf.Bytes())
 	}
 	bw.buf.Reset()
 	return nil
 }
 
-// Close the underlying temp file and reset the in-memory buffer.
+// keepInMemory moves the content of the temp file into the in-memory buffer
+// and removes the temp file, the subsequent writes will be kept in memory.
+func (bw *bufferedWriter) keepInMemory() error {
+	bw.memory = true
+	if bw.tmp == nil {
+		return nil
+	}
+	if bw.verify {
+		if _, err := bw.checksum(nil, 0); err != nil {
+			return err
+		}
+	}
+	fi, err := bw.tmp.Stat()
+	if err != nil {
+		return err
+	}
+	return bw.removeTempFile(fi.Size(), nil)
+}
+
+// removeTempFile provides a function to move the given size of data which
+// has been written to the temp file back into the in-memory buffer, and close
+// and remove the temp file. The given error will be returned if it isn't nil,
+// otherwise the error of reading or closing the temp file will be returned.
+func (bw *bufferedWriter) removeTempFile(size int64, err error) error {
+	var buf bytes.Buffer
+	buf.Grow(int(size) + bw.buf.Len())
+	if _, readErr := buf.ReadFrom(io.NewSectionReader(bw.tmp, 0, size)); readErr != nil {
+		return readErr
+	}
+	_, _ = buf.Write(bw.buf.Bytes())
+	tmp := bw.tmp
+	bw.tmp, bw.buf, bw.sum = nil, buf, 0
+	defer os.Remove(tmp.Name())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Close the underlying temp file and reset the in-memory buffer. The temp file
+// will be removed unless it has been kept for the checkpoint.
 func (bw *bufferedWriter) Close() error {
 	bw.buf.Reset()
 	if bw.tmp == nil {
 		return nil
 	}
+	if bw.keep {
+		return bw.tmp.Close()
+	}
 	defer os.Remove(bw.tmp.Name())
 	return bw.tmp.Close()
 }
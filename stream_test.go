@@ -1,13 +1,18 @@
 package excelize
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -38,6 +43,63 @@ func BenchmarkStreamWriter(b *testing.B) {
 	b.ReportAllocs()
 }
 
+func BenchmarkStreamWriterSetRowCells(b *testing.B) {
+	file := NewFile()
+	defer func() {
+		if err := file.Close(); err != nil {
+			b.Error(err)
+		}
+	}()
+	row := make([]Cell, 10)
+	for colID := 0; colID < 10; colID++ {
+		row[colID] = Cell{Value: colID}
+	}
+
+	for n := 0; n < b.N; n++ {
+		streamWriter, _ := file.NewStreamWriter("Sheet1")
+		for rowID := 10; rowID <= 110; rowID++ {
+			cell, _ := CoordinatesToCellName(1, rowID)
+			_ = streamWriter.SetRowCells(cell, row)
+		}
+	}
+
+	b.ReportAllocs()
+}
+
+func BenchmarkStreamWriterSetStringRow(b *testing.B) {
+	file := NewFile()
+	defer func() {
+		if err := file.Close(); err != nil {
+			b.Error(err)
+		}
+	}()
+	row, values := make([]string, 10), make([]interface{}, 10)
+	for colID := 0; colID < 10; colID++ {
+		row[colID] = fmt.Sprintf("Text %d", colID)
+		values[colID] = row[colID]
+	}
+	b.Run("SetRow", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			streamWriter, _ := file.NewStreamWriter("Sheet1")
+			for rowID := 10; rowID <= 110; rowID++ {
+				cell, _ := CoordinatesToCellName(1, rowID)
+				_ = streamWriter.SetRow(cell, values)
+			}
+		}
+		b.ReportAllocs()
+	})
+	b.Run("SetStringRow", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			streamWriter, _ := file.NewStreamWriter("Sheet1")
+			for rowID := 10; rowID <= 110; rowID++ {
+				cell, _ := CoordinatesToCellName(1, rowID)
+				_ = streamWriter.SetStringRow(cell, row)
+			}
+		}
+		b.ReportAllocs()
+	})
+}
+
 func TestStreamWriter(t *testing.T) {
 	file := NewFile()
 	streamWriter, err := file.NewStreamWriter("Sheet1")
@@ -154,6 +216,32 @@ func TestStreamWriter(t *testing.T) {
 	assert.NoError(t, file.Close())
 }
 
+func TestNewStreamWriterForNewSheet(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriterForNewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet2", sw.Sheet)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Data"}))
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	val, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Data", val)
+	// Test create stream writer for the existing worksheet
+	sw, err = f.NewStreamWriterForNewSheet("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sw.SheetID)
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+	// Test create stream writer with invalid worksheet name
+	sw, err = f.NewStreamWriterForNewSheet("Sheet:1")
+	assert.Nil(t, sw)
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test create stream writer with invalid stream options
+	_, err = f.NewStreamWriterForNewSheet("Sheet3", StreamOptions{XMLHeader: stringPtr("<xml>")})
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamSetColWidth(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -167,6 +255,35 @@ func TestStreamSetColWidth(t *testing.T) {
 	assert.Equal(t, ErrColumnWidth, streamWriter.SetColWidth(1, 3, MaxColumnWidth+1))
 	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
 	assert.Equal(t, ErrStreamSetColWidth, streamWriter.SetColWidth(2, 3, 20))
+
+	// Test set width of the adjacent columns with the same width one by one
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	for col := 1000; col > 0; col-- {
+		width := 20.0
+		if col == 500 {
+			width = 20.5
+		}
+		assert.NoError(t, streamWriter.SetColWidth(col, col, width))
+	}
+	assert.NoError(t, streamWriter.SetColWidth(1002, 1003, 20))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A"}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.Contains(t, string(file.readBytes("xl/worksheets/sheet1.xml")), `<cols><col min="1" max="499" width="20" customWidth="1"/><col min="500" max="500" width="20.5" customWidth="1"/><col min="501" max="1000" width="20" customWidth="1"/><col min="1002" max="1003" width="20" customWidth="1"/></cols>`)
+	width, err := file.GetColWidth("Sheet1", "SF")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.5, width)
+
+	// Test set width of the adjacent columns with the default style
+	styleID, err := file.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetDefaultStyle(styleID))
+	assert.NoError(t, streamWriter.SetColWidth(2, 2, 15))
+	assert.NoError(t, streamWriter.SetColWidth(3, 4, 15))
+	assert.NoError(t, streamWriter.Flush())
+	assert.Contains(t, string(file.readBytes("xl/worksheets/sheet1.xml")), fmt.Sprintf(`<cols><col min="1" max="1" width="9.140625" style="%[1]d"/><col min="2" max="4" width="15" style="%[1]d" customWidth="1"/><col min="5" max="16384" width="9.140625" style="%[1]d"/></cols>`, styleID))
 }
 
 func TestStreamSetPanes(t *testing.T) {
@@ -190,6 +307,463 @@ func TestStreamSetPanes(t *testing.T) {
 	assert.Equal(t, ErrParameterInvalid, streamWriter.SetPanes(nil))
 	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
 	assert.Equal(t, ErrStreamSetPanes, streamWriter.SetPanes(paneOpts))
+	// Test set freeze panes with the active cell in the unfrozen pane
+	streamWriter, err = file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, streamWriter.SetPanes(&Panes{Freeze: true, YSplit: 1, Selection: []Selection{{ActiveCell: "B5", Pane: "bottomLeft"}}}))
+	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
+	assert.NoError(t, streamWriter.Flush())
+	assert.Contains(t, string(file.readBytes("xl/worksheets/sheet1.xml")), `<pane activePane="bottomLeft" state="frozen" topLeftCell="A2" ySplit="1"></pane><selection activeCell="B5" pane="bottomLeft" sqref="B5"></selection>`)
+}
+
+func TestStreamSetPageLayout(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterInvalid, sw.SetPageLayout(nil))
+	assert.Equal(t, ErrPageSetupAdjustTo, sw.SetPageLayout(&PageLayoutOptions{AdjustTo: uintPtr(401)}))
+	assert.Equal(t, ErrPageSetupFitTo, sw.SetPageLayout(&PageLayoutOptions{FitToWidth: intPtr(-1)}))
+	assert.NoError(t, sw.SetPageLayout(&PageLayoutOptions{AdjustTo: uintPtr(90), FitToWidth: intPtr(1), FitToHeight: intPtr(1)}))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Summary"}))
+	assert.Equal(t, ErrStreamSetPageLayout, sw.SetPageLayout(&PageLayoutOptions{FitToWidth: intPtr(2)}))
+	assert.NoError(t, sw.Flush())
+	content := string(f.readBytes("xl/worksheets/sheet1.xml"))
+	assert.Contains(t, content, `<sheetPr><pageSetUpPr fitToPage="true"></pageSetUpPr></sheetPr><dimension ref="A1"/>`)
+	assert.Contains(t, content, `<pageSetup fitToHeight="1" fitToWidth="1" scale="90"></pageSetup>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetPageLayout.xlsx")))
+	opts, err := f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(90), *opts.AdjustTo)
+	assert.Equal(t, 1, *opts.FitToWidth)
+	assert.Equal(t, 1, *opts.FitToHeight)
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *props.FitToPage)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetSheetView(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterInvalid, sw.SetSheetView(nil))
+	assert.NoError(t, sw.SetSheetView(&ViewOptions{ShowZeros: boolPtr(false)}))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{0, 1}))
+	assert.Equal(t, ErrStreamSetSheetView, sw.SetSheetView(&ViewOptions{ShowZeros: boolPtr(true)}))
+	assert.NoError(t, sw.Flush())
+	r, err := sw.rawData.Reader()
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `<sheetView showZeros="false" tabSelected="true" workbookViewId="0">`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetSheetView.xlsx")))
+	opts, err := f.GetSheetView("Sheet1", -1)
+	assert.NoError(t, err)
+	assert.False(t, *opts.ShowZeros)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetDefaultStyle(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	defaultStyle, err := f.NewStyle(&Style{Font: &Font{Family: "Calibri", Size: 10}})
+	assert.NoError(t, err)
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, newInvalidStyleID(-1), sw.SetDefaultStyle(-1))
+	assert.Equal(t, newInvalidStyleID(10), sw.SetDefaultStyle(10))
+	assert.NoError(t, sw.SetColWidth(3, 4, 20))
+	assert.NoError(t, sw.SetDefaultStyle(defaultStyle))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", Cell{StyleID: boldStyle, Value: "B"}, &Cell{Value: "C"}}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{Value: 1}, {StyleID: boldStyle, Value: 2}}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"D"}, RowOpts{StyleID: boldStyle}))
+	assert.Equal(t, ErrStreamSetDefaultStyle, sw.SetDefaultStyle(defaultStyle))
+	assert.NoError(t, sw.Flush())
+	for cell, expected := range map[string]int{"A1": defaultStyle, "B1": boldStyle, "C1": defaultStyle, "A2": defaultStyle, "B2": boldStyle, "A3": boldStyle} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultStyle, ws.SheetData.Row[0].S)
+	assert.Equal(t, []xlsxCol{
+		{Min: 1, Max: 2, Width: float64Ptr(defaultColWidth), Style: defaultStyle},
+		{Min: 3, Max: 4, Width: float64Ptr(20), CustomWidth: true, Style: defaultStyle},
+		{Min: 5, Max: MaxColumns, Width: float64Ptr(defaultColWidth), Style: defaultStyle},
+	}, ws.Cols.Col)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetDefaultStyle.xlsx")))
+
+	// Test set default style with auto fit columns width
+	f2 := NewFile()
+	styleID, err := f2.NewStyle(&Style{Font: &Font{Size: 10}})
+	assert.NoError(t, err)
+	sw, err = f2.NewStreamWriter("Sheet1", StreamOptions{AutoFitColWidth: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(1, 1, 20))
+	assert.NoError(t, sw.SetDefaultStyle(styleID))
+	assert.NoError(t, sw.SetRow("B1", []interface{}{"Apple"}))
+	assert.NoError(t, sw.Flush())
+	ws, err = f2.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.Cols.Col, 3)
+	for _, col := range ws.Cols.Col {
+		assert.Equal(t, styleID, col.Style)
+	}
+	assert.NoError(t, f2.Close())
+	// Test set default style with unsupported charset style sheet
+	f3 := NewFile()
+	sw, err = f3.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	f3.Styles = nil
+	f3.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetDefaultStyle(1), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f3.Close())
+}
+
+func TestStreamAddRichValue(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	stock := &RichValue{Type: "_linkedentity", Fields: []RichValueField{
+		{Name: "%EntityServiceId", Value: 268435456},
+		{Name: "_DisplayString", Value: "Contoso"},
+		{Name: "%IsRefreshable", Value: true},
+		{Name: "Price", Value: 123.45},
+	}}
+	id1, err := sw.AddRichValue(stock)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id1)
+	stock.Fields[1].Value = "Fabrikam"
+	id2, err := sw.AddRichValue(stock)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id2)
+	id3, err := sw.AddRichValue(&RichValue{Type: "_entity", Fields: []RichValueField{{Name: "_DisplayString", Value: "Entity"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{RichValueID: id1, Value: "ignored"}, &Cell{RichValueID: id2}, "C"}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{RichValueID: id3}}))
+	assert.NoError(t, sw.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i, vm := range []uint{1, 2} {
+		c := ws.SheetData.Row[0].C[i]
+		assert.Equal(t, "e", c.T)
+		assert.Equal(t, formulaErrorVALUE, c.V)
+		assert.Equal(t, vm, *c.Vm)
+	}
+	assert.Nil(t, ws.SheetData.Row[0].C[2].Vm)
+	assert.Equal(t, uint(3), *ws.SheetData.Row[1].C[0].Vm)
+	for part, expected := range map[string][]string{
+		defaultXMLMetadata: {
+			`<metadataType name="XLRICHVALUE"`,
+			`<futureMetadata name="XLRICHVALUE" count="3"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><xlrd:rvb i="0"/></ext></extLst></bk>`,
+			`<valueMetadata count="3"><bk><rc t="1" v="0"></rc></bk><bk><rc t="1" v="1"></rc></bk><bk><rc t="1" v="2"></rc></bk></valueMetadata>`,
+		},
+		defaultXMLRdRichValuePart: {
+			`<rvData xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="3">`,
+			`<rv s="0"><v>268435456</v><v>Contoso</v><v>1</v><v>123.45</v></rv><rv s="0"><v>268435456</v><v>Fabrikam</v><v>1</v><v>123.45</v></rv><rv s="1"><v>Entity</v></rv>`,
+		},
+		defaultXMLRdRichValueStructure: {
+			`<rvStructures xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="2">`,
+			`<s t="_linkedentity"><k n="%EntityServiceId" t="i"></k><k n="_DisplayString" t="s"></k><k n="%IsRefreshable" t="b"></k><k n="Price"></k></s><s t="_entity">`,
+		},
+	} {
+		content, ok := f.Pkg.Load(part)
+		assert.True(t, ok)
+		for _, str := range expected {
+			assert.Contains(t, string(content.([]byte)), str)
+		}
+	}
+	// Test add rich values in another stream writer
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	id4, err := sw.AddRichValue(&RichValue{Type: "_entity", Fields: []RichValueField{{Name: "_DisplayString", Value: "Entity2"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id4)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{RichValueID: id4}}))
+	assert.NoError(t, sw.Flush())
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	assert.NoError(t, err)
+	relTypes := make(map[string]int)
+	for _, rel := range rels.Relationships {
+		relTypes[rel.Type]++
+	}
+	for _, relType := range []string{SourceRelationshipSheetMetadata, SourceRelationshipRdRichValue, SourceRelationshipRdRichValueStructure} {
+		assert.Equal(t, 1, relTypes[relType])
+	}
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	overrides := make(map[string]string)
+	for _, override := range contentTypes.Overrides {
+		overrides[override.PartName] = override.ContentType
+	}
+	assert.Equal(t, ContentTypeSpreadSheetMLMetadata, overrides["/xl/metadata.xml"])
+	assert.Equal(t, ContentTypeRdRichValue, overrides["/xl/richData/rdrichvalue.xml"])
+	assert.Equal(t, ContentTypeRdRichValueStructure, overrides["/xl/richData/rdrichvaluestructure.xml"])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAddRichValue.xlsx")))
+	// Test add rich value with invalid parameters
+	for _, value := range []*RichValue{
+		nil,
+		{Fields: []RichValueField{{Name: "A", Value: 1}}},
+		{Type: "_entity"},
+		{Type: "_entity", Fields: []RichValueField{{Value: 1}}},
+		{Type: "_entity", Fields: []RichValueField{{Name: "A", Value: 1}, {Name: "A", Value: 2}}},
+		{Type: "_entity", Fields: []RichValueField{{Name: "A", Value: []int{1}}}},
+	} {
+		_, err = sw.AddRichValue(value)
+		assert.Equal(t, ErrParameterInvalid, err)
+	}
+	// Test reference rich value with invalid ID
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A2", []interface{}{Cell{RichValueID: 5}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A3", []interface{}{&Cell{RichValueID: -1}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRowCells("A4", []Cell{{RichValueID: 5}}))
+	// Test the rows have been closed on error
+	assert.Equal(t, strings.Count(sw.rawData.buf.String(), "<row "), strings.Count(sw.rawData.buf.String(), "</row>"))
+	assert.NoError(t, f.Close())
+
+	// Test the row has been closed on referencing rich value with invalid ID
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A1", []interface{}{1, Cell{RichValueID: 1}}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{2}))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"2"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test add rich value to the workbook which already contains the metadata
+	f, err = OpenFile(filepath.Join("test", "TestStreamAddRichValue.xlsx"))
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	_, err = sw.AddRichValue(&RichValue{Type: "_entity", Fields: []RichValueField{{Name: "_DisplayString", Value: "Entity"}}})
+	assert.Equal(t, ErrStreamRichValue, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetSheetState(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetProps("Sheet2", &SheetPropsOptions{TabColorRGB: stringPtr("FFFF0000")}))
+	sw, err := f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetSheetState("hidden"))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A"}))
+	assert.NoError(t, sw.Flush())
+	// Test set the sheet state after flush
+	sw, err = f.NewStreamWriter("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"B"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, sw.SetSheetState("veryHidden"))
+	// Test set the sheet state with invalid state
+	assert.Equal(t, ErrSheetState, sw.SetSheetState("unknown"))
+	// Test set the activated sheet state
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetSheetState("hidden"))
+	assert.NoError(t, sw.Flush())
+
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	for sheet, expected := range map[string]string{"Sheet1": "", "Sheet2": "hidden", "Sheet3": "veryHidden"} {
+		idx, err := f.GetSheetIndex(sheet)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, f.WorkBook.Sheets.Sheet[idx].State)
+	}
+	opts, err := f.GetSheetProps("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, "FFFF0000", *opts.TabColorRGB)
+	rows, err := f.GetRows("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"B"}}, rows)
+	// Test set the sheet visible again
+	sw, err = f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetSheetState("visible"))
+	visible, err := f.GetSheetVisible("Sheet2")
+	assert.NoError(t, err)
+	assert.True(t, visible)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamWriteMaps(t *testing.T) {
+	records := []map[string]interface{}{
+		{"Name": "Apple", "Price": 1.5, "ID": 1},
+		{"ID": 2, "Name": "Orange"},
+		{"Price": 3, "ID": 3, "Note": "extra"},
+	}
+	seq := func(records []map[string]interface{}) func(yield func(map[string]interface{}) bool) {
+		return func(yield func(map[string]interface{}) bool) {
+			for _, record := range records {
+				if !yield(record) {
+					return
+				}
+			}
+		}
+	}
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Title"}))
+	// Test write maps with the given header and ignore unknown keys
+	assert.NoError(t, sw.WriteMaps([]string{"ID", "Name", "Price"}, seq(records), WriteMapsOptions{IgnoreUnknownKeys: true}))
+	// Test write maps with the header derived from the first row
+	assert.NoError(t, sw.WriteMaps(nil, seq(records[:2])))
+	// Test write maps with unknown keys
+	assert.Equal(t, newStreamMapKeyError("Note", 12), sw.WriteMaps([]string{"ID", "Name", "Price"}, seq(records)))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Title"},
+		{"ID", "Name", "Price"},
+		{"1", "Apple", "1.5"},
+		{"2", "Orange"},
+		{"3", "", "3"},
+		{"ID", "Name", "Price"},
+		{"1", "Apple", "1.5"},
+		{"2", "Orange"},
+		{"ID", "Name", "Price"},
+		{"1", "Apple", "1.5"},
+		{"2", "Orange"},
+	}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test write maps with invalid header
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SkipRows(TotalRows))
+	assert.Equal(t, ErrMaxRows, sw.WriteMaps([]string{"ID"}, seq(records)))
+	assert.Equal(t, ErrMaxRows, sw.WriteMaps(nil, seq(records)))
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetPrintOptions(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetPrintOptions(true, true, true, false))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A"}))
+	assert.NoError(t, sw.MergeCell("A1", "B1"))
+	assert.NoError(t, sw.Flush())
+	// Test set the print options after flushing the stream writer
+	assert.Equal(t, ErrStreamSetPrintOptions, sw.SetPrintOptions(false, false, false, false))
+	assert.Contains(t, string(f.readXML("xl/worksheets/sheet1.xml")), `</mergeCells><printOptions gridLines="true" headings="true" horizontalCentered="true"></printOptions></worksheet>`)
+	opts, err := f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, *opts.Horizontally)
+	assert.False(t, *opts.Vertically)
+	// Test unset the print options
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetPrintOptions(false, true, false, true))
+	assert.NoError(t, sw.SetPrintOptions(false, false, false, false))
+	assert.NoError(t, sw.Flush())
+	assert.NotContains(t, string(f.readXML("xl/worksheets/sheet1.xml")), "printOptions")
+}
+
+func TestStreamAutoFitColWidth(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{AutoFitColWidth: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(3, 3, 20))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name", true, "Fixed", 3.14159}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"Long text\nline", false, "Wider than the fixed width", 10}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"中文", nil, nil, nil, []RichTextRun{{Text: "Rich"}, {Text: " text"}}}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{nil, CellError("#N/A")}))
+	// Test large amounts of data in the temp file
+	for r := 5; r < 10000; r++ {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", r), []interface{}{r}))
+	}
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:D3"}))
+	assert.NoError(t, sw.Flush())
+	data := string(f.readXML("xl/worksheets/sheet1.xml"))
+	assert.Contains(t, data, `<dimension ref="A1:E9999"/>`)
+	assert.Contains(t, data, `<cols><col min="1" max="1" width="9.71" customWidth="1"/><col min="2" max="2" width="5.71" customWidth="1"/><col min="3" max="3" width="20" customWidth="1"/><col min="4" max="4" width="7.71" customWidth="1"/><col min="5" max="5" width="9.71" customWidth="1"/></cols><sheetData>`)
+	cols, err := f.GetCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, cols[0], 9999)
+	width, err := f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, 9.71, width)
+
+	// Test estimate the column width
+	assert.Equal(t, 0.0, estimateColWidth(""))
+	assert.Equal(t, 4.71, estimateColWidth("中文"))
+	assert.Equal(t, 4.71, estimateColWidth("ＡＢ"))
+	assert.Equal(t, float64(MaxColumnWidth), estimateColWidth(strings.Repeat("A", 300)))
+}
+
+func TestStreamSetColHyperlink(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColHyperlink(2, true))
+	assert.NoError(t, sw.SetColHyperlink(3, false))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name", "https://github.com/xuri/excelize", "https://github.com"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"Name", "https://github.com/xuri/excelize", ""}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"https://example.com", 1, "https://github.com"}))
+	assert.NoError(t, sw.Flush())
+	for cell, expected := range map[string]string{
+		"B1": "https://github.com/xuri/excelize", "C1": "https://github.com",
+		"B2": "https://github.com/xuri/excelize", "C3": "https://github.com",
+	} {
+		ok, link, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, link)
+	}
+	for _, cell := range []string{"A3", "B3", "C2"} {
+		ok, _, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.com/xuri/excelize", ws.Hyperlinks.Hyperlink[0].Display)
+	assert.Empty(t, ws.Hyperlinks.Hyperlink[1].Display)
+	// Test the identical URLs share the same relationship
+	assert.Equal(t, ws.Hyperlinks.Hyperlink[0].RID, ws.Hyperlinks.Hyperlink[2].RID)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetColHyperlink.xlsx")))
+	// Test set column hyperlink with invalid column number
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrColumnNumber, sw.SetColHyperlink(0, true))
+	assert.Equal(t, ErrColumnNumber, sw.SetColHyperlink(MaxColumns+1, true))
+	// Test set column hyperlink exceeds the hyperlinks limit
+	assert.NoError(t, sw.SetColHyperlink(1, true))
+	sw.worksheet.Hyperlinks = &xlsxHyperlinks{Hyperlink: make([]xlsxHyperlink, TotalSheetHyperlinks+1)}
+	assert.Equal(t, ErrTotalSheetHyperlinks, sw.SetRow("A1", []interface{}{"https://github.com"}))
+	// Test set cell hyperlink with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.setCellHyperlink(&xlsxC{R: "A"}, "https://github.com"))
 }
 
 func TestStreamTable(t *testing.T) {
@@ -199,8 +773,8 @@ func TestStreamTable(t *testing.T) {
 	}()
 	streamWriter, err := file.NewStreamWriter("Sheet1")
 	assert.NoError(t, err)
-	// Test add table without table header
-	assert.EqualError(t, streamWriter.AddTable(&Table{Range: "A1:C2"}), "XML syntax error on line 2: unexpected EOF")
+	// Test add table without written rows
+	assert.Equal(t, newStreamTableRangeError("A1:C2", 0), streamWriter.AddTable(&Table{Range: "A1:C2"}))
 	// Write some rows. We want enough rows to force a temp file (>16MB)
 	assert.NoError(t, streamWriter.SetRow("A1", []interface{}{"A", "B", "C"}))
 	row := []interface{}{1, 2, 3}
@@ -230,12 +804,35 @@ func TestStreamTable(t *testing.T) {
 	assert.Equal(t, newInvalidNameError("1Table"), streamWriter.AddTable(&Table{Range: "A:B1", Name: "1Table"}))
 	// Test add table with row number exceeds maximum limit
 	assert.Equal(t, ErrMaxRows, streamWriter.AddTable(&Table{Range: "A1048576:C1048576"}))
+	// Test add table with range exceeds the written rows
+	assert.Equal(t, newStreamTableRangeError("A9999:C10000", 9999), streamWriter.AddTable(&Table{Range: "A9999:C10000"}))
+	assert.Equal(t, newStreamTableRangeError("A1:C10001", 9999), streamWriter.AddTable(&Table{Range: "C10001:A1"}))
 	// Test add table with unsupported charset content types
 	file.ContentTypes = nil
 	file.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, streamWriter.AddTable(&Table{Range: "A1:C2"}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestStreamTableHeaderNotWritten(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SkipRows(1))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"A", "B"}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{1, 2}))
+	assert.NoError(t, sw.SetRow("A5", []interface{}{3, 4}))
+	assert.NoError(t, sw.SkipRows(2))
+	// Test add table with the header row has been skipped
+	assert.Equal(t, newStreamTableHeaderError(1), sw.AddTable(&Table{Range: "A1:B3"}))
+	assert.Equal(t, newStreamTableHeaderError(4), sw.AddTable(&Table{Range: "A4:B5"}))
+	assert.Equal(t, newStreamTableHeaderError(6), sw.AddTable(&Table{Range: "A6:B7"}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A2:B3"}))
+	assert.NoError(t, sw.Flush())
+}
+
 func TestStreamMergeCells(t *testing.T) {
 	file := NewFile()
 	defer func() {
@@ -246,9 +843,86 @@ func TestStreamMergeCells(t *testing.T) {
 	assert.NoError(t, streamWriter.MergeCell("A1", "D1"))
 	// Test merge cells with illegal cell reference
 	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), streamWriter.MergeCell("A", "D1"))
+	// Test merge cells by the slice of ranges
+	assert.NoError(t, streamWriter.MergeCells([]string{"E1:F1", "C3:A2", "$G$1:$H$3"}))
+	assert.NoError(t, streamWriter.MergeCells(nil))
+	// Test merge cells with overlapped ranges, no range will be merged
+	assert.Equal(t, newStreamMergeCellOverlapError("A5:C6", "B6:B7"), streamWriter.MergeCells([]string{"A5:C6", "D5:E5", "B6:B7", "A4:A8"}))
+	assert.Equal(t, newStreamMergeCellOverlapError("D9:E9", "E9:F10"), streamWriter.MergeCells([]string{"A12:B12", "D9:E9", "A9:B10", "E9:F10", "A10:A11"}))
+	// Test merge cells with invalid ranges
+	assert.Equal(t, ErrParameterInvalid, streamWriter.MergeCells([]string{"A20:B20", "A21"}))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), streamWriter.MergeCells([]string{"A:B20"}))
+	assert.Equal(t, 4, streamWriter.mergeCellsCount)
 	assert.NoError(t, streamWriter.Flush())
 	// Save spreadsheet by the given path
 	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamMergeCells.xlsx")))
+	mergeCells, err := file.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	var refs []string
+	for _, mergeCell := range mergeCells {
+		refs = append(refs, mergeCell.GetStartAxis()+":"+mergeCell.GetEndAxis())
+	}
+	assert.Equal(t, []string{"A1:D1", "E1:F1", "A2:C3", "G1:H3"}, refs)
+}
+
+func TestStreamStrictMergeCells(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{StrictMergeCells: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.MergeCell("A1", "B2"))
+	// Test merge cells overlapped with the range merged before
+	assert.Equal(t, newStreamMergeCellOverlapError("A1:B2", "B2:C3"), sw.MergeCell("B2", "C3"))
+	assert.Equal(t, newStreamMergeCellOverlapError("A1:B2", "A2:A3"), sw.MergeCells([]string{"D1:E1", "A2:A3"}))
+	assert.NoError(t, sw.MergeCells([]string{"D1:E1", "C3:C4"}))
+	assert.Equal(t, 3, sw.mergeCellsCount)
+	// Test set values on the top-left cells, the styles on the covered cells
+	// and the value on the covered cell
+	assert.Equal(t, newStreamMergeCellValueError("E1", "D1:E1"), sw.SetRow("A1", []interface{}{"Merged", Cell{StyleID: 1}, nil, Cell{Formula: "A1"}, 1}))
+	assert.Equal(t, newStreamMergeCellValueError("B2", "A1:B2"), sw.SetRowCells("A2", []Cell{{StyleID: 1}, {Formula: "A1"}}))
+	// Test merge cells after the covered rows have been written
+	assert.NoError(t, sw.SetRow("A3", []interface{}{1, 2, 3}))
+	assert.NoError(t, sw.SetRow("A5", []interface{}{"A", "B"}))
+	assert.NoError(t, sw.MergeCell("A5", "A6"))
+	// Test checkpoint and resume the strict merged cells mode
+	state, err := sw.Checkpoint()
+	assert.NoError(t, err)
+	resumed, err := f.ResumeStreamWriter("Sheet1", state)
+	assert.NoError(t, err)
+	assert.True(t, resumed.strictMerge)
+	assert.Equal(t, sw.mergeRanges, resumed.mergeRanges)
+	assert.NoError(t, resumed.MergeCell("A3", "B3"))
+	assert.Equal(t, newStreamMergeCellValueError("B3", "A3:B3"), resumed.Flush())
+	assert.NoError(t, resumed.rawData.Close())
+
+	// Test the late merged cell range without values on the covered cells
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{StrictMergeCells: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Merged", Cell{StyleID: 1}}))
+	assert.NoError(t, sw.MergeCell("A1", "B1"))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamStrictMergeCells.xlsx")))
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "Merged", mergeCells[0].GetCellValue())
+	assert.NoError(t, f.Close())
+
+	// Test resume the stream writer with invalid merged cell range
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{StrictMergeCells: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.MergeCell("A1", "B1"))
+	state, err = sw.Checkpoint()
+	assert.NoError(t, err)
+	_, err = f.ResumeStreamWriter("Sheet1", []byte(strings.Replace(string(state), `ref="A1:B1"`, `ref="A1"`, 1)))
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, sw.rawData.Close())
+	assert.NoError(t, f.Close())
+	assert.NoError(t, RemoveStreamCheckpoint(state))
 }
 
 func TestStreamInsertPageBreak(t *testing.T) {
@@ -279,6 +953,61 @@ func TestNewStreamWriter(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestNewStreamWriterWithNamespaces(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{
+		Namespaces: []xml.Attr{NameSpaceSpreadSheet, SourceRelationship},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1, 2}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B2"}))
+	assert.NoError(t, sw.Flush())
+	r, err := sw.rawData.Reader()
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	assert.NotContains(t, string(b), "mc:Ignorable")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNewStreamWriterWithNamespaces.xlsx")))
+	val, err := f.GetCellValue("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "B", val)
+}
+
+func TestNewStreamWriterWithXMLHeader(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	for _, header := range []string{"", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"} {
+		sw, err := f.NewStreamWriter("Sheet1", StreamOptions{XMLHeader: &header})
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{"A"}))
+		assert.NoError(t, sw.Flush())
+		r, err := sw.rawData.Reader()
+		assert.NoError(t, err)
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(b), header+"<worksheet "))
+		val, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "A", val)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNewStreamWriterWithXMLHeader.xlsx")))
+	// Test new stream writer with the default XML header
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sw.rawData.buf.String(), xml.Header+"<worksheet "))
+	// Test new stream writer with invalid XML header
+	header := "<worksheet>"
+	_, err = f.NewStreamWriter("Sheet1", StreamOptions{XMLHeader: &header})
+	assert.Equal(t, ErrParameterInvalid, err)
+}
+
 func TestStreamMarshalAttrs(t *testing.T) {
 	var r *RowOpts
 	attrs, err := r.marshalAttrs()
@@ -347,61 +1076,963 @@ func TestStreamSetRowWithStyle(t *testing.T) {
 	}
 }
 
-func TestStreamSetCellValFunc(t *testing.T) {
+func TestStreamSetRowForceText(t *testing.T) {
 	f := NewFile()
 	defer func() {
 		assert.NoError(t, f.Close())
 	}()
 	sw, err := f.NewStreamWriter("Sheet1")
 	assert.NoError(t, err)
-	c := &xlsxC{}
-	for _, val := range []interface{}{
-		128,
-		int8(-128),
-		int16(-32768),
-		int32(-2147483648),
-		int64(-9223372036854775808),
-		uint(128),
-		uint8(255),
-		uint16(65535),
-		uint32(4294967295),
-		uint64(18446744073709551615),
-		float32(100.1588),
-		100.1588,
-		" Hello",
-		[]byte(" Hello"),
-		time.Now().UTC(),
-		time.Duration(1e13),
-		true,
-		nil,
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		Cell{Value: 7, ForceText: true},
+		Cell{Value: "007", ForceText: true},
+		&Cell{Value: 15551234567, ForceText: true},
+		Cell{Value: true, ForceText: true},
+		Cell{Value: false, ForceText: true},
+		Cell{Value: 1.5, Formula: "A1", ForceText: true},
+		Cell{ForceText: true},
+		Cell{Value: 7},
+	}))
+	assert.NoError(t, sw.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i, expected := range []struct{ T, V string }{
+		{"inlineStr", ""}, {"inlineStr", ""}, {"inlineStr", ""}, {"inlineStr", ""},
+		{"inlineStr", ""}, {"str", "1.5"}, {"", ""}, {"", "7"},
+	} {
+		assert.Equal(t, expected.T, ws.SheetData.Row[0].C[i].T, i)
+		assert.Equal(t, expected.V, ws.SheetData.Row[0].C[i].V, i)
+	}
+	for cell, expected := range map[string]string{"A1": "7", "B1": "007", "C1": "15551234567", "D1": "TRUE", "E1": "FALSE", "H1": "7"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+}
+
+func TestStreamSetCellFormulaResultType(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		1, 2,
+		Cell{Formula: "A1+B1"},
+		&Cell{Formula: "A1*B1", ResultType: CellTypeNumber},
+		Cell{Formula: "TODAY()", ResultType: CellTypeDate},
+		Cell{Formula: "A1>B1", ResultType: CellTypeBool},
+		Cell{Formula: "A1/0", ResultType: CellTypeError},
+		Cell{Formula: "A1&B1", ResultType: CellTypeInlineString},
+		Cell{Formula: "A1&B1", ResultType: CellTypeSharedString},
+		Cell{Formula: "A1&B1", Value: "12", ResultType: CellTypeNumber},
+	}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{Formula: "B1-A1"}, {Formula: "NOT(TRUE)", ResultType: CellTypeBool}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A3", []interface{}{Cell{Formula: "A1", ResultType: CellTypeFormula}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRowCells("A4", []Cell{{Formula: "A1", ResultType: CellTypeSharedString + 1}}))
+	assert.NoError(t, sw.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for i, expected := range []string{"", "", "", "", "", "b", "e", "str", "str", "str"} {
+		assert.Equal(t, expected, ws.SheetData.Row[0].C[i].T, i)
+	}
+	assert.NotNil(t, ws.SheetData.Row[0].C[2].F)
+	assert.Equal(t, "", ws.SheetData.Row[1].C[0].T)
+	assert.Equal(t, "b", ws.SheetData.Row[1].C[1].T)
+	assert.Equal(t, 0, ws.SheetData.Row[0].C[3].S)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 22, *styles.CellXfs.Xf[ws.SheetData.Row[0].C[4].S].NumFmtID)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamFullCalcOnLoad(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1, 2}))
+	assert.NoError(t, sw.Flush())
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxCalcPr{CalcID: "122211"}, wb.CalcPr)
+	// Test set full calculation on load with formulas
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1, 2, Cell{Formula: "A1+B1"}}))
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, &xlsxCalcPr{CalcID: "122211", FullCalcOnLoad: true}, wb.CalcPr)
+	wb.CalcPr = nil
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRowCells("A1", []Cell{{Formula: "1+1"}}))
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, &xlsxCalcPr{FullCalcOnLoad: true}, wb.CalcPr)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamFullCalcOnLoad.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test flush stream writer with unsupported charset workbook
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRowCells("A1", []Cell{{Formula: "1+1"}}))
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.Flush(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetRowBorders(t *testing.T) {
+	f := NewFile()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	borderStyle, err := f.NewStyle(&Style{Border: []Border{{Type: "left", Color: "FF0000", Style: 1}, {Type: "bottom", Color: "FF0000", Style: 1}}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Total", 100, Cell{StyleID: boldStyle, Value: 1}, date}, RowOpts{TopBorder: RowBorderThick}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{Value: "Total"}, {Value: 200}}, RowOpts{TopBorder: RowBorderThick}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"Data"}, RowOpts{StyleID: borderStyle, BottomBorder: RowBorderDouble, BorderColor: "0000FF"}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{"Data"}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A5", []interface{}{"Data"}, RowOpts{TopBorder: RowBorderDouble + 1}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A6", []interface{}{"Data"}, RowOpts{BottomBorder: RowBorderDouble + 1, ValidateFirst: true}))
+	assert.NoError(t, sw.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	rows := ws.SheetData.Row
+	assert.Len(t, rows, 4)
+	topStyle := rows[0].S
+	assert.True(t, rows[0].CustomFormat)
+	assert.Equal(t, topStyle, rows[1].S)
+	assert.Equal(t, topStyle, rows[0].C[0].S)
+	assert.Equal(t, topStyle, rows[0].C[1].S)
+	assert.Equal(t, topStyle, rows[1].C[0].S)
+	assert.Equal(t, topStyle, rows[1].C[1].S)
+	assert.Equal(t, 0, rows[3].S)
+	assert.Equal(t, 0, rows[3].C[0].S)
+	style, err := f.GetStyle(topStyle)
+	assert.NoError(t, err)
+	assert.Equal(t, []Border{{Type: "top", Color: "000000", Style: 5}}, style.Border)
+	style, err = f.GetStyle(rows[0].C[2].S)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, []Border{{Type: "top", Color: "000000", Style: 5}}, style.Border)
+	style, err = f.GetStyle(rows[0].C[3].S)
+	assert.NoError(t, err)
+	assert.Equal(t, 22, style.NumFmt)
+	assert.Equal(t, []Border{{Type: "top", Color: "000000", Style: 5}}, style.Border)
+	style, err = f.GetStyle(rows[2].S)
+	assert.NoError(t, err)
+	assert.Equal(t, []Border{{Type: "left", Color: "FF0000", Style: 1}, {Type: "bottom", Color: "0000FF", Style: 6}}, style.Border)
+	assert.Equal(t, rows[2].S, rows[2].C[0].S)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetRowColors(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Passed", 100, Cell{StyleID: boldStyle, Value: 1}}, RowOpts{FillColor: "C6EFCE", FontColor: "006100"}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{Value: "Passed"}}, RowOpts{FillColor: "#c6efce", FontColor: "006100"}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"Failed"}, RowOpts{StyleID: boldStyle, FillColor: "FFC7CE", TopBorder: RowBorderThin}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{"Data"}))
+	assert.Len(t, sw.rowColorStyles, 2)
+	// Test checkpoint and resume the stream writer with the row colors
+	state, err := sw.Checkpoint()
+	assert.NoError(t, err)
+	resumed, err := f.ResumeStreamWriter("Sheet1", state)
+	assert.NoError(t, err)
+	assert.Equal(t, sw.rowColorStyles, resumed.rowColorStyles)
+	assert.NoError(t, resumed.SetRow("A5", []interface{}{"Passed"}, RowOpts{FillColor: "C6EFCE", FontColor: "006100"}))
+	assert.Len(t, resumed.rowColorStyles, 2)
+	assert.NoError(t, resumed.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	rows := ws.SheetData.Row
+	assert.Len(t, rows, 5)
+	passedStyle := rows[0].S
+	assert.True(t, rows[0].CustomFormat)
+	for _, styleID := range []int{rows[0].C[0].S, rows[0].C[1].S, rows[1].S, rows[1].C[0].S, rows[4].S, rows[4].C[0].S} {
+		assert.Equal(t, passedStyle, styleID)
+	}
+	style, err := f.GetStyle(passedStyle)
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"C6EFCE"}}, style.Fill)
+	assert.Equal(t, "006100", style.Font.Color)
+	// Test the cell with an explicit style ID keeps its own style
+	assert.Equal(t, boldStyle, rows[0].C[2].S)
+	// Test the row colors combined with the row style and borders
+	style, err = f.GetStyle(rows[2].C[0].S)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, []string{"FFC7CE"}, style.Fill.Color)
+	assert.Equal(t, []Border{{Type: "top", Color: "000000", Style: 1}}, style.Border)
+	assert.Equal(t, 0, rows[3].S)
+
+	assert.NoError(t, f.Close())
+
+	// Test set row colors with the invalid style ID
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, newInvalidStyleID(10), sw.SetRow("A1", []interface{}{"Data"}, RowOpts{StyleID: 10, FillColor: "FF0000"}))
+	assert.NoError(t, sw.rawData.Close())
+}
+
+func TestStreamSetRowCells(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRowCells("A1", []Cell{
+		{StyleID: styleID, Value: "Data"},
+		{Value: 1},
+		{},
+		{Formula: "SUM(B1,B1)"},
+		{Value: 7, ForceText: true},
+	}, RowOpts{Height: 20}))
+	assert.NoError(t, sw.SetRowCells("B2", []Cell{{StyleID: styleID}}))
+	// Test set row cells with invalid row number
+	assert.Equal(t, newStreamSetRowError(2), sw.SetRowCells("A2", []Cell{{Value: 1}}))
+	// Test set row cells with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.SetRowCells("A", []Cell{{Value: 1}}))
+	// Test set row cells with invalid row options
+	assert.Equal(t, ErrMaxRowHeight, sw.SetRowCells("A3", []Cell{{Value: 1}}, RowOpts{Height: MaxRowHeight + 1}))
+	assert.NoError(t, sw.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, *ws.SheetData.Row[0].Ht)
+	assert.Equal(t, styleID, ws.SheetData.Row[0].C[0].S)
+	assert.Nil(t, ws.SheetData.Row[0].C[2].F)
+	assert.Equal(t, "SUM(B1,B1)", ws.SheetData.Row[0].C[3].F.Content)
+	assert.Equal(t, "inlineStr", ws.SheetData.Row[0].C[4].T)
+	assert.Equal(t, styleID, ws.SheetData.Row[1].C[1].S)
+	for cell, expected := range map[string]string{"A1": "Data", "B1": "1", "E1": "7"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+}
+
+func TestStreamSetStringRow(t *testing.T) {
+	styleID := 1
+	values := []string{"'123", "https://github.com", " text ", "", "Text"}
+	// Test the string row is the same as the row written by SetRow
+	var rows [][]byte
+	for _, setStringRow := range []bool{false, true} {
+		f := NewFile(Options{ApostropheAsQuotePrefix: true})
+		_, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+		assert.NoError(t, err)
+		sw, err := f.NewStreamWriter("Sheet1")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetColHyperlink(2, true))
+		assert.NoError(t, sw.SetColumnTypes([]ColumnType{ColumnTypeAuto, ColumnTypeAuto, ColumnTypeAuto, ColumnTypeAuto, ColumnTypeText}))
+		if setStringRow {
+			assert.NoError(t, sw.SetStringRow("B2", values, RowOpts{StyleID: styleID, Height: 20}))
+		} else {
+			row := make([]interface{}, len(values))
+			for i, value := range values {
+				row[i] = value
+			}
+			assert.NoError(t, sw.SetRow("B2", row, RowOpts{StyleID: styleID, Height: 20}))
+		}
+		assert.NoError(t, sw.Flush())
+		r, err := sw.rawData.Reader()
+		assert.NoError(t, err)
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		rows = append(rows, b)
+		assert.NoError(t, f.Close())
+	}
+	assert.Equal(t, string(rows[0]), string(rows[1]))
+
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetStringRow("A1", values))
+	// Test set string row with invalid row number
+	assert.Equal(t, newStreamSetRowError(1), sw.SetStringRow("A1", values))
+	assert.Equal(t, newStreamSetRowError(1), sw.SetStringRow("A1", values, RowOpts{ValidateFirst: true}))
+	// Test set string row with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.SetStringRow("A", values))
+	// Test set string row exceeds the maximum column number
+	cell, err := CoordinatesToCellName(MaxColumns, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrColumnNumber, sw.SetStringRow(cell, values))
+	// Test set string row with the mismatched column type
+	assert.NoError(t, sw.SetColumnTypes([]ColumnType{ColumnTypeInt}))
+	assert.Equal(t, newStreamColumnTypeError("A3", "1", ColumnTypeInt), sw.SetStringRow("A3", []string{"1"}))
+	assert.NoError(t, sw.Flush())
+	for cell, expected := range map[string]string{"A1": "'123", "C1": " text ", "D1": ""} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamFillRow(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.FillRow("B1", "-", 3, RowOpts{StyleID: styleID}))
+	assert.NoError(t, sw.FillRow("A2", Cell{StyleID: styleID, Value: 0}, 2))
+	assert.NoError(t, sw.FillRow("A3", nil, 5, RowOpts{Height: 20}))
+	assert.NoError(t, sw.FillRow("A4", &Cell{Formula: "ROW()"}, 2))
+	// Test fill row with invalid count
+	assert.Equal(t, ErrParameterInvalid, sw.FillRow("A5", 1, -1))
+	// Test fill row exceeds the maximum columns
+	assert.Equal(t, ErrColumnNumber, sw.FillRow("XFD5", 1, 2))
+	// Test fill row with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.FillRow("A", 1, 1))
+	// Test fill row with invalid row number
+	assert.Equal(t, newStreamSetRowError(4), sw.FillRow("A4", 1, 1))
+	// Test fill row with invalid error value and validate first
+	assert.Equal(t, newInvalidCellErrorError("#n/a"), sw.FillRow("A5", CellError("#n/a"), 2, RowOpts{ValidateFirst: true}))
+	assert.NoError(t, sw.Flush())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.SheetData.Row[0].C, 4)
+	assert.Len(t, ws.SheetData.Row[1].C, 2)
+	assert.Empty(t, ws.SheetData.Row[2].C)
+	assert.Equal(t, 20.0, *ws.SheetData.Row[2].Ht)
+	for _, cell := range []string{"B1", "C1", "D1", "A2", "B2"} {
+		style, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, style, cell)
+	}
+	for cell, expected := range map[string]string{"A1": "", "B1": "-", "D1": "-", "B2": "0"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "B4")
+	assert.NoError(t, err)
+	assert.Equal(t, "ROW()", formula)
+}
+
+// testSQLConnector implements a minimal database driver connection, statement
+// and rows returning the given columns and rows for testing.
+type testSQLConnector struct {
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+func (c *testSQLConnector) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *testSQLConnector) Driver() driver.Driver                        { return nil }
+func (c *testSQLConnector) Prepare(string) (driver.Stmt, error)          { return c, nil }
+func (c *testSQLConnector) Begin() (driver.Tx, error)                    { return nil, driver.ErrSkip }
+func (c *testSQLConnector) Close() error                                 { return nil }
+func (c *testSQLConnector) NumInput() int                                { return 0 }
+func (c *testSQLConnector) Exec([]driver.Value) (driver.Result, error)   { return nil, driver.ErrSkip }
+func (c *testSQLConnector) Query([]driver.Value) (driver.Rows, error) {
+	return &testSQLRows{connector: c}, nil
+}
+
+type testSQLRows struct {
+	connector *testSQLConnector
+	idx       int
+}
+
+func (r *testSQLRows) Columns() []string { return r.connector.columns }
+func (r *testSQLRows) Close() error      { return nil }
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.connector.rows) {
+		if r.connector.err != nil {
+			return r.connector.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.connector.rows[r.idx])
+	r.idx++
+	return nil
+}
+
+func TestStreamSetColumnTypes(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColumnTypes([]ColumnType{
+		ColumnTypeInt, ColumnTypeFloat, ColumnTypeDate, ColumnTypeText, ColumnTypeBool, ColumnTypeAuto,
+	}))
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1, 1.5, date, "Apple", true, "auto", 7}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{uint8(2), float32(2.5), date, []byte("Orange"), false, 3, 8}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{nil, 3, nil, nil, nil, nil}))
+	assert.NoError(t, sw.SetRowCells("A4", []Cell{{Value: int64(4)}, {Formula: "B1+B2"}, {Value: date}}))
+	// Test set row with mismatched values
+	for i, val := range []interface{}{"1", "1.5", "2024-01-02", 1, "TRUE"} {
+		colType := ColumnType(i + 1)
+		cell, err := CoordinatesToCellName(i+1, 2*i+5)
+		assert.NoError(t, err)
+		assert.Equal(t, newStreamColumnTypeError(cell, val, colType), sw.SetRow(cell, []interface{}{val}, RowOpts{ValidateFirst: true}), cell)
+		assert.Equal(t, newStreamColumnTypeError(cell, val, colType), sw.SetRow(cell, []interface{}{val}), cell)
+		cell, err = CoordinatesToCellName(i+1, 2*i+6)
+		assert.NoError(t, err)
+		assert.Equal(t, newStreamColumnTypeError(cell, val, colType), sw.SetRowCells(cell, []Cell{{Value: val}}), cell)
+	}
+	assert.EqualError(t, sw.SetRow("D15", []interface{}{1}), "value 1 of type int in cell D15 does not match the column type text")
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "1.5", "1/2/24 00:00", "Apple", "TRUE", "auto", "7"}, rows[0])
+	assert.Equal(t, []string{"2", "2.5", "1/2/24 00:00", "Orange", "FALSE", "3", "8"}, rows[1])
+	assert.Equal(t, []string{"", "3"}, rows[2])
+	for _, cell := range []string{"C1", "C2", "C4"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, sw.dateStyleID, styleID, cell)
+	}
+	cellType, err := f.GetCellType("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeInlineString, cellType)
+	cellType, err = f.GetCellType("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+
+	// Test set column types with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, sw.SetColumnTypes([]ColumnType{ColumnTypeBool + 1}))
+	assert.Equal(t, ErrColumnNumber, sw.SetColumnTypes(make([]ColumnType, MaxColumns+1)))
+	// Test set column types with unsupported charset workbook
+	f2 := NewFile()
+	sw, err = f2.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	f2.WorkBook = nil
+	f2.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetColumnTypes([]ColumnType{ColumnTypeDate}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f2.Close())
+}
+
+func TestStreamWriteSQLRows(t *testing.T) {
+	date := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	connector := &testSQLConnector{
+		columns: []string{"id", "name", "price", "active", "created", "note"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Apple"), []byte("1.5"), true, date, nil},
+			{int64(2), "Orange", []byte("2.25"), false, nil, []byte("sold out")},
+		},
+	}
+	db := sql.OpenDB(connector)
+	defer func() {
+		assert.NoError(t, db.Close())
+	}()
+	query := func() *sql.Rows {
+		rows, err := db.Query("SELECT")
+		assert.NoError(t, err)
+		return rows
+	}
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	rows := query()
+	assert.NoError(t, sw.WriteSQLRows("B2", rows, SQLExportOptions{
+		HeaderStyleID: styleID,
+		Converter: func(column *sql.ColumnType, value interface{}) (interface{}, error) {
+			if b, ok := value.([]byte); ok && column.Name() == "price" {
+				return strconv.ParseFloat(string(b), 64)
+			}
+			return value, nil
+		},
+	}))
+	assert.NoError(t, rows.Close())
+	// Test write SQL rows without header
+	rows = query()
+	assert.NoError(t, sw.WriteSQLRows("A5", rows, SQLExportOptions{SkipHeader: true}))
+	assert.NoError(t, rows.Close())
+	// Test write SQL rows with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, sw.WriteSQLRows("A8", nil, SQLExportOptions{}))
+	rows = query()
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.WriteSQLRows("A", rows, SQLExportOptions{}))
+	// Test write SQL rows with invalid row number
+	assert.Equal(t, newStreamSetRowError(6), sw.WriteSQLRows("A6", rows, SQLExportOptions{}))
+	assert.Equal(t, newStreamSetRowError(6), sw.WriteSQLRows("A6", rows, SQLExportOptions{SkipHeader: true}))
+	assert.NoError(t, rows.Close())
+	// Test write SQL rows with converter error
+	rows = query()
+	expected := errors.New("converter error")
+	assert.Equal(t, expected, sw.WriteSQLRows("A8", rows, SQLExportOptions{
+		SkipHeader: true,
+		Converter: func(*sql.ColumnType, interface{}) (interface{}, error) {
+			return nil, expected
+		},
+	}))
+	assert.NoError(t, rows.Close())
+	// Test write SQL rows with the error on iteration
+	connector.err = errors.New("iteration error")
+	rows = query()
+	assert.Equal(t, connector.err, sw.WriteSQLRows("A10", rows, SQLExportOptions{SkipHeader: true}))
+	assert.NoError(t, rows.Close())
+	// Test write SQL rows with closed rows
+	assert.Error(t, sw.WriteSQLRows("A20", rows, SQLExportOptions{}))
+	assert.NoError(t, sw.Flush())
+
+	for cell, expected := range map[string]string{
+		"B2": "id", "G2": "note", "B3": "1", "C3": "Apple", "D3": "1.5", "E3": "TRUE",
+		"G3": "", "C4": "Orange", "D4": "2.25", "E4": "FALSE", "F4": "", "G4": "sold out",
+		"A5": "1", "C5": "1.5", "A6": "2", "A10": "1", "A11": "2",
+	} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]CellType{"E3": CellTypeBool, "C5": CellTypeInlineString, "D5": CellTypeBool} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cellType, cell)
+	}
+	style, err := f.GetCellStyle("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, style)
+	val, err := f.GetCellValue("Sheet1", "F3", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "45293", val)
+}
+
+func TestStreamBoolAsText(t *testing.T) {
+	for _, opts := range []Options{{}, {BoolAsText: true}} {
+		f := NewFile(opts)
+		sw, err := f.NewStreamWriter("Sheet1")
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow("A1", []interface{}{true, false}))
+		assert.NoError(t, sw.Flush())
+		expected := CellTypeBool
+		if opts.BoolAsText {
+			expected = CellTypeInlineString
+		}
+		for cell, value := range map[string]string{"A1": "TRUE", "B1": "FALSE"} {
+			cellType, err := f.GetCellType("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, cellType)
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, value, val)
+		}
+		assert.NoError(t, f.Close())
+	}
+}
+
+func TestStreamQuotePrefix(t *testing.T) {
+	f := NewFile(Options{ApostropheAsQuotePrefix: true})
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"'123", "text", Cell{Value: "'0012"}, Cell{Formula: `"'A"`, Value: "'A"}}, RowOpts{BottomBorder: RowBorderThin}))
+	assert.NoError(t, sw.Flush())
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "123", "B1": "text", "C1": "0012", "D1": "'A"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+		ID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		xf := s.CellXfs.Xf[ID]
+		assert.Equal(t, cell == "A1" || cell == "C1", xf.QuotePrefix != nil && *xf.QuotePrefix, cell)
+		assert.NotZero(t, *xf.BorderID, cell)
+	}
+	assert.NoError(t, f.Close())
+	// Test write quote prefix text with invalid style ID
+	f = NewFile(Options{ApostropheAsQuotePrefix: true})
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, newInvalidStyleID(100), sw.SetRow("A1", []interface{}{Cell{StyleID: 100, Value: "'123"}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamCellError(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{CellError("#N/A"), Cell{Value: CellError("#DIV/0!")}, CellError("#VALUE!")}))
+	// Test set row with invalid error literal
+	assert.Equal(t, newInvalidCellErrorError("#n/a"), sw.SetRow("A2", []interface{}{CellError("#n/a")}, RowOpts{ValidateFirst: true}))
+	assert.Equal(t, newInvalidCellErrorError("#ERR"), sw.SetRowCells("A3", []Cell{{Value: CellError("#ERR")}}))
+	assert.NoError(t, sw.Flush())
+	for cell, value := range map[string]string{"A1": "#N/A", "B1": "#DIV/0!", "C1": "#VALUE!"} {
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeError, cellType)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, value, val)
+	}
+}
+
+func TestStreamSetRowValidateFirst(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	opts := RowOpts{ValidateFirst: true}
+	invalid := []RichTextRun{{Text: strings.Repeat("s", TotalCellChars+1)}}
+	// Test the invalid value aborts the row without writing any cell
+	assert.Equal(t, ErrCellCharsLength, sw.SetRow("A1", []interface{}{"A", Cell{Value: invalid}}, opts))
+	assert.Equal(t, ErrCellCharsLength, sw.SetRow("A1", []interface{}{"A", &Cell{Value: invalid}}, opts))
+	assert.Equal(t, ErrCellCharsLength, sw.SetRowCells("A1", []Cell{{Value: "A"}, {Value: invalid}}, opts))
+	assert.Equal(t, ErrColumnNumber, sw.SetRow("XFD1", []interface{}{"A", nil, "B"}, opts))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.SetRow("A", []interface{}{"A"}, opts))
+	assert.Equal(t, ErrMaxRowHeight, sw.SetRow("A1", []interface{}{"A"}, RowOpts{ValidateFirst: true, Height: MaxRowHeight + 1}))
+	assert.Equal(t, 0, sw.rows)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", Cell{Value: "B"}}, opts))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{Value: "C"}, {Value: "D"}}, opts))
+	assert.Equal(t, newStreamSetRowError(2), sw.SetRow("A2", []interface{}{"A"}, opts))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "B"}, {"C", "D"}}, rows)
+}
+
+func TestStreamSetRowValidateFirstCells(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{StrictMergeCells: true, ValidateFormulaRefs: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.MergeCell("B1", "C1"))
+	opts := RowOpts{ValidateFirst: true}
+	// Test the invalid cell aborts the row without writing any cell
+	for _, c := range []struct {
+		cells    []Cell
+		expected error
+	}{
+		{cells: []Cell{{Value: "A"}, {Value: 1, NumFmt: "0.0", DisplayText: "1"}}, expected: ErrParameterInvalid},
+		{cells: []Cell{{Value: "A"}, {Formula: "A1", ResultType: CellTypeFormula}}, expected: ErrParameterInvalid},
+		{cells: []Cell{{Value: "A"}, {RichValueID: 1}}, expected: ErrParameterInvalid},
+		{cells: []Cell{{Value: "A"}, {Formula: "SheetN!A1"}}, expected: newStreamFormulaSheetRefError("B1", "SheetN")},
+		{cells: []Cell{{Value: "A"}, {Value: "B"}, {Value: "C"}}, expected: newStreamMergeCellValueError("C1", "B1:C1")},
+		{cells: []Cell{{Value: "A"}, {Value: "B"}, {Formula: "A1"}}, expected: newStreamMergeCellValueError("C1", "B1:C1")},
+	} {
+		values := make([]interface{}, len(c.cells))
+		for i := range c.cells {
+			values[i] = c.cells[i]
+		}
+		assert.Equal(t, c.expected, sw.SetRow("A1", values, opts))
+		values[len(values)-1] = &c.cells[len(c.cells)-1]
+		assert.Equal(t, c.expected, sw.SetRow("A1", values, opts))
+		assert.Equal(t, c.expected, sw.SetRowCells("A1", c.cells, opts))
+	}
+	assert.Equal(t, ErrParameterInvalid, sw.FillRow("A1", Cell{RichValueID: -1}, 2, opts))
+	assert.Equal(t, newStreamMergeCellValueError("C1", "B1:C1"), sw.SetStringRow("A1", []string{"A", "B", "C"}, opts))
+	assert.Equal(t, 0, sw.rows)
+	assert.Empty(t, sw.dimension)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B", Cell{StyleID: 1}, Cell{Formula: "A1", NumFmt: "0.0"}}, opts))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "B", "", ""}}, rows)
+}
+
+func TestStreamSkipRows(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SkipRows(1))
+	assert.Equal(t, newStreamSetRowError(1), sw.SetRow("A1", []interface{}{1}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"Section 1"}))
+	assert.NoError(t, sw.SkipRows(0))
+	assert.NoError(t, sw.SkipRows(2))
+	for row := 3; row <= 4; row++ {
+		assert.Equal(t, newStreamSetRowError(row), sw.SetRow(fmt.Sprintf("A%d", row), []interface{}{1}))
+	}
+	assert.NoError(t, sw.SetRow("A5", []interface{}{"Section 2"}))
+	// Test skip rows with invalid number of rows
+	assert.Equal(t, ErrParameterInvalid, sw.SkipRows(-1))
+	assert.Equal(t, ErrMaxRows, sw.SkipRows(TotalRows))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"Section 1"}, nil, nil, {"Section 2"}}, rows)
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2:A5", dimension)
+}
+
+func TestStreamSetCellValFunc(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	c := &xlsxC{}
+	for _, val := range []interface{}{
+		128,
+		int8(-128),
+		int16(-32768),
+		int32(-2147483648),
+		int64(-9223372036854775808),
+		uint(128),
+		uint8(255),
+		uint16(65535),
+		uint32(4294967295),
+		uint64(18446744073709551615),
+		float32(100.1588),
+		100.1588,
+		" Hello",
+		[]byte(" Hello"),
+		time.Now().UTC(),
+		time.Duration(1e13),
+		true,
+		nil,
 		complex64(5 + 10i),
 	} {
 		assert.NoError(t, sw.setCellValFunc(c, val))
 	}
 }
 
-func TestStreamWriterOutlineLevel(t *testing.T) {
-	file := NewFile()
-	streamWriter, err := file.NewStreamWriter("Sheet1")
+func TestStreamWriterOutlineLevel(t *testing.T) {
+	file := NewFile()
+	streamWriter, err := file.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+
+	// Test set outlineLevel in row
+	assert.NoError(t, streamWriter.SetRow("A1", nil, RowOpts{OutlineLevel: 1}))
+	assert.NoError(t, streamWriter.SetRow("A2", nil, RowOpts{OutlineLevel: 7}))
+	assert.ErrorIs(t, ErrOutlineLevel, streamWriter.SetRow("A3", nil, RowOpts{OutlineLevel: 8}))
+
+	assert.NoError(t, streamWriter.Flush())
+	// Save spreadsheet by the given path
+	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamWriterSetRowOutlineLevel.xlsx")))
+
+	file, err = OpenFile(filepath.Join("test", "TestStreamWriterSetRowOutlineLevel.xlsx"))
+	assert.NoError(t, err)
+	for rowIdx, expected := range []uint8{1, 7, 0} {
+		level, err := file.GetRowOutlineLevel("Sheet1", rowIdx+1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level)
+	}
+	assert.NoError(t, file.Close())
+}
+
+func TestStreamSetOutlineSummary(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetOutlineSummary(false, true))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Total"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1}, RowOpts{OutlineLevel: 1}))
+	assert.Equal(t, ErrStreamSetOutlineSummary, sw.SetOutlineSummary(true, true))
+	assert.NoError(t, sw.Flush())
+	r, err := sw.rawData.Reader()
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `<sheetPr><outlinePr summaryBelow="false" summaryRight="true"></outlinePr></sheetPr>`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetOutlineSummary.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamSetOutlineSummary.xlsx"))
+	assert.NoError(t, err)
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, *props.OutlineSummaryBelow)
+	assert.True(t, *props.OutlineSummaryRight)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamValidateFormulaRefs(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	_, err := f.NewSheet("My Sheet")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{ValidateFormulaRefs: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		Cell{Formula: "Sheet2!A1*2"},
+		Cell{Formula: "SUM('My Sheet'!A1:B2)"},
+		Cell{Formula: "SUM(Sheet1:Sheet2!A1)"},
+		Cell{Formula: "[1]Ext!A1"},
+		Cell{Formula: "A1+1"},
+	}))
+	// Test set formula with the reference to a worksheet which does not exist
+	assert.Equal(t, newStreamFormulaSheetRefError("B2", "SheetN"), sw.SetRow("A2", []interface{}{1, Cell{Formula: "SheetN!A1"}}))
+	assert.Equal(t, newStreamFormulaSheetRefError("A3", "SheetN"), sw.SetRow("A3", []interface{}{Cell{Formula: "SUM(Sheet1:SheetN!A1)"}}))
+	// Test checkpoint and resume the formula references validation mode
+	state, err := sw.Checkpoint()
+	assert.NoError(t, err)
+	resumed, err := f.ResumeStreamWriter("Sheet1", state)
+	assert.NoError(t, err)
+	assert.True(t, resumed.validateRefs)
+	assert.NoError(t, resumed.Flush())
+	formula, err := f.GetCellFormula("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM('My Sheet'!A1:B2)", formula)
+
+	// Test set formula with the reference to a worksheet which does not exist
+	// without validation
+	sw, err = f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{Formula: "SheetN!A1"}}))
+	assert.NoError(t, sw.Flush())
+}
+
+func TestStreamLastRowFormula(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Total", Cell{Formula: "SUM(B3:B{LASTROW})"}, "{LASTROW}"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"Name", "Value"}))
+	for row := 3; row <= 5; row++ {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", row), []interface{}{"Item", row}))
+	}
+	// Test checkpoint and resume the stream writer with the last row placeholder
+	state, err := sw.Checkpoint()
+	assert.NoError(t, err)
+	sw, err = f.ResumeStreamWriter("Sheet1", state)
+	assert.NoError(t, err)
+	for row := 6; row <= 10; row++ {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", row), []interface{}{"Item", row}))
+	}
+	assert.NoError(t, sw.SetRow("A12", []interface{}{"Total", Cell{Formula: "AVERAGE(B3:B{LASTROW})&\"<{LASTROW}>\"", ResultType: CellTypeInlineString}}))
+	assert.NoError(t, sw.Flush())
+	for cell, expected := range map[string]string{"B1": "SUM(B3:B10)", "B12": "AVERAGE(B3:B10)&\"<10>\""} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula)
+	}
+	cellValue, err := f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "{LASTROW}", cellValue)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamLastRowFormula.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test replace the last row placeholder without the data rows
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{AutoFitColWidth: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{Cell{Formula: "COUNTA(B1:B{LASTROW})"}}))
+	assert.NoError(t, sw.Flush())
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "COUNTA(B1:B1)", formula)
+	assert.NoError(t, f.Close())
+
+	// Test replace the last row placeholder with closed temp file
+	sw = &StreamWriter{lastRowRef: 1}
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.rawData.tmp.Close())
+	assert.Error(t, sw.writeLastRowFormulas())
+	assert.NoError(t, os.Remove(sw.rawData.tmp.Name()))
+}
+
+func TestStreamAppend(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(1, 2, 20))
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name", "Value"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"A", 1}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"B", Cell{Formula: "B2+1"}}))
+	assert.NoError(t, sw.MergeCell("C1", "D1"))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B3"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAppend1.xlsx")))
+	// Test continue appending rows in the same session after flushing
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{Append: true})
 	assert.NoError(t, err)
+	assert.Equal(t, 3, sw.LastRow())
+	assert.Equal(t, newStreamSetRowError(3), sw.SetRow("A3", []interface{}{"C", 3}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{"C", 3}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAppend2.xlsx")))
+	assert.NoError(t, f.Close())
 
-	// Test set outlineLevel in row
-	assert.NoError(t, streamWriter.SetRow("A1", nil, RowOpts{OutlineLevel: 1}))
-	assert.NoError(t, streamWriter.SetRow("A2", nil, RowOpts{OutlineLevel: 7}))
-	assert.ErrorIs(t, ErrOutlineLevel, streamWriter.SetRow("A3", nil, RowOpts{OutlineLevel: 8}))
+	// Test continue appending rows after reopening the saved workbook
+	f, err = OpenFile(filepath.Join("test", "TestStreamAppend2.xlsx"))
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{Append: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, sw.LastRow())
+	assert.NoError(t, sw.SetRow("A6", []interface{}{"E", 5, "F"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAppend3.xlsx")))
+	assert.NoError(t, f.Close())
 
-	assert.NoError(t, streamWriter.Flush())
-	// Save spreadsheet by the given path
-	assert.NoError(t, file.SaveAs(filepath.Join("test", "TestStreamWriterSetRowOutlineLevel.xlsx")))
+	f, err = OpenFile(filepath.Join("test", "TestStreamAppend3.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Value"}, {"A", "1"}, {"B", ""}, {"C", "3"}, nil, {"E", "5", "F"}}, rows)
+	formula, err := f.GetCellFormula("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2+1", formula)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "C1", mergeCells[0].GetStartAxis())
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C6", ws.Dimension.Ref)
+	assert.NoError(t, f.Close())
 
-	file, err = OpenFile(filepath.Join("test", "TestStreamWriterSetRowOutlineLevel.xlsx"))
+	// Test append rows to the worksheet without data
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{Append: true})
 	assert.NoError(t, err)
-	for rowIdx, expected := range []uint8{1, 7, 0} {
-		level, err := file.GetRowOutlineLevel("Sheet1", rowIdx+1)
+	assert.Equal(t, 0, sw.LastRow())
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Data"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.Close())
+
+	// Test keep the existing tables of the worksheet without append mode
+	f = NewFile()
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:E3", Name: "Table1"}))
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	for row := 1; row <= 3; row++ {
+		cell, err := CoordinatesToCellName(1, row)
 		assert.NoError(t, err)
-		assert.Equal(t, expected, level)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{"A", "B", nil, "D", "E"}))
 	}
-	assert.NoError(t, file.Close())
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B3", Name: "Table2"}))
+	assert.NoError(t, sw.Flush())
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, ws.TableParts.TableParts, 2)
+	assert.Equal(t, 2, ws.TableParts.Count)
+	assert.NoError(t, f.Close())
 }
 
 func TestStreamWriterReader(t *testing.T) {
@@ -425,11 +2056,18 @@ func TestStreamWriterReader(t *testing.T) {
 		file:    NewFile(),
 		rawData: bufferedWriter{},
 	}
-	// Test getRowValues without expected row
+	// Test getRowValues without expected cell
 	sw.rawData.buf.WriteString("<worksheet><row r=\"1\"><c r=\"B1\"></c></row><worksheet/>")
 	_, err = sw.getRowValues(1, 1, 1)
 	assert.NoError(t, err)
 	sw.rawData.buf.Reset()
+	// Test getRowValues without expected row
+	for _, hRow := range []int{2, 4} {
+		sw.rawData.buf.WriteString("<worksheet><row r=\"3\"><c r=\"A3\"></c></row></worksheet>")
+		_, err = sw.getRowValues(hRow, 1, 1)
+		assert.Equal(t, newStreamTableHeaderError(hRow), err)
+		sw.rawData.buf.Reset()
+	}
 	// Test getRowValues with illegal cell reference
 	sw.rawData.buf.WriteString("<worksheet><row r=\"1\"><c r=\"A\"></c></row><worksheet/>")
 	_, err = sw.getRowValues(1, 1, 1)
@@ -442,6 +2080,262 @@ func TestStreamWriterReader(t *testing.T) {
 	sw.rawData.buf.Reset()
 }
 
+func TestStreamVerifyTempFile(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	assert.True(t, sw.rawData.verify)
+	// Spill the written data to the temp file
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	for row := 1; row <= 10; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{"Header", row}))
+		assert.NoError(t, sw.rawData.Flush())
+	}
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B10"}))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 10)
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:B10", dimension)
+
+	// Test verify corrupted temp file
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet2", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Header"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1}))
+	assert.NoError(t, sw.rawData.Flush())
+	_, err = sw.rawData.tmp.WriteAt([]byte("X"), int64(sw.dimensionOffset))
+	assert.NoError(t, err)
+	_, err = sw.rawData.Reader()
+	assert.Equal(t, ErrStreamChecksum, err)
+	assert.Equal(t, ErrStreamChecksum, sw.AddTable(&Table{Range: "A1:A2"}))
+	assert.Equal(t, ErrStreamChecksum, sw.Flush())
+	assert.NoError(t, sw.rawData.Close())
+
+	// Test verify closed temp file
+	sw = &StreamWriter{rawData: bufferedWriter{verify: true}}
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.rawData.tmp.Close())
+	_, err = sw.rawData.WriteAt([]byte("X"), 0)
+	assert.Error(t, err)
+	assert.NoError(t, os.Remove(sw.rawData.tmp.Name()))
+}
+
+func TestStreamTempFileWriteFailure(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Header", 1}))
+	assert.NoError(t, sw.rawData.Flush())
+	fi, err := sw.rawData.tmp.Stat()
+	assert.NoError(t, err)
+	// Simulate the temp file write failure by the read-only temp file
+	tmpName := sw.rawData.tmp.Name()
+	assert.NoError(t, sw.rawData.tmp.Close())
+	sw.rawData.tmp, err = os.Open(tmpName)
+	assert.NoError(t, err)
+	_, err = sw.rawData.tmp.Seek(0, io.SeekEnd)
+	assert.NoError(t, err)
+	row := make([]interface{}, StreamChunkSize/TotalCellChars+1)
+	for i := range row {
+		row[i] = strings.Repeat("A", TotalCellChars)
+	}
+	err = sw.SetRow("A2", row)
+	var tempErr ErrStreamTempFile
+	assert.True(t, errors.As(err, &tempErr))
+	assert.Error(t, tempErr.Err)
+	assert.True(t, errors.Is(err, tempErr.Err))
+	assert.Contains(t, err.Error(), "failed to write the temp file of the stream writer")
+	// Test the partially written temp file has been removed, and the written
+	// data has been moved into memory
+	assert.Nil(t, sw.rawData.tmp)
+	assert.Greater(t, int64(sw.rawData.buf.Len()), fi.Size()+StreamChunkSize)
+	_, err = os.Stat(tmpName)
+	assert.True(t, os.IsNotExist(err))
+	// Test continue writing in memory after the temp file write failure
+	assert.NoError(t, sw.KeepInMemory())
+	assert.Nil(t, sw.rawData.tmp)
+	assert.NoError(t, sw.SetRow("A3", row[:1]))
+	assert.NoError(t, sw.Flush())
+	assert.Nil(t, sw.rawData.tmp)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, []string{"Header", "1"}, rows[0])
+	assert.Len(t, rows[1], len(row))
+	assert.NoError(t, sw.KeepInMemory())
+
+	// Test keep in memory with corrupted temp file
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet2", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Header"}))
+	assert.NoError(t, sw.rawData.Flush())
+	_, err = sw.rawData.tmp.WriteAt([]byte("X"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrStreamChecksum, sw.KeepInMemory())
+	assert.NoError(t, sw.rawData.Close())
+
+	// Test keep in memory and flush with closed temp file
+	sw = &StreamWriter{rawData: bufferedWriter{}}
+	sw.rawData.tmp, err = os.CreateTemp(os.TempDir(), "excelize-")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.rawData.tmp.Close())
+	assert.IsType(t, ErrStreamTempFile{}, sw.rawData.Flush())
+	assert.Error(t, sw.KeepInMemory())
+	assert.NoError(t, os.Remove(sw.rawData.tmp.Name()))
+
+	// Test sync with the temp file can't be created
+	dir := filepath.Join(t.TempDir(), "excelize")
+	for _, env := range []string{"TMPDIR", "TMP", "TEMP"} {
+		t.Setenv(env, dir)
+	}
+	sw = &StreamWriter{rawData: bufferedWriter{}}
+	_, err = sw.rawData.WriteString(strings.Repeat("A", StreamChunkSize))
+	assert.NoError(t, err)
+	err = sw.rawData.Sync()
+	assert.True(t, errors.As(err, &tempErr))
+	assert.True(t, os.IsNotExist(tempErr.Err))
+	assert.Nil(t, sw.rawData.tmp)
+	assert.Equal(t, StreamChunkSize, sw.rawData.buf.Len())
+}
+
+func TestStreamWriterRows(t *testing.T) {
+	f := NewFile()
+	defer func() {
+		assert.NoError(t, f.Close())
+	}()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	row := make([]interface{}, 50)
+	for colID := 0; colID < 50; colID++ {
+		row[colID] = colID
+	}
+	// Write rows to force the stream writer spill to a temporary file
+	for rowID := 1; sw.rawData.tmp == nil || rowID <= 2; rowID++ {
+		cell, _ := CoordinatesToCellName(1, rowID)
+		assert.NoError(t, sw.SetRow(cell, row))
+	}
+	assert.NoError(t, sw.SetRow("A20000", []interface{}{"end"}))
+	assert.NoError(t, sw.Flush())
+	// Test read rows from the temporary file of the stream writer
+	rows, err := f.Rows("Sheet1")
+	assert.NoError(t, err)
+	var rowCount int
+	var lastRow []string
+	for rows.Next() {
+		rowCount++
+		if lastRow, err = rows.Columns(); err != nil {
+			break
+		}
+	}
+	assert.NoError(t, err)
+	assert.NoError(t, rows.Close())
+	assert.Equal(t, 20000, rowCount)
+	assert.Equal(t, []string{"end"}, lastRow)
+	cellValue, err := f.GetCellValue("Sheet1", "AX1")
+	assert.NoError(t, err)
+	assert.Equal(t, "49", cellValue)
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:AX20000", dimension)
+
+	// Test read rows with closed temporary file
+	assert.NoError(t, sw.rawData.tmp.Close())
+	_, err = f.Rows("Sheet1")
+	assert.Error(t, err)
+	f.Pkg.Delete("xl/worksheets/sheet1.xml")
+	assert.Empty(t, f.readXML("xl/worksheets/sheet1.xml"))
+}
+
+func TestStreamAddChart(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name", "Apple", "Orange", "Pear"}))
+	for r, row := range [][]interface{}{{"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8}} {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", r+2), row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:D4"}))
+	assert.NoError(t, sw.AddChart("F1", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, sw.AddChart("F16", &Chart{Type: Col, Series: series}, &Chart{Type: Pie, Series: series[:1]}))
+	// Test add chart with invalid cell reference and chart type
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.AddChart("A", &Chart{Type: Line, Series: series}))
+	assert.Equal(t, newUnsupportedChartType(0xff), sw.AddChart("F31", &Chart{Type: 0xff, Series: series}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamAddChart.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamAddChart.xlsx"))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotNil(t, ws.Drawing)
+	assert.Len(t, ws.TableParts.TableParts, 1)
+	assert.NotEqual(t, ws.Drawing.RID, ws.TableParts.TableParts[0].RID)
+	assert.Equal(t, "../drawings/drawing1.xml", f.getSheetRelationshipsTargetByID("Sheet1", ws.Drawing.RID))
+	for _, name := range []string{"xl/charts/chart1.xml", "xl/charts/chart2.xml", "xl/drawings/drawing1.xml"} {
+		_, ok := f.Pkg.Load(name)
+		assert.True(t, ok, name)
+	}
+	cellValue, err := f.GetCellValue("Sheet1", "D4")
+	assert.NoError(t, err)
+	assert.Equal(t, "8", cellValue)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamWriterDimension(t *testing.T) {
+	for _, c := range []struct {
+		rows     map[string][]interface{}
+		expected string
+	}{
+		{map[string][]interface{}{}, "A1"},
+		{map[string][]interface{}{"C3": {"flag"}}, "C3"},
+		{map[string][]interface{}{"C3": {nil, "flag"}}, "D3"},
+		{map[string][]interface{}{"B2": {1, 2}, "A4": {nil, nil, 3}}, "B2:C4"},
+		{map[string][]interface{}{"A1": {1}, "A2": {2}}, "A1:A2"},
+	} {
+		f := NewFile()
+		sw, err := f.NewStreamWriter("Sheet1")
+		assert.NoError(t, err)
+		for _, cell := range []string{"A1", "B2", "C3", "A2", "A4"} {
+			if row, ok := c.rows[cell]; ok {
+				assert.NoError(t, sw.SetRow(cell, row))
+			}
+		}
+		assert.NoError(t, sw.Flush())
+		dimension, err := f.GetSheetDimension("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, dimension)
+		assert.NoError(t, f.Close())
+	}
+}
+
 func TestStreamWriterGetRowElement(t *testing.T) {
 	// Test get row element without r attribute
 	dec := xml.NewDecoder(strings.NewReader("<row ht=\"0\" />"))
@@ -450,7 +2344,406 @@ func TestStreamWriterGetRowElement(t *testing.T) {
 		if err == io.EOF {
 			break
 		}
-		_, ok := getRowElement(token, 0)
+		_, _, ok := getRowElement(token)
 		assert.False(t, ok)
 	}
 }
+
+func TestStreamSetCellNumFmt(t *testing.T) {
+	f := NewFile()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}, NumFmt: 2})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		Cell{Value: date, NumFmt: "[$-409]mmmm d, yyyy;@"},
+		&Cell{Value: 1234.5, NumFmt: "[$€-407]#,##0.00"},
+		Cell{StyleID: boldStyle, Value: 1234.5, NumFmt: "[$€-407]#,##0.00"},
+	}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{
+		{Value: date, NumFmt: "[$-409]mmmm d, yyyy;@"},
+		{Value: 1234.5, NumFmt: "[$€-407]#,##0.00"},
+		{StyleID: boldStyle, Value: 1234.5},
+	}))
+	assert.Len(t, sw.numFmtStyles, 3)
+	assert.NoError(t, sw.Flush())
+
+	for cell, expected := range map[string]struct {
+		numFmt string
+		bold   bool
+		value  string
+	}{
+		"A1": {numFmt: "[$-409]mmmm d, yyyy;@", value: "March 15, 2024"},
+		"B1": {numFmt: "[$€-407]#,##0.00", value: "€1,234.50"},
+		"C1": {numFmt: "[$€-407]#,##0.00", bold: true, value: "€1,234.50"},
+		"A2": {numFmt: "[$-409]mmmm d, yyyy;@", value: "March 15, 2024"},
+		"B2": {numFmt: "[$€-407]#,##0.00", value: "€1,234.50"},
+	} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		if assert.NotNil(t, style.CustomNumFmt, cell) {
+			assert.Equal(t, expected.numFmt, *style.CustomNumFmt, cell)
+		}
+		assert.Equal(t, expected.bold, style.Font != nil && style.Font.Bold, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, val, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, boldStyle, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetCellNumFmt.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test set cell number format with invalid style ID
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, newInvalidStyleID(10), sw.SetRow("A1", []interface{}{Cell{StyleID: 10, Value: 1, NumFmt: "0.0"}}))
+	assert.Equal(t, newInvalidStyleID(10), sw.SetRow("A2", []interface{}{&Cell{StyleID: 10, Value: 1, NumFmt: "0.0"}}))
+	assert.Equal(t, newInvalidStyleID(10), sw.SetRowCells("A3", []Cell{{StyleID: 10, Value: 1, NumFmt: "0.0"}}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{1}))
+	// Test the rows have been closed on error
+	assert.Equal(t, 4, strings.Count(sw.rawData.buf.String(), "</row>"))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, nil, {"1"}}, rows)
+	assert.NoError(t, f.Close())
+
+	// Test set cell number format with unsupported charset style sheet
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetRow("A1", []interface{}{Cell{Value: 1, NumFmt: "0.0"}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetCellDisplayText(t *testing.T) {
+	f := NewFile()
+	boldStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		Cell{Value: 1520000, DisplayText: "1.52M USD"},
+		&Cell{Value: -1.5, DisplayText: `1.5 "kg"`},
+		Cell{StyleID: boldStyle, Value: 0, DisplayText: "1.52M USD"},
+	}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{
+		{Value: 1520000, DisplayText: "1.52M USD"},
+		{Formula: "A1*2", DisplayText: "3.04M USD"},
+	}))
+	assert.Len(t, sw.numFmtStyles, 4)
+	assert.NoError(t, sw.Flush())
+
+	for cell, expected := range map[string]struct {
+		raw, value string
+		bold       bool
+	}{
+		"A1": {raw: "1520000", value: "1.52M USD"},
+		"B1": {raw: "-1.5", value: `1.5 "kg"`},
+		"C1": {raw: "0", value: "1.52M USD", bold: true},
+		"A2": {raw: "1520000", value: "1.52M USD"},
+	} {
+		raw, err := f.GetCellValue("Sheet1", cell, Options{RawCellValue: true})
+		assert.NoError(t, err)
+		assert.Equal(t, expected.raw, raw, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, val, cell)
+		cellType, err := f.GetCellType("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, CellTypeUnset, cellType, cell)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.bold, style.Font != nil && style.Font.Bold, cell)
+	}
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, style.CustomNumFmt) {
+		assert.Equal(t, `"1.52M USD";"1.52M USD";"1.52M USD"`, *style.CustomNumFmt)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetCellDisplayText.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test set cell display text with number format
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A1", []interface{}{Cell{Value: 1, NumFmt: "0.0", DisplayText: "1"}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRowCells("A2", []Cell{{Value: 1, NumFmt: "0.0", DisplayText: "1"}}))
+	assert.Equal(t, ErrParameterInvalid, sw.SetRow("A3", []interface{}{1, &Cell{Value: 1, NumFmt: "0.0", DisplayText: "1"}}))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{1}))
+	// Test the rows have been closed on error
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, {"1"}, {"1"}}, rows)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetCellDuration(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	duration := 30*time.Hour + 15*time.Minute + 30*time.Second
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		duration,
+		Cell{Value: duration, NumFmt: "[h]:mm"},
+		&Cell{Value: 90 * time.Minute},
+		2 * time.Hour,
+	}))
+	assert.NoError(t, sw.SetRowCells("A2", []Cell{{Value: duration}, {Value: duration, NumFmt: "[h]:mm"}}))
+	assert.Len(t, sw.numFmtStyles, 3)
+	assert.NoError(t, sw.Flush())
+	for cell, expected := range map[string]struct {
+		numFmt int
+		value  string
+	}{
+		"A1": {numFmt: 46, value: "30:15:30"},
+		"C1": {numFmt: 20, value: "01:30"},
+		"D1": {numFmt: 20, value: "02:00"},
+		"A2": {numFmt: 46, value: "30:15:30"},
+	} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.numFmt, style.NumFmt, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.value, val, cell)
+	}
+	for _, cell := range []string{"B1", "B2"} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		if assert.NotNil(t, style.CustomNumFmt, cell) {
+			assert.Equal(t, "[h]:mm", *style.CustomNumFmt, cell)
+		}
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "30:15", val, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetCellDuration.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test set duration cell with unsupported charset style sheet
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.SetRow("A1", []interface{}{duration}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamSetConditionalFormat(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	for row := 1; row <= 10; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{row * 10, row}))
+	}
+	iconSet := []ConditionalFormatOptions{{
+		Type: "icon_set", IconStyle: "3TrafficLights1", ReverseIcons: true,
+		IconThresholds: []ConditionalFormatIconThreshold{{Type: "num", Value: "50"}, {Type: "num", Value: "80", GreaterThan: true}},
+	}}
+	assert.NoError(t, sw.SetConditionalFormat("A1:A10", iconSet))
+	dataBar := []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", NegativeBarColor: "#FFC000"}}
+	assert.NoError(t, sw.SetConditionalFormat("B1:B10", dataBar))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamSetConditionalFormat.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamSetConditionalFormat.xlsx"))
+	assert.NoError(t, err)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	iconSet[0].Priority = 1
+	assert.Equal(t, iconSet, opts["A1:A10"])
+	assert.Len(t, opts["B1:B10"], 1)
+	assert.Equal(t, "#FFC000", opts["B1:B10"][0].NegativeBarColor)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.NotNil(t, ws.ExtLst)
+	assert.Contains(t, ws.ExtLst.Ext, ExtURIConditionalFormattings)
+	// Test set conditional format with invalid range reference
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterRequired, sw.SetConditionalFormat("", iconSet))
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamCheckpoint(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColHyperlink(2, true))
+	assert.NoError(t, sw.SetColumnTypes([]ColumnType{ColumnTypeInt, ColumnTypeText, ColumnTypeDate}))
+	writeRows := func(sw *StreamWriter, from, to int) {
+		for row := from; row <= to; row++ {
+			cell, err := CoordinatesToCellName(1, row)
+			assert.NoError(t, err)
+			assert.NoError(t, sw.SetRow(cell, []interface{}{
+				Cell{Value: row, NumFmt: "0.00"}, fmt.Sprintf("https://github.com/xuri/excelize/%d", row%3),
+				time.Date(2024, 1, row, 0, 0, 0, 0, time.UTC),
+			}, RowOpts{BottomBorder: RowBorderThin}))
+		}
+	}
+	writeRows(sw, 1, 10)
+	assert.NoError(t, sw.MergeCell("D1", "E1"))
+	state, err := sw.Checkpoint()
+	assert.NoError(t, err)
+	// The rows written after the checkpoint will be discarded on resuming
+	writeRows(sw, 11, 15)
+	assert.NoError(t, sw.rawData.Flush())
+
+	f2 := NewFile()
+	sw2, err := f2.ResumeStreamWriter("Sheet1", state)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, sw2.rows)
+	writeRows(sw2, 11, 20)
+	assert.NoError(t, sw2.Flush())
+	assert.NoError(t, f2.SaveAs(filepath.Join("test", "TestStreamCheckpoint.xlsx")))
+	assert.NoError(t, f2.Close())
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestStreamCheckpoint.xlsx"))
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 20)
+	assert.Equal(t, []string{"11.00", "https://github.com/xuri/excelize/2", "1/11/24 00:00"}, rows[10])
+	link, target, err := f.GetCellHyperLink("Sheet1", "B20")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize/2", target)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	dimension, err := f.GetSheetDimension("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1:C20", dimension)
+	assert.NoError(t, f.Close())
+
+	// Test resume the stream writer after the workbook has been closed
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	writeRows(sw, 1, 10)
+	state, err = sw.Checkpoint()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	var checkpoint streamCheckpoint
+	assert.NoError(t, xml.Unmarshal(state, &checkpoint))
+	assert.FileExists(t, checkpoint.TempFile)
+	f = NewFile()
+	sw, err = f.ResumeStreamWriter("Sheet1", state)
+	assert.NoError(t, err)
+	writeRows(sw, 11, 20)
+	assert.NoError(t, sw.Flush())
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 20)
+	assert.Equal(t, []string{"20.00", "https://github.com/xuri/excelize/2", "1/20/24 00:00"}, rows[19])
+	// Test the temp file has been removed on closing the workbook after flushing
+	assert.NoError(t, f.Close())
+	assert.NoFileExists(t, checkpoint.TempFile)
+
+	// Test checkpoint the stream writer with tables
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A", "B"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1, 2}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B2"}))
+	_, err = sw.Checkpoint()
+	assert.Equal(t, ErrStreamCheckpoint, err)
+	assert.NoError(t, f.Close())
+
+	// Test resume the stream writer with invalid worksheet name and checkpoint
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{VerifyTempFile: true})
+	assert.NoError(t, err)
+	writeRows(sw, 1, 2)
+	state, err = sw.Checkpoint()
+	assert.NoError(t, err)
+	f2 = NewFile()
+	_, err = f2.ResumeStreamWriter("Sheet:1", state)
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	_, err = f2.ResumeStreamWriter("SheetN", state)
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, err)
+	_, err = f2.ResumeStreamWriter("Sheet1", []byte("<streamCheckpoint"))
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	_, err = f2.ResumeStreamWriter("Sheet1", []byte("<streamCheckpoint><tempFile></tempFile></streamCheckpoint>"))
+	assert.Error(t, err)
+	// Test resume the stream writer with invalid state
+	for _, fn := range []func(checkpoint *streamCheckpoint){
+		func(checkpoint *streamCheckpoint) { checkpoint.Size = -1 },
+		func(checkpoint *streamCheckpoint) { checkpoint.DimensionOffset = int(checkpoint.Size) + 1 },
+		func(checkpoint *streamCheckpoint) { checkpoint.MergeCellsCount = -1 },
+		func(checkpoint *streamCheckpoint) { checkpoint.DefaultStyleID = -1 },
+		func(checkpoint *streamCheckpoint) { checkpoint.Rows = TotalRows + 1 },
+		func(checkpoint *streamCheckpoint) { checkpoint.LastRow = -1 },
+		func(checkpoint *streamCheckpoint) { checkpoint.Dimension = []int{1, 1} },
+		func(checkpoint *streamCheckpoint) { checkpoint.Dimension = []int{1, 1, MaxColumns + 1, 2} },
+		func(checkpoint *streamCheckpoint) {
+			checkpoint.ColWidths = []streamCheckpointColWidth{{Col: 1, Width: 10}}
+		},
+		func(checkpoint *streamCheckpoint) {
+			checkpoint.AutoFitColWidth, checkpoint.ColWidths = true, []streamCheckpointColWidth{{Col: 0, Width: 10}}
+		},
+		func(checkpoint *streamCheckpoint) { checkpoint.ColumnTypes = []int{int(ColumnTypeBool) + 1} },
+		func(checkpoint *streamCheckpoint) {
+			checkpoint.HyperlinkRIDs = []streamCheckpointLinkRID{{Link: "https://github.com/xuri/excelize", RID: "rId1"}}
+		},
+		func(checkpoint *streamCheckpoint) {
+			checkpoint.HyperlinkCols = []streamCheckpointLinkCol{{Col: MaxColumns + 1}}
+		},
+	} {
+		var checkpoint streamCheckpoint
+		assert.NoError(t, xml.Unmarshal(state, &checkpoint))
+		fn(&checkpoint)
+		invalidState, err := xml.Marshal(checkpoint)
+		assert.NoError(t, err)
+		_, err = f2.ResumeStreamWriter("Sheet1", invalidState)
+		assert.Equal(t, ErrStreamCheckpoint, err)
+	}
+	// Test resume the stream writer with corrupted and truncated temp file
+	_, err = sw.rawData.tmp.WriteAt([]byte("X"), int64(sw.dimensionOffset))
+	assert.NoError(t, err)
+	_, err = f2.ResumeStreamWriter("Sheet1", state)
+	assert.Equal(t, ErrStreamChecksum, err)
+	assert.NoError(t, sw.rawData.tmp.Truncate(10))
+	_, err = f2.ResumeStreamWriter("Sheet1", state)
+	assert.Equal(t, ErrStreamCheckpoint, err)
+	assert.NoError(t, f2.Close())
+	assert.NoError(t, f.Close())
+	// Test resume the stream writer with unsupported charset worksheet
+	f2 = NewFile()
+	f2.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f2.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f2.ResumeStreamWriter("Sheet1", state)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f2.Close())
+	// Test remove the temp file of the checkpointed stream writer
+	assert.NoError(t, xml.Unmarshal(state, &checkpoint))
+	assert.FileExists(t, checkpoint.TempFile)
+	assert.NoError(t, RemoveStreamCheckpoint(state))
+	assert.NoFileExists(t, checkpoint.TempFile)
+	assert.EqualError(t, RemoveStreamCheckpoint([]byte("<streamCheckpoint")), "XML syntax error on line 1: unexpected EOF")
+}
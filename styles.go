@@ -21,6 +21,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/tiendc/go-deepcopy"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
@@ -133,13 +135,76 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 		if style.Font.Size > MaxFontSize {
 			return style, ErrFontSize
 		}
+		if err = style.Font.validate(); err != nil {
+			return style, err
+		}
+	}
+	for _, border := range style.Border {
+		if border.Style < 0 || border.Style >= len(styleBorders) {
+			return style, ErrBorderStyle
+		}
+	}
+	if style.Alignment != nil {
+		if err = style.Alignment.validate(); err != nil {
+			return style, err
+		}
 	}
 	if style.CustomNumFmt != nil && len(*style.CustomNumFmt) == 0 {
 		err = ErrCustomNumFmt
 	}
+	if style.Fill.Type == "gradient" && style.Fill.Gradient != nil {
+		err = style.Fill.Gradient.validate()
+	}
 	return style, err
 }
 
+// validate provides a function to validate the vertical alignment and scheme
+// of the font.
+func (fnt *Font) validate() error {
+	if fnt.VertAlign != "" && inStrSlice(supportedFontVertAlign, fnt.VertAlign, false) == -1 {
+		return ErrFontVertAlign
+	}
+	if fnt.Scheme != "" && inStrSlice(supportedFontSchemes, fnt.Scheme, false) == -1 {
+		return ErrFontScheme
+	}
+	return nil
+}
+
+// validate provides a function to validate the alignment settings.
+func (a *Alignment) validate() error {
+	if a.Indent < 0 || a.Indent > MaxIndent {
+		return ErrIndent
+	}
+	if a.ReadingOrder > 2 {
+		return ErrReadingOrder
+	}
+	if (a.TextRotation < 0 || a.TextRotation > 180) && a.TextRotation != 255 {
+		return ErrTextRotation
+	}
+	return nil
+}
+
+// validate provides a function to validate the gradient fill settings.
+func (g *GradientFill) validate() error {
+	if g.Type != "" && g.Type != "linear" && g.Type != "path" {
+		return ErrParameterInvalid
+	}
+	for _, fraction := range []float64{g.Left, g.Right, g.Top, g.Bottom} {
+		if fraction < 0 || fraction > 1 {
+			return ErrParameterInvalid
+		}
+	}
+	if len(g.Stops) < 2 {
+		return ErrGradientFillStops
+	}
+	for i, stop := range g.Stops {
+		if stop.Position < 0 || stop.Position > 1 || (i > 0 && stop.Position < g.Stops[i-1].Position) {
+			return ErrGradientFillStops
+		}
+	}
+	return nil
+}
+
 // NewStyle provides a function to create the style for cells by a given style
 // options, and returns style index. The same style index can not be used
 // across different workbook. This function is concurrency safe. Note that
@@ -158,6 +223,13 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 diagonalDown | Diagonal down border
 //	 diagonalUp   | Diagonal up border
 //
+// The diagonal up and diagonal down borders can be set together to draw an X
+// through the cell. Note that a cell only has one diagonal line definition in
+// the spreadsheet, so both directions always share the same style and color,
+// if they were given with different settings, the latter one in the 'Border'
+// slice will be used for both directions. The 'Border.Style' must be between
+// 0 and 13, otherwise NewStyle will return an error.
+//
 // The following table shows the border styles used in 'Border.Style' supported
 // by excelize index number:
 //
@@ -200,6 +272,25 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 3-5   | Vertical        | 12-15 | From corner
 //	 6-8   | Diagonal Up     | 16    | From center
 //
+// The 'Fill.Gradient' can be used to create the linear gradient fill at an
+// arbitrary angle or the path gradient fill with multiple stops instead of
+// the preset shading styles, the 'Fill.Shading' and 'Fill.Color' will be
+// ignored in this case. The position of the stops must be between 0 and 1 in
+// ascending order. For example, create a linear gradient fill with three
+// stops at 30 degrees:
+//
+//	style, err := f.NewStyle(&excelize.Style{
+//	    Fill: excelize.Fill{Type: "gradient", Gradient: &excelize.GradientFill{
+//	        Type:   "linear",
+//	        Degree: 30,
+//	        Stops: []excelize.GradientStop{
+//	            {Position: 0, Color: "FFFFFF"},
+//	            {Position: 0.4, Color: "5B9BD5"},
+//	            {Position: 1, Color: "1F4E79"},
+//	        },
+//	    }},
+//	})
+//
 // The following table shows the pattern styles used in 'Fill.Pattern' supported
 // by excelize index number:
 //
@@ -226,7 +317,8 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // For example, an indent value of 1 means that the text begins 3 space widths
 // (of the normal style font) from the edge of the cell. Note: The width of one
 // space character is defined by the font. Only left, right, and distributed
-// horizontal alignments are supported.
+// horizontal alignments are supported. The indent value must be between 0 and
+// 250.
 //
 // The following table shows the type of cells' horizontal alignment used
 // in 'Alignment.Horizontal':
@@ -269,6 +361,20 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // The 'Alignment.RelativeIndent' is an integer value to indicate the additional
 // number of spaces of indentation to adjust for text in a cell.
 //
+// The 'Alignment.TextRotation' is an integer value to specify the rotation of
+// the text in a cell, the valid value of this field was:
+//
+//	 Value    | Description
+//	----------+----------------------------------------------------
+//	 0 - 90   | The text rotates counterclockwise by the given degrees.
+//	 91 - 180 | The text rotates clockwise by the given degrees minus 90.
+//	 255      | The text is displayed vertically stacked from top to bottom.
+//
+// The 'Alignment.ShrinkToFit' specifies if the displayed text in the cell
+// should be shrunk to fit the cell width. The 'Alignment.ShrinkToFit' and
+// 'Alignment.WrapText' can be set both and will be kept in the style, but the
+// spreadsheet application only applies the wrap text in that case.
+//
 // The following table shows the type of font underline style used in
 // 'Font.Underline':
 //
@@ -278,6 +384,22 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 //	 single
 //	 double
 //
+// The 'Font.VertAlign' specifies the vertical position of the text, the valid
+// values are 'baseline', 'superscript' and 'subscript', it can be used with
+// the rich text runs to write the text such as "H₂O" or "m²". The
+// 'Font.Charset' specifies the character set of the font, such as 0 for ANSI
+// and 128 for Shift-JIS, and the 'Font.Condense' and 'Font.Extend' specify the
+// font is condensed or extended to fill the space, they are used for the
+// compatibility with the legacy spreadsheet applications.
+//
+// The 'Font.Scheme' specifies the font belongs to the font scheme of the
+// theme, the valid values are 'none', 'major' and 'minor'. The spreadsheet
+// application renders the font with the 'major' or 'minor' scheme by the
+// headings or body font of the theme, instead of the 'Font.Family', so that
+// changing the fonts of the theme updates these cells. The default font of
+// the workbook uses the 'minor' scheme, leave it empty or set it to 'none' when
+// specifying a different 'Font.Family' for the cells.
+//
 // NumFmt is used to set the built-in all languages formats index, built-in
 // language formats index, or built-in currency formats index, it doesn't work
 // when you specify the custom number format by CustomNumFmt. When you get
@@ -959,10 +1081,9 @@ func parseFormatStyleSet(style *Style) (*Style, error) {
 // part, or only the positive part.
 func (f *File) NewStyle(style *Style) (int, error) {
 	var (
-		fs                                  *Style
-		font                                *xlsxFont
-		err                                 error
-		cellXfsID, fontID, borderID, fillID int
+		fs        *Style
+		err       error
+		cellXfsID int
 	)
 	if style == nil {
 		return cellXfsID, err
@@ -988,13 +1109,23 @@ func (f *File) NewStyle(style *Style) (int, error) {
 		return cellXfsID, err
 	}
 
-	numFmtID := newNumFmt(s, fs)
+	numFmtID, fontID, fillID, borderID := f.newXfComponents(s, fs)
+	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
+	applyProtection, protection := fs.Protection != nil, newProtection(fs)
+	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+}
+
+// newXfComponents provides a function to create the number format, font,
+// fill and border records by given style if they don't exist, and returns
+// the index of these records.
+func (f *File) newXfComponents(s *xlsxStyleSheet, fs *Style) (numFmtID, fontID, fillID, borderID int) {
+	numFmtID = newNumFmt(s, fs)
 
 	if fs.Font != nil {
 		fontID, _ = f.getFontID(s, fs)
 		if fontID == -1 {
 			s.Fonts.Count++
-			font, _ = f.newFont(fs)
+			font, _ := f.newFont(fs)
 			s.Fonts.Font = append(s.Fonts.Font, font)
 			fontID = s.Fonts.Count - 1
 		}
@@ -1020,10 +1151,7 @@ func (f *File) NewStyle(style *Style) (int, error) {
 			fillID = 0
 		}
 	}
-
-	applyAlignment, alignment := fs.Alignment != nil, newAlignment(fs)
-	applyProtection, protection := fs.Protection != nil, newProtection(fs)
-	return setCellXfs(s, fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	return
 }
 
 var (
@@ -1442,11 +1570,11 @@ func (f *File) extractBorders(bdr *xlsxBorder, s *xlsxStyleSheet, style *Style)
 	if bdr != nil {
 		var borders []Border
 		extractBorder := func(lineType string, line xlsxLine) {
-			if line.Style != "" {
+			if idx := inStrSlice(styleBorders, line.Style, false); line.Style != "" && idx != -1 {
 				borders = append(borders, Border{
 					Type:  lineType,
 					Color: f.getThemeColor(line.Color),
-					Style: inStrSlice(styleBorders, line.Style, false),
+					Style: idx,
 				})
 			}
 		}
@@ -1474,20 +1602,25 @@ func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 		var fill Fill
 		if fl.GradientFill != nil {
 			fill.Type = "gradient"
-			for shading, variants := range styleFillVariants() {
-				if fl.GradientFill.Bottom == variants.Bottom &&
-					fl.GradientFill.Degree == variants.Degree &&
-					fl.GradientFill.Left == variants.Left &&
-					fl.GradientFill.Right == variants.Right &&
-					fl.GradientFill.Top == variants.Top &&
-					fl.GradientFill.Type == variants.Type {
-					fill.Shading = shading
-					break
-				}
-			}
 			for _, stop := range fl.GradientFill.Stop {
 				fill.Color = append(fill.Color, f.getThemeColor(&stop.Color))
 			}
+			if fill.Shading = getFillShading(fl.GradientFill); fill.Shading == -1 {
+				fill.Shading = 0
+				fill.Gradient = &GradientFill{
+					Type:   "linear",
+					Degree: fl.GradientFill.Degree,
+				}
+				if fl.GradientFill.Type == "path" {
+					fill.Gradient = &GradientFill{
+						Type: "path", Left: fl.GradientFill.Left, Right: fl.GradientFill.Right,
+						Top: fl.GradientFill.Top, Bottom: fl.GradientFill.Bottom,
+					}
+				}
+				for i, stop := range fl.GradientFill.Stop {
+					fill.Gradient.Stops = append(fill.Gradient.Stops, GradientStop{Position: stop.Position, Color: fill.Color[i]})
+				}
+			}
 		}
 		if fl.PatternFill != nil {
 			fill.Type = "pattern"
@@ -1503,6 +1636,34 @@ func (f *File) extractFills(fl *xlsxFill, s *xlsxStyleSheet, style *Style) {
 	}
 }
 
+// getFillShading provides a function to get the index of the preset variants
+// of the gradient fill style by given gradient fill, returns -1 if the
+// gradient fill doesn't match any preset variants.
+func getFillShading(gradient *xlsxGradientFill) int {
+	for shading, variants := range styleFillVariants() {
+		if gradient.Bottom != variants.Bottom || gradient.Degree != variants.Degree ||
+			gradient.Left != variants.Left || gradient.Right != variants.Right ||
+			gradient.Top != variants.Top || gradient.Type != variants.Type ||
+			len(gradient.Stop) != len(variants.Stop) {
+			continue
+		}
+		matched := true
+		for i, stop := range gradient.Stop {
+			if stop == nil || stop.Position != variants.Stop[i].Position {
+				matched = false
+				break
+			}
+		}
+		if matched && len(gradient.Stop) == 3 && !reflect.DeepEqual(gradient.Stop[0].Color, gradient.Stop[2].Color) {
+			matched = false
+		}
+		if matched {
+			return shading
+		}
+	}
+	return -1
+}
+
 // extractFont provides a function to extract font styles settings by given
 // font styles definition.
 func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
@@ -1528,6 +1689,21 @@ func (f *File) extractFont(fnt *xlsxFont, s *xlsxStyleSheet, style *Style) {
 		if fnt.Strike != nil {
 			font.Strike = fnt.Strike.Value()
 		}
+		if fnt.Condense != nil {
+			font.Condense = fnt.Condense.Value()
+		}
+		if fnt.Extend != nil {
+			font.Extend = fnt.Extend.Value()
+		}
+		if fnt.VertAlign != nil {
+			font.VertAlign = fnt.VertAlign.Value()
+		}
+		if fnt.Charset != nil && fnt.Charset.Val != nil {
+			font.Charset = intPtr(*fnt.Charset.Val)
+		}
+		if fnt.Scheme != nil {
+			font.Scheme = fnt.Scheme.Value()
+		}
 		if fnt.Color != nil {
 			font.Color = strings.TrimPrefix(fnt.Color.RGB, "FF")
 			font.ColorIndexed = fnt.Color.Indexed
@@ -1613,14 +1789,20 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
 	if idx < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= idx {
 		return style, newInvalidStyleID(idx)
 	}
-	style = &Style{}
-	xf := s.CellXfs.Xf[idx]
+	return f.extractXf(s.CellXfs.Xf[idx], s), nil
+}
+
+// extractXf provides a function to extract style definition by given
+// formatting record.
+func (f *File) extractXf(xf xlsxXf, s *xlsxStyleSheet) *Style {
+	style := &Style{}
 	if extractStyleCondFuncs["fill"](xf, s) {
 		f.extractFills(s.Fills.Fill[*xf.FillID], s, style)
 	}
@@ -1637,7 +1819,7 @@ func (f *File) GetStyle(idx int) (*Style, error) {
 		f.extractProtection(xf.Protection, s, style)
 	}
 	f.extractNumFmt(xf.NumFmtID, s, style)
-	return style, nil
+	return style
 }
 
 // getStyleID provides a function to get styleID by given style. If given
@@ -1659,6 +1841,9 @@ func (f *File) getStyleID(ss *xlsxStyleSheet, style *Style) (int, error) {
 		numFmtID = getCustomNumFmtID(ss, style)
 	}
 	for xfID, xf := range ss.CellXfs.Xf {
+		if xf.XfID != nil && *xf.XfID != 0 {
+			continue
+		}
 		if getXfIDFuncs["numFmt"](numFmtID, xf, style) &&
 			getXfIDFuncs["font"](fontID, xf, style) &&
 			getXfIDFuncs["fill"](fillID, xf, style) &&
@@ -1721,6 +1906,7 @@ func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	f.mu.Lock()
 	s, err := f.stylesReader()
 	if err != nil {
+		f.mu.Unlock()
 		return style, err
 	}
 	f.mu.Unlock()
@@ -1739,11 +1925,146 @@ func (f *File) GetConditionalStyle(idx int) (*Style, error) {
 	f.extractAlignment(xf.Alignment, s, style)
 	f.extractProtection(xf.Protection, s, style)
 	if xf.NumFmt != nil {
-		f.extractNumFmt(&xf.NumFmt.NumFmtID, s, style)
+		if _, ok := builtInNumFmt[xf.NumFmt.NumFmtID]; ok || xf.NumFmt.FormatCode == "" {
+			f.extractNumFmt(&xf.NumFmt.NumFmtID, s, style)
+			return style, nil
+		}
+		// The custom number format of the conditional format style is stored
+		// in the style itself
+		if decimalPlaces := f.extractNumFmtDecimal(xf.NumFmt.FormatCode); decimalPlaces != -1 {
+			style.DecimalPlaces = &decimalPlaces
+		}
+		style.CustomNumFmt = stringPtr(xf.NumFmt.FormatCode)
 	}
 	return style, nil
 }
 
+// tableStyleElementTypes defined the table style element types in the order
+// of the table style elements.
+var tableStyleElementTypes = []string{
+	"wholeTable", "headerRow", "totalRow", "firstColumn", "lastColumn",
+	"firstRowStripe", "secondRowStripe", "firstColumnStripe",
+	"secondColumnStripe", "firstHeaderCell", "lastHeaderCell",
+	"firstTotalCell", "lastTotalCell", "firstSubtotalColumn",
+	"secondSubtotalColumn", "thirdSubtotalColumn", "firstSubtotalRow",
+	"secondSubtotalRow", "thirdSubtotalRow", "blankRow",
+	"firstColumnSubheading", "secondColumnSubheading",
+	"thirdColumnSubheading", "firstRowSubheading", "secondRowSubheading",
+	"thirdRowSubheading", "pageFieldLabels", "pageFieldValues",
+}
+
+// NewTableStyle provides a function to create a custom table style by given
+// style name and definition, the style name can be used as the StyleName of
+// the table or the PivotTableStyleName of the PivotTable. The definition maps
+// the table style element types to the styles, and the styles support the
+// same parameters with the NewConditionalStyle function. The available table
+// style element types are:
+//
+//	wholeTable             | firstTotalCell         | blankRow
+//	headerRow              | lastTotalCell          | firstColumnSubheading
+//	totalRow               | firstSubtotalColumn    | secondColumnSubheading
+//	firstColumn            | secondSubtotalColumn   | thirdColumnSubheading
+//	lastColumn             | thirdSubtotalColumn    | firstRowSubheading
+//	firstRowStripe         | firstSubtotalRow       | secondRowSubheading
+//	secondRowStripe        | secondSubtotalRow      | thirdRowSubheading
+//	firstColumnStripe      | thirdSubtotalRow       | pageFieldLabels
+//	secondColumnStripe     | firstHeaderCell        | pageFieldValues
+//	                       | lastHeaderCell         |
+//
+// For example, create a table style with the header row and banded rows
+// colors, and use it for the table:
+//
+//	err := f.NewTableStyle("CorporateTable", &excelize.TableStyleDefinition{
+//	    Elements: map[string]*excelize.Style{
+//	        "headerRow": {
+//	            Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+//	            Fill: excelize.Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1},
+//	        },
+//	        "firstRowStripe": {
+//	            Fill: excelize.Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1},
+//	        },
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddTable("Sheet1", &excelize.Table{Range: "A1:D5", StyleName: "CorporateTable"})
+func (f *File) NewTableStyle(name string, def *TableStyleDefinition) error {
+	if name == "" || def == nil {
+		return ErrParameterRequired
+	}
+	for typ := range def.Elements {
+		if inStrSlice(tableStyleElementTypes, typ, true) == -1 {
+			return newInvalidTableStyleElementError(typ)
+		}
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if s.TableStyles == nil {
+		s.TableStyles = &xlsxTableStyles{DefaultTableStyle: "TableStyleMedium2", DefaultPivotStyle: "PivotStyleLight16"}
+	}
+	for _, tableStyle := range s.TableStyles.TableStyles {
+		if strings.EqualFold(tableStyle.Name, name) {
+			return newExistsTableStyleError(name)
+		}
+	}
+	tableStyle := &xlsxTableStyle{Name: name}
+	if def.Pivot {
+		tableStyle.Pivot, tableStyle.Table = 1, boolPtr(false)
+	}
+	for _, typ := range tableStyleElementTypes {
+		style, ok := def.Elements[typ]
+		if !ok || style == nil {
+			continue
+		}
+		dxfID, err := f.NewConditionalStyle(style)
+		if err != nil {
+			return err
+		}
+		tableStyle.TableStyleElement = append(tableStyle.TableStyleElement, &xlsxTableStyleElement{Type: typ, DxfID: intPtr(dxfID)})
+	}
+	tableStyle.Count = len(tableStyle.TableStyleElement)
+	s.TableStyles.TableStyles = append(s.TableStyles.TableStyles, tableStyle)
+	s.TableStyles.Count = len(s.TableStyles.TableStyles)
+	return err
+}
+
+// getTableStyle provides a function to get the definition of the custom table
+// style by given style name, it returns nil if the style is not a custom table
+// style.
+func (f *File) getTableStyle(name string) *TableStyleDefinition {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil || s.TableStyles == nil {
+		return nil
+	}
+	for _, tableStyle := range s.TableStyles.TableStyles {
+		if !strings.EqualFold(tableStyle.Name, name) {
+			continue
+		}
+		def := &TableStyleDefinition{
+			Pivot:    tableStyle.Table != nil && !*tableStyle.Table,
+			Elements: make(map[string]*Style),
+		}
+		for _, element := range tableStyle.TableStyleElement {
+			if element.DxfID == nil {
+				continue
+			}
+			if style, err := f.GetConditionalStyle(*element.DxfID); err == nil {
+				def.Elements[element.Type] = style
+			}
+		}
+		return def
+	}
+	return nil
+}
+
 // newDxfNumFmt provides a function to create number format for conditional
 // format styles.
 func newDxfNumFmt(styleSheet *xlsxStyleSheet, style *Style, dxf *xlsxDxf) *xlsxNumFmt {
@@ -1793,20 +2114,161 @@ func (f *File) GetDefaultFont() (string, error) {
 
 // SetDefaultFont changes the default font in the workbook.
 func (f *File) SetDefaultFont(fontName string) error {
+	if len(fontName) > MaxFontFamilyLength {
+		return ErrFontLength
+	}
+	return f.setDefaultFont(func(font *xlsxFont) {
+		font.Name = &attrValString{Val: stringPtr(fontName)}
+	})
+}
+
+// GetDefaultFontSize provides the default font size currently set in the
+// workbook. The spreadsheet generated by excelize default font size is 11.
+func (f *File) GetDefaultFontSize() (float64, error) {
+	font, err := f.readDefaultFont()
+	if err != nil {
+		return 0, err
+	}
+	if font.Sz == nil {
+		return 11, err
+	}
+	return font.Sz.Value(), err
+}
+
+// SetDefaultFontSize changes the default font size in the workbook. The
+// default row height of the worksheets without custom default row height
+// will be updated to match the new font size, and the column widths in the
+// character units are measured by the maximum digit width of the new font
+// size afterwards. Note that the row height and the digit width are estimated
+// by the font size, the metrics of the specific font family are not taken
+// into account. For example, set the default font size of the workbook as 10:
+//
+//	err := f.SetDefaultFontSize(10)
+func (f *File) SetDefaultFontSize(size float64) error {
+	if size < MinFontSize || size > MaxFontSize {
+		return ErrFontSize
+	}
+	if err := f.setDefaultFont(func(font *xlsxFont) {
+		font.Sz = &attrValFloat{Val: float64Ptr(size)}
+	}); err != nil {
+		return err
+	}
+	return f.setDefaultRowHeight()
+}
+
+// GetDefaultFontStyle provides a function to get the default font of the
+// workbook, including the font family, size, color, bold, italic and so on.
+// For example, get the default font of the workbook:
+//
+//	font, err := f.GetDefaultFontStyle()
+func (f *File) GetDefaultFontStyle() (*Font, error) {
+	font, err := f.readDefaultFont()
+	if err != nil {
+		return nil, err
+	}
+	var style Style
+	f.extractFont(font, nil, &style)
+	if style.Font.Size == 0 {
+		style.Font.Size = 11
+	}
+	return style.Font, err
+}
+
+// SetDefaultFontStyle provides a function to set the default font of the
+// workbook by given font settings. The default font name will be kept if the
+// font family is empty, and the default row height of the worksheets will be
+// updated to match the new font size as the SetDefaultFontSize function. For
+// example, set the default font of the workbook as bold 10 points Arial:
+//
+//	err := f.SetDefaultFontStyle(&excelize.Font{Family: "Arial", Size: 10, Bold: true})
+func (f *File) SetDefaultFontStyle(font *Font) error {
+	if font == nil {
+		return ErrParameterRequired
+	}
+	fontStyle := *font
+	style, err := parseFormatStyleSet(&Style{Font: &fontStyle})
+	if err != nil {
+		return err
+	}
+	fnt, err := f.newFont(style)
+	if err != nil {
+		return err
+	}
+	if err = f.setDefaultFont(func(font *xlsxFont) { *font = *fnt }); err != nil {
+		return err
+	}
+	return f.setDefaultRowHeight()
+}
+
+// setDefaultRowHeight provides a function to update the default row height of
+// the worksheets without custom default row height by the default font size.
+func (f *File) setDefaultRowHeight() error {
+	_, rowHeight := f.getDefaultFontMetrics()
+	for _, sheet := range f.GetSheetList() {
+		if name, ok := f.getSheetXMLPath(sheet); !ok || isNotWorksheetPath(name) {
+			continue
+		}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		if ws.SheetFormatPr == nil {
+			ws.SheetFormatPr = &xlsxSheetFormatPr{}
+		}
+		if !ws.SheetFormatPr.CustomHeight {
+			ws.SheetFormatPr.DefaultRowHeight = rowHeight
+		}
+	}
+	return nil
+}
+
+// setDefaultFont provides a function to update the font of the Normal style
+// by given function, and mark the Normal style as customized.
+func (f *File) setDefaultFont(fn func(font *xlsxFont)) error {
 	font, err := f.readDefaultFont()
 	if err != nil {
 		return err
 	}
-	font.Name.Val = stringPtr(fontName)
+	fn(font)
 	f.mu.Lock()
+	defer f.mu.Unlock()
 	s, _ := f.stylesReader()
-	f.mu.Unlock()
 	s.Fonts.Font[0] = font
 	custom := true
-	s.CellStyles.CellStyle[0].CustomBuiltIn = &custom
+	for i := 0; s.CellStyles != nil && i < len(s.CellStyles.CellStyle); i++ {
+		cellStyle := s.CellStyles.CellStyle[i]
+		if cellStyle == nil || cellStyle.BuiltInID == nil || *cellStyle.BuiltInID != 0 {
+			continue
+		}
+		cellStyle.CustomBuiltIn = &custom
+		if s.CellStyleXfs != nil && cellStyle.XfID < len(s.CellStyleXfs.Xf) {
+			s.CellStyleXfs.Xf[cellStyle.XfID].FontID = intPtr(0)
+		}
+	}
 	return err
 }
 
+// getDefaultFontMetrics provides a function to estimate the maximum digit
+// width in pixels and the default row height in points of the default font
+// by the font size.
+func (f *File) getDefaultFontMetrics() (maxDigitWidth, rowHeight float64) {
+	size := 11.0
+	s, err := f.stylesReader()
+	if err != nil {
+		// Discard the partially decoded style sheet, so that the error will be
+		// returned by the next reading.
+		f.Styles = nil
+	}
+	if err == nil && s.Fonts != nil && len(s.Fonts.Font) > 0 {
+		if fnt := s.Fonts.Font[0]; fnt != nil && fnt.Sz != nil && fnt.Sz.Value() > 0 {
+			size = fnt.Sz.Value()
+		}
+	}
+	maxDigitWidth = math.Max(math.Round(size*2/3), 1)
+	rowHeight = math.Ceil(size*1.3/0.75-1e-9) * 0.75
+	return
+}
+
 // readDefaultFont provides an un-marshalled font value.
 func (f *File) readDefaultFont() (*xlsxFont, error) {
 	f.mu.Lock()
@@ -1887,9 +2349,11 @@ func (f *File) newFont(style *Style) (*xlsxFont, error) {
 		fnt.I = &attrValBool{Val: &style.Font.Italic}
 	}
 	if *fnt.Name.Val == "" {
-		if *fnt.Name.Val, err = f.GetDefaultFont(); err != nil {
+		defaultFont, err := f.GetDefaultFont()
+		if err != nil {
 			return &fnt, err
 		}
+		*fnt.Name.Val = defaultFont
 	}
 	if style.Font.Strike {
 		fnt.Strike = &attrValBool{Val: &style.Font.Strike}
@@ -1897,6 +2361,21 @@ func (f *File) newFont(style *Style) (*xlsxFont, error) {
 	if idx := inStrSlice(supportedUnderlineTypes, style.Font.Underline, true); idx != -1 {
 		fnt.U = &attrValString{Val: stringPtr(supportedUnderlineTypes[idx])}
 	}
+	if style.Font.Condense {
+		fnt.Condense = &attrValBool{Val: &style.Font.Condense}
+	}
+	if style.Font.Extend {
+		fnt.Extend = &attrValBool{Val: &style.Font.Extend}
+	}
+	if idx := inStrSlice(supportedFontVertAlign, style.Font.VertAlign, false); idx != -1 {
+		fnt.VertAlign = &attrValString{Val: stringPtr(supportedFontVertAlign[idx])}
+	}
+	if style.Font.Charset != nil {
+		fnt.Charset = &attrValInt{Val: intPtr(*style.Font.Charset)}
+	}
+	if idx := inStrSlice(supportedFontSchemes, style.Font.Scheme, false); idx != -1 {
+		fnt.Scheme = &attrValString{Val: stringPtr(supportedFontSchemes[idx])}
+	}
 	return &fnt, err
 }
 
@@ -2037,6 +2516,10 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
+		if style.Fill.Gradient != nil {
+			fill.GradientFill = newGradientFill(style.Fill.Gradient)
+			break
+		}
 		if len(style.Fill.Color) != 2 || style.Fill.Shading < 0 || style.Fill.Shading > 16 {
 			break
 		}
@@ -2075,6 +2558,22 @@ func newFills(style *Style, fg bool) *xlsxFill {
 	return &fill
 }
 
+// newGradientFill provides a function to create the gradient fill element by
+// given gradient fill settings with arbitrary stops.
+func newGradientFill(g *GradientFill) *xlsxGradientFill {
+	gradient := xlsxGradientFill{Degree: g.Degree}
+	if g.Type == "path" {
+		gradient = xlsxGradientFill{Type: g.Type, Left: g.Left, Right: g.Right, Top: g.Top, Bottom: g.Bottom}
+	}
+	for _, stop := range g.Stops {
+		gradient.Stop = append(gradient.Stop, &xlsxGradientFillStop{
+			Position: stop.Position,
+			Color:    xlsxColor{RGB: getPaletteColor(stop.Color)},
+		})
+	}
+	return &gradient
+}
+
 // newAlignment provides a function to formatting information pertaining to
 // text alignment in cells. There are a variety of choices for how text is
 // aligned both horizontally and vertically, as well as indentation settings,
@@ -2127,7 +2626,7 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 func newBorders(style *Style) *xlsxBorder {
 	var border xlsxBorder
 	for _, v := range style.Border {
-		if 0 <= v.Style && v.Style < 14 {
+		if 0 <= v.Style && v.Style < len(styleBorders) {
 			var color xlsxColor
 			color.RGB = getPaletteColor(v.Color)
 			switch v.Type {
@@ -2160,6 +2659,20 @@ func newBorders(style *Style) *xlsxBorder {
 // setCellXfs provides a function to set describes all the formatting for a
 // cell.
 func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) (int, error) {
+	if len(style.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	xf := newXf(fontID, numFmtID, fillID, borderID, applyAlignment, applyProtection, alignment, protection)
+	xfID := 0
+	xf.XfID = &xfID
+	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
+	style.CellXfs.Count = len(style.CellXfs.Xf)
+	return style.CellXfs.Count - 1, nil
+}
+
+// newXf provides a function to create the formatting record by given index
+// of the number format, font, fill and border records.
+func newXf(fontID, numFmtID, fillID, borderID int, applyAlignment, applyProtection bool, alignment *xlsxAlignment, protection *xlsxProtection) xlsxXf {
 	var xf xlsxXf
 	xf.FontID = intPtr(fontID)
 	if fontID != 0 {
@@ -2177,10 +2690,6 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 	if borderID != 0 {
 		xf.ApplyBorder = boolPtr(true)
 	}
-	if len(style.CellXfs.Xf) == MaxCellStyles {
-		return 0, ErrCellStyles
-	}
-	style.CellXfs.Count = len(style.CellXfs.Xf) + 1
 	xf.Alignment = alignment
 	if alignment != nil {
 		xf.ApplyAlignment = boolPtr(applyAlignment)
@@ -2189,10 +2698,7 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 		xf.ApplyProtection = boolPtr(applyProtection)
 		xf.Protection = protection
 	}
-	xfID := 0
-	xf.XfID = &xfID
-	style.CellXfs.Xf = append(style.CellXfs.Xf, xf)
-	return style.CellXfs.Count - 1, nil
+	return xf
 }
 
 // GetCellStyle provides a function to get cell style index by given worksheet
@@ -2215,10 +2721,75 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
 }
 
+// GetCellEffectiveStyle provides a function to get the effective style index
+// and the resolved style definition of the cell by given worksheet name and
+// cell reference. The existing cell always uses its own style, even if the
+// style index is 0. When the cell doesn't exist, the displayed formatting of
+// the cell comes from the row style with custom format, the column style, and
+// then the Normal style with index 0. The theme and indexed colors in the
+// returned style will be converted to RGB colors. This function is concurrency
+// safe. For example, get the effective style of cell A1 on Sheet1:
+//
+//	styleID, style, err := f.GetCellEffectiveStyle("Sheet1", "A1")
+func (f *File) GetCellEffectiveStyle(sheet, cell string) (int, *Style, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return 0, nil, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	styleID, err := ws.getCellEffectiveStyle(cell)
+	ws.mu.Unlock()
+	if err != nil {
+		return styleID, nil, err
+	}
+	style, err := f.GetStyle(styleID)
+	return styleID, style, err
+}
+
+// getCellEffectiveStyle provides a function to get the effective style index
+// of the cell by given cell reference without creating the cell. The existing
+// cell always uses its own style, and the cell which doesn't exist inherits
+// the custom row style first, and then the column style.
+func (ws *xlsxWorksheet) getCellEffectiveStyle(cell string) (int, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return 0, err
+	}
+	if cell, err = CoordinatesToCellName(col, row); err != nil {
+		return 0, err
+	}
+	for rowIdx := range ws.SheetData.Row {
+		rowData := &ws.SheetData.Row[rowIdx]
+		if rowData.R != row {
+			continue
+		}
+		for colIdx := range rowData.C {
+			if colData := &rowData.C[colIdx]; colData.R == cell {
+				return colData.S, err
+			}
+		}
+		if rowData.CustomFormat && rowData.S != 0 {
+			return rowData.S, err
+		}
+		break
+	}
+	if ws.Cols != nil {
+		for _, c := range ws.Cols.Col {
+			if c.Min <= col && col <= c.Max && c.Style != 0 {
+				return c.Style, err
+			}
+		}
+	}
+	return 0, err
+}
+
 // SetCellStyle provides a function to add style attribute for cells by given
 // worksheet name, range reference and style ID. This function is concurrency
-// safe. Note that diagonalDown and diagonalUp type border should be use same
-// color in the same range. SetCellStyle will overwrite the existing
+// safe. Note that diagonalDown and diagonalUp type border share the same style
+// and color in the same range. SetCellStyle will overwrite the existing
 // styles for the cell, it won't append or merge style with existing styles.
 //
 // For example create a borders of cell H9 on Sheet1:
@@ -2230,7 +2801,7 @@ func (f *File) GetCellStyle(sheet, cell string) (int, error) {
 //	        {Type: "bottom", Color: "FFFF00", Style: 5},
 //	        {Type: "right", Color: "FF0000", Style: 6},
 //	        {Type: "diagonalDown", Color: "A020F0", Style: 7},
-//	        {Type: "diagonalUp", Color: "A020F0", Style: 8},
+//	        {Type: "diagonalUp", Color: "A020F0", Style: 7},
 //	    },
 //	})
 //	if err != nil {
@@ -2374,20 +2945,423 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 	return err
 }
 
-// SetConditionalFormat provides a function to create conditional formatting
-// rule for cell value. Conditional formatting is a feature of Excel which
-// allows you to apply a format to a cell or a range of cells based on certain
-// criteria.
+// SetCellStylePartial provides a function to patch the style of cells by given
+// worksheet name, range reference, style patch and the fields of the style to
+// be overridden. Unlike SetCellStyle, which replaces the style of the cells
+// wholesale, this function keeps the other fields of the existing style for
+// each cell, derives a new style with only the specified fields overridden by
+// the patch, and reuses it for the cells which shared the same existing
+// style. For example, apply the number format with 2 decimal places for the
+// cells A1:C3 on Sheet1, and keep the fonts, fills and borders of the cells:
 //
-// The type option is a required parameter and it has no default value.
-// Allowable type values and their associated parameters are:
+//	err := f.SetCellStylePartial("Sheet1", "A1", "C3",
+//	    &excelize.Style{NumFmt: 2}, excelize.StyleFieldNumFmt)
 //
-//	 Type          | Parameters
-//	---------------+------------------------------------
-//	 cell          | Criteria
-//	               | Value
-//	               | MinValue
-//	               | MaxValue
+// Combine the style fields to override multiple fields at once, for example:
+//
+//	err := f.SetCellStylePartial("Sheet1", "A1", "C3", &excelize.Style{
+//	    Font: &excelize.Font{Bold: true},
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1},
+//	}, excelize.StyleFieldFont|excelize.StyleFieldFill)
+func (f *File) SetCellStylePartial(sheet, topLeftCell, bottomRightCell string, patch *Style, fields StyleFields) error {
+	if patch == nil {
+		return ErrParameterInvalid
+	}
+	hCol, hRow, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	vCol, vRow, err := CellNameToCoordinates(bottomRightCell)
+	if err != nil {
+		return err
+	}
+	// Normalize the range, such correct C1:B3 to B1:C3.
+	if vCol < hCol {
+		vCol, hCol = hCol, vCol
+	}
+	if vRow < hRow {
+		vRow, hRow = hRow, vRow
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.prepareSheetXML(vCol, vRow)
+	ws.makeContiguousColumns(hRow, vRow, vCol)
+
+	derived := make(map[int]int)
+	for row := hRow; row <= vRow; row++ {
+		for col := hCol; col <= vCol; col++ {
+			c := &ws.SheetData.Row[row-1].C[col-1]
+			styleID := ws.prepareCellStyle(col, row, c.S)
+			newStyleID, ok := derived[styleID]
+			if !ok {
+				if newStyleID, err = f.patchStyle(styleID, patch, fields); err != nil {
+					return err
+				}
+				derived[styleID] = newStyleID
+			}
+			c.S = newStyleID
+		}
+	}
+	return err
+}
+
+// patchStyle provides a function to create a style derived from the existing
+// style by given style index, with the specified fields overridden by the
+// style patch, and returns the index of the derived style.
+func (f *File) patchStyle(styleID int, patch *Style, fields StyleFields) (int, error) {
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return styleID, err
+	}
+	if fields&StyleFieldBorder != 0 {
+		style.Border = patch.Border
+	}
+	if fields&StyleFieldFill != 0 {
+		style.Fill = patch.Fill
+	}
+	if fields&StyleFieldFont != 0 {
+		style.Font = patch.Font
+	}
+	if fields&StyleFieldAlignment != 0 {
+		style.Alignment = patch.Alignment
+	}
+	if fields&StyleFieldProtection != 0 {
+		style.Protection = patch.Protection
+	}
+	if fields&StyleFieldNumFmt != 0 {
+		style.NumFmt, style.DecimalPlaces = patch.NumFmt, patch.DecimalPlaces
+		style.CustomNumFmt, style.NegRed = patch.CustomNumFmt, patch.NegRed
+	}
+	return f.NewStyle(style)
+}
+
+// builtInNamedStyles provides a function to get the built-in ID and the
+// formatting of the built-in named cell styles.
+func builtInNamedStyles() map[string]NamedStyle {
+	bold := func(color string) *Font { return &Font{Bold: true, Color: color} }
+	return map[string]NamedStyle{
+		"Normal":             {BuiltInID: intPtr(0), Style: &Style{}},
+		"Comma":              {BuiltInID: intPtr(3), Style: &Style{NumFmt: 43}},
+		"Currency":           {BuiltInID: intPtr(4), Style: &Style{NumFmt: 44}},
+		"Percent":            {BuiltInID: intPtr(5), Style: &Style{NumFmt: 9}},
+		"Comma [0]":          {BuiltInID: intPtr(6), Style: &Style{NumFmt: 41}},
+		"Currency [0]":       {BuiltInID: intPtr(7), Style: &Style{NumFmt: 42}},
+		"Hyperlink":          {BuiltInID: intPtr(8), Style: &Style{Font: &Font{Color: "0563C1", Underline: "single"}}},
+		"Followed Hyperlink": {BuiltInID: intPtr(9), Style: &Style{Font: &Font{Color: "954F72", Underline: "single"}}},
+		"Note": {BuiltInID: intPtr(10), Style: &Style{
+			Fill: Fill{Type: "pattern", Color: []string{"FFFFCC"}, Pattern: 1},
+			Border: []Border{
+				{Type: "left", Color: "B2B2B2", Style: 1}, {Type: "right", Color: "B2B2B2", Style: 1},
+				{Type: "top", Color: "B2B2B2", Style: 1}, {Type: "bottom", Color: "B2B2B2", Style: 1},
+			},
+		}},
+		"Warning Text": {BuiltInID: intPtr(11), Style: &Style{Font: &Font{Color: "FF0000"}}},
+		"Title":        {BuiltInID: intPtr(15), Style: &Style{Font: &Font{Family: "Calibri Light", Size: 18, Color: "44546A"}}},
+		"Heading 1": {BuiltInID: intPtr(16), Style: &Style{
+			Font:   &Font{Bold: true, Size: 15, Color: "44546A"},
+			Border: []Border{{Type: "bottom", Color: "4472C4", Style: 5}},
+		}},
+		"Heading 2": {BuiltInID: intPtr(17), Style: &Style{
+			Font:   &Font{Bold: true, Size: 13, Color: "44546A"},
+			Border: []Border{{Type: "bottom", Color: "A2B8E1", Style: 5}},
+		}},
+		"Heading 3": {BuiltInID: intPtr(18), Style: &Style{
+			Font:   bold("44546A"),
+			Border: []Border{{Type: "bottom", Color: "8EA9DB", Style: 2}},
+		}},
+		"Heading 4": {BuiltInID: intPtr(19), Style: &Style{Font: bold("44546A")}},
+		"Input": {BuiltInID: intPtr(20), Style: &Style{
+			Font: &Font{Color: "3F3F76"},
+			Fill: Fill{Type: "pattern", Color: []string{"FFCC99"}, Pattern: 1},
+			Border: []Border{
+				{Type: "left", Color: "7F7F7F", Style: 1}, {Type: "right", Color: "7F7F7F", Style: 1},
+				{Type: "top", Color: "7F7F7F", Style: 1}, {Type: "bottom", Color: "7F7F7F", Style: 1},
+			},
+		}},
+		"Output": {BuiltInID: intPtr(21), Style: &Style{
+			Font: bold("3F3F3F"),
+			Fill: Fill{Type: "pattern", Color: []string{"F2F2F2"}, Pattern: 1},
+			Border: []Border{
+				{Type: "left", Color: "3F3F3F", Style: 1}, {Type: "right", Color: "3F3F3F", Style: 1},
+				{Type: "top", Color: "3F3F3F", Style: 1}, {Type: "bottom", Color: "3F3F3F", Style: 1},
+			},
+		}},
+		"Calculation": {BuiltInID: intPtr(22), Style: &Style{
+			Font: bold("FA7D00"),
+			Fill: Fill{Type: "pattern", Color: []string{"F2F2F2"}, Pattern: 1},
+			Border: []Border{
+				{Type: "left", Color: "7F7F7F", Style: 1}, {Type: "right", Color: "7F7F7F", Style: 1},
+				{Type: "top", Color: "7F7F7F", Style: 1}, {Type: "bottom", Color: "7F7F7F", Style: 1},
+			},
+		}},
+		"Check Cell": {BuiltInID: intPtr(23), Style: &Style{
+			Font: bold("FFFFFF"),
+			Fill: Fill{Type: "pattern", Color: []string{"A5A5A5"}, Pattern: 1},
+			Border: []Border{
+				{Type: "left", Color: "3F3F3F", Style: 6}, {Type: "right", Color: "3F3F3F", Style: 6},
+				{Type: "top", Color: "3F3F3F", Style: 6}, {Type: "bottom", Color: "3F3F3F", Style: 6},
+			},
+		}},
+		"Linked Cell": {BuiltInID: intPtr(24), Style: &Style{
+			Font:   &Font{Color: "FA7D00"},
+			Border: []Border{{Type: "bottom", Color: "FF8001", Style: 6}},
+		}},
+		"Total": {BuiltInID: intPtr(25), Style: &Style{
+			Font: bold("000000"),
+			Border: []Border{
+				{Type: "top", Color: "4472C4", Style: 1}, {Type: "bottom", Color: "4472C4", Style: 6},
+			},
+		}},
+		"Good": {BuiltInID: intPtr(26), Style: &Style{
+			Font: &Font{Color: "006100"},
+			Fill: Fill{Type: "pattern", Color: []string{"C6EFCE"}, Pattern: 1},
+		}},
+		"Bad": {BuiltInID: intPtr(27), Style: &Style{
+			Font: &Font{Color: "9C0006"},
+			Fill: Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1},
+		}},
+		"Neutral": {BuiltInID: intPtr(28), Style: &Style{
+			Font: &Font{Color: "9C5700"},
+			Fill: Fill{Type: "pattern", Color: []string{"FFEB9C"}, Pattern: 1},
+		}},
+		"Explanatory Text": {BuiltInID: intPtr(53), Style: &Style{Font: &Font{Italic: true, Color: "7F7F7F"}}},
+	}
+}
+
+// NewNamedStyle provides a function to create a custom named cell style by
+// given style name and style options. The named style can be applied to cells
+// by the SetCellNamedStyle function, and it will be listed in the cell styles
+// gallery of the spreadsheet application. For example, create a named style
+// "Highlight" with bold font and yellow fill:
+//
+//	err := f.NewNamedStyle("Highlight", &excelize.Style{
+//	    Font: &excelize.Font{Bold: true},
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+//	})
+func (f *File) NewNamedStyle(name string, style *Style) error {
+	if name == "" || style == nil {
+		return ErrParameterRequired
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.getNamedStyleXfID(name); ok {
+		return ErrNamedStyleDuplicate
+	}
+	_, err = f.addNamedStyle(s, name, nil, style)
+	return err
+}
+
+// addNamedStyle provides a function to create the master formatting record
+// and the cell style record of the named style, and returns the index of the
+// master formatting record.
+func (f *File) addNamedStyle(s *xlsxStyleSheet, name string, builtInID *int, style *Style) (int, error) {
+	fs, err := parseFormatStyleSet(style)
+	if err != nil {
+		return -1, err
+	}
+	if s.CellStyles == nil {
+		s.CellStyles = &xlsxCellStyles{}
+	}
+	if s.CellStyleXfs == nil {
+		s.CellStyleXfs = &xlsxCellStyleXfs{Xf: []xlsxXf{{NumFmtID: intPtr(0), FontID: intPtr(0), FillID: intPtr(0), BorderID: intPtr(0)}}}
+		s.CellStyles.CellStyle = append([]*xlsxCellStyle{{Name: "Normal", XfID: 0, BuiltInID: intPtr(0)}}, s.CellStyles.CellStyle...)
+	}
+	numFmtID, fontID, fillID, borderID := f.newXfComponents(s, fs)
+	xf := newXf(fontID, numFmtID, fillID, borderID, fs.Alignment != nil, fs.Protection != nil, newAlignment(fs), newProtection(fs))
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, xf)
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	xfID := s.CellStyleXfs.Count - 1
+	s.CellStyles.CellStyle = append(s.CellStyles.CellStyle, &xlsxCellStyle{Name: name, XfID: xfID, BuiltInID: builtInID})
+	s.CellStyles.Count = len(s.CellStyles.CellStyle)
+	return xfID, err
+}
+
+// getNamedStyleXfID provides a function to get the index of the master
+// formatting record by given named style name.
+func (s *xlsxStyleSheet) getNamedStyleXfID(name string) (int, bool) {
+	if s.CellStyles == nil || s.CellStyleXfs == nil {
+		return -1, false
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if cellStyle != nil && strings.EqualFold(cellStyle.Name, name) && cellStyle.XfID < len(s.CellStyleXfs.Xf) {
+			return cellStyle.XfID, true
+		}
+	}
+	return -1, false
+}
+
+// GetNamedStyles provides a function to get all named cell styles defined in
+// the workbook, including the built-in named styles which have been used.
+func (f *File) GetNamedStyles() ([]NamedStyle, error) {
+	var namedStyles []NamedStyle
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return namedStyles, err
+	}
+	f.mu.Unlock()
+	if s.CellStyles == nil || s.CellStyleXfs == nil {
+		return namedStyles, err
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if cellStyle == nil || cellStyle.XfID >= len(s.CellStyleXfs.Xf) {
+			continue
+		}
+		namedStyles = append(namedStyles, NamedStyle{
+			Name:      cellStyle.Name,
+			BuiltInID: cellStyle.BuiltInID,
+			Style:     f.extractXf(s.CellStyleXfs.Xf[cellStyle.XfID], s),
+		})
+	}
+	return namedStyles, err
+}
+
+// SetCellNamedStyle provides a function to apply the named cell style for
+// cells by given worksheet name, range reference and style name. The
+// built-in named styles, such as "Good", "Bad", "Neutral", "Input", "Output",
+// "Heading 1" and so on, will be created with the correct built-in ID on
+// first use. The direct formatting which has been set on the cells will be
+// kept and layered on top of the named style. For example, apply the built-in
+// "Good" style for the range A1:B2 on Sheet1:
+//
+//	err := f.SetCellNamedStyle("Sheet1", "A1", "B2", "Good")
+func (f *File) SetCellNamedStyle(sheet, topLeftCell, bottomRightCell, styleName string) error {
+	coordinates, err := cellRefsToCoordinates(topLeftCell, bottomRightCell)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	xfID, err := f.getOrAddNamedStyle(s, styleName)
+	if err != nil {
+		return err
+	}
+	// Lock the worksheet before the style sheet, the same order as the other
+	// style setters, to avoid deadlock.
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ws.prepareSheetXML(coordinates[2], coordinates[3])
+	ws.makeContiguousColumns(coordinates[1], coordinates[3], coordinates[2])
+	styleIDs := make(map[int]int)
+	for r := coordinates[1] - 1; r < coordinates[3]; r++ {
+		for c := coordinates[0] - 1; c < coordinates[2]; c++ {
+			cell := &ws.SheetData.Row[r].C[c]
+			styleID, ok := styleIDs[cell.S]
+			if !ok {
+				if styleID, err = s.setNamedCellXfs(xfID, cell.S); err != nil {
+					return err
+				}
+				styleIDs[cell.S] = styleID
+			}
+			cell.S = styleID
+		}
+	}
+	return err
+}
+
+// getOrAddNamedStyle provides a function to get the index of the master
+// formatting record by given named style name, the built-in named style will
+// be created if it doesn't exist.
+func (f *File) getOrAddNamedStyle(s *xlsxStyleSheet, styleName string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if xfID, ok := s.getNamedStyleXfID(styleName); ok {
+		return xfID, nil
+	}
+	namedStyle, ok := builtInNamedStyles()[styleName]
+	if !ok {
+		return -1, newNoExistNamedStyleError(styleName)
+	}
+	return f.addNamedStyle(s, styleName, namedStyle.BuiltInID, namedStyle.Style)
+}
+
+// setNamedCellXfs provides a function to get or create the cell formatting
+// record linked to the given named style master formatting record, the
+// applied direct formatting of the given cell formatting record will be kept.
+func (s *xlsxStyleSheet) setNamedCellXfs(xfID, styleID int) (int, error) {
+	xf := s.CellStyleXfs.Xf[xfID]
+	xf.XfID = intPtr(xfID)
+	if s.CellXfs != nil && styleID > 0 && styleID < len(s.CellXfs.Xf) {
+		cellXf := s.CellXfs.Xf[styleID]
+		if cellXf.ApplyNumberFormat != nil && *cellXf.ApplyNumberFormat {
+			xf.NumFmtID, xf.ApplyNumberFormat = cellXf.NumFmtID, cellXf.ApplyNumberFormat
+		}
+		if cellXf.ApplyFont != nil && *cellXf.ApplyFont {
+			xf.FontID, xf.ApplyFont = cellXf.FontID, cellXf.ApplyFont
+		}
+		if cellXf.ApplyFill != nil && *cellXf.ApplyFill {
+			xf.FillID, xf.ApplyFill = cellXf.FillID, cellXf.ApplyFill
+		}
+		if cellXf.ApplyBorder != nil && *cellXf.ApplyBorder {
+			xf.BorderID, xf.ApplyBorder = cellXf.BorderID, cellXf.ApplyBorder
+		}
+		if cellXf.ApplyAlignment != nil && *cellXf.ApplyAlignment {
+			xf.Alignment, xf.ApplyAlignment = cellXf.Alignment, cellXf.ApplyAlignment
+		}
+		if cellXf.ApplyProtection != nil && *cellXf.ApplyProtection {
+			xf.Protection, xf.ApplyProtection = cellXf.Protection, cellXf.ApplyProtection
+		}
+	}
+	if s.CellXfs == nil {
+		s.CellXfs = &xlsxCellXfs{}
+	}
+	for idx, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			return idx, nil
+		}
+	}
+	if len(s.CellXfs.Xf) == MaxCellStyles {
+		return 0, ErrCellStyles
+	}
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, nil
+}
+
+// SetConditionalFormat provides a function to create conditional formatting
+// rule for cell value. Conditional formatting is a feature of Excel which
+// allows you to apply a format to a cell or a range of cells based on certain
+// criteria.
+//
+// The type option is a required parameter and it has no default value.
+// Allowable type values and their associated parameters are:
+//
+//	 Type          | Parameters
+//	---------------+------------------------------------
+//	 cell          | Criteria
+//	               | Value
+//	               | MinValue
+//	               | MaxValue
 //	 time_period   | Criteria
 //	 text          | Criteria
 //	               | Value
@@ -2426,7 +3400,14 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 //	               | BarDirection
 //	               | BarOnly
 //	               | BarSolid
+//	               | NegativeBarColor
+//	               | NegativeBarBorderColor
+//	               | AxisColor
+//	               | AxisPosition
+//	               | MinLength
+//	               | MaxLength
 //	 icon_set      | IconStyle
+//	               | IconThresholds
 //	               | ReverseIcons
 //	               | IconsOnly
 //	 formula       | Criteria
@@ -2499,6 +3480,27 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 //	    },
 //	)
 //
+// type: format style - The FormatStyle parameter can be used to specify the
+// format by the style definition directly instead of the pre-created format,
+// the format will be created automatically, and the Format parameter will be
+// ignored unless it refers to a format with the same style definition. The
+// GetConditionalFormats function returns the style definition of the format
+// in this parameter, so that the conditional formats can be copied to another
+// worksheet or workbook by the SetConditionalFormat function:
+//
+//	err := f.SetConditionalFormat("Sheet1", "D1:D10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:     "cell",
+//	            Criteria: ">",
+//	            Value:    "6",
+//	            FormatStyle: &excelize.Style{
+//	                Font: &excelize.Font{Color: "9A0511"},
+//	            },
+//	        },
+//	    },
+//	)
+//
 // Note: In Excel, a conditional format is superimposed over the existing cell
 // format and not all cell format properties can be modified. Properties that
 // cannot be modified in a conditional format are font name, font size,
@@ -2758,6 +3760,31 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 // BarSolid - Used for turns on a solid (non-gradient) fill for data bars, this
 // is only visible in Excel 2010 and later.
 //
+// NegativeBarColor - Used for sets the fill color for the negative values of
+// a data bar, the default value is #FF0000. This is only visible in Excel 2010
+// and later.
+//
+// NegativeBarBorderColor - Used for sets the border color for the negative
+// values of a data bar, the border color of the positive values will be used
+// if this is empty. This is only visible in Excel 2010 and later.
+//
+// AxisColor - Used for sets the color of the axis line for the data bar, the
+// default value is #FF0000. This is only visible in Excel 2010 and later.
+//
+// AxisPosition - Used for sets the position of the axis for the data bar. The
+// available options are:
+//
+//	automatic - Display the axis at a variable position based on the negative values.
+//	middle - Display the axis at the midpoint of the cell.
+//	none - No axis, the negative values bars are shown in the same direction as the positive values bars.
+//
+// MinLength - Used for sets the shortest bar length in percentage of the cell
+// width, between 0 and 100, the default value is 0.
+//
+// MaxLength - Used for sets the longest bar length in percentage of the cell
+// width, between 0 and 100, the default value is 100. The MinLength must be
+// less than or equal to the MaxLength.
+//
 // IconStyle - The available options are:
 //
 //	3Arrows
@@ -2778,6 +3805,32 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 //	5Quarters
 //	5Rating
 //
+// The icon styles 3Stars, 3Triangles and 5Boxes and the custom combinations of
+// the icons which introduced in Excel 2010 are not supported.
+//
+// IconThresholds - Used for sets the thresholds of the icons except the first
+// one, the number of the thresholds must be one less than the number of the
+// icons in the IconStyle. The Type of the threshold can be num, percent,
+// percentile or formula, and the icon will be shown when the cell value is
+// greater than or equal to the Value of the threshold, or greater than it if
+// the GreaterThan is true. The thresholds of each icon style default to the
+// equal percent intervals. For example, show the red, yellow and green traffic
+// lights for the cell values less than 50, between 50 and 80 and greater than
+// 80:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:      "icon_set",
+//	            IconStyle: "3TrafficLights1",
+//	            IconThresholds: []excelize.ConditionalFormatIconThreshold{
+//	                {Type: "num", Value: "50"},
+//	                {Type: "num", Value: "80", GreaterThan: true},
+//	            },
+//	        },
+//	    },
+//	)
+//
 // ReverseIcons - Used for set reversed icons sets.
 //
 // IconsOnly - Used for set displayed without the cell value.
@@ -2786,6 +3839,15 @@ func (f *File) SetCellStyle(sheet, topLeftCell, bottomRightCell string, styleID
 // formatting rule when more than one rule is applied to a cell or a range of
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
+//
+// Priority - used to set the evaluation order of the conditional formatting
+// rule, the priorities are unique and shared by all conditional formatting
+// rules in the worksheet, and the rule with the lower value is evaluated
+// first. The rules will be appended after the existing rules by default, if
+// this parameter is set then the rule will be inserted at the given priority
+// and the priorities of the other rules in the worksheet will be renumbered.
+// The rule will be placed at the end if the priority exceeds the number of
+// rules in the worksheet.
 func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFormatOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -2797,11 +3859,18 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 	}
 	// Create a pseudo GUID for each unique rule.
 	var rules int
+	ruleIDs := make(map[string]bool)
 	for _, cf := range ws.ConditionalFormatting {
 		rules += len(cf.CfRule)
+		for _, cr := range cf.CfRule {
+			if ID := getCfRuleExtID(cr); ID != "" {
+				ruleIDs[ID] = true
+			}
+		}
 	}
 	var (
 		cfRule          []*xlsxCfRule
+		priorities      []cfRulePriority
 		noCriteriaTypes = []string{
 			"containsBlanks",
 			"notContainsBlanks",
@@ -2814,6 +3883,14 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 	for i, opt := range opts {
 		var vt, ct string
 		var ok bool
+		if opt.Priority < 0 {
+			return ErrParameterInvalid
+		}
+		if opt.FormatStyle != nil {
+			if opt.Format, err = f.getCondFmtStyleID(opt.Format, opt.FormatStyle); err != nil {
+				return err
+			}
+		}
 		// "type" is a required parameter, check for valid validation types.
 		vt, ok = validType[opt.Type]
 		if ok {
@@ -2822,12 +3899,21 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 			if ok || inStrSlice(noCriteriaTypes, vt, true) != -1 {
 				drawFunc, ok := drawContFmtFunc[vt]
 				if ok {
+					var GUID string
 					priority := rules + i
-					rule, x14rule := drawFunc(priority, ct, mastCell,
-						fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), priority), &opt)
+					for ID := priority; ; ID++ {
+						if GUID = fmt.Sprintf("{00000000-0000-0000-%04X-%012X}", f.getSheetID(sheet), ID); !ruleIDs[GUID] {
+							break
+						}
+					}
+					ruleIDs[GUID] = true
+					rule, x14rule := drawFunc(priority, ct, mastCell, GUID, &opt)
 					if rule == nil {
 						return ErrParameterInvalid
 					}
+					if opt.Priority > 0 {
+						priorities = append(priorities, cfRulePriority{rule: rule, priority: opt.Priority})
+					}
 					if x14rule != nil {
 						if err = f.appendCfRule(ws, x14rule); err != nil {
 							return err
@@ -2847,9 +3933,79 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		SQRef:  SQRef,
 		CfRule: cfRule,
 	})
+	sort.SliceStable(priorities, func(i, j int) bool {
+		return priorities[i].priority < priorities[j].priority
+	})
+	for _, p := range priorities {
+		ws.setCfRulePriority(p.rule, p.priority)
+	}
 	return err
 }
 
+// getCondFmtStyleID returns the conditional format style index by given
+// format style index and style definition, the format style index will be
+// reused if it refers to the same style definition, otherwise a new
+// conditional format style will be created.
+func (f *File) getCondFmtStyleID(format *int, style *Style) (*int, error) {
+	if format != nil {
+		if existing, err := f.GetConditionalStyle(*format); err == nil && reflect.DeepEqual(existing, style) {
+			return format, err
+		}
+	}
+	styleID, err := f.NewConditionalStyle(style)
+	return &styleID, err
+}
+
+// cfRulePriority directly maps the conditional formatting rule and the
+// priority requested for it.
+type cfRulePriority struct {
+	rule     *xlsxCfRule
+	priority int
+}
+
+// getCfRuleExtID returns the ID of the conditional formatting rule extension
+// by given conditional formatting rule, it returns empty if the rule has no
+// extension.
+func getCfRuleExtID(cr *xlsxCfRule) string {
+	if cr.ExtLst == nil {
+		return ""
+	}
+	ext := decodeX14ConditionalFormattingExt{}
+	_ = xml.Unmarshal([]byte(cr.ExtLst.Ext), &ext)
+	return ext.ID
+}
+
+// setCfRulePriority moves the given conditional formatting rule to the given
+// priority and renumbers the priorities of all conditional formatting rules
+// in the worksheet with continuous values starting at 1. The priorities will
+// be renumbered only if the given rule is nil.
+func (ws *xlsxWorksheet) setCfRulePriority(rule *xlsxCfRule, priority int) {
+	var cfRules []*xlsxCfRule
+	for _, cf := range ws.ConditionalFormatting {
+		if cf == nil {
+			continue
+		}
+		for _, cr := range cf.CfRule {
+			if cr != rule {
+				cfRules = append(cfRules, cr)
+			}
+		}
+	}
+	sort.SliceStable(cfRules, func(i, j int) bool {
+		return cfRules[i].Priority < cfRules[j].Priority
+	})
+	if rule != nil {
+		idx := priority - 1
+		if idx > len(cfRules) {
+			idx = len(cfRules)
+		}
+		cfRules = append(cfRules[:idx], append([]*xlsxCfRule{rule}, cfRules[idx:]...)...)
+	}
+	for i, cr := range cfRules {
+		cr.Priority = i + 1
+	}
+}
+
 // prepareConditionalFormatRange returns checked cell range and master cell
 // reference by giving conditional formatting range reference.
 func prepareConditionalFormatRange(rangeRef string) (string, string, error) {
@@ -3103,6 +4259,26 @@ func (f *File) extractCondFmtDataBarRule(ID string, format *ConditionalFormatOpt
 				if rule.DataBar.BorderColor != nil {
 					format.BarBorderColor = "#" + f.getThemeColor(rule.DataBar.BorderColor)
 				}
+				if color := f.getThemeColor(rule.DataBar.NegativeFillColor); color != "" && !strings.EqualFold(color, "FF0000") {
+					format.NegativeBarColor = "#" + color
+				}
+				if sameAsPositive := rule.DataBar.NegativeBarBorderColorSameAsPositive; sameAsPositive != nil && !*sameAsPositive && rule.DataBar.NegativeBorderColor != nil {
+					format.NegativeBarBorderColor = "#" + f.getThemeColor(rule.DataBar.NegativeBorderColor)
+				}
+				if color := f.getThemeColor(rule.DataBar.AxisColor); color != "" && !strings.EqualFold(color, "FF0000") {
+					format.AxisColor = "#" + color
+				}
+				format.AxisPosition = rule.DataBar.AxisPosition
+				format.MinLength, format.MaxLength = 10, 90
+				if rule.DataBar.MinLength != nil {
+					format.MinLength = *rule.DataBar.MinLength
+				}
+				if rule.DataBar.MaxLength != nil {
+					format.MaxLength = *rule.DataBar.MaxLength
+				}
+				if format.MaxLength == 100 {
+					format.MaxLength = 0
+				}
 			}
 		}
 	}
@@ -3165,12 +4341,37 @@ func (f *File) extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) Conditio
 		}
 		format.IconStyle = c.IconSet.IconSet
 		format.ReverseIcons = c.IconSet.Reverse
+		format.IconThresholds = extractCondFmtIconThresholds(c.IconSet)
 	}
 	return format
 }
 
+// extractCondFmtIconThresholds provides a function to extract the thresholds
+// of the icons by given icon set, it returns nil if the thresholds are the
+// default thresholds of the icon style.
+func extractCondFmtIconThresholds(iconSet *xlsxIconSet) []ConditionalFormatIconThreshold {
+	var thresholds []ConditionalFormatIconThreshold
+	preset, ok := condFmtIconSetPresets[iconSet.IconSet]
+	isDefault := ok && len(iconSet.Cfvo) == len(preset.IconSet.Cfvo)
+	for i, c := range iconSet.Cfvo {
+		greaterThan := c.Gte != nil && !*c.Gte
+		if isDefault && (c.Type != preset.IconSet.Cfvo[i].Type || c.Val != preset.IconSet.Cfvo[i].Val || greaterThan) {
+			isDefault = false
+		}
+		if i > 0 {
+			thresholds = append(thresholds, ConditionalFormatIconThreshold{Type: c.Type, Value: c.Val, GreaterThan: greaterThan})
+		}
+	}
+	if isDefault {
+		return nil
+	}
+	return thresholds
+}
+
 // GetConditionalFormats returns conditional format settings by given worksheet
-// name.
+// name. The rules of the same range reference are returned in the document
+// order, and the Priority of each rule reflects its evaluation order in the
+// worksheet.
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
 	conditionalFormats := make(map[string][]ConditionalFormatOptions)
 	ws, err := f.workSheetReader(sheet)
@@ -3178,10 +4379,15 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 		return conditionalFormats, err
 	}
 	for _, cf := range ws.ConditionalFormatting {
-		var opts []ConditionalFormatOptions
+		opts := conditionalFormats[cf.SQRef]
 		for _, cr := range cf.CfRule {
 			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(f, cr, ws.ExtLst))
+				opt := extractFunc(f, cr, ws.ExtLst)
+				opt.Priority = cr.Priority
+				if opt.Format != nil {
+					opt.FormatStyle, _ = f.GetConditionalStyle(*opt.Format)
+				}
+				opts = append(opts, opt)
 			}
 		}
 		conditionalFormats[cf.SQRef] = opts
@@ -3205,23 +4411,191 @@ func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 	return nil
 }
 
-// drawCondFmtCellIs provides a function to create conditional formatting rule
-// for cell value (include between, not between, equal, not equal, greater
-// than and less than) by given priority, criteria type and format settings.
-func drawCondFmtCellIs(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
-	c := &xlsxCfRule{
-		Priority:   p + 1,
-		StopIfTrue: format.StopIfTrue,
-		Type:       validType[format.Type],
-		Operator:   ct,
-		DxfID:      format.Format,
+// DeleteConditionalFormatRule provides a function to delete a single
+// conditional formatting rule by given worksheet name, range reference and
+// the index of the rule in the rules returned by the GetConditionalFormats
+// function for the range. The priorities of the remaining rules in the
+// worksheet will be renumbered. For example, delete the second rule of the
+// range A1:A10 on Sheet1:
+//
+//	err := f.DeleteConditionalFormatRule("Sheet1", "A1:A10", 1)
+func (f *File) DeleteConditionalFormatRule(sheet, rangeRef string, index int) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
 	}
-	// "between" and "not between" criteria require 2 values.
-	if ct == "between" || ct == "notBetween" {
-		c.Formula = append(c.Formula, []string{format.MinValue, format.MaxValue}...)
+	cf, idx, err := ws.getCfRule(rangeRef, index)
+	if err != nil {
+		return err
 	}
-	if inStrSlice(cellIsCriteriaType, ct, true) != -1 {
-		c.Formula = append(c.Formula, format.Value)
+	ID := getCfRuleExtID(cf.CfRule[idx])
+	if cf.CfRule = append(cf.CfRule[:idx], cf.CfRule[idx+1:]...); len(cf.CfRule) == 0 {
+		for i, c := range ws.ConditionalFormatting {
+			if c == cf {
+				ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
+				break
+			}
+		}
+	}
+	ws.setCfRulePriority(nil, 0)
+	if ID != "" {
+		return f.deleteX14CfRule(ws, ID)
+	}
+	return err
+}
+
+// SetConditionalFormatRulePriority provides a function to set the priority of
+// a single conditional formatting rule by given worksheet name, range
+// reference, the index of the rule in the rules returned by the
+// GetConditionalFormats function for the range and the new priority. The
+// priorities are shared by all conditional formatting rules in the worksheet,
+// the priorities of the other rules will be renumbered. For example, make the
+// second rule of the range A1:A10 on Sheet1 evaluated first:
+//
+//	err := f.SetConditionalFormatRulePriority("Sheet1", "A1:A10", 1, 1)
+func (f *File) SetConditionalFormatRulePriority(sheet, rangeRef string, index, priority int) error {
+	if priority < 1 {
+		return ErrParameterInvalid
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	cf, idx, err := ws.getCfRule(rangeRef, index)
+	if err != nil {
+		return err
+	}
+	ws.setCfRulePriority(cf.CfRule[idx], priority)
+	return err
+}
+
+// getCfRule returns the conditional formatting which contains the rule and
+// the index of the rule in it by given range reference and the index of the
+// rule in all the conditional formatting rules of the range.
+func (ws *xlsxWorksheet) getCfRule(rangeRef string, index int) (*xlsxConditionalFormatting, int, error) {
+	SQRef, _, err := prepareConditionalFormatRange(rangeRef)
+	if err != nil {
+		return nil, -1, err
+	}
+	if idx := index; idx >= 0 {
+		for _, cf := range ws.ConditionalFormatting {
+			if cf == nil || cf.SQRef != SQRef {
+				continue
+			}
+			if idx < len(cf.CfRule) {
+				return cf, idx, err
+			}
+			idx -= len(cf.CfRule)
+		}
+	}
+	return nil, -1, newNoExistCfRuleError(rangeRef, index)
+}
+
+// deleteX14CfRule provides a function to delete the conditional formatting
+// rule in the worksheet extension list by given rule ID, and the conditional
+// formatting will be deleted if there are no other rules in it.
+func (f *File) deleteX14CfRule(ws *xlsxWorksheet, ID string) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	var exts []*xlsxExt
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURIConditionalFormattings {
+			if ext.Content = deleteX14CfRuleContent(ext.Content, ID); !strings.Contains(ext.Content, "cfRule") {
+				continue
+			}
+		}
+		exts = append(exts, ext)
+	}
+	if decodeExtLst.Ext = exts; len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// deleteX14CfRuleContent returns the content of the conditional formattings
+// extension without the rule by given rule ID.
+func deleteX14CfRuleContent(content, ID string) string {
+	type span struct {
+		start, end int64
+		matched    bool
+	}
+	var (
+		spans, rules []span
+		condFmt      span
+		decoder      = xml.NewDecoder(strings.NewReader(content))
+	)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "conditionalFormatting":
+				condFmt, rules = span{start: offset}, nil
+			case "cfRule":
+				rule := span{start: offset}
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "id" && attr.Value == ID {
+						rule.matched = true
+					}
+				}
+				rules = append(rules, rule)
+			}
+		case xml.EndElement:
+			switch element.Name.Local {
+			case "conditionalFormatting":
+				var matched []span
+				for _, rule := range rules {
+					if rule.matched {
+						matched = append(matched, rule)
+					}
+				}
+				if condFmt.end = decoder.InputOffset(); len(matched) > 0 && len(matched) == len(rules) {
+					matched = []span{condFmt}
+				}
+				spans = append(spans, matched...)
+			case "cfRule":
+				if len(rules) > 0 {
+					rules[len(rules)-1].end = decoder.InputOffset()
+				}
+			}
+		}
+	}
+	for i := len(spans) - 1; i >= 0; i-- {
+		content = content[:spans[i].start] + content[spans[i].end:]
+	}
+	return content
+}
+
+// drawCondFmtCellIs provides a function to create conditional formatting rule
+// for cell value (include between, not between, equal, not equal, greater
+// than and less than) by given priority, criteria type and format settings.
+func drawCondFmtCellIs(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	c := &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       validType[format.Type],
+		Operator:   ct,
+		DxfID:      format.Format,
+	}
+	// "between" and "not between" criteria require 2 values.
+	if ct == "between" || ct == "notBetween" {
+		c.Formula = append(c.Formula, []string{format.MinValue, format.MaxValue}...)
+	}
+	if inStrSlice(cellIsCriteriaType, ct, true) != -1 {
+		c.Formula = append(c.Formula, format.Value)
 	}
 	return c, nil
 }
@@ -3370,17 +4744,29 @@ func drawCondFmtColorScale(p int, ct, ref, GUID string, format *ConditionalForma
 func drawCondFmtDataBar(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
 	var x14CfRule *xlsxX14CfRule
 	var extLst *xlsxExtLst
-	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" {
+	maxLength := format.MaxLength
+	if maxLength == 0 {
+		maxLength = 100
+	}
+	if inStrSlice([]string{"", "automatic", "middle", "none"}, format.AxisPosition, true) == -1 ||
+		format.MinLength < 0 || maxLength > 100 || format.MinLength > maxLength {
+		return nil, nil
+	}
+	if format.BarSolid || format.BarDirection == "leftToRight" || format.BarDirection == "rightToLeft" || format.BarBorderColor != "" ||
+		format.NegativeBarColor != "" || format.NegativeBarBorderColor != "" || format.AxisColor != "" || format.AxisPosition != "" ||
+		format.MinLength != 0 || format.MaxLength != 0 {
 		extLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:id>%s</x14:id></ext>`, ExtURIConditionalFormattingRuleID, NameSpaceSpreadSheetX14.Value, GUID)}
 		x14CfRule = &xlsxX14CfRule{
 			Type: validType[format.Type],
 			ID:   GUID,
 			DataBar: &xlsx14DataBar{
-				MaxLength:         100,
+				MaxLength:         maxLength,
+				MinLength:         format.MinLength,
 				Border:            format.BarBorderColor != "",
 				Gradient:          !format.BarSolid,
 				Direction:         format.BarDirection,
-				Cfvo:              []*xlsxCfvo{{Type: "autoMin"}, {Type: "autoMax"}},
+				AxisPosition:      format.AxisPosition,
+				Cfvo:              []*xlsxX14Cfvo{getX14DataBarCfvo(format.MinType, format.MinValue), getX14DataBarCfvo(format.MaxType, format.MaxValue)},
 				NegativeFillColor: &xlsxColor{RGB: "FFFF0000"},
 				AxisColor:         &xlsxColor{RGB: "FFFF0000"},
 			},
@@ -3388,6 +4774,16 @@ func drawCondFmtDataBar(p int, ct, ref, GUID string, format *ConditionalFormatOp
 		if x14CfRule.DataBar.Border {
 			x14CfRule.DataBar.BorderColor = &xlsxColor{RGB: getPaletteColor(format.BarBorderColor)}
 		}
+		if format.NegativeBarColor != "" {
+			x14CfRule.DataBar.NegativeFillColor.RGB = getPaletteColor(format.NegativeBarColor)
+		}
+		if format.NegativeBarBorderColor != "" {
+			x14CfRule.DataBar.NegativeBarBorderColorSameAsPositive = boolPtr(false)
+			x14CfRule.DataBar.NegativeBorderColor = &xlsxColor{RGB: getPaletteColor(format.NegativeBarBorderColor)}
+		}
+		if format.AxisColor != "" {
+			x14CfRule.DataBar.AxisColor.RGB = getPaletteColor(format.AxisColor)
+		}
 	}
 	return &xlsxCfRule{
 		Priority:   p + 1,
@@ -3402,6 +4798,19 @@ func drawCondFmtDataBar(p int, ct, ref, GUID string, format *ConditionalFormatOp
 	}, x14CfRule
 }
 
+// getX14DataBarCfvo provides a function to create the conditional format value
+// object of the data bar extension by given value type and value, the lowest
+// and highest value types will be converted to the automatic types.
+func getX14DataBarCfvo(typ, val string) *xlsxX14Cfvo {
+	switch typ {
+	case "min":
+		return &xlsxX14Cfvo{Type: "autoMin"}
+	case "max":
+		return &xlsxX14Cfvo{Type: "autoMax"}
+	}
+	return &xlsxX14Cfvo{Type: typ, F: val}
+}
+
 // drawCondFmtExp provides a function to create conditional formatting rule
 // for expression by given priority, criteria type and format settings.
 func drawCondFmtExp(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
@@ -3465,16 +4874,37 @@ func drawCondFmtNoBlanks(p int, ct, ref, GUID string, format *ConditionalFormatO
 // drawCondFmtIconSet provides a function to create conditional formatting rule
 // for icon set by given priority, criteria type and format settings.
 func drawCondFmtIconSet(p int, ct, ref, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
-	cfRule, ok := condFmtIconSetPresets[format.IconStyle]
+	preset, ok := condFmtIconSetPresets[format.IconStyle]
 	if !ok {
 		return nil, nil
 	}
-	cfRule.Priority = p + 1
-	cfRule.IconSet.IconSet = format.IconStyle
-	cfRule.IconSet.Reverse = format.ReverseIcons
-	cfRule.IconSet.ShowValue = boolPtr(!format.IconsOnly)
-	cfRule.Type = validType[format.Type]
-	return cfRule, nil
+	cfvo := preset.IconSet.Cfvo
+	if format.IconThresholds != nil {
+		if len(format.IconThresholds) != len(preset.IconSet.Cfvo)-1 {
+			return nil, nil
+		}
+		cfvo = []*xlsxCfvo{preset.IconSet.Cfvo[0]}
+		for _, threshold := range format.IconThresholds {
+			if inStrSlice([]string{"num", "percent", "percentile", "formula"}, threshold.Type, true) == -1 || threshold.Value == "" {
+				return nil, nil
+			}
+			c := &xlsxCfvo{Type: threshold.Type, Val: threshold.Value}
+			if threshold.GreaterThan {
+				c.Gte = boolPtr(false)
+			}
+			cfvo = append(cfvo, c)
+		}
+	}
+	return &xlsxCfRule{
+		Type:     validType[format.Type],
+		Priority: p + 1,
+		IconSet: &xlsxIconSet{
+			Cfvo:      cfvo,
+			IconSet:   format.IconStyle,
+			ShowValue: boolPtr(!format.IconsOnly),
+			Reverse:   format.ReverseIcons,
+		},
+	}, nil
 }
 
 // getPaletteColor provides a function to convert the RBG color by given
@@ -3517,3 +4947,626 @@ func ThemeColor(baseColor string, tint float64) string {
 	br, bg, bb := HSLToRGB(h, s, l)
 	return fmt.Sprintf("FF%02X%02X%02X", br, bg, bb)
 }
+
+// themeColorSlots returns the color slots of the workbook theme color scheme
+// in the order of the theme color index used in the cell styles.
+func (clrScheme *decodeColorScheme) themeColorSlots() []*decodeCTColor {
+	return []*decodeCTColor{
+		&clrScheme.Lt1, &clrScheme.Dk1, &clrScheme.Lt2, &clrScheme.Dk2,
+		&clrScheme.Accent1, &clrScheme.Accent2, &clrScheme.Accent3,
+		&clrScheme.Accent4, &clrScheme.Accent5, &clrScheme.Accent6,
+		&clrScheme.Hlink, &clrScheme.FolHlink,
+	}
+}
+
+// GetThemeColors provides a function to get the twelve color slots of the
+// color scheme in the workbook theme.
+func (f *File) GetThemeColors() (ThemeColors, error) {
+	var colors ThemeColors
+	if f.Theme == nil {
+		return colors, ErrThemeNotExist
+	}
+	clrScheme := f.Theme.ThemeElements.ClrScheme
+	for i, val := range []*string{
+		&colors.Light1, &colors.Dark1, &colors.Light2, &colors.Dark2,
+		&colors.Accent1, &colors.Accent2, &colors.Accent3, &colors.Accent4,
+		&colors.Accent5, &colors.Accent6, &colors.Hyperlink, &colors.FollowedHyperlink,
+	} {
+		if clr := clrScheme.themeColorSlots()[i].colorChoice(); clr != nil {
+			*val = strings.ToUpper(*clr)
+		}
+	}
+	return colors, nil
+}
+
+// SetThemeColors provides a function to set the color slots of the color
+// scheme in the workbook theme, the fonts and format scheme of the theme will
+// be preserved. The empty color slot will keep the existing color. For
+// example, change the accent 1 and accent 2 colors of the theme:
+//
+//	err := f.SetThemeColors(excelize.ThemeColors{
+//	    Accent1: "1F4E79",
+//	    Accent2: "C55A11",
+//	})
+func (f *File) SetThemeColors(colors ThemeColors) error {
+	if f.Theme == nil {
+		return ErrThemeNotExist
+	}
+	values := []string{
+		colors.Light1, colors.Dark1, colors.Light2, colors.Dark2,
+		colors.Accent1, colors.Accent2, colors.Accent3, colors.Accent4,
+		colors.Accent5, colors.Accent6, colors.Hyperlink, colors.FollowedHyperlink,
+	}
+	for i, val := range values {
+		if val = strings.TrimPrefix(val, "#"); val == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(val, 16, 32); err != nil || len(val) != 6 {
+			return ErrParameterInvalid
+		}
+		values[i] = strings.ToUpper(val)
+	}
+	for i, slot := range f.Theme.ThemeElements.ClrScheme.themeColorSlots() {
+		if values[i] == "" {
+			continue
+		}
+		if slot.SysClr != nil {
+			slot.SysClr.LastClr = values[i]
+			continue
+		}
+		*slot = decodeCTColor{SrgbClr: &attrValString{Val: stringPtr(values[i])}}
+	}
+	return nil
+}
+
+// ThemeColorToRGB provides a function to convert the theme color by given
+// theme color index and tint value to the 'RRGGBB' hexadecimal notation
+// color, the theme color index and tint can be get from the font, fill and
+// border style definitions returned by the GetStyle function.
+func (f *File) ThemeColorToRGB(themeIdx int, tint float64) (string, error) {
+	if f.Theme == nil {
+		return "", ErrThemeNotExist
+	}
+	slots := f.Theme.ThemeElements.ClrScheme.themeColorSlots()
+	if themeIdx < 0 || themeIdx >= len(slots) {
+		return "", ErrParameterInvalid
+	}
+	clr := slots[themeIdx].colorChoice()
+	if clr == nil || len(*clr) != 6 {
+		return "", ErrParameterInvalid
+	}
+	return strings.TrimPrefix(ThemeColor(strings.ToUpper(*clr), tint), "FF"), nil
+}
+
+// CompactStyles provides a function to deduplicate the identical cell
+// formats, fonts, fills, borders and custom number formats in the workbook,
+// rewrites the style references of the cells, rows and columns in all
+// worksheets to the canonical style index, and drops the records no longer
+// referenced anywhere, returns the number of removed style records. The
+// differential formats used by the conditional formats and the cell styles
+// records used by the named styles will not be changed. Note that the style
+// index returned by the NewStyle function before compacting may be changed,
+// and this function doesn't support the workbook with streamed worksheets.
+// For example, compact styles of the workbook:
+//
+//	removed, err := f.CompactStyles()
+func (f *File) CompactStyles() (int, error) {
+	if len(f.streams) > 0 {
+		return 0, ErrCompactStylesStream
+	}
+	var sheets []*xlsxWorksheet
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return 0, err
+		}
+		sheets = append(sheets, ws)
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellXfs == nil || len(s.CellXfs.Xf) == 0 {
+		return 0, nil
+	}
+	if s.NumFmts == nil {
+		s.NumFmts = &xlsxNumFmts{}
+	}
+	if s.Fonts == nil {
+		s.Fonts = &xlsxFonts{}
+	}
+	if s.Fills == nil {
+		s.Fills = &xlsxFills{}
+	}
+	if s.Borders == nil {
+		s.Borders = &xlsxBorders{}
+	}
+	if s.CellStyleXfs == nil {
+		s.CellStyleXfs = &xlsxCellStyleXfs{}
+	}
+	f.copiedStyles, f.quotePrefixes = nil, nil
+	numFmtIDs, fontIdx, fillIdx, borderIdx := s.dedupeXfComponents()
+	s.remapXfComponents(numFmtIDs, fontIdx, fillIdx, borderIdx)
+	xfIdx := dedupeStyleRecords(len(s.CellXfs.Xf), func(i int) []byte {
+		output, _ := xml.Marshal(s.CellXfs.Xf[i])
+		return output
+	})
+	usedXfs := map[int]bool{0: true}
+	forEachStyleRef(sheets, func(styleID *int) {
+		if *styleID > 0 && *styleID < len(xfIdx) {
+			usedXfs[xfIdx[*styleID]] = true
+		}
+	})
+	newXfIdx := compactStyleRecords(xfIdx, usedXfs)
+	removed := len(s.CellXfs.Xf)
+	var xfs []xlsxXf
+	for i, xf := range s.CellXfs.Xf {
+		if xfIdx[i] == i && usedXfs[i] {
+			xfs = append(xfs, xf)
+		}
+	}
+	s.CellXfs.Xf, s.CellXfs.Count = xfs, len(xfs)
+	removed -= len(xfs)
+	forEachStyleRef(sheets, func(styleID *int) {
+		if *styleID < 0 || *styleID >= len(newXfIdx) {
+			*styleID = 0
+			return
+		}
+		*styleID = newXfIdx[*styleID]
+	})
+	return removed + s.dropUnusedXfComponents(fontIdx, fillIdx, borderIdx), err
+}
+
+// dedupeStyleRecords provides a function to find the canonical record index
+// for each style record by given number of records and the function to
+// serialize a record, the first one of the identical records is canonical.
+func dedupeStyleRecords(n int, key func(i int) []byte) []int {
+	canonical, seen := make([]int, n), make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		k := string(key(i))
+		if idx, ok := seen[k]; ok {
+			canonical[i] = idx
+			continue
+		}
+		seen[k], canonical[i] = i, i
+	}
+	return canonical
+}
+
+// compactStyleRecords provides a function to get the new record index for
+// each style record after dropping the non-canonical and unused records by
+// given canonical record indexes and the used canonical records.
+func compactStyleRecords(canonical []int, used map[int]bool) []int {
+	newIdx, cnt := make([]int, len(canonical)), 0
+	for i := range canonical {
+		if newIdx[i] = -1; canonical[i] == i && used[i] {
+			newIdx[i], cnt = cnt, cnt+1
+		}
+	}
+	for i := range canonical {
+		newIdx[i] = newIdx[canonical[i]]
+	}
+	return newIdx
+}
+
+// remapStyleID provides a function to get a new style component index
+// reference by given original reference and new record indexes.
+func remapStyleID(id *int, idx []int) *int {
+	if id == nil || *id < 0 || *id >= len(idx) || idx[*id] == -1 {
+		return id
+	}
+	return intPtr(idx[*id])
+}
+
+// forEachStyleRef provides a function to walk through the cell format
+// references of the cells, rows and columns in the given worksheets.
+func forEachStyleRef(sheets []*xlsxWorksheet, fn func(styleID *int)) {
+	for _, ws := range sheets {
+		if ws.Cols != nil {
+			for i := range ws.Cols.Col {
+				fn(&ws.Cols.Col[i].Style)
+			}
+		}
+		for r := range ws.SheetData.Row {
+			fn(&ws.SheetData.Row[r].S)
+			for c := range ws.SheetData.Row[r].C {
+				fn(&ws.SheetData.Row[r].C[c].S)
+			}
+		}
+	}
+}
+
+// dedupeXfComponents provides a function to find the canonical custom number
+// format ID, font, fill and border index for the style components.
+func (s *xlsxStyleSheet) dedupeXfComponents() (map[int]int, []int, []int, []int) {
+	numFmtIDs, codes := make(map[int]int), make(map[string]int)
+	for _, numFmt := range s.NumFmts.NumFmt {
+		code := numFmt.FormatCode + "\x00" + numFmt.FormatCode16
+		if ID, ok := codes[code]; ok {
+			numFmtIDs[numFmt.NumFmtID] = ID
+			continue
+		}
+		codes[code], numFmtIDs[numFmt.NumFmtID] = numFmt.NumFmtID, numFmt.NumFmtID
+	}
+	fontIdx := dedupeStyleRecords(len(s.Fonts.Font), func(i int) []byte {
+		output, _ := xml.Marshal(s.Fonts.Font[i])
+		return output
+	})
+	fillIdx := dedupeStyleRecords(len(s.Fills.Fill), func(i int) []byte {
+		output, _ := xml.Marshal(s.Fills.Fill[i])
+		return output
+	})
+	borderIdx := dedupeStyleRecords(len(s.Borders.Border), func(i int) []byte {
+		output, _ := xml.Marshal(s.Borders.Border[i])
+		return output
+	})
+	return numFmtIDs, fontIdx, fillIdx, borderIdx
+}
+
+// remapXfComponents provides a function to update the number format ID, font,
+// fill and border index references of the cell formats and cell style
+// formats by given number format IDs mapping and new record indexes.
+func (s *xlsxStyleSheet) remapXfComponents(numFmtIDs map[int]int, fontIdx, fillIdx, borderIdx []int) {
+	for _, xfs := range [][]xlsxXf{s.CellXfs.Xf, s.CellStyleXfs.Xf} {
+		for i := range xfs {
+			if xfs[i].NumFmtID != nil {
+				if ID, ok := numFmtIDs[*xfs[i].NumFmtID]; ok {
+					xfs[i].NumFmtID = intPtr(ID)
+				}
+			}
+			xfs[i].FontID = remapStyleID(xfs[i].FontID, fontIdx)
+			xfs[i].FillID = remapStyleID(xfs[i].FillID, fillIdx)
+			xfs[i].BorderID = remapStyleID(xfs[i].BorderID, borderIdx)
+		}
+	}
+}
+
+// dropUnusedXfComponents provides a function to remove the duplicate and
+// unused custom number formats, fonts, fills and borders after the cell
+// formats have been compacted, returns the number of removed records.
+func (s *xlsxStyleSheet) dropUnusedXfComponents(fontIdx, fillIdx, borderIdx []int) int {
+	usedNumFmts, usedFonts := make(map[int]bool), map[int]bool{0: true}
+	usedFills, usedBorders := map[int]bool{0: true, 1: true}, map[int]bool{0: true}
+	for _, xfs := range [][]xlsxXf{s.CellXfs.Xf, s.CellStyleXfs.Xf} {
+		for _, xf := range xfs {
+			for _, ref := range []struct {
+				ID   *int
+				used map[int]bool
+			}{
+				{xf.NumFmtID, usedNumFmts}, {xf.FontID, usedFonts},
+				{xf.FillID, usedFills}, {xf.BorderID, usedBorders},
+			} {
+				if ref.ID != nil {
+					ref.used[*ref.ID] = true
+				}
+			}
+		}
+	}
+	var (
+		removed   int
+		numFmts   []*xlsxNumFmt
+		fonts     []*xlsxFont
+		fills     []*xlsxFill
+		borders   []*xlsxBorder
+		newFont   = compactStyleRecords(fontIdx, usedFonts)
+		newFill   = compactStyleRecords(fillIdx, usedFills)
+		newBorder = compactStyleRecords(borderIdx, usedBorders)
+	)
+	for _, numFmt := range s.NumFmts.NumFmt {
+		if usedNumFmts[numFmt.NumFmtID] {
+			numFmts = append(numFmts, numFmt)
+		}
+	}
+	for i, font := range s.Fonts.Font {
+		if fontIdx[i] == i && usedFonts[i] {
+			fonts = append(fonts, font)
+		}
+	}
+	for i, fill := range s.Fills.Fill {
+		if fillIdx[i] == i && usedFills[i] {
+			fills = append(fills, fill)
+		}
+	}
+	for i, border := range s.Borders.Border {
+		if borderIdx[i] == i && usedBorders[i] {
+			borders = append(borders, border)
+		}
+	}
+	removed = len(s.NumFmts.NumFmt) - len(numFmts) + len(s.Fonts.Font) - len(fonts) +
+		len(s.Fills.Fill) - len(fills) + len(s.Borders.Border) - len(borders)
+	s.NumFmts.NumFmt, s.NumFmts.Count = numFmts, len(numFmts)
+	s.Fonts.Font, s.Fonts.Count = fonts, len(fonts)
+	s.Fills.Fill, s.Fills.Count = fills, len(fills)
+	s.Borders.Border, s.Borders.Count = borders, len(borders)
+	s.remapXfComponents(nil, newFont, newFill, newBorder)
+	if s.NumFmts.Count == 0 {
+		s.NumFmts = nil
+	}
+	if s.Fonts.Count == 0 {
+		s.Fonts = nil
+	}
+	if s.Fills.Count == 0 {
+		s.Fills = nil
+	}
+	if s.Borders.Count == 0 {
+		s.Borders = nil
+	}
+	if s.CellStyleXfs.Count == 0 && len(s.CellStyleXfs.Xf) == 0 {
+		s.CellStyleXfs = nil
+	}
+	return removed
+}
+
+// CopyStyleFrom provides a function to copy the cell style by given source
+// workbook and the style index in the source workbook, and returns the style
+// index in the workbook. The font, fill, border and custom number format
+// records will be deep-copied, and the identical records which already exist
+// in the workbook will be reused, the custom number formats with the same
+// format code will be mapped to one number format. The copied styles will be
+// cached, so that copy the same style repeatedly is cheap. For example, copy
+// the style of the cell A1 in the source workbook to the cell A1 in the
+// workbook:
+//
+//	styleID, err := src.GetCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if styleID, err = f.CopyStyleFrom(src, styleID); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellStyle("Sheet1", "A1", "A1", styleID)
+func (f *File) CopyStyleFrom(src *File, srcStyleID int) (int, error) {
+	if src == nil {
+		return 0, ErrParameterInvalid
+	}
+	ss, err := src.stylesReader()
+	if err != nil {
+		return 0, err
+	}
+	ds, err := f.stylesReader()
+	if err != nil {
+		return 0, err
+	}
+	// Take the snapshot of the source style and release the lock of the source
+	// style sheet before locking the style sheet, to avoid deadlock on copying
+	// styles between two workbooks in both directions concurrently.
+	xf, snapshot, err := ss.getXfSnapshot(srcStyleID)
+	if err != nil {
+		return 0, err
+	}
+	if src == f {
+		return srcStyleID, err
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if styleID, ok := f.copiedStyles[src.id][srcStyleID]; ok && src.id != 0 {
+		return styleID, err
+	}
+	ds.copyXfComponents(snapshot, &xf)
+	name := snapshot.getNamedStyleName(xf.XfID)
+	xf.XfID = intPtr(0)
+	if name != "" {
+		if xfID, ok := ds.getNamedStyleXfID(name); ok {
+			xf.XfID = intPtr(xfID)
+		}
+	}
+	if ds.CellXfs == nil {
+		ds.CellXfs = &xlsxCellXfs{}
+	}
+	styleID := findStyleRecord(len(ds.CellXfs.Xf), xf, func(i int) interface{} { return ds.CellXfs.Xf[i] })
+	if styleID == -1 {
+		if len(ds.CellXfs.Xf) == MaxCellStyles {
+			return 0, ErrCellStyles
+		}
+		ds.CellXfs.Xf = append(ds.CellXfs.Xf, xf)
+		ds.CellXfs.Count = len(ds.CellXfs.Xf)
+		styleID = ds.CellXfs.Count - 1
+	}
+	// The copied styles are cached by the identifier of the source workbook,
+	// so that the cache doesn't keep the source workbook alive.
+	if src.id == 0 {
+		return styleID, err
+	}
+	if f.copiedStyles == nil {
+		f.copiedStyles = make(map[uint64]map[int]int)
+	}
+	if f.copiedStyles[src.id] == nil {
+		f.copiedStyles[src.id] = make(map[int]int)
+	}
+	f.copiedStyles[src.id][srcStyleID] = styleID
+	return styleID, err
+}
+
+// getXfSnapshot provides a function to get the deep copy of the cell format
+// by given style index, and a style sheet which only contains the deep copies
+// of the number format, font, fill, border and cell style records referenced
+// by the cell format at the same indexes, so that the records can be copied
+// without holding the lock of the style sheet.
+func (s *xlsxStyleSheet) getXfSnapshot(styleID int) (xlsxXf, *xlsxStyleSheet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var xf xlsxXf
+	snapshot := &xlsxStyleSheet{}
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return xf, snapshot, newInvalidStyleID(styleID)
+	}
+	if err := deepcopy.Copy(&xf, s.CellXfs.Xf[styleID]); err != nil {
+		return xf, snapshot, err
+	}
+	if xf.NumFmtID != nil && s.NumFmts != nil {
+		for _, numFmt := range s.NumFmts.NumFmt {
+			if numFmt != nil && numFmt.NumFmtID == *xf.NumFmtID {
+				numFmt := *numFmt
+				snapshot.NumFmts = &xlsxNumFmts{NumFmt: []*xlsxNumFmt{&numFmt}}
+				break
+			}
+		}
+	}
+	if ID := xf.FontID; ID != nil && s.Fonts != nil && *ID >= 0 && *ID < len(s.Fonts.Font) {
+		snapshot.Fonts = &xlsxFonts{Font: make([]*xlsxFont, *ID+1)}
+		if s.Fonts.Font[*ID] != nil {
+			snapshot.Fonts.Font[*ID] = new(xlsxFont)
+			_ = deepcopy.Copy(snapshot.Fonts.Font[*ID], s.Fonts.Font[*ID])
+		}
+	}
+	if ID := xf.FillID; ID != nil && s.Fills != nil && *ID >= 0 && *ID < len(s.Fills.Fill) {
+		snapshot.Fills = &xlsxFills{Fill: make([]*xlsxFill, *ID+1)}
+		if s.Fills.Fill[*ID] != nil {
+			snapshot.Fills.Fill[*ID] = new(xlsxFill)
+			_ = deepcopy.Copy(snapshot.Fills.Fill[*ID], s.Fills.Fill[*ID])
+		}
+	}
+	if ID := xf.BorderID; ID != nil && s.Borders != nil && *ID >= 0 && *ID < len(s.Borders.Border) {
+		snapshot.Borders = &xlsxBorders{Border: make([]*xlsxBorder, *ID+1)}
+		if s.Borders.Border[*ID] != nil {
+			snapshot.Borders.Border[*ID] = new(xlsxBorder)
+			_ = deepcopy.Copy(snapshot.Borders.Border[*ID], s.Borders.Border[*ID])
+		}
+	}
+	if name := s.getNamedStyleName(xf.XfID); name != "" {
+		snapshot.CellStyles = &xlsxCellStyles{CellStyle: []*xlsxCellStyle{{Name: name, XfID: *xf.XfID}}}
+	}
+	return xf, snapshot, nil
+}
+
+// getQuotePrefixStyle provides a function to get the style index derived from
+// the given style index with the quote prefix, which specifies the text of
+// the cell is preceded by an apostrophe. The derived styles will be cached.
+func (f *File) getQuotePrefixStyle(styleID int) (int, error) {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return styleID, err
+	}
+	f.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ID, ok := f.quotePrefixes[styleID]; ok {
+		return ID, err
+	}
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return styleID, newInvalidStyleID(styleID)
+	}
+	var xf xlsxXf
+	if err = deepcopy.Copy(&xf, s.CellXfs.Xf[styleID]); err != nil {
+		return styleID, err
+	}
+	if xf.QuotePrefix != nil && *xf.QuotePrefix {
+		return styleID, err
+	}
+	xf.QuotePrefix = boolPtr(true)
+	ID := findStyleRecord(len(s.CellXfs.Xf), xf, func(i int) interface{} { return s.CellXfs.Xf[i] })
+	if ID == -1 {
+		if len(s.CellXfs.Xf) == MaxCellStyles {
+			return styleID, ErrCellStyles
+		}
+		s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+		s.CellXfs.Count = len(s.CellXfs.Xf)
+		ID = s.CellXfs.Count - 1
+	}
+	if f.quotePrefixes == nil {
+		f.quotePrefixes = make(map[int]int)
+	}
+	f.quotePrefixes[styleID] = ID
+	return ID, err
+}
+
+// findStyleRecord provides a function to get the index of the identical
+// record by given number of records, the record to find and the function to
+// get record by index, it will return -1 if the record doesn't exist.
+func findStyleRecord(n int, record interface{}, get func(i int) interface{}) int {
+	target, _ := xml.Marshal(record)
+	for i := 0; i < n; i++ {
+		if output, _ := xml.Marshal(get(i)); bytes.Equal(output, target) {
+			return i
+		}
+	}
+	return -1
+}
+
+// getNamedStyleName provides a function to get the named cell style name by
+// given cell style format index, returns empty string if not found.
+func (s *xlsxStyleSheet) getNamedStyleName(xfID *int) string {
+	if xfID == nil || *xfID == 0 || s.CellStyles == nil {
+		return ""
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if cellStyle != nil && cellStyle.XfID == *xfID {
+			return cellStyle.Name
+		}
+	}
+	return ""
+}
+
+// copyXfComponents provides a function to copy the number format, font, fill
+// and border records referenced by the given cell format from the source
+// style sheet, and update the references of the cell format to the
+// identical or new created records in the style sheet.
+func (s *xlsxStyleSheet) copyXfComponents(src *xlsxStyleSheet, xf *xlsxXf) {
+	if xf.NumFmtID != nil && src.NumFmts != nil {
+		for _, numFmt := range src.NumFmts.NumFmt {
+			if numFmt != nil && numFmt.NumFmtID == *xf.NumFmtID {
+				style := &Style{CustomNumFmt: stringPtr(numFmt.FormatCode)}
+				if numFmtID := getCustomNumFmtID(s, style); numFmtID != -1 {
+					xf.NumFmtID = intPtr(numFmtID)
+					break
+				}
+				xf.NumFmtID = intPtr(setCustomNumFmt(s, style))
+				break
+			}
+		}
+	}
+	if xf.FontID != nil && src.Fonts != nil && *xf.FontID >= 0 && *xf.FontID < len(src.Fonts.Font) {
+		if s.Fonts == nil {
+			s.Fonts = &xlsxFonts{}
+		}
+		font := src.Fonts.Font[*xf.FontID]
+		if fontID := findStyleRecord(len(s.Fonts.Font), font, func(i int) interface{} { return s.Fonts.Font[i] }); fontID != -1 {
+			xf.FontID = intPtr(fontID)
+		} else {
+			var newFont xlsxFont
+			_ = deepcopy.Copy(&newFont, font)
+			s.Fonts.Font = append(s.Fonts.Font, &newFont)
+			s.Fonts.Count = len(s.Fonts.Font)
+			xf.FontID = intPtr(s.Fonts.Count - 1)
+		}
+	}
+	if xf.FillID != nil && src.Fills != nil && *xf.FillID >= 0 && *xf.FillID < len(src.Fills.Fill) {
+		if s.Fills == nil {
+			s.Fills = &xlsxFills{}
+		}
+		fill := src.Fills.Fill[*xf.FillID]
+		if fillID := findStyleRecord(len(s.Fills.Fill), fill, func(i int) interface{} { return s.Fills.Fill[i] }); fillID != -1 {
+			xf.FillID = intPtr(fillID)
+		} else {
+			var newFill xlsxFill
+			_ = deepcopy.Copy(&newFill, fill)
+			s.Fills.Fill = append(s.Fills.Fill, &newFill)
+			s.Fills.Count = len(s.Fills.Fill)
+			xf.FillID = intPtr(s.Fills.Count - 1)
+		}
+	}
+	if xf.BorderID != nil && src.Borders != nil && *xf.BorderID >= 0 && *xf.BorderID < len(src.Borders.Border) {
+		if s.Borders == nil {
+			s.Borders = &xlsxBorders{}
+		}
+		border := src.Borders.Border[*xf.BorderID]
+		if borderID := findStyleRecord(len(s.Borders.Border), border, func(i int) interface{} { return s.Borders.Border[i] }); borderID != -1 {
+			xf.BorderID = intPtr(borderID)
+		} else {
+			var newBorder xlsxBorder
+			_ = deepcopy.Copy(&newBorder, border)
+			s.Borders.Border = append(s.Borders.Border, &newBorder)
+			s.Borders.Count = len(s.Borders.Border)
+			xf.BorderID = intPtr(s.Borders.Count - 1)
+		}
+	}
+}
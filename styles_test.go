@@ -5,6 +5,7 @@ import (
 	"math"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,6 +49,82 @@ func TestStyleFill(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleFill.xlsx")))
 }
 
+func TestStyleGradientFill(t *testing.T) {
+	f := NewFile()
+	linear := Fill{Type: "gradient", Gradient: &GradientFill{
+		Type:   "linear",
+		Degree: 30,
+		Stops: []GradientStop{
+			{Position: 0, Color: "FFFFFF"},
+			{Position: 0.4, Color: "5B9BD5"},
+			{Position: 1, Color: "1F4E79"},
+		},
+	}}
+	path := Fill{Type: "gradient", Gradient: &GradientFill{
+		Type: "path", Left: 0.2, Right: 0.8, Top: 0.3, Bottom: 0.7,
+		Stops: []GradientStop{
+			{Position: 0, Color: "FFFF00"},
+			{Position: 1, Color: "FF0000"},
+		},
+	}}
+	for _, fill := range []Fill{linear, path} {
+		styleID, err := f.NewStyle(&Style{Fill: fill})
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, fill.Gradient, style.Fill.Gradient)
+		assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	}
+	// Test get style with preset shading gradient fill
+	styleID, err := f.NewStyle(&Style{Fill: Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 2}})
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Nil(t, style.Fill.Gradient)
+	assert.Equal(t, 2, style.Fill.Shading)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleGradientFill.xlsx")))
+	// Test create gradient fill with invalid settings
+	for _, gradient := range []*GradientFill{
+		{Type: "radial", Stops: linear.Gradient.Stops},
+		{Type: "path", Left: 1.5, Stops: linear.Gradient.Stops},
+	} {
+		_, err = f.NewStyle(&Style{Fill: Fill{Type: "gradient", Gradient: gradient}})
+		assert.Equal(t, ErrParameterInvalid, err)
+	}
+	for _, stops := range [][]GradientStop{
+		{{Position: 0, Color: "FFFFFF"}},
+		{{Position: 0, Color: "FFFFFF"}, {Position: 1.2, Color: "000000"}},
+		{{Position: 0.6, Color: "FFFFFF"}, {Position: 0.2, Color: "000000"}},
+	} {
+		_, err = f.NewStyle(&Style{Fill: Fill{Type: "gradient", Gradient: &GradientFill{Stops: stops}}})
+		assert.Equal(t, ErrGradientFillStops, err)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test get style with gradient fill authored by the spreadsheet application
+	f = NewFile()
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	s.Fills.Fill = append(s.Fills.Fill, &xlsxFill{GradientFill: &xlsxGradientFill{
+		Degree: 90, Stop: []*xlsxGradientFillStop{
+			{Position: 0, Color: xlsxColor{RGB: "FF92D050"}},
+			{Position: 0.5, Color: xlsxColor{RGB: "FFFFFFFF"}},
+			{Position: 1, Color: xlsxColor{RGB: "FF00B050"}},
+		},
+	}})
+	s.Fills.Count = len(s.Fills.Fill)
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xlsxXf{FillID: intPtr(s.Fills.Count - 1), ApplyFill: boolPtr(true)})
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	style, err = f.GetStyle(s.CellXfs.Count - 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &GradientFill{Type: "linear", Degree: 90, Stops: []GradientStop{
+		{Position: 0, Color: "92D050"},
+		{Position: 0.5, Color: "FFFFFF"},
+		{Position: 1, Color: "00B050"},
+	}}, style.Fill.Gradient)
+	assert.NoError(t, f.Close())
+}
+
 func TestSetConditionalFormat(t *testing.T) {
 	cases := []struct {
 		label  string
@@ -195,6 +272,23 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
 	// Test creating a conditional format with invalid icon set style
 	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}))
+	// Test creating a conditional format with invalid icon set thresholds
+	for _, thresholds := range [][]ConditionalFormatIconThreshold{
+		{{Type: "num", Value: "1"}},
+		{{Type: "num", Value: "1"}, {Type: "max", Value: "2"}},
+		{{Type: "num", Value: "1"}, {Type: "num"}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Arrows", IconThresholds: thresholds}}))
+	}
+	// Test creating multiple icon set conditional formats with the same style
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3Flags"}, {Type: "icon_set", IconStyle: "3Flags", IconThresholds: []ConditionalFormatIconThreshold{{Type: "num", Value: "1"}, {Type: "num", Value: "2"}}}}))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{
+		{Type: "icon_set", IconStyle: "3Flags", Priority: 1},
+		{Type: "icon_set", IconStyle: "3Flags", IconThresholds: []ConditionalFormatIconThreshold{{Type: "num", Value: "1"}, {Type: "num", Value: "2"}}, Priority: 2},
+	}, opts["B1:B2"])
+	assert.Equal(t, []*xlsxCfvo{{Type: "percent", Val: "0"}, {Type: "percent", Val: "33"}, {Type: "percent", Val: "67"}}, condFmtIconSetPresets["3Flags"].IconSet.Cfvo)
 	// Test unsupported conditional formatting rule types
 	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1", []ConditionalFormatOptions{{Type: "unsupported"}}))
 
@@ -268,25 +362,28 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "2_color_scale", Criteria: "=", MinType: "num", MaxType: "num", MinColor: "#FF0000", MaxColor: "#0000FF"}},
 		{{Type: "data_bar", Criteria: "=", MinType: "num", MaxType: "num", MinValue: "-10", MaxValue: "10", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarOnly: true, BarSolid: true, StopIfTrue: true}},
 		{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarDirection: "rightToLeft", BarOnly: true, BarSolid: true, StopIfTrue: true}},
+		{{Type: "data_bar", Criteria: "=", MinType: "percent", MaxType: "formula", MinValue: "10", MaxValue: "$B$1", BarColor: "#638EC6", NegativeBarColor: "#FFC000", NegativeBarBorderColor: "#C00000", AxisColor: "#000000", AxisPosition: "middle", MinLength: 5, MaxLength: 80}},
 		{{Type: "formula", Format: intPtr(1), Criteria: "="}},
 		{{Type: "blanks", Format: intPtr(1)}},
 		{{Type: "no_blanks", Format: intPtr(1)}},
 		{{Type: "errors", Format: intPtr(1)}},
 		{{Type: "no_errors", Format: intPtr(1)}},
 		{{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true, IconsOnly: true}},
+		{{Type: "icon_set", IconStyle: "4Rating", IconThresholds: []ConditionalFormatIconThreshold{{Type: "num", Value: "10"}, {Type: "percentile", Value: "50", GreaterThan: true}, {Type: "formula", Value: "$B$1"}}}},
 	} {
 		f := NewFile()
 		err := f.SetConditionalFormat("Sheet1", "A2:A1,B:B,2:2", format)
 		assert.NoError(t, err)
 		opts, err := f.GetConditionalFormats("Sheet1")
 		assert.NoError(t, err)
+		format[0].Priority = 1
 		assert.Equal(t, format, opts["A2:A1 B1:B1048576 A2:XFD2"])
 	}
 	// Test get multiple conditional formats
 	f := NewFile()
 	expected := []ConditionalFormatOptions{
-		{Type: "data_bar", Criteria: "=", MinType: "num", MaxType: "num", MinValue: "-10", MaxValue: "10", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarOnly: true, BarSolid: true, StopIfTrue: true},
-		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarDirection: "rightToLeft", BarOnly: true, BarSolid: false, StopIfTrue: true},
+		{Type: "data_bar", Criteria: "=", MinType: "num", MaxType: "num", MinValue: "-10", MaxValue: "10", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarOnly: true, BarSolid: true, StopIfTrue: true, Priority: 1},
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarDirection: "rightToLeft", BarOnly: true, BarSolid: false, StopIfTrue: true, Priority: 2},
 	}
 	err := f.SetConditionalFormat("Sheet1", "A1:A2", expected)
 	assert.NoError(t, err)
@@ -303,6 +400,203 @@ func TestGetConditionalFormats(t *testing.T) {
 	assert.Equal(t, ErrSheetNameInvalid, err)
 }
 
+func TestConditionalFormatDataBarExtension(t *testing.T) {
+	// Test data bar with the extension created by Excel round-trip
+	f := NewFile()
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ID := "{00000000-0000-0000-0000-000000000001}"
+	ws.ConditionalFormatting = []*xlsxConditionalFormatting{{SQRef: "A1:A5", CfRule: []*xlsxCfRule{{
+		Type: "dataBar", Priority: 1,
+		DataBar: &xlsxDataBar{Cfvo: []*xlsxCfvo{{Type: "min"}, {Type: "max"}}, Color: []*xlsxColor{{RGB: "FF638EC6"}}},
+		ExtLst:  &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:id>%s</x14:id></ext>`, ExtURIConditionalFormattingRuleID, NameSpaceSpreadSheetX14.Value, ID)},
+	}}}}
+	ws.ExtLst = &xlsxExtLst{Ext: fmt.Sprintf(`<ext uri="%s" xmlns:x14="%s"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="%s"><x14:cfRule type="dataBar" id="%s"><x14:dataBar minLength="0" maxLength="100" border="1" gradient="0" negativeBarBorderColorSameAsPositive="0" axisPosition="middle"><x14:cfvo type="autoMin"/><x14:cfvo type="autoMax"/><x14:borderColor rgb="FF638EC6"/><x14:negativeFillColor rgb="FFFFC000"/><x14:negativeBorderColor rgb="FFC00000"/><x14:axisColor rgb="FF000000"/></x14:dataBar></x14:cfRule><xm:sqref>A1:A5</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`,
+		ExtURIConditionalFormattings, NameSpaceSpreadSheetX14.Value, NameSpaceSpreadSheetExcel2006Main.Value, ID)}
+	expected := []ConditionalFormatOptions{{
+		Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarBorderColor: "#638EC6", BarSolid: true,
+		NegativeBarColor: "#FFC000", NegativeBarBorderColor: "#C00000", AxisColor: "#000000", AxisPosition: "middle", Priority: 1,
+	}}
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts["A1:A5"])
+
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", expected))
+	ws, err = f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Contains(t, ws.ExtLst.Ext, `<x14:dataBar maxLength="100" minLength="0" border="true" gradient="false" negativeBarBorderColorSameAsPositive="false" axisPosition="middle"><x14:cfvo type="autoMin"></x14:cfvo><x14:cfvo type="autoMax"></x14:cfvo><x14:borderColor rgb="FF638EC6"></x14:borderColor><x14:negativeFillColor rgb="FFFFC000"></x14:negativeFillColor><x14:negativeBorderColor rgb="FFC00000"></x14:negativeBorderColor><x14:axisColor rgb="FF000000"></x14:axisColor></x14:dataBar>`)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts["A1:A5"])
+
+	// Test the number type value objects of the data bar extension
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B5", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "num", MaxType: "percentile", MinValue: "-10", MaxValue: "90", BarColor: "#638EC6", BarSolid: true}}))
+	assert.Contains(t, ws.ExtLst.Ext, `<x14:cfvo type="num"><xm:f>-10</xm:f></x14:cfvo><x14:cfvo type="percentile"><xm:f>90</xm:f></x14:cfvo>`)
+
+	// Test set data bar with invalid extension settings
+	for _, opt := range []ConditionalFormatOptions{
+		{AxisPosition: "unknown"},
+		{MinLength: -1},
+		{MaxLength: 101},
+		{MinLength: 60, MaxLength: 50},
+	} {
+		opt.Type, opt.Criteria, opt.MinType, opt.MaxType = "data_bar", "=", "min", "max"
+		assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "C1:C5", []ConditionalFormatOptions{opt}))
+	}
+}
+
+func TestConditionalFormatRulePriority(t *testing.T) {
+	f := NewFile()
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	style, err := f.GetConditionalStyle(format)
+	assert.NoError(t, err)
+	rule := func(value string, priority int) ConditionalFormatOptions {
+		return ConditionalFormatOptions{Type: "cell", Criteria: "greater than", Format: &format, FormatStyle: style, Value: value, Priority: priority}
+	}
+	// getPriorities returns the values of rules ordered by evaluation order
+	getPriorities := func() []string {
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		var count int
+		for _, cf := range ws.ConditionalFormatting {
+			count += len(cf.CfRule)
+		}
+		values := make([]string, count)
+		for _, cf := range ws.ConditionalFormatting {
+			for _, cr := range cf.CfRule {
+				value := "dataBar"
+				if len(cr.Formula) > 0 {
+					value = cr.Formula[0]
+				}
+				values[cr.Priority-1] = value
+			}
+		}
+		return values
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{rule("1", 0), rule("2", 0)}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B5", []ConditionalFormatOptions{rule("3", 0)}))
+	// Test insert rules with the given priorities
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C5", []ConditionalFormatOptions{rule("4", 3), rule("5", 1), rule("6", 10)}))
+	assert.Equal(t, []string{"5", "1", "4", "2", "3", "6"}, getPriorities())
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{rule("4", 3), rule("5", 1), rule("6", 6)}, opts["C1:C5"])
+	// Test set the priority of a single rule
+	assert.NoError(t, f.SetConditionalFormatRulePriority("Sheet1", "A1:A5", 1, 1))
+	assert.Equal(t, []string{"2", "5", "1", "4", "3", "6"}, getPriorities())
+	assert.NoError(t, f.SetConditionalFormatRulePriority("Sheet1", "C1:C5", 1, 10))
+	assert.Equal(t, []string{"2", "1", "4", "3", "6", "5"}, getPriorities())
+	// Test delete a single rule
+	assert.NoError(t, f.DeleteConditionalFormatRule("Sheet1", "A1:A5", 0))
+	assert.Equal(t, []string{"2", "4", "3", "6", "5"}, getPriorities())
+	assert.NoError(t, f.DeleteConditionalFormatRule("Sheet1", "B1:B5", 0))
+	assert.Equal(t, []string{"2", "4", "6", "5"}, getPriorities())
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts, 2)
+	// Test the index of the rule in multiple conditional formats with the same range
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{rule("7", 0)}))
+	assert.NoError(t, f.DeleteConditionalFormatRule("Sheet1", "A1:A5", 1))
+	assert.Equal(t, []string{"2", "4", "6", "5"}, getPriorities())
+
+	// Test delete the data bar rule with the extension
+	for _, ref := range []string{"D1:D5", "E1:E5"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", ref, []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true}}))
+	}
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	IDs := []string{getCfRuleExtID(ws.ConditionalFormatting[2].CfRule[0]), getCfRuleExtID(ws.ConditionalFormatting[3].CfRule[0])}
+	assert.NoError(t, f.DeleteConditionalFormatRule("Sheet1", "D1:D5", 0))
+	assert.NotContains(t, ws.ExtLst.Ext, IDs[0])
+	assert.Contains(t, ws.ExtLst.Ext, IDs[1])
+	// Test create rule after deleting rules without the duplicate rule ID
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D5", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true}}))
+	assert.NotEqual(t, IDs[1], getCfRuleExtID(ws.ConditionalFormatting[3].CfRule[0]))
+	assert.NoError(t, f.DeleteConditionalFormatRule("Sheet1", "E1:E5", 0))
+	assert.NoError(t, f.DeleteConditionalFormatRule("Sheet1", "D1:D5", 0))
+	assert.Nil(t, ws.ExtLst)
+	assert.Equal(t, []string{"2", "4", "6", "5"}, getPriorities())
+
+	// Test delete or set priority of the rule with invalid index
+	expected := newNoExistCfRuleError("A1:A5", 1)
+	assert.Equal(t, expected, f.DeleteConditionalFormatRule("Sheet1", "A1:A5", 1))
+	assert.Equal(t, expected, f.SetConditionalFormatRulePriority("Sheet1", "A1:A5", 1, 1))
+	assert.Equal(t, newNoExistCfRuleError("A1:A5", -1), f.DeleteConditionalFormatRule("Sheet1", "A1:A5", -1))
+	assert.Equal(t, newNoExistCfRuleError("F1", 0), f.DeleteConditionalFormatRule("Sheet1", "F1", 0))
+	// Test delete or set priority of the rule with invalid range reference
+	assert.Equal(t, ErrParameterRequired, f.DeleteConditionalFormatRule("Sheet1", "", 0))
+	assert.Equal(t, ErrParameterRequired, f.SetConditionalFormatRulePriority("Sheet1", "", 0, 1))
+	// Test set rule with invalid priority
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormatRulePriority("Sheet1", "A1:A5", 0, 0))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{rule("1", -1)}))
+	// Test delete or set priority of the rule on not exists worksheet
+	assert.EqualError(t, f.DeleteConditionalFormatRule("SheetN", "A1:A5", 0), "sheet SheetN does not exist")
+	assert.EqualError(t, f.SetConditionalFormatRulePriority("SheetN", "A1:A5", 0, 1), "sheet SheetN does not exist")
+	// Test delete the rule with invalid extension list characters
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D5", []ConditionalFormatOptions{{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true}}))
+	ws.ExtLst.Ext = "<ext><x14:conditionalFormattings></x14:conditionalFormatting></x14:conditionalFormattings></ext>"
+	assert.EqualError(t, f.DeleteConditionalFormatRule("Sheet1", "D1:D5", 0), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
+}
+
+func TestConditionalFormatStyle(t *testing.T) {
+	f := NewFile()
+	style := &Style{
+		Font:         &Font{Bold: true, Color: "9A0511"},
+		Fill:         Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1},
+		Border:       []Border{{Type: "left", Color: "0000FF", Style: 1}},
+		CustomNumFmt: stringPtr("0.00%"),
+	}
+	// Test set conditional format with style definition
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Value: "6", FormatStyle: style},
+		{Type: "duplicate", Criteria: "=", FormatStyle: &Style{Font: &Font{Italic: true}}},
+	}))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, opts["A1:A10"], 2)
+	for i, opt := range opts["A1:A10"] {
+		assert.Equal(t, i, *opt.Format)
+		expected, err := f.GetConditionalStyle(i)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, opt.FormatStyle)
+	}
+	assert.True(t, opts["A1:A10"][0].FormatStyle.Font.Bold)
+	assert.Equal(t, "0.00%", *opts["A1:A10"][0].FormatStyle.CustomNumFmt)
+	// Test copy conditional formats in the same workbook reuse the formats
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", opts["A1:A10"]))
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, styles.Dxfs.Count)
+
+	// Test copy conditional formats to another workbook
+	target := NewFile()
+	_, err = target.NewConditionalStyle(&Style{Font: &Font{Strike: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, target.SetConditionalFormat("Sheet1", "A1:A10", opts["A1:A10"]))
+	targetOpts, err := target.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	for i, opt := range targetOpts["A1:A10"] {
+		assert.Equal(t, i+1, *opt.Format)
+		assert.Equal(t, opts["A1:A10"][i].FormatStyle, opt.FormatStyle)
+	}
+	// Test set conditional format with invalid style definition
+	assert.Equal(t, ErrFontLength, target.SetConditionalFormat("Sheet1", "B1", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Value: "6", FormatStyle: &Style{Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}}},
+	}))
+	assert.NoError(t, f.Close())
+	assert.NoError(t, target.Close())
+}
+
+func TestDeleteX14CfRuleContent(t *testing.T) {
+	content := `<x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="%s"><x14:cfRule type="dataBar" id="{1}"><x14:dataBar/></x14:cfRule><x14:cfRule type="dataBar" id="{2}"/><xm:sqref>A1:A5</xm:sqref></x14:conditionalFormatting><x14:conditionalFormatting xmlns:xm="%s"><x14:cfRule type="dataBar" id="{3}"/><xm:sqref>B1:B5</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings>`
+	assert.Equal(t, `<x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="xm"><x14:cfRule type="dataBar" id="{2}"/><xm:sqref>A1:A5</xm:sqref></x14:conditionalFormatting><x14:conditionalFormatting xmlns:xm="xm"><x14:cfRule type="dataBar" id="{3}"/><xm:sqref>B1:B5</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings>`,
+		deleteX14CfRuleContent(fmt.Sprintf(content, "xm", "xm"), "{1}"))
+	assert.Equal(t, `<x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="xm"><x14:cfRule type="dataBar" id="{1}"><x14:dataBar/></x14:cfRule><x14:cfRule type="dataBar" id="{2}"/><xm:sqref>A1:A5</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings>`,
+		deleteX14CfRuleContent(fmt.Sprintf(content, "xm", "xm"), "{3}"))
+}
+
 func TestUnsetConditionalFormat(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 7))
@@ -514,12 +808,152 @@ func TestSetDefaultFont(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, s, "Arial", "Default font should change to Arial")
 	assert.Equal(t, *styles.CellStyles.CellStyle[0].CustomBuiltIn, true)
+	assert.Equal(t, 0, *styles.CellStyleXfs.Xf[0].FontID)
+	size, err := f.GetDefaultFontSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 11.0, size)
+	// Test set default font with invalid font name
+	assert.Equal(t, ErrFontLength, f.SetDefaultFont(strings.Repeat("s", MaxFontFamilyLength+1)))
 	// Test set default font with unsupported charset style sheet
 	f.Styles = nil
 	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.SetDefaultFont("Arial"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetDefaultFontSize(t *testing.T) {
+	f := NewFile()
+	size, err := f.GetDefaultFontSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 11.0, size)
+	// Test get default font size without the font size
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	styles.Fonts.Font[0].Sz = nil
+	size, err = f.GetDefaultFontSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 11.0, size)
+	// Test get default font size with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetDefaultFontSize()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestDefaultFontStyle(t *testing.T) {
+	f := NewFile()
+	font, err := f.GetDefaultFontStyle()
+	assert.NoError(t, err)
+	assert.Equal(t, "Calibri", font.Family)
+	assert.Equal(t, 11.0, font.Size)
+	expected := &Font{Family: "Arial", Size: 14, Color: "FF0000", Bold: true, Italic: true}
+	assert.NoError(t, f.SetDefaultFontStyle(expected))
+	font, err = f.GetDefaultFontStyle()
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Family, font.Family)
+	assert.Equal(t, expected.Size, font.Size)
+	assert.Equal(t, expected.Color, font.Color)
+	assert.Equal(t, expected.Bold, font.Bold)
+	assert.Equal(t, expected.Italic, font.Italic)
+	ht, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 18.75, ht)
+	// Test set default font style without font family and size
+	assert.NoError(t, f.SetDefaultFontStyle(&Font{Bold: true}))
+	font, err = f.GetDefaultFontStyle()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", font.Family)
+	assert.Equal(t, 11.0, font.Size)
+	assert.True(t, font.Bold)
+	assert.False(t, font.Italic)
+	assert.Empty(t, font.Color)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefaultFontStyle.xlsx")))
+	// Test get default font style without the font size
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	styles.Fonts.Font[0].Sz = nil
+	font, err = f.GetDefaultFontStyle()
+	assert.NoError(t, err)
+	assert.Equal(t, 11.0, font.Size)
+	// Test set default font style with invalid options
+	assert.Equal(t, ErrParameterRequired, f.SetDefaultFontStyle(nil))
+	assert.Equal(t, ErrFontLength, f.SetDefaultFontStyle(&Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}))
+	assert.Equal(t, ErrFontSize, f.SetDefaultFontStyle(&Font{Size: MaxFontSize + 1}))
+	assert.NoError(t, f.Close())
+	// Test get and set default font style with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetDefaultFontStyle()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	assert.EqualError(t, f.SetDefaultFontStyle(&Font{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetDefaultFontSize(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDefaultFont("Arial"))
+	ht, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultRowHeight, ht)
+	assert.Equal(t, int(defaultColWidthPixels), f.getColWidth("Sheet1", 1))
+	assert.Equal(t, 18, f.getRowHeight("Sheet1", 1))
+
+	// Test set default font size with the worksheets and chart sheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetProps("Sheet2", &SheetPropsOptions{CustomHeight: boolPtr(true), DefaultRowHeight: float64Ptr(30)}))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$A$1"}},
+	}))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 10))
+	assert.NoError(t, f.SetDefaultFontSize(14))
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, *styles.CellStyles.CellStyle[0].CustomBuiltIn, true)
+	s, err := f.GetDefaultFont()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", s)
+	size, err := f.GetDefaultFontSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 14.0, size)
+	for sheet, expected := range map[string]float64{"Sheet1": 18.75, "Sheet2": 30} {
+		ht, err := f.GetRowHeight(sheet, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ht, sheet)
+	}
+	// Test the column width in pixels measured by the digit width of the new font
+	assert.Equal(t, 98, f.getColWidth("Sheet1", 2))
+	assert.Equal(t, 82, f.getColWidth("Sheet1", 1))
+	assert.Equal(t, 23, f.getRowHeight("Sheet1", 1))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetDefaultFontSize.xlsx")))
+
+	// Test set default font size with invalid size
+	assert.Equal(t, ErrFontSize, f.SetDefaultFontSize(MaxFontSize+1))
+	assert.Equal(t, ErrFontSize, f.SetDefaultFontSize(0))
+	// Test set default font size with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked.Delete("xl/worksheets/sheet1.xml")
+	assert.EqualError(t, f.SetDefaultFontSize(11), "XML syntax error on line 1: invalid UTF-8")
+	// Test set default font size with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetDefaultFontSize(11), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test get the default row height and column width in pixels without the sheet format properties
+	f = NewFile()
+	assert.NoError(t, f.SetDefaultFontSize(8))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetFormatPr = nil
+	assert.Equal(t, 47, f.getColWidth("Sheet1", 1))
+	assert.Equal(t, 13, f.getRowHeight("Sheet1", 1))
+	assert.NoError(t, f.Close())
+}
+
 func TestStylesReader(t *testing.T) {
 	f := NewFile()
 	// Test read styles with unsupported charset
@@ -553,6 +987,252 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetCellEffectiveStyle(t *testing.T) {
+	f := NewFile()
+	cellStyle, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	rowStyle, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	colStyle, err := f.NewStyle(&Style{NumFmt: 14, Font: &Font{Color: "FF0000"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetColStyle("Sheet1", "B:C", colStyle))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 2, 2, rowStyle))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", cellStyle))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	// Cells without the style attribute in the row with style but without
+	// custom format, and in the styled column
+	ws.(*xlsxWorksheet).SheetData.Row = append(ws.(*xlsxWorksheet).SheetData.Row,
+		xlsxRow{R: 4, S: rowStyle, C: []xlsxC{{R: "A4", V: "1"}, {R: "C4", V: "45292"}}})
+	for cell, expected := range map[string]int{
+		"A1": 0, "B1": colStyle, "A2": 0, "B2": cellStyle, "C2": rowStyle, "D2": rowStyle,
+		"A4": 0, "B4": colStyle, "C4": 0, "D4": 0, "C10": colStyle,
+	} {
+		styleID, style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+		assert.NotNil(t, style, cell)
+	}
+	_, style, err := f.GetCellEffectiveStyle("Sheet1", "C10")
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	assert.Equal(t, "FF0000", style.Font.Color)
+	// Test get cell value of the existing cell without style in the styled column
+	val, err := f.GetCellValue("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, "45292", val)
+	val, _, err = f.GetCellFormattedValueAndColor("Sheet1", "C4")
+	assert.NoError(t, err)
+	assert.Equal(t, "45292", val)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "", "45292"}, rows[3])
+	// Test get effective style with invalid cell reference
+	_, _, err = f.GetCellEffectiveStyle("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get effective style with invalid sheet name
+	_, _, err = f.GetCellEffectiveStyle("Sheet:1", "A1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get effective style with invalid style ID
+	lastRow := &ws.(*xlsxWorksheet).SheetData.Row[len(ws.(*xlsxWorksheet).SheetData.Row)-1]
+	lastRow.C[0].S = 1000
+	styleID, _, err := f.GetCellEffectiveStyle("Sheet1", "A4")
+	assert.Equal(t, 1000, styleID)
+	assert.Equal(t, newInvalidStyleID(1000), err)
+}
+
+func TestSetCellStylePartial(t *testing.T) {
+	f := NewFile()
+	base := &Style{
+		Border: []Border{{Type: "left", Color: "0000FF", Style: 1}},
+		Fill:   Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1},
+		Font:   &Font{Bold: true, Color: "FF0000"},
+	}
+	styleID1, err := f.NewStyle(base)
+	assert.NoError(t, err)
+	styleID2, err := f.NewStyle(&Style{Font: &Font{Italic: true}, Alignment: &Alignment{Horizontal: "center"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B2", styleID1))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C1", "C2", styleID2))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, styleID2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1234.5678))
+
+	assert.NoError(t, f.SetCellStylePartial("Sheet1", "C3", "A1", &Style{NumFmt: 2, Font: &Font{Size: 20}}, StyleFieldNumFmt))
+	derived := make(map[string]int)
+	for _, cell := range []string{"A1", "A2", "B1", "B2", "C1", "C2", "A3", "C3"} {
+		derived[cell], err = f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+	}
+	// Test the cells shared the same existing style reuse the derived style
+	for _, cell := range []string{"A2", "B1", "B2"} {
+		assert.Equal(t, derived["A1"], derived[cell], cell)
+	}
+	for _, cell := range []string{"C2", "A3", "C3"} {
+		assert.Equal(t, derived["C1"], derived[cell], cell)
+	}
+	assert.NotEqual(t, styleID1, derived["A1"])
+	assert.NotEqual(t, styleID2, derived["C1"])
+
+	style, err := f.GetStyle(derived["A1"])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, style.NumFmt)
+	assert.Equal(t, base.Border, style.Border)
+	assert.Equal(t, base.Fill.Color, style.Fill.Color)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, "FF0000", style.Font.Color)
+	style, err = f.GetStyle(derived["C1"])
+	assert.NoError(t, err)
+	assert.Equal(t, 2, style.NumFmt)
+	assert.True(t, style.Font.Italic)
+	assert.Equal(t, "center", style.Alignment.Horizontal)
+	val, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234.57", val)
+
+	// Test patch multiple style fields for the unstyled cells
+	assert.NoError(t, f.SetCellStylePartial("Sheet1", "D1", "D1", &Style{
+		Font:       &Font{Underline: "single"},
+		Protection: &Protection{Hidden: true, Locked: true},
+	}, StyleFieldFont|StyleFieldProtection))
+	styleID, err := f.GetCellStyle("Sheet1", "D1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "single", style.Font.Underline)
+	assert.True(t, style.Protection.Hidden)
+
+	// Test override all fields of the style
+	assert.NoError(t, f.SetCellStylePartial("Sheet1", "A1", "A1", &Style{}, StyleFieldAll))
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Empty(t, style.Border)
+	assert.Equal(t, 0, style.NumFmt)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellStylePartial.xlsx")))
+
+	// Test set cell style partial with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.SetCellStylePartial("Sheet1", "A1", "A1", nil, StyleFieldAll))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellStylePartial("Sheet1", "A", "A1", &Style{}, StyleFieldAll))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellStylePartial("Sheet1", "A1", "A", &Style{}, StyleFieldAll))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, f.SetCellStylePartial("SheetN", "A1", "A1", &Style{}, StyleFieldAll))
+	assert.Equal(t, ErrBorderStyle, f.SetCellStylePartial("Sheet1", "A1", "A1", &Style{Border: []Border{{Type: "left", Style: 14}}}, StyleFieldBorder))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 1000
+	assert.Equal(t, newInvalidStyleID(1000), f.SetCellStylePartial("Sheet1", "A1", "A1", &Style{}, StyleFieldAll))
+}
+
+func TestNamedStyle(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+	// Test apply built-in named style with direct formatting on the cell
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "A1", "B2", "Good"))
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "C1", "C1", "Good"))
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, s.CellStyles.CellStyle, 2)
+	assert.Equal(t, "Good", s.CellStyles.CellStyle[1].Name)
+	assert.Equal(t, 26, *s.CellStyles.CellStyle[1].BuiltInID)
+	styleA1, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	styleB2, err := f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	styleC1, err := f.GetCellStyle("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleA1, styleB2)
+	assert.Equal(t, styleB2, styleC1)
+	assert.Equal(t, 1, *s.CellXfs.Xf[styleA1].XfID)
+	assert.Equal(t, *s.CellXfs.Xf[styleID].FontID, *s.CellXfs.Xf[styleA1].FontID)
+	assert.Equal(t, *s.CellStyleXfs.Xf[1].FillID, *s.CellXfs.Xf[styleA1].FillID)
+	style, err := f.GetStyle(styleB2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"C6EFCE"}, style.Fill.Color)
+	assert.Equal(t, "006100", style.Font.Color)
+	// Test create style which is the same with the named style cell format
+	newStyleID, err := f.NewStyle(style)
+	assert.NoError(t, err)
+	assert.NotEqual(t, styleB2, newStyleID)
+
+	// Test create custom named style
+	assert.NoError(t, f.NewNamedStyle("Highlight", &Style{
+		Font: &Font{Bold: true},
+		Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+	}))
+	assert.Equal(t, ErrNamedStyleDuplicate, f.NewNamedStyle("Highlight", &Style{}))
+	assert.Equal(t, ErrParameterRequired, f.NewNamedStyle("", &Style{}))
+	assert.Equal(t, ErrParameterRequired, f.NewNamedStyle("Style", nil))
+	assert.Equal(t, ErrFontLength, f.NewNamedStyle("Style", &Style{Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}}))
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "D1", "D1", "Highlight"))
+	namedStyles, err := f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Len(t, namedStyles, 3)
+	assert.Equal(t, "Normal", namedStyles[0].Name)
+	assert.Equal(t, "Highlight", namedStyles[2].Name)
+	assert.Nil(t, namedStyles[2].BuiltInID)
+	assert.True(t, namedStyles[2].Style.Font.Bold)
+	assert.Equal(t, []string{"FFFF00"}, namedStyles[2].Style.Fill.Color)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNamedStyle.xlsx")))
+
+	// Test apply named style with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetCellNamedStyle("Sheet1", "A", "B2", "Good"))
+	// Test apply not exists named style
+	assert.EqualError(t, f.SetCellNamedStyle("Sheet1", "A1", "B2", "Unknown"), "named style Unknown does not exist")
+	// Test apply named style on not exists worksheet
+	assert.EqualError(t, f.SetCellNamedStyle("SheetN", "A1", "B2", "Good"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test named style with unsupported charset style sheet
+	for _, fn := range []func(f *File) error{
+		func(f *File) error { return f.NewNamedStyle("Style", &Style{}) },
+		func(f *File) error { return f.SetCellNamedStyle("Sheet1", "A1", "B2", "Good") },
+		func(f *File) error { _, err := f.GetNamedStyles(); return err },
+	} {
+		f = NewFile()
+		f.Styles = nil
+		f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+		assert.EqualError(t, fn(f), "XML syntax error on line 1: invalid UTF-8")
+		assert.NoError(t, f.Close())
+	}
+
+	// Test named style without cell styles
+	f = NewFile()
+	s, err = f.stylesReader()
+	assert.NoError(t, err)
+	s.CellStyles, s.CellStyleXfs = nil, nil
+	namedStyles, err = f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Empty(t, namedStyles)
+	assert.NoError(t, f.SetCellNamedStyle("Sheet1", "A1", "A1", "Bad"))
+	namedStyles, err = f.GetNamedStyles()
+	assert.NoError(t, err)
+	assert.Len(t, namedStyles, 2)
+	assert.Equal(t, "Normal", namedStyles[0].Name)
+	assert.Equal(t, 0, *namedStyles[0].BuiltInID)
+	assert.Equal(t, "Bad", namedStyles[1].Name)
+	assert.NoError(t, f.Close())
+
+	// Test apply named style and patch cell style concurrently
+	f = NewFile()
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(2)
+		go func(cell string) {
+			defer wg.Done()
+			assert.NoError(t, f.SetCellNamedStyle("Sheet1", "A1", cell, "Good"))
+		}(fmt.Sprintf("B%d", i))
+		go func(cell string) {
+			defer wg.Done()
+			assert.NoError(t, f.SetCellStylePartial("Sheet1", "A1", cell, &Style{Font: &Font{Bold: true}}, StyleFieldFont))
+		}(fmt.Sprintf("B%d", i))
+	}
+	wg.Wait()
+	assert.NoError(t, f.Close())
+}
+
 func TestGetStyleID(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.getStyleID(&xlsxStyleSheet{}, nil)
@@ -604,6 +1284,300 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
 
+func TestThemeColors(t *testing.T) {
+	f := NewFile()
+	colors, err := f.GetThemeColors()
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeColors{
+		Dark1: "000000", Light1: "FFFFFF", Dark2: "44546A", Light2: "E7E6E6",
+		Accent1: "5B9BD5", Accent2: "ED7D31", Accent3: "A5A5A5", Accent4: "FFC000",
+		Accent5: "4472C4", Accent6: "70AD47", Hyperlink: "0563C1", FollowedHyperlink: "954F72",
+	}, colors)
+	assert.NoError(t, f.SetThemeColors(ThemeColors{Dark1: "111111", Accent1: "#1f4e79"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetThemeColors(ThemeColors{Accent2: "FFF"}))
+	assert.Equal(t, ErrParameterInvalid, f.SetThemeColors(ThemeColors{Accent2: "GGGGGG"}))
+	for _, c := range []struct {
+		idx  int
+		tint float64
+		rgb  string
+	}{
+		{1, 0, "111111"},
+		{4, 0, "1F4E79"},
+		{0, -0.5, "808080"},
+		{11, 0, "954F72"},
+	} {
+		rgb, err := f.ThemeColorToRGB(c.idx, c.tint)
+		assert.NoError(t, err)
+		assert.Equal(t, c.rgb, rgb)
+	}
+	_, err = f.ThemeColorToRGB(12, 0)
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.ThemeColorToRGB(-1, 0)
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestThemeColors.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestThemeColors.xlsx"))
+	assert.NoError(t, err)
+	colors, err = f.GetThemeColors()
+	assert.NoError(t, err)
+	assert.Equal(t, "111111", colors.Dark1)
+	assert.Equal(t, "1F4E79", colors.Accent1)
+	assert.Equal(t, "ED7D31", colors.Accent2)
+	assert.Equal(t, "Calibri", f.Theme.ThemeElements.FontScheme.MinorFont.Latin.Typeface)
+	// Test convert theme color without color value
+	f.Theme.ThemeElements.ClrScheme.Accent2 = decodeCTColor{}
+	_, err = f.ThemeColorToRGB(5, 0)
+	assert.Equal(t, ErrParameterInvalid, err)
+	assert.NoError(t, f.Close())
+
+	// Test theme colors without theme part
+	f = NewFile()
+	f.Theme = nil
+	_, err = f.GetThemeColors()
+	assert.Equal(t, ErrThemeNotExist, err)
+	assert.Equal(t, ErrThemeNotExist, f.SetThemeColors(ThemeColors{}))
+	_, err = f.ThemeColorToRGB(0, 0)
+	assert.Equal(t, ErrThemeNotExist, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestCompactStyles(t *testing.T) {
+	f := NewFile()
+	styleA, err := f.NewStyle(&Style{Font: &Font{Bold: true}, CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	styleB, err := f.NewStyle(&Style{Font: &Font{Italic: true}, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00FF00"}}})
+	assert.NoError(t, err)
+	_, err = f.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}})
+	assert.NoError(t, err)
+	// Append duplicate font, number format and cell format of the style A
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	xf := s.CellXfs.Xf[styleA]
+	s.Fonts.Font = append(s.Fonts.Font, s.Fonts.Font[*xf.FontID])
+	s.Fonts.Count = len(s.Fonts.Font)
+	s.NumFmts.NumFmt = append(s.NumFmts.NumFmt, &xlsxNumFmt{NumFmtID: 200, FormatCode: "0.000"})
+	s.NumFmts.Count = len(s.NumFmts.NumFmt)
+	xf.FontID, xf.NumFmtID = intPtr(len(s.Fonts.Font)-1), intPtr(200)
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	styleDup := len(s.CellXfs.Xf) - 1
+
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleA))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", styleDup))
+	assert.NoError(t, f.SetRowStyle("Sheet1", 3, 3, styleB))
+	assert.NoError(t, f.SetColStyle("Sheet1", "C", styleDup))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet2", "B2", "B2", styleB))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$A$1:$A$2", Values: "Sheet1!$A$1:$A$2"}},
+	}))
+	cells := []struct{ sheet, cell string }{
+		{"Sheet1", "A1"}, {"Sheet1", "A2"}, {"Sheet1", "B3"}, {"Sheet1", "C1"}, {"Sheet1", "D1"}, {"Sheet2", "B2"},
+	}
+	getStyles := func() []*Style {
+		var styles []*Style
+		for _, c := range cells {
+			styleID, err := f.GetCellStyle(c.sheet, c.cell)
+			assert.NoError(t, err)
+			style, err := f.GetStyle(styleID)
+			assert.NoError(t, err)
+			styles = append(styles, style)
+		}
+		return styles
+	}
+	expected := getStyles()
+	removed, err := f.CompactStyles()
+	assert.NoError(t, err)
+	// Duplicate cell format, font and number format, unused cell format and fill
+	assert.Equal(t, 5, removed)
+	assert.Equal(t, expected, getStyles())
+	assert.Len(t, s.CellXfs.Xf, 3)
+	assert.Equal(t, s.CellXfs.Count, len(s.CellXfs.Xf))
+	assert.Len(t, s.NumFmts.NumFmt, 1)
+	assert.Len(t, s.Fills.Fill, 3)
+	styleID, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, styleID)
+	// Test compact styles without duplicate or unused styles
+	removed, err = f.CompactStyles()
+	assert.NoError(t, err)
+	assert.Zero(t, removed)
+	assert.Equal(t, expected, getStyles())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCompactStyles.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test compact styles on save the workbook
+	f = NewFile()
+	_, err = f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCompactStyles.xlsx"), Options{CompactStyles: true}))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestCompactStyles.xlsx"))
+	assert.NoError(t, err)
+	s, err = f.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, s.CellXfs.Xf, 1)
+	assert.Len(t, s.Fonts.Font, 1)
+	assert.Nil(t, s.NumFmts)
+	assert.NoError(t, f.Close())
+
+	// Test compact styles with streamed worksheet
+	f = NewFile()
+	_, err = f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	_, err = f.CompactStyles()
+	assert.Equal(t, ErrCompactStylesStream, err)
+	assert.Equal(t, ErrCompactStylesStream, f.SaveAs(filepath.Join("test", "TestCompactStyles.xlsx"), Options{CompactStyles: true}))
+	assert.NoError(t, f.Close())
+
+	// Test compact styles with unsupported charset worksheet and style sheet
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, err = f.CompactStyles()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.CompactStyles()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test compact styles without cell formats
+	f = NewFile()
+	f.Styles.CellXfs = nil
+	removed, err = f.CompactStyles()
+	assert.NoError(t, err)
+	assert.Zero(t, removed)
+	assert.NoError(t, f.Close())
+}
+
+func TestCopyStyleFrom(t *testing.T) {
+	src, dst := NewFile(), NewFile()
+	srcStyle := &Style{
+		Font:         &Font{Bold: true, Color: "FF0000"},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:       []Border{{Type: "left", Color: "0000FF", Style: 1}},
+		Alignment:    &Alignment{Horizontal: "center"},
+		CustomNumFmt: stringPtr("0.000"),
+	}
+	srcStyleID, err := src.NewStyle(srcStyle)
+	assert.NoError(t, err)
+	srcStyleID2, err := src.NewStyle(&Style{Font: &Font{Italic: true}, CustomNumFmt: stringPtr("0.000")})
+	assert.NoError(t, err)
+	// Test copy style to the workbook which has other custom number formats
+	_, err = dst.NewStyle(&Style{CustomNumFmt: stringPtr("0.0")})
+	assert.NoError(t, err)
+	dstStyleID, err := dst.CopyStyleFrom(src, srcStyleID)
+	assert.NoError(t, err)
+	expected, err := src.GetStyle(srcStyleID)
+	assert.NoError(t, err)
+	style, err := dst.GetStyle(dstStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, style)
+	dstStyleID2, err := dst.CopyStyleFrom(src, srcStyleID2)
+	assert.NoError(t, err)
+	assert.NotEqual(t, dstStyleID, dstStyleID2)
+	s, err := dst.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, s.NumFmts.NumFmt, 2)
+	assert.Equal(t, *s.CellXfs.Xf[dstStyleID].NumFmtID, *s.CellXfs.Xf[dstStyleID2].NumFmtID)
+	// Test copy the same style repeatedly
+	styleID, err := dst.CopyStyleFrom(src, srcStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, dstStyleID, styleID)
+	delete(dst.copiedStyles, src.id)
+	styleID, err = dst.CopyStyleFrom(src, srcStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, dstStyleID, styleID)
+	assert.Len(t, s.CellXfs.Xf, 4)
+	// Test copy style within the same workbook
+	styleID, err = src.CopyStyleFrom(src, srcStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, srcStyleID, styleID)
+	// Test copy style with named style
+	assert.NoError(t, src.SetCellNamedStyle("Sheet1", "A1", "A1", "Good"))
+	srcStyleID, err = src.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	styleID, err = dst.CopyStyleFrom(src, srcStyleID)
+	assert.NoError(t, err)
+	assert.Zero(t, *s.CellXfs.Xf[styleID].XfID)
+	assert.NoError(t, dst.SetCellNamedStyle("Sheet1", "A1", "A1", "Good"))
+	dst.copiedStyles = nil
+	styleID, err = dst.CopyStyleFrom(src, srcStyleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *s.CellXfs.Xf[styleID].XfID)
+	expected, err = src.GetStyle(srcStyleID)
+	assert.NoError(t, err)
+	style, err = dst.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, style)
+	// Test copy style with invalid parameters
+	_, err = dst.CopyStyleFrom(nil, 0)
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = dst.CopyStyleFrom(src, -1)
+	assert.Equal(t, newInvalidStyleID(-1), err)
+	_, err = dst.CopyStyleFrom(src, len(src.Styles.CellXfs.Xf))
+	assert.Equal(t, newInvalidStyleID(len(src.Styles.CellXfs.Xf)), err)
+	// Test copy style exceeds the cell styles limit
+	dst.copiedStyles = nil
+	s.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	_, err = dst.CopyStyleFrom(src, srcStyleID2)
+	assert.Equal(t, ErrCellStyles, err)
+	assert.NoError(t, dst.Close())
+	// Test copy style to the workbook without style records
+	dst = NewFile()
+	dst.Styles = &xlsxStyleSheet{}
+	styleID, err = dst.CopyStyleFrom(src, srcStyleID2)
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	assert.Len(t, dst.Styles.Fonts.Font, 1)
+	assert.NoError(t, dst.Close())
+	// Test copy style with unsupported charset style sheet
+	for _, f := range []*File{src, dst} {
+		f.Styles = nil
+		f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+		_, err = dst.CopyStyleFrom(src, 0)
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	}
+	assert.NoError(t, src.Close())
+
+	// Test copy styles between two workbooks in both directions concurrently
+	f1, f2 := NewFile(), NewFile()
+	styleID1, err := f1.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	styleID2, err := f2.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"E0EBF5"}, Pattern: 1}})
+	assert.NoError(t, err)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := f1.CopyStyleFrom(f2, styleID2)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := f2.CopyStyleFrom(f1, styleID1)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Contains(t, f1.copiedStyles, f2.id)
+	assert.Contains(t, f2.copiedStyles, f1.id)
+	// Test copy style from the workbook without identifier
+	styleID, err = f2.CopyStyleFrom(&File{Styles: f1.Styles}, styleID1)
+	assert.NoError(t, err)
+	assert.Equal(t, f2.copiedStyles[f1.id][styleID1], styleID)
+	assert.NotContains(t, f2.copiedStyles, uint64(0))
+	assert.NoError(t, f1.Close())
+	assert.NoError(t, f2.Close())
+}
+
 func TestGetThemeColor(t *testing.T) {
 	assert.Empty(t, (&File{}).getThemeColor(&xlsxColor{}))
 	f := NewFile()
@@ -617,6 +1591,56 @@ func TestGetThemeColor(t *testing.T) {
 	assert.Nil(t, clr.colorChoice())
 }
 
+func TestStyleBorderRoundTrip(t *testing.T) {
+	f := NewFile()
+	for i, borderType := range styleBorderTypes {
+		for style := 0; style < len(styleBorders); style++ {
+			cell, err := CoordinatesToCellName(i*2+2, style*2+2)
+			assert.NoError(t, err)
+			expected := &Style{Border: []Border{{Type: borderType, Color: "A020F0", Style: style}}}
+			styleID, err := f.NewStyle(expected)
+			assert.NoError(t, err)
+			assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, styleID))
+			result, err := f.GetStyle(styleID)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.Border, result.Border, cell)
+		}
+	}
+	// Test diagonal up and down borders with shared and different settings
+	for i, borders := range [][]Border{
+		{{Type: "diagonalUp", Color: "FF0000", Style: 5}, {Type: "diagonalDown", Color: "FF0000", Style: 5}},
+		{{Type: "diagonalUp", Color: "FF0000", Style: 5}, {Type: "diagonalDown", Color: "0000FF", Style: 8}},
+	} {
+		styleID, err := f.NewStyle(&Style{Border: borders})
+		assert.NoError(t, err)
+		cell, err := CoordinatesToCellName(len(styleBorderTypes)*2+2, i*2+2)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, styleID))
+		result, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		last := borders[len(borders)-1]
+		assert.Equal(t, []Border{
+			{Type: "diagonalUp", Color: last.Color, Style: last.Style},
+			{Type: "diagonalDown", Color: last.Color, Style: last.Style},
+		}, result.Border)
+		ss, err := f.stylesReader()
+		assert.NoError(t, err)
+		border := ss.Borders.Border[*ss.CellXfs.Xf[styleID].BorderID]
+		assert.True(t, border.DiagonalUp)
+		assert.True(t, border.DiagonalDown)
+	}
+	// Test get style with unknown border style
+	ss, err := f.stylesReader()
+	assert.NoError(t, err)
+	ss.Borders.Border = append(ss.Borders.Border, &xlsxBorder{Left: xlsxLine{Style: "unknown"}})
+	ss.Borders.Count = len(ss.Borders.Border)
+	ss.CellXfs.Xf = append(ss.CellXfs.Xf, xlsxXf{BorderID: intPtr(ss.Borders.Count - 1)})
+	result, err := f.GetStyle(len(ss.CellXfs.Xf) - 1)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Border)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleBorderRoundTrip.xlsx")))
+}
+
 func TestGetStyle(t *testing.T) {
 	f := NewFile()
 	expected := &Style{
@@ -761,3 +1785,126 @@ func TestGetStyle(t *testing.T) {
 	assert.Nil(t, style)
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAlignmentRoundTrip(t *testing.T) {
+	f := NewFile()
+	horizontals := []string{"", "general", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}
+	verticals := []string{"", "top", "center", "bottom", "justify", "distributed"}
+	var idx int
+	for _, textRotation := range []int{0, 45, 90, 91, 135, 180, 255} {
+		for _, indent := range []int{0, 1, 15, 16, MaxIndent} {
+			for _, readingOrder := range []uint64{0, 1, 2} {
+				for _, shrinkToFit := range []bool{false, true} {
+					for _, wrapText := range []bool{false, true} {
+						idx++
+						expected := &Style{Alignment: &Alignment{
+							Horizontal:      horizontals[idx%len(horizontals)],
+							Indent:          indent,
+							JustifyLastLine: idx%2 == 0,
+							ReadingOrder:    readingOrder,
+							RelativeIndent:  idx%5 - 2,
+							ShrinkToFit:     shrinkToFit,
+							TextRotation:    textRotation,
+							Vertical:        verticals[idx%len(verticals)],
+							WrapText:        wrapText,
+						}}
+						styleID, err := f.NewStyle(expected)
+						assert.NoError(t, err)
+						style, err := f.GetStyle(styleID)
+						assert.NoError(t, err)
+						assert.Equal(t, expected.Alignment, style.Alignment)
+					}
+				}
+			}
+		}
+	}
+	// Test the alignment settings round-trip after save and reopen
+	styleID, err := f.NewStyle(&Style{Alignment: &Alignment{TextRotation: 255, Indent: MaxIndent, ShrinkToFit: true, WrapText: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Alignment{TextRotation: 255, Indent: MaxIndent, ShrinkToFit: true, WrapText: true}, style.Alignment)
+	// Test create style with invalid alignment settings
+	for _, alignment := range []*Alignment{{Indent: -1}, {Indent: MaxIndent + 1}} {
+		_, err = f.NewStyle(&Style{Alignment: alignment})
+		assert.Equal(t, ErrIndent, err)
+	}
+	_, err = f.NewStyle(&Style{Alignment: &Alignment{ReadingOrder: 3}})
+	assert.Equal(t, ErrReadingOrder, err)
+	for _, alignment := range []*Alignment{{TextRotation: -1}, {TextRotation: 181}, {TextRotation: 254}} {
+		_, err = f.NewStyle(&Style{Alignment: alignment})
+		assert.Equal(t, ErrTextRotation, err)
+	}
+	_, err = f.NewConditionalStyle(&Style{Alignment: &Alignment{TextRotation: 256}})
+	assert.Equal(t, ErrTextRotation, err)
+	assert.NoError(t, f.Close())
+}
+
+func TestFontRoundTrip(t *testing.T) {
+	f := NewFile()
+	charset := 128
+	for _, fnt := range []*Font{
+		{Bold: true, VertAlign: "superscript"},
+		{Size: 9, VertAlign: "Subscript", Condense: true, Extend: true},
+		{Family: "MS Gothic", Charset: &charset},
+		{Family: "Calibri Light", Scheme: "major"},
+		{Italic: true, Scheme: "minor", VertAlign: "baseline"},
+	} {
+		styleID, err := f.NewStyle(&Style{Font: fnt})
+		assert.NoError(t, err)
+		style, err := f.GetStyle(styleID)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(fnt.VertAlign), style.Font.VertAlign)
+		assert.Equal(t, fnt.Charset, style.Font.Charset)
+		assert.Equal(t, fnt.Scheme, style.Font.Scheme)
+		assert.Equal(t, fnt.Condense, style.Font.Condense)
+		assert.Equal(t, fnt.Extend, style.Font.Extend)
+	}
+	// Test create style with invalid font vertical alignment and scheme
+	_, err := f.NewStyle(&Style{Font: &Font{VertAlign: "top"}})
+	assert.Equal(t, ErrFontVertAlign, err)
+	_, err = f.NewStyle(&Style{Font: &Font{Scheme: "body"}})
+	assert.Equal(t, ErrFontScheme, err)
+	assert.NoError(t, f.Close())
+
+	// Test the workbook which relies on the minor font scheme of the theme
+	f, err = OpenFile(filepath.Join("test", "Book1.xlsx"))
+	assert.NoError(t, err)
+	style, err := f.GetStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "minor", style.Font.Scheme)
+	assert.Nil(t, style.Font.Charset)
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true, Scheme: "minor"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	f.Theme.ThemeElements.FontScheme.MinorFont.Latin.Typeface = "Aptos"
+	file := filepath.Join("test", "TestFontRoundTrip.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "Aptos", f.Theme.ThemeElements.FontScheme.MinorFont.Latin.Typeface)
+	style, err = f.GetStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "minor", style.Font.Scheme)
+	styleID, err = f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, "minor", style.Font.Scheme)
+	// Test create style based on the cell style keeps the font scheme
+	style.Font.Italic = true
+	styleID, err = f.NewStyle(style)
+	assert.NoError(t, err)
+	s, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "minor", s.Fonts.Font[*s.CellXfs.Xf[styleID].FontID].Scheme.Value())
+	assert.NoError(t, f.Close())
+}
@@ -42,6 +42,16 @@ func parseTableOptions(opts *Table) (*Table, error) {
 	if err = checkDefinedName(opts.Name); err != nil {
 		return opts, err
 	}
+	for _, column := range opts.Columns {
+		for _, style := range []*Style{column.HeaderRowStyle, column.DataStyle, column.TotalsRowStyle} {
+			if style == nil {
+				continue
+			}
+			if _, err = parseFormatStyleSet(style); err != nil {
+				return opts, err
+			}
+		}
+	}
 	return opts, err
 }
 
@@ -73,11 +83,27 @@ func parseTableOptions(opts *Table) (*Table, error) {
 // be unique, starts with a letter or underscore (_), doesn't include a
 // space or character, and should be no more than 255 characters
 //
-// StyleName: The built-in table style names
+// StyleName: The built-in table style names, or the name of the custom table
+// style created by the NewTableStyle function
 //
 //	TableStyleLight1 - TableStyleLight21
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
+//
+// Columns: The differential formats of the table columns in the order of the
+// columns in the table range, the HeaderRowStyle, DataStyle and
+// TotalsRowStyle specify the format of the header row, data area and totals
+// row of the column, which will be applied on the column region by the
+// spreadsheet application when the table expands. For example, create a table
+// with the currency format on the data area of the second column:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range: "A1:B5",
+//	    Columns: []excelize.TableColumn{
+//	        {},
+//	        {DataStyle: &excelize.Style{NumFmt: 164}},
+//	    },
+//	})
 func (f *File) AddTable(sheet string, table *Table) error {
 	options, err := parseTableOptions(table)
 	if err != nil {
@@ -125,7 +151,10 @@ func (f *File) AddTable(sheet string, table *Table) error {
 }
 
 // GetTables provides the method to get all tables in a worksheet by given
-// worksheet name.
+// worksheet name. The StyleDefinition of the table will be returned if the
+// table uses a custom table style, and the Columns with the resolved
+// differential formats will be returned if any column of the table has the
+// differential formats.
 func (f *File) GetTables(sheet string) ([]Table, error) {
 	var tables []Table
 	ws, err := f.workSheetReader(sheet)
@@ -161,7 +190,9 @@ func (f *File) GetTables(sheet string) ([]Table, error) {
 				table.ShowFirstColumn = t.TableStyleInfo.ShowFirstColumn
 				table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
 				table.ShowRowStripes = &t.TableStyleInfo.ShowRowStripes
+				table.StyleDefinition = f.getTableStyle(table.StyleName)
 			}
+			table.Columns = f.getTableColumnsStyle(t.TableColumns)
 			tables = append(tables, table)
 		}
 	}
@@ -305,7 +336,7 @@ func (f *File) setTableColumns(sheet string, showHeaderRow bool, x1, y1, x2 int,
 		}
 		header = append(header, name)
 		if column := getTableColumn(name); column != nil {
-			column.ID, column.DataDxfID, column.QueryTableFieldID = idx, 0, 0
+			column.ID, column.QueryTableFieldID = idx, 0
 			tableColumns = append(tableColumns, column)
 			continue
 		}
@@ -321,6 +352,65 @@ func (f *File) setTableColumns(sheet string, showHeaderRow bool, x1, y1, x2 int,
 	return nil
 }
 
+// setTableColumnsStyle provides a function to create the differential formats
+// of the table columns by given table columns options.
+func (f *File) setTableColumnsStyle(tableColumns []*xlsxTableColumn, columns []TableColumn) error {
+	for i, column := range columns {
+		if i >= len(tableColumns) {
+			break
+		}
+		for _, dxf := range []struct {
+			style *Style
+			dxfID **int
+		}{
+			{column.HeaderRowStyle, &tableColumns[i].HeaderRowDxfID},
+			{column.DataStyle, &tableColumns[i].DataDxfID},
+			{column.TotalsRowStyle, &tableColumns[i].TotalsRowDxfID},
+		} {
+			if dxf.style == nil {
+				continue
+			}
+			dxfID, err := f.NewConditionalStyle(dxf.style)
+			if err != nil {
+				return err
+			}
+			*dxf.dxfID = intPtr(dxfID)
+		}
+	}
+	return nil
+}
+
+// getTableColumnsStyle provides a function to get the differential formats of
+// the table columns, it returns nil if no column has the differential formats.
+func (f *File) getTableColumnsStyle(tableColumns *xlsxTableColumns) []TableColumn {
+	if tableColumns == nil {
+		return nil
+	}
+	var (
+		columns []TableColumn
+		hasDxf  bool
+		getDxf  = func(dxfID *int) *Style {
+			if dxfID == nil {
+				return nil
+			}
+			hasDxf = true
+			style, _ := f.GetConditionalStyle(*dxfID)
+			return style
+		}
+	)
+	for _, column := range tableColumns.TableColumn {
+		columns = append(columns, TableColumn{
+			HeaderRowStyle: getDxf(column.HeaderRowDxfID),
+			DataStyle:      getDxf(column.DataDxfID),
+			TotalsRowStyle: getDxf(column.TotalsRowDxfID),
+		})
+	}
+	if !hasDxf {
+		return nil
+	}
+	return columns
+}
+
 // checkDefinedName check whether there are illegal characters in the defined
 // name or table name. Verify that the name:
 // 1. Starts with a letter or underscore (_)
@@ -390,6 +480,9 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		},
 	}
 	_ = f.setTableColumns(sheet, !hideHeaderRow, x1, y1, x2, &t)
+	if err = f.setTableColumnsStyle(t.TableColumns.TableColumn, opts.Columns); err != nil {
+		return err
+	}
 	if hideHeaderRow {
 		t.AutoFilter = nil
 		t.HeaderRowCount = intPtr(0)
@@ -108,6 +108,171 @@ func TestGetTables(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNewTableStyle(t *testing.T) {
+	f := NewFile()
+	header := &Style{Font: &Font{Bold: true, Color: "FFFFFF"}, Fill: Fill{Type: "pattern", Color: []string{"1F4E78"}, Pattern: 1}}
+	stripe := &Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}}
+	assert.NoError(t, f.NewTableStyle("CorporateTable", &TableStyleDefinition{
+		Elements: map[string]*Style{"headerRow": header, "firstRowStripe": stripe, "wholeTable": nil},
+	}))
+	assert.NoError(t, f.NewTableStyle("CorporatePivot", &TableStyleDefinition{
+		Pivot: true, Elements: map[string]*Style{"pageFieldLabels": header},
+	}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Name: "Table1", StyleName: "CorporateTable"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:E3", Name: "Table2", StyleName: "TableStyleMedium2"}))
+	// Test create table style with the existing name
+	assert.Equal(t, newExistsTableStyleError("corporateTable"), f.NewTableStyle("corporateTable", &TableStyleDefinition{}))
+	// Test create table style with invalid element type
+	assert.Equal(t, newInvalidTableStyleElementError("unknown"), f.NewTableStyle("Style", &TableStyleDefinition{
+		Elements: map[string]*Style{"unknown": stripe},
+	}))
+	// Test create table style without name or definition
+	assert.Equal(t, ErrParameterRequired, f.NewTableStyle("", &TableStyleDefinition{}))
+	assert.Equal(t, ErrParameterRequired, f.NewTableStyle("Style", nil))
+	// Test create table style with invalid style
+	assert.Equal(t, ErrFontLength, f.NewTableStyle("Style", &TableStyleDefinition{
+		Elements: map[string]*Style{"headerRow": {Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}}},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNewTableStyle.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestNewTableStyle.xlsx"))
+	assert.NoError(t, err)
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, styles.TableStyles.Count)
+	assert.Equal(t, &xlsxTableStyle{Name: "CorporateTable", Count: 2, TableStyleElement: []*xlsxTableStyleElement{
+		{Type: "headerRow", DxfID: intPtr(0)}, {Type: "firstRowStripe", DxfID: intPtr(1)},
+	}}, styles.TableStyles.TableStyles[0])
+	assert.Equal(t, &xlsxTableStyle{Name: "CorporatePivot", Pivot: 1, Count: 1, Table: boolPtr(false), TableStyleElement: []*xlsxTableStyleElement{
+		{Type: "pageFieldLabels", DxfID: intPtr(2)},
+	}}, styles.TableStyles.TableStyles[1])
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	expected := make(map[string]*Style)
+	for typ, dxfID := range map[string]int{"headerRow": 0, "firstRowStripe": 1} {
+		expected[typ], err = f.GetConditionalStyle(dxfID)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, &TableStyleDefinition{Elements: expected}, tables[0].StyleDefinition)
+	assert.True(t, expected["headerRow"].Font.Bold)
+	assert.Nil(t, tables[1].StyleDefinition)
+	assert.True(t, f.getTableStyle("CorporatePivot").Pivot)
+	assert.NoError(t, f.Close())
+
+	// Test create table style with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.NewTableStyle("Style", &TableStyleDefinition{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.Nil(t, f.getTableStyle("Style"))
+	// Test create table style without the table styles in the style sheet
+	f = NewFile()
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	styles.TableStyles = nil
+	assert.NoError(t, f.NewTableStyle("Style", &TableStyleDefinition{}))
+	assert.Equal(t, "TableStyleMedium2", styles.TableStyles.DefaultTableStyle)
+	assert.Equal(t, &TableStyleDefinition{Elements: map[string]*Style{}}, f.getTableStyle("Style"))
+}
+
+func TestTableColumnsStyle(t *testing.T) {
+	f := NewFile()
+	header := &Style{Font: &Font{Bold: true}}
+	data := &Style{Fill: Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1}}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value", "Total"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:C3",
+		Name:  "Table1",
+		Columns: []TableColumn{
+			{HeaderRowStyle: header},
+			{DataStyle: data, TotalsRowStyle: header},
+			{}, {DataStyle: data},
+		},
+	}))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	headerStyle, err := f.GetConditionalStyle(0)
+	assert.NoError(t, err)
+	dataStyle, err := f.GetConditionalStyle(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "DDEBF7", dataStyle.Fill.Color[0])
+	assert.Equal(t, []TableColumn{
+		{HeaderRowStyle: headerStyle},
+		{DataStyle: dataStyle, TotalsRowStyle: headerStyle},
+		{},
+	}, tables[0].Columns)
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `<tableColumn id="1" name="Name" headerRowDxfId="0"></tableColumn><tableColumn id="2" name="Value" dataDxfId="1" totalsRowDxfId="2"></tableColumn>`)
+	// Test the differential formats of the table columns with zero index are
+	// preserved on modifying the table
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 1))
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `<tableColumn id="1" name="Name" headerRowDxfId="0"></tableColumn><tableColumn id="2" name="Value" dataDxfId="1" totalsRowDxfId="2"></tableColumn>`)
+	// Test the table without differential formats
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "E1:F3", Name: "Table2"}))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, tables[1].Columns)
+	// Test add table with invalid columns style
+	assert.Equal(t, ErrFontLength, f.AddTable("Sheet1", &Table{Range: "H1:I3", Columns: []TableColumn{
+		{DataStyle: &Style{Font: &Font{Family: strings.Repeat("s", MaxFontFamilyLength+1)}}},
+	}}))
+	assert.NoError(t, f.Close())
+
+	// Test the table with differential formats authored by the spreadsheet
+	// application survives a re-save
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B3", Name: "Table1"}))
+	tableXML := []byte(`<table xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" id="1" name="Table1" displayName="Table1" ref="A1:B3" headerRowDxfId="0" dataDxfId="0" totalsRowShown="0"><autoFilter ref="A1:B3"/><tableColumns count="2"><tableColumn id="1" name="Name" dataDxfId="0"/><tableColumn id="2" name="Value" headerRowDxfId="0"/></tableColumns><tableStyleInfo name="TableStyleMedium2" showFirstColumn="0" showLastColumn="0" showRowStripes="1" showColumnStripes="0"/></table>`)
+	f.Pkg.Store("xl/tables/table1.xml", tableXML)
+	_, err = f.NewConditionalStyle(data)
+	assert.NoError(t, err)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, tableXML, f.readXML("xl/tables/table1.xml"))
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	dataStyle, err = f.GetConditionalStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []TableColumn{{DataStyle: dataStyle}, {HeaderRowStyle: dataStyle}}, tables[0].Columns)
+	assert.NoError(t, f.InsertRows("Sheet1", 2, 1))
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `ref="A1:B4" totalsRowShown="false" headerRowDxfId="0" dataDxfId="0"`)
+	assert.Contains(t, string(f.readXML("xl/tables/table1.xml")), `<tableColumn id="1" name="Name" dataDxfId="0"></tableColumn><tableColumn id="2" name="Value" headerRowDxfId="0"></tableColumn>`)
+	assert.NoError(t, f.Close())
+
+	// Test add table with differential formats of the table columns by the
+	// stream writer
+	f = NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name", "Value"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"A", 1}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A1:B2", Columns: []TableColumn{{}, {DataStyle: data}}}))
+	assert.NoError(t, sw.Flush())
+	tables, err = f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	dataStyle, err = f.GetConditionalStyle(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []TableColumn{{}, {DataStyle: dataStyle}}, tables[0].Columns)
+	// Test add table with invalid columns style by the stream writer
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	sw, err = f.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"Name", "Value"}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"A", 1}))
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, sw.AddTable(&Table{Range: "A1:B2", Columns: []TableColumn{{DataStyle: data}}}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteTable(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B4", Name: "Table1"}))
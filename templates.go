@@ -24,6 +24,8 @@ var (
 	NameSpaceDrawingML                      = xml.Attr{Name: xml.Name{Local: "a", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/main"}
 	NameSpaceDrawingMLA14                   = xml.Attr{Name: xml.Name{Local: "a14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/main"}
 	NameSpaceDrawingMLChart                 = xml.Attr{Name: xml.Name{Local: "c", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/chart"}
+	NameSpaceDrawingMLChartEx               = xml.Attr{Name: xml.Name{Local: "cx", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2014/chartex"}
+	NameSpaceDrawingMLChartEx1              = xml.Attr{Name: xml.Name{Local: "cx1", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2015/9/8/chartex"}
 	NameSpaceDrawingMLSlicer                = xml.Attr{Name: xml.Name{Local: "sle", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2010/slicer"}
 	NameSpaceDrawingMLSlicerX15             = xml.Attr{Name: xml.Name{Local: "sle15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2012/slicer"}
 	NameSpaceDrawingMLSpreadSheet           = xml.Attr{Name: xml.Name{Local: "xdr", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing"}
@@ -32,6 +34,7 @@ var (
 	NameSpaceSpreadSheetExcel2006Main       = xml.Attr{Name: xml.Name{Local: "xne", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/excel/2006/main"}
 	NameSpaceSpreadSheetX14                 = xml.Attr{Name: xml.Name{Local: "x14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"}
 	NameSpaceSpreadSheetX15                 = xml.Attr{Name: xml.Name{Local: "x15", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2010/11/main"}
+	NameSpaceSpreadSheetXLRD                = xml.Attr{Name: xml.Name{Local: "xlrd", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"}
 	NameSpaceSpreadSheetXR10                = xml.Attr{Name: xml.Name{Local: "xr10", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/spreadsheetml/2016/revision10"}
 	SourceRelationship                      = xml.Attr{Name: xml.Name{Local: "r", Space: "xmlns"}, Value: "http://schemas.openxmlformats.org/officeDocument/2006/relationships"}
 	SourceRelationshipChart20070802         = xml.Attr{Name: xml.Name{Local: "c14", Space: "xmlns"}, Value: "http://schemas.microsoft.com/office/drawing/2007/8/2/chart"}
@@ -43,15 +46,19 @@ var (
 // Source relationship and namespace.
 const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
+	ContentTypeChartEx                            = "application/vnd.ms-office.chartex+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
+	ContentTypeRdRichValue                        = "application/vnd.ms-excel.rdrichvalue+xml"
+	ContentTypeRdRichValueStructure               = "application/vnd.ms-excel.rdrichvaluestructure+xml"
 	ContentTypeRelationships                      = "application/vnd.openxmlformats-package.relationships+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
 	ContentTypeSlicer                             = "application/vnd.ms-excel.slicer+xml"
 	ContentTypeSlicerCache                        = "application/vnd.ms-excel.slicerCache+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
+	ContentTypeSpreadSheetMLMetadata              = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
@@ -61,6 +68,10 @@ const (
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	ContentTypeXLSBSharedStrings                  = "application/vnd.ms-excel.sharedStrings"
+	ContentTypeXLSBStyles                         = "application/vnd.ms-excel.styles"
+	ContentTypeXLSBWorkbook                       = "application/vnd.ms-excel.sheet.binary.macroEnabled.main"
+	ContentTypeXLSBWorksheet                      = "application/vnd.ms-excel.worksheet"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
@@ -69,6 +80,7 @@ const (
 	NameSpaceXML                                  = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                    = "http://www.w3.org/2001/XMLSchema-instance"
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	SourceRelationshipChartEx                     = "http://schemas.microsoft.com/office/2014/relationships/chartEx"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
@@ -80,9 +92,13 @@ const (
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
+	SourceRelationshipRdRichValue                 = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValue"
+	SourceRelationshipRdRichValueStructure        = "http://schemas.microsoft.com/office/2017/06/relationships/rdRichValueStructure"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipSheetMetadata               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
 	SourceRelationshipSlicer                      = "http://schemas.microsoft.com/office/2007/relationships/slicer"
 	SourceRelationshipSlicerCache                 = "http://schemas.microsoft.com/office/2007/relationships/slicerCache"
+	SourceRelationshipStyles                      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
@@ -118,6 +134,7 @@ const (
 	ExtURIPivotHierarchy                 = "{F1805F06-0CD304483-9156-8803C3D141DF}"
 	ExtURIPivotTableReferences           = "{983426D0-5260-488c-9760-48F4B6AC55F4}"
 	ExtURIProtectedRanges                = "{FC87AEE6-9EDD-4A0A-B7FB-166176984837}"
+	ExtURIRichValueBlock                 = "{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"
 	ExtURISlicerCacheDefinition          = "{2F2917AC-EB37-4324-AD4E-5DD8C200BD13}"
 	ExtURISlicerCacheHideItemsWithNoData = "{470722E0-AACD-4C17-9CDC-17EF765DBC7E}"
 	ExtURISlicerCachesX14                = "{BBE1A952-AA13-448e-AADC-164F8A28A991}"
@@ -175,6 +192,7 @@ const (
 	MaxFormControlValue  = 30000
 	MaxFontFamilyLength  = 31
 	MaxFontSize          = 409
+	MaxIndent            = 250
 	MaxRowHeight         = 409
 	MaxSheetNameLength   = 31
 	MinColumns           = 1
@@ -191,14 +209,20 @@ const (
 	pivotTableVersion           = 3
 	pivotTableRefreshedVersion  = 8
 	defaultDrawingScale         = 1.0
+	defaultImageDPI             = 96.0
 	defaultChartDimensionWidth  = 480
 	defaultChartDimensionHeight = 260
+	defaultChartSheetMarginLR   = 0.7
+	defaultChartSheetMarginTB   = 0.75
+	defaultChartSheetMarginHF   = 0.3
 	defaultSlicerWidth          = 200
 	defaultSlicerHeight         = 200
 	defaultChartLegendPosition  = "bottom"
 	defaultChartShowBlanksAs    = "gap"
+	defaultChartDateAxisNumFmt  = "m/d/yyyy"
 	defaultShapeSize            = 160
 	defaultShapeLineWidth       = 1
+	lastRowPlaceholder          = "{LASTROW}"
 )
 
 // ColorMappingType is the type of color transformation.
@@ -252,6 +276,9 @@ var chartDataLabelsPositionTypes = map[ChartDataLabelPositionType]string{
 	ChartDataLabelsPositionAbove:      "t",
 }
 
+// supportedPaneTypes defined supported pane types of the worksheet view.
+var supportedPaneTypes = []string{"bottomLeft", "bottomRight", "topLeft", "topRight"}
+
 // supportedChartDataLabelsPosition defined supported chart data labels position
 // types for each type of chart.
 var supportedChartDataLabelsPosition = map[ChartType][]ChartDataLabelPositionType{
@@ -287,6 +314,7 @@ const (
 	defaultXMLRdRichValuePart             = "xl/richData/rdrichvalue.xml"
 	defaultXMLRdRichValueRel              = "xl/richData/richValueRel.xml"
 	defaultXMLRdRichValueRelRels          = "xl/richData/_rels/richValueRel.xml.rels"
+	defaultXMLRdRichValueStructure        = "xl/richData/rdrichvaluestructure.xml"
 	defaultXMLRdRichValueWebImagePart     = "xl/richData/rdRichValueWebImage.xml"
 	defaultXMLRdRichValueWebImagePartRels = "xl/richData/_rels/rdRichValueWebImage.xml.rels"
 )
@@ -488,6 +516,12 @@ var supportedContentTypes = map[string]string{
 // supportedUnderlineTypes defined supported underline types.
 var supportedUnderlineTypes = []string{"none", "single", "double"}
 
+// supportedFontVertAlign defined supported font vertical alignment types.
+var supportedFontVertAlign = []string{"baseline", "superscript", "subscript"}
+
+// supportedFontSchemes defined supported font schemes.
+var supportedFontSchemes = []string{"none", "major", "minor"}
+
 // supportedDrawingUnderlineTypes defined supported underline types in drawing
 // markup language.
 var supportedDrawingUnderlineTypes = []string{
@@ -499,12 +533,71 @@ var supportedDrawingUnderlineTypes = []string{
 // drawing markup language.
 var supportedDrawingTextVerticalType = []string{"horz", "vert", "vert270", "wordArtVert", "eaVert", "mongolianVert", "wordArtVertRtl"}
 
+// supportedDrawingTextAlignment defined supported paragraph horizontal
+// alignment types and the text alignment types in drawing markup language.
+var supportedDrawingTextAlignment = map[string]string{
+	"left": "l", "center": "ctr", "right": "r", "justify": "just", "distributed": "dist",
+}
+
+// supportedDrawingTextAnchor defined supported text body vertical alignment
+// types and the text anchoring types in drawing markup language.
+var supportedDrawingTextAnchor = map[string]string{
+	"top": "t", "center": "ctr", "bottom": "b", "justify": "just", "distributed": "dist",
+}
+
+// supportedDrawingTextBullet defined supported paragraph bullet types in
+// drawing markup language.
+var supportedDrawingTextBullet = []string{"none", "char", "autoNum"}
+
+// supportedDrawingTextAutofit defined supported text body autofit types in
+// drawing markup language.
+var supportedDrawingTextAutofit = []string{"none", "shrink", "resize"}
+
+// supportedDrawingLineDash defined supported preset line dash types in
+// drawing markup language.
+var supportedDrawingLineDash = []string{
+	"solid", "dot", "dash", "lgDash", "dashDot", "lgDashDot", "lgDashDotDot", "sysDash", "sysDot", "sysDashDot", "sysDashDotDot",
+}
+
+// supportedConnectorTypes defined supported connector types and the preset
+// geometry of the connectors in drawing markup language.
+var supportedConnectorTypes = map[string]string{
+	"straight": "straightConnector1", "bent": "bentConnector3", "curved": "curvedConnector3",
+}
+
+// supportedConnectorArrowTypes defined supported line end types of the
+// connectors in drawing markup language.
+var supportedConnectorArrowTypes = []string{"none", "triangle", "stealth", "diamond", "oval", "arrow"}
+
+// supportedConnectorArrowSizes defined supported line end width and length
+// types of the connectors in drawing markup language.
+var supportedConnectorArrowSizes = []string{"sm", "med", "lg"}
+
 // supportedPositioning defined supported positioning types.
 var supportedPositioning = []string{"absolute", "oneCell", "twoCell"}
 
 // supportedPageOrientation defined supported page setup page orientation.
 var supportedPageOrientation = []string{"portrait", "landscape"}
 
+// chartSheetPaperSizes defined the width and height in inches of the portrait
+// paper sizes which used for sizing the chart to the printable area of the
+// chartsheet, the letter paper size will be used for the others.
+var chartSheetPaperSizes = map[int][2]float64{
+	1:  {8.5, 11},
+	2:  {8.5, 11},
+	3:  {11, 17},
+	4:  {17, 11},
+	5:  {8.5, 14},
+	6:  {5.5, 8.5},
+	7:  {7.25, 10.5},
+	8:  {11.69, 16.54},
+	9:  {8.27, 11.69},
+	10: {8.27, 11.69},
+	11: {5.83, 8.27},
+	12: {9.84, 13.9},
+	13: {6.93, 9.84},
+}
+
 // supportedPageOrder defined supported page setup page order.
 var supportedPageOrder = []string{"overThenDown", "downThenOver"}
 
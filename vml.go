@@ -470,7 +470,10 @@ func (f *File) DeleteFormControl(sheet, cell string) error {
 }
 
 // countVMLDrawing provides a function to get VML drawing files count storage
-// in the folder xl/drawings.
+// in the folder xl/drawings. The largest number in the VML drawing part names
+// will be returned if it's greater than the count, so that the new VML
+// drawing part will not overwrite the existing part after some parts have
+// been deleted.
 func (f *File) countVMLDrawing() int {
 	drawings := map[string]struct{}{}
 	f.Pkg.Range(func(k, v interface{}) bool {
@@ -484,7 +487,13 @@ func (f *File) countVMLDrawing() int {
 			drawings[rel] = struct{}{}
 		}
 	}
-	return len(drawings)
+	count := len(drawings)
+	for drawing := range drawings {
+		if ID, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(drawing, "xl/drawings/vmlDrawing"), ".vml")); err == nil && ID > count {
+			count = ID
+		}
+	}
+	return count
 }
 
 // decodeVMLDrawingReader provides a function to get the pointer to the
@@ -1087,12 +1096,40 @@ func extractVMLFont(font []decodeVMLFont) []RichTextRun {
 // types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ.
 //
 // The extension should be provided with a "." in front, e.g. ".png".
-// The width and height should have units in them, e.g. "100pt".
+// The width and height should have units in them, e.g. "100pt", the number
+// without unit will be used as the points, e.g. "100" is equal to "100pt".
+//
+// The image of the each position is identified by the "Position", "IsFooter",
+// "FirstPage" and "EvenPage", the existing image in the same position will be
+// replaced. Set the "FirstPage" or "EvenPage" to add the image for the first
+// page or the even pages header and footer, which are used when the
+// "DifferentFirst" or "DifferentOddEven" of the header and footer options is
+// enabled, and they can't be set together. The header and footer definitions
+// must contain &G in the same position for showing the image. For example,
+// add a logo in the left of the header:
+//
+//	err := f.SetHeaderFooter("Sheet1", &excelize.HeaderFooterOptions{
+//	    OddHeader: "&L&G&CMonthly Report",
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.AddHeaderFooterImage("Sheet1", &excelize.HeaderFooterImageOptions{
+//	    Position:  excelize.HeaderFooterImagePositionLeft,
+//	    File:      logo,
+//	    Extension: ".png",
+//	    Width:     "50",
+//	    Height:    "32",
+//	})
 func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if opts == nil || (opts.FirstPage && opts.EvenPage) {
+		return ErrParameterInvalid
+	}
 	ext, ok := supportedImageTypes[strings.ToLower(opts.Extension)]
 	if !ok {
 		return ErrImgExt
@@ -1114,93 +1151,29 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 		f.addSheetNameSpace(sheet, SourceRelationship)
 		f.addSheetLegacyDrawingHF(sheet, rID)
 	}
-
-	shapeID := map[HeaderFooterImagePositionType]string{
-		HeaderFooterImagePositionLeft:   "L",
-		HeaderFooterImagePositionCenter: "C",
-		HeaderFooterImagePositionRight:  "R",
-	}[opts.Position] +
-		map[bool]string{false: "H", true: "F"}[opts.IsFooter] +
-		map[bool]string{false: "", true: "FIRST"}[opts.FirstPage]
-	vml := f.VMLDrawing[drawingVML]
-	if vml == nil {
-		vml = &vmlDrawing{
-			XMLNSv: "urn:schemas-microsoft-com:vml",
-			XMLNSo: "urn:schemas-microsoft-com:office:office",
-			XMLNSx: "urn:schemas-microsoft-com:office:excel",
-			ShapeLayout: &xlsxShapeLayout{
-				Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: sheetID},
-			},
-			ShapeType: &xlsxShapeType{
-				ID:             "_x0000_t75",
-				CoordSize:      "21600,21600",
-				Spt:            75,
-				PreferRelative: "t",
-				Path:           "m@4@5l@4@11@9@11@9@5xe",
-				Filled:         "f",
-				Stroked:        "f",
-				Stroke:         &xlsxStroke{JoinStyle: "miter"},
-				VFormulas: &vFormulas{
-					Formulas: []vFormula{
-						{Equation: "if lineDrawn pixelLineWidth 0"},
-						{Equation: "sum @0 1 0"},
-						{Equation: "sum 0 0 @1"},
-						{Equation: "prod @2 1 2"},
-						{Equation: "prod @3 21600 pixelWidth"},
-						{Equation: "prod @3 21600 pixelHeight"},
-						{Equation: "sum @0 0 1"},
-						{Equation: "prod @6 1 2"},
-						{Equation: "prod @7 21600 pixelWidth"},
-						{Equation: "sum @8 21600 0"},
-						{Equation: "prod @7 21600 pixelHeight"},
-						{Equation: "sum @10 21600 0"},
-					},
-				},
-				VPath: &vPath{ExtrusionOK: "f", GradientShapeOK: "t", ConnectType: "rect"},
-				Lock:  &oLock{Ext: "edit", AspectRatio: "t"},
-			},
-		}
-		// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
-		d, err := f.decodeVMLDrawingReader(drawingVML)
-		if err != nil {
-			return err
-		}
-		if d != nil {
-			vml.ShapeType.ID = d.ShapeType.ID
-			vml.ShapeType.CoordSize = d.ShapeType.CoordSize
-			vml.ShapeType.Spt = d.ShapeType.Spt
-			vml.ShapeType.PreferRelative = d.ShapeType.PreferRelative
-			vml.ShapeType.Path = d.ShapeType.Path
-			vml.ShapeType.Filled = d.ShapeType.Filled
-			vml.ShapeType.Stroked = d.ShapeType.Stroked
-			for _, v := range d.Shape {
-				s := xlsxShape{
-					ID:    v.ID,
-					SpID:  v.SpID,
-					Type:  v.Type,
-					Style: v.Style,
-					Val:   v.Val,
-				}
-				vml.Shape = append(vml.Shape, s)
-			}
-		}
+	vml, err := f.headerFooterVMLReader(drawingVML, sheetID)
+	if err != nil {
+		return err
 	}
-
+	shapeID := getHeaderFooterImageShapeID(opts)
+	drawingVMLRels := "xl/drawings/_rels/vmlDrawing" + strconv.Itoa(vmlID) + ".vml.rels"
 	for idx, shape := range vml.Shape {
 		if shape.ID == shapeID {
 			vml.Shape = append(vml.Shape[:idx], vml.Shape[idx+1:]...)
+			f.deleteHeaderFooterImageRels(drawingVMLRels, shape.Val)
+			break
 		}
 	}
 
-	style := fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%s;height:%s;z-index:1", opts.Width, opts.Height)
-	drawingVMLRels := "xl/drawings/_rels/vmlDrawing" + strconv.Itoa(vmlID) + ".vml.rels"
+	style := fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%s;height:%s;z-index:1",
+		getHeaderFooterImageSize(opts.Width), getHeaderFooterImageSize(opts.Height))
 
 	mediaStr := ".." + strings.TrimPrefix(f.addMedia(opts.File, ext), "xl")
 	imageID := f.addRels(drawingVMLRels, SourceRelationshipImage, mediaStr, "")
 
 	shape := xlsxShape{
 		ID:    shapeID,
-		SpID:  "_x0000_s1025",
+		SpID:  getHeaderFooterImageSpID(vml),
 		Type:  "#_x0000_t75",
 		Style: style,
 	}
@@ -1218,3 +1191,182 @@ func (f *File) AddHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions
 	}
 	return f.setContentTypePartVMLExtensions()
 }
+
+// DeleteHeaderFooterImage provides a function to delete the header or footer
+// image by given worksheet name and the image position settings, which are
+// the "Position", "IsFooter", "FirstPage" and "EvenPage" of the header and
+// footer image options. The VML drawing part of the header and footer will be
+// removed from the worksheet if no images left, and the image file will be
+// deleted from the workbook if it's no longer used. For example, delete the
+// image in the center of the footer on Sheet1:
+//
+//	err := f.DeleteHeaderFooterImage("Sheet1", &excelize.HeaderFooterImageOptions{
+//	    Position: excelize.HeaderFooterImagePositionCenter,
+//	    IsFooter: true,
+//	})
+func (f *File) DeleteHeaderFooterImage(sheet string, opts *HeaderFooterImageOptions) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if opts == nil || (opts.FirstPage && opts.EvenPage) {
+		return ErrParameterInvalid
+	}
+	if ws.LegacyDrawingHF == nil {
+		return err
+	}
+	drawingVML := strings.TrimPrefix(strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawingHF.RID), "..", "xl"), "/")
+	drawingVMLRels := "xl/drawings/_rels/" + filepath.Base(drawingVML) + ".rels"
+	vml, err := f.headerFooterVMLReader(drawingVML, f.getSheetID(sheet))
+	if err != nil {
+		return err
+	}
+	shapeID := getHeaderFooterImageShapeID(opts)
+	for idx, shape := range vml.Shape {
+		if shape.ID == shapeID {
+			vml.Shape = append(vml.Shape[:idx], vml.Shape[idx+1:]...)
+			f.deleteHeaderFooterImageRels(drawingVMLRels, shape.Val)
+			break
+		}
+	}
+	if len(vml.Shape) > 0 {
+		f.VMLDrawing[drawingVML] = vml
+		return err
+	}
+	f.deleteSheetRelationships(sheet, ws.LegacyDrawingHF.RID)
+	ws.LegacyDrawingHF = nil
+	delete(f.VMLDrawing, drawingVML)
+	delete(f.DecodeVMLDrawing, drawingVML)
+	f.Relationships.Delete(drawingVMLRels)
+	f.Pkg.Delete(drawingVMLRels)
+	f.Pkg.Delete(drawingVML)
+	return err
+}
+
+// headerFooterVMLReader provides a function to get the VML drawing of the
+// header and footer images by given VML drawing part path and sheet ID, the
+// existing shapes in the VML drawing part will be loaded.
+func (f *File) headerFooterVMLReader(drawingVML string, sheetID int) (*vmlDrawing, error) {
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return vml, nil
+	}
+	vml := &vmlDrawing{
+		XMLNSv: "urn:schemas-microsoft-com:vml",
+		XMLNSo: "urn:schemas-microsoft-com:office:office",
+		XMLNSx: "urn:schemas-microsoft-com:office:excel",
+		ShapeLayout: &xlsxShapeLayout{
+			Ext: "edit", IDmap: &xlsxIDmap{Ext: "edit", Data: sheetID},
+		},
+		ShapeType: &xlsxShapeType{
+			ID:             "_x0000_t75",
+			CoordSize:      "21600,21600",
+			Spt:            75,
+			PreferRelative: "t",
+			Path:           "m@4@5l@4@11@9@11@9@5xe",
+			Filled:         "f",
+			Stroked:        "f",
+			Stroke:         &xlsxStroke{JoinStyle: "miter"},
+			VFormulas: &vFormulas{
+				Formulas: []vFormula{
+					{Equation: "if lineDrawn pixelLineWidth 0"},
+					{Equation: "sum @0 1 0"},
+					{Equation: "sum 0 0 @1"},
+					{Equation: "prod @2 1 2"},
+					{Equation: "prod @3 21600 pixelWidth"},
+					{Equation: "prod @3 21600 pixelHeight"},
+					{Equation: "sum @0 0 1"},
+					{Equation: "prod @6 1 2"},
+					{Equation: "prod @7 21600 pixelWidth"},
+					{Equation: "sum @8 21600 0"},
+					{Equation: "prod @7 21600 pixelHeight"},
+					{Equation: "sum @10 21600 0"},
+				},
+			},
+			VPath: &vPath{ExtrusionOK: "f", GradientShapeOK: "t", ConnectType: "rect"},
+			Lock:  &oLock{Ext: "edit", AspectRatio: "t"},
+		},
+	}
+	// Load exist VML shapes from xl/drawings/vmlDrawing%d.vml
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		vml.ShapeType.ID = d.ShapeType.ID
+		vml.ShapeType.CoordSize = d.ShapeType.CoordSize
+		vml.ShapeType.Spt = d.ShapeType.Spt
+		vml.ShapeType.PreferRelative = d.ShapeType.PreferRelative
+		vml.ShapeType.Path = d.ShapeType.Path
+		vml.ShapeType.Filled = d.ShapeType.Filled
+		vml.ShapeType.Stroked = d.ShapeType.Stroked
+		for _, v := range d.Shape {
+			s := xlsxShape{
+				ID:    v.ID,
+				SpID:  v.SpID,
+				Type:  v.Type,
+				Style: v.Style,
+				Val:   v.Val,
+			}
+			vml.Shape = append(vml.Shape, s)
+		}
+	}
+	return vml, nil
+}
+
+// deleteHeaderFooterImageRels provides a function to delete the image
+// relationship of the header and footer image by given VML drawing
+// relationships part path and the inner content of the VML shape, the image
+// file will be deleted if it's no longer used.
+func (f *File) deleteHeaderFooterImageRels(drawingVMLRels, val string) {
+	var shapeVal decodeShapeVal
+	if err := f.xmlNewDecoder(strings.NewReader("<shape>" + val + "</shape>")).Decode(&shapeVal); err != nil || shapeVal.ImageData == nil {
+		return
+	}
+	rel := f.getDrawingRelationships(drawingVMLRels, shapeVal.ImageData.RelID)
+	if rel == nil {
+		return
+	}
+	f.deleteDrawingRels(drawingVMLRels, rel.ID)
+	f.deleteUnusedMedia(strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/"))
+}
+
+// getHeaderFooterImageShapeID provides a function to get the VML shape ID of
+// the header and footer image by given image position settings, such as "LH"
+// for the left header image, and "CFFIRST" for the center footer image of the
+// first page.
+func getHeaderFooterImageShapeID(opts *HeaderFooterImageOptions) string {
+	return map[HeaderFooterImagePositionType]string{
+		HeaderFooterImagePositionLeft:   "L",
+		HeaderFooterImagePositionCenter: "C",
+		HeaderFooterImagePositionRight:  "R",
+	}[opts.Position] +
+		map[bool]string{false: "H", true: "F"}[opts.IsFooter] +
+		map[bool]string{false: "", true: "FIRST"}[opts.FirstPage] +
+		map[bool]string{false: "", true: "EVEN"}[opts.EvenPage]
+}
+
+// getHeaderFooterImageSpID provides a function to get an unused shape ID of
+// the VML drawing for the header and footer image, the shape IDs start from
+// the 1024 times of the drawing ID in the shape layout.
+func getHeaderFooterImageSpID(vml *vmlDrawing) string {
+	spID := 1024
+	if vml.ShapeLayout != nil && vml.ShapeLayout.IDmap != nil && vml.ShapeLayout.IDmap.Data > 0 {
+		spID = vml.ShapeLayout.IDmap.Data * 1024
+	}
+	for _, shape := range vml.Shape {
+		if ID, err := strconv.Atoi(strings.TrimPrefix(shape.SpID, "_x0000_s")); err == nil && ID > spID {
+			spID = ID
+		}
+	}
+	return "_x0000_s" + strconv.Itoa(spID+1)
+}
+
+// getHeaderFooterImageSize provides a function to get the size of the header
+// and footer image in the VML shape style, the number without unit will be
+// used as the points.
+func getHeaderFooterImageSize(size string) string {
+	if _, err := strconv.ParseFloat(size, 64); err == nil {
+		return size + "pt"
+	}
+	return size
+}
@@ -222,6 +222,14 @@ type decodeShape struct {
 type decodeShapeVal struct {
 	TextBox    decodeVMLTextBox    `xml:"textbox"`
 	ClientData decodeVMLClientData `xml:"ClientData"`
+	ImageData  *decodeVMLImageData `xml:"imagedata"`
+}
+
+// decodeVMLImageData defines the structure used to parse the imagedata element
+// in the VML.
+type decodeVMLImageData struct {
+	RelID string `xml:"relid,attr"`
+	Title string `xml:"title,attr"`
 }
 
 // decodeVMLFontU defines the structure used to parse the u element in the VML.
@@ -346,6 +354,7 @@ type HeaderFooterImageOptions struct {
 	File      []byte
 	IsFooter  bool
 	FirstPage bool
+	EvenPage  bool
 	Extension string
 	Width     string
 	Height    string
@@ -509,3 +509,98 @@ func TestAddHeaderFooterImage(t *testing.T) {
 	}), "XML syntax error on line 1: invalid UTF-8")
 	assert.NoError(t, f.Close())
 }
+
+func TestDeleteHeaderFooterImage(t *testing.T) {
+	f, sheet, wb := NewFile(), "Sheet1", filepath.Join("test", "TestDeleteHeaderFooterImage.xlsx")
+	assert.NoError(t, f.SetHeaderFooter(sheet, &HeaderFooterOptions{
+		DifferentOddEven: true,
+		OddHeader:        "&L&G",
+		OddFooter:        "&C&G",
+		EvenHeader:       "&R&G",
+	}))
+	images := map[string][]byte{".png": nil, ".jpg": nil, ".gif": nil}
+	for ext := range images {
+		img, err := os.ReadFile(filepath.Join("test", "images", "excel"+ext))
+		assert.NoError(t, err)
+		images[ext] = img
+	}
+	for _, opts := range []*HeaderFooterImageOptions{
+		{Position: HeaderFooterImagePositionLeft, File: images[".png"], Extension: ".png", Width: "50", Height: "32"},
+		{Position: HeaderFooterImagePositionCenter, File: images[".jpg"], Extension: ".jpg", IsFooter: true, Width: "50pt", Height: "32pt"},
+		{Position: HeaderFooterImagePositionRight, File: images[".gif"], Extension: ".gif", EvenPage: true, Width: "0.5in", Height: "0.3in"},
+	} {
+		assert.NoError(t, f.AddHeaderFooterImage(sheet, opts))
+	}
+	vml := f.VMLDrawing["xl/drawings/vmlDrawing1.vml"]
+	if assert.Len(t, vml.Shape, 3) {
+		for i, expected := range []struct{ ID, SpID, Style string }{
+			{ID: "LH", SpID: "_x0000_s1025", Style: "position:absolute;margin-left:0;margin-top:0;width:50pt;height:32pt;z-index:1"},
+			{ID: "CF", SpID: "_x0000_s1026", Style: "position:absolute;margin-left:0;margin-top:0;width:50pt;height:32pt;z-index:1"},
+			{ID: "RHEVEN", SpID: "_x0000_s1027", Style: "position:absolute;margin-left:0;margin-top:0;width:0.5in;height:0.3in;z-index:1"},
+		} {
+			assert.Equal(t, expected.ID, vml.Shape[i].ID)
+			assert.Equal(t, expected.SpID, vml.Shape[i].SpID)
+			assert.Equal(t, expected.Style, vml.Shape[i].Style)
+		}
+	}
+	// Test add and delete header footer image with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage(sheet, nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddHeaderFooterImage(sheet, &HeaderFooterImageOptions{FirstPage: true, EvenPage: true}))
+	assert.Equal(t, ErrParameterInvalid, f.DeleteHeaderFooterImage(sheet, nil))
+	assert.Equal(t, ErrParameterInvalid, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{FirstPage: true, EvenPage: true}))
+	// Test delete header footer image on not exists worksheet
+	assert.EqualError(t, f.DeleteHeaderFooterImage("SheetN", &HeaderFooterImageOptions{}), "sheet SheetN does not exist")
+
+	// Test delete the center footer image and the unused image file
+	assert.NoError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{Position: HeaderFooterImagePositionCenter, IsFooter: true}))
+	_, ok := f.Pkg.Load("xl/media/image2.jpg")
+	assert.False(t, ok)
+	rels, err := f.relsReader("xl/drawings/_rels/vmlDrawing1.vml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 2)
+	// Test delete the image which not exists in the position
+	assert.NoError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{Position: HeaderFooterImagePositionCenter}))
+	assert.NoError(t, f.SaveAs(wb))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(wb)
+	assert.NoError(t, err)
+	pics, err := f.GetSheetPictures(sheet, SheetPicturesOptions{HeaderFooter: true})
+	assert.NoError(t, err)
+	if assert.Len(t, pics, 2) {
+		assert.Equal(t, "LH", pics[0].Anchor.Name)
+		assert.Equal(t, "RHEVEN", pics[1].Anchor.Name)
+	}
+	// Test delete all header footer images and the VML drawing part
+	assert.NoError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{Position: HeaderFooterImagePositionLeft}))
+	assert.NoError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{Position: HeaderFooterImagePositionRight, EvenPage: true}))
+	ws, err := f.workSheetReader(sheet)
+	assert.NoError(t, err)
+	assert.Nil(t, ws.LegacyDrawingHF)
+	_, ok = f.Pkg.Load("xl/drawings/vmlDrawing1.vml")
+	assert.False(t, ok)
+	assert.Empty(t, f.getSheetRelationshipsTargetByID(sheet, "rId1"))
+	assert.NoError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{}))
+	assert.NoError(t, f.SaveAs(wb))
+	assert.NoError(t, f.Close())
+
+	// Test add header footer image after deleting the VML drawing part
+	f = NewFile()
+	assert.NoError(t, f.AddHeaderFooterImage(sheet, &HeaderFooterImageOptions{File: images[".png"], Extension: ".png", Width: "50", Height: "32"}))
+	assert.NoError(t, f.AddFormControl(sheet, FormControl{Cell: "A1", Type: FormControlButton, Macro: "Button1_Click", Text: "Button 1"}))
+	assert.NoError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{}))
+	assert.NoError(t, f.AddHeaderFooterImage(sheet, &HeaderFooterImageOptions{File: images[".png"], Extension: ".png", Width: "50", Height: "32"}))
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing2.vml"].Shape, 1)
+	assert.Len(t, f.VMLDrawing["xl/drawings/vmlDrawing3.vml"].Shape, 1)
+	formControls, err := f.GetFormControls(sheet)
+	assert.NoError(t, err)
+	assert.Len(t, formControls, 1)
+	assert.NoError(t, f.Close())
+
+	// Test delete header footer image with unsupported charset VML drawing
+	f, err = OpenFile(filepath.Join("test", "TestAddHeaderFooterImage.xlsx"))
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteHeaderFooterImage(sheet, &HeaderFooterImageOptions{}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -59,6 +59,90 @@ func (f *File) GetWorkbookProps() (WorkbookPropsOptions, error) {
 	return opts, err
 }
 
+// SetWorkbookView provides a function to set the first workbook view of the
+// workbook by given view settings, the workbook view specifies the active
+// sheet, the first visible sheet tab, the position and the size of the
+// window. The settings are stored in the workbook part, so they can be used
+// for the workbook built by the stream writer, and that wouldn't be affected
+// by flushing the stream writer. For example, set the third worksheet as the
+// active sheet with the window size of 1920 x 1080 pixels:
+//
+//	activeTab, width, height := 2, 28800, 16200
+//	err := f.SetWorkbookView(&excelize.WorkbookViewOptions{
+//	    ActiveTab:    &activeTab,
+//	    WindowWidth:  &width,
+//	    WindowHeight: &height,
+//	})
+//
+// Note that the selected state of the sheet tab of the worksheet created by
+// stream writer will be written with the first row, so please set the active
+// sheet before writing rows with the stream writer.
+func (f *File) SetWorkbookView(opts *WorkbookViewOptions) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		return err
+	}
+	for _, idx := range []*int{opts.ActiveTab, opts.FirstSheet} {
+		if idx != nil && (*idx < 0 || *idx >= len(wb.Sheets.Sheet)) {
+			return ErrSheetIdx
+		}
+	}
+	for _, size := range []*int{opts.WindowWidth, opts.WindowHeight} {
+		if size != nil && *size < 0 {
+			return ErrParameterInvalid
+		}
+	}
+	if wb.BookViews == nil {
+		wb.BookViews = &xlsxBookViews{}
+	}
+	if len(wb.BookViews.WorkBookView) == 0 {
+		wb.BookViews.WorkBookView = append(wb.BookViews.WorkBookView, xlsxWorkBookView{})
+	}
+	view := &wb.BookViews.WorkBookView[0]
+	if opts.FirstSheet != nil {
+		view.FirstSheet = *opts.FirstSheet
+	}
+	if opts.XWindow != nil {
+		view.XWindow = strconv.Itoa(*opts.XWindow)
+	}
+	if opts.YWindow != nil {
+		view.YWindow = strconv.Itoa(*opts.YWindow)
+	}
+	if opts.WindowWidth != nil {
+		view.WindowWidth = *opts.WindowWidth
+	}
+	if opts.WindowHeight != nil {
+		view.WindowHeight = *opts.WindowHeight
+	}
+	if opts.ActiveTab != nil {
+		f.SetActiveSheet(*opts.ActiveTab)
+	}
+	return err
+}
+
+// GetWorkbookView provides a function to get the settings of the first
+// workbook view of the workbook.
+func (f *File) GetWorkbookView() (WorkbookViewOptions, error) {
+	var opts WorkbookViewOptions
+	wb, err := f.workbookReader()
+	if err != nil {
+		return opts, err
+	}
+	var view xlsxWorkBookView
+	if wb.BookViews != nil && len(wb.BookViews.WorkBookView) > 0 {
+		view = wb.BookViews.WorkBookView[0]
+	}
+	xWindow, _ := strconv.Atoi(view.XWindow)
+	yWindow, _ := strconv.Atoi(view.YWindow)
+	opts.ActiveTab, opts.FirstSheet = intPtr(view.ActiveTab), intPtr(view.FirstSheet)
+	opts.XWindow, opts.YWindow = intPtr(xWindow), intPtr(yWindow)
+	opts.WindowWidth, opts.WindowHeight = intPtr(view.WindowWidth), intPtr(view.WindowHeight)
+	return opts, err
+}
+
 // ProtectWorkbook provides a function to prevent other users from viewing
 // hidden worksheets, adding, moving, deleting, or hiding worksheets, and
 // renaming worksheets in a workbook. The optional field AlgorithmName
@@ -256,9 +340,9 @@ func (f *File) setContentTypePartRelsExtensions() error {
 // for relationship parts and the Main Document part.
 func (f *File) setContentTypePartImageExtensions() error {
 	imageTypes := map[string]string{
-		"bmp": "image/", "jpeg": "image/", "png": "image/", "gif": "image/",
-		"svg": "image/", "tiff": "image/", "emf": "image/x-", "wmf": "image/x-",
-		"emz": "image/x-", "wmz": "image/x-",
+		"bmp": "image/bmp", "jpeg": "image/jpeg", "png": "image/png", "gif": "image/gif",
+		"svg": "image/svg+xml", "tiff": "image/tiff", "emf": "image/x-emf", "wmf": "image/x-wmf",
+		"emz": "image/x-emz", "wmz": "image/x-wmz",
 	}
 	content, err := f.contentTypesReader()
 	if err != nil {
@@ -269,10 +353,10 @@ func (f *File) setContentTypePartImageExtensions() error {
 	for _, file := range content.Defaults {
 		delete(imageTypes, file.Extension)
 	}
-	for extension, prefix := range imageTypes {
+	for extension, contentType := range imageTypes {
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   extension,
-			ContentType: prefix + extension,
+			ContentType: contentType,
 		})
 	}
 	return err
@@ -311,9 +395,13 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 	}
 	partNames := map[string]string{
 		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartEx":       "/xl/charts/chartEx" + strconv.Itoa(index) + ".xml",
 		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
 		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
 		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"metadata":      "/" + defaultXMLMetadata,
+		"richValue":     "/" + defaultXMLRdRichValuePart,
+		"richValueStr":  "/" + defaultXMLRdRichValueStructure,
 		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
 		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
 		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
@@ -323,9 +411,13 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 	}
 	contentTypes := map[string]string{
 		"chart":         ContentTypeDrawingML,
+		"chartEx":       ContentTypeChartEx,
 		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
 		"comments":      ContentTypeSpreadSheetMLComments,
 		"drawings":      ContentTypeDrawing,
+		"metadata":      ContentTypeSpreadSheetMLMetadata,
+		"richValue":     ContentTypeRdRichValue,
+		"richValueStr":  ContentTypeRdRichValueStructure,
 		"table":         ContentTypeSpreadSheetMLTable,
 		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
 		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
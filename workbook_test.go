@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,6 +33,61 @@ func TestWorkbookProps(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestWorkbookView(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetWorkbookView(nil))
+	for _, sheet := range []string{"Sheet2", "Sheet3"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	// Test set workbook view for the workbook built by the stream writer
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1}))
+	assert.NoError(t, sw.Flush())
+	expected := WorkbookViewOptions{
+		ActiveTab:    intPtr(2),
+		FirstSheet:   intPtr(1),
+		XWindow:      intPtr(120),
+		YWindow:      intPtr(240),
+		WindowWidth:  intPtr(28800),
+		WindowHeight: intPtr(16200),
+	}
+	assert.NoError(t, f.SetWorkbookView(&expected))
+	// Test the flushed stream worksheet not been loaded on set active sheet
+	_, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	sw, err = f.NewStreamWriter("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{3}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestWorkbookView.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestWorkbookView.xlsx"))
+	assert.NoError(t, err)
+	opts, err := f.GetWorkbookView()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, opts)
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	ws, err := f.workSheetReader("Sheet3")
+	assert.NoError(t, err)
+	assert.True(t, ws.SheetViews.SheetView[0].TabSelected)
+	// Test set workbook view with invalid settings
+	assert.Equal(t, ErrSheetIdx, f.SetWorkbookView(&WorkbookViewOptions{ActiveTab: intPtr(3)}))
+	assert.Equal(t, ErrSheetIdx, f.SetWorkbookView(&WorkbookViewOptions{FirstSheet: intPtr(-1)}))
+	assert.Equal(t, ErrParameterInvalid, f.SetWorkbookView(&WorkbookViewOptions{WindowWidth: intPtr(-1)}))
+	// Test set and get workbook view with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWorkbookView(&expected), "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.GetWorkbookView()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestDeleteWorkbookRels(t *testing.T) {
 	f := NewFile()
 	// Test delete pivot table without worksheet relationships
@@ -0,0 +1,1095 @@
+// Copyright 2016 - 2024 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.18 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/xuri/efp"
+)
+
+// This section defines the record types of the binary records in the XLSB
+// workbook used by the XLSB writer.
+const (
+	xlsbBrtRowHdr            = 0x0000
+	xlsbBrtCellRk            = 0x0002
+	xlsbBrtCellError         = 0x0003
+	xlsbBrtCellBool          = 0x0004
+	xlsbBrtCellReal          = 0x0005
+	xlsbBrtCellIsst          = 0x0007
+	xlsbBrtFmlaString        = 0x0008
+	xlsbBrtFmlaNum           = 0x0009
+	xlsbBrtFmlaBool          = 0x000A
+	xlsbBrtFmlaError         = 0x000B
+	xlsbBrtSSTItem           = 0x0013
+	xlsbBrtFont              = 0x002B
+	xlsbBrtFill              = 0x002D
+	xlsbBrtBorder            = 0x002E
+	xlsbBrtXF                = 0x002F
+	xlsbBrtStyle             = 0x0030
+	xlsbBrtBeginSheet        = 0x0081
+	xlsbBrtEndSheet          = 0x0082
+	xlsbBrtBeginBook         = 0x0083
+	xlsbBrtEndBook           = 0x0084
+	xlsbBrtBeginBookViews    = 0x0087
+	xlsbBrtEndBookViews      = 0x0088
+	xlsbBrtBeginBundleShs    = 0x008F
+	xlsbBrtEndBundleShs      = 0x0090
+	xlsbBrtBeginSheetData    = 0x0091
+	xlsbBrtEndSheetData      = 0x0092
+	xlsbBrtWsDim             = 0x0094
+	xlsbBrtWbProp            = 0x0099
+	xlsbBrtBundleSh          = 0x009C
+	xlsbBrtBookView          = 0x009E
+	xlsbBrtBeginSst          = 0x009F
+	xlsbBrtEndSst            = 0x00A0
+	xlsbBrtBeginStyleSheet   = 0x0116
+	xlsbBrtEndStyleSheet     = 0x0117
+	xlsbBrtBeginFills        = 0x025B
+	xlsbBrtEndFills          = 0x025C
+	xlsbBrtBeginFonts        = 0x0263
+	xlsbBrtEndFonts          = 0x0264
+	xlsbBrtBeginBorders      = 0x0265
+	xlsbBrtEndBorders        = 0x0266
+	xlsbBrtBeginCellXFs      = 0x0269
+	xlsbBrtEndCellXFs        = 0x026A
+	xlsbBrtBeginStyles       = 0x026B
+	xlsbBrtEndStyles         = 0x026C
+	xlsbBrtBeginCellStyleXFs = 0x0272
+	xlsbBrtEndCellStyleXFs   = 0x0273
+)
+
+// This section defines the parse expression tokens of the formula in the
+// XLSB workbook.
+const (
+	xlsbPtgAdd      = 0x03
+	xlsbPtgSub      = 0x04
+	xlsbPtgMul      = 0x05
+	xlsbPtgDiv      = 0x06
+	xlsbPtgPower    = 0x07
+	xlsbPtgConcat   = 0x08
+	xlsbPtgLt       = 0x09
+	xlsbPtgLe       = 0x0A
+	xlsbPtgEq       = 0x0B
+	xlsbPtgGe       = 0x0C
+	xlsbPtgGt       = 0x0D
+	xlsbPtgNe       = 0x0E
+	xlsbPtgUplus    = 0x12
+	xlsbPtgUminus   = 0x13
+	xlsbPtgPercent  = 0x14
+	xlsbPtgParen    = 0x15
+	xlsbPtgMissArg  = 0x16
+	xlsbPtgStr      = 0x17
+	xlsbPtgErr      = 0x1C
+	xlsbPtgBool     = 0x1D
+	xlsbPtgInt      = 0x1E
+	xlsbPtgNum      = 0x1F
+	xlsbPtgRef      = 0x24
+	xlsbPtgArea     = 0x25
+	xlsbPtgFunc     = 0x41
+	xlsbPtgFuncVar  = 0x42
+	xlsbPtgRefV     = 0x44
+	xlsbPtgAreaV    = 0x45
+	xlsbRkMaxInt    = 1<<29 - 1
+	xlsbRkMinInt    = -1 << 29
+	xlsbColSpanSize = 1024
+)
+
+// xlsbErrors defined the error codes of the cell error literals in the XLSB
+// workbook.
+var xlsbErrors = map[string]byte{
+	formulaErrorNULL:        0x00,
+	formulaErrorDIV:         0x07,
+	formulaErrorVALUE:       0x0F,
+	formulaErrorREF:         0x17,
+	formulaErrorNAME:        0x1D,
+	formulaErrorNUM:         0x24,
+	formulaErrorNA:          0x2A,
+	formulaErrorGETTINGDATA: 0x2B,
+}
+
+// xlsbOperators defined the parse expression tokens and precedence of the
+// infix operators in the formula of the XLSB workbook.
+var xlsbOperators = map[string]struct {
+	ptg  byte
+	prec int
+}{
+	"^": {xlsbPtgPower, 4}, "*": {xlsbPtgMul, 3}, "/": {xlsbPtgDiv, 3},
+	"+": {xlsbPtgAdd, 2}, "-": {xlsbPtgSub, 2}, "&": {xlsbPtgConcat, 1},
+	"=": {xlsbPtgEq, 0}, "<>": {xlsbPtgNe, 0}, "<": {xlsbPtgLt, 0},
+	"<=": {xlsbPtgLe, 0}, ">": {xlsbPtgGt, 0}, ">=": {xlsbPtgGe, 0},
+}
+
+// xlsbFunction defined the index of the built-in function in the function
+// table, and the minimum and maximum number of the arguments of the function.
+// The function with the variable number of the arguments is stored in the
+// PtgFuncVar token, otherwise stored in the PtgFunc token.
+type xlsbFunction struct {
+	tab      uint16
+	min, max int
+	varArgs  bool
+}
+
+// xlsbFunctions defined the built-in functions supported in the formula of
+// the XLSB workbook.
+var xlsbFunctions = map[string]xlsbFunction{
+	"ABS":         {tab: 24, min: 1, max: 1},
+	"AND":         {tab: 36, min: 1, max: 255, varArgs: true},
+	"AVERAGE":     {tab: 5, min: 1, max: 255, varArgs: true},
+	"COLUMN":      {tab: 9, min: 0, max: 1, varArgs: true},
+	"CONCATENATE": {tab: 336, min: 1, max: 255, varArgs: true},
+	"COUNT":       {tab: 0, min: 1, max: 255, varArgs: true},
+	"COUNTA":      {tab: 169, min: 1, max: 255, varArgs: true},
+	"EXP":         {tab: 21, min: 1, max: 1},
+	"IF":          {tab: 1, min: 2, max: 3, varArgs: true},
+	"INT":         {tab: 25, min: 1, max: 1},
+	"ISERROR":     {tab: 3, min: 1, max: 1},
+	"ISNA":        {tab: 2, min: 1, max: 1},
+	"LEFT":        {tab: 115, min: 1, max: 2, varArgs: true},
+	"LEN":         {tab: 32, min: 1, max: 1},
+	"LN":          {tab: 22, min: 1, max: 1},
+	"LOWER":       {tab: 112, min: 1, max: 1},
+	"MAX":         {tab: 7, min: 1, max: 255, varArgs: true},
+	"MID":         {tab: 31, min: 3, max: 3},
+	"MIN":         {tab: 6, min: 1, max: 255, varArgs: true},
+	"MOD":         {tab: 39, min: 2, max: 2},
+	"NA":          {tab: 10, min: 0, max: 0},
+	"NOT":         {tab: 38, min: 1, max: 1},
+	"NOW":         {tab: 74, min: 0, max: 0},
+	"OR":          {tab: 37, min: 1, max: 255, varArgs: true},
+	"PI":          {tab: 19, min: 0, max: 0},
+	"PRODUCT":     {tab: 183, min: 1, max: 255, varArgs: true},
+	"RIGHT":       {tab: 116, min: 1, max: 2, varArgs: true},
+	"ROUND":       {tab: 27, min: 2, max: 2},
+	"ROUNDDOWN":   {tab: 213, min: 2, max: 2},
+	"ROUNDUP":     {tab: 212, min: 2, max: 2},
+	"ROW":         {tab: 8, min: 0, max: 1, varArgs: true},
+	"SIGN":        {tab: 26, min: 1, max: 1},
+	"SQRT":        {tab: 20, min: 1, max: 1},
+	"SUM":         {tab: 4, min: 1, max: 255, varArgs: true},
+	"TODAY":       {tab: 221, min: 0, max: 0},
+	"TRIM":        {tab: 118, min: 1, max: 1},
+	"UPPER":       {tab: 113, min: 1, max: 1},
+	"VALUE":       {tab: 33, min: 1, max: 1},
+}
+
+// xlsbRefPattern defined the pattern of the cell, column and row reference
+// in the formula of the XLSB workbook.
+var xlsbRefPattern = regexp.MustCompile(`^(\$?)([A-Za-z]{0,3})(\$?)(\d*)$`)
+
+// XLSBWriter defined the writer for creating the XLSB workbook with the
+// worksheets written by the XLSB stream writers.
+type XLSBWriter struct {
+	mu       sync.Mutex
+	zw       *zip.Writer
+	streams  []*XLSBStreamWriter
+	sst      map[string]int
+	sstItems []string
+	sstTotal int
+	closed   bool
+}
+
+// XLSBStreamWriter defined the stream writer for writing the worksheet of the
+// XLSB workbook in the binary records.
+type XLSBStreamWriter struct {
+	xw      *XLSBWriter
+	Sheet   string
+	SheetID int
+	rawData bufferedWriter
+	rows    int
+	dims    []int
+	flushed bool
+}
+
+// xlsbFormulaFrame defined the operator, parenthesis or function on the
+// operator stack on converting the formula to the parse expression tokens.
+type xlsbFormulaFrame struct {
+	ptg    byte
+	prec   int
+	paren  bool
+	fn     *xlsbFunction
+	name   string
+	args   int
+	hasArg bool
+}
+
+// NewXLSBWriter returns the XLSB writer by given io.Writer, the XLSB workbook
+// will be written to the writer with the worksheets created by the
+// NewStreamWriter function of the XLSB writer. The XLSB workbook stores the
+// worksheets in the binary records instead of the XML, which is smaller and
+// faster to open for the worksheets with large amounts of data. The XLSB
+// writer only supports writing the cell values and formulas with the default
+// style, the following binary records of the cells are supported:
+//
+//	BrtCellRk      integer between -536870912 and 536870911
+//	BrtCellReal    other numbers
+//	BrtCellIsst    string and []byte in the shared strings table
+//	BrtCellBool    bool
+//	BrtCellError   CellError except #SPILL! and #CALC!
+//	BrtFmlaNum     formula with number or nil cached value
+//	BrtFmlaString  formula with string cached value
+//	BrtFmlaBool    formula with bool cached value
+//	BrtFmlaError   formula with CellError cached value
+//
+// The shared strings table is kept in memory until the writer closed, and the
+// rows of each worksheet will be written to the temporary files on disk when
+// in-memory chunks data over 16MB. You must call the 'Close' function of the
+// XLSB writer to write the workbook part, the shared strings table and the
+// styles of the workbook. For example, create an XLSB workbook with 102400
+// rows x 50 columns with numbers:
+//
+//	file, err := os.Create("Book1.xlsb")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	xw := excelize.NewXLSBWriter(file)
+//	sw, err := xw.NewStreamWriter("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := sw.SetRow("A1", []interface{}{"ID", "Value", "Total"}); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for rowID := 2; rowID <= 102400; rowID++ {
+//	    row := make([]interface{}, 50)
+//	    for colID := 0; colID < 50; colID++ {
+//	        row[colID] = rand.Intn(640000)
+//	    }
+//	    cell, err := excelize.CoordinatesToCellName(1, rowID)
+//	    if err != nil {
+//	        fmt.Println(err)
+//	        break
+//	    }
+//	    if err := sw.SetRow(cell, row); err != nil {
+//	        fmt.Println(err)
+//	        break
+//	    }
+//	}
+//	if err := sw.Flush(); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := xw.Close(); err != nil {
+//	    fmt.Println(err)
+//	}
+func NewXLSBWriter(w io.Writer) *XLSBWriter {
+	return &XLSBWriter{zw: zip.NewWriter(w), sst: make(map[string]int)}
+}
+
+// NewStreamWriter returns the XLSB stream writer by given worksheet name used
+// for writing data on a new worksheet of the XLSB workbook. The worksheets
+// are placed in the workbook in the order of creation, and the first
+// worksheet will be the active worksheet.
+func (xw *XLSBWriter) NewStreamWriter(sheet string) (*XLSBStreamWriter, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	xw.mu.Lock()
+	defer xw.mu.Unlock()
+	if xw.closed {
+		return nil, ErrXLSBWriterClosed
+	}
+	for _, sw := range xw.streams {
+		if strings.EqualFold(sw.Sheet, sheet) {
+			return nil, ErrXLSBSheetNameDuplicate
+		}
+	}
+	sw := &XLSBStreamWriter{xw: xw, Sheet: sheet, SheetID: len(xw.streams) + 1}
+	xw.streams = append(xw.streams, sw)
+	return sw, nil
+}
+
+// Close writes the workbook part, the shared strings table and the styles of
+// the XLSB workbook, and closes the XLSB writer. The XLSB stream writers which
+// have not been flushed will be flushed before writing the workbook part. The
+// underlying io.Writer will not be closed.
+func (xw *XLSBWriter) Close() error {
+	xw.mu.Lock()
+	defer xw.mu.Unlock()
+	if xw.closed {
+		return ErrXLSBWriterClosed
+	}
+	if len(xw.streams) == 0 {
+		return ErrXLSBNoSheet
+	}
+	for _, sw := range xw.streams {
+		if sw.flushed {
+			continue
+		}
+		if err := sw.writeSheet(); err != nil {
+			return err
+		}
+	}
+	xw.closed = true
+	for _, part := range []struct {
+		path string
+		fn   func() ([]byte, error)
+	}{
+		{defaultXMLPathContentTypes, xw.contentTypes},
+		{"_rels/.rels", xw.rootRels},
+		{"xl/workbook.bin", xw.workbook},
+		{"xl/_rels/workbook.bin.rels", xw.workbookRels},
+		{"xl/styles.bin", xw.styles},
+		{"xl/sharedStrings.bin", xw.sharedStrings},
+	} {
+		content, err := part.fn()
+		if err != nil {
+			return err
+		}
+		if err = xw.writePart(part.path, content); err != nil {
+			return err
+		}
+	}
+	return xw.zw.Close()
+}
+
+// writePart provides a function to add a file to the XLSB workbook package by
+// given path and content.
+func (xw *XLSBWriter) writePart(path string, content []byte) error {
+	fi, err := xw.zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate})
+	if err != nil {
+		return err
+	}
+	_, err = fi.Write(content)
+	return err
+}
+
+// contentTypes provides a function to get the content types part of the XLSB
+// workbook.
+func (xw *XLSBWriter) contentTypes() ([]byte, error) {
+	types := xlsxTypes{Defaults: []xlsxDefault{
+		{Extension: "bin", ContentType: ContentTypeXLSBWorkbook},
+		{Extension: "rels", ContentType: ContentTypeRelationships},
+		{Extension: "xml", ContentType: "application/xml"},
+	}}
+	for _, sw := range xw.streams {
+		types.Overrides = append(types.Overrides, xlsxOverride{
+			PartName:    fmt.Sprintf("/xl/worksheets/sheet%d.bin", sw.SheetID),
+			ContentType: ContentTypeXLSBWorksheet,
+		})
+	}
+	types.Overrides = append(types.Overrides,
+		xlsxOverride{PartName: "/xl/styles.bin", ContentType: ContentTypeXLSBStyles},
+		xlsxOverride{PartName: "/xl/sharedStrings.bin", ContentType: ContentTypeXLSBSharedStrings},
+	)
+	output, err := xml.Marshal(&types)
+	return append([]byte(xml.Header), output...), err
+}
+
+// rootRels provides a function to get the package relationships part of the
+// XLSB workbook.
+func (xw *XLSBWriter) rootRels() ([]byte, error) {
+	output, err := xml.Marshal(&xlsxRelationships{Relationships: []xlsxRelationship{
+		{ID: "rId1", Type: SourceRelationshipOfficeDocument, Target: "xl/workbook.bin"},
+	}})
+	return append([]byte(xml.Header), output...), err
+}
+
+// workbookRels provides a function to get the workbook relationships part of
+// the XLSB workbook.
+func (xw *XLSBWriter) workbookRels() ([]byte, error) {
+	var rels xlsxRelationships
+	for _, sw := range xw.streams {
+		rels.Relationships = append(rels.Relationships, xlsxRelationship{
+			ID:     sw.relID(),
+			Type:   SourceRelationshipWorkSheet,
+			Target: fmt.Sprintf("worksheets/sheet%d.bin", sw.SheetID),
+		})
+	}
+	rels.Relationships = append(rels.Relationships,
+		xlsxRelationship{ID: fmt.Sprintf("rId%d", len(xw.streams)+1), Type: SourceRelationshipStyles, Target: "styles.bin"},
+		xlsxRelationship{ID: fmt.Sprintf("rId%d", len(xw.streams)+2), Type: SourceRelationshipSharedStrings, Target: "sharedStrings.bin"},
+	)
+	output, err := xml.Marshal(&rels)
+	return append([]byte(xml.Header), output...), err
+}
+
+// workbook provides a function to get the workbook part of the XLSB workbook
+// with the workbook properties, the workbook view and the sheets bundle. The
+// workbook view opens the first worksheet in the default window size.
+func (xw *XLSBWriter) workbook() ([]byte, error) {
+	var buf, view []byte
+	for _, n := range []uint32{0, 460, 28800, 17600, 600, 0, 0} {
+		view = appendXLSBUint32(view, n)
+	}
+	buf = appendXLSBRecord(buf, xlsbBrtBeginBook, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtWbProp, appendXLSBString(make([]byte, 8), "ThisWorkbook"))
+	buf = appendXLSBRecord(buf, xlsbBrtBeginBookViews, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBookView, append(view, 0x78))
+	buf = appendXLSBRecord(buf, xlsbBrtEndBookViews, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginBundleShs, nil)
+	for _, sw := range xw.streams {
+		var data []byte
+		data = appendXLSBUint32(data, 0)
+		data = appendXLSBUint32(data, uint32(sw.SheetID))
+		data = appendXLSBString(data, sw.relID())
+		data = appendXLSBString(data, sw.Sheet)
+		buf = appendXLSBRecord(buf, xlsbBrtBundleSh, data)
+	}
+	buf = appendXLSBRecord(buf, xlsbBrtEndBundleShs, nil)
+	return appendXLSBRecord(buf, xlsbBrtEndBook, nil), nil
+}
+
+// styles provides a function to get the styles part of the XLSB workbook,
+// which contains the default font, fills, border and cell formats.
+func (xw *XLSBWriter) styles() ([]byte, error) {
+	var buf, font, xf []byte
+	font = appendXLSBUint16(font, 220)
+	font = appendXLSBUint16(font, 0)
+	font = appendXLSBUint16(font, 400)
+	font = append(font, 0, 0, 0, 2, 0, 0)
+	font = append(font, 0x06, 0x01, 0, 0, 0, 0, 0, 0xFF, 2)
+	font = appendXLSBString(font, "Calibri")
+	buf = appendXLSBRecord(buf, xlsbBrtBeginStyleSheet, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginFonts, appendXLSBUint32(nil, 1))
+	buf = appendXLSBRecord(buf, xlsbBrtFont, font)
+	buf = appendXLSBRecord(buf, xlsbBrtEndFonts, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginFills, appendXLSBUint32(nil, 2))
+	for _, pattern := range []uint32{0, 17} {
+		buf = appendXLSBRecord(buf, xlsbBrtFill, append(appendXLSBUint32(nil, pattern), make([]byte, 64)...))
+	}
+	buf = appendXLSBRecord(buf, xlsbBrtEndFills, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginBorders, appendXLSBUint32(nil, 1))
+	buf = appendXLSBRecord(buf, xlsbBrtBorder, make([]byte, 51))
+	buf = appendXLSBRecord(buf, xlsbBrtEndBorders, nil)
+	xf = append(appendXLSBUint16(nil, 0xFFFF), make([]byte, 14)...)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginCellStyleXFs, appendXLSBUint32(nil, 1))
+	buf = appendXLSBRecord(buf, xlsbBrtXF, xf)
+	buf = appendXLSBRecord(buf, xlsbBrtEndCellStyleXFs, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginCellXFs, appendXLSBUint32(nil, 1))
+	buf = appendXLSBRecord(buf, xlsbBrtXF, make([]byte, 16))
+	buf = appendXLSBRecord(buf, xlsbBrtEndCellXFs, nil)
+	buf = appendXLSBRecord(buf, xlsbBrtBeginStyles, appendXLSBUint32(nil, 1))
+	buf = appendXLSBRecord(buf, xlsbBrtStyle, appendXLSBString(append(appendXLSBUint32(nil, 0), 1, 0, 0, 0), "Normal"))
+	buf = appendXLSBRecord(buf, xlsbBrtEndStyles, nil)
+	return appendXLSBRecord(buf, xlsbBrtEndStyleSheet, nil), nil
+}
+
+// sharedStrings provides a function to get the shared strings table part of
+// the XLSB workbook.
+func (xw *XLSBWriter) sharedStrings() ([]byte, error) {
+	var buf, data []byte
+	data = appendXLSBUint32(data, uint32(xw.sstTotal))
+	data = appendXLSBUint32(data, uint32(len(xw.sstItems)))
+	buf = appendXLSBRecord(buf, xlsbBrtBeginSst, data)
+	for _, item := range xw.sstItems {
+		buf = appendXLSBRecord(buf, xlsbBrtSSTItem, appendXLSBString([]byte{0}, item))
+	}
+	return appendXLSBRecord(buf, xlsbBrtEndSst, nil), nil
+}
+
+// sharedStringIndex provides a function to get the index of the string in
+// the shared strings table, the string will be added to the table if it
+// doesn't exist.
+func (xw *XLSBWriter) sharedStringIndex(val string) int {
+	xw.mu.Lock()
+	defer xw.mu.Unlock()
+	xw.sstTotal++
+	if idx, ok := xw.sst[val]; ok {
+		return idx
+	}
+	idx := len(xw.sstItems)
+	xw.sst[val] = idx
+	xw.sstItems = append(xw.sstItems, val)
+	return idx
+}
+
+// relID provides a function to get the relationship ID of the worksheet in
+// the workbook relationships part.
+func (sw *XLSBStreamWriter) relID() string {
+	return fmt.Sprintf("rId%d", sw.SheetID)
+}
+
+// SetRow writes an array to stream rows by giving starting cell reference and
+// a pointer to an array of values. Note that you must call the 'Flush'
+// function to end the streaming writing process, and ensure that the order of
+// row numbers is ascending. The nil values will be skipped, the Cell could be
+// used to write the formula with the cached result in the Value of the Cell,
+// the style, number format, rich value and other options of the Cell are not
+// supported in the XLSB workbook. For example, write a row with the formula:
+//
+//	err := sw.SetRow("A1", []interface{}{
+//	    1, 2, excelize.Cell{Formula: "SUM(A1:B1)", Value: 3},
+//	})
+//
+// The formula supports the numbers, strings, booleans, error literals, the
+// cell and range references on the same worksheet, the arithmetic,
+// comparison and concatenation operators, the parentheses and the following
+// functions:
+//
+//	ABS, AND, AVERAGE, COLUMN, CONCATENATE, COUNT, COUNTA, EXP, IF, INT,
+//	ISERROR, ISNA, LEFT, LEN, LN, LOWER, MAX, MID, MIN, MOD, NA, NOT, NOW,
+//	OR, PI, PRODUCT, RIGHT, ROUND, ROUNDDOWN, ROUNDUP, ROW, SIGN, SQRT, SUM,
+//	TODAY, TRIM, UPPER, VALUE
+func (sw *XLSBStreamWriter) SetRow(cell string, values []interface{}) error {
+	if sw.flushed {
+		return ErrXLSBStreamFlushed
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	if row <= sw.rows {
+		return newStreamSetRowError(row)
+	}
+	var cells []byte
+	var spans [][]int
+	for i, val := range values {
+		if val == nil {
+			continue
+		}
+		c := col + i - 1
+		if c >= MaxColumns {
+			return ErrColumnNumber
+		}
+		var rec []byte
+		if rec, err = sw.cellRecord(c, row, val); err != nil {
+			return err
+		}
+		if rec == nil {
+			continue
+		}
+		cells = append(cells, rec...)
+		if len(spans) == 0 || spans[len(spans)-1][0]/xlsbColSpanSize != c/xlsbColSpanSize {
+			spans = append(spans, []int{c, c})
+		}
+		spans[len(spans)-1][1] = c
+		sw.extendDimension(c, row-1)
+	}
+	sw.rows = row
+	if len(spans) == 0 {
+		return nil
+	}
+	var hdr []byte
+	hdr = appendXLSBUint32(hdr, uint32(row-1))
+	hdr = appendXLSBUint32(hdr, 0)
+	hdr = appendXLSBUint16(hdr, 300)
+	hdr = append(hdr, 0, 0, 0)
+	hdr = appendXLSBUint32(hdr, uint32(len(spans)))
+	for _, span := range spans {
+		hdr = appendXLSBUint32(hdr, uint32(span[0]))
+		hdr = appendXLSBUint32(hdr, uint32(span[1]))
+	}
+	_, _ = sw.rawData.Write(appendXLSBRecord(nil, xlsbBrtRowHdr, hdr))
+	_, _ = sw.rawData.Write(cells)
+	return sw.rawData.Sync()
+}
+
+// extendDimension provides a function to extend the used range of the
+// worksheet by given zero-based column and row number.
+func (sw *XLSBStreamWriter) extendDimension(col, row int) {
+	if sw.dims == nil {
+		sw.dims = []int{row, row, col, col}
+		return
+	}
+	sw.dims[1] = row
+	if col < sw.dims[2] {
+		sw.dims[2] = col
+	}
+	if col > sw.dims[3] {
+		sw.dims[3] = col
+	}
+}
+
+// cellRecord provides a function to get the binary record of the cell by
+// given zero-based column number, row number and the cell value. It returns
+// nil if the value is nil.
+func (sw *XLSBStreamWriter) cellRecord(col, row int, val interface{}) ([]byte, error) {
+	data := appendXLSBUint32(appendXLSBUint32(nil, uint32(col)), 0)
+	v, ok := val.(Cell)
+	if !ok {
+		v.Value = val
+	}
+	if v.StyleID != 0 || v.NumFmt != "" || v.DisplayText != "" || v.RichValueID != 0 || v.ResultType != CellTypeUnset {
+		return nil, newXLSBCellValueError(col+1, row)
+	}
+	if v.ForceText && v.Value != nil {
+		if _, ok := v.Value.(string); !ok {
+			v.Value = fmt.Sprint(v.Value)
+		}
+	}
+	var typ int
+	if v.Formula == "" {
+		switch val := v.Value.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			if utf8.RuneCountInString(val) > TotalCellChars {
+				return nil, ErrCellCharsLength
+			}
+			typ, data = xlsbBrtCellIsst, appendXLSBUint32(data, uint32(sw.xw.sharedStringIndex(val)))
+		case []byte:
+			return sw.cellRecord(col, row, string(val))
+		case bool:
+			typ, data = xlsbBrtCellBool, append(data, xlsbBool(val))
+		case CellError:
+			code, ok := xlsbErrors[string(val)]
+			if !ok {
+				return nil, newXLSBCellValueError(col+1, row)
+			}
+			typ, data = xlsbBrtCellError, append(data, code)
+		default:
+			f, ok := xlsbNumber(val)
+			if !ok {
+				return nil, newXLSBCellValueError(col+1, row)
+			}
+			if f == math.Trunc(f) && f >= xlsbRkMinInt && f <= xlsbRkMaxInt {
+				typ, data = xlsbBrtCellRk, appendXLSBUint32(data, uint32(int32(f)<<2|0x02))
+				break
+			}
+			typ, data = xlsbBrtCellReal, appendXLSBFloat64(data, f)
+		}
+		return appendXLSBRecord(nil, typ, data), nil
+	}
+	rgce, err := xlsbFormula(v.Formula)
+	if err != nil {
+		return nil, err
+	}
+	switch val := v.Value.(type) {
+	case nil:
+		typ, data = xlsbBrtFmlaNum, appendXLSBFloat64(data, 0)
+	case string:
+		if utf8.RuneCountInString(val) > TotalCellChars {
+			return nil, ErrCellCharsLength
+		}
+		typ, data = xlsbBrtFmlaString, appendXLSBString(data, val)
+	case bool:
+		typ, data = xlsbBrtFmlaBool, append(data, xlsbBool(val))
+	case CellError:
+		code, ok := xlsbErrors[string(val)]
+		if !ok {
+			return nil, newXLSBCellValueError(col+1, row)
+		}
+		typ, data = xlsbBrtFmlaError, append(data, code)
+	default:
+		f, ok := xlsbNumber(val)
+		if !ok {
+			return nil, newXLSBCellValueError(col+1, row)
+		}
+		typ, data = xlsbBrtFmlaNum, appendXLSBFloat64(data, f)
+	}
+	data = appendXLSBUint16(data, 0)
+	data = appendXLSBUint32(data, uint32(len(rgce)))
+	data = append(data, rgce...)
+	return appendXLSBRecord(nil, typ, appendXLSBUint32(data, 0)), nil
+}
+
+// xlsbNumber returns the floating-point number of the integer and
+// floating-point value, and whether the value is a number.
+func xlsbNumber(val interface{}) (float64, bool) {
+	switch val := val.(type) {
+	case int:
+		return float64(val), true
+	case int8:
+		return float64(val), true
+	case int16:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case uint:
+		return float64(val), true
+	case uint8:
+		return float64(val), true
+	case uint16:
+		return float64(val), true
+	case uint32:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	case float32:
+		return float64(val), true
+	case float64:
+		return val, true
+	}
+	return 0, false
+}
+
+// Flush ending the streaming writing process, and writes the worksheet part
+// to the XLSB workbook.
+func (sw *XLSBStreamWriter) Flush() error {
+	sw.xw.mu.Lock()
+	defer sw.xw.mu.Unlock()
+	if sw.xw.closed {
+		return ErrXLSBWriterClosed
+	}
+	if sw.flushed {
+		return ErrXLSBStreamFlushed
+	}
+	return sw.writeSheet()
+}
+
+// writeSheet provides a function to write the worksheet part with the written
+// rows to the XLSB workbook package, and remove the temporary file of the
+// rows.
+func (sw *XLSBStreamWriter) writeSheet() error {
+	defer sw.rawData.Close()
+	sw.flushed = true
+	dims := sw.dims
+	if dims == nil {
+		dims = []int{0, 0, 0, 0}
+	}
+	var hdr, data []byte
+	for _, n := range dims {
+		data = appendXLSBUint32(data, uint32(n))
+	}
+	hdr = appendXLSBRecord(hdr, xlsbBrtBeginSheet, nil)
+	hdr = appendXLSBRecord(hdr, xlsbBrtWsDim, data)
+	hdr = appendXLSBRecord(hdr, xlsbBrtBeginSheetData, nil)
+	fi, err := sw.xw.zw.CreateHeader(&zip.FileHeader{
+		Name: fmt.Sprintf("xl/worksheets/sheet%d.bin", sw.SheetID), Method: zip.Deflate,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = fi.Write(hdr); err != nil {
+		return err
+	}
+	var from io.Reader
+	if from, err = sw.rawData.Reader(); err != nil {
+		return err
+	}
+	if _, err = io.Copy(fi, from); err != nil {
+		return err
+	}
+	_, err = fi.Write(appendXLSBRecord(appendXLSBRecord(nil, xlsbBrtEndSheetData, nil), xlsbBrtEndSheet, nil))
+	return err
+}
+
+// xlsbFormula provides a function to convert the formula to the parse
+// expression tokens of the formula in the XLSB workbook.
+func xlsbFormula(formula string) ([]byte, error) {
+	var (
+		rgce    []byte
+		stack   []*xlsbFormulaFrame
+		depth   int
+		invalid bool
+		err     error
+		ps      = efp.ExcelParser()
+	)
+	// emit appends the parse expression token which takes the given number
+	// of the operands, and tracks the depth of the operands stack.
+	emit := func(operands int, ptg ...byte) {
+		if depth < operands {
+			invalid = true
+		}
+		depth, rgce = depth+1-operands, append(rgce, ptg...)
+	}
+	// popOperators pop the operators until the parenthesis or function on the
+	// top of the stack, or the operator with lower precedence.
+	popOperators := func(prec int) {
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			if top.paren || top.fn != nil || top.prec < prec {
+				return
+			}
+			if stack = stack[:len(stack)-1]; top.prec == 6 {
+				emit(1, top.ptg)
+				continue
+			}
+			emit(2, top.ptg)
+		}
+	}
+	// function returns the innermost function on the stack.
+	function := func() *xlsbFormulaFrame {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].fn != nil {
+				return stack[i]
+			}
+		}
+		return nil
+	}
+	// operand marks the argument of the innermost function has been written.
+	operand := func() {
+		if fn := function(); fn != nil {
+			fn.hasArg = true
+		}
+	}
+	for _, token := range ps.Parse(strings.TrimPrefix(formula, "=")) {
+		switch token.TType {
+		case efp.TokenTypeWhitespace:
+			continue
+		case efp.TokenTypeOperand:
+			var ptg []byte
+			if ptg, err = appendXLSBOperand(nil, token, function() != nil); err != nil {
+				return nil, newXLSBFormulaError(formula, token.TValue)
+			}
+			emit(0, ptg...)
+			operand()
+			continue
+		case efp.TokenTypeOperatorPrefix:
+			ptg := byte(xlsbPtgUminus)
+			if token.TValue == "+" {
+				ptg = xlsbPtgUplus
+			}
+			stack = append(stack, &xlsbFormulaFrame{ptg: ptg, prec: 6})
+			continue
+		case efp.TokenTypeOperatorPostfix:
+			popOperators(5)
+			emit(1, xlsbPtgPercent)
+			continue
+		case efp.TokenTypeOperatorInfix:
+			if op, ok := xlsbOperators[token.TValue]; ok {
+				popOperators(op.prec)
+				stack = append(stack, &xlsbFormulaFrame{ptg: op.ptg, prec: op.prec})
+				continue
+			}
+		case efp.TokenTypeSubexpression:
+			if token.TSubType == efp.TokenSubTypeStart {
+				stack = append(stack, &xlsbFormulaFrame{paren: true})
+				continue
+			}
+			popOperators(0)
+			if len(stack) > 0 && stack[len(stack)-1].paren {
+				stack = stack[:len(stack)-1]
+				emit(1, xlsbPtgParen)
+				continue
+			}
+		case efp.TokenTypeFunction:
+			if token.TSubType == efp.TokenSubTypeStart {
+				if fn, ok := xlsbFunctions[strings.ToUpper(token.TValue)]; ok {
+					stack = append(stack, &xlsbFormulaFrame{fn: &fn, name: token.TValue})
+					continue
+				}
+				break
+			}
+			popOperators(0)
+			if len(stack) == 0 || stack[len(stack)-1].fn == nil {
+				break
+			}
+			top := stack[len(stack)-1]
+			if !top.hasArg && top.args > 0 {
+				emit(0, xlsbPtgMissArg)
+			}
+			if top.hasArg || top.args > 0 {
+				top.args++
+			}
+			if top.args < top.fn.min || top.args > top.fn.max {
+				return nil, newXLSBFormulaError(formula, top.name)
+			}
+			stack = stack[:len(stack)-1]
+			if top.fn.varArgs {
+				emit(top.args, appendXLSBUint16([]byte{xlsbPtgFuncVar, byte(top.args)}, top.fn.tab)...)
+			} else {
+				emit(top.args, appendXLSBUint16([]byte{xlsbPtgFunc}, top.fn.tab)...)
+			}
+			operand()
+			continue
+		case efp.TokenTypeArgument:
+			popOperators(0)
+			if len(stack) == 0 || stack[len(stack)-1].fn == nil {
+				break
+			}
+			top := stack[len(stack)-1]
+			if !top.hasArg {
+				emit(0, xlsbPtgMissArg)
+			}
+			top.args, top.hasArg = top.args+1, false
+			continue
+		}
+		return nil, newXLSBFormulaError(formula, token.TValue)
+	}
+	popOperators(0)
+	if len(stack) > 0 || invalid || depth != 1 {
+		return nil, newXLSBFormulaError(formula, "")
+	}
+	return rgce, nil
+}
+
+// appendXLSBOperand provides a function to append the parse expression token
+// of the operand in the formula of the XLSB workbook. The references in the
+// arguments of the functions are written in the reference class, otherwise
+// in the value class.
+func appendXLSBOperand(rgce []byte, token efp.Token, refClass bool) ([]byte, error) {
+	switch token.TSubType {
+	case efp.TokenSubTypeNumber:
+		f, err := strconv.ParseFloat(token.TValue, 64)
+		if err != nil {
+			return rgce, err
+		}
+		if f == math.Trunc(f) && f >= 0 && f <= math.MaxUint16 && !strings.ContainsAny(token.TValue, ".eE") {
+			return appendXLSBUint16(append(rgce, xlsbPtgInt), uint16(f)), nil
+		}
+		return appendXLSBFloat64(append(rgce, xlsbPtgNum), f), nil
+	case efp.TokenSubTypeText:
+		u := utf16.Encode([]rune(token.TValue))
+		if len(u) > 255 {
+			return rgce, ErrParameterInvalid
+		}
+		rgce = appendXLSBUint16(append(rgce, xlsbPtgStr), uint16(len(u)))
+		for _, c := range u {
+			rgce = appendXLSBUint16(rgce, c)
+		}
+		return rgce, nil
+	case efp.TokenSubTypeLogical:
+		return append(rgce, xlsbPtgBool, xlsbBool(strings.EqualFold(token.TValue, "TRUE"))), nil
+	case efp.TokenSubTypeError:
+		code, ok := xlsbErrors[token.TValue]
+		if !ok {
+			return rgce, ErrParameterInvalid
+		}
+		return append(rgce, xlsbPtgErr, code), nil
+	case efp.TokenSubTypeRange:
+		return appendXLSBRef(rgce, token.TValue, refClass)
+	}
+	return rgce, ErrParameterInvalid
+}
+
+// appendXLSBRef provides a function to append the parse expression token of
+// the cell reference or the range reference on the same worksheet, such as
+// A1, $A$1, A1:B2, A:B or 1:2.
+func appendXLSBRef(rgce []byte, ref string, refClass bool) ([]byte, error) {
+	var refs [][]int
+	for _, part := range strings.Split(ref, ":") {
+		matches := xlsbRefPattern.FindStringSubmatch(part)
+		if len(matches) != 5 || (matches[2] == "" && matches[4] == "") {
+			return rgce, ErrParameterInvalid
+		}
+		col, row, colRel, rowRel := -1, -1, matches[1] == "", matches[3] == ""
+		if matches[2] == "" {
+			rowRel = matches[1] == "" && matches[3] == ""
+		}
+		if matches[2] != "" {
+			n, err := ColumnNameToNumber(matches[2])
+			if err != nil {
+				return rgce, err
+			}
+			col = n - 1
+		}
+		if matches[4] != "" {
+			n, err := strconv.Atoi(matches[4])
+			if err != nil || n < 1 || n > TotalRows {
+				return rgce, ErrParameterInvalid
+			}
+			row = n - 1
+		}
+		refs = append(refs, []int{col, row, xlsbColRel(colRel, rowRel)})
+	}
+	if len(refs) > 2 {
+		return rgce, ErrParameterInvalid
+	}
+	if len(refs) == 1 {
+		if refs[0][0] == -1 || refs[0][1] == -1 {
+			return rgce, ErrParameterInvalid
+		}
+		ptg := byte(xlsbPtgRefV)
+		if refClass {
+			ptg = xlsbPtgRef
+		}
+		rgce = appendXLSBUint32(append(rgce, ptg), uint32(refs[0][1]))
+		return appendXLSBUint16(rgce, uint16(refs[0][0]|refs[0][2])), nil
+	}
+	first, last := refs[0], refs[1]
+	if (first[0] == -1) != (last[0] == -1) || (first[1] == -1) != (last[1] == -1) {
+		return rgce, ErrParameterInvalid
+	}
+	if first[0] == -1 {
+		first[0], last[0] = 0, MaxColumns-1
+	}
+	if first[1] == -1 {
+		first[1], last[1] = 0, TotalRows-1
+	}
+	ptg := byte(xlsbPtgAreaV)
+	if refClass {
+		ptg = xlsbPtgArea
+	}
+	rgce = appendXLSBUint32(append(rgce, ptg), uint32(first[1]))
+	rgce = appendXLSBUint32(rgce, uint32(last[1]))
+	rgce = appendXLSBUint16(rgce, uint16(first[0]|first[2]))
+	return appendXLSBUint16(rgce, uint16(last[0]|last[2])), nil
+}
+
+// xlsbColRel returns the relative flags of the column and row in the column
+// field of the cell reference in the formula of the XLSB workbook.
+func xlsbColRel(colRel, rowRel bool) int {
+	var flags int
+	if colRel {
+		flags |= 0x4000
+	}
+	if rowRel {
+		flags |= 0x8000
+	}
+	return flags
+}
+
+// xlsbBool returns the byte of the boolean value in the XLSB workbook.
+func xlsbBool(val bool) byte {
+	if val {
+		return 1
+	}
+	return 0
+}
+
+// appendXLSBRecord provides a function to append the binary record by given
+// record type and record data, the record type and the size of the record
+// data are written as the variable-length integers.
+func appendXLSBRecord(buf []byte, typ int, data []byte) []byte {
+	buf = appendXLSBVarint(buf, typ)
+	buf = appendXLSBVarint(buf, len(data))
+	return append(buf, data...)
+}
+
+// appendXLSBVarint provides a function to append the variable-length integer
+// of the record type or record size, each byte stores 7 bits of the integer
+// and the high bit indicates whether there are more bytes.
+func appendXLSBVarint(buf []byte, n int) []byte {
+	for n >= 0x80 {
+		buf = append(buf, byte(n&0x7F|0x80))
+		n >>= 7
+	}
+	return append(buf, byte(n))
+}
+
+// appendXLSBUint16 provides a function to append the little-endian 16-bit
+// unsigned integer.
+func appendXLSBUint16(buf []byte, n uint16) []byte {
+	return append(buf, byte(n), byte(n>>8))
+}
+
+// appendXLSBUint32 provides a function to append the little-endian 32-bit
+// unsigned integer.
+func appendXLSBUint32(buf []byte, n uint32) []byte {
+	return append(buf, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+}
+
+// appendXLSBFloat64 provides a function to append the little-endian IEEE 754
+// floating-point number.
+func appendXLSBFloat64(buf []byte, f float64) []byte {
+	n := math.Float64bits(f)
+	return appendXLSBUint32(appendXLSBUint32(buf, uint32(n)), uint32(n>>32))
+}
+
+// appendXLSBString provides a function to append the wide string, which
+// stores the number of the UTF-16 code units followed by the UTF-16 string.
+func appendXLSBString(buf []byte, s string) []byte {
+	u := utf16.Encode([]rune(s))
+	buf = appendXLSBUint32(buf, uint32(len(u)))
+	for _, c := range u {
+		buf = appendXLSBUint16(buf, c)
+	}
+	return buf
+}
@@ -0,0 +1,368 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xlsbTestRecord defined the binary record parsed from the XLSB workbook
+// part for testing.
+type xlsbTestRecord struct {
+	typ  int
+	data []byte
+}
+
+// xlsbErrWriter defined the writer which always returns an error for
+// testing.
+type xlsbErrWriter struct{}
+
+func (xlsbErrWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write error")
+}
+
+func TestXLSBWriter(t *testing.T) {
+	var buf bytes.Buffer
+	xw := NewXLSBWriter(&buf)
+	sw, err := xw.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{
+		"Name", 1, 2.5, true, nil, CellError("#N/A"), []byte("Name"),
+		Cell{Formula: "SUM(B1:C1)", Value: 3.5},
+		Cell{Formula: `="a"&"b"`, Value: "ab"},
+		Cell{Formula: "B1>0", Value: true},
+		Cell{Formula: "NA()", Value: CellError("#N/A")},
+		Cell{Formula: "NOW()"},
+		Cell{Value: 10, ForceText: true},
+	}))
+	assert.NoError(t, sw.SetRow("C2", []interface{}{nil}))
+	assert.NoError(t, sw.SetRow("B3", []interface{}{int64(1 << 40), uint64(math.MaxUint64), -1, float32(0.5)}))
+	assert.NoError(t, sw.SetRow("AMJ4", []interface{}{1, 2}))
+	assert.NoError(t, sw.Flush())
+	_, err = xw.NewStreamWriter("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, xw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var names []string
+	for _, fi := range zr.File {
+		names = append(names, fi.Name)
+	}
+	assert.Equal(t, []string{
+		"xl/worksheets/sheet1.bin", "xl/worksheets/sheet2.bin", defaultXMLPathContentTypes, "_rels/.rels",
+		"xl/workbook.bin", "xl/_rels/workbook.bin.rels", "xl/styles.bin", "xl/sharedStrings.bin",
+	}, names)
+	contentTypes := string(readXLSBTestPart(t, zr, defaultXMLPathContentTypes))
+	for _, contentType := range []string{
+		`<Default Extension="bin" ContentType="` + ContentTypeXLSBWorkbook + `"></Default>`,
+		`<Override PartName="/xl/worksheets/sheet2.bin" ContentType="` + ContentTypeXLSBWorksheet + `"></Override>`,
+		`<Override PartName="/xl/styles.bin" ContentType="` + ContentTypeXLSBStyles + `"></Override>`,
+		`<Override PartName="/xl/sharedStrings.bin" ContentType="` + ContentTypeXLSBSharedStrings + `"></Override>`,
+	} {
+		assert.Contains(t, contentTypes, contentType)
+	}
+	assert.Contains(t, string(readXLSBTestPart(t, zr, "_rels/.rels")), `Target="xl/workbook.bin"`)
+	assert.Contains(t, string(readXLSBTestPart(t, zr, "xl/_rels/workbook.bin.rels")),
+		`<Relationship Id="rId2" Target="worksheets/sheet2.bin" Type="`+SourceRelationshipWorkSheet+`"></Relationship>`+
+			`<Relationship Id="rId3" Target="styles.bin" Type="`+SourceRelationshipStyles+`"></Relationship>`+
+			`<Relationship Id="rId4" Target="sharedStrings.bin" Type="`+SourceRelationshipSharedStrings+`"></Relationship>`)
+
+	// Test the workbook part with the sheets bundle
+	records := readXLSBTestRecords(t, readXLSBTestPart(t, zr, "xl/workbook.bin"))
+	assert.Equal(t, []int{
+		xlsbBrtBeginBook, xlsbBrtWbProp, xlsbBrtBeginBookViews, xlsbBrtBookView, xlsbBrtEndBookViews,
+		xlsbBrtBeginBundleShs, xlsbBrtBundleSh, xlsbBrtBundleSh, xlsbBrtEndBundleShs, xlsbBrtEndBook,
+	}, xlsbTestRecordTypes(records))
+	assert.Equal(t, appendXLSBString(make([]byte, 8), "ThisWorkbook"), records[1].data)
+	assert.Len(t, records[3].data, 29)
+	assert.Equal(t, appendXLSBString(appendXLSBString([]byte{0, 0, 0, 0, 2, 0, 0, 0}, "rId2"), "Sheet2"), records[7].data)
+
+	// Test the shared strings table
+	records = readXLSBTestRecords(t, readXLSBTestPart(t, zr, "xl/sharedStrings.bin"))
+	assert.Equal(t, []int{xlsbBrtBeginSst, xlsbBrtSSTItem, xlsbBrtSSTItem, xlsbBrtEndSst}, xlsbTestRecordTypes(records))
+	assert.Equal(t, []byte{3, 0, 0, 0, 2, 0, 0, 0}, records[0].data)
+	assert.Equal(t, appendXLSBString([]byte{0}, "Name"), records[1].data)
+	assert.Equal(t, appendXLSBString([]byte{0}, "10"), records[2].data)
+
+	// Test the styles part
+	records = readXLSBTestRecords(t, readXLSBTestPart(t, zr, "xl/styles.bin"))
+	assert.Equal(t, []int{
+		xlsbBrtBeginStyleSheet, xlsbBrtBeginFonts, xlsbBrtFont, xlsbBrtEndFonts,
+		xlsbBrtBeginFills, xlsbBrtFill, xlsbBrtFill, xlsbBrtEndFills,
+		xlsbBrtBeginBorders, xlsbBrtBorder, xlsbBrtEndBorders,
+		xlsbBrtBeginCellStyleXFs, xlsbBrtXF, xlsbBrtEndCellStyleXFs,
+		xlsbBrtBeginCellXFs, xlsbBrtXF, xlsbBrtEndCellXFs,
+		xlsbBrtBeginStyles, xlsbBrtStyle, xlsbBrtEndStyles, xlsbBrtEndStyleSheet,
+	}, xlsbTestRecordTypes(records))
+
+	// Test the worksheet part with the cell records
+	records = readXLSBTestRecords(t, readXLSBTestPart(t, zr, "xl/worksheets/sheet1.bin"))
+	assert.Equal(t, []int{
+		xlsbBrtBeginSheet, xlsbBrtWsDim, xlsbBrtBeginSheetData,
+		xlsbBrtRowHdr, xlsbBrtCellIsst, xlsbBrtCellRk, xlsbBrtCellReal, xlsbBrtCellBool, xlsbBrtCellError, xlsbBrtCellIsst,
+		xlsbBrtFmlaNum, xlsbBrtFmlaString, xlsbBrtFmlaBool, xlsbBrtFmlaError, xlsbBrtFmlaNum, xlsbBrtCellIsst,
+		xlsbBrtRowHdr, xlsbBrtCellReal, xlsbBrtCellReal, xlsbBrtCellRk, xlsbBrtCellReal,
+		xlsbBrtRowHdr, xlsbBrtCellRk, xlsbBrtCellRk,
+		xlsbBrtEndSheetData, xlsbBrtEndSheet,
+	}, xlsbTestRecordTypes(records))
+	assert.Equal(t, []byte{0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 4, 0, 0}, records[1].data)
+	assert.Equal(t, []byte{
+		0, 0, 0, 0, 0, 0, 0, 0, 0x2C, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 12, 0, 0, 0,
+	}, records[3].data)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, records[4].data)
+	assert.Equal(t, []byte{1, 0, 0, 0, 0, 0, 0, 0, 6, 0, 0, 0}, records[5].data)
+	assert.Equal(t, appendXLSBFloat64([]byte{2, 0, 0, 0, 0, 0, 0, 0}, 2.5), records[6].data)
+	assert.Equal(t, []byte{3, 0, 0, 0, 0, 0, 0, 0, 1}, records[7].data)
+	assert.Equal(t, []byte{5, 0, 0, 0, 0, 0, 0, 0, 0x2A}, records[8].data)
+	assert.Equal(t, []byte{6, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, records[9].data)
+	rgce, _ := hex.DecodeString("25000000000000000001c002c042010400" + "00000000")
+	assert.Equal(t, append(appendXLSBFloat64([]byte{7, 0, 0, 0, 0, 0, 0, 0}, 3.5),
+		append([]byte{0, 0, 17, 0, 0, 0}, rgce...)...), records[10].data)
+	rgce, _ = hex.DecodeString("1701006100170100620008")
+	assert.Equal(t, append(appendXLSBString([]byte{8, 0, 0, 0, 0, 0, 0, 0}, "ab"),
+		append([]byte{0, 0, 11, 0, 0, 0}, append(rgce, 0, 0, 0, 0)...)...), records[11].data)
+	assert.Equal(t, []byte{9, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 11, 0, 0, 0, 0x44, 0, 0, 0, 0, 1, 0xC0, 0x1E, 0, 0, 0x0D, 0, 0, 0, 0}, records[12].data)
+	assert.Equal(t, []byte{10, 0, 0, 0, 0, 0, 0, 0, 0x2A, 0, 0, 3, 0, 0, 0, 0x41, 10, 0, 0, 0, 0, 0}, records[13].data)
+	assert.Equal(t, []byte{12, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0}, records[15].data)
+	assert.Equal(t, []byte{
+		2, 0, 0, 0, 0, 0, 0, 0, 0x2C, 1, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0,
+	}, records[16].data)
+	assert.Equal(t, appendXLSBFloat64([]byte{1, 0, 0, 0, 0, 0, 0, 0}, 1<<40), records[17].data)
+	assert.Equal(t, appendXLSBFloat64([]byte{2, 0, 0, 0, 0, 0, 0, 0}, math.MaxUint64), records[18].data)
+	assert.Equal(t, []byte{3, 0, 0, 0, 0, 0, 0, 0, 0xFE, 0xFF, 0xFF, 0xFF}, records[19].data)
+	assert.Equal(t, appendXLSBFloat64([]byte{4, 0, 0, 0, 0, 0, 0, 0}, 0.5), records[20].data)
+	// Test the column spans of the row with the cells across the blocks of
+	// 1024 columns
+	assert.Equal(t, []byte{
+		3, 0, 0, 0, 0, 0, 0, 0, 0x2C, 1, 0, 0, 0, 2, 0, 0, 0,
+		0xFF, 3, 0, 0, 0xFF, 3, 0, 0, 0, 4, 0, 0, 0, 4, 0, 0,
+	}, records[21].data)
+
+	// Test the empty worksheet
+	records = readXLSBTestRecords(t, readXLSBTestPart(t, zr, "xl/worksheets/sheet2.bin"))
+	assert.Equal(t, []int{xlsbBrtBeginSheet, xlsbBrtWsDim, xlsbBrtBeginSheetData, xlsbBrtEndSheetData, xlsbBrtEndSheet}, xlsbTestRecordTypes(records))
+	assert.Equal(t, make([]byte, 16), records[1].data)
+
+	// Test using the closed XLSB writer
+	_, err = xw.NewStreamWriter("Sheet3")
+	assert.Equal(t, ErrXLSBWriterClosed, err)
+	assert.Equal(t, ErrXLSBWriterClosed, sw.Flush())
+	assert.Equal(t, ErrXLSBWriterClosed, xw.Close())
+}
+
+func TestXLSBWriterSaveFile(t *testing.T) {
+	file, err := os.Create(filepath.Join("test", "TestXLSBWriter.xlsb"))
+	assert.NoError(t, err)
+	defer file.Close()
+	xw := NewXLSBWriter(file)
+	sw, err := xw.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"ID", "Value", "Total"}))
+	// Test write rows to the temporary file when in-memory chunks data over
+	// the chunk size
+	row := make([]interface{}, 50)
+	for rowID := 2; rowID <= 20000; rowID++ {
+		for colID := 0; colID < 50; colID++ {
+			row[colID] = float64(rowID) + float64(colID)/100
+		}
+		cell, _ := CoordinatesToCellName(1, rowID)
+		assert.NoError(t, sw.SetRow(cell, row))
+	}
+	assert.NotNil(t, sw.rawData.tmp)
+	tempFile := sw.rawData.tmp.Name()
+	assert.NoError(t, xw.Close())
+	_, err = os.Stat(tempFile)
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, ErrXLSBStreamFlushed, sw.SetRow("A20001", []interface{}{1}))
+	fi, err := file.Stat()
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(file, fi.Size())
+	assert.NoError(t, err)
+	records := readXLSBTestRecords(t, readXLSBTestPart(t, zr, "xl/worksheets/sheet1.bin"))
+	assert.Len(t, records, 3+20000+3+19999*50+2)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0x1F, 0x4E, 0, 0, 0, 0, 0, 0, 0x31, 0, 0, 0}, records[1].data)
+	assert.Equal(t, appendXLSBFloat64([]byte{49, 0, 0, 0, 0, 0, 0, 0}, 20000.49), records[len(records)-3].data)
+}
+
+func TestXLSBWriterErrors(t *testing.T) {
+	xw := NewXLSBWriter(io.Discard)
+	assert.Equal(t, ErrXLSBNoSheet, xw.Close())
+	_, err := xw.NewStreamWriter("")
+	assert.Equal(t, ErrSheetNameBlank, err)
+	_, err = xw.NewStreamWriter("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	sw, err := xw.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	_, err = xw.NewStreamWriter("SHEET1")
+	assert.Equal(t, ErrXLSBSheetNameDuplicate, err)
+
+	// Test set row with invalid cell reference and non-ascending row number
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), sw.SetRow("A", []interface{}{1}))
+	assert.NoError(t, sw.SetRow("A2", []interface{}{1}))
+	assert.Equal(t, newStreamSetRowError(2), sw.SetRow("A2", []interface{}{1}))
+	assert.Equal(t, newStreamSetRowError(1), sw.SetRow("A1", []interface{}{1}))
+	assert.Equal(t, ErrColumnNumber, sw.SetRow("XFD3", []interface{}{1, 2}))
+
+	// Test set row with unsupported values
+	for _, val := range []interface{}{
+		time.Now(), time.Second, []RichTextRun{{Text: "a"}}, CellError("#SPILL!"),
+		Cell{StyleID: 1, Value: 1}, Cell{NumFmt: "0.00", Value: 1}, Cell{DisplayText: "a", Value: 1},
+		Cell{RichValueID: 1}, Cell{ResultType: CellTypeNumber, Formula: "1"},
+		Cell{Formula: "1", Value: time.Now()}, Cell{Formula: "1", Value: CellError("#CALC!")},
+	} {
+		assert.EqualError(t, sw.SetRow("B3", []interface{}{1, val}), "unsupported value of cell C3 in the XLSB workbook")
+	}
+	assert.Equal(t, ErrCellCharsLength, sw.SetRow("A3", []interface{}{strings.Repeat("c", TotalCellChars+1)}))
+	assert.Equal(t, ErrCellCharsLength, sw.SetRow("A3", []interface{}{Cell{Formula: "1", Value: strings.Repeat("c", TotalCellChars+1)}}))
+	assert.EqualError(t, sw.SetRow("A3", []interface{}{Cell{Formula: "Sheet2!A1"}}), `unsupported token "Sheet2!A1" in formula "Sheet2!A1" of the XLSB workbook`)
+	// Test the failed row has not been written
+	assert.NoError(t, sw.SetRow("A3", []interface{}{1}))
+	assert.Equal(t, 2, sw.rows-1)
+
+	assert.NoError(t, sw.Flush())
+	assert.Equal(t, ErrXLSBStreamFlushed, sw.Flush())
+	assert.NoError(t, xw.Close())
+
+	// Test write the XLSB workbook with the failed writer
+	xw = NewXLSBWriter(xlsbErrWriter{})
+	sw, err = xw.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{strings.Repeat("c", TotalCellChars)}))
+	assert.EqualError(t, xw.Close(), "write error")
+}
+
+func TestXLSBFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"SUM(A1:B2)":                         "25" + "00000000" + "01000000" + "00c0" + "01c0" + "42010400",
+		"=A1+1":                              "4400000000" + "00c0" + "1e0100" + "03",
+		"-$B$2^2":                            "44010000000100" + "13" + "1e0200" + "07",
+		"(1+2)*3":                            "1e01001e02000315" + "1e030005",
+		`IF(A1,,"x")`:                        "2400000000" + "00c0" + "16" + "170100" + "7800" + "42030100",
+		"IF(A1,1,)":                          "240000000000c01e0100" + "16" + "42030100",
+		"NOW()":                              "414a00",
+		"ROUND(2.5%,1)":                      "1f" + "0000000000000440" + "14" + "1e0100" + "411b00",
+		"TRUE=#N/A":                          "1d01" + "1c2a" + "0b",
+		"COUNT(A:A)":                         "25" + "00000000" + "ffff0f00" + "00c0" + "00c0" + "42010000",
+		"1:$2":                               "45" + "00000000" + "01000000" + "00c0" + "ff3f",
+		"B1&C1<>D1":                          "440000000001c0" + "440000000002c0" + "08" + "440000000003c0" + "0e",
+		"+1-2*3/4>=5":                        "1e0100" + "1e0200" + "1e0300" + "05" + "1e0400" + "06" + "04" + "1e0500" + "0c",
+		"1<2":                                "1e01001e020009",
+		"1<=2":                               "1e01001e02000a",
+		"1>2":                                "1e01001e02000d",
+		"SUM(-1, 2)":                         "1e0100131e0200" + "42020400",
+		"CONCATENATE()":                      "",
+		"ABS(1,2)":                           "",
+		"VLOOKUP(A1)":                        "",
+		"SUM(1":                              "",
+		"1+":                                 "",
+		"(1":                                 "",
+		"1)":                                 "",
+		"{1,2}":                              "",
+		"Name1":                              "",
+		"XFE1":                               "",
+		"A1048577":                           "",
+		"A1:B2:C3":                           "",
+		"A:1":                                "",
+		"A":                                  "",
+		"#SPILL!":                            "",
+		"A1 B1":                              "",
+		`"` + strings.Repeat("c", 256) + `"`: "",
+	} {
+		rgce, err := xlsbFormula(formula)
+		if expected == "" {
+			assert.Error(t, err, formula)
+			continue
+		}
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, hex.EncodeToString(rgce), formula)
+	}
+	rgce, err := xlsbFormula("65536+1.0+1E3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1f000000000000f0401f000000000000f03f031f0000000000408f4003", hex.EncodeToString(rgce))
+}
+
+func TestXLSBNumber(t *testing.T) {
+	for _, val := range []interface{}{
+		int(1), int8(1), int16(1), int32(1), int64(1), uint(1), uint8(1),
+		uint16(1), uint32(1), uint64(1), float32(1), float64(1),
+	} {
+		f, ok := xlsbNumber(val)
+		assert.True(t, ok)
+		assert.Equal(t, 1.0, f)
+	}
+	_, ok := xlsbNumber("1")
+	assert.False(t, ok)
+	assert.Equal(t, byte(0), xlsbBool(false))
+}
+
+func TestAppendXLSBRecord(t *testing.T) {
+	assert.Equal(t, []byte{0x00, 0x00}, appendXLSBRecord(nil, xlsbBrtRowHdr, nil))
+	assert.Equal(t, []byte{0x96, 0x02, 0x01, 0xFF}, appendXLSBRecord(nil, xlsbBrtBeginStyleSheet, []byte{0xFF}))
+	data := make([]byte, 200)
+	assert.Equal(t, append([]byte{0x9C, 0x01, 0xC8, 0x01}, data...), appendXLSBRecord(nil, xlsbBrtBundleSh, data))
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFF, 0x7F}, appendXLSBVarint(nil, 1<<28-1))
+	s := appendXLSBString(nil, "a😀")
+	assert.Equal(t, uint32(3), binary.LittleEndian.Uint32(s))
+	assert.Equal(t, utf16.Encode([]rune("a😀"))[1], binary.LittleEndian.Uint16(s[6:]))
+}
+
+// readXLSBTestPart provides a function to read the part of the XLSB workbook
+// package for testing.
+func readXLSBTestPart(t *testing.T, zr *zip.Reader, name string) []byte {
+	rc, err := zr.Open(name)
+	assert.NoError(t, err)
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	assert.NoError(t, err)
+	return data
+}
+
+// readXLSBTestRecords provides a function to parse the binary records of the
+// XLSB workbook part for testing.
+func readXLSBTestRecords(t *testing.T, data []byte) []xlsbTestRecord {
+	var records []xlsbTestRecord
+	readVarint := func(limit int) int {
+		var n int
+		for i := 0; i < limit; i++ {
+			b := data[0]
+			data = data[1:]
+			n |= int(b&0x7F) << (7 * i)
+			if b&0x80 == 0 {
+				break
+			}
+		}
+		return n
+	}
+	for len(data) > 0 {
+		typ, size := readVarint(2), readVarint(4)
+		if !assert.GreaterOrEqual(t, len(data), size) {
+			break
+		}
+		records = append(records, xlsbTestRecord{typ: typ, data: data[:size]})
+		data = data[size:]
+	}
+	return records
+}
+
+// xlsbTestRecordTypes returns the record types of the binary records for
+// testing.
+func xlsbTestRecordTypes(records []xlsbTestRecord) []int {
+	var types []int
+	for _, record := range records {
+		types = append(types, record.typ)
+	}
+	return types
+}
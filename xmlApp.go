@@ -73,3 +73,20 @@ type xlsxVectorLpstr struct {
 type xlsxDigSig struct {
 	Content string `xml:",innerxml"`
 }
+
+// xlsxVector directly maps the vector element in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes, it
+// used for decoding the heading pairs and titles of parts of the document.
+type xlsxVector struct {
+	Size     int           `xml:"size,attr"`
+	BaseType string        `xml:"baseType,attr"`
+	Variant  []xlsxVariant `xml:"variant"`
+	Lpstr    []string      `xml:"lpstr"`
+}
+
+// xlsxVariant directly maps the variant element in the namespace
+// http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes.
+type xlsxVariant struct {
+	Lpstr *string `xml:"lpstr"`
+	I4    *int    `xml:"i4"`
+}
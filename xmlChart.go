@@ -83,29 +83,32 @@ type aBodyPr struct {
 	Anchor           string  `xml:"anchor,attr,omitempty"`
 	AnchorCtr        bool    `xml:"anchorCtr,attr"`
 	Rot              int     `xml:"rot,attr"`
-	BIns             float64 `xml:"bIns,attr,omitempty"`
+	BIns             *int    `xml:"bIns,attr"`
 	CompatLnSpc      bool    `xml:"compatLnSpc,attr,omitempty"`
 	ForceAA          bool    `xml:"forceAA,attr,omitempty"`
 	FromWordArt      bool    `xml:"fromWordArt,attr,omitempty"`
 	HorzOverflow     string  `xml:"horzOverflow,attr,omitempty"`
-	LIns             float64 `xml:"lIns,attr,omitempty"`
+	LIns             *int    `xml:"lIns,attr"`
 	NumCol           int     `xml:"numCol,attr,omitempty"`
-	RIns             float64 `xml:"rIns,attr,omitempty"`
+	RIns             *int    `xml:"rIns,attr"`
 	RtlCol           bool    `xml:"rtlCol,attr,omitempty"`
 	SpcCol           int     `xml:"spcCol,attr,omitempty"`
 	SpcFirstLastPara bool    `xml:"spcFirstLastPara,attr"`
-	TIns             float64 `xml:"tIns,attr,omitempty"`
+	TIns             *int    `xml:"tIns,attr"`
 	Upright          bool    `xml:"upright,attr,omitempty"`
 	Vert             string  `xml:"vert,attr,omitempty"`
 	VertOverflow     string  `xml:"vertOverflow,attr,omitempty"`
 	Wrap             string  `xml:"wrap,attr,omitempty"`
+	NoAutofit        *string `xml:"a:noAutofit"`
+	NormAutofit      *string `xml:"a:normAutofit"`
+	SpAutoFit        *string `xml:"a:spAutoFit"`
 }
 
 // aP (Paragraph) directly maps the a:p element. This element specifies a
 // paragraph of content in the document.
 type aP struct {
 	PPr        *aPPr        `xml:"a:pPr"`
-	R          *aR          `xml:"a:r"`
+	R          []*aR        `xml:"a:r"`
 	EndParaRPr *aEndParaRPr `xml:"a:endParaRPr"`
 }
 
@@ -116,7 +119,41 @@ type aP struct {
 // formatting, since they are directly applied to the paragraph and supersede
 // any formatting from styles.
 type aPPr struct {
-	DefRPr aRPr `xml:"a:defRPr"`
+	Algn      string           `xml:"algn,attr,omitempty"`
+	Indent    int              `xml:"indent,attr,omitempty"`
+	Lvl       int              `xml:"lvl,attr,omitempty"`
+	MarL      int              `xml:"marL,attr,omitempty"`
+	LnSpc     *aTextSpacing    `xml:"a:lnSpc"`
+	SpcBef    *aTextSpacing    `xml:"a:spcBef"`
+	SpcAft    *aTextSpacing    `xml:"a:spcAft"`
+	BuFont    *xlsxCTTextFont  `xml:"a:buFont"`
+	BuNone    *string          `xml:"a:buNone"`
+	BuAutoNum *aTextAutoNumber `xml:"a:buAutoNum"`
+	BuChar    *aTextCharBullet `xml:"a:buChar"`
+	DefRPr    *aRPr            `xml:"a:defRPr"`
+}
+
+// aTextSpacing directly maps the a:lnSpc, a:spcBef and a:spcAft elements.
+// These elements specify the vertical line spacing and the amount of the
+// vertical white space that will be present before and after a paragraph,
+// in percentage of the text size or in points.
+type aTextSpacing struct {
+	SpcPct *attrValInt `xml:"a:spcPct"`
+	SpcPts *attrValInt `xml:"a:spcPts"`
+}
+
+// aTextAutoNumber (Auto-Numbered Bullet) directly maps the a:buAutoNum
+// element. This element specifies that automatic numbered bullet points
+// should be applied to a paragraph.
+type aTextAutoNumber struct {
+	Type    string `xml:"type,attr"`
+	StartAt int    `xml:"startAt,attr,omitempty"`
+}
+
+// aTextCharBullet (Character Bullet) directly maps the a:buChar element. This
+// element specifies that a character be applied to a set of bullets.
+type aTextCharBullet struct {
+	Char string `xml:"char,attr"`
 }
 
 // aSolidFill (Solid Fill) directly maps the solidFill element. This element
@@ -317,6 +354,7 @@ type cPlotArea struct {
 	Surface3DChart []*cCharts `xml:"surface3DChart"`
 	SurfaceChart   []*cCharts `xml:"surfaceChart"`
 	CatAx          []*cAxs    `xml:"catAx"`
+	DateAx         []*cDateAx `xml:"dateAx"`
 	ValAx          []*cAxs    `xml:"valAx"`
 	SerAx          []*cAxs    `xml:"serAx"`
 	SpPr           *cSpPr     `xml:"spPr"`
@@ -324,23 +362,34 @@ type cPlotArea struct {
 
 // cCharts specifies the common element of the chart.
 type cCharts struct {
-	BarDir       *attrValString `xml:"barDir"`
-	BubbleScale  *attrValFloat  `xml:"bubbleScale"`
-	Grouping     *attrValString `xml:"grouping"`
-	RadarStyle   *attrValString `xml:"radarStyle"`
-	ScatterStyle *attrValString `xml:"scatterStyle"`
-	OfPieType    *attrValString `xml:"ofPieType"`
-	VaryColors   *attrValBool   `xml:"varyColors"`
-	Wireframe    *attrValBool   `xml:"wireframe"`
-	Ser          *[]cSer        `xml:"ser"`
-	SplitPos     *attrValInt    `xml:"splitPos"`
-	SerLines     *attrValString `xml:"serLines"`
-	DLbls        *cDLbls        `xml:"dLbls"`
-	Shape        *attrValString `xml:"shape"`
-	HoleSize     *attrValInt    `xml:"holeSize"`
-	Smooth       *attrValBool   `xml:"smooth"`
-	Overlap      *attrValInt    `xml:"overlap"`
-	AxID         []*attrValInt  `xml:"axId"`
+	BarDir        *attrValString `xml:"barDir"`
+	BubbleScale   *attrValFloat  `xml:"bubbleScale"`
+	Grouping      *attrValString `xml:"grouping"`
+	RadarStyle    *attrValString `xml:"radarStyle"`
+	ScatterStyle  *attrValString `xml:"scatterStyle"`
+	OfPieType     *attrValString `xml:"ofPieType"`
+	VaryColors    *attrValBool   `xml:"varyColors"`
+	Wireframe     *attrValBool   `xml:"wireframe"`
+	Ser           *[]cSer        `xml:"ser"`
+	DLbls         *cDLbls        `xml:"dLbls"`
+	GapWidth      *attrValInt    `xml:"gapWidth"`
+	SplitType     *attrValString `xml:"splitType"`
+	SplitPos      *attrValFloat  `xml:"splitPos"`
+	CustSplit     *cCustSplit    `xml:"custSplit"`
+	SecondPieSize *attrValInt    `xml:"secondPieSize"`
+	SerLines      *cChartLines   `xml:"serLines"`
+	Shape         *attrValString `xml:"shape"`
+	HoleSize      *attrValInt    `xml:"holeSize"`
+	Smooth        *attrValBool   `xml:"smooth"`
+	Overlap       *attrValInt    `xml:"overlap"`
+	AxID          []*attrValInt  `xml:"axId"`
+}
+
+// cCustSplit directly maps the custSplit element. This element specifies the
+// data points which shall be in the second plot of the pie of pie or bar of
+// pie chart with the custom split type.
+type cCustSplit struct {
+	SecondPiePt []*attrValInt `xml:"secondPiePt"`
 }
 
 // cAxs directly maps the catAx and valAx element.
@@ -371,6 +420,34 @@ type cAxs struct {
 	NoMultiLvlLbl  *attrValBool   `xml:"noMultiLvlLbl"`
 }
 
+// cDateAx directly maps the dateAx element. This element specifies a date
+// axis, the category values of the chart series on this axis are the serial
+// date numbers.
+type cDateAx struct {
+	AxID           *attrValInt    `xml:"axId"`
+	Scaling        *cScaling      `xml:"scaling"`
+	Delete         *attrValBool   `xml:"delete"`
+	AxPos          *attrValString `xml:"axPos"`
+	MajorGridlines *cChartLines   `xml:"majorGridlines"`
+	MinorGridlines *cChartLines   `xml:"minorGridlines"`
+	Title          *cTitle        `xml:"title"`
+	NumFmt         *cNumFmt       `xml:"numFmt"`
+	MajorTickMark  *attrValString `xml:"majorTickMark"`
+	MinorTickMark  *attrValString `xml:"minorTickMark"`
+	TickLblPos     *attrValString `xml:"tickLblPos"`
+	SpPr           *cSpPr         `xml:"spPr"`
+	TxPr           *cTxPr         `xml:"txPr"`
+	CrossAx        *attrValInt    `xml:"crossAx"`
+	Crosses        *attrValString `xml:"crosses"`
+	Auto           *attrValBool   `xml:"auto"`
+	LblOffset      *attrValInt    `xml:"lblOffset"`
+	BaseTimeUnit   *attrValString `xml:"baseTimeUnit"`
+	MajorUnit      *attrValFloat  `xml:"majorUnit"`
+	MajorTimeUnit  *attrValString `xml:"majorTimeUnit"`
+	MinorUnit      *attrValFloat  `xml:"minorUnit"`
+	MinorTimeUnit  *attrValString `xml:"minorTimeUnit"`
+}
+
 // cChartLines directly maps the chart lines content model.
 type cChartLines struct {
 	SpPr *cSpPr `xml:"spPr"`
@@ -399,10 +476,12 @@ type cSer struct {
 	Order            *attrValInt  `xml:"order"`
 	Tx               *cTx         `xml:"tx"`
 	SpPr             *cSpPr       `xml:"spPr"`
+	Marker           *cMarker     `xml:"marker"`
 	DPt              []*cDPt      `xml:"dPt"`
 	DLbls            *cDLbls      `xml:"dLbls"`
-	Marker           *cMarker     `xml:"marker"`
 	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
+	Trendline        *cTrendline  `xml:"trendline"`
+	ErrBars          []*cErrBars  `xml:"errBars"`
 	Cat              *cCat        `xml:"cat"`
 	Val              *cVal        `xml:"val"`
 	XVal             *cCat        `xml:"xVal"`
@@ -412,6 +491,41 @@ type cSer struct {
 	Bubble3D         *attrValBool `xml:"bubble3D"`
 }
 
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline.
+type cTrendline struct {
+	Name          string         `xml:"name,omitempty"`
+	SpPr          *cSpPr         `xml:"spPr"`
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	Forward       *attrValFloat  `xml:"forward"`
+	Backward      *attrValFloat  `xml:"backward"`
+	Intercept     *attrValFloat  `xml:"intercept"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
+	TrendlineLbl  *cTrendlineLbl `xml:"trendlineLbl"`
+}
+
+// cTrendlineLbl (Trendline Label) directly maps the trendlineLbl element. This
+// element specifies a trendline label.
+type cTrendlineLbl struct {
+	NumFmt *cNumFmt `xml:"numFmt"`
+}
+
+// cErrBars (Error Bars) directly maps the errBars element. This element
+// specifies the error bars.
+type cErrBars struct {
+	ErrDir     *attrValString `xml:"errDir"`
+	ErrBarType *attrValString `xml:"errBarType"`
+	ErrValType *attrValString `xml:"errValType"`
+	NoEndCap   *attrValBool   `xml:"noEndCap"`
+	Plus       *cVal          `xml:"plus"`
+	Minus      *cVal          `xml:"minus"`
+	Val        *attrValFloat  `xml:"val"`
+	SpPr       *cSpPr         `xml:"spPr"`
+}
+
 // cMarker (Marker) directly maps the marker element. This element specifies a
 // data marker.
 type cMarker struct {
@@ -423,14 +537,17 @@ type cMarker struct {
 // cDPt (Data Point) directly maps the dPt element. This element specifies a
 // single data point.
 type cDPt struct {
-	IDx      *attrValInt  `xml:"idx"`
-	Bubble3D *attrValBool `xml:"bubble3D"`
-	SpPr     *cSpPr       `xml:"spPr"`
+	IDx       *attrValInt  `xml:"idx"`
+	Marker    *cMarker     `xml:"marker"`
+	Bubble3D  *attrValBool `xml:"bubble3D"`
+	Explosion *attrValInt  `xml:"explosion"`
+	SpPr      *cSpPr       `xml:"spPr"`
 }
 
 // cCat (Category Axis Data) directly maps the cat element. This element
 // specifies the data used for the category axis.
 type cCat struct {
+	NumRef *cNumRef `xml:"numRef"`
 	StrRef *cStrRef `xml:"strRef"`
 }
 
@@ -445,8 +562,8 @@ type cStrRef struct {
 // cStrCache (String Cache) directly maps the strCache element. This element
 // specifies the last string data used for a chart.
 type cStrCache struct {
-	Pt      []*cPt      `xml:"pt"`
 	PtCount *attrValInt `xml:"ptCount"`
+	Pt      []*cPt      `xml:"pt"`
 }
 
 // cPt directly maps the pt element. This element specifies data for a
@@ -473,8 +590,8 @@ type cNumRef struct {
 // last data shown on the chart for a series.
 type cNumCache struct {
 	FormatCode string      `xml:"formatCode"`
-	Pt         []*cPt      `xml:"pt"`
 	PtCount    *attrValInt `xml:"ptCount"`
+	Pt         []*cPt      `xml:"pt"`
 }
 
 // cDLbls (Data Labels) directly maps the dLbls element. This element serves
@@ -496,11 +613,21 @@ type cDLbls struct {
 // cLegend (Legend) directly maps the legend element. This element specifies
 // the legend.
 type cLegend struct {
-	Layout    *string        `xml:"layout"`
-	LegendPos *attrValString `xml:"legendPos"`
-	Overlay   *attrValBool   `xml:"overlay"`
-	SpPr      *cSpPr         `xml:"spPr"`
-	TxPr      *cTxPr         `xml:"txPr"`
+	LegendPos   *attrValString  `xml:"legendPos"`
+	LegendEntry []*cLegendEntry `xml:"legendEntry"`
+	Layout      *string         `xml:"layout"`
+	Overlay     *attrValBool    `xml:"overlay"`
+	SpPr        *cSpPr          `xml:"spPr"`
+	TxPr        *cTxPr          `xml:"txPr"`
+}
+
+// cLegendEntry (Legend Entry) directly maps the legendEntry element. This
+// element specifies a legend entry, which can be deleted from the legend
+// without affecting the plotted series.
+type cLegendEntry struct {
+	IDx    *attrValInt  `xml:"idx"`
+	Delete *attrValBool `xml:"delete"`
+	TxPr   *cTxPr       `xml:"txPr"`
 }
 
 // cPrintSettings directly maps the printSettings element. This element
@@ -522,6 +649,207 @@ type cPageMargins struct {
 	T      float64 `xml:"t,attr"`
 }
 
+// cxChartSpace directly maps the cx:chartSpace element. The chart space is the
+// root element of the chart extension part, which used for the chart types
+// introduced in Excel 2016, such as box and whisker chart.
+type cxChartSpace struct {
+	XMLName   xml.Name    `xml:"cx:chartSpace"`
+	XMLNSa    string      `xml:"xmlns:a,attr"`
+	XMLNSr    string      `xml:"xmlns:r,attr"`
+	XMLNScx   string      `xml:"xmlns:cx,attr"`
+	ChartData cxChartData `xml:"cx:chartData"`
+	Chart     cxChart     `xml:"cx:chart"`
+	SpPr      *cSpPr      `xml:"cx:spPr"`
+	TxPr      *cTxPr      `xml:"cx:txPr"`
+}
+
+// cxChartData directly maps the cx:chartData element. This element specifies
+// the data used by the chart.
+type cxChartData struct {
+	Data []*cxData `xml:"cx:data"`
+}
+
+// cxData directly maps the cx:data element. This element specifies a set of
+// data dimensions referenced by the chart series.
+type cxData struct {
+	ID     int          `xml:"id,attr"`
+	StrDim *cxDimension `xml:"cx:strDim"`
+	NumDim *cxDimension `xml:"cx:numDim"`
+}
+
+// cxDimension directly maps the cx:strDim and cx:numDim element. This element
+// specifies a string or numeric data dimension by formula.
+type cxDimension struct {
+	Type string `xml:"type,attr"`
+	F    string `xml:"cx:f"`
+}
+
+// cxChart directly maps the cx:chart element. This element specifies the
+// title, plot area and legend of the chart.
+type cxChart struct {
+	Title    *cxTitle   `xml:"cx:title"`
+	PlotArea cxPlotArea `xml:"cx:plotArea"`
+	Legend   *cxLegend  `xml:"cx:legend"`
+}
+
+// cxTitle directly maps the cx:title element. This element specifies the
+// title of the chart.
+type cxTitle struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+	Tx      cxTx   `xml:"cx:tx"`
+}
+
+// cxTx directly maps the cx:tx element. This element specifies text by rich
+// text or formula reference.
+type cxTx struct {
+	TxData *cxTxData `xml:"cx:txData"`
+	Rich   *cRich    `xml:"cx:rich"`
+}
+
+// cxTxData directly maps the cx:txData element. This element specifies text
+// by formula reference.
+type cxTxData struct {
+	F string `xml:"cx:f"`
+}
+
+// cxPlotArea directly maps the cx:plotArea element. This element specifies
+// the plot area region and axes of the chart.
+type cxPlotArea struct {
+	PlotAreaRegion cxPlotAreaRegion `xml:"cx:plotAreaRegion"`
+	Axis           []*cxAxis        `xml:"cx:axis"`
+	SpPr           *cSpPr           `xml:"cx:spPr"`
+}
+
+// cxPlotAreaRegion directly maps the cx:plotAreaRegion element. This element
+// specifies the series of the chart.
+type cxPlotAreaRegion struct {
+	Series []*cxSeries `xml:"cx:series"`
+}
+
+// cxSeries directly maps the cx:series element. This element specifies a
+// series on the chart.
+type cxSeries struct {
+	LayoutID   string        `xml:"layoutId,attr"`
+	OwnerIdx   *int          `xml:"ownerIdx,attr"`
+	UniqueID   string        `xml:"uniqueId,attr,omitempty"`
+	FormatIdx  int           `xml:"formatIdx,attr"`
+	Tx         *cxTx         `xml:"cx:tx"`
+	SpPr       *cSpPr        `xml:"cx:spPr"`
+	DataPt     []*cxDataPt   `xml:"cx:dataPt"`
+	DataLabels *cxDataLabels `xml:"cx:dataLabels"`
+	DataID     *attrValInt   `xml:"cx:dataId"`
+	LayoutPr   *cxLayoutPr   `xml:"cx:layoutPr"`
+	AxisID     []attrValInt  `xml:"cx:axisId"`
+}
+
+// cxDataPt directly maps the cx:dataPt element. This element specifies the
+// format of an individual data point.
+type cxDataPt struct {
+	Idx  int    `xml:"idx,attr"`
+	SpPr *cSpPr `xml:"cx:spPr"`
+}
+
+// cxDataLabels directly maps the cx:dataLabels element. This element
+// specifies the settings for the data labels of the series.
+type cxDataLabels struct {
+	Pos        string                 `xml:"pos,attr,omitempty"`
+	Visibility *cxDataLabelVisibility `xml:"cx:visibility"`
+}
+
+// cxDataLabelVisibility directly maps the cx:visibility element of the data
+// labels. This element specifies the visibility of the data label contents.
+type cxDataLabelVisibility struct {
+	SeriesName   bool `xml:"seriesName,attr"`
+	CategoryName bool `xml:"categoryName,attr"`
+	Value        bool `xml:"value,attr"`
+}
+
+// cxLayoutPr directly maps the cx:layoutPr element. This element specifies
+// the layout properties of the series.
+type cxLayoutPr struct {
+	Visibility  *cxVisibility `xml:"cx:visibility"`
+	Aggregation *string       `xml:"cx:aggregation"`
+	Binning     *cxBinning    `xml:"cx:binning"`
+	Statistics  *cxStatistics `xml:"cx:statistics"`
+	Subtotals   *cxSubtotals  `xml:"cx:subtotals"`
+}
+
+// cxBinning directly maps the cx:binning element. This element specifies the
+// binning settings of the histogram and Pareto chart series.
+type cxBinning struct {
+	IntervalClosed string        `xml:"intervalClosed,attr,omitempty"`
+	Underflow      string        `xml:"underflow,attr,omitempty"`
+	Overflow       string        `xml:"overflow,attr,omitempty"`
+	BinSize        *attrValFloat `xml:"cx:binSize"`
+	BinCount       *attrValInt   `xml:"cx:binCount"`
+}
+
+// cxVisibility directly maps the cx:visibility element. This element
+// specifies the visibility of the series elements.
+type cxVisibility struct {
+	ConnectorLines *bool `xml:"connectorLines,attr"`
+	MeanLine       *bool `xml:"meanLine,attr"`
+	MeanMarker     *bool `xml:"meanMarker,attr"`
+	Nonoutliers    *bool `xml:"nonoutliers,attr"`
+	Outliers       *bool `xml:"outliers,attr"`
+}
+
+// cxSubtotals directly maps the cx:subtotals element. This element specifies
+// the indexes of the data points which are subtotals in the waterfall chart.
+type cxSubtotals struct {
+	Idx []attrValInt `xml:"cx:idx"`
+}
+
+// cxStatistics directly maps the cx:statistics element. This element
+// specifies the quartile calculation method of the series.
+type cxStatistics struct {
+	QuartileMethod string `xml:"quartileMethod,attr"`
+}
+
+// cxAxis directly maps the cx:axis element. This element specifies an axis
+// of the chart.
+type cxAxis struct {
+	ID             int           `xml:"id,attr"`
+	Hidden         bool          `xml:"hidden,attr,omitempty"`
+	CatScaling     *cxCatScaling `xml:"cx:catScaling"`
+	ValScaling     *cxValScaling `xml:"cx:valScaling"`
+	Units          *cxUnits      `xml:"cx:units"`
+	MajorGridlines *string       `xml:"cx:majorGridlines"`
+	MinorGridlines *string       `xml:"cx:minorGridlines"`
+	TickLabels     *string       `xml:"cx:tickLabels"`
+	TxPr           *cTxPr        `xml:"cx:txPr"`
+}
+
+// cxCatScaling directly maps the cx:catScaling element. This element
+// specifies the scaling of the category axis.
+type cxCatScaling struct {
+	GapWidth string `xml:"gapWidth,attr,omitempty"`
+}
+
+// cxValScaling directly maps the cx:valScaling element. This element
+// specifies the scaling of the value axis.
+type cxValScaling struct {
+	Max       string `xml:"max,attr,omitempty"`
+	Min       string `xml:"min,attr,omitempty"`
+	MajorUnit string `xml:"majorUnit,attr,omitempty"`
+}
+
+// cxUnits directly maps the cx:units element. This element specifies the
+// display units of the value axis.
+type cxUnits struct {
+	Unit string `xml:"unit,attr,omitempty"`
+}
+
+// cxLegend directly maps the cx:legend element. This element specifies the
+// legend of the chart.
+type cxLegend struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+}
+
 // ChartNumFmt directly maps the number format settings of the chart.
 type ChartNumFmt struct {
 	CustomNumFmt string
@@ -530,10 +858,15 @@ type ChartNumFmt struct {
 
 // ChartAxis directly maps the format settings of the chart axis.
 type ChartAxis struct {
+	Type              string
 	None              bool
 	MajorGridLines    bool
 	MinorGridLines    bool
 	MajorUnit         float64
+	MinorUnit         float64
+	BaseTimeUnit      string
+	MajorTimeUnit     string
+	MinorTimeUnit     string
 	TickLabelPosition ChartTickLabelPositionType
 	TickLabelSkip     int
 	ReverseOrder      bool
@@ -545,9 +878,20 @@ type ChartAxis struct {
 	LogBase           float64
 	NumFmt            ChartNumFmt
 	Title             []RichTextRun
+	TitleFormat       ChartTitle
 	axID              int
 }
 
+// ChartTitle directly maps the format settings of the chart title and the
+// axis title.
+type ChartTitle struct {
+	CellRef  string
+	Font     *Font
+	Rotation *int
+	Overlay  bool
+	ref      *chartSeriesRef
+}
+
 // ChartDimension directly maps the dimension of the chart.
 type ChartDimension struct {
 	Width  uint
@@ -557,6 +901,11 @@ type ChartDimension struct {
 // ChartPlotArea directly maps the format settings of the plot area.
 type ChartPlotArea struct {
 	SecondPlotValues int
+	SplitType        ChartSplitType
+	SplitValue       float64
+	SecondPlotPoints []int
+	SecondPlotSize   uint
+	SeriesLines      ChartLine
 	ShowBubbleSize   bool
 	ShowCatName      bool
 	ShowLeaderLines  bool
@@ -575,31 +924,101 @@ type Chart struct {
 	Dimension    ChartDimension
 	Legend       ChartLegend
 	Title        []RichTextRun
+	TitleFormat  ChartTitle
 	VaryColors   *bool
 	XAxis        ChartAxis
 	YAxis        ChartAxis
+	YAxis2       ChartAxis
+	ZAxis        ChartAxis
 	PlotArea     ChartPlotArea
 	Fill         Fill
 	Border       ChartLine
 	ShowBlanksAs string
+	GapWidth     *uint
+	Overlap      *int
 	BubbleSize   int
 	HoleSize     int
+	BoxWhisker   ChartBoxWhisker
+	Waterfall    ChartWaterfall
+	Funnel       ChartFunnel
+	Histogram    ChartHistogram
+	ChartSheet   *ChartSheetOptions
 	order        int
 }
 
+// ChartSheetOptions directly maps the settings of the chartsheet which
+// created by the AddChartSheet function.
+type ChartSheetOptions struct {
+	Index        *int
+	ZoomScale    *float64
+	PageLayout   *PageLayoutOptions
+	HeaderFooter *HeaderFooterOptions
+}
+
+// ChartBoxWhisker directly maps the format settings of the box and whisker
+// chart.
+type ChartBoxWhisker struct {
+	ShowInnerPoints   bool
+	ShowOutlierPoints bool
+	ShowMeanMarkers   bool
+	ShowMeanLine      bool
+	QuartileMethod    string
+}
+
+// ChartHistogram directly maps the binning settings of the histogram and
+// Pareto chart.
+type ChartHistogram struct {
+	ByCategory bool
+	BinWidth   float64
+	BinCount   int
+	Overflow   *float64
+	Underflow  *float64
+}
+
+// ChartFunnel directly maps the format settings of the funnel chart.
+type ChartFunnel struct {
+	GapWidth *uint
+}
+
+// ChartWaterfall directly maps the format settings of the waterfall chart.
+type ChartWaterfall struct {
+	Totals             []int
+	ShowConnectorLines bool
+	IncreaseFill       Fill
+	DecreaseFill       Fill
+	TotalFill          Fill
+	values             [][]float64
+}
+
 // ChartLegend directly maps the format settings of the chart legend.
 type ChartLegend struct {
-	Position      string
-	ShowLegendKey bool
+	Position       string
+	ShowLegendKey  bool
+	Font           *Font
+	Fill           Fill
+	Border         ChartLine
+	Overlay        bool
+	DeletedEntries []int
 }
 
 // ChartMarker directly maps the format settings of the chart marker.
 type ChartMarker struct {
 	Fill   Fill
+	Border ChartLine
 	Symbol string
 	Size   int
 }
 
+// ChartDataPoint directly maps the format settings of the single data point
+// of the chart series.
+type ChartDataPoint struct {
+	Index     int
+	Fill      Fill
+	Border    ChartLine
+	Marker    ChartMarker
+	Explosion int
+}
+
 // ChartLine directly maps the format settings of the chart line.
 type ChartLine struct {
 	Type   ChartLineType
@@ -616,5 +1035,155 @@ type ChartSeries struct {
 	Fill              Fill
 	Line              ChartLine
 	Marker            ChartMarker
+	DataPoints        []ChartDataPoint
 	DataLabelPosition ChartDataLabelPositionType
+	Type              ChartType
+	SecondaryAxis     bool
+	Trendline         ChartTrendline
+	ErrorBars         ChartErrorBars
+	categories        *chartSeriesRef
+	values            *chartSeriesRef
+}
+
+// chartSeriesRef directly maps the formula and the cached values of the chart
+// series data which referenced by the defined name or the table.
+type chartSeriesRef struct {
+	formula string
+	cache   []string
+}
+
+// chartPartElements defines the positions of the elements in the chart part
+// which can be updated by the UpdateChart function. Each position is the pair
+// of the start and end offset of the element.
+type chartPartElements struct {
+	prefix           string
+	namespace        string
+	namespaceA       string
+	chartStart       int
+	title            []int
+	autoTitleDeleted []int
+	series           []*chartPartSeries
+	groupAxIDs       [][]int
+	scalings         map[int]*chartPartScaling
+}
+
+// chartPartSeries defines the positions of the elements of the series in the
+// chart part. The start and end specify the offsets of the content of the
+// series element.
+type chartPartSeries struct {
+	start   int
+	end     int
+	order   []int
+	tx      []int
+	cat     []int
+	val     []int
+	catName string
+	valName string
+	catNum  bool
+}
+
+// chartPartScaling defines the positions of the elements of the axis scaling
+// in the chart part. The start specifies the offset of the content of the
+// scaling element.
+type chartPartScaling struct {
+	start       int
+	logBase     []int
+	orientation []int
+	max         []int
+	min         []int
+}
+
+// chartPartEdit defines the content to replace the range of the chart part.
+type chartPartEdit struct {
+	start   int
+	end     int
+	content []byte
+}
+
+// ChartTrendline directly maps the format settings of the chart series
+// trendline.
+type ChartTrendline struct {
+	Type            ChartTrendlineType
+	Name            string
+	Order           int
+	Period          int
+	Forward         float64
+	Backward        float64
+	Intercept       *float64
+	DisplayEquation bool
+	DisplayRSquared bool
+	Fill            Fill
+	Line            ChartLine
+}
+
+// ChartErrorBars directly maps the format settings of the chart series error
+// bars.
+type ChartErrorBars struct {
+	Type        ChartErrorBarsType
+	Direction   ChartErrorBarsDirection
+	Value       float64
+	Plus        string
+	Minus       string
+	NoEndCap    bool
+	Fill        Fill
+	Line        ChartLine
+	plusValues  []float64
+	minusValues []float64
+}
+
+// ChartAnchor directly maps the position of the chart in the worksheet. The
+// Type is one of "absolute", "oneCell" and "twoCell". The From and To specify
+// the cell references of the top-left and bottom-right corner of the cell
+// anchored chart, and the OffsetX and OffsetY specify the offsets in pixels
+// from the top-left corner of the From cell. The X and Y specify the position
+// in pixels of the absolute anchored chart. The Width and Height specify the
+// size of the chart in pixels.
+type ChartAnchor struct {
+	Type    string
+	From    string
+	To      string
+	OffsetX int
+	OffsetY int
+	X       int
+	Y       int
+	Width   int
+	Height  int
+}
+
+// ChartSeriesCache directly maps the cached values of the chart series which
+// last calculated by the spreadsheet application.
+type ChartSeriesCache struct {
+	Name       string
+	Categories []string
+	Values     []string
+	Sizes      []string
+}
+
+// ChartPatch directly maps the settings of the chart to be updated by the
+// UpdateChart function. The chart will be identified by the ChartName if it
+// isn't empty. Each item of the Series specifies the new Name, Categories and
+// Values formula of the series with the same index in the chart, the empty
+// formula will be kept unchanged, and the other fields of the series will be
+// ignored. The Title replaces the chart title if it isn't empty. The Maximum
+// and Minimum of the XAxis, YAxis and YAxis2 replace the axis bounds if they
+// aren't nil, and the other fields of the axis will be ignored.
+type ChartPatch struct {
+	ChartName string
+	Series    []ChartSeries
+	Title     []RichTextRun
+	XAxis     ChartAxis
+	YAxis     ChartAxis
+	YAxis2    ChartAxis
+}
+
+// ChartInfo directly maps the chart definition read from the worksheet. The
+// Chart can be used to add the same chart to other worksheet, and the
+// SeriesCache specifies the cached values of each series of the Chart.
+type ChartInfo struct {
+	Name        string
+	Anchor      ChartAnchor
+	DrawingPart string
+	ChartPart   string
+	Chart       Chart
+	SeriesCache []ChartSeriesCache
 }
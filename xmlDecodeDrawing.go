@@ -19,6 +19,7 @@ import "encoding/xml"
 // element. It moves with cells and its extents are in EMU units.
 type decodeCellAnchor struct {
 	EditAs           string                  `xml:"editAs,attr,omitempty"`
+	Pos              *decodePos              `xml:"pos"`
 	From             *decodeFrom             `xml:"from"`
 	To               *decodeTo               `xml:"to"`
 	Sp               *decodeSp               `xml:"sp"`
@@ -108,6 +109,7 @@ type decodeWsDr struct {
 	Xdr              string              `xml:"xmlns xdr,attr"`
 	R                string              `xml:"xmlns r,attr"`
 	AlternateContent []*xlsxInnerXML     `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	AbsoluteAnchor   []*decodeCellAnchor `xml:"absoluteAnchor,omitempty"`
 	OneCellAnchor    []*decodeCellAnchor `xml:"oneCellAnchor,omitempty"`
 	TwoCellAnchor    []*decodeCellAnchor `xml:"twoCellAnchor,omitempty"`
 }
@@ -117,11 +119,19 @@ type decodeWsDr struct {
 // information that does not affect the appearance of the picture to be
 // stored.
 type decodeCNvPr struct {
-	XMLName xml.Name `xml:"cNvPr"`
-	ID      int      `xml:"id,attr"`
-	Name    string   `xml:"name,attr"`
-	Descr   string   `xml:"descr,attr"`
-	Title   string   `xml:"title,attr,omitempty"`
+	XMLName    xml.Name          `xml:"cNvPr"`
+	ID         int               `xml:"id,attr"`
+	Name       string            `xml:"name,attr"`
+	Descr      string            `xml:"descr,attr"`
+	Title      string            `xml:"title,attr,omitempty"`
+	HlinkClick *decodeHlinkClick `xml:"hlinkClick"`
+}
+
+// decodeHlinkClick directly maps the hlinkClick element, which specifies the
+// on-click hyperlink of the drawing object.
+type decodeHlinkClick struct {
+	RID     string `xml:"id,attr"`
+	Tooltip string `xml:"tooltip,attr,omitempty"`
 }
 
 // decodePicLocks directly maps the picLocks (Picture Locks). This element
@@ -145,9 +155,21 @@ type decodePicLocks struct {
 // decodeBlip element specifies the existence of an image (binary large image
 // or picture) and contains a reference to the image data.
 type decodeBlip struct {
-	Embed  string `xml:"embed,attr"`
-	Cstate string `xml:"cstate,attr,omitempty"`
-	R      string `xml:"r,attr"`
+	Embed  string            `xml:"embed,attr"`
+	Cstate string            `xml:"cstate,attr,omitempty"`
+	R      string            `xml:"r,attr"`
+	ExtLst *decodeBlipExtLst `xml:"extLst"`
+}
+
+// decodeBlipExtLst directly maps the extLst element of the blip, which
+// specifies the SVG picture of the blip.
+type decodeBlipExtLst struct {
+	Ext []struct {
+		URI     string `xml:"uri,attr"`
+		SVGBlip *struct {
+			Embed string `xml:"embed,attr"`
+		} `xml:"svgBlip"`
+	} `xml:"ext"`
 }
 
 // decodeStretch directly maps the stretch element. This element specifies
@@ -236,6 +258,12 @@ type decodePic struct {
 	SpPr     decodeSpPr     `xml:"spPr"`
 }
 
+// decodePos directly specifies the position of the absolute anchor.
+type decodePos struct {
+	X int `xml:"x,attr"`
+	Y int `xml:"y,attr"`
+}
+
 // decodeFrom specifies the starting anchor.
 type decodeFrom struct {
 	Col    int `xml:"col"`
@@ -274,3 +302,367 @@ type decodeCellImages struct {
 type decodeCellImage struct {
 	Pic decodePic `xml:"pic"`
 }
+
+// decodeShapeWsDr defines the structure used to deserialize the drawing part
+// for getting the shapes in the worksheet.
+type decodeShapeWsDr struct {
+	AbsoluteAnchor []*decodeShapeAnchor `xml:"absoluteAnchor"`
+	OneCellAnchor  []*decodeShapeAnchor `xml:"oneCellAnchor"`
+	TwoCellAnchor  []*decodeShapeAnchor `xml:"twoCellAnchor"`
+}
+
+// decodeShapeAnchor defines the structure used to deserialize the
+// absoluteAnchor, oneCellAnchor and twoCellAnchor element for getting the
+// shapes in the worksheet. The drawing objects in the anchor are captured by
+// any element, and the element name specifies the type of the object.
+type decodeShapeAnchor struct {
+	EditAs     string                `xml:"editAs,attr"`
+	Pos        *decodeChartPos       `xml:"pos"`
+	From       *decodeFrom           `xml:"from"`
+	To         *decodeTo             `xml:"to"`
+	Ext        *aExt                 `xml:"ext"`
+	Objects    []*decodeDrawingShape `xml:",any"`
+	ClientData *decodeClientData     `xml:"clientData"`
+}
+
+// decodeDrawingShape defines the structure used to deserialize the xdr:sp,
+// xdr:cxnSp and xdr:grpSp element. The child objects of the group shape are
+// captured by any element.
+type decodeDrawingShape struct {
+	XMLName   xml.Name
+	Macro     string                `xml:"macro,attr"`
+	NvSpPr    *decodeNvSpPr         `xml:"nvSpPr"`
+	NvCxnSpPr *decodeNvSpPr         `xml:"nvCxnSpPr"`
+	NvGrpSpPr *decodeNvSpPr         `xml:"nvGrpSpPr"`
+	SpPr      *decodeShapeSpPr      `xml:"spPr"`
+	Style     *decodeShapeStyle     `xml:"style"`
+	TxBody    *decodeShapeTxBody    `xml:"txBody"`
+	Objects   []*decodeDrawingShape `xml:",any"`
+}
+
+// decodeShapeSpPr defines the structure used to deserialize the xdr:spPr
+// element of the shape.
+type decodeShapeSpPr struct {
+	Xfrm      *decodeXfrm       `xml:"xfrm"`
+	PrstGeom  *decodePrstGeom   `xml:"prstGeom"`
+	SolidFill *decodeShapeColor `xml:"solidFill"`
+	Ln        *struct {
+		W         int               `xml:"w,attr"`
+		SolidFill *decodeShapeColor `xml:"solidFill"`
+		PrstDash  *attrValString    `xml:"prstDash"`
+	} `xml:"ln"`
+}
+
+// decodeShapeStyle defines the structure used to deserialize the xdr:style
+// element of the shape.
+type decodeShapeStyle struct {
+	LnRef   *decodeShapeColor `xml:"lnRef"`
+	FillRef *decodeShapeColor `xml:"fillRef"`
+}
+
+// decodeShapeColor defines the structure used to deserialize the element
+// which contains the RGB color, such as a:solidFill, a:lnRef and a:fillRef.
+type decodeShapeColor struct {
+	SrgbClr *attrValString `xml:"srgbClr"`
+}
+
+// decodeShapeTxBody defines the structure used to deserialize the xdr:txBody
+// element of the shape.
+type decodeShapeTxBody struct {
+	BodyPr *struct {
+		Wrap        string        `xml:"wrap,attr"`
+		Anchor      string        `xml:"anchor,attr"`
+		LIns        *int          `xml:"lIns,attr"`
+		TIns        *int          `xml:"tIns,attr"`
+		RIns        *int          `xml:"rIns,attr"`
+		BIns        *int          `xml:"bIns,attr"`
+		NoAutofit   *xlsxInnerXML `xml:"noAutofit"`
+		NormAutofit *xlsxInnerXML `xml:"normAutofit"`
+		SpAutoFit   *xlsxInnerXML `xml:"spAutoFit"`
+	} `xml:"bodyPr"`
+	P []struct {
+		PPr *decodeShapePPr  `xml:"pPr"`
+		R   []decodeChartRun `xml:"r"`
+	} `xml:"p"`
+}
+
+// decodeShapePPr defines the structure used to deserialize the a:pPr element
+// of the paragraph in the shape.
+type decodeShapePPr struct {
+	Algn   string                  `xml:"algn,attr"`
+	Lvl    int                     `xml:"lvl,attr"`
+	LnSpc  *decodeShapeTextSpacing `xml:"lnSpc"`
+	SpcBef *decodeShapeTextSpacing `xml:"spcBef"`
+	SpcAft *decodeShapeTextSpacing `xml:"spcAft"`
+	BuNone *xlsxInnerXML           `xml:"buNone"`
+	BuChar *struct {
+		Char string `xml:"char,attr"`
+	} `xml:"buChar"`
+	BuAutoNum *struct {
+		Type string `xml:"type,attr"`
+	} `xml:"buAutoNum"`
+}
+
+// decodeShapeTextSpacing defines the structure used to deserialize the
+// a:lnSpc, a:spcBef and a:spcAft element of the paragraph in the shape.
+type decodeShapeTextSpacing struct {
+	SpcPct *attrValInt `xml:"spcPct"`
+	SpcPts *attrValInt `xml:"spcPts"`
+}
+
+// decodeChartWsDr defines the structure used to deserialize the drawing part
+// for getting the charts in the worksheet.
+type decodeChartWsDr struct {
+	AbsoluteAnchor []*decodeChartAnchor `xml:"absoluteAnchor"`
+	OneCellAnchor  []*decodeChartAnchor `xml:"oneCellAnchor"`
+	TwoCellAnchor  []*decodeChartAnchor `xml:"twoCellAnchor"`
+}
+
+// decodeChartAnchor defines the structure used to deserialize the
+// absoluteAnchor, oneCellAnchor and twoCellAnchor element for getting the
+// charts in the worksheet.
+type decodeChartAnchor struct {
+	EditAs           string                         `xml:"editAs,attr"`
+	Pos              *decodeChartPos                `xml:"pos"`
+	From             *decodeFrom                    `xml:"from"`
+	To               *decodeTo                      `xml:"to"`
+	Ext              *aExt                          `xml:"ext"`
+	GraphicFrame     *decodeChartGraphicFrame       `xml:"graphicFrame"`
+	AlternateContent []*decodeChartAlternateContent `xml:"AlternateContent"`
+	ClientData       *decodeClientData              `xml:"clientData"`
+}
+
+// decodeChartPos defines the structure used to deserialize the xdr:pos
+// element.
+type decodeChartPos struct {
+	X int `xml:"x,attr"`
+	Y int `xml:"y,attr"`
+}
+
+// decodeChartAlternateContent defines the structure used to deserialize the
+// mc:AlternateContent element which contains the chart extension graphic
+// frame.
+type decodeChartAlternateContent struct {
+	Choice *struct {
+		GraphicFrame *decodeChartGraphicFrame `xml:"graphicFrame"`
+	} `xml:"Choice"`
+}
+
+// decodeChartGraphicFrame defines the structure used to deserialize the
+// xdr:graphicFrame element which contains the chart.
+type decodeChartGraphicFrame struct {
+	NvGraphicFramePr decodeNvGraphicFramePr `xml:"nvGraphicFramePr"`
+	Graphic          struct {
+		GraphicData struct {
+			URI   string `xml:"uri,attr"`
+			Chart *struct {
+				RID string `xml:"id,attr"`
+			} `xml:"chart"`
+		} `xml:"graphicData"`
+	} `xml:"graphic"`
+}
+
+// decodeChartSpace defines the structure used to deserialize the c:chartSpace
+// element for getting the chart definitions.
+type decodeChartSpace struct {
+	Chart struct {
+		Title            *decodeChartTitle `xml:"title"`
+		AutoTitleDeleted *attrValBool      `xml:"autoTitleDeleted"`
+		PlotArea         decodePlotArea    `xml:"plotArea"`
+		Legend           *struct {
+			LegendPos   *attrValString `xml:"legendPos"`
+			LegendEntry []struct {
+				IDx    *attrValInt  `xml:"idx"`
+				Delete *attrValBool `xml:"delete"`
+			} `xml:"legendEntry"`
+			Overlay *attrValBool `xml:"overlay"`
+			TxPr    *struct {
+				P []struct {
+					PPr *struct {
+						DefRPr *decodeChartRPr `xml:"defRPr"`
+					} `xml:"pPr"`
+				} `xml:"p"`
+			} `xml:"txPr"`
+		} `xml:"legend"`
+		DispBlanksAs *attrValString `xml:"dispBlanksAs"`
+	} `xml:"chart"`
+}
+
+// decodeChartTitle defines the structure used to deserialize the c:title
+// element.
+type decodeChartTitle struct {
+	Tx struct {
+		StrRef *cStrRef         `xml:"strRef"`
+		Rich   *decodeChartRich `xml:"rich"`
+	} `xml:"tx"`
+	Overlay *attrValBool `xml:"overlay"`
+	TxPr    *struct {
+		BodyPr *decodeChartBodyPr `xml:"bodyPr"`
+		P      []struct {
+			PPr *struct {
+				DefRPr *decodeChartRPr `xml:"defRPr"`
+			} `xml:"pPr"`
+		} `xml:"p"`
+	} `xml:"txPr"`
+}
+
+// decodeChartRich defines the structure used to deserialize the c:rich and
+// cx:rich element.
+type decodeChartRich struct {
+	BodyPr *decodeChartBodyPr `xml:"bodyPr"`
+	P      []struct {
+		R []decodeChartRun `xml:"r"`
+	} `xml:"p"`
+}
+
+// decodeChartBodyPr defines the structure used to deserialize the a:bodyPr
+// element in the chart text.
+type decodeChartBodyPr struct {
+	Rot *int `xml:"rot,attr"`
+}
+
+// decodeChartRun defines the structure used to deserialize the a:r element in
+// the chart rich text.
+type decodeChartRun struct {
+	RPr *decodeChartRPr `xml:"rPr"`
+	T   string          `xml:"t"`
+}
+
+// decodeChartRPr defines the structure used to deserialize the a:rPr and
+// a:defRPr element in the chart text.
+type decodeChartRPr struct {
+	B         bool    `xml:"b,attr"`
+	I         bool    `xml:"i,attr"`
+	Sz        float64 `xml:"sz,attr"`
+	Strike    string  `xml:"strike,attr"`
+	U         string  `xml:"u,attr"`
+	SolidFill *struct {
+		SrgbClr *attrValString `xml:"srgbClr"`
+	} `xml:"solidFill"`
+	Latin *struct {
+		Typeface string `xml:"typeface,attr"`
+	} `xml:"latin"`
+}
+
+// decodePlotArea defines the structure used to deserialize the c:plotArea
+// element. The chart groups are captured by any element, and the element name
+// specifies the chart type of the group.
+type decodePlotArea struct {
+	CatAx  []*decodeChartAxis  `xml:"catAx"`
+	DateAx []*decodeChartAxis  `xml:"dateAx"`
+	ValAx  []*decodeChartAxis  `xml:"valAx"`
+	SerAx  []*decodeChartAxis  `xml:"serAx"`
+	Charts []*decodeChartGroup `xml:",any"`
+}
+
+// decodeChartGroup defines the structure used to deserialize the chart group
+// element in the plot area, such as c:barChart and c:lineChart.
+type decodeChartGroup struct {
+	XMLName       xml.Name
+	BarDir        *attrValString       `xml:"barDir"`
+	Grouping      *attrValString       `xml:"grouping"`
+	OfPieType     *attrValString       `xml:"ofPieType"`
+	VaryColors    *attrValBool         `xml:"varyColors"`
+	Wireframe     *attrValBool         `xml:"wireframe"`
+	Ser           []*decodeChartSeries `xml:"ser"`
+	DLbls         *cDLbls              `xml:"dLbls"`
+	GapWidth      *attrValInt          `xml:"gapWidth"`
+	SplitType     *attrValString       `xml:"splitType"`
+	SplitPos      *attrValFloat        `xml:"splitPos"`
+	CustSplit     *cCustSplit          `xml:"custSplit"`
+	SecondPieSize *attrValInt          `xml:"secondPieSize"`
+	SerLines      []*cChartLines       `xml:"serLines"`
+	Shape         *attrValString       `xml:"shape"`
+	HoleSize      *attrValInt          `xml:"holeSize"`
+	Overlap       *attrValInt          `xml:"overlap"`
+	BubbleScale   *attrValFloat        `xml:"bubbleScale"`
+	AxID          []*attrValInt        `xml:"axId"`
+}
+
+// decodeChartSeries defines the structure used to deserialize the c:ser
+// element.
+type decodeChartSeries struct {
+	Tx *struct {
+		StrRef *cStrRef `xml:"strRef"`
+		V      string   `xml:"v"`
+	} `xml:"tx"`
+	Marker     *cMarker            `xml:"marker"`
+	DPt        []*decodeChartDPt   `xml:"dPt"`
+	Cat        *decodeChartSerData `xml:"cat"`
+	Val        *decodeChartSerData `xml:"val"`
+	XVal       *decodeChartSerData `xml:"xVal"`
+	YVal       *decodeChartSerData `xml:"yVal"`
+	Smooth     *attrValBool        `xml:"smooth"`
+	BubbleSize *decodeChartSerData `xml:"bubbleSize"`
+	Bubble3D   *attrValBool        `xml:"bubble3D"`
+}
+
+// decodeChartDPt defines the structure used to deserialize the c:dPt element.
+type decodeChartDPt struct {
+	IDx       *attrValInt `xml:"idx"`
+	Marker    *cMarker    `xml:"marker"`
+	Explosion *attrValInt `xml:"explosion"`
+}
+
+// decodeChartSerData defines the structure used to deserialize the c:cat,
+// c:val, c:xVal, c:yVal and c:bubbleSize element.
+type decodeChartSerData struct {
+	StrRef *cStrRef `xml:"strRef"`
+	NumRef *cNumRef `xml:"numRef"`
+}
+
+// decodeChartAxis defines the structure used to deserialize the c:catAx,
+// c:dateAx, c:valAx and c:serAx element.
+type decodeChartAxis struct {
+	XMLName        xml.Name
+	AxID           *attrValInt       `xml:"axId"`
+	Scaling        *cScaling         `xml:"scaling"`
+	Delete         *attrValBool      `xml:"delete"`
+	MajorGridlines *struct{}         `xml:"majorGridlines"`
+	MinorGridlines *struct{}         `xml:"minorGridlines"`
+	Title          *decodeChartTitle `xml:"title"`
+	NumFmt         *cNumFmt          `xml:"numFmt"`
+	TickLblPos     *attrValString    `xml:"tickLblPos"`
+	MajorUnit      *attrValFloat     `xml:"majorUnit"`
+	MinorUnit      *attrValFloat     `xml:"minorUnit"`
+	TickLblSkip    *attrValInt       `xml:"tickLblSkip"`
+	BaseTimeUnit   *attrValString    `xml:"baseTimeUnit"`
+	MajorTimeUnit  *attrValString    `xml:"majorTimeUnit"`
+	MinorTimeUnit  *attrValString    `xml:"minorTimeUnit"`
+}
+
+// decodeChartExSpace defines the structure used to deserialize the
+// cx:chartSpace element for getting the chart extension definitions.
+type decodeChartExSpace struct {
+	ChartData struct {
+		Data []struct {
+			ID     int `xml:"id,attr"`
+			StrDim *struct {
+				F string `xml:"f"`
+			} `xml:"strDim"`
+			NumDim *struct {
+				F string `xml:"f"`
+			} `xml:"numDim"`
+		} `xml:"data"`
+	} `xml:"chartData"`
+	Chart struct {
+		Title *struct {
+			Tx struct {
+				Rich *decodeChartRich `xml:"rich"`
+			} `xml:"tx"`
+		} `xml:"title"`
+		PlotArea struct {
+			PlotAreaRegion struct {
+				Series []struct {
+					LayoutID string `xml:"layoutId,attr"`
+					Tx       *struct {
+						TxData *struct {
+							F string `xml:"f"`
+						} `xml:"txData"`
+					} `xml:"tx"`
+					DataID *attrValInt `xml:"dataId"`
+				} `xml:"series"`
+			} `xml:"plotAreaRegion"`
+		} `xml:"plotArea"`
+	} `xml:"chart"`
+}
@@ -102,8 +102,10 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
-	Off xlsxOff `xml:"a:off"`
-	Ext aExt    `xml:"a:ext"`
+	FlipH bool    `xml:"flipH,attr,omitempty"`
+	FlipV bool    `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff `xml:"a:off"`
+	Ext   aExt    `xml:"a:ext"`
 }
 
 // xlsxCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -161,8 +163,20 @@ type xlsxBlipFill struct {
 // has a minimum value of greater than or equal to 0. This simple type has a
 // maximum value of less than or equal to 20116800.
 type xlsxLineProperties struct {
-	W         int           `xml:"w,attr,omitempty"`
-	SolidFill *xlsxInnerXML `xml:"a:solidFill"`
+	W         int            `xml:"w,attr,omitempty"`
+	SolidFill *xlsxInnerXML  `xml:"a:solidFill"`
+	PrstDash  *attrValString `xml:"a:prstDash"`
+	HeadEnd   *aLineEnd      `xml:"a:headEnd"`
+	TailEnd   *aLineEnd      `xml:"a:tailEnd"`
+}
+
+// aLineEnd directly maps the a:headEnd and a:tailEnd elements. These elements
+// specify the decorations which can be added to the head and tail of a line,
+// such as the arrowheads.
+type aLineEnd struct {
+	Type string `xml:"type,attr,omitempty"`
+	W    string `xml:"w,attr,omitempty"`
+	Len  string `xml:"len,attr,omitempty"`
 }
 
 // xlsxSpPr directly maps the spPr (Shape Properties). This element specifies
@@ -224,6 +238,7 @@ type xdrCellAnchor struct {
 	To               *xlsxTo                 `xml:"xdr:to"`
 	Ext              *aExt                   `xml:"xdr:ext"`
 	Sp               *xdrSp                  `xml:"xdr:sp"`
+	CxnSp            *xdrCxnSp               `xml:"xdr:cxnSp"`
 	Pic              *xlsxPic                `xml:"xdr:pic,omitempty"`
 	GraphicFrame     string                  `xml:",innerxml"`
 	AlternateContent []*xlsxAlternateContent `xml:"mc:AlternateContent"`
@@ -323,9 +338,10 @@ type xlsxGraphic struct {
 // document. This graphic object is provided entirely by the document authors
 // who choose to persist this data within the document.
 type xlsxGraphicData struct {
-	URI   string     `xml:"uri,attr"`
-	Chart *xlsxChart `xml:"c:chart,omitempty"`
-	Sle   *xlsxSle   `xml:"sle:slicer"`
+	URI     string       `xml:"uri,attr"`
+	Chart   *xlsxChart   `xml:"c:chart,omitempty"`
+	ChartEx *xlsxChartEx `xml:"cx:chart,omitempty"`
+	Sle     *xlsxSle     `xml:"sle:slicer"`
 }
 
 type xlsxSle struct {
@@ -340,6 +356,13 @@ type xlsxChart struct {
 	R   string `xml:"xmlns:r,attr"`
 }
 
+// xlsxChartEx (Chart Extension) directly maps the cx:chart element.
+type xlsxChartEx struct {
+	CX  string `xml:"xmlns:cx,attr"`
+	R   string `xml:"xmlns:r,attr"`
+	RID string `xml:"r:id,attr"`
+}
+
 // xdrSp (Shape) directly maps the xdr:sp element. This element specifies the
 // existence of a single shape. A shape can either be a preset or a custom
 // geometry, defined using the SpreadsheetDrawingML framework. In addition to a
@@ -357,6 +380,25 @@ type xdrSp struct {
 	TxBody   *xdrTxBody `xml:"xdr:txBody"`
 }
 
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two shapes,
+// such as the straight, elbow and curved connectors.
+type xdrCxnSp struct {
+	XMLName   xml.Name      `xml:"xdr:cxnSp"`
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element. This element specifies all non-visual properties
+// for a connection shape.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr `xml:"xdr:cNvPr"`
+	CNvCxnSpPr string     `xml:"xdr:cNvCxnSpPr"`
+}
+
 // xdrNvSpPr (Non-Visual Properties for a Shape) directly maps the xdr:nvSpPr
 // element. This element specifies all non-visual properties for a shape. This
 // element is a container for the non-visual identification properties, shape
@@ -428,6 +470,27 @@ type Picture struct {
 	File       []byte
 	Format     *GraphicOptions
 	InsertType PictureInsertType
+	Anchor     *PictureAnchor
+}
+
+// PictureAnchor directly maps the placement of the picture or the shape, which
+// returned by the GetSheetPictures and GetShapes function.
+type PictureAnchor struct {
+	Type        string
+	Name        string
+	From        string
+	FromOffsetX int
+	FromOffsetY int
+	To          string
+	ToOffsetX   int
+	ToOffsetY   int
+}
+
+// SheetPicturesOptions directly maps the settings of the pictures returned by
+// the GetSheetPictures function.
+type SheetPicturesOptions struct {
+	HeaderFooter bool
+	InCell       bool
 }
 
 // GraphicOptions directly maps the format settings of the picture.
@@ -438,30 +501,93 @@ type GraphicOptions struct {
 	LockAspectRatio     bool
 	AutoFit             bool
 	AutoFitIgnoreAspect bool
+	AutoFitCell         bool
 	OffsetX             int
 	OffsetY             int
 	ScaleX              float64
 	ScaleY              float64
 	Hyperlink           string
 	HyperlinkType       string
+	HyperlinkTooltip    string
 	Positioning         string
+	ToCell              string
+	Fallback            []byte
 }
 
-// Shape directly maps the format settings of the shape.
+// Shape directly maps the format settings of the shape. The Anchor and
+// GroupName are only used by the GetShapes function, which specify the
+// placement of the shape and the name of the group shape which contains it.
 type Shape struct {
-	Cell      string
-	Type      string
-	Macro     string
-	Width     uint
-	Height    uint
-	Format    GraphicOptions
-	Fill      Fill
-	Line      ShapeLine
-	Paragraph []RichTextRun
+	Cell       string
+	GroupName  string
+	Anchor     *PictureAnchor
+	Type       string
+	Macro      string
+	Width      uint
+	Height     uint
+	Format     GraphicOptions
+	Fill       Fill
+	Line       ShapeLine
+	Paragraph  []RichTextRun
+	Paragraphs []ShapeParagraph
+	TextBody   ShapeTextBody
+}
+
+// ShapeParagraph directly maps the settings of the paragraph in the shape,
+// which contains the rich text runs and the paragraph properties. The
+// SpaceBefore and SpaceAfter specify the space in points, and the
+// LineSpacing specifies the line spacing in multiples of the single line.
+type ShapeParagraph struct {
+	Runs             []RichTextRun
+	Alignment        string
+	Bullet           string
+	BulletChar       string
+	BulletNumberType string
+	IndentLevel      int
+	SpaceBefore      float64
+	SpaceAfter       float64
+	LineSpacing      float64
+}
+
+// ShapeTextBody directly maps the settings of the text body in the shape. The
+// margins of the text body specify in points.
+type ShapeTextBody struct {
+	Autofit       string
+	VerticalAlign string
+	WordWrap      bool
+	LeftMargin    *float64
+	TopMargin     *float64
+	RightMargin   *float64
+	BottomMargin  *float64
 }
 
 // ShapeLine directly maps the line settings of the shape.
 type ShapeLine struct {
 	Color string
 	Width *float64
+	Dash  string
+}
+
+// ConnectorOptions directly maps the settings of the connector, which is
+// drawn from the start point to the end point. The start and end points are
+// specified by the cell reference and the offsets in pixels.
+type ConnectorOptions struct {
+	From        string
+	FromOffsetX int
+	FromOffsetY int
+	To          string
+	ToOffsetX   int
+	ToOffsetY   int
+	Type        string
+	Macro       string
+	Line        ShapeLine
+	BeginArrow  ConnectorArrow
+	EndArrow    ConnectorArrow
+}
+
+// ConnectorArrow directly maps the arrowhead settings of the connector.
+type ConnectorArrow struct {
+	Type   string
+	Width  string
+	Length string
 }
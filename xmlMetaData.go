@@ -24,6 +24,7 @@ import "encoding/xml"
 // can be propagated along with the value as it is referenced in formulas.
 type xlsxMetadata struct {
 	XMLName         xml.Name             `xml:"metadata"`
+	XMLNS           string               `xml:"xmlns,attr"`
 	MetadataTypes   *xlsxInnerXML        `xml:"metadataTypes"`
 	MetadataStrings *xlsxInnerXML        `xml:"metadataStrings"`
 	MdxMetadata     *xlsxInnerXML        `xml:"mdxMetadata"`
@@ -36,6 +37,8 @@ type xlsxMetadata struct {
 // xlsxFutureMetadata directly maps the futureMetadata element. This element
 // represents future metadata information.
 type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr,omitempty"`
 	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
 	ExtLst *xlsxInnerXML             `xml:"extLst"`
 }
@@ -72,6 +75,7 @@ type xlsxMetadataRecord struct {
 // data.
 type xlsxRichValueData struct {
 	XMLName xml.Name        `xml:"rvData"`
+	XMLNS   string          `xml:"xmlns,attr"`
 	Count   int             `xml:"count,attr,omitempty"`
 	Rv      []xlsxRichValue `xml:"rv"`
 	ExtLst  *xlsxInnerXML   `xml:"extLst"`
@@ -85,6 +89,47 @@ type xlsxRichValue struct {
 	Fb *xlsxInnerXML `xml:"fb"`
 }
 
+// xlsxRichValueStructures directly maps the rvStructures element that
+// specifies a list of rich value structures.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"rvStructures"`
+	XMLNS   string                   `xml:"xmlns,attr"`
+	Count   int                      `xml:"count,attr,omitempty"`
+	S       []xlsxRichValueStructure `xml:"s"`
+	ExtLst  *xlsxInnerXML            `xml:"extLst"`
+}
+
+// xlsxRichValueStructure directly maps the s element that specifies the type
+// and the keys of a rich value structure.
+type xlsxRichValueStructure struct {
+	T string             `xml:"t,attr"`
+	K []xlsxRichValueKey `xml:"k"`
+}
+
+// xlsxRichValueKey directly maps the k element that specifies the name and
+// value type of a key in a rich value structure.
+type xlsxRichValueKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr,omitempty"`
+}
+
+// RichValueField directly maps the name and value of a key of the rich value.
+// The value type of the key is derived from the type of the value, only the
+// string, boolean, integer and float number types are supported.
+type RichValueField struct {
+	Name  string
+	Value interface{}
+}
+
+// RichValue directly maps the settings of a rich value record. The Type
+// specifies the structure type of the rich value, such as "_entity" for the
+// entity data type, or "_linkedentity" for the linked data types, such as
+// stocks and geography.
+type RichValue struct {
+	Type   string
+	Fields []RichValueField
+}
+
 // xlsxRichValueRels directly maps the richValueRels element. This element that
 // specifies a list of rich value relationships.
 type xlsxRichValueRels struct {
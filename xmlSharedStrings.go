@@ -85,8 +85,11 @@ type xlsxRPr struct {
 	Scheme    *attrValString `xml:"scheme"`
 }
 
-// RichTextRun directly maps the settings of the rich text run.
+// RichTextRun directly maps the settings of the rich text run. The
+// IsInherited indicates the run has no run properties and the Font is
+// inherited from the font of the cell style.
 type RichTextRun struct {
-	Font *Font
-	Text string
+	Font        *Font
+	Text        string
+	IsInherited bool
 }
@@ -87,20 +87,21 @@ type xlsxFonts struct {
 // xlsxFont directly maps the font element. This element defines the
 // properties for one of the fonts used in this workbook.
 type xlsxFont struct {
-	B        *attrValBool   `xml:"b"`
-	I        *attrValBool   `xml:"i"`
-	Strike   *attrValBool   `xml:"strike"`
-	Outline  *attrValBool   `xml:"outline"`
-	Shadow   *attrValBool   `xml:"shadow"`
-	Condense *attrValBool   `xml:"condense"`
-	Extend   *attrValBool   `xml:"extend"`
-	U        *attrValString `xml:"u"`
-	Sz       *attrValFloat  `xml:"sz"`
-	Color    *xlsxColor     `xml:"color"`
-	Name     *attrValString `xml:"name"`
-	Family   *attrValInt    `xml:"family"`
-	Charset  *attrValInt    `xml:"charset"`
-	Scheme   *attrValString `xml:"scheme"`
+	B         *attrValBool   `xml:"b"`
+	I         *attrValBool   `xml:"i"`
+	Strike    *attrValBool   `xml:"strike"`
+	Outline   *attrValBool   `xml:"outline"`
+	Shadow    *attrValBool   `xml:"shadow"`
+	Condense  *attrValBool   `xml:"condense"`
+	Extend    *attrValBool   `xml:"extend"`
+	U         *attrValString `xml:"u"`
+	VertAlign *attrValString `xml:"vertAlign"`
+	Sz        *attrValFloat  `xml:"sz"`
+	Color     *xlsxColor     `xml:"color"`
+	Name      *attrValString `xml:"name"`
+	Family    *attrValInt    `xml:"family"`
+	Charset   *attrValInt    `xml:"charset"`
+	Scheme    *attrValString `xml:"scheme"`
 }
 
 // xlsxFills directly maps the fills' element. This element defines the cell
@@ -275,11 +276,20 @@ type xlsxTableStyles struct {
 // a single table style definition that indicates how a spreadsheet application
 // should format and display a table.
 type xlsxTableStyle struct {
-	Name              string `xml:"name,attr,omitempty"`
-	Pivot             int    `xml:"pivot,attr"`
-	Count             int    `xml:"count,attr,omitempty"`
-	Table             bool   `xml:"table,attr,omitempty"`
-	TableStyleElement string `xml:",innerxml"`
+	Name              string                   `xml:"name,attr,omitempty"`
+	Pivot             int                      `xml:"pivot,attr"`
+	Count             int                      `xml:"count,attr,omitempty"`
+	Table             *bool                    `xml:"table,attr"`
+	TableStyleElement []*xlsxTableStyleElement `xml:"tableStyleElement"`
+}
+
+// xlsxTableStyleElement directly maps the tableStyleElement element. This
+// element specifies formatting for one area of a table or PivotTable by
+// referencing a differential format.
+type xlsxTableStyleElement struct {
+	Type  string `xml:"type,attr"`
+	Size  int    `xml:"size,attr,omitempty"`
+	DxfID *int   `xml:"dxfId,attr"`
 }
 
 // xlsxNumFmts directly maps the numFmts element. This element defines the
@@ -348,14 +358,41 @@ type Font struct {
 	ColorTheme   *int
 	ColorTint    float64
 	VertAlign    string
+	Charset      *int
+	Scheme       string
+	Condense     bool
+	Extend       bool
 }
 
 // Fill directly maps the fill settings of the cells.
 type Fill struct {
-	Type    string
-	Pattern int
-	Color   []string
-	Shading int
+	Type     string
+	Pattern  int
+	Color    []string
+	Shading  int
+	Gradient *GradientFill
+}
+
+// GradientFill directly maps the settings of the gradient fill with arbitrary
+// stops. The Type is "linear" or "path", the Degree specifies the angle of
+// the linear gradient, and the Left, Right, Top and Bottom specify the
+// convergence fractions of the path gradient rectangle in the range of 0 to
+// 1.
+type GradientFill struct {
+	Type   string
+	Degree float64
+	Left   float64
+	Right  float64
+	Top    float64
+	Bottom float64
+	Stops  []GradientStop
+}
+
+// GradientStop directly maps the position and color of the gradient fill
+// stop, the position is in the range of 0 to 1.
+type GradientStop struct {
+	Position float64
+	Color    string
 }
 
 // Protection directly maps the protection settings of the cells.
@@ -376,3 +413,38 @@ type Style struct {
 	CustomNumFmt  *string
 	NegRed        bool
 }
+
+// TableStyleDefinition directly maps the definition of the custom table
+// style. Set Pivot to true to define a PivotTable style, otherwise a table
+// style will be defined. The Elements maps the table style element types to
+// the styles of the table areas.
+type TableStyleDefinition struct {
+	Pivot    bool
+	Elements map[string]*Style
+}
+
+// StyleFields is the bit flags type of the cell style fields, which used to
+// specify the fields to be overridden for the SetCellStylePartial function.
+type StyleFields uint8
+
+// This section defines the cell style fields enumeration for the
+// SetCellStylePartial function. The StyleFieldNumFmt covers the 'NumFmt',
+// 'DecimalPlaces', 'CustomNumFmt' and 'NegRed' fields of the style.
+const (
+	StyleFieldBorder StyleFields = 1 << iota
+	StyleFieldFill
+	StyleFieldFont
+	StyleFieldAlignment
+	StyleFieldProtection
+	StyleFieldNumFmt
+	StyleFieldAll = StyleFieldBorder | StyleFieldFill | StyleFieldFont |
+		StyleFieldAlignment | StyleFieldProtection | StyleFieldNumFmt
+)
+
+// NamedStyle directly maps the settings of the named cell style. The
+// BuiltInID is nil for the custom named styles.
+type NamedStyle struct {
+	Name      string
+	BuiltInID *int
+	Style     *Style
+}
@@ -33,12 +33,12 @@ type xlsxTable struct {
 	TotalsRowCount       int                 `xml:"totalsRowCount,attr,omitempty"`
 	TotalsRowShown       *bool               `xml:"totalsRowShown,attr"`
 	Published            bool                `xml:"published,attr,omitempty"`
-	HeaderRowDxfID       int                 `xml:"headerRowDxfId,attr,omitempty"`
-	DataDxfID            int                 `xml:"dataDxfId,attr,omitempty"`
-	TotalsRowDxfID       int                 `xml:"totalsRowDxfId,attr,omitempty"`
-	HeaderRowBorderDxfID int                 `xml:"headerRowBorderDxfId,attr,omitempty"`
-	TableBorderDxfID     int                 `xml:"tableBorderDxfId,attr,omitempty"`
-	TotalsRowBorderDxfID int                 `xml:"totalsRowBorderDxfId,attr,omitempty"`
+	HeaderRowDxfID       *int                `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID            *int                `xml:"dataDxfId,attr,omitempty"`
+	TotalsRowDxfID       *int                `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowBorderDxfID *int                `xml:"headerRowBorderDxfId,attr,omitempty"`
+	TableBorderDxfID     *int                `xml:"tableBorderDxfId,attr,omitempty"`
+	TotalsRowBorderDxfID *int                `xml:"totalsRowBorderDxfId,attr,omitempty"`
 	HeaderRowCellStyle   string              `xml:"headerRowCellStyle,attr,omitempty"`
 	DataCellStyle        string              `xml:"dataCellStyle,attr,omitempty"`
 	TotalsRowCellStyle   string              `xml:"totalsRowCellStyle,attr,omitempty"`
@@ -183,9 +183,9 @@ type xlsxTableColumn struct {
 	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
 	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
 	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
+	HeaderRowDxfID     *int   `xml:"headerRowDxfId,attr,omitempty"`
+	DataDxfID          *int   `xml:"dataDxfId,attr,omitempty"`
+	TotalsRowDxfID     *int   `xml:"totalsRowDxfId,attr,omitempty"`
 	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
 	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
 	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
@@ -244,6 +244,15 @@ type Table struct {
 	ShowHeaderRow     *bool
 	ShowLastColumn    bool
 	ShowRowStripes    *bool
+	StyleDefinition   *TableStyleDefinition
+	Columns           []TableColumn
+}
+
+// TableColumn directly maps the differential formats of the table column.
+type TableColumn struct {
+	HeaderRowStyle *Style
+	DataStyle      *Style
+	TotalsRowStyle *Style
 }
 
 // AutoFilterOptions directly maps the auto filter settings.
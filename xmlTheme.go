@@ -243,3 +243,20 @@ type decodeStyleMatrix struct {
 	EffectStyleLst xlsxEffectStyleLst `xml:"effectStyleLst"`
 	BgFillStyleLst xlsxBgFillStyleLst `xml:"bgFillStyleLst"`
 }
+
+// ThemeColors directly maps the twelve color slots of the color scheme in the
+// workbook theme, the colors are represented in 'RRGGBB' hexadecimal notation.
+type ThemeColors struct {
+	Dark1             string
+	Light1            string
+	Dark2             string
+	Light2            string
+	Accent1           string
+	Accent2           string
+	Accent3           string
+	Accent4           string
+	Accent5           string
+	Accent6           string
+	Hyperlink         string
+	FollowedHyperlink string
+}
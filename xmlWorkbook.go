@@ -246,6 +246,7 @@ type xlsxAlternateContent struct {
 type xlsxChoice struct {
 	XMLName    xml.Name `xml:"mc:Choice"`
 	XMLNSA14   string   `xml:"xmlns:a14,attr,omitempty"`
+	XMLNSCX1   string   `xml:"xmlns:cx1,attr,omitempty"`
 	XMLNSSle15 string   `xml:"xmlns:sle15,attr,omitempty"`
 	Requires   string   `xml:"Requires,attr,omitempty"`
 	Content    string   `xml:",innerxml"`
@@ -391,6 +392,17 @@ type WorkbookPropsOptions struct {
 	CodeName      *string
 }
 
+// WorkbookViewOptions directly maps the settings of the workbook view, the
+// position and size of the window are in twips (1/20 of a point).
+type WorkbookViewOptions struct {
+	ActiveTab    *int
+	FirstSheet   *int
+	XWindow      *int
+	YWindow      *int
+	WindowWidth  *int
+	WindowHeight *int
+}
+
 // WorkbookProtectionOptions directly maps the settings of workbook protection.
 type WorkbookProtectionOptions struct {
 	AlgorithmName string
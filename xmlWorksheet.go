@@ -602,7 +602,7 @@ type xlsxIconSet struct {
 // cfvo (Conditional Format Value Object) describes the values of the
 // interpolation points in a gradient scale.
 type xlsxCfvo struct {
-	Gte    bool        `xml:"gte,attr,omitempty"`
+	Gte    *bool       `xml:"gte,attr"`
 	Type   string      `xml:"type,attr,omitempty"`
 	Val    string      `xml:"val,attr,omitempty"`
 	ExtLst *xlsxExtLst `xml:"extLst"`
@@ -747,17 +747,20 @@ type decodeX14CfRule struct {
 
 // decodeX14DataBar directly maps the dataBar element.
 type decodeX14DataBar struct {
-	XMLName           xml.Name    `xml:"dataBar"`
-	MaxLength         int         `xml:"maxLength,attr"`
-	MinLength         int         `xml:"minLength,attr"`
-	Border            bool        `xml:"border,attr,omitempty"`
-	Gradient          *bool       `xml:"gradient,attr"`
-	ShowValue         bool        `xml:"showValue,attr,omitempty"`
-	Direction         string      `xml:"direction,attr,omitempty"`
-	Cfvo              []*xlsxCfvo `xml:"cfvo"`
-	BorderColor       *xlsxColor  `xml:"borderColor"`
-	NegativeFillColor *xlsxColor  `xml:"negativeFillColor"`
-	AxisColor         *xlsxColor  `xml:"axisColor"`
+	XMLName                              xml.Name    `xml:"dataBar"`
+	MaxLength                            *int        `xml:"maxLength,attr"`
+	MinLength                            *int        `xml:"minLength,attr"`
+	Border                               bool        `xml:"border,attr,omitempty"`
+	Gradient                             *bool       `xml:"gradient,attr"`
+	ShowValue                            bool        `xml:"showValue,attr,omitempty"`
+	Direction                            string      `xml:"direction,attr,omitempty"`
+	NegativeBarBorderColorSameAsPositive *bool       `xml:"negativeBarBorderColorSameAsPositive,attr"`
+	AxisPosition                         string      `xml:"axisPosition,attr,omitempty"`
+	Cfvo                                 []*xlsxCfvo `xml:"cfvo"`
+	BorderColor                          *xlsxColor  `xml:"borderColor"`
+	NegativeFillColor                    *xlsxColor  `xml:"negativeFillColor"`
+	NegativeBorderColor                  *xlsxColor  `xml:"negativeBorderColor"`
+	AxisColor                            *xlsxColor  `xml:"axisColor"`
 }
 
 // xlsxX14ConditionalFormattings directly maps the conditionalFormattings
@@ -783,16 +786,25 @@ type xlsxX14CfRule struct {
 
 // xlsx14DataBar directly maps the dataBar element.
 type xlsx14DataBar struct {
-	MaxLength         int         `xml:"maxLength,attr"`
-	MinLength         int         `xml:"minLength,attr"`
-	Border            bool        `xml:"border,attr"`
-	Gradient          bool        `xml:"gradient,attr"`
-	ShowValue         bool        `xml:"showValue,attr,omitempty"`
-	Direction         string      `xml:"direction,attr,omitempty"`
-	Cfvo              []*xlsxCfvo `xml:"x14:cfvo"`
-	BorderColor       *xlsxColor  `xml:"x14:borderColor"`
-	NegativeFillColor *xlsxColor  `xml:"x14:negativeFillColor"`
-	AxisColor         *xlsxColor  `xml:"x14:axisColor"`
+	MaxLength                            int            `xml:"maxLength,attr"`
+	MinLength                            int            `xml:"minLength,attr"`
+	Border                               bool           `xml:"border,attr"`
+	Gradient                             bool           `xml:"gradient,attr"`
+	ShowValue                            bool           `xml:"showValue,attr,omitempty"`
+	Direction                            string         `xml:"direction,attr,omitempty"`
+	NegativeBarBorderColorSameAsPositive *bool          `xml:"negativeBarBorderColorSameAsPositive,attr"`
+	AxisPosition                         string         `xml:"axisPosition,attr,omitempty"`
+	Cfvo                                 []*xlsxX14Cfvo `xml:"x14:cfvo"`
+	BorderColor                          *xlsxColor     `xml:"x14:borderColor"`
+	NegativeFillColor                    *xlsxColor     `xml:"x14:negativeFillColor"`
+	NegativeBorderColor                  *xlsxColor     `xml:"x14:negativeBorderColor"`
+	AxisColor                            *xlsxColor     `xml:"x14:axisColor"`
+}
+
+// xlsxX14Cfvo directly maps the cfvo element.
+type xlsxX14Cfvo struct {
+	Type string `xml:"type,attr"`
+	F    string `xml:"xm:f,omitempty"`
 }
 
 // xlsxX14SparklineGroups directly maps the sparklineGroups element.
@@ -912,32 +924,68 @@ type Panes struct {
 	Selection   []Selection
 }
 
+// SplitPanesOptions directly maps the settings of the split panes with the
+// synchronized scroll positions.
+type SplitPanesOptions struct {
+	// XSplit specifies the horizontal position of the vertical split bar in
+	// 1/20th of a point, 0 (zero) if none.
+	XSplit int
+	// YSplit specifies the vertical position of the horizontal split bar in
+	// 1/20th of a point, 0 (zero) if none.
+	YSplit int
+	// TopLeftCell specifies the top left visible cell of the top left pane,
+	// default is A1.
+	TopLeftCell string
+	// BottomRightCell specifies the top left visible cell of the bottom right
+	// pane, default is the same as the TopLeftCell.
+	BottomRightCell string
+	// ActiveCell specifies the active cell in the active pane.
+	ActiveCell string
+}
+
 // ConditionalFormatOptions directly maps the conditional format settings of the cells.
 type ConditionalFormatOptions struct {
-	Type           string
-	AboveAverage   bool
-	Percent        bool
-	Format         *int
-	Criteria       string
-	Value          string
-	MinType        string
-	MidType        string
-	MaxType        string
-	MinValue       string
-	MidValue       string
-	MaxValue       string
-	MinColor       string
-	MidColor       string
-	MaxColor       string
-	BarColor       string
-	BarBorderColor string
-	BarDirection   string
-	BarOnly        bool
-	BarSolid       bool
-	IconStyle      string
-	ReverseIcons   bool
-	IconsOnly      bool
-	StopIfTrue     bool
+	Type                   string
+	AboveAverage           bool
+	Percent                bool
+	Format                 *int
+	FormatStyle            *Style
+	Criteria               string
+	Value                  string
+	MinType                string
+	MidType                string
+	MaxType                string
+	MinValue               string
+	MidValue               string
+	MaxValue               string
+	MinColor               string
+	MidColor               string
+	MaxColor               string
+	BarColor               string
+	BarBorderColor         string
+	BarDirection           string
+	BarOnly                bool
+	BarSolid               bool
+	NegativeBarColor       string
+	NegativeBarBorderColor string
+	AxisColor              string
+	AxisPosition           string
+	MinLength              int
+	MaxLength              int
+	IconStyle              string
+	IconThresholds         []ConditionalFormatIconThreshold
+	ReverseIcons           bool
+	IconsOnly              bool
+	StopIfTrue             bool
+	Priority               int
+}
+
+// ConditionalFormatIconThreshold directly maps the threshold of the icon in
+// the icon set conditional formatting rule.
+type ConditionalFormatIconThreshold struct {
+	Type        string
+	Value       string
+	GreaterThan bool
 }
 
 // SheetProtectionOptions directly maps the settings of worksheet protection.
@@ -1106,3 +1154,35 @@ type SheetPropsOptions struct {
 	// ThickBottom specifies if rows have a thick bottom border by default.
 	ThickBottom *bool
 }
+
+// InheritStyleType is the type of the formatting inheritance for the inserted
+// rows or columns.
+type InheritStyleType byte
+
+// Formatting inheritance types enumeration. For the inserted columns, the
+// InheritFromAbove and InheritFromBelow are the same as the InheritFromLeft
+// and InheritFromRight.
+const (
+	InheritNone InheritStyleType = iota
+	InheritFromAbove
+	InheritFromBelow
+	InheritFromLeft  = InheritFromAbove
+	InheritFromRight = InheritFromBelow
+)
+
+// InsertRowsOptions directly maps the settings of inserting rows.
+type InsertRowsOptions struct {
+	// InheritStyle specifies if the inserted rows inherit the row style,
+	// height, outline level and the cell styles from the row above or below
+	// the inserted rows. The inserted rows are unformatted by default.
+	InheritStyle InheritStyleType
+}
+
+// InsertColsOptions directly maps the settings of inserting columns.
+type InsertColsOptions struct {
+	// InheritStyle specifies if the inserted columns inherit the column style,
+	// width, outline level and the cell styles from the column left or right
+	// of the inserted columns. The inserted columns are unformatted by
+	// default.
+	InheritStyle InheritStyleType
+}